@@ -29,11 +29,15 @@ and create a copy that belongs to the service account.
 
 If the --client option is provided, the command will search for the specified credential on your local
 client store and upload a copy of the credential that will be owned by the service account.
+
+If the --dry-run option is provided along with --client, the credential is checked against the models
+using it and the impact on each model is reported, but nothing is updated.
 `
 
 	updateCredentialCommandExamples = `
     juju update-service-account-credential <client-id> aws <credential-name>
 	juju update-service-account-credential --client <client-id> aws <credential-name> 
+	juju update-service-account-credential --client --dry-run <client-id> aws <credential-name>
 
 `
 )
@@ -59,6 +63,7 @@ type updateCredentialCommand struct {
 	cloud          string
 	credentialName string
 	client         bool
+	dryRun         bool
 }
 
 // Info implements Command.Info.
@@ -80,6 +85,7 @@ func (c *updateCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
 		"json": cmd.FormatJson,
 	})
 	f.BoolVar(&c.client, "client", false, "Provide this option to use a credential from your local store instead")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report the impact of the update on models without updating the credential")
 }
 
 // Init implements the cmd.Command interface.
@@ -99,6 +105,9 @@ func (c *updateCredentialCommand) Init(args []string) error {
 	if len(args) > 3 {
 		return errors.E("too many args")
 	}
+	if c.dryRun && !c.client {
+		return errors.E("--dry-run can only be used with --client")
+	}
 	return nil
 }
 
@@ -156,6 +165,7 @@ func (c *updateCredentialCommand) updateFromLocalStore(apiCaller jujuapi.Connect
 		UpdateCredentialArgs: jujuparams.UpdateCredentialArgs{
 			Credentials: []jujuparams.TaggedCredential{taggedCredential},
 		},
+		DryRun: c.dryRun,
 	}
 
 	client := api.NewClient(apiCaller)
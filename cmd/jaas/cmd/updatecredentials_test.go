@@ -88,6 +88,61 @@ func (s *updateCredentialsSuite) TestUpdateCredentialsWithLocalCredentials(c *gc
 	})
 }
 
+func (s *updateCredentialsSuite) TestUpdateCredentialsDryRun(c *gc.C) {
+	ctx := context.Background()
+
+	clientID := "abda51b2-d735-4794-a8bd-49c506baa4af"
+	clientIDWithDomain := clientID + "@serviceaccount"
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	sa, err := dbmodel.NewIdentity(clientIDWithDomain)
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.GetIdentity(ctx, sa)
+	c.Assert(err, gc.IsNil)
+
+	// Make alice admin of the service account
+	tuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("alice@canonical.com")),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewServiceAccountTag(clientIDWithDomain)),
+	}
+	err = s.JIMM.OpenFGAClient.AddRelation(ctx, tuple)
+	c.Assert(err, gc.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "kubernetes",
+	}
+	err = s.JIMM.Database.AddCloud(ctx, &cloud)
+	c.Assert(err, gc.IsNil)
+
+	clientStore := s.ClientStore()
+	err = clientStore.UpdateCredential("test-cloud", jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"test-credentials": jujucloud.NewCredential(jujucloud.EmptyAuthType, map[string]string{
+				"foo": "bar",
+			}),
+		},
+	})
+	c.Assert(err, gc.IsNil)
+
+	cmdContext, err := cmdtesting.RunCommand(c, cmd.NewUpdateCredentialsCommandForTesting(clientStore, bClient), clientID, "test-cloud", "test-credentials", "--client", "--dry-run")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdContext), gc.Equals, `results:
+- credentialtag: cloudcred-test-cloud_abda51b2-d735-4794-a8bd-49c506baa4af@serviceaccount_test-credentials
+  error: null
+  models: []
+`)
+
+	// The credential has not been stored.
+	cred := dbmodel.CloudCredential{}
+	cred.SetTag(names.NewCloudCredentialTag("test-cloud/" + clientIDWithDomain + "/test-credentials"))
+	err = s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.ErrorMatches, ".*not found")
+}
+
 func (s *updateCredentialsSuite) TestCloudNotInLocalStore(c *gc.C) {
 	bClient := s.SetupCLIAccess(c, "alice")
 	_, err := cmdtesting.RunCommand(c, cmd.NewUpdateCredentialsCommandForTesting(s.ClientStore(), bClient),
@@ -128,7 +183,7 @@ func (s *updateCredentialsSuite) TestUpdateServiceAccountCredentialFromControlle
 	user, err := dbmodel.NewIdentity("alice@canonical.com")
 	c.Assert(err, gc.IsNil)
 	u := openfga.NewUser(user, s.OFGAClient)
-	err = s.JIMM.AddServiceAccount(ctx, u, clientIDWithDomain)
+	err = s.JIMM.AddServiceAccount(ctx, u, clientIDWithDomain, "")
 	c.Assert(err, gc.IsNil)
 
 	// Create cloud and cloud-credential for Alice.
@@ -189,6 +244,10 @@ func (s *updateCredentialsSuite) TestMissingArgs(c *gc.C) {
 		name:          "too many args",
 		args:          []string{"some-client-id", "some-cloud", "some-credential-name", "extra-arg"},
 		expectedError: "too many args",
+	}, {
+		name:          "dry run without client",
+		args:          []string{"some-client-id", "some-cloud", "some-credential-name", "--dry-run"},
+		expectedError: "--dry-run can only be used with --client",
 	}}
 
 	bClient := s.SetupCLIAccess(c, "alice")
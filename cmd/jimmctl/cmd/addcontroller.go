@@ -23,6 +23,13 @@ var (
 	addControllerCommandDoc = `
 	add-controller command adds a controller to jimm.
 
+	All the clouds and regions available on the controller are imported
+	into JIMM when it is added. The controller is given the highest
+	priority in the region hosting its controller model and JIMM's
+	configured default priority in every other region. The discovered
+	cloud regions are included in the output, and those that were not
+	previously known to JIMM are also reported on stderr.
+
 	Example:
 		jimmctl add-controller <filename> 
 		jimmctl add-controller <filename> --format json
@@ -105,6 +112,11 @@ func (c *addControllerCommand) Run(ctxt *cmd.Context) error {
 	if err != nil {
 		return errors.E(err)
 	}
+	for _, cr := range info.DiscoveredCloudRegions {
+		if cr.New {
+			ctxt.Infof("added cloud region %s/%s (priority %d)", cr.Cloud, cr.Region, cr.Priority)
+		}
+	}
 	return nil
 }
 
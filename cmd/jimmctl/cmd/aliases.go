@@ -0,0 +1,278 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	aliasesDoc = `
+aliases command enables management of model aliases. An alias is a
+name you give to a model that may be used in place of the model's UUID
+in JIMM requests, such as model-status. Aliases are private to the user
+that sets them. Models may also be referred to by a path of the form
+<owner>/<name>.
+`
+
+	setModelAliasDoc = `
+set command gives a model an alias. If the alias already refers to a
+model it is changed to refer to the given model.
+
+Example:
+	jimmctl aliases set <alias> <model>
+
+Examples:
+	jimmctl aliases set prod alice@canonical.com/production
+	jimmctl aliases set dev 00000002-0000-0000-0000-000000000001
+`
+
+	listModelAliasesDoc = `
+list command lists your model aliases.
+
+Example:
+	jimmctl aliases list
+`
+
+	removeModelAliasDoc = `
+remove command removes a model alias. The model is not changed.
+
+Example:
+	jimmctl aliases remove <alias>
+`
+)
+
+// NewAliasesCommand returns a command for managing model aliases.
+func NewAliasesCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "aliases",
+		Doc:     aliasesDoc,
+		Purpose: "Model alias management.",
+	})
+	cmd.Register(newSetModelAliasCommand())
+	cmd.Register(newListModelAliasesCommand())
+	cmd.Register(newRemoveModelAliasCommand())
+
+	return cmd
+}
+
+// newSetModelAliasCommand returns a command to set a model alias.
+func newSetModelAliasCommand() cmd.Command {
+	cmd := &setModelAliasCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelAliasCommand sets a model alias.
+type setModelAliasCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetModelAliasRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setModelAliasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set",
+		Purpose: "Set a model alias.",
+		Doc:     setModelAliasDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelAliasCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelAliasCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("alias and model must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.params.Alias, c.params.Model = args[0], modelReference(args[1])
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelAliasCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	alias, err := client.SetModelAlias(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, alias)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListModelAliasesCommand returns a command to list model aliases.
+func newListModelAliasesCommand() cmd.Command {
+	cmd := &listModelAliasesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listModelAliasesCommand lists model aliases.
+type listModelAliasesCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listModelAliasesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List model aliases.",
+		Doc:     listModelAliasesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelAliasesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatAliasesTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listModelAliasesCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listModelAliasesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	aliases, err := client.ListModelAliases()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, aliases)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveModelAliasCommand returns a command to remove a model alias.
+func newRemoveModelAliasCommand() cmd.Command {
+	cmd := &removeModelAliasCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeModelAliasCommand removes a model alias.
+type removeModelAliasCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.RemoveModelAliasRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeModelAliasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a model alias.",
+		Doc:     removeModelAliasDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeModelAliasCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("alias must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.Alias = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeModelAliasCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveModelAlias(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatAliasesTabular adds a row for each model alias to the table.
+func formatAliasesTabular(t *table, value interface{}) error {
+	aliases, ok := value.([]apiparams.ModelAlias)
+	if !ok {
+		return unexpectedType(aliases, value)
+	}
+	t.AddHeader("Alias", "Model", "Model Tag")
+	for _, a := range aliases {
+		t.AddRow(a.Alias, a.ModelPath, a.ModelTag)
+	}
+	return nil
+}
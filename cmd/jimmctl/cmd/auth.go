@@ -18,6 +18,9 @@ func NewAuthCommand() *jujucmd.SuperCommand {
 	})
 	cmd.Register(NewGroupCommand())
 	cmd.Register(NewRelationCommand())
+	cmd.Register(NewDefaultsCommand())
+	cmd.Register(NewExportRelationsCommand())
+	cmd.Register(NewImportRelationsCommand())
 
 	return cmd
 }
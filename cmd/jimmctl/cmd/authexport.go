@@ -0,0 +1,207 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	exportRelationsDoc = `
+export command writes every relation tuple in JIMM's authorisation model
+to a JSON file, in the form stored in OpenFGA. The file can be imported
+into another JIMM with the import command.
+
+Example:
+	jimmctl auth export <filename>
+`
+
+	importRelationsDoc = `
+import command adds the relation tuples in a file written by the export
+command to JIMM's authorisation model. Tuples that already exist are
+left unchanged.
+
+Example:
+	jimmctl auth import <filename>
+`
+)
+
+// importBatchSize is the number of tuples sent in each ImportRelations
+// call.
+const importBatchSize = 100
+
+// NewExportRelationsCommand returns a command to export relation tuples.
+func NewExportRelationsCommand() cmd.Command {
+	cmd := &exportRelationsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// exportRelationsCommand exports relation tuples.
+type exportRelationsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	filename string
+}
+
+// Info implements the cmd.Command interface.
+func (c *exportRelationsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export",
+		Args:    "<filename>",
+		Purpose: "Export the authorisation model's relation tuples.",
+		Doc:     exportRelationsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportRelationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *exportRelationsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("filename not specified")
+	}
+	c.filename, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *exportRelationsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	req := apiparams.ExportRelationsRequest{PageSize: defaultPageSize}
+	tuples := []apiparams.RelationshipTuple{}
+	for {
+		resp, err := client.ExportRelations(&req)
+		if err != nil {
+			return errors.E(err)
+		}
+		tuples = append(tuples, resp.Tuples...)
+		if resp.ContinuationToken == "" {
+			break
+		}
+		req.ContinuationToken = resp.ContinuationToken
+	}
+
+	data, err := json.MarshalIndent(tuples, "", "  ")
+	if err != nil {
+		return errors.E(err)
+	}
+	if err := os.WriteFile(ctxt.AbsPath(c.filename), data, 0600); err != nil {
+		return errors.E(err)
+	}
+	fmt.Fprintf(ctxt.Stdout, "exported %d tuples to %s\n", len(tuples), c.filename)
+	return nil
+}
+
+// NewImportRelationsCommand returns a command to import relation tuples.
+func NewImportRelationsCommand() cmd.Command {
+	cmd := &importRelationsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// importRelationsCommand imports relation tuples.
+type importRelationsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	filename string
+}
+
+// Info implements the cmd.Command interface.
+func (c *importRelationsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import",
+		Args:    "<filename>",
+		Purpose: "Import exported relation tuples into the authorisation model.",
+		Doc:     importRelationsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importRelationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *importRelationsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("filename not specified")
+	}
+	c.filename, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *importRelationsCommand) Run(ctxt *cmd.Context) error {
+	tuples, err := readTupleFile(ctxt.AbsPath(c.filename))
+	if err != nil {
+		return errors.E(err)
+	}
+
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	var added, existing int
+	for len(tuples) > 0 {
+		n := min(len(tuples), importBatchSize)
+		resp, err := client.ImportRelations(&apiparams.ImportRelationsRequest{Tuples: tuples[:n]})
+		if err != nil {
+			return errors.E(err)
+		}
+		added += resp.Added
+		existing += resp.Existing
+		tuples = tuples[n:]
+	}
+	fmt.Fprintf(ctxt.Stdout, "imported %d tuples, %d already existed\n", added, existing)
+	return nil
+}
@@ -0,0 +1,342 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var backupCommandDoc = `
+backup command writes a snapshot of JIMM's database and the OpenFGA
+relation tuples to a gzipped tar archive, which can be restored into a
+fresh deployment with the restore command.
+
+The database is read in a single transaction, but the tuples are read
+afterwards, so JIMM should be put in maintenance mode while the backup
+is taken.
+
+The backup does not include sessions, macaroon root keys, the audit log
+or the OAuth tokens of users. Secrets are not included either, whether
+they are held in Vault or in JIMM's database: cloud and controller
+credentials must be backed up separately, and the restored deployment
+keeps its own JWKS and session signing keys.
+
+Example:
+	jimmctl backup jimm-backup.tar.gz
+`
+
+var restoreCommandDoc = `
+restore command restores a backup archive written by the backup command
+into a fresh JIMM deployment. The deployment must not have any
+controllers and its database must have the same schema version as the
+one the backup was taken from. All data in the deployment's database is
+replaced, other than the audit log, and the tuples in the backup are
+added to OpenFGA. Existing sessions are removed, so users must log in
+again after the restore.
+
+Example:
+	jimmctl restore jimm-backup.tar.gz
+`
+
+// Names of the files in a backup archive.
+const (
+	backupMetadataFile = "metadata.json"
+	backupTuplesFile   = "tuples.json"
+	backupTablesDir    = "tables"
+)
+
+// backupMetadata holds the metadata stored in a backup archive. The
+// tables are listed in the order in which they must be restored.
+type backupMetadata struct {
+	Time                 time.Time `json:"time"`
+	DatabaseMajorVersion int       `json:"database-major-version"`
+	DatabaseMinorVersion int       `json:"database-minor-version"`
+	Tables               []string  `json:"tables"`
+}
+
+// NewBackupCommand returns a command to back up JIMM.
+func NewBackupCommand() cmd.Command {
+	cmd := &backupCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// backupCommand writes a backup of JIMM to an archive.
+type backupCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	filename string
+}
+
+// Info implements the cmd.Command interface.
+func (c *backupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "backup",
+		Args:    "<filename>",
+		Purpose: "Back up JIMM's database and relation tuples.",
+		Doc:     backupCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *backupCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *backupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("filename not specified")
+	}
+	c.filename, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *backupCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	b, err := client.Backup()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	f, err := os.Create(ctxt.AbsPath(c.filename))
+	if err != nil {
+		return errors.E(err)
+	}
+	if err := writeBackupArchive(f, b); err != nil {
+		f.Close()
+		return errors.E(err, "cannot write backup")
+	}
+	if err := f.Close(); err != nil {
+		return errors.E(err)
+	}
+	fmt.Fprintf(ctxt.Stdout, "backed up %d tables and %d tuples to %s\n", len(b.Tables), len(b.Tuples), c.filename)
+	return nil
+}
+
+// NewRestoreCommand returns a command to restore a backup of JIMM.
+func NewRestoreCommand() cmd.Command {
+	cmd := &restoreCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// restoreCommand restores a backup of JIMM from an archive.
+type restoreCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	filename string
+}
+
+// Info implements the cmd.Command interface.
+func (c *restoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore",
+		Args:    "<filename>",
+		Purpose: "Restore a backup into a fresh JIMM deployment.",
+		Doc:     restoreCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *restoreCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("filename not specified")
+	}
+	c.filename, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCommand) Run(ctxt *cmd.Context) error {
+	f, err := os.Open(ctxt.AbsPath(c.filename))
+	if err != nil {
+		return errors.E(err)
+	}
+	defer f.Close()
+	b, err := readBackupArchive(f)
+	if err != nil {
+		return errors.E(err, "cannot read backup")
+	}
+
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.Restore(&apiparams.RestoreRequest{Backup: *b}); err != nil {
+		return errors.E(err)
+	}
+	fmt.Fprintf(ctxt.Stdout, "restored %d tables and %d tuples from backup taken at %s\n", len(b.Tables), len(b.Tuples), b.Time.Format(time.RFC3339))
+	return nil
+}
+
+// writeBackupArchive writes the given backup to w as a gzipped tar
+// archive holding the metadata, the tuples and a file for each table.
+func writeBackupArchive(w io.Writer, b *apiparams.Backup) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	md := backupMetadata{
+		Time:                 b.Time,
+		DatabaseMajorVersion: b.DatabaseMajorVersion,
+		DatabaseMinorVersion: b.DatabaseMinorVersion,
+		Tables:               make([]string, len(b.Tables)),
+	}
+	for i, t := range b.Tables {
+		md.Tables[i] = t.Name
+	}
+	if err := writeArchiveJSON(tw, backupMetadataFile, b.Time, md); err != nil {
+		return err
+	}
+	if err := writeArchiveJSON(tw, backupTuplesFile, b.Time, b.Tuples); err != nil {
+		return err
+	}
+	for _, t := range b.Tables {
+		if err := writeArchiveJSON(tw, path.Join(backupTablesDir, t.Name+".json"), b.Time, t.Rows); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// writeArchiveJSON writes v, encoded as JSON, to the named file in the
+// archive.
+func writeArchiveJSON(tw *tar.Writer, name string, modTime time.Time, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	hdr := tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	}
+	if err := tw.WriteHeader(&hdr); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// readBackupArchive reads a backup written by writeBackupArchive from r.
+func readBackupArchive(r io.Reader) (*apiparams.Backup, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = data
+	}
+
+	var md backupMetadata
+	if err := readArchiveJSON(files, backupMetadataFile, &md); err != nil {
+		return nil, err
+	}
+	b := apiparams.Backup{
+		Time:                 md.Time,
+		DatabaseMajorVersion: md.DatabaseMajorVersion,
+		DatabaseMinorVersion: md.DatabaseMinorVersion,
+		Tables:               make([]apiparams.BackupTable, len(md.Tables)),
+	}
+	if err := readArchiveJSON(files, backupTuplesFile, &b.Tuples); err != nil {
+		return nil, err
+	}
+	for i, name := range md.Tables {
+		b.Tables[i].Name = name
+		if err := readArchiveJSON(files, path.Join(backupTablesDir, name+".json"), &b.Tables[i].Rows); err != nil {
+			return nil, err
+		}
+	}
+	return &b, nil
+}
+
+// readArchiveJSON decodes the JSON contents of the named archive file
+// into v.
+func readArchiveJSON(files map[string][]byte, name string, v any) error {
+	data, ok := files[name]
+	if !ok {
+		return errors.E(fmt.Sprintf("archive does not contain %s", name))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.E(err, fmt.Sprintf("cannot decode %s", name))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type backupArchiveSuite struct{}
+
+var _ = gc.Suite(&backupArchiveSuite{})
+
+func (s *backupArchiveSuite) TestBackupArchiveRoundTrip(c *gc.C) {
+	b := apiparams.Backup{
+		Time:                 time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		DatabaseMajorVersion: 1,
+		DatabaseMinorVersion: 21,
+		Tables: []apiparams.BackupTable{{
+			Name: "users",
+			Rows: []json.RawMessage{
+				json.RawMessage(`{"id":1,"name":"alice@canonical.com"}`),
+				json.RawMessage(`{"id":2,"name":"bob@canonical.com"}`),
+			},
+		}, {
+			Name: "groups",
+			Rows: []json.RawMessage{},
+		}},
+		Tuples: []apiparams.RelationshipTuple{{
+			Object:       "user:alice@canonical.com",
+			Relation:     "administrator",
+			TargetObject: "controller:00000001-0000-0000-0000-000000000001",
+		}},
+	}
+
+	var buf bytes.Buffer
+	err := cmd.WriteBackupArchive(&buf, &b)
+	c.Assert(err, gc.IsNil)
+
+	got, err := cmd.ReadBackupArchive(&buf)
+	c.Assert(err, gc.IsNil)
+	c.Check(got.Time.Equal(b.Time), gc.Equals, true)
+	got.Time = b.Time
+	c.Check(*got, gc.DeepEquals, b)
+}
+
+func (s *backupArchiveSuite) TestReadBackupArchiveInvalid(c *gc.C) {
+	_, err := cmd.ReadBackupArchive(bytes.NewReader([]byte("not an archive")))
+	c.Check(err, gc.ErrorMatches, `.*gzip: invalid header`)
+}
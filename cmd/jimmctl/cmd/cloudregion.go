@@ -0,0 +1,152 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	cloudRegionDoc = `
+cloud-region command enables cloud regions to be closed for, and
+reopened to, the placement of new models.
+`
+
+	disableCloudRegionDoc = `
+disable command closes a cloud region for the placement of new models,
+for example during a provider incident. Existing models in the region
+are unaffected.
+
+Example:
+	jimmctl cloud-region disable <cloud>/<region>
+`
+
+	enableCloudRegionDoc = `
+enable command reopens a disabled cloud region for the placement of new
+models.
+
+Example:
+	jimmctl cloud-region enable <cloud>/<region>
+`
+)
+
+// NewCloudRegionCommand returns a command for managing cloud regions.
+func NewCloudRegionCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "cloud-region",
+		Doc:     cloudRegionDoc,
+		Purpose: "Cloud region management.",
+	})
+	cmd.Register(newDisableCloudRegionCommand())
+	cmd.Register(newEnableCloudRegionCommand())
+
+	return cmd
+}
+
+// newDisableCloudRegionCommand returns a command to disable a cloud
+// region.
+func newDisableCloudRegionCommand() cmd.Command {
+	cmd := &setCloudRegionDisabledCommand{
+		store:    jujuclient.NewFileClientStore(),
+		disabled: true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// newEnableCloudRegionCommand returns a command to enable a cloud
+// region.
+func newEnableCloudRegionCommand() cmd.Command {
+	cmd := &setCloudRegionDisabledCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setCloudRegionDisabledCommand enables or disables a cloud region.
+type setCloudRegionDisabledCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	disabled bool
+
+	params apiparams.SetCloudRegionDisabledRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setCloudRegionDisabledCommand) Info() *cmd.Info {
+	if c.disabled {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "disable",
+			Args:    "<cloud>/<region>",
+			Purpose: "Close a cloud region for new models.",
+			Doc:     disableCloudRegionDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "enable",
+		Args:    "<cloud>/<region>",
+		Purpose: "Reopen a cloud region for new models.",
+		Doc:     enableCloudRegionDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setCloudRegionDisabledCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *setCloudRegionDisabledCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("cloud region not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	cloud, region, ok := strings.Cut(args[0], "/")
+	if !ok || region == "" {
+		return errors.E("cloud region must be specified as <cloud>/<region>")
+	}
+	if !names.IsValidCloud(cloud) {
+		return errors.E("invalid cloud name")
+	}
+	c.params.CloudTag = names.NewCloudTag(cloud).String()
+	c.params.Region = region
+	c.params.Disabled = c.disabled
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setCloudRegionDisabledCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetCloudRegionDisabled(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type cloudRegionSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&cloudRegionSuite{})
+
+func (s *cloudRegionSuite) regionDisabled(c *gc.C) bool {
+	cloud := dbmodel.Cloud{Name: jimmtest.TestCloudName}
+	err := s.JIMM.Database.GetCloud(context.Background(), &cloud)
+	c.Assert(err, gc.IsNil)
+	r := cloud.Region(jimmtest.TestCloudRegionName)
+	return r.Disabled
+}
+
+func (s *cloudRegionSuite) TestDisableEnableCloudRegion(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableCloudRegionCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName+"/"+jimmtest.TestCloudRegionName)
+	c.Assert(err, gc.IsNil)
+	c.Check(s.regionDisabled(c), gc.Equals, true)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewEnableCloudRegionCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName+"/"+jimmtest.TestCloudRegionName)
+	c.Assert(err, gc.IsNil)
+	c.Check(s.regionDisabled(c), gc.Equals, false)
+}
+
+func (s *cloudRegionSuite) TestDisableCloudRegionNotFound(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableCloudRegionCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName+"/no-such-region")
+	c.Assert(err, gc.ErrorMatches, `cloud region .*/no-such-region not found`)
+}
+
+func (s *cloudRegionSuite) TestDisableCloudRegionUnauthorized(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableCloudRegionCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName+"/"+jimmtest.TestCloudRegionName)
+	c.Assert(err, gc.ErrorMatches, `unauthorized.*`)
+}
+
+func (s *cloudRegionSuite) TestDisableCloudRegionInvalidArgs(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableCloudRegionCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName)
+	c.Assert(err, gc.ErrorMatches, `cloud region must be specified as <cloud>/<region>`)
+}
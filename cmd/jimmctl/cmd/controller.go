@@ -0,0 +1,131 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	controllerDoc = `
+controller command enables management of the controllers hosting models
+for JIMM.
+`
+
+	controllerDrainDoc = `
+drain command marks a controller as draining, no new models are placed on
+a draining controller. If --migrate is specified the models hosted on the
+controller are migrated to other controllers hosting the same cloud
+region. Use --cancel to stop draining a controller.
+
+Example:
+	jimmctl controller drain controller-1
+	jimmctl controller drain controller-1 --migrate
+	jimmctl controller drain controller-1 --cancel
+`
+)
+
+// NewControllerCommand returns a command for managing controllers.
+func NewControllerCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "controller",
+		Doc:     controllerDoc,
+		Purpose: "Controller management.",
+	})
+	cmd.Register(newControllerDrainCommand())
+
+	return cmd
+}
+
+// newControllerDrainCommand returns a command to drain a controller.
+func newControllerDrainCommand() cmd.Command {
+	cmd := &controllerDrainCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// controllerDrainCommand sets the draining status of a controller.
+type controllerDrainCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	params apiparams.DrainControllerRequest
+	cancel bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *controllerDrainCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "drain",
+		Args:    "<name>",
+		Purpose: "Drain a controller.",
+		Doc:     controllerDrainDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerDrainCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.params.Migrate, "migrate", false, "migrate the controller's models to other controllers")
+	f.BoolVar(&c.cancel, "cancel", false, "stop draining the controller")
+}
+
+// Init implements the cmd.Command interface.
+func (c *controllerDrainCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing controller name")
+	}
+	c.params.Name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	if c.cancel && c.params.Migrate {
+		return errors.E("cannot specify both --cancel and --migrate")
+	}
+	c.params.Draining = !c.cancel
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *controllerDrainCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.DrainController(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+)
+
+type controllerDrainSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&controllerDrainSuite{})
+
+func (s *controllerDrainSuite) TestDrainSuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewControllerDrainCommandForTesting(s.ClientStore(), bClient), "controller-1")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `(?s)controller:\n  name: controller-1\n.*  status:\n    status: draining\n.*`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewControllerDrainCommandForTesting(s.ClientStore(), bClient), "controller-1", "--cancel")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `(?s)controller:\n  name: controller-1\n.*  status:\n    status: available\n.*`)
+}
+
+func (s *controllerDrainSuite) TestDrainNotSuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewControllerDrainCommandForTesting(s.ClientStore(), bClient), "controller-1")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *controllerDrainSuite) TestDrainCancelAndMigrate(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewControllerDrainCommandForTesting(s.ClientStore(), bClient), "controller-1", "--cancel", "--migrate")
+	c.Assert(err, gc.ErrorMatches, `cannot specify both --cancel and --migrate`)
+}
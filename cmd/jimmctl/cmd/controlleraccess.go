@@ -0,0 +1,183 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	controllerAccessDoc = `
+controller-access command manages the JIMM controller access of users.
+`
+
+	grantControllerAccessDoc = `
+grant command grants JIMM controller access to one or more users. The
+access level may be "login", "add-model" or "superuser". Granting
+"superuser" makes the users JIMM administrators. Every user may log in to
+JIMM, and add-model access is granted on clouds, so granting "login" or
+"add-model" only ensures that the users are known to JIMM.
+
+The result for each user is reported, the command fails if access could
+not be granted to any of the users.
+
+Example:
+	jimmctl controller-access grant superuser alice@canonical.com bob@canonical.com
+`
+
+	revokeControllerAccessDoc = `
+revoke command revokes JIMM controller access from one or more users. As
+in juju, revoking an access level also revokes any level above it, so
+revoking any level removes the users' JIMM administrator access. Users
+whose "login" access is revoked may still log in to JIMM.
+
+The result for each user is reported, the command fails if access could
+not be revoked from any of the users.
+
+Example:
+	jimmctl controller-access revoke superuser alice@canonical.com bob@canonical.com
+`
+)
+
+// NewControllerAccessCommand returns a command for managing the JIMM
+// controller access of users.
+func NewControllerAccessCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "controller-access",
+		Doc:     controllerAccessDoc,
+		Purpose: "Controller access management.",
+	})
+	cmd.Register(newGrantControllerAccessCommand())
+	cmd.Register(newRevokeControllerAccessCommand())
+
+	return cmd
+}
+
+// newGrantControllerAccessCommand returns a command to grant controller
+// access.
+func newGrantControllerAccessCommand() cmd.Command {
+	cmd := &modifyControllerAccessCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// newRevokeControllerAccessCommand returns a command to revoke controller
+// access.
+func newRevokeControllerAccessCommand() cmd.Command {
+	cmd := &modifyControllerAccessCommand{
+		store:  jujuclient.NewFileClientStore(),
+		revoke: true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// modifyControllerAccessCommand grants or revokes controller access.
+type modifyControllerAccessCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	revoke   bool
+
+	params apiparams.ControllerAccessRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *modifyControllerAccessCommand) Info() *cmd.Info {
+	if c.revoke {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "revoke",
+			Args:    "<access> <user> [<user>...]",
+			Purpose: "Revoke controller access from users.",
+			Doc:     revokeControllerAccessDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "grant",
+		Args:    "<access> <user> [<user>...]",
+		Purpose: "Grant controller access to users.",
+		Doc:     grantControllerAccessDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modifyControllerAccessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *modifyControllerAccessCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("access level not specified")
+	}
+	if len(args) < 2 {
+		return errors.E("no users specified")
+	}
+	c.params.Access = args[0]
+	for _, user := range args[1:] {
+		if !names.IsValidUser(user) {
+			return errors.E(fmt.Sprintf("invalid user name %q", user))
+		}
+		c.params.UserTags = append(c.params.UserTags, names.NewUserTag(user).String())
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *modifyControllerAccessCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	var resp apiparams.ControllerAccessResults
+	if c.revoke {
+		resp, err = client.RevokeControllerAccess(&c.params)
+	} else {
+		resp, err = client.GrantControllerAccess(&c.params)
+	}
+	if err != nil {
+		return errors.E(err)
+	}
+
+	if err := c.out.Write(ctxt, resp); err != nil {
+		return errors.E(err)
+	}
+	var failed int
+	for _, r := range resp.Results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.E(fmt.Sprintf("failed to modify access for %d of %d users", failed, len(resp.Results)))
+	}
+	return nil
+}
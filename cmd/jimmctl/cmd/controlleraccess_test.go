@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+type controllerAccessSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&controllerAccessSuite{})
+
+func (s *controllerAccessSuite) isAdministrator(c *gc.C, name string) bool {
+	identity, err := dbmodel.NewIdentity(name)
+	c.Assert(err, gc.IsNil)
+	isAdmin, err := openfga.IsAdministrator(context.Background(), openfga.NewUser(identity, s.OFGAClient), s.JIMM.ResourceTag())
+	c.Assert(err, gc.IsNil)
+	return isAdmin
+}
+
+func (s *controllerAccessSuite) TestGrantRevokeControllerAccess(c *gc.C) {
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewGrantControllerAccessCommandForTesting(s.ClientStore(), bClient), "superuser", "charlie@canonical.com", "dave@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `results:
+- user-tag: user-charlie@canonical.com
+- user-tag: user-dave@canonical.com
+`)
+	c.Check(s.isAdministrator(c, "charlie@canonical.com"), gc.Equals, true)
+	c.Check(s.isAdministrator(c, "dave@canonical.com"), gc.Equals, true)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewRevokeControllerAccessCommandForTesting(s.ClientStore(), bClient), "superuser", "charlie@canonical.com", "eve@canonical.com")
+	c.Check(err, gc.ErrorMatches, `failed to modify access for 1 of 2 users`)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `results:
+- user-tag: user-charlie@canonical.com
+- user-tag: user-eve@canonical.com
+  error: .*not found.*
+`)
+	c.Check(s.isAdministrator(c, "charlie@canonical.com"), gc.Equals, false)
+	c.Check(s.isAdministrator(c, "dave@canonical.com"), gc.Equals, true)
+}
+
+func (s *controllerAccessSuite) TestGrantControllerAccessInvalidLevel(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewGrantControllerAccessCommandForTesting(s.ClientStore(), bClient), "admin", "charlie@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access level "admin"`)
+}
+
+func (s *controllerAccessSuite) TestGrantControllerAccessUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewGrantControllerAccessCommandForTesting(s.ClientStore(), bClient), "superuser", "bob@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized.*`)
+	c.Check(s.isAdministrator(c, "bob@canonical.com"), gc.Equals, false)
+}
+
+func (s *controllerAccessSuite) TestControllerAccessMissingUsers(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewGrantControllerAccessCommandForTesting(s.ClientStore(), bClient), "superuser")
+	c.Assert(err, gc.ErrorMatches, `no users specified`)
+}
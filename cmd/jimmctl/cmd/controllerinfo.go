@@ -56,6 +56,8 @@ type controllerInfoCommand struct {
 	file           cmd.FileVar
 	local          bool
 	tlsHostname    string
+	srvName        string
+	dashboardURL   string
 }
 
 func (c *controllerInfoCommand) Info() *cmd.Info {
@@ -71,6 +73,8 @@ func (c *controllerInfoCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.local, "local", false, "If local flag is specified, then the local API address and CA cert of the controller will be used.")
 	f.StringVar(&c.tlsHostname, "tls-hostname", "", "Specify the hostname for TLS verfiication.")
+	f.StringVar(&c.srvName, "srv-name", "", "Specify a DNS SRV name that JIMM resolves to find the controller's API addresses.")
+	f.StringVar(&c.dashboardURL, "dashboard-url", "", "Specify the URL of the controller's Juju Dashboard.")
 }
 
 // Init implements the cmd.Command interface.
@@ -115,6 +119,8 @@ func (c *controllerInfoCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	info.TLSHostname = c.tlsHostname
+	info.SRVName = c.srvName
+	info.DashboardURL = c.dashboardURL
 	info.PublicAddress = c.publicAddress
 	if c.local {
 		info.CACertificate = controller.CACert
@@ -0,0 +1,108 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var deprecatedFacadeUsageCommandDoc = `
+deprecated-facade-usage command displays the clients that have called
+deprecated facade versions since JIMM started, to help decide when
+those versions can safely be removed. Clients are identified by the
+identity that made the calls and their network address.
+
+Example:
+	jimmctl deprecated-facade-usage
+	jimmctl deprecated-facade-usage --format json
+`
+
+// NewDeprecatedFacadeUsageCommand returns a command to list the usage
+// of deprecated facade versions.
+func NewDeprecatedFacadeUsageCommand() cmd.Command {
+	cmd := &deprecatedFacadeUsageCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// deprecatedFacadeUsageCommand lists the usage of deprecated facade
+// versions.
+type deprecatedFacadeUsageCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *deprecatedFacadeUsageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "deprecated-facade-usage",
+		Purpose: "Lists clients using deprecated facade versions.",
+		Doc:     deprecatedFacadeUsageCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *deprecatedFacadeUsageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatDeprecatedFacadeUsageTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *deprecatedFacadeUsageCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *deprecatedFacadeUsageCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	usage, err := client.ListDeprecatedFacadeUsage()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, usage)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatDeprecatedFacadeUsageTabular adds a row for each recorded use of
+// a deprecated facade to the table.
+func formatDeprecatedFacadeUsageTabular(t *table, value interface{}) error {
+	usage, ok := value.([]apiparams.DeprecatedFacadeUsage)
+	if !ok {
+		return unexpectedType(usage, value)
+	}
+	t.AddHeader("Facade", "Version", "Identity", "Remote Address", "Calls", "First Call", "Last Call")
+	for _, u := range usage {
+		t.AddRow(u.Facade, u.Version, u.Identity, u.RemoteAddress, u.Calls, formatTime(u.FirstCall), formatTime(u.LastCall))
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	defaultsDoc = `
+defaults command enables management of the access granted to every
+user of jimm via everyone@external.
+`
+
+	listDefaultsDoc = `
+list command lists the relations granted to every user.
+
+Example:
+	jimmctl auth defaults list
+`
+
+	grantDefaultDoc = `
+grant command grants a relation on a resource to every user. Relations
+giving administrative access cannot be granted.
+
+Example:
+	jimmctl auth defaults grant <relation> <target_object>
+
+Examples:
+	jimmctl auth defaults grant can_addmodel cloud-aws
+	jimmctl auth defaults grant reader applicationoffer-alice@canonical.com/mymodel.myoffer
+`
+
+	revokeDefaultDoc = `
+revoke command revokes a relation on a resource from every user.
+
+Example:
+	jimmctl auth defaults revoke <relation> <target_object>
+`
+)
+
+// NewDefaultsCommand returns a command for managing the access granted
+// to every user.
+func NewDefaultsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "defaults",
+		Doc:     defaultsDoc,
+		Purpose: "Default access management.",
+	})
+	cmd.Register(newListDefaultsCommand())
+	cmd.Register(newSetDefaultCommand(true))
+	cmd.Register(newSetDefaultCommand(false))
+
+	return cmd
+}
+
+// newListDefaultsCommand returns a command to list the relations granted
+// to every user.
+func newListDefaultsCommand() cmd.Command {
+	cmd := &listDefaultsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listDefaultsCommand lists the relations granted to every user.
+type listDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listDefaultsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List the relations granted to every user.",
+		Doc:     listDefaultsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatEveryoneDefaultsTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listDefaultsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listDefaultsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListEveryoneDefaults()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp.Defaults)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newSetDefaultCommand returns a command to grant, or revoke, a relation
+// for every user.
+func newSetDefaultCommand(granted bool) cmd.Command {
+	cmd := &setDefaultCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+	cmd.params.Granted = granted
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setDefaultCommand grants or revokes a relation for every user.
+type setDefaultCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetEveryoneDefaultRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setDefaultCommand) Info() *cmd.Info {
+	if c.params.Granted {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "grant",
+			Purpose: "Grant a relation to every user.",
+			Doc:     grantDefaultDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke",
+		Purpose: "Revoke a relation from every user.",
+		Doc:     revokeDefaultDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setDefaultCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setDefaultCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("relation and target object must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.params.Relation, c.params.TargetObject = args[0], args[1]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setDefaultCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetEveryoneDefault(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatEveryoneDefaultsTabular adds a row for each default relation to
+// the table.
+func formatEveryoneDefaultsTabular(t *table, value interface{}) error {
+	defaults, ok := value.([]apiparams.EveryoneDefault)
+	if !ok {
+		return unexpectedType(defaults, value)
+	}
+	t.AddHeader("Relation", "Target Object")
+	for _, d := range defaults {
+		t.AddRow(d.Relation, d.TargetObject)
+	}
+	return nil
+}
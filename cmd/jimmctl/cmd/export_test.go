@@ -3,6 +3,8 @@
 package cmd
 
 import (
+	"io"
+
 	"github.com/juju/cmd/v3"
 	jujuapi "github.com/juju/juju/api"
 	"github.com/juju/juju/cloud"
@@ -13,15 +15,33 @@ import (
 )
 
 var (
-	AccessMessage          = accessMessageFormat
-	AccessResultAllowed    = accessResultAllowed
-	AccessResultDenied     = accessResultDenied
-	DefaultPageSize        = defaultPageSize
-	FormatRelationsTabular = formatRelationsTabular
+	AccessMessage       = accessMessageFormat
+	AccessResultAllowed = accessResultAllowed
+	AccessResultDenied  = accessResultDenied
+	DefaultPageSize     = defaultPageSize
 )
 
+// FormatRelationsTabular writes the relations to w in the tabular format
+// used by the list relations command.
+func FormatRelationsTabular(w io.Writer, value interface{}) error {
+	return writeTable(w, formatRelationsTabular, value, false)
+}
+
+// FormatLimitsTabular writes the limits to w in the tabular format used by
+// the list limits command.
+func FormatLimitsTabular(w io.Writer, value interface{}, noHeaders bool) error {
+	return writeTable(w, formatLimitsTabular, value, noHeaders)
+}
+
 type AccessResult = accessResult
 
+var (
+	ParseLoadTestWorkload = parseLoadTestWorkload
+	Percentile            = percentile
+	WriteBackupArchive    = writeBackupArchive
+	ReadBackupArchive     = readBackupArchive
+)
+
 func NewListControllersCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
 	cmd := &listControllersCommand{
 		store:    store,
@@ -127,6 +147,15 @@ func NewSetControllerDeprecatedCommandForTesting(store jujuclient.ClientStore, l
 	return modelcmd.WrapBase(cmd)
 }
 
+func NewControllerDrainCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &controllerDrainCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
 func NewImportModelCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
 	cmd := &importModelCommand{
 		store:    store,
@@ -226,6 +255,24 @@ func NewCheckRelationCommandForTesting(store jujuclient.ClientStore, lp jujuapi.
 	return modelcmd.WrapBase(cmd)
 }
 
+func NewAddTemporaryRelationCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &addTemporaryRelationCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewListTemporaryRelationsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listTemporaryRelationsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
 func NewCrossModelQueryCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
 	cmd := &crossModelQueryCommand{
 		store:    store,
@@ -252,3 +299,177 @@ func NewMigrateModelCommandForTesting(store jujuclient.ClientStore, lp jujuapi.L
 
 	return modelcmd.WrapBase(cmd)
 }
+
+func NewMigrateControllerCredentialsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &migrateControllerCredentialsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewSetServiceAccountAllowedCIDRsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &setServiceAccountAllowedCIDRsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewRemoveUserCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &removeUserCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewRotateSessionKeyCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &rotateSessionKeyCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewTransferModelCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &transferModelCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewListModelsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listModelsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewSetModelLabelsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &setModelLabelsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewCreateServiceAccountCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &createServiceAccountCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewListServiceAccountsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listServiceAccountsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewSetServiceAccountDisabledCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider, disabled bool) cmd.Command {
+	cmd := &setServiceAccountDisabledCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+	cmd.params.Disabled = disabled
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewDeleteServiceAccountCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &deleteServiceAccountCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewShowModelArchiveCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &showModelArchiveCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewDisableCloudRegionCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &setCloudRegionDisabledCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+		disabled: true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewEnableCloudRegionCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &setCloudRegionDisabledCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewGrantControllerAccessCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &modifyControllerAccessCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewRevokeControllerAccessCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &modifyControllerAccessCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+		revoke:   true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewListSessionsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listSessionsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewRevokeSessionsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &revokeSessionsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewUsageReportCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &usageReportCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	cofga "github.com/canonical/ofga"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/names/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// DeveloperCommandsEnvVar is the environment variable that must be set
+// for jimmctl to register commands only intended for developers.
+const DeveloperCommandsEnvVar = "JIMMCTL_DEVELOPER_COMMANDS"
+
+var generateEstateCommandDoc = `
+generate-estate command populates a JIMM database and OpenFGA store with
+a generated estate of clouds, controllers, users, groups and models, so
+that the performance of listing and searching can be measured against a
+realistic and reproducible data set. Estates generated with the same
+seed and sizes are identical.
+
+The command connects directly to the database and OpenFGA store, which
+are configured with the same environment variables as the JIMM server:
+JIMM_DSN, JIMM_UUID, OPENFGA_SCHEME, OPENFGA_HOST, OPENFGA_PORT,
+OPENFGA_STORE, OPENFGA_AUTH_MODEL and OPENFGA_TOKEN. The database must
+not already contain any of the generated entities. The generated
+controllers do not exist, so the command must never be run against a
+production deployment.
+
+This command is only available if the ` + DeveloperCommandsEnvVar + `
+environment variable is set.
+
+Example:
+	jimmctl generate-estate --controllers 20 --users 5000 --models 50000
+`
+
+// NewGenerateEstateCommand returns a command to populate a database
+// with a generated estate.
+func NewGenerateEstateCommand() cmd.Command {
+	return &generateEstateCommand{}
+}
+
+// generateEstateCommand populates a database with a generated estate.
+type generateEstateCommand struct {
+	cmd.CommandBase
+
+	params jimmtest.EstateParams
+}
+
+// Info implements the cmd.Command interface.
+func (c *generateEstateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "generate-estate",
+		Purpose: "Populates a JIMM database with a generated estate for performance testing.",
+		Doc:     generateEstateCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *generateEstateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.Int64Var(&c.params.Seed, "seed", 1, "seed for the generated estate")
+	f.IntVar(&c.params.Clouds, "clouds", 3, "number of clouds")
+	f.IntVar(&c.params.RegionsPerCloud, "regions", 3, "number of regions in each cloud")
+	f.IntVar(&c.params.Controllers, "controllers", 10, "number of controllers")
+	f.IntVar(&c.params.Users, "users", 1000, "number of users")
+	f.IntVar(&c.params.Groups, "groups", 50, "number of groups")
+	f.IntVar(&c.params.Models, "models", 10000, "number of models")
+}
+
+// Init implements the cmd.Command interface.
+func (c *generateEstateCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.params.Controllers < 0 || c.params.Users < 0 || c.params.Groups < 0 || c.params.Models < 0 {
+		return errors.E("sizes must not be negative")
+	}
+	if c.params.Models > 0 && (c.params.Controllers == 0 || c.params.Users == 0) {
+		return errors.E("models require at least one controller and user")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *generateEstateCommand) Run(ctxt *cmd.Context) error {
+	jimmUUID := os.Getenv("JIMM_UUID")
+	if !names.IsValidController(jimmUUID) {
+		return errors.E("JIMM_UUID must be set to the UUID of the JIMM controller")
+	}
+
+	dsn := os.Getenv("JIMM_DSN")
+	var dialect gorm.Dialector
+	switch {
+	case strings.HasPrefix(dsn, "pgx:"):
+		dialect = postgres.Open(strings.TrimPrefix(dsn, "pgx:"))
+	case strings.HasPrefix(dsn, "postgres:") || strings.HasPrefix(dsn, "postgresql:"):
+		dialect = postgres.Open(dsn)
+	default:
+		return errors.E("JIMM_DSN must be set to a postgres DSN")
+	}
+	gdb, err := gorm.Open(dialect, &gorm.Config{})
+	if err != nil {
+		return errors.E(err, "cannot connect to database")
+	}
+	database := db.Database{DB: gdb}
+	defer database.Close()
+	if err := database.Migrate(ctxt, false); err != nil {
+		return errors.E(err)
+	}
+
+	cofgaClient, err := cofga.NewClient(ctxt, cofga.OpenFGAParams{
+		Scheme:      os.Getenv("OPENFGA_SCHEME"),
+		Host:        os.Getenv("OPENFGA_HOST"),
+		Port:        os.Getenv("OPENFGA_PORT"),
+		Token:       os.Getenv("OPENFGA_TOKEN"),
+		StoreID:     os.Getenv("OPENFGA_STORE"),
+		AuthModelID: os.Getenv("OPENFGA_AUTH_MODEL"),
+	})
+	if err != nil {
+		return errors.E(err, "cannot connect to OpenFGA")
+	}
+
+	start := time.Now()
+	estate := jimmtest.GenerateEstate(c.params)
+	err = estate.Populate(ctxt, &database, openfga.NewOpenFGAClient(cofgaClient), names.NewControllerTag(jimmUUID))
+	if err != nil {
+		return errors.E(err)
+	}
+	fmt.Fprintf(ctxt.Stdout, "generated %d clouds, %d controllers, %d users, %d groups and %d models in %s\n",
+		len(estate.Clouds), len(estate.Controllers), len(estate.Users), len(estate.Groups), len(estate.Models),
+		time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -319,7 +319,7 @@ func newListGroupsCommand() cmd.Command {
 // listGroupsCommand Lists all groups.
 type listGroupsCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out listOutput
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -348,10 +348,7 @@ func (c *listGroupsCommand) Init(args []string) error {
 // SetFlags implements Command.SetFlags.
 func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.out.AddFlags(f, "yaml", formatGroupsTabular)
 	f.IntVar(&c.limit, "limit", 0, "The maximum number of groups to return")
 	f.IntVar(&c.offset, "offset", 0, "The offset to use when requesting groups")
 }
@@ -382,3 +379,16 @@ func (c *listGroupsCommand) Run(ctxt *cmd.Context) error {
 
 	return nil
 }
+
+// formatGroupsTabular adds a row for each group to the table.
+func formatGroupsTabular(t *table, value interface{}) error {
+	groups, ok := value.([]apiparams.Group)
+	if !ok {
+		return unexpectedType(groups, value)
+	}
+	t.AddHeader("Name", "UUID", "Created", "Updated")
+	for _, g := range groups {
+		t.AddRow(g.Name, g.UUID, g.CreatedAt, g.UpdatedAt)
+	}
+	return nil
+}
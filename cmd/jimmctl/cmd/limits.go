@@ -0,0 +1,288 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	limitsDoc = `
+limits command enables management of the limits on the models, machines,
+cores and units that may be used by each user, group, controller or cloud.
+`
+
+	listLimitsDoc = `
+list command lists the limits and the current usage within each limit.
+
+Example:
+	jimmctl limits list
+`
+
+	setLimitDoc = `
+set command sets a limit on the models, machines, cores or units that
+may be used by a user, group, controller or cloud. A limit on a group
+applies to each member of the group without a limit of its own. A scope
+of "user", "controller" or "cloud" sets the default limit for every user,
+controller or cloud without a limit of its own.
+
+Example:
+	jimmctl limits set <entity> <scope> <value>
+
+Examples:
+	jimmctl limits set models user-alice@canonical.com 10
+	jimmctl limits set models user 5
+	jimmctl limits set models group-team-a 5
+	jimmctl limits set models controller-ctl-1 500
+	jimmctl limits set cores cloud-aws 1000
+`
+
+	removeLimitDoc = `
+remove command removes a limit.
+
+Example:
+	jimmctl limits remove <entity> <scope>
+`
+)
+
+// NewLimitsCommand returns a command for managing limits.
+func NewLimitsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "limits",
+		Doc:     limitsDoc,
+		Purpose: "Resource limit management.",
+	})
+	cmd.Register(newListLimitsCommand())
+	cmd.Register(newSetLimitCommand())
+	cmd.Register(newRemoveLimitCommand())
+
+	return cmd
+}
+
+// newListLimitsCommand returns a command to list limits.
+func newListLimitsCommand() cmd.Command {
+	cmd := &listLimitsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listLimitsCommand lists limits.
+type listLimitsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listLimitsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List limits.",
+		Doc:     listLimitsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listLimitsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatLimitsTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listLimitsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listLimitsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	limits, err := client.ListLimits()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, limits)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newSetLimitCommand returns a command to set a limit.
+func newSetLimitCommand() cmd.Command {
+	cmd := &setLimitCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setLimitCommand sets a limit.
+type setLimitCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetLimitRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setLimitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set",
+		Purpose: "Set a limit.",
+		Doc:     setLimitDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setLimitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setLimitCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.E("entity, scope and value must be specified")
+	}
+	if len(args) > 3 {
+		return errors.E("too many args")
+	}
+	value, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errors.E("invalid limit value")
+	}
+	c.params.Entity, c.params.Scope, c.params.Value = args[0], args[1], value
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setLimitCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetLimit(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveLimitCommand returns a command to remove a limit.
+func newRemoveLimitCommand() cmd.Command {
+	cmd := &removeLimitCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeLimitCommand removes a limit.
+type removeLimitCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.RemoveLimitRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeLimitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a limit.",
+		Doc:     removeLimitDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeLimitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeLimitCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("entity and scope must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.params.Entity, c.params.Scope = args[0], args[1]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeLimitCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveLimit(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatLimitsTabular adds a row for each limit to the table. The usage
+// of default limits is left empty.
+func formatLimitsTabular(t *table, value interface{}) error {
+	limits, ok := value.([]apiparams.Limit)
+	if !ok {
+		return unexpectedType(limits, value)
+	}
+	t.AddHeader("Entity", "Scope", "Value", "Usage")
+	for _, l := range limits {
+		var usage string
+		if l.Usage != nil {
+			usage = strconv.FormatInt(*l.Usage, 10)
+		}
+		t.AddRow(l.Entity, l.Scope, l.Value, usage)
+	}
+	return nil
+}
@@ -4,10 +4,7 @@ package cmd
 
 import (
 	"encoding/json"
-	"fmt"
-	"io"
 
-	"github.com/gosuri/uitable"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/gnuflag"
 	jujuapi "github.com/juju/juju/api"
@@ -42,7 +39,7 @@ func NewListAuditEventsCommand() cmd.Command {
 // model status.
 type listAuditEventsCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out listOutput
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -61,16 +58,14 @@ func (c *listAuditEventsCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listAuditEventsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml":    cmd.FormatYaml,
-		"json":    cmd.FormatJson,
-		"tabular": formatTabular,
-	})
+	c.out.AddFlags(f, "yaml", formatTabular)
 	f.StringVar(&c.args.After, "after", "", "display events that happened after specified time")
 	f.StringVar(&c.args.Before, "before", "", "display events that happened before specified time")
 	f.StringVar(&c.args.UserTag, "user-tag", "", "display events performed by authenticated user")
 	f.StringVar(&c.args.Method, "method", "", "display events for a specific method call")
 	f.StringVar(&c.args.Model, "model", "", "display events for a specific model (model name is controller/model)")
+	f.StringVar(&c.args.ModelUUID, "model-uuid", "", "display events for the model with the given UUID")
+	f.StringVar(&c.args.Cursor, "cursor", "", "display the events following the next-cursor returned with a previous page")
 	f.IntVar(&c.args.Offset, "offset", 0, "offset the set of returned audit events")
 	f.IntVar(&c.args.Limit, "limit", 0, "limit the maximum number of returned audit events")
 	f.BoolVar(&c.args.SortTime, "reverse", false, "reverse the order of logs, showing the most recent first")
@@ -110,17 +105,13 @@ func (c *listAuditEventsCommand) Run(ctxt *cmd.Context) error {
 	return nil
 }
 
-func formatTabular(writer io.Writer, value interface{}) error {
+func formatTabular(t *table, value interface{}) error {
 	e, ok := value.(apiparams.AuditEvents)
 	if !ok {
-		return errors.E(fmt.Sprintf("expected value of type %T, got %T", e, value))
+		return unexpectedType(e, value)
 	}
 
-	table := uitable.New()
-	table.MaxColWidth = 50
-	table.Wrap = true
-
-	table.AddRow("Time", "User", "Model", "ConversationId", "MessageId", "Method", "IsResponse", "Params", "Errors")
+	t.AddHeader("Time", "User", "Model", "ConversationId", "MessageId", "Method", "IsResponse", "Params", "Errors")
 	for _, event := range e.Events {
 		errorJSON, err := json.Marshal(event.Errors)
 		if err != nil {
@@ -130,8 +121,7 @@ func formatTabular(writer io.Writer, value interface{}) error {
 		if err != nil {
 			return errors.E(err)
 		}
-		table.AddRow(event.Time, event.UserTag, event.Model, event.ConversationId, event.MessageId, event.FacadeMethod, event.IsResponse, string(paramsJSON), string(errorJSON))
+		t.AddRow(event.Time, event.UserTag, event.Model, event.ConversationId, event.MessageId, event.FacadeMethod, event.IsResponse, string(paramsJSON), string(errorJSON))
 	}
-	fmt.Fprint(writer, table)
 	return nil
 }
@@ -3,6 +3,9 @@
 package cmd
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/juju/cmd/v3"
 	"github.com/juju/gnuflag"
 	jujuapi "github.com/juju/juju/api"
@@ -12,11 +15,14 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 var listControllersComandDoc = `
 	list-controllers command displays controller information
-	for all controllers known to JIMM.
+	for all controllers known to JIMM, including the agent version,
+	availability, number of models and cloud regions of each
+	controller.
 
 	Example:
 		jimmctl controllers 
@@ -36,7 +42,7 @@ func NewListControllersCommand() cmd.Command {
 // for all controllers known to JIMM.
 type listControllersCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out listOutput
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -54,10 +60,7 @@ func (c *listControllersCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listControllersCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.out.AddFlags(f, "yaml", formatControllersTabular)
 }
 
 // Run implements Command.Run.
@@ -83,3 +86,20 @@ func (c *listControllersCommand) Run(ctxt *cmd.Context) error {
 	}
 	return nil
 }
+
+// formatControllersTabular adds a row for each controller to the table.
+func formatControllersTabular(t *table, value interface{}) error {
+	controllers, ok := value.([]apiparams.ControllerInfo)
+	if !ok {
+		return unexpectedType(controllers, value)
+	}
+	t.AddHeader("Name", "UUID", "Public Address", "Cloud", "Region", "Version", "Status", "Since", "Models", "Cloud Regions")
+	for _, ctl := range controllers {
+		var since string
+		if ctl.Status.Since != nil {
+			since = formatTime(*ctl.Status.Since)
+		}
+		t.AddRow(ctl.Name, ctl.UUID, ctl.PublicAddress, ctl.CloudTag, ctl.CloudRegion, ctl.AgentVersion, ctl.Status.Status, since, strconv.Itoa(ctl.ModelCount), strings.Join(ctl.CloudRegions, ","))
+	}
+	return nil
+}
@@ -30,6 +30,9 @@ var (
     info: ""
     data: {}
     since: null
+  cloudregions:
+  - ` + jimmtest.TestCloudName + `/` + jimmtest.TestCloudRegionName + `
+  modelcount: 0
 - name: controller-1
   uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
   publicaddress: ""
@@ -48,6 +51,9 @@ var (
     info: ""
     data: {}
     since: null
+  cloudregions:
+  - ` + jimmtest.TestCloudName + `/` + jimmtest.TestCloudRegionName + `
+  modelcount: 0
 `
 
 	expectedOutput = `- name: jaas
@@ -64,6 +70,8 @@ var (
     info: ""
     data: {}
     since: null
+  cloudregions: \[\]
+  modelcount: 0
 `
 )
 
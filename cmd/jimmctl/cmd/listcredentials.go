@@ -0,0 +1,136 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var listCredentialsCommandDoc = `
+	list-credentials command lists the cloud credentials known to JIMM.
+	JIMM administrators may list the credentials of any user, other
+	users only see their own credentials. The credential attributes
+	are never shown.
+
+	Example:
+		jimmctl list-credentials
+		jimmctl list-credentials --owner alice@canonical.com --cloud aws
+		jimmctl list-credentials --valid=false --auth-type access-key
+		jimmctl list-credentials --offset 50 --limit 50
+`
+
+// NewListCredentialsCommand returns a command to list cloud credentials.
+func NewListCredentialsCommand() cmd.Command {
+	cmd := &listCredentialsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listCredentialsCommand lists cloud credentials.
+type listCredentialsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	valid  string
+	params apiparams.ListCloudCredentialsRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *listCredentialsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list-credentials",
+		Purpose: "Lists cloud credentials known to JIMM.",
+		Doc:     listCredentialsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatCredentialsTabular)
+	f.StringVar(&c.params.Owner, "owner", "", "only list credentials owned by this user")
+	f.StringVar(&c.params.Cloud, "cloud", "", "only list credentials for this cloud")
+	f.StringVar(&c.params.AuthType, "auth-type", "", "only list credentials with this auth-type")
+	f.StringVar(&c.valid, "valid", "", "only list credentials known to be valid (true) or invalid (false)")
+	f.IntVar(&c.params.Offset, "offset", 0, "number of credentials to skip")
+	f.IntVar(&c.params.Limit, "limit", 0, "maximum number of credentials to list")
+}
+
+// Init implements the cmd.Command interface.
+func (c *listCredentialsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.valid != "" {
+		valid, err := strconv.ParseBool(c.valid)
+		if err != nil {
+			return errors.E("invalid value for --valid, expected true or false")
+		}
+		c.params.Valid = &valid
+	}
+	if c.params.Offset < 0 || c.params.Limit < 0 {
+		return errors.E("offset and limit cannot be negative")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listCredentialsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	creds, err := client.ListCloudCredentials(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, creds)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatCredentialsTabular adds a row for each credential to the table.
+// The validity of credentials that have never been checked is left empty.
+func formatCredentialsTabular(t *table, value interface{}) error {
+	creds, ok := value.([]apiparams.CloudCredentialInfo)
+	if !ok {
+		return unexpectedType(creds, value)
+	}
+	t.AddHeader("Cloud", "Owner", "Name", "Auth Type", "Valid", "Expires", "Models")
+	for _, cred := range creds {
+		var valid, expires string
+		if cred.Valid != nil {
+			valid = strconv.FormatBool(*cred.Valid)
+		}
+		if cred.ExpiresAt != nil {
+			expires = formatTime(*cred.ExpiresAt)
+		}
+		t.AddRow(cred.Cloud, cred.Owner, cred.Name, cred.AuthType, valid, expires, strconv.Itoa(cred.Models))
+	}
+	return nil
+}
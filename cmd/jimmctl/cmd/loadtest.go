@@ -0,0 +1,421 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	"github.com/juju/juju/api/client/modelmanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var loadTestCommandDoc = `
+loadtest command drives a synthetic workload against the current JIMM
+controller and reports the latency of each operation, to help with
+capacity planning.
+
+A number of concurrent workers each open a connection to JIMM and
+repeatedly perform operations, chosen at random according to the
+weights in the workload, until the duration has elapsed. The available
+operations are:
+
+	login              open and close a new connection
+	list-models        list the models the user can see
+	add-destroy-model  add a model and then destroy it
+	watch              start an all-model watcher, wait for the
+	                   initial deltas and stop the watcher
+
+Models added by the add-destroy-model operation are named with the
+model prefix and are destroyed without waiting for the destruction to
+complete. The workload should be run against a test deployment, or
+with an account whose model limits allow for the added models.
+
+Example:
+	jimmctl loadtest --duration 5m --concurrency 20
+	jimmctl loadtest --workload login=1,list-models=10 --format json
+	jimmctl loadtest --workload add-destroy-model=1 --cloud aws --region us-east-1 --credential aws/alice@canonical.com/cred
+`
+
+// Load test operations.
+const (
+	loadTestLogin           = "login"
+	loadTestListModels      = "list-models"
+	loadTestAddDestroyModel = "add-destroy-model"
+	loadTestWatch           = "watch"
+)
+
+// defaultLoadTestWorkload is the workload used if none is specified,
+// it does not add models.
+const defaultLoadTestWorkload = "login=1,list-models=5,watch=1"
+
+// loadTestWeight is the relative weight of an operation in a workload.
+type loadTestWeight struct {
+	op     string
+	weight int
+}
+
+// parseLoadTestWorkload parses a workload specified as a comma
+// separated list of <operation>=<weight> pairs.
+func parseLoadTestWorkload(s string) ([]loadTestWeight, error) {
+	var workload []loadTestWeight
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		op, w, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, errors.E(fmt.Sprintf("invalid workload entry %q, expected <operation>=<weight>", part))
+		}
+		switch op {
+		case loadTestLogin, loadTestListModels, loadTestAddDestroyModel, loadTestWatch:
+		default:
+			return nil, errors.E(fmt.Sprintf("unknown operation %q", op))
+		}
+		if seen[op] {
+			return nil, errors.E(fmt.Sprintf("operation %q specified more than once", op))
+		}
+		seen[op] = true
+		weight, err := strconv.Atoi(w)
+		if err != nil || weight < 0 {
+			return nil, errors.E(fmt.Sprintf("invalid weight %q for operation %q", w, op))
+		}
+		if weight > 0 {
+			workload = append(workload, loadTestWeight{op: op, weight: weight})
+		}
+	}
+	if len(workload) == 0 {
+		return nil, errors.E("workload has no operations")
+	}
+	return workload, nil
+}
+
+// chooseLoadTestOp chooses an operation from the workload at random
+// according to the operation weights.
+func chooseLoadTestOp(r *rand.Rand, workload []loadTestWeight) string {
+	total := 0
+	for _, w := range workload {
+		total += w.weight
+	}
+	n := r.Intn(total)
+	for _, w := range workload {
+		if n < w.weight {
+			return w.op
+		}
+		n -= w.weight
+	}
+	return workload[len(workload)-1].op
+}
+
+// loadTestOperationResult holds the results of one operation in a load
+// test.
+type loadTestOperationResult struct {
+	Operation  string `json:"operation" yaml:"operation"`
+	Count      int    `json:"count" yaml:"count"`
+	Errors     int    `json:"errors" yaml:"errors"`
+	FirstError string `json:"first-error,omitempty" yaml:"first-error,omitempty"`
+	P50        string `json:"p50" yaml:"p50"`
+	P90        string `json:"p90" yaml:"p90"`
+	P99        string `json:"p99" yaml:"p99"`
+	Max        string `json:"max" yaml:"max"`
+}
+
+// loadTestReport holds the results of a load test.
+type loadTestReport struct {
+	Duration    string                    `json:"duration" yaml:"duration"`
+	Concurrency int                       `json:"concurrency" yaml:"concurrency"`
+	Operations  int                       `json:"operations" yaml:"operations"`
+	Errors      int                       `json:"errors" yaml:"errors"`
+	Throughput  float64                   `json:"throughput" yaml:"throughput"`
+	Results     []loadTestOperationResult `json:"results" yaml:"results"`
+}
+
+// loadTestRecorder records the latency and outcome of operations.
+type loadTestRecorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	firstErr  map[string]string
+}
+
+func newLoadTestRecorder() *loadTestRecorder {
+	return &loadTestRecorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+		firstErr:  make(map[string]string),
+	}
+}
+
+// record records an operation that took d and failed with err, if err
+// is not nil.
+func (r *loadTestRecorder) record(op string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[op] = append(r.latencies[op], d)
+	if err != nil {
+		if r.errors[op] == 0 {
+			r.firstErr[op] = err.Error()
+		}
+		r.errors[op]++
+	}
+}
+
+// report returns the report for a load test that ran for the given
+// duration with the given concurrency.
+func (r *loadTestRecorder) report(elapsed time.Duration, concurrency int) loadTestReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := loadTestReport{
+		Duration:    elapsed.Round(time.Millisecond).String(),
+		Concurrency: concurrency,
+	}
+	ops := make([]string, 0, len(r.latencies))
+	for op := range r.latencies {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	for _, op := range ops {
+		ls := append([]time.Duration(nil), r.latencies[op]...)
+		sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+		rep.Results = append(rep.Results, loadTestOperationResult{
+			Operation:  op,
+			Count:      len(ls),
+			Errors:     r.errors[op],
+			FirstError: r.firstErr[op],
+			P50:        percentile(ls, 50).String(),
+			P90:        percentile(ls, 90).String(),
+			P99:        percentile(ls, 99).String(),
+			Max:        ls[len(ls)-1].String(),
+		})
+		rep.Operations += len(ls)
+		rep.Errors += r.errors[op]
+	}
+	if elapsed > 0 {
+		rep.Throughput = float64(rep.Operations) / elapsed.Seconds()
+	}
+	return rep
+}
+
+// percentile returns the p-th percentile, using the nearest rank method,
+// of the given sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// NewLoadTestCommand returns a command to run a load test against JIMM.
+func NewLoadTestCommand() cmd.Command {
+	cmd := &loadTestCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// loadTestCommand runs a load test against JIMM.
+type loadTestCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	duration    time.Duration
+	concurrency int
+	workloadStr string
+	seed        int64
+	modelPrefix string
+	cloud       string
+	region      string
+	credential  string
+
+	workload []loadTestWeight
+}
+
+// Info implements the cmd.Command interface.
+func (c *loadTestCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "loadtest",
+		Purpose: "Runs a synthetic workload against JIMM and reports latencies.",
+		Doc:     loadTestCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *loadTestCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.DurationVar(&c.duration, "duration", time.Minute, "how long to run the workload for")
+	f.IntVar(&c.concurrency, "concurrency", 10, "number of concurrent workers")
+	f.StringVar(&c.workloadStr, "workload", defaultLoadTestWorkload, "comma separated <operation>=<weight> pairs")
+	f.Int64Var(&c.seed, "seed", 0, "seed for choosing operations, by default the current time is used")
+	f.StringVar(&c.modelPrefix, "model-prefix", "loadtest", "prefix of the names of models added by the workload")
+	f.StringVar(&c.cloud, "cloud", "", "cloud in which to add models")
+	f.StringVar(&c.region, "region", "", "cloud region in which to add models")
+	f.StringVar(&c.credential, "credential", "", "credential used to add models, as <cloud>/<owner>/<name>")
+}
+
+// Init implements the cmd.Command interface.
+func (c *loadTestCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.duration <= 0 {
+		return errors.E("duration must be positive")
+	}
+	if c.concurrency < 1 {
+		return errors.E("concurrency must be at least 1")
+	}
+	if c.credential != "" && !names.IsValidCloudCredential(c.credential) {
+		return errors.E(fmt.Sprintf("invalid credential %q", c.credential))
+	}
+	var err error
+	c.workload, err = parseLoadTestWorkload(c.workloadStr)
+	if err != nil {
+		return err
+	}
+	if c.seed == 0 {
+		c.seed = time.Now().UnixNano()
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *loadTestCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	dial := func() (jujuapi.Connection, error) {
+		return c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	}
+
+	ctx, cancel := context.WithTimeout(ctxt, c.duration)
+	defer cancel()
+
+	rec := newLoadTestRecorder()
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			c.runWorker(ctx, worker, dial, rec)
+		}(i)
+	}
+	wg.Wait()
+
+	return c.out.Write(ctxt, rec.report(time.Since(start), c.concurrency))
+}
+
+// runWorker performs operations on its own connection until the context
+// is done. If the connection fails it is replaced.
+func (c *loadTestCommand) runWorker(ctx context.Context, worker int, dial func() (jujuapi.Connection, error), rec *loadTestRecorder) {
+	//nolint:gosec // The random choice of operations need not be secure.
+	r := rand.New(rand.NewSource(c.seed + int64(worker)))
+	var conn jujuapi.Connection
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for seq := 0; ctx.Err() == nil; seq++ {
+		if conn == nil || conn.IsBroken() {
+			if conn != nil {
+				conn.Close()
+				conn = nil
+			}
+			t0 := time.Now()
+			var err error
+			conn, err = dial()
+			rec.record(loadTestLogin, time.Since(t0), err)
+			if err != nil {
+				conn = nil
+				continue
+			}
+		}
+
+		op := chooseLoadTestOp(r, c.workload)
+		t0 := time.Now()
+		err := c.runOp(op, worker, seq, conn, dial)
+		if ctx.Err() != nil {
+			// Operations interrupted by the end of the test are not
+			// recorded.
+			return
+		}
+		rec.record(op, time.Since(t0), err)
+	}
+}
+
+// runOp performs a single operation.
+func (c *loadTestCommand) runOp(op string, worker, seq int, conn jujuapi.Connection, dial func() (jujuapi.Connection, error)) error {
+	switch op {
+	case loadTestLogin:
+		conn, err := dial()
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case loadTestListModels:
+		user, ok := conn.AuthTag().(names.UserTag)
+		if !ok {
+			return errors.E("not logged in as a user")
+		}
+		_, err := modelmanager.NewClient(conn).ListModels(user.Id())
+		return err
+	case loadTestAddDestroyModel:
+		user, ok := conn.AuthTag().(names.UserTag)
+		if !ok {
+			return errors.E("not logged in as a user")
+		}
+		var credTag names.CloudCredentialTag
+		if c.credential != "" {
+			credTag = names.NewCloudCredentialTag(c.credential)
+		}
+		client := modelmanager.NewClient(conn)
+		name := fmt.Sprintf("%s-%d-%d-%d", c.modelPrefix, c.seed%100000, worker, seq)
+		info, err := client.CreateModel(name, user.Id(), c.cloud, c.region, credTag, nil)
+		if err != nil {
+			return err
+		}
+		return client.DestroyModel(names.NewModelTag(info.UUID), nil, nil, nil, nil)
+	case loadTestWatch:
+		client := api.NewClient(conn)
+		id, err := client.WatchAllModels(&apiparams.WatchAllModelsRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = client.AllModelWatcherNext(id)
+		if stopErr := client.AllModelWatcherStop(id); err == nil {
+			err = stopErr
+		}
+		return err
+	default:
+		return errors.E(fmt.Sprintf("unknown operation %q", op))
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+)
+
+type loadTestSuite struct{}
+
+var _ = gc.Suite(&loadTestSuite{})
+
+func (s *loadTestSuite) TestParseLoadTestWorkload(c *gc.C) {
+	_, err := cmd.ParseLoadTestWorkload("login=1, list-models=5,watch=0")
+	c.Check(err, gc.IsNil)
+
+	for _, test := range []struct {
+		workload    string
+		expectError string
+	}{{
+		workload:    "login",
+		expectError: `invalid workload entry "login", expected <operation>=<weight>`,
+	}, {
+		workload:    "login=1,deploy=2",
+		expectError: `unknown operation "deploy"`,
+	}, {
+		workload:    "login=1,login=2",
+		expectError: `operation "login" specified more than once`,
+	}, {
+		workload:    "login=-1",
+		expectError: `invalid weight "-1" for operation "login"`,
+	}, {
+		workload:    "login=0,watch=0",
+		expectError: `workload has no operations`,
+	}} {
+		_, err := cmd.ParseLoadTestWorkload(test.workload)
+		c.Check(err, gc.ErrorMatches, test.expectError, gc.Commentf("workload %q", test.workload))
+	}
+}
+
+func (s *loadTestSuite) TestPercentile(c *gc.C) {
+	var ds []time.Duration
+	for i := 1; i <= 200; i++ {
+		ds = append(ds, time.Duration(i)*time.Millisecond)
+	}
+	c.Check(cmd.Percentile(ds, 50), gc.Equals, 100*time.Millisecond)
+	c.Check(cmd.Percentile(ds, 90), gc.Equals, 180*time.Millisecond)
+	c.Check(cmd.Percentile(ds, 99), gc.Equals, 198*time.Millisecond)
+	c.Check(cmd.Percentile(ds[:1], 99), gc.Equals, time.Millisecond)
+	c.Check(cmd.Percentile(nil, 50), gc.Equals, time.Duration(0))
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	maintenanceDoc = `
+maintenance command enables management of JIMM's maintenance mode. While
+in maintenance mode JIMM rejects requests that would change its state,
+such as adding models or granting access, but continues to serve requests
+that only read state and connections to models. Maintenance mode is
+intended to be used while the database is migrated or JIMM is upgraded.
+`
+
+	maintenanceStatusDoc = `
+status command shows whether JIMM is in maintenance mode.
+
+Example:
+	jimmctl maintenance status
+`
+
+	maintenanceEnableDoc = `
+enable command puts JIMM into maintenance mode. The optional message is
+returned to clients whose requests are rejected.
+
+Example:
+	jimmctl maintenance enable --message "database upgrade until 14:00 UTC"
+`
+
+	maintenanceDisableDoc = `
+disable command takes JIMM out of maintenance mode.
+
+Example:
+	jimmctl maintenance disable
+`
+)
+
+// NewMaintenanceCommand returns a command for managing maintenance mode.
+func NewMaintenanceCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "maintenance",
+		Doc:     maintenanceDoc,
+		Purpose: "Maintenance mode management.",
+	})
+	cmd.Register(newMaintenanceStatusCommand())
+	cmd.Register(newSetMaintenanceModeCommand(true))
+	cmd.Register(newSetMaintenanceModeCommand(false))
+
+	return cmd
+}
+
+// newMaintenanceStatusCommand returns a command to show the maintenance
+// mode.
+func newMaintenanceStatusCommand() cmd.Command {
+	cmd := &maintenanceStatusCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// maintenanceStatusCommand shows the maintenance mode.
+type maintenanceStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *maintenanceStatusCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "status",
+		Purpose: "Show the maintenance mode.",
+		Doc:     maintenanceStatusDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *maintenanceStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *maintenanceStatusCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *maintenanceStatusCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	mm, err := client.GetMaintenanceMode()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, mm)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newSetMaintenanceModeCommand returns a command to enable, or if
+// enabled is false disable, maintenance mode.
+func newSetMaintenanceModeCommand(enabled bool) cmd.Command {
+	cmd := &setMaintenanceModeCommand{
+		store: jujuclient.NewFileClientStore(),
+		params: apiparams.SetMaintenanceModeRequest{
+			Enabled: enabled,
+		},
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setMaintenanceModeCommand enables or disables maintenance mode.
+type setMaintenanceModeCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetMaintenanceModeRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setMaintenanceModeCommand) Info() *cmd.Info {
+	if c.params.Enabled {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "enable",
+			Purpose: "Enable maintenance mode.",
+			Doc:     maintenanceEnableDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "disable",
+		Purpose: "Disable maintenance mode.",
+		Doc:     maintenanceDisableDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setMaintenanceModeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	if c.params.Enabled {
+		f.StringVar(&c.params.Message, "message", "", "message returned to clients whose requests are rejected")
+	}
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setMaintenanceModeCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setMaintenanceModeCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetMaintenanceMode(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
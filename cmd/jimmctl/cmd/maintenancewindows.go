@@ -0,0 +1,310 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	maintenanceWindowsDoc = `
+maintenance-windows command enables management of the maintenance
+windows declared for controllers. While a controller is in a maintenance
+window JIMM does not report it as unavailable, migrations to and from it
+are refused and users creating models on it are warned.
+`
+
+	addMaintenanceWindowDoc = `
+add command declares a maintenance window for a controller. The start
+time is an RFC 3339 time and the end is either an RFC 3339 time or a
+duration after the start.
+
+Example:
+	jimmctl maintenance-windows add <controller name> <start> <end> [--reason <reason>]
+
+Examples:
+	jimmctl maintenance-windows add controller-1 2024-06-01T22:00:00Z 2024-06-02T02:00:00Z
+	jimmctl maintenance-windows add controller-1 2024-06-01T22:00:00Z 4h --reason "upgrade to 3.6"
+`
+
+	listMaintenanceWindowsDoc = `
+list command lists the maintenance windows that have not yet ended, for
+every controller or for the given controller.
+
+Example:
+	jimmctl maintenance-windows list [--controller <controller name>] [--all]
+`
+
+	removeMaintenanceWindowDoc = `
+remove command removes a maintenance window.
+
+Example:
+	jimmctl maintenance-windows remove <id>
+`
+)
+
+// NewMaintenanceWindowsCommand returns a command for managing controller
+// maintenance windows.
+func NewMaintenanceWindowsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "maintenance-windows",
+		Doc:     maintenanceWindowsDoc,
+		Purpose: "Controller maintenance window management.",
+	})
+	cmd.Register(newAddMaintenanceWindowCommand())
+	cmd.Register(newListMaintenanceWindowsCommand())
+	cmd.Register(newRemoveMaintenanceWindowCommand())
+
+	return cmd
+}
+
+// newAddMaintenanceWindowCommand returns a command to add a maintenance
+// window.
+func newAddMaintenanceWindowCommand() cmd.Command {
+	cmd := &addMaintenanceWindowCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addMaintenanceWindowCommand adds a maintenance window.
+type addMaintenanceWindowCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.AddMaintenanceWindowRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *addMaintenanceWindowCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Purpose: "Add a controller maintenance window.",
+		Doc:     addMaintenanceWindowDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addMaintenanceWindowCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.params.Reason, "reason", "", "description of the maintenance")
+}
+
+// Init implements the cmd.Command interface.
+func (c *addMaintenanceWindowCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.E("controller name, start and end must be specified")
+	}
+	if len(args) > 3 {
+		return errors.E("too many args")
+	}
+	c.params.Controller = args[0]
+	start, err := time.Parse(time.RFC3339, args[1])
+	if err != nil {
+		return errors.E("invalid start time, must be an RFC 3339 time")
+	}
+	c.params.StartsAt = start
+	if d, err := time.ParseDuration(args[2]); err == nil {
+		c.params.EndsAt = start.Add(d)
+	} else if end, err := time.Parse(time.RFC3339, args[2]); err == nil {
+		c.params.EndsAt = end
+	} else {
+		return errors.E("invalid end, must be an RFC 3339 time or a duration")
+	}
+	if !c.params.EndsAt.After(c.params.StartsAt) {
+		return errors.E("maintenance window must end after it starts")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addMaintenanceWindowCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	w, err := client.AddMaintenanceWindow(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, w)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListMaintenanceWindowsCommand returns a command to list maintenance
+// windows.
+func newListMaintenanceWindowsCommand() cmd.Command {
+	cmd := &listMaintenanceWindowsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listMaintenanceWindowsCommand lists maintenance windows.
+type listMaintenanceWindowsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.ListMaintenanceWindowsRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *listMaintenanceWindowsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List controller maintenance windows.",
+		Doc:     listMaintenanceWindowsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listMaintenanceWindowsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatMaintenanceWindowsTabular)
+	f.StringVar(&c.params.Controller, "controller", "", "only list windows for the named controller")
+	f.BoolVar(&c.params.All, "all", false, "also list windows that have ended")
+}
+
+// Init implements the cmd.Command interface.
+func (c *listMaintenanceWindowsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listMaintenanceWindowsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	windows, err := client.ListMaintenanceWindows(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, windows)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveMaintenanceWindowCommand returns a command to remove a
+// maintenance window.
+func newRemoveMaintenanceWindowCommand() cmd.Command {
+	cmd := &removeMaintenanceWindowCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeMaintenanceWindowCommand removes a maintenance window.
+type removeMaintenanceWindowCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.RemoveMaintenanceWindowRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeMaintenanceWindowCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a controller maintenance window.",
+		Doc:     removeMaintenanceWindowDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeMaintenanceWindowCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("maintenance window id must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return errors.E("invalid maintenance window id")
+	}
+	c.params.ID = uint(id)
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeMaintenanceWindowCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveMaintenanceWindow(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatMaintenanceWindowsTabular adds a row for each maintenance window
+// to the table.
+func formatMaintenanceWindowsTabular(t *table, value interface{}) error {
+	windows, ok := value.([]apiparams.MaintenanceWindow)
+	if !ok {
+		return unexpectedType(windows, value)
+	}
+	t.AddHeader("ID", "Controller", "Starts", "Ends", "Active", "Created By", "Reason")
+	for _, w := range windows {
+		t.AddRow(w.ID, w.Controller, formatTime(w.StartsAt), formatTime(w.EndsAt), w.Active, w.CreatedBy, w.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+)
+
+const migrateControllerCredentialsDoc = `
+	migrate-controller-credentials moves the admin credentials of every
+	controller that are still stored in JIMM's database into the configured
+	credential store (e.g. Vault). Controllers that have only one of a
+	username and password stored are skipped and listed in the output.
+	If the credential store is Vault, macaroon root keys are also moved
+	out of JIMM's database. It is safe to run the command more than once.
+
+	Example:
+		jimmctl migrate-controller-credentials
+`
+
+// NewMigrateControllerCredentialsCommand returns a command used to move
+// controller admin credentials into the credential store.
+func NewMigrateControllerCredentialsCommand() cmd.Command {
+	cmd := &migrateControllerCredentialsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// migrateControllerCredentialsCommand moves controller admin credentials
+// into the credential store.
+type migrateControllerCredentialsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements Command.Info.
+func (c *migrateControllerCredentialsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migrate-controller-credentials",
+		Purpose: "Moves controller admin credentials and root keys into the credential store.",
+		Doc:     migrateControllerCredentialsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *migrateControllerCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *migrateControllerCredentialsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *migrateControllerCredentialsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.MigrateControllerCredentials()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -25,9 +25,21 @@ var migrateModelCommandDoc = `
 	Note that multiple models can be targeted for migration by supplying
 	multiple model uuids.
 
+	With --schedule the migrations are queued with JIMM's migration
+	scheduler rather than started immediately. The scheduler runs the
+	migration pre-checks against the source and target controllers
+	and limits the number of migrations in progress at once.
+
+	The progress of scheduled migrations is shown by "migrate status",
+	optionally for a single model. Add --pending to only show the
+	migrations that have not finished.
+
 	Example:
 		jimmctl migrate <controller-name> <model-uuid> 
 		jimmctl migrate <controller-name> <model-uuid> <model-uuid> <model-uuid>
+		jimmctl migrate --schedule <controller-name> <model-uuid> <model-uuid>
+		jimmctl migrate status
+		jimmctl migrate status --pending <model-uuid>
 `
 
 // NewMigrateModelCommand returns a command to migrate models.
@@ -42,12 +54,16 @@ func NewMigrateModelCommand() cmd.Command {
 // migrateModelCommand migrates a model.
 type migrateModelCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out listOutput
 
 	store            jujuclient.ClientStore
 	dialOpts         *jujuapi.DialOpts
 	targetController string
 	modelTags        []string
+
+	schedule bool
+	status   bool
+	pending  bool
 }
 
 func (c *migrateModelCommand) Info() *cmd.Info {
@@ -61,14 +77,27 @@ func (c *migrateModelCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *migrateModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.out.AddFlags(f, "yaml", formatMigrationsTabular)
+	f.BoolVar(&c.schedule, "schedule", false, "queue the migrations with the migration scheduler")
+	f.BoolVar(&c.pending, "pending", false, "only show migrations that have not finished")
 }
 
 // Init implements the cmd.Command interface.
 func (c *migrateModelCommand) Init(args []string) error {
+	if len(args) > 0 && args[0] == "status" {
+		c.status = true
+		switch len(args) {
+		case 1:
+		case 2:
+			if !names.IsValidModel(args[1]) {
+				return errors.E(fmt.Sprintf("%s is not a valid model uuid", args[1]))
+			}
+			c.modelTags = []string{args[1]}
+		default:
+			return errors.E("too many args")
+		}
+		return nil
+	}
 	if len(args) < 2 {
 		return errors.E("Missing controller name and model uuid arguments")
 	}
@@ -100,12 +129,28 @@ func (c *migrateModelCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	client := api.NewClient(apiCaller)
+	if c.status {
+		req := apiparams.ListMigrationsRequest{Pending: c.pending}
+		if len(c.modelTags) > 0 {
+			req.ModelUUID = c.modelTags[0]
+		}
+		migrations, err := client.ListMigrations(&req)
+		if err != nil {
+			return err
+		}
+		return c.out.Write(ctxt, migrations)
+	}
+
 	specs := []apiparams.MigrateModelInfo{}
 	for _, model := range c.modelTags {
 		specs = append(specs, apiparams.MigrateModelInfo{ModelTag: model, TargetController: c.targetController})
 	}
-	req := apiparams.MigrateModelRequest{Specs: specs}
-	events, err := client.MigrateModel(&req)
+	var events interface{}
+	if c.schedule {
+		events, err = client.ScheduleMigrations(&apiparams.ScheduleMigrationsRequest{Specs: specs})
+	} else {
+		events, err = client.MigrateModel(&apiparams.MigrateModelRequest{Specs: specs})
+	}
 	if err != nil {
 		return err
 	}
@@ -116,3 +161,31 @@ func (c *migrateModelCommand) Run(ctxt *cmd.Context) error {
 	}
 	return nil
 }
+
+// formatMigrationsTabular adds a row for each migration to the table.
+func formatMigrationsTabular(t *table, value interface{}) error {
+	switch v := value.(type) {
+	case []apiparams.MigrationStatus:
+		t.AddHeader("ID", "Model", "UUID", "Source", "Target", "Status", "Queued", "Completed", "Error")
+		for _, m := range v {
+			var completed string
+			if m.Completed != nil {
+				completed = formatTime(*m.Completed)
+			}
+			t.AddRow(m.ID, m.ModelName, m.ModelUUID, m.SourceController, m.TargetController, m.Status, formatTime(m.Queued), completed, m.Error)
+		}
+	case []apiparams.ScheduleMigrationResult:
+		t.AddHeader("ID", "Model", "UUID", "Target", "Status", "Error")
+		for _, r := range v {
+			if r.Migration == nil {
+				t.AddRow("", "", "", "", "", r.Error)
+				continue
+			}
+			m := r.Migration
+			t.AddRow(m.ID, m.ModelName, m.ModelUUID, m.TargetController, m.Status, r.Error)
+		}
+	default:
+		return unexpectedType([]apiparams.MigrationStatus(nil), value)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	modelArchiveDoc = `
+model-archive command enables inspection of the snapshots JIMM takes of
+models as they are destroyed. Snapshots are only taken when JIMM is
+configured with JIMM_ARCHIVE_DESTROYED_MODELS.
+`
+
+	showModelArchiveDoc = `
+show command shows the final configuration and status, including the
+applications and machines, of a destroyed model.
+
+Example:
+	jimmctl model-archive show <model uuid>
+`
+)
+
+// NewModelArchiveCommand returns a command for inspecting model archives.
+func NewModelArchiveCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "model-archive",
+		Doc:     modelArchiveDoc,
+		Purpose: "Destroyed model archive inspection.",
+	})
+	cmd.Register(newShowModelArchiveCommand())
+
+	return cmd
+}
+
+// newShowModelArchiveCommand returns a command to show a model archive.
+func newShowModelArchiveCommand() cmd.Command {
+	cmd := &showModelArchiveCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// showModelArchiveCommand shows the archive of a destroyed model.
+type showModelArchiveCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.GetModelArchiveRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *showModelArchiveCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show",
+		Args:    "<model uuid>",
+		Purpose: "Show the archive of a destroyed model.",
+		Doc:     showModelArchiveDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showModelArchiveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *showModelArchiveCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("model uuid not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if !names.IsValidModel(args[0]) {
+		return errors.E("invalid model uuid")
+	}
+	c.params.ModelUUID = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showModelArchiveCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	archive, err := client.GetModelArchive(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, archive)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
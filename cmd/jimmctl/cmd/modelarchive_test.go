@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+type modelArchiveSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&modelArchiveSuite{})
+
+const archivedModelUUID = "00000002-0000-0000-0000-000000000001"
+
+func (s *modelArchiveSuite) TestShowModelArchive(c *gc.C) {
+	err := s.JIMM.Database.AddModelArchive(context.Background(), &dbmodel.ModelArchive{
+		ModelUUID:         archivedModelUUID,
+		ModelName:         "model-1",
+		OwnerIdentityName: "charlie@canonical.com",
+		ControllerName:    "controller-1",
+		ArchivedBy:        "charlie@canonical.com",
+		Config:            dbmodel.Map{"default-series": "jammy"},
+		Status:            dbmodel.Map{"applications": map[string]interface{}{}},
+	})
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewShowModelArchiveCommandForTesting(s.ClientStore(), bClient), archivedModelUUID)
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `model-uuid: `+archivedModelUUID+`
+model-name: model-1
+owner: charlie@canonical.com
+controller: controller-1
+archived-by: charlie@canonical.com
+archived-at: .*
+config:
+  default-series: jammy
+status:
+  applications: {}
+`)
+}
+
+func (s *modelArchiveSuite) TestShowModelArchiveNotFound(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewShowModelArchiveCommandForTesting(s.ClientStore(), bClient), archivedModelUUID)
+	c.Assert(err, gc.ErrorMatches, `.*record not found.*`)
+}
+
+func (s *modelArchiveSuite) TestShowModelArchiveUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewShowModelArchiveCommandForTesting(s.ClientStore(), bClient), archivedModelUUID)
+	c.Assert(err, gc.ErrorMatches, `unauthorized.*`)
+}
+
+func (s *modelArchiveSuite) TestShowModelArchiveInvalidUUID(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewShowModelArchiveCommandForTesting(s.ClientStore(), bClient), "not-a-uuid")
+	c.Assert(err, gc.ErrorMatches, `invalid model uuid`)
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	modelConfigPoliciesDoc = `
+model-config-policies command enables management of the policies that
+forbid or force model config values in every model. Policies are checked
+when models are added and when model config is set through JIMM.
+`
+
+	listModelConfigPoliciesDoc = `
+list command lists the model config policies.
+
+Example:
+	jimmctl model-config-policies list
+`
+
+	setModelConfigPolicyDoc = `
+set command sets the policy for a model config key, replacing any
+existing policy for the key. A forbid policy prevents the key being set
+to the given value or, if no value is given, being set at all. A force
+policy requires the key to have the given value, which is also set on
+every new model.
+
+Example:
+	jimmctl model-config-policies set <key> forbid|force [<value>] [--description <description>]
+
+Examples:
+	jimmctl model-config-policies set firewall-mode forbid none --description "models must be firewalled"
+	jimmctl model-config-policies set logging-config force "<root>=INFO"
+	jimmctl model-config-policies set automatically-retry-hooks forbid
+`
+
+	removeModelConfigPolicyDoc = `
+remove command removes the policy for a model config key.
+
+Example:
+	jimmctl model-config-policies remove <key>
+`
+)
+
+// NewModelConfigPoliciesCommand returns a command for managing model
+// config policies.
+func NewModelConfigPoliciesCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "model-config-policies",
+		Doc:     modelConfigPoliciesDoc,
+		Purpose: "Model config policy management.",
+	})
+	cmd.Register(newListModelConfigPoliciesCommand())
+	cmd.Register(newSetModelConfigPolicyCommand())
+	cmd.Register(newRemoveModelConfigPolicyCommand())
+
+	return cmd
+}
+
+// newListModelConfigPoliciesCommand returns a command to list model
+// config policies.
+func newListModelConfigPoliciesCommand() cmd.Command {
+	cmd := &listModelConfigPoliciesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listModelConfigPoliciesCommand lists model config policies.
+type listModelConfigPoliciesCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listModelConfigPoliciesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List model config policies.",
+		Doc:     listModelConfigPoliciesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelConfigPoliciesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatModelConfigPoliciesTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listModelConfigPoliciesCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listModelConfigPoliciesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	policies, err := client.ListModelConfigPolicies()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, policies)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newSetModelConfigPolicyCommand returns a command to set a model config
+// policy.
+func newSetModelConfigPolicyCommand() cmd.Command {
+	cmd := &setModelConfigPolicyCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelConfigPolicyCommand sets a model config policy.
+type setModelConfigPolicyCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetModelConfigPolicyRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setModelConfigPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set",
+		Purpose: "Set a model config policy.",
+		Doc:     setModelConfigPolicyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelConfigPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Description, "description", "", "explanation of the policy shown to users who violate it")
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelConfigPolicyCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("key and action must be specified")
+	}
+	if len(args) > 3 {
+		return errors.E("too many args")
+	}
+	c.params.Key, c.params.Action = args[0], args[1]
+	if len(args) == 3 {
+		c.params.Value = &args[2]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelConfigPolicyCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetModelConfigPolicy(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveModelConfigPolicyCommand returns a command to remove a model
+// config policy.
+func newRemoveModelConfigPolicyCommand() cmd.Command {
+	cmd := &removeModelConfigPolicyCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeModelConfigPolicyCommand removes a model config policy.
+type removeModelConfigPolicyCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.RemoveModelConfigPolicyRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeModelConfigPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a model config policy.",
+		Doc:     removeModelConfigPolicyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeModelConfigPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeModelConfigPolicyCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("key must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.Key = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeModelConfigPolicyCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveModelConfigPolicy(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatModelConfigPoliciesTabular adds a row for each model config
+// policy to the table.
+func formatModelConfigPoliciesTabular(t *table, value interface{}) error {
+	policies, ok := value.([]apiparams.ModelConfigPolicy)
+	if !ok {
+		return unexpectedType(policies, value)
+	}
+	t.AddHeader("Key", "Action", "Value", "Description")
+	for _, p := range policies {
+		var v string
+		if p.Value != nil {
+			v = *p.Value
+		}
+		t.AddRow(p.Key, p.Action, v, p.Description)
+	}
+	return nil
+}
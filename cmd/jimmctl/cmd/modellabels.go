@@ -0,0 +1,235 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var listModelsCommandDoc = `
+	models command lists the models you can access, as recorded by JIMM,
+	with the labels set on each model. The --labels flag restricts the
+	list to models whose labels match a selector. A selector is a comma
+	separated list of requirements of the form key=value, key!=value or
+	key, all of which must be met.
+
+	The --idle-for flag restricts the list to models in which no activity,
+	such as a unit changing or a client connecting through JIMM, has been
+	seen for at least the given duration. Durations may be given in days,
+	for example 30d, or in any form accepted by Go's time.ParseDuration,
+	for example 12h.
+
+	Example:
+		jimmctl models
+		jimmctl models --labels team=payments,env=prod
+		jimmctl models --labels 'team,env!=dev' --format json
+		jimmctl models --idle-for 30d
+`
+
+// NewListModelsCommand returns a command to list models.
+func NewListModelsCommand() cmd.Command {
+	cmd := &listModelsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listModelsCommand lists the models the user can access.
+type listModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.ListModelStatusesRequest
+}
+
+func (c *listModelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "models",
+		Purpose: "Lists the models you can access, optionally filtered by label.",
+		Doc:     listModelsCommandDoc,
+		Aliases: []string{"list-models"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", formatModelsTabular)
+	f.StringVar(&c.params.LabelSelector, "labels", "", "only list models with labels matching this selector")
+	f.Var((*daysDuration)(&c.params.IdleFor), "idle-for", "only list models with no activity for at least this long, e.g. 30d")
+}
+
+// Run implements Command.Run.
+func (c *listModelsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	models, err := client.ListModelStatuses(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, models)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatModelsTabular adds a row for each model to the table.
+func formatModelsTabular(t *table, value interface{}) error {
+	models, ok := value.([]apiparams.ModelStatus)
+	if !ok {
+		return unexpectedType(models, value)
+	}
+	t.AddHeader("Name", "Owner", "Controller", "Status", "Last activity", "Labels")
+	for _, m := range models {
+		t.AddRow(m.Name, m.Owner, m.Controller, m.Status, formatTime(m.LastActivity), formatLabels(m.Labels))
+	}
+	return nil
+}
+
+// A daysDuration is a gnuflag.Value holding a duration that may also be
+// given as a whole number of days, for example "30d".
+type daysDuration time.Duration
+
+// Set implements gnuflag.Value.
+func (d *daysDuration) Set(s string) error {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return errors.E(fmt.Sprintf("invalid duration %q", s))
+		}
+		*d = daysDuration(time.Duration(n) * 24 * time.Hour)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil || v < 0 {
+		return errors.E(fmt.Sprintf("invalid duration %q", s))
+	}
+	*d = daysDuration(v)
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (d *daysDuration) String() string {
+	if *d == 0 {
+		return ""
+	}
+	return time.Duration(*d).String()
+}
+
+// formatLabels formats model labels as a sorted, comma separated list of
+// key=value pairs.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+var setModelLabelsCommandDoc = `
+	set-model-labels command replaces the labels set on a model. Labels
+	are given as key=value arguments. If no labels are given all the
+	model's labels are removed. The model may be given by UUID, by a path
+	of the form <owner>/<name> or by one of your model aliases.
+
+	Example:
+		jimmctl set-model-labels <model-uuid> team=payments env=prod
+		jimmctl set-model-labels alice@canonical.com/mymodel
+`
+
+// NewSetModelLabelsCommand returns a command to set the labels on a
+// model.
+func NewSetModelLabelsCommand() cmd.Command {
+	cmd := &setModelLabelsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelLabelsCommand replaces the labels set on a model.
+type setModelLabelsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetModelLabelsRequest
+}
+
+func (c *setModelLabelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-model-labels",
+		Args:    "<model> [<key>=<value> ...]",
+		Purpose: "Replace the labels set on a model",
+		Doc:     setModelLabelsCommandDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelLabelsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("missing model")
+	}
+	c.params.ModelTag = modelReference(args[0])
+	for _, arg := range args[1:] {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return errors.E(fmt.Sprintf("invalid label %q, expected key=value", arg))
+		}
+		if c.params.Labels == nil {
+			c.params.Labels = make(map[string]string)
+		}
+		if _, ok := c.params.Labels[k]; ok {
+			return errors.E(fmt.Sprintf("label %q given more than once", k))
+		}
+		c.params.Labels[k] = v
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelLabelsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(apiCaller)
+	if err := client.SetModelLabels(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
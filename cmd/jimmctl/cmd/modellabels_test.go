@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type modelLabelsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&modelLabelsSuite{})
+
+func (s *modelLabelsSuite) TestSetModelLabelsAndListModels(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-3", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// charlie is the models' owner
+	bClient := s.SetupCLIAccess(c, "charlie")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "team=payments", "env=prod")
+	c.Assert(err, gc.IsNil)
+
+	context, err := cmdtesting.RunCommand(c, cmd.NewListModelsCommandForTesting(s.ClientStore(), bClient), "--labels", "team=payments,env=prod", "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `model-2\s+charlie@canonical.com\s+controller-1\s+\S*\s+\S+\s+env=prod,team=payments\n`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListModelsCommandForTesting(s.ClientStore(), bClient), "--labels", "env!=prod", "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `(?s).*model-3.*`)
+	c.Check(cmdtesting.Stdout(context), gc.Not(gc.Matches), `(?s).*model-2.*`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "team")
+	c.Check(err, gc.ErrorMatches, `invalid label "team", expected key=value`)
+}
+
+func (s *modelLabelsSuite) TestListIdleModels(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-3", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	m := dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}}
+	err := s.JIMM.Database.GetModel(context.Background(), &m)
+	c.Assert(err, gc.IsNil)
+	m.LastActivity = sql.NullTime{Time: time.Now().Add(-45 * 24 * time.Hour), Valid: true}
+	err = s.JIMM.Database.UpdateModelLastActivity(context.Background(), &m)
+	c.Assert(err, gc.IsNil)
+
+	bClient := s.SetupCLIAccess(c, "charlie")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListModelsCommandForTesting(s.ClientStore(), bClient), "--idle-for", "30d", "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `model-2\s+charlie@canonical.com\s+controller-1\s+.*\n`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListModelsCommandForTesting(s.ClientStore(), bClient), "--idle-for", "thirty days")
+	c.Check(err, gc.ErrorMatches, `invalid value "thirty days" for flag --idle-for: invalid duration "thirty days"`)
+}
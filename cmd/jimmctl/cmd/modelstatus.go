@@ -17,11 +17,14 @@ import (
 )
 
 var modelStatusCommandDoc = `
-	model-status command displays full model status.
+	model-status command displays full model status. The model may be
+	given by UUID, by a path of the form <owner>/<name> or by one of
+	your model aliases.
 
 	Example:
 		jimmctl model-status <model uuid> 
 		jimmctl model-status <model uuid> --format yaml
+		jimmctl model-status alice@canonical.com/mymodel
 `
 
 // NewModelStatusCommand returns a command to display full model status.
@@ -39,9 +42,9 @@ type modelStatusCommand struct {
 	modelcmd.ControllerCommandBase
 	out cmd.Output
 
-	store     jujuclient.ClientStore
-	dialOpts  *jujuapi.DialOpts
-	modelUUID string
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	model    string
 }
 
 func (c *modelStatusCommand) Info() *cmd.Info {
@@ -66,7 +69,7 @@ func (c *modelStatusCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.E("missing model uuid")
 	}
-	c.modelUUID, args = args[0], args[1:]
+	c.model, args = args[0], args[1:]
 	if len(args) > 0 {
 		return errors.E("unknown arguments")
 	}
@@ -80,7 +83,6 @@ func (c *modelStatusCommand) Run(ctxt *cmd.Context) error {
 		return errors.E(err, "could not determine controller")
 	}
 
-	modelTag := names.NewModelTag(c.modelUUID)
 	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
 	if err != nil {
 		return err
@@ -88,7 +90,7 @@ func (c *modelStatusCommand) Run(ctxt *cmd.Context) error {
 
 	client := api.NewClient(apiCaller)
 	status, err := client.FullModelStatus(&apiparams.FullModelStatusRequest{
-		ModelTag: modelTag.String(),
+		ModelTag: modelReference(c.model),
 	})
 	if err != nil {
 		return errors.E(err)
@@ -100,3 +102,13 @@ func (c *modelStatusCommand) Run(ctxt *cmd.Context) error {
 	}
 	return nil
 }
+
+// modelReference returns the model reference to send to JIMM for the
+// given argument. Model UUIDs are converted to model tags, other
+// references, such as paths and aliases, are resolved by JIMM.
+func modelReference(arg string) string {
+	if names.IsValidModel(arg) {
+		return names.NewModelTag(arg).String()
+	}
+	return arg
+}
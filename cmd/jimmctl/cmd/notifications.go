@@ -0,0 +1,299 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	notificationsDoc = `
+notifications command enables management of the routes along which
+notifications, such as credential expiry warnings, usage alerts and
+migration completion notices, are delivered to users and groups.
+`
+
+	addNotificationRouteDoc = `
+add command adds a route along which notifications are delivered. The
+transport is one of "email", "slack" or "https" and the destination is
+an email address, Slack incoming webhook URL or https URL respectively.
+By default the route is for the current user and delivers every kind of
+notification. JIMM administrators may add routes for other users and for
+groups.
+
+Example:
+	jimmctl notifications add <transport> <destination> [--subject <tag>] [--kind <kind>]
+
+Examples:
+	jimmctl notifications add email alice@canonical.com
+	jimmctl notifications add slack https://hooks.slack.com/services/... --subject group-team-a --kind usage-alert
+`
+
+	listNotificationRoutesDoc = `
+list command lists the notification routes for the current user, or for
+every user and group if the current user is a JIMM administrator.
+
+Example:
+	jimmctl notifications list
+`
+
+	removeNotificationRouteDoc = `
+remove command removes a notification route.
+
+Example:
+	jimmctl notifications remove <id>
+`
+)
+
+// NewNotificationsCommand returns a command for managing notification
+// routes.
+func NewNotificationsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "notifications",
+		Doc:     notificationsDoc,
+		Purpose: "Notification route management.",
+	})
+	cmd.Register(newAddNotificationRouteCommand())
+	cmd.Register(newListNotificationRoutesCommand())
+	cmd.Register(newRemoveNotificationRouteCommand())
+
+	return cmd
+}
+
+// newAddNotificationRouteCommand returns a command to add a notification
+// route.
+func newAddNotificationRouteCommand() cmd.Command {
+	cmd := &addNotificationRouteCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addNotificationRouteCommand adds a notification route.
+type addNotificationRouteCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.AddNotificationRouteRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *addNotificationRouteCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Purpose: "Add a notification route.",
+		Doc:     addNotificationRouteDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addNotificationRouteCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.params.Subject, "subject", "", "tag of the user or group the route is for")
+	f.StringVar(&c.params.Kind, "kind", "", "kind of notification to deliver, all kinds if not specified")
+}
+
+// Init implements the cmd.Command interface.
+func (c *addNotificationRouteCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("transport and destination must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.params.Transport, c.params.Destination = args[0], args[1]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addNotificationRouteCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	route, err := client.AddNotificationRoute(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, route)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListNotificationRoutesCommand returns a command to list
+// notification routes.
+func newListNotificationRoutesCommand() cmd.Command {
+	cmd := &listNotificationRoutesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listNotificationRoutesCommand lists notification routes.
+type listNotificationRoutesCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listNotificationRoutesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List notification routes.",
+		Doc:     listNotificationRoutesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listNotificationRoutesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatNotificationRoutesTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listNotificationRoutesCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listNotificationRoutesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	routes, err := client.ListNotificationRoutes()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, routes)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveNotificationRouteCommand returns a command to remove a
+// notification route.
+func newRemoveNotificationRouteCommand() cmd.Command {
+	cmd := &removeNotificationRouteCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeNotificationRouteCommand removes a notification route.
+type removeNotificationRouteCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.RemoveNotificationRouteRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeNotificationRouteCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a notification route.",
+		Doc:     removeNotificationRouteDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeNotificationRouteCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("route id must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return errors.E("invalid route id")
+	}
+	c.params.ID = uint(id)
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeNotificationRouteCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveNotificationRoute(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatNotificationRoutesTabular adds a row for each notification route
+// to the table. Routes for every kind of notification have the kind
+// "all".
+func formatNotificationRoutesTabular(t *table, value interface{}) error {
+	routes, ok := value.([]apiparams.NotificationRoute)
+	if !ok {
+		return unexpectedType(routes, value)
+	}
+	t.AddHeader("ID", "Subject", "Kind", "Transport", "Destination")
+	for _, r := range routes {
+		kind := r.Kind
+		if kind == "" {
+			kind = "all"
+		}
+		t.AddRow(r.ID, r.Subject, kind, r.Transport, r.Destination)
+	}
+	return nil
+}
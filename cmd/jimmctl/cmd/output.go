@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// listOutput holds the output options of a listing command. Every listing
+// command supports the "tabular", "yaml" and "json" formats. The yaml and
+// json formats write the API response types in pkg/api/params, which are
+// the stable machine-readable schema of the command's output. The tabular
+// format writes a fixed set of columns for each command, optionally
+// without the header row so that the output can be processed by scripts.
+type listOutput struct {
+	out       cmd.Output
+	noHeaders bool
+}
+
+// A tableFormatter adds the rows for the given value to the table.
+type tableFormatter func(t *table, value interface{}) error
+
+// AddFlags adds the --format and --no-headers flags to the flag set. The
+// given tableFormatter is used for the tabular format.
+func (o *listOutput) AddFlags(f *gnuflag.FlagSet, defaultFormatter string, tabular tableFormatter) {
+	o.out.AddFlags(f, defaultFormatter, map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		"tabular": func(w io.Writer, value interface{}) error {
+			return writeTable(w, tabular, value, o.noHeaders)
+		},
+	})
+	f.BoolVar(&o.noHeaders, "no-headers", false, "do not print the column headers in tabular output")
+}
+
+// Write writes the given value in the selected format.
+func (o *listOutput) Write(ctx *cmd.Context, value interface{}) error {
+	return o.out.Write(ctx, value)
+}
+
+// table is a table of tabular output.
+type table struct {
+	*uitable.Table
+
+	noHeaders bool
+	errors    []string
+}
+
+// AddHeader adds the header row to the table, unless headers have been
+// disabled.
+func (t *table) AddHeader(columns ...interface{}) {
+	if t.noHeaders {
+		return
+	}
+	t.AddRow(columns...)
+}
+
+// AddError adds an error message to be printed after the table.
+func (t *table) AddError(msg string) {
+	t.errors = append(t.errors, msg)
+}
+
+// writeTable writes the value to w as a table built by the given
+// tableFormatter.
+func writeTable(w io.Writer, f tableFormatter, value interface{}, noHeaders bool) error {
+	t := table{
+		Table:     uitable.New(),
+		noHeaders: noHeaders,
+	}
+	t.MaxColWidth = 50
+	t.Wrap = true
+	if err := f(&t, value); err != nil {
+		return err
+	}
+	if len(t.Rows) > 0 {
+		fmt.Fprintln(w, t.Table)
+	}
+	if len(t.errors) != 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Errors")
+		for _, msg := range t.errors {
+			fmt.Fprintln(w, msg)
+		}
+	}
+	return nil
+}
+
+// unexpectedType returns the error returned by a tableFormatter given a
+// value of an unexpected type.
+func unexpectedType(expected, got interface{}) error {
+	return errors.E(fmt.Sprintf("expected value of type %T, got %T", expected, got))
+}
+
+// formatTime formats a time for tabular output. The zero time is shown as
+// an empty cell.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
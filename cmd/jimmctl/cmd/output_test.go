@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"strings"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type outputSuite struct{}
+
+var _ = gc.Suite(&outputSuite{})
+
+func (s *outputSuite) TestTabular(c *gc.C) {
+	usage := int64(2)
+	limits := []apiparams.Limit{{
+		Entity: "models",
+		Scope:  "user",
+		Value:  5,
+	}, {
+		Entity: "models",
+		Scope:  "user-alice@canonical.com",
+		Value:  10,
+		Usage:  &usage,
+	}}
+
+	var b strings.Builder
+	err := cmd.FormatLimitsTabular(&b, limits, false)
+	c.Assert(err, gc.IsNil)
+	c.Check(b.String(), gc.Equals, `Entity	Scope                   	Value	Usage
+models	user                    	5    	     
+models	user-alice@canonical.com	10   	2    
+`)
+
+	b.Reset()
+	err = cmd.FormatLimitsTabular(&b, limits, true)
+	c.Assert(err, gc.IsNil)
+	c.Check(b.String(), gc.Equals, `models	user                    	5 	 
+models	user-alice@canonical.com	10	2
+`)
+
+	b.Reset()
+	err = cmd.FormatLimitsTabular(&b, []apiparams.Limit{}, true)
+	c.Assert(err, gc.IsNil)
+	c.Check(b.String(), gc.Equals, "")
+
+	err = cmd.FormatLimitsTabular(&b, "limits", false)
+	c.Check(err, gc.ErrorMatches, `expected value of type \[\]params.Limit, got string`)
+}
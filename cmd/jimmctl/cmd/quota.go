@@ -0,0 +1,251 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	quotaDoc = `
+quota command enables management of the quotas on the models, machines
+and cores that may be used by a user or by each member of a group.
+Quotas are limits, see the limits command, scoped to a single user or
+group.
+`
+
+	setQuotaDoc = `
+set command sets the quota of a user or group. A quota on a group
+applies to each member of the group that has no quota of their own.
+
+Example:
+	jimmctl quota set <user|group> [--models <n>] [--machines <n>] [--cores <n>]
+
+Examples:
+	jimmctl quota set user-alice@canonical.com --models 10
+	jimmctl quota set group-team-a --models 5 --machines 20 --cores 80
+`
+
+	showQuotaDoc = `
+show command shows the quota of a user or group.
+
+Example:
+	jimmctl quota show <user|group>
+`
+)
+
+// quotaEntities holds the entities that may be given a quota, in the
+// order they are set.
+var quotaEntities = []string{"models", "machines", "cores"}
+
+// NewQuotaCommand returns a command for managing quotas.
+func NewQuotaCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "quota",
+		Doc:     quotaDoc,
+		Purpose: "User and group quota management.",
+	})
+	cmd.Register(newSetQuotaCommand())
+	cmd.Register(newShowQuotaCommand())
+
+	return cmd
+}
+
+// parseQuotaScope checks that the scope is a single user or group.
+func parseQuotaScope(scope string) error {
+	kind, id, _ := strings.Cut(scope, "-")
+	if (kind != "user" && kind != "group") || id == "" {
+		return errors.E("quota must be for a user or group, for example user-alice@canonical.com or group-team-a")
+	}
+	return nil
+}
+
+// newSetQuotaCommand returns a command to set a quota.
+func newSetQuotaCommand() cmd.Command {
+	cmd := &setQuotaCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setQuotaCommand sets a quota.
+type setQuotaCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	scope  string
+	values map[string]*int64
+	reason string
+}
+
+// Info implements the cmd.Command interface.
+func (c *setQuotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set",
+		Args:    "<user|group>",
+		Purpose: "Set the quota of a user or group.",
+		Doc:     setQuotaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.values = make(map[string]*int64)
+	for _, entity := range quotaEntities {
+		v := int64(-1)
+		c.values[entity] = &v
+		f.Int64Var(&v, entity, -1, "maximum number of "+entity)
+	}
+	f.StringVar(&c.reason, "reason", "", "reason for the change, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setQuotaCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("user or group not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if err := parseQuotaScope(args[0]); err != nil {
+		return err
+	}
+	c.scope = args[0]
+	set := false
+	for _, v := range c.values {
+		set = set || *v >= 0
+	}
+	if !set {
+		return errors.E("at least one of --models, --machines or --cores must be specified")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setQuotaCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	for _, entity := range quotaEntities {
+		v := *c.values[entity]
+		if v < 0 {
+			continue
+		}
+		err := client.SetLimit(&apiparams.SetLimitRequest{
+			Entity: entity,
+			Scope:  c.scope,
+			Value:  v,
+			Reason: c.reason,
+		})
+		if err != nil {
+			return errors.E(err)
+		}
+	}
+	return nil
+}
+
+// newShowQuotaCommand returns a command to show a quota.
+func newShowQuotaCommand() cmd.Command {
+	cmd := &showQuotaCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// showQuotaCommand shows a quota.
+type showQuotaCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	scope string
+}
+
+// Info implements the cmd.Command interface.
+func (c *showQuotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show",
+		Args:    "<user|group>",
+		Purpose: "Show the quota of a user or group.",
+		Doc:     showQuotaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatLimitsTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *showQuotaCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("user or group not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if err := parseQuotaScope(args[0]); err != nil {
+		return err
+	}
+	c.scope = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showQuotaCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	limits, err := client.ListLimits()
+	if err != nil {
+		return errors.E(err)
+	}
+	quota := []apiparams.Limit{}
+	for _, l := range limits {
+		if l.Scope == c.scope {
+			quota = append(quota, l)
+		}
+	}
+
+	err = c.out.Write(ctxt, quota)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var reconcileDoc = `
+reconcile command compares the models, controllers, clouds and groups
+in jimm's database with the relations stored in OpenFGA. Relations
+implied by the database but missing from OpenFGA, and relations that
+refer to entities no longer in the database, are reported. With
+--repair the missing relations are added and the orphaned relations
+removed.
+
+Example:
+	jimmctl auth relation reconcile [--repair]
+`
+
+// newReconcileCommand returns a command to reconcile the database with
+// OpenFGA.
+func newReconcileCommand() cmd.Command {
+	cmd := &reconcileCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// reconcileCommand reconciles the database with OpenFGA.
+type reconcileCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	repair bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *reconcileCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "reconcile",
+		Purpose: "Reconcile the database with OpenFGA relations.",
+		Doc:     reconcileDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *reconcileCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *reconcileCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.repair, "repair", false, "repair the differences found")
+}
+
+// Run implements Command.Run.
+func (c *reconcileCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.Reconcile(&apiparams.ReconcileRequest{
+		Repair: c.repair,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 	"io"
 	"os"
 
-	"github.com/gosuri/uitable"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/gnuflag"
 	jujuapi "github.com/juju/juju/api"
@@ -154,6 +153,9 @@ func NewRelationCommand() *cmd.SuperCommand {
 	cmd.Register(newRemoveRelationCommand())
 	cmd.Register(newCheckRelationCommand())
 	cmd.Register(newListRelationsCommand())
+	cmd.Register(newAddTemporaryRelationCommand())
+	cmd.Register(newListTemporaryRelationsCommand())
+	cmd.Register(newReconcileCommand())
 
 	return cmd
 }
@@ -271,6 +273,7 @@ type removeRelationCommand struct {
 	targetObject string
 
 	filename string // optional
+	reason   string // optional
 }
 
 // Info implements the cmd.Command interface.
@@ -303,6 +306,7 @@ func (c *removeRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 		"json": cmd.FormatJson,
 	})
 	f.StringVar(&c.filename, "f", "", "file location of JSON encoded tuples")
+	f.StringVar(&c.reason, "reason", "", "reason for removing the relation(s), recorded in the audit log")
 }
 
 // Run implements Command.Run.
@@ -317,7 +321,9 @@ func (c *removeRelationCommand) Run(ctxt *cmd.Context) error {
 		return err
 	}
 
-	var params apiparams.RemoveRelationRequest
+	params := apiparams.RemoveRelationRequest{
+		Reason: c.reason,
+	}
 	if c.filename == "" {
 		params.Tuples = append(params.Tuples, apiparams.RelationshipTuple{
 			Object:       c.object,
@@ -495,7 +501,7 @@ func newListRelationsCommand() cmd.Command {
 // listRelationsCommand adds a relation.
 type listRelationsCommand struct {
 	modelcmd.ControllerCommandBase
-	out cmd.Output
+	out listOutput
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -524,11 +530,7 @@ func (c *listRelationsCommand) Init(args []string) error {
 // SetFlags implements Command.SetFlags.
 func (c *listRelationsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml":    cmd.FormatYaml,
-		"json":    cmd.FormatJson,
-		"tabular": formatRelationsTabular,
-	})
+	c.out.AddFlags(f, "yaml", formatRelationsTabular)
 	f.StringVar(&c.tuple.Object, "object", "", "relation object")
 	f.StringVar(&c.tuple.Relation, "relation", "", "relation name")
 	f.StringVar(&c.tuple.TargetObject, "target", "", "relation target object")
@@ -584,28 +586,19 @@ func fetchRelations(client *api.Client, params apiparams.ListRelationshipTuplesR
 	}
 }
 
-func formatRelationsTabular(writer io.Writer, value interface{}) error {
+func formatRelationsTabular(t *table, value interface{}) error {
 	resp, ok := value.(*apiparams.ListRelationshipTuplesResponse)
 	if !ok {
-		return errors.E(fmt.Sprintf("expected value of type %T, got %T", resp, value))
+		return unexpectedType(resp, value)
 	}
 
-	table := uitable.New()
-	table.MaxColWidth = 80
-	table.Wrap = true
-
-	table.AddRow("Object", "Relation", "Target Object")
+	t.MaxColWidth = 80
+	t.AddHeader("Object", "Relation", "Target Object")
 	for _, tuple := range resp.Tuples {
-		table.AddRow(tuple.Object, tuple.Relation, tuple.TargetObject)
+		t.AddRow(tuple.Object, tuple.Relation, tuple.TargetObject)
 	}
-	fmt.Fprint(writer, table)
-
-	if len(resp.Errors) != 0 {
-		fmt.Fprintf(writer, "\n\n")
-		fmt.Fprintln(writer, "Errors")
-		for _, msg := range resp.Errors {
-			fmt.Fprintln(writer, msg)
-		}
+	for _, msg := range resp.Errors {
+		t.AddError(msg)
 	}
 	return nil
 }
@@ -51,6 +51,9 @@ type removeCloudFromControllerCommand struct {
 	// should be removed from.
 	targetControllerName string
 
+	// reason is an optional justification recorded in the audit log.
+	reason string
+
 	removeCloudFromControllerAPIFunc func() (removeCloudFromControllerAPI, error)
 	store                            jujuclient.ClientStore
 	dialOpts                         *jujuapi.DialOpts
@@ -76,6 +79,7 @@ func (c *removeCloudFromControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 	})
+	f.StringVar(&c.reason, "reason", "", "reason for removing the cloud, recorded in the audit log")
 }
 
 // Init implements the cmd.Command interface.
@@ -117,6 +121,7 @@ func (c *removeCloudFromControllerCommand) removeCloudFromController(ctxt *cmd.C
 	params := &apiparams.RemoveCloudFromControllerRequest{
 		CloudTag:       "cloud-" + c.cloudName,
 		ControllerName: c.targetControllerName,
+		Reason:         c.reason,
 	}
 
 	err = client.RemoveCloudFromController(params)
@@ -60,6 +60,7 @@ func (c *removeControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 		"json": cmd.FormatJson,
 	})
 	f.BoolVar(&c.params.Force, "force", false, "force remove a controller")
+	f.StringVar(&c.params.Reason, "reason", "", "reason for removing the controller, recorded in the audit log")
 }
 
 // Init implements the cmd.Command interface.
@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	removeUserCommandDoc = `
+	remove-user command offboards a user from jimm, disabling their
+	identity, ending their sessions, removing all of their access and
+	revoking their cloud credentials. Disabled users cannot log in.
+	Cloud credentials still used by models are kept. Models owned by the
+	user can be given to another user with --reassign-models-to.
+
+	Example:
+		jimmctl remove-user <user>
+		jimmctl remove-user <user> --reassign-models-to <user>
+`
+)
+
+// NewRemoveUserCommand returns a command to remove a user.
+func NewRemoveUserCommand() cmd.Command {
+	cmd := &removeUserCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeUserCommand removes a user.
+type removeUserCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store      jujuclient.ClientStore
+	dialOpts   *jujuapi.DialOpts
+	params     apiparams.OffboardUserRequest
+	reassignTo string
+}
+
+func (c *removeUserCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-user",
+		Purpose: "Remove a user from jimm",
+		Doc:     removeUserCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeUserCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.reassignTo, "reassign-models-to", "", "user to become the owner of the removed user's models")
+	f.StringVar(&c.params.Reason, "reason", "", "reason for removing the user, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeUserCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("user not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if !names.IsValidUser(args[0]) {
+		return errors.E("invalid user name")
+	}
+	c.params.UserTag = names.NewUserTag(args[0]).String()
+	if c.reassignTo != "" {
+		if !names.IsValidUser(c.reassignTo) {
+			return errors.E("invalid user name for --reassign-models-to")
+		}
+		c.params.ReassignModelsTo = names.NewUserTag(c.reassignTo).String()
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeUserCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(apiCaller)
+	resp, err := client.OffboardUser(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+type removeUserSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&removeUserSuite{})
+
+func (s *removeUserSuite) TestRemoveUserSuperuser(c *gc.C) {
+	ctx := context.Background()
+
+	identity, err := dbmodel.NewIdentity("charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.GetIdentity(ctx, identity)
+	c.Assert(err, gc.IsNil)
+	charlie := openfga.NewUser(identity, s.OFGAClient)
+	err = charlie.SetControllerAccess(ctx, s.JIMM.ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewRemoveUserCommandForTesting(s.ClientStore(), bClient), "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `removed-relations:
+- object: user-charlie@canonical.com
+  relation: administrator
+  target_object: controller-jimm
+`)
+
+	isAdmin, err := openfga.IsAdministrator(ctx, charlie, s.JIMM.ResourceTag())
+	c.Assert(err, gc.IsNil)
+	c.Assert(isAdmin, gc.Equals, false)
+}
+
+func (s *removeUserSuite) TestRemoveUserInvalidReassignment(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRemoveUserCommandForTesting(s.ClientStore(), bClient), "charlie@canonical.com", "--reassign-models-to", "not a user")
+	c.Assert(err, gc.ErrorMatches, `invalid user name for --reassign-models-to`)
+}
+
+func (s *removeUserSuite) TestRemoveUser(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRemoveUserCommandForTesting(s.ClientStore(), bClient), names.NewUserTag("alice@canonical.com").Id())
+	c.Assert(err, gc.ErrorMatches, `unauthorized.*`)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+)
+
+var rotateSessionKeyCommandDoc = `
+	rotate-session-key command replaces the key JIMM uses to sign the
+	session tokens given to CLI clients with a new random key. Tokens
+	signed with the replaced key remain valid until the key is rotated
+	again, tokens signed with any older key are rejected. Run the command twice to retire a compromised
+	key immediately, this logs out every user of the CLI. The new key is
+	used by every JIMM replica and takes precedence over
+	JIMM_SESSION_SECRET_KEY for session tokens only. Browser session
+	cookies are still signed with JIMM_SESSION_SECRET_KEY, to rotate that
+	key change the configuration and move the old key to
+	JIMM_SESSION_PREVIOUS_SECRET_KEYS.
+
+	Example:
+		jimmctl rotate-session-key
+`
+
+// NewRotateSessionKeyCommand returns a command to rotate the session
+// signing key.
+func NewRotateSessionKeyCommand() cmd.Command {
+	cmd := &rotateSessionKeyCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// rotateSessionKeyCommand rotates the session signing key.
+type rotateSessionKeyCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+func (c *rotateSessionKeyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rotate-session-key",
+		Purpose: "Rotate the key used to sign CLI session tokens",
+		Doc:     rotateSessionKeyCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateSessionKeyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *rotateSessionKeyCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *rotateSessionKeyCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(apiCaller)
+	if err := client.RotateSessionKey(); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type rotateSessionKeySuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&rotateSessionKeySuite{})
+
+func (s *rotateSessionKeySuite) TestRotateSessionKeySuperuser(c *gc.C) {
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateSessionKeyCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+
+	keys, err := s.JIMM.Database.GetSessionKeys(context.Background())
+	c.Assert(err, gc.IsNil)
+	c.Assert(keys, gc.HasLen, 2)
+	c.Check(keys[1], gc.Equals, jimmtest.JWTTestSecret)
+	c.Check(s.JIMM.OAuthAuthenticator.SessionKeys(), gc.DeepEquals, keys)
+}
+
+func (s *rotateSessionKeySuite) TestRotateSessionKey(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateSessionKeyCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *rotateSessionKeySuite) TestRotateSessionKeyTooManyArgs(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateSessionKeyCommandForTesting(s.ClientStore(), bClient), "extra")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+}
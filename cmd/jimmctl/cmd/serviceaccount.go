@@ -0,0 +1,392 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	serviceAccountDoc = `
+service-account command enables management of the service accounts
+you administer.
+`
+
+	createServiceAccountDoc = `
+create command adds a service account, created with the identity
+provider, to JIMM and makes you its administrator. Can only be run
+once per service account.
+
+Example:
+	jimmctl service-account create <client-id> --purpose "CI deployments"
+`
+
+	listServiceAccountsDoc = `
+list command lists the service accounts you administer. JIMM
+administrators see every service account.
+
+Example:
+	jimmctl service-account list
+`
+
+	disableServiceAccountDoc = `
+disable command prevents a service account from authenticating to JIMM.
+
+Example:
+	jimmctl service-account disable <client-id>
+`
+
+	enableServiceAccountDoc = `
+enable command allows a disabled service account to authenticate to
+JIMM again.
+
+Example:
+	jimmctl service-account enable <client-id>
+`
+
+	deleteServiceAccountDoc = `
+delete command removes a service account from JIMM. The service
+account's cloud credentials are revoked, all of its access and all
+access to administer it is removed, and it can no longer authenticate.
+The service account must not own any models.
+
+Usage:
+-y	Delete the service account without prompting for confirmation
+
+Example:
+	jimmctl service-account delete <client-id>
+`
+)
+
+// NewServiceAccountCommand returns a command for service account
+// management.
+func NewServiceAccountCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "service-account",
+		Doc:     serviceAccountDoc,
+		Purpose: "Service account management.",
+	})
+	cmd.Register(newCreateServiceAccountCommand())
+	cmd.Register(newListServiceAccountsCommand())
+	cmd.Register(newSetServiceAccountDisabledCommand(true))
+	cmd.Register(newSetServiceAccountDisabledCommand(false))
+	cmd.Register(newDeleteServiceAccountCommand())
+
+	return cmd
+}
+
+// newCreateServiceAccountCommand returns a command to add a service
+// account.
+func newCreateServiceAccountCommand() cmd.Command {
+	cmd := &createServiceAccountCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// createServiceAccountCommand adds a service account.
+type createServiceAccountCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.AddServiceAccountRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *createServiceAccountCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create",
+		Args:    "<client-id>",
+		Purpose: "Add a service account.",
+		Doc:     createServiceAccountDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *createServiceAccountCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Purpose, "purpose", "", "what the service account is used for")
+}
+
+// Init implements the cmd.Command interface.
+func (c *createServiceAccountCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("client ID not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.ClientID = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *createServiceAccountCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.AddServiceAccount(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListServiceAccountsCommand returns a command to list service
+// accounts.
+func newListServiceAccountsCommand() cmd.Command {
+	cmd := &listServiceAccountsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listServiceAccountsCommand lists the service accounts the user
+// administers.
+type listServiceAccountsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listServiceAccountsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List service accounts.",
+		Doc:     listServiceAccountsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listServiceAccountsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", formatServiceAccountsTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listServiceAccountsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listServiceAccountsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	sas, err := client.ListServiceAccounts()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, sas)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatServiceAccountsTabular adds a row for each service account to the
+// table.
+func formatServiceAccountsTabular(t *table, value interface{}) error {
+	sas, ok := value.([]apiparams.ServiceAccount)
+	if !ok {
+		return unexpectedType(sas, value)
+	}
+	t.AddHeader("Client ID", "Creator", "Purpose", "Created", "Disabled")
+	for _, sa := range sas {
+		t.AddRow(sa.ClientID, sa.Creator, sa.Purpose, formatTime(sa.CreatedAt), sa.Disabled)
+	}
+	return nil
+}
+
+// newSetServiceAccountDisabledCommand returns a command to disable, or
+// re-enable, a service account.
+func newSetServiceAccountDisabledCommand(disabled bool) cmd.Command {
+	cmd := &setServiceAccountDisabledCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+	cmd.params.Disabled = disabled
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setServiceAccountDisabledCommand disables, or re-enables, a service
+// account.
+type setServiceAccountDisabledCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.SetServiceAccountDisabledRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *setServiceAccountDisabledCommand) Info() *cmd.Info {
+	if c.params.Disabled {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "disable",
+			Args:    "<client-id>",
+			Purpose: "Disable a service account.",
+			Doc:     disableServiceAccountDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "enable",
+		Args:    "<client-id>",
+		Purpose: "Enable a disabled service account.",
+		Doc:     enableServiceAccountDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *setServiceAccountDisabledCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("client ID not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.ClientID = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setServiceAccountDisabledCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetServiceAccountDisabled(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newDeleteServiceAccountCommand returns a command to delete a service
+// account.
+func newDeleteServiceAccountCommand() cmd.Command {
+	cmd := &deleteServiceAccountCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// deleteServiceAccountCommand deletes a service account.
+type deleteServiceAccountCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.DeleteServiceAccountRequest
+	force    bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *deleteServiceAccountCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "delete",
+		Args:    "<client-id>",
+		Purpose: "Delete a service account.",
+		Doc:     deleteServiceAccountDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *deleteServiceAccountCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "y", false, "delete service account without prompt")
+}
+
+// Init implements the cmd.Command interface.
+func (c *deleteServiceAccountCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("client ID not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.ClientID = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *deleteServiceAccountCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	if !c.force {
+		reader := bufio.NewReader(ctxt.Stdin)
+		// Using Fprintf over c.out.write to avoid printing a new line.
+		_, err := fmt.Fprintf(ctxt.Stdout, "This will also revoke the service account's cloud credentials and remove all associated relations.\nConfirm you would like to delete service account %q (y/N): ", c.params.ClientID)
+		if err != nil {
+			return err
+		}
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return errors.E(err, "Failed to read from input.")
+		}
+		text = strings.ReplaceAll(text, "\n", "")
+		if !(text == "y" || text == "Y") {
+			return nil
+		}
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.DeleteServiceAccount(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+)
+
+type serviceAccountSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&serviceAccountSuite{})
+
+func (s *serviceAccountSuite) TestServiceAccountLifecycle(c *gc.C) {
+	clientID := "fca1f605-736e-4d1f-bcd2-aecc726923be"
+
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewCreateServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID, "--purpose", "ci")
+	c.Assert(err, gc.IsNil)
+
+	context, err := cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClient), "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, clientID+`@serviceaccount\s+bob@canonical.com\s+ci\s+\S+ \S+\s+false\n`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetServiceAccountDisabledCommandForTesting(s.ClientStore(), bClient, true), clientID)
+	c.Assert(err, gc.IsNil)
+	context, err = cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClient), "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `(?s).*disabled: true.*`)
+
+	// Other users do not see the service account.
+	aClient := s.SetupCLIAccess(c, "charlie")
+	context, err = cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), aClient), "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "")
+	_, err = cmdtesting.RunCommand(c, cmd.NewDeleteServiceAccountCommandForTesting(s.ClientStore(), aClient), clientID, "-y")
+	c.Check(err, gc.ErrorMatches, `unauthorized`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewDeleteServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID, "-y")
+	c.Assert(err, gc.IsNil)
+	context, err = cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClient), "--no-headers")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "")
+}
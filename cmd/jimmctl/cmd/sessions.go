@@ -0,0 +1,230 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	sessionsDoc = `
+sessions command manages the browser and device login sessions created
+when users log in to JIMM.
+`
+
+	listSessionsDoc = `
+list command lists the active browser and device login sessions. The
+sessions of a single user may be listed using the --user flag.
+
+Example:
+	jimmctl sessions list
+	jimmctl sessions list --user alice@canonical.com
+`
+
+	revokeSessionsDoc = `
+revoke command revokes all the active browser and device login sessions
+of a user. The user must log in again before using JIMM. Connections
+that are already established are not closed.
+
+Example:
+	jimmctl sessions revoke alice@canonical.com
+`
+)
+
+// NewSessionsCommand returns a command for managing login sessions.
+func NewSessionsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "sessions",
+		Doc:     sessionsDoc,
+		Purpose: "Login session management.",
+	})
+	cmd.Register(newListSessionsCommand())
+	cmd.Register(newRevokeSessionsCommand())
+
+	return cmd
+}
+
+// newListSessionsCommand returns a command to list login sessions.
+func newListSessionsCommand() cmd.Command {
+	cmd := &listSessionsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listSessionsCommand lists login sessions.
+type listSessionsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	user string
+}
+
+// Info implements the cmd.Command interface.
+func (c *listSessionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List active login sessions.",
+		Doc:     listSessionsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSessionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatSessionsTabular)
+	f.StringVar(&c.user, "user", "", "only list the sessions of this user")
+}
+
+// Init implements the cmd.Command interface.
+func (c *listSessionsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.E(fmt.Sprintf("invalid user name %q", c.user))
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listSessionsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	var req apiparams.ListSessionsRequest
+	if c.user != "" {
+		req.UserTag = names.NewUserTag(c.user).String()
+	}
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListSessions(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp.Sessions)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatSessionsTabular adds a row for each session to the table.
+func formatSessionsTabular(t *table, value interface{}) error {
+	sessions, ok := value.([]apiparams.Session)
+	if !ok {
+		return unexpectedType(sessions, value)
+	}
+	t.AddHeader("ID", "User", "Kind", "Created", "Expires")
+	for _, s := range sessions {
+		t.AddRow(strconv.FormatUint(uint64(s.ID), 10), s.User, s.Kind, s.CreatedAt, s.ExpiresAt)
+	}
+	return nil
+}
+
+// newRevokeSessionsCommand returns a command to revoke the login
+// sessions of a user.
+func newRevokeSessionsCommand() cmd.Command {
+	cmd := &revokeSessionsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// revokeSessionsCommand revokes the login sessions of a user.
+type revokeSessionsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	user string
+}
+
+// Info implements the cmd.Command interface.
+func (c *revokeSessionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke",
+		Args:    "<user>",
+		Purpose: "Revoke the login sessions of a user.",
+		Doc:     revokeSessionsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *revokeSessionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *revokeSessionsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("user not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if !names.IsValidUser(args[0]) {
+		return errors.E(fmt.Sprintf("invalid user name %q", args[0]))
+	}
+	c.user = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *revokeSessionsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.RevokeSessions(&apiparams.RevokeSessionsRequest{
+		UserTag: names.NewUserTag(c.user).String(),
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
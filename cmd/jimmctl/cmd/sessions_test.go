@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+type sessionsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&sessionsSuite{})
+
+func (s *sessionsSuite) addSessions(c *gc.C) {
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+	for i, name := range []string{"charlie@canonical.com", "charlie@canonical.com", "dave@canonical.com"} {
+		identity, err := dbmodel.NewIdentity(name)
+		c.Assert(err, gc.IsNil)
+		err = s.JIMM.Database.GetIdentity(ctx, identity)
+		c.Assert(err, gc.IsNil)
+		kind := dbmodel.BrowserSession
+		if i%2 == 1 {
+			kind = dbmodel.DeviceSession
+		}
+		err = s.JIMM.Database.AddSession(ctx, &dbmodel.Session{
+			IdentityName: name,
+			Kind:         kind,
+			Key:          name + "-" + kind,
+			ExpiresAt:    expires,
+		})
+		c.Assert(err, gc.IsNil)
+	}
+}
+
+func (s *sessionsSuite) TestListAndRevokeSessions(c *gc.C) {
+	s.addSessions(c)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListSessionsCommandForTesting(s.ClientStore(), bClient), "--format", "tabular")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `(?s)ID +User +Kind +Created +Expires
+\d+ +charlie@canonical.com +browser +.*
+\d+ +charlie@canonical.com +device +.*
+\d+ +dave@canonical.com +browser +.*
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewRevokeSessionsCommandForTesting(s.ClientStore(), bClient), "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "revoked: 2\n")
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListSessionsCommandForTesting(s.ClientStore(), bClient), "--user", "dave@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `- id: \d+
+  user: dave@canonical.com
+  kind: browser
+  created-at: .*
+  expires-at: .*
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListSessionsCommandForTesting(s.ClientStore(), bClient), "--user", "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "[]\n")
+}
+
+func (s *sessionsSuite) TestSessionsUnauthorized(c *gc.C) {
+	s.addSessions(c)
+
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListSessionsCommandForTesting(s.ClientStore(), bClient))
+	c.Check(err, gc.ErrorMatches, `unauthorized.*`)
+	_, err = cmdtesting.RunCommand(c, cmd.NewRevokeSessionsCommandForTesting(s.ClientStore(), bClient), "charlie@canonical.com")
+	c.Check(err, gc.ErrorMatches, `unauthorized.*`)
+}
+
+func (s *sessionsSuite) TestRevokeSessionsInvalidArgs(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRevokeSessionsCommandForTesting(s.ClientStore(), bClient))
+	c.Check(err, gc.ErrorMatches, `user not specified`)
+	_, err = cmdtesting.RunCommand(c, cmd.NewRevokeSessionsCommandForTesting(s.ClientStore(), bClient), "not a user")
+	c.Check(err, gc.ErrorMatches, `invalid user name "not a user"`)
+}
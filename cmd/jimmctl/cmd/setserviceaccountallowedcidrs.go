@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var setServiceAccountAllowedCIDRsDoc = `
+	set-service-account-allowed-cidrs restricts the network ranges from
+	which a service account may authenticate. Specifying no ranges
+	removes the restriction.
+
+	Example:
+		jimmctl set-service-account-allowed-cidrs <client-id> [<cidr>...]
+		jimmctl set-service-account-allowed-cidrs 2ad4ae7e-9e2f-4b7a-a59c-4e2b4f7c3f7d 10.0.0.0/8 192.168.1.0/24
+`
+
+// NewSetServiceAccountAllowedCIDRsCommand returns a command used to
+// restrict the addresses a service account may authenticate from.
+func NewSetServiceAccountAllowedCIDRsCommand() cmd.Command {
+	cmd := &setServiceAccountAllowedCIDRsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setServiceAccountAllowedCIDRsCommand sets the network ranges a service
+// account may authenticate from.
+type setServiceAccountAllowedCIDRsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	clientID string
+	cidrs    []string
+}
+
+func (c *setServiceAccountAllowedCIDRsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-service-account-allowed-cidrs",
+		Purpose: "Restricts the addresses a service account may authenticate from.",
+		Doc:     setServiceAccountAllowedCIDRsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setServiceAccountAllowedCIDRsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *setServiceAccountAllowedCIDRsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing client ID")
+	}
+	c.clientID, c.cidrs = args[0], args[1:]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setServiceAccountAllowedCIDRsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.SetServiceAccountAllowedCIDRs(&apiparams.SetServiceAccountAllowedCIDRsRequest{
+		ClientID: c.clientID,
+		CIDRs:    c.cidrs,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	return nil
+}
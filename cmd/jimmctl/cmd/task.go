@@ -0,0 +1,125 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	taskDoc = `
+task command enables inspection of long-running tasks, such as models
+being created asynchronously.
+`
+
+	showTaskDoc = `
+show command shows the status of a task. Users may only see the tasks
+they started.
+
+Example:
+	jimmctl task show <task id>
+`
+)
+
+// NewTaskCommand returns a command for inspecting tasks.
+func NewTaskCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "task",
+		Doc:     taskDoc,
+		Purpose: "Long-running task inspection.",
+	})
+	cmd.Register(newShowTaskCommand())
+
+	return cmd
+}
+
+// newShowTaskCommand returns a command to show the status of a task.
+func newShowTaskCommand() cmd.Command {
+	cmd := &showTaskCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// showTaskCommand shows the status of a task.
+type showTaskCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.GetTaskStatusRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *showTaskCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show",
+		Args:    "<task id>",
+		Purpose: "Show the status of a task.",
+		Doc:     showTaskDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showTaskCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *showTaskCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("task id not specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return errors.E("invalid task id")
+	}
+	c.params.TaskID = uint(id)
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showTaskCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	status, err := client.GetTaskStatus(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, status)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
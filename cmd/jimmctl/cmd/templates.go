@@ -0,0 +1,406 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	templatesDoc = `
+templates command enables management of model config templates. A
+template is a named set of model config values that is applied when a
+model is added with the "jimm-templates" config key, for example:
+
+	juju add-model mymodel --config jimm-templates=alice@canonical.com/base
+
+Multiple templates may be given as a comma-separated list, they are
+applied in order and any other config values given override them.
+Templates are identified by a path of the form <owner>/<name>, if the
+owner is omitted the template is owned by the current user.
+`
+
+	setModelConfigTemplateDoc = `
+set command creates, or replaces, a model config template. Config values
+may be given as key=value arguments, read from a YAML file, or both, in
+which case the arguments override values in the file. The template may
+be shared with other users, or with every user by sharing it with
+everyone@external.
+
+Example:
+	jimmctl templates set <path> [key=value ...] [--file <file>] [--share <user>,...]
+
+Examples:
+	jimmctl templates set base logging-config="<root>=INFO" --share bob@canonical.com
+	jimmctl templates set alice@canonical.com/base --file base.yaml
+`
+
+	showModelConfigTemplateDoc = `
+show command shows a model config template.
+
+Example:
+	jimmctl templates show <path>
+`
+
+	listModelConfigTemplatesDoc = `
+list command lists the model config templates the current user may use.
+
+Example:
+	jimmctl templates list
+`
+
+	removeModelConfigTemplateDoc = `
+remove command removes a model config template. Models already created
+with the template are not changed.
+
+Example:
+	jimmctl templates remove <path>
+`
+)
+
+// NewTemplatesCommand returns a command for managing model config
+// templates.
+func NewTemplatesCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "templates",
+		Doc:     templatesDoc,
+		Purpose: "Model config template management.",
+	})
+	cmd.Register(newSetModelConfigTemplateCommand())
+	cmd.Register(newShowModelConfigTemplateCommand())
+	cmd.Register(newListModelConfigTemplatesCommand())
+	cmd.Register(newRemoveModelConfigTemplateCommand())
+
+	return cmd
+}
+
+// newSetModelConfigTemplateCommand returns a command to set a model
+// config template.
+func newSetModelConfigTemplateCommand() cmd.Command {
+	cmd := &setModelConfigTemplateCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelConfigTemplateCommand sets a model config template.
+type setModelConfigTemplateCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	file     cmd.FileVar
+	share    string
+	path     string
+	values   map[string]interface{}
+}
+
+// Info implements the cmd.Command interface.
+func (c *setModelConfigTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set",
+		Purpose: "Set a model config template.",
+		Doc:     setModelConfigTemplateDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelConfigTemplateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.Var(&c.file, "file", "YAML file containing model config values")
+	f.StringVar(&c.share, "share", "", "comma-separated names of the users to share the template with")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelConfigTemplateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("template path must be specified")
+	}
+	c.path = args[0]
+	c.values = make(map[string]interface{})
+	for _, arg := range args[1:] {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return errors.E("invalid config value " + arg + ", expected key=value")
+		}
+		c.values[k] = v
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelConfigTemplateCommand) Run(ctxt *cmd.Context) error {
+	params := apiparams.SetModelConfigTemplateRequest{
+		Path:   c.path,
+		Config: make(map[string]interface{}),
+	}
+	if c.file.Path != "" {
+		if err := unmarshalYAMLFile(ctxt, &params.Config, c.file); err != nil {
+			return errors.E(err)
+		}
+	}
+	for k, v := range c.values {
+		params.Config[k] = v
+	}
+	for _, name := range strings.Split(c.share, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			params.SharedWith = append(params.SharedWith, name)
+		}
+	}
+
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	t, err := client.SetModelConfigTemplate(&params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, t)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newShowModelConfigTemplateCommand returns a command to show a model
+// config template.
+func newShowModelConfigTemplateCommand() cmd.Command {
+	cmd := &showModelConfigTemplateCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// showModelConfigTemplateCommand shows a model config template.
+type showModelConfigTemplateCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.ModelConfigTemplateRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *showModelConfigTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show",
+		Purpose: "Show a model config template.",
+		Doc:     showModelConfigTemplateDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showModelConfigTemplateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *showModelConfigTemplateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("template path must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.Path = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showModelConfigTemplateCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	t, err := client.GetModelConfigTemplate(&c.params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, t)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListModelConfigTemplatesCommand returns a command to list model
+// config templates.
+func newListModelConfigTemplatesCommand() cmd.Command {
+	cmd := &listModelConfigTemplatesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listModelConfigTemplatesCommand lists model config templates.
+type listModelConfigTemplatesCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listModelConfigTemplatesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List model config templates.",
+		Doc:     listModelConfigTemplatesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelConfigTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatModelConfigTemplatesTabular)
+}
+
+// Init implements the cmd.Command interface.
+func (c *listModelConfigTemplatesCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listModelConfigTemplatesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	templates, err := client.ListModelConfigTemplates()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, templates)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveModelConfigTemplateCommand returns a command to remove a
+// model config template.
+func newRemoveModelConfigTemplateCommand() cmd.Command {
+	cmd := &removeModelConfigTemplateCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeModelConfigTemplateCommand removes a model config template.
+type removeModelConfigTemplateCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.ModelConfigTemplateRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeModelConfigTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a model config template.",
+		Doc:     removeModelConfigTemplateDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeModelConfigTemplateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("template path must be specified")
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	c.params.Path = args[0]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeModelConfigTemplateCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveModelConfigTemplate(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatModelConfigTemplatesTabular adds a row for each model config
+// template to the table. The config values are only included in the yaml
+// and json formats.
+func formatModelConfigTemplatesTabular(t *table, value interface{}) error {
+	templates, ok := value.([]apiparams.ModelConfigTemplate)
+	if !ok {
+		return unexpectedType(templates, value)
+	}
+	t.AddHeader("Path", "Keys", "Shared With", "Updated")
+	for _, tmpl := range templates {
+		t.AddRow(tmpl.Path, len(tmpl.Config), strings.Join(tmpl.SharedWith, ","), formatTime(tmpl.UpdatedAt))
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	addTemporaryRelationDoc = `
+add-temporary command adds a relation to jimm that is removed
+automatically once the given duration has passed.
+
+Example:
+	jimmctl auth relation add-temporary --duration <duration> <object> <relation> <target_object>
+
+Examples:
+jimmctl auth relation add-temporary --duration 2h user-Alice administrator controller-jimm
+`
+
+	listTemporaryRelationsDoc = `
+list-temporary command lists the temporary relations in jimm that
+have not yet expired.
+
+Example:
+	jimmctl auth relation list-temporary
+`
+)
+
+// newAddTemporaryRelationCommand returns a command to add a temporary
+// relation.
+func newAddTemporaryRelationCommand() cmd.Command {
+	cmd := &addTemporaryRelationCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addTemporaryRelationCommand adds a temporary relation.
+type addTemporaryRelationCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	object       string
+	relation     string
+	targetObject string
+	duration     time.Duration
+}
+
+// Info implements the cmd.Command interface.
+func (c *addTemporaryRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-temporary",
+		Purpose: "Add temporary relation to jimm.",
+		Doc:     addTemporaryRelationDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *addTemporaryRelationCommand) Init(args []string) error {
+	err := verifyTupleArguments(args)
+	if err != nil {
+		return errors.E(err)
+	}
+	if c.duration <= 0 {
+		return errors.E("duration must be specified")
+	}
+	c.object, c.relation, c.targetObject = args[0], args[1], args[2]
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addTemporaryRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.DurationVar(&c.duration, "duration", 0, "length of time the relation is granted for")
+}
+
+// Run implements Command.Run.
+func (c *addTemporaryRelationCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	grant, err := client.AddTemporaryRelation(&apiparams.AddTemporaryRelationRequest{
+		Tuple: apiparams.RelationshipTuple{
+			Object:       c.object,
+			Relation:     c.relation,
+			TargetObject: c.targetObject,
+		},
+		Duration: c.duration,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, grant)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListTemporaryRelationsCommand returns a command to list temporary
+// relations.
+func newListTemporaryRelationsCommand() cmd.Command {
+	cmd := &listTemporaryRelationsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listTemporaryRelationsCommand lists temporary relations.
+type listTemporaryRelationsCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements the cmd.Command interface.
+func (c *listTemporaryRelationsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list-temporary",
+		Purpose: "List temporary relations in jimm.",
+		Doc:     listTemporaryRelationsDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listTemporaryRelationsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listTemporaryRelationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatTemporaryGrantsTabular)
+}
+
+// Run implements Command.Run.
+func (c *listTemporaryRelationsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListTemporaryRelations()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp.Grants)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatTemporaryGrantsTabular adds a row for each temporary grant to the
+// table.
+func formatTemporaryGrantsTabular(t *table, value interface{}) error {
+	grants, ok := value.([]apiparams.TemporaryGrant)
+	if !ok {
+		return unexpectedType(grants, value)
+	}
+	t.AddHeader("Object", "Relation", "Target Object", "Granted By", "Expires")
+	for _, g := range grants {
+		t.AddRow(g.Tuple.Object, g.Tuple.Relation, g.Tuple.TargetObject, g.GrantedBy, g.ExpiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var transferModelCommandDoc = `
+	transfer-model command makes the given user the owner of a model.
+	The new owner is given admin access to the model and the previous
+	owner's admin access is removed. The model may be given by UUID, by
+	a path of the form <owner>/<name> or by one of your model aliases.
+	The transfer fails if the new owner already owns a model with the
+	same name. Only JIMM records the new owner; the model's controller
+	continues to report the original owner.
+
+	Example:
+		jimmctl transfer-model <model-uuid> <user>
+`
+
+// NewTransferModelCommand returns a command to transfer the ownership of
+// a model.
+func NewTransferModelCommand() cmd.Command {
+	cmd := &transferModelCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// transferModelCommand transfers the ownership of a model.
+type transferModelCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	params   apiparams.TransferModelOwnershipRequest
+}
+
+func (c *transferModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "transfer-model",
+		Purpose: "Transfer the ownership of a model to another user",
+		Doc:     transferModelCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *transferModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.params.Reason, "reason", "", "reason for the transfer, recorded in the audit log")
+}
+
+// Init implements the cmd.Command interface.
+func (c *transferModelCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("missing model uuid and user arguments")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	if !names.IsValidUser(args[1]) {
+		return errors.E("invalid user name")
+	}
+	c.params.ModelTag = modelReference(args[0])
+	c.params.OwnerTag = names.NewUserTag(args[1]).String()
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *transferModelCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(apiCaller)
+	if err := client.TransferModelOwnership(&c.params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
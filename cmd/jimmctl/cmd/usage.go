@@ -0,0 +1,177 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	usageDoc = `
+usage command reports the machine and unit usage of the models in JIMM.
+`
+
+	usageReportDoc = `
+report command reports the machine-hours and unit-hours accrued by each
+model between two UTC days, inclusive. By default the report covers the
+current month up to and including today, and holds the total usage of
+each model over the period. The --daily flag reports the usage of each
+model on each day instead.
+
+Usage is sampled every few minutes from the machine and unit counts JIMM
+keeps for each model, and is only accrued while JIMM is running.
+
+Example:
+	jimmctl usage report
+	jimmctl usage report --from 2024-01-01 --to 2024-01-31
+	jimmctl usage report --from 2024-01-01 --daily --format tabular
+`
+)
+
+// NewUsageCommand returns a command for reporting model usage.
+func NewUsageCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "usage",
+		Doc:     usageDoc,
+		Purpose: "Model usage reporting.",
+	})
+	cmd.Register(newUsageReportCommand())
+
+	return cmd
+}
+
+// newUsageReportCommand returns a command to report model usage.
+func newUsageReportCommand() cmd.Command {
+	cmd := &usageReportCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// usageReportCommand reports model usage.
+type usageReportCommand struct {
+	modelcmd.ControllerCommandBase
+	out listOutput
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	from  string
+	to    string
+	daily bool
+
+	req apiparams.UsageReportRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *usageReportCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "report",
+		Purpose: "Report model usage.",
+		Doc:     usageReportDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *usageReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", formatUsageRecordsTabular)
+	f.StringVar(&c.from, "from", "", "first day of the report (YYYY-MM-DD), defaults to the start of the current month")
+	f.StringVar(&c.to, "to", "", "last day of the report (YYYY-MM-DD), defaults to today")
+	f.BoolVar(&c.daily, "daily", false, "report the usage of each day separately")
+}
+
+// Init implements the cmd.Command interface.
+func (c *usageReportCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	now := time.Now().UTC()
+	c.req = apiparams.UsageReportRequest{
+		From:  time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Daily: c.daily,
+	}
+	var err error
+	if c.from != "" {
+		if c.req.From, err = time.Parse(time.DateOnly, c.from); err != nil {
+			return errors.E("invalid --from date, expected YYYY-MM-DD")
+		}
+	}
+	if c.to != "" {
+		if c.req.To, err = time.Parse(time.DateOnly, c.to); err != nil {
+			return errors.E("invalid --to date, expected YYYY-MM-DD")
+		}
+	}
+	if c.req.To.Before(c.req.From) {
+		return errors.E("--to date is before --from date")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *usageReportCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.UsageReport(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp.Records)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatUsageRecordsTabular adds a row for each usage record to the
+// table.
+func formatUsageRecordsTabular(t *table, value interface{}) error {
+	records, ok := value.([]apiparams.UsageRecord)
+	if !ok {
+		return unexpectedType(records, value)
+	}
+	daily := len(records) > 0 && records[0].Date != ""
+	header := []interface{}{"Model", "Owner", "Controller", "Machine-hours", "Unit-hours"}
+	if daily {
+		header = append([]interface{}{"Date"}, header...)
+	}
+	t.AddHeader(header...)
+	for _, r := range records {
+		row := []interface{}{r.ModelName, r.Owner, r.Controller, formatHours(r.MachineHours), formatHours(r.UnitHours)}
+		if daily {
+			row = append([]interface{}{r.Date}, row...)
+		}
+		t.AddRow(row...)
+	}
+	return nil
+}
+
+// formatHours formats a number of hours to two decimal places.
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type usageSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&usageSuite{})
+
+func (s *usageSuite) TestUsageReport(c *gc.C) {
+	ctx := context.Background()
+
+	bClient := s.SetupCLIAccess(c, "alice")
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// Only model-1 has any machines or units.
+	err := s.JIMM.Database.DB.Exec("UPDATE models SET machines = 0, units = 0").Error
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.DB.Exec("UPDATE models SET machines = 1, units = 2 WHERE name = 'model-1'").Error
+	c.Assert(err, gc.IsNil)
+	from := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	err = s.JIMM.AccrueModelUsage(ctx, from, from.Add(4*time.Hour))
+	c.Assert(err, gc.IsNil)
+
+	context, err := cmdtesting.RunCommand(c, cmd.NewUsageReportCommandForTesting(s.ClientStore(), bClient), "--from", "2024-01-01", "--to", "2024-01-31", "--format", "tabular")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `Model +Owner +Controller +Machine-hours +Unit-hours
+model-1 +charlie@canonical.com +controller-\d +4.00 +8.00
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewUsageReportCommandForTesting(s.ClientStore(), bClient), "--from", "2024-01-02", "--to", "2024-01-02", "--daily")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `- date: "2024-01-02"
+  model-uuid: .*
+  model-name: model-1
+  owner: charlie@canonical.com
+  controller: controller-\d
+  machine-hours: 2
+  unit-hours: 4
+`)
+}
+
+func (s *usageSuite) TestUsageReportInvalidDates(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewUsageReportCommandForTesting(s.ClientStore(), bClient), "--from", "yesterday")
+	c.Check(err, gc.ErrorMatches, `invalid --from date, expected YYYY-MM-DD`)
+	_, err = cmdtesting.RunCommand(c, cmd.NewUsageReportCommandForTesting(s.ClientStore(), bClient), "--from", "2024-02-01", "--to", "2024-01-01")
+	c.Check(err, gc.ErrorMatches, `--to date is before --from date`)
+}
+
+func (s *usageSuite) TestUsageReportUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewUsageReportCommandForTesting(s.ClientStore(), bClient))
+	c.Check(err, gc.ErrorMatches, `unauthorized.*`)
+}
@@ -0,0 +1,497 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	cofga "github.com/canonical/ofga"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gosuri/uitable"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/names/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/vault"
+)
+
+var validateConfigCommandDoc = `
+validate-config command validates the configuration of a JIMM server
+before it is deployed. The configuration is read from one or more
+environment files, in the format used by systemd's EnvironmentFile, and
+later files override the values in earlier ones.
+
+Each section of the configuration (database, controller, OAuth, OpenFGA,
+Vault and listeners) is checked and, unless --offline is specified, the
+database, OAuth issuer, OpenFGA store and Vault server are contacted and
+the listener addresses are bound to check that they can be used. A
+report of every check is printed, and the command fails if any check
+fails.
+
+Example:
+	jimmctl validate-config jimm.env jimm-db.env jimm-oauth.env
+	jimmctl validate-config --offline --format tabular jimm.env
+`
+
+// Status values of a configuration check.
+const (
+	configCheckOK      = "ok"
+	configCheckWarning = "warning"
+	configCheckError   = "error"
+	configCheckSkipped = "skipped"
+)
+
+// configReport is the report printed by validate-config.
+type configReport struct {
+	Valid  bool          `json:"valid" yaml:"valid"`
+	Checks []configCheck `json:"checks" yaml:"checks"`
+}
+
+// configCheck is the result of a single configuration check.
+type configCheck struct {
+	Section string `json:"section" yaml:"section"`
+	Check   string `json:"check" yaml:"check"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NewValidateConfigCommand returns a command to validate a JIMM server
+// configuration.
+func NewValidateConfigCommand() cmd.Command {
+	return &validateConfigCommand{}
+}
+
+// validateConfigCommand validates a JIMM server configuration.
+type validateConfigCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	files   []string
+	offline bool
+	timeout time.Duration
+}
+
+// Info implements the cmd.Command interface.
+func (c *validateConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "validate-config",
+		Args:    "<filename> ...",
+		Purpose: "Validates a JIMM server configuration.",
+		Doc:     validateConfigCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *validateConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatConfigReportTabular,
+	})
+	f.BoolVar(&c.offline, "offline", false, "do not check connectivity to the configured services")
+	f.DurationVar(&c.timeout, "timeout", 10*time.Second, "timeout for each connectivity check")
+}
+
+// Init implements the cmd.Command interface.
+func (c *validateConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("filename not specified")
+	}
+	c.files = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *validateConfigCommand) Run(ctxt *cmd.Context) error {
+	env := make(map[string]string)
+	for _, filename := range c.files {
+		f, err := os.Open(ctxt.AbsPath(filename))
+		if err != nil {
+			return errors.E(err)
+		}
+		err = readEnvFile(f, env)
+		f.Close()
+		if err != nil {
+			return errors.E(err, fmt.Sprintf("cannot read %s: %s", filename, err))
+		}
+	}
+
+	v := configValidator{
+		env:     env,
+		live:    !c.offline,
+		timeout: c.timeout,
+	}
+	report := v.validate(ctxt)
+	if err := c.out.Write(ctxt, report); err != nil {
+		return errors.E(err)
+	}
+	if !report.Valid {
+		return errors.E("configuration is not valid")
+	}
+	return nil
+}
+
+// readEnvFile reads the variables in an environment file into env. Blank
+// lines and lines starting with # are ignored, values may optionally be
+// quoted.
+func readEnvFile(r io.Reader, env map[string]string) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return errors.E(fmt.Sprintf("line %d: expected KEY=VALUE", n))
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[key] = value
+	}
+	return scanner.Err()
+}
+
+// configValidator validates the configuration held in env.
+type configValidator struct {
+	env     map[string]string
+	live    bool
+	timeout time.Duration
+
+	report configReport
+}
+
+// validate checks every section of the configuration and returns the
+// report.
+func (v *configValidator) validate(ctx context.Context) configReport {
+	v.report = configReport{Valid: true}
+	v.validateDatabase(ctx)
+	v.validateController()
+	v.validateOAuth(ctx)
+	v.validateOpenFGA(ctx)
+	v.validateVault(ctx)
+	v.validateListeners()
+	return v.report
+}
+
+// add records the result of a check.
+func (v *configValidator) add(section, check, status, message string) {
+	if status == configCheckError {
+		v.report.Valid = false
+	}
+	v.report.Checks = append(v.report.Checks, configCheck{
+		Section: section,
+		Check:   check,
+		Status:  status,
+		Message: message,
+	})
+}
+
+// required checks that each of the given variables is set, returning
+// false if any are missing.
+func (v *configValidator) required(section string, keys ...string) bool {
+	ok := true
+	for _, k := range keys {
+		if v.env[k] == "" {
+			v.add(section, k, configCheckError, "not set")
+			ok = false
+		}
+	}
+	return ok
+}
+
+// liveCheck runs f, with a timeout, if live checks are enabled and
+// records its result.
+func (v *configValidator) liveCheck(ctx context.Context, section, check string, f func(context.Context) error) {
+	if !v.live {
+		v.add(section, check, configCheckSkipped, "offline")
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+	if err := f(ctx); err != nil {
+		v.add(section, check, configCheckError, err.Error())
+		return
+	}
+	v.add(section, check, configCheckOK, "")
+}
+
+func (v *configValidator) validateDatabase(ctx context.Context) {
+	const section = "database"
+	if !v.required(section, "JIMM_DSN") {
+		return
+	}
+	v.validateDSN(ctx, section, "JIMM_DSN", "connect")
+	if v.env["JIMM_READ_REPLICA_DSN"] != "" {
+		v.validateDSN(ctx, section, "JIMM_READ_REPLICA_DSN", "connect-read-replica")
+	}
+}
+
+// validateDSN checks that the DSN held in key is a postgres DSN and, if
+// live checks are enabled, that the database can be reached.
+func (v *configValidator) validateDSN(ctx context.Context, section, key, check string) {
+	dsn := v.env[key]
+	var dialect gorm.Dialector
+	switch {
+	case strings.HasPrefix(dsn, "pgx:"):
+		dialect = postgres.Open(strings.TrimPrefix(dsn, "pgx:"))
+	case strings.HasPrefix(dsn, "postgres:") || strings.HasPrefix(dsn, "postgresql:"):
+		dialect = postgres.Open(dsn)
+	default:
+		v.add(section, key, configCheckError, "unsupported DSN, must be a postgres DSN")
+		return
+	}
+	v.add(section, key, configCheckOK, "")
+	v.liveCheck(ctx, section, check, func(ctx context.Context) error {
+		gdb, err := gorm.Open(dialect, &gorm.Config{Logger: logger.Discard})
+		if err != nil {
+			return err
+		}
+		sqlDB, err := gdb.DB()
+		if err != nil {
+			return err
+		}
+		defer sqlDB.Close()
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+func (v *configValidator) validateController() {
+	const section = "controller"
+	if v.required(section, "JIMM_UUID") {
+		if names.IsValidController(v.env["JIMM_UUID"]) {
+			v.add(section, "JIMM_UUID", configCheckOK, "")
+		} else {
+			v.add(section, "JIMM_UUID", configCheckError, "not a valid controller UUID")
+		}
+	}
+	if len(strings.Fields(v.env["JIMM_ADMINS"])) == 0 {
+		v.add(section, "JIMM_ADMINS", configCheckWarning, "no administrators configured")
+	} else {
+		v.add(section, "JIMM_ADMINS", configCheckOK, "")
+	}
+	if v.env["JIMM_DNS_NAME"] == "" {
+		v.add(section, "JIMM_DNS_NAME", configCheckWarning, "not set, JWTs will not have a valid issuer")
+	} else {
+		v.add(section, "JIMM_DNS_NAME", configCheckOK, "")
+	}
+}
+
+func (v *configValidator) validateOAuth(ctx context.Context) {
+	const section = "oauth"
+	if v.required(section, "JIMM_OAUTH_CLIENT_ID", "JIMM_OAUTH_CLIENT_SECRET") {
+		v.add(section, "client", configCheckOK, "")
+	}
+	if len(strings.Fields(v.env["JIMM_OAUTH_SCOPES"])) == 0 {
+		v.add(section, "JIMM_OAUTH_SCOPES", configCheckError, "no scopes configured")
+	} else {
+		v.add(section, "JIMM_OAUTH_SCOPES", configCheckOK, "")
+	}
+	if n, err := strconv.Atoi(v.env["JIMM_SESSION_COOKIE_MAX_AGE"]); err != nil || n < 0 {
+		v.add(section, "JIMM_SESSION_COOKIE_MAX_AGE", configCheckError, "must be a non-negative integer")
+	} else {
+		v.add(section, "JIMM_SESSION_COOKIE_MAX_AGE", configCheckOK, "")
+	}
+	if len(v.env["JIMM_SESSION_SECRET_KEY"]) < 64 {
+		v.add(section, "JIMM_SESSION_SECRET_KEY", configCheckError, "must be at least 64 characters")
+	} else {
+		v.add(section, "JIMM_SESSION_SECRET_KEY", configCheckOK, "")
+	}
+	if v.env["JIMM_OAUTH_ADDITIONAL_ISSUERS"] != "" {
+		if _, err := auth.ParseIssuerParams(v.env["JIMM_OAUTH_ADDITIONAL_ISSUERS"]); err != nil {
+			v.add(section, "JIMM_OAUTH_ADDITIONAL_ISSUERS", configCheckError, err.Error())
+		} else {
+			v.add(section, "JIMM_OAUTH_ADDITIONAL_ISSUERS", configCheckOK, "")
+		}
+	}
+
+	if !v.required(section, "JIMM_OAUTH_ISSUER_URL") {
+		return
+	}
+	issuer := v.env["JIMM_OAUTH_ISSUER_URL"]
+	u, err := url.Parse(issuer)
+	switch {
+	case err != nil:
+		v.add(section, "JIMM_OAUTH_ISSUER_URL", configCheckError, err.Error())
+		return
+	case u.Scheme == "" || u.Host == "":
+		v.add(section, "JIMM_OAUTH_ISSUER_URL", configCheckError, "must be an absolute URL")
+		return
+	case u.Scheme != "https":
+		v.add(section, "JIMM_OAUTH_ISSUER_URL", configCheckWarning, "issuer is not using https")
+	default:
+		v.add(section, "JIMM_OAUTH_ISSUER_URL", configCheckOK, "")
+	}
+	v.liveCheck(ctx, section, "discovery", func(ctx context.Context) error {
+		_, err := oidc.NewProvider(ctx, issuer)
+		return err
+	})
+}
+
+func (v *configValidator) validateOpenFGA(ctx context.Context) {
+	const section = "openfga"
+	if !v.required(section, "OPENFGA_SCHEME", "OPENFGA_HOST", "OPENFGA_PORT", "OPENFGA_STORE", "OPENFGA_AUTH_MODEL", "OPENFGA_TOKEN") {
+		return
+	}
+	ok := true
+	if s := v.env["OPENFGA_SCHEME"]; s != "http" && s != "https" {
+		v.add(section, "OPENFGA_SCHEME", configCheckError, "must be http or https")
+		ok = false
+	}
+	if port, err := strconv.ParseUint(v.env["OPENFGA_PORT"], 10, 16); err != nil || port == 0 {
+		v.add(section, "OPENFGA_PORT", configCheckError, "not a valid port")
+		ok = false
+	}
+	if !ok {
+		return
+	}
+	v.add(section, "parameters", configCheckOK, "")
+	v.liveCheck(ctx, section, "store", func(ctx context.Context) error {
+		// Creating the client checks that the store and
+		// authorisation model exist.
+		_, err := cofga.NewClient(ctx, cofga.OpenFGAParams{
+			Scheme:      v.env["OPENFGA_SCHEME"],
+			Host:        v.env["OPENFGA_HOST"],
+			Port:        v.env["OPENFGA_PORT"],
+			Token:       v.env["OPENFGA_TOKEN"],
+			StoreID:     v.env["OPENFGA_STORE"],
+			AuthModelID: v.env["OPENFGA_AUTH_MODEL"],
+		})
+		return err
+	})
+}
+
+func (v *configValidator) validateVault(ctx context.Context) {
+	const section = "vault"
+	if _, ok := v.env["INSECURE_SECRET_STORAGE"]; ok {
+		v.add(section, "INSECURE_SECRET_STORAGE", configCheckWarning, "secrets are stored in plaintext in the database")
+		return
+	}
+	if !v.required(section, "VAULT_ADDR", "VAULT_PATH", "VAULT_ROLE_ID", "VAULT_ROLE_SECRET_ID") {
+		return
+	}
+	if u, err := url.Parse(v.env["VAULT_ADDR"]); err != nil || u.Scheme == "" || u.Host == "" {
+		v.add(section, "VAULT_ADDR", configCheckError, "must be an absolute URL")
+		return
+	}
+	kvPath := strings.ReplaceAll(v.env["VAULT_PATH"], "/", "")
+	if kvPath == "" {
+		v.add(section, "VAULT_PATH", configCheckError, "must name a KV secrets engine")
+		return
+	}
+	v.add(section, "parameters", configCheckOK, "")
+	v.liveCheck(ctx, section, "login", func(ctx context.Context) error {
+		cfg := vaultapi.DefaultConfig()
+		cfg.Address = v.env["VAULT_ADDR"]
+		client, err := vaultapi.NewClient(cfg)
+		if err != nil {
+			return err
+		}
+		store := vault.VaultStore{
+			Client:       client,
+			RoleID:       v.env["VAULT_ROLE_ID"],
+			RoleSecretID: v.env["VAULT_ROLE_SECRET_ID"],
+			KVPath:       kvPath,
+		}
+		// Reading the JWKS expiry logs in and reads from the KV
+		// path, it is not an error for it not to exist yet.
+		_, err = store.GetJWKSExpiry(ctx)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+func (v *configValidator) validateListeners() {
+	const section = "listeners"
+	addr := v.env["JIMM_LISTEN_ADDR"]
+	if addr == "" {
+		addr = ":http-alt"
+	}
+	v.validateListener(section, "JIMM_LISTEN_ADDR", addr)
+	if adminAddr := v.env["JIMM_ADMIN_LISTEN_ADDR"]; adminAddr == addr {
+		v.add(section, "JIMM_ADMIN_LISTEN_ADDR", configCheckError, "must be different to JIMM_LISTEN_ADDR")
+	} else if adminAddr != "" {
+		v.validateListener(section, "JIMM_ADMIN_LISTEN_ADDR", adminAddr)
+	}
+	grpcAddr := v.env["JIMM_ADMIN_GRPC_LISTEN_ADDR"]
+	if grpcAddr == "" {
+		return
+	}
+	if grpcAddr == addr || grpcAddr == v.env["JIMM_ADMIN_LISTEN_ADDR"] {
+		v.add(section, "JIMM_ADMIN_GRPC_LISTEN_ADDR", configCheckError, "must be different to JIMM_LISTEN_ADDR and JIMM_ADMIN_LISTEN_ADDR")
+		return
+	}
+	if !v.required(section, "JIMM_TLS_CERT_FILE", "JIMM_TLS_KEY_FILE", "JIMM_ADMIN_GRPC_CLIENT_CA_FILE") {
+		return
+	}
+	v.validateListener(section, "JIMM_ADMIN_GRPC_LISTEN_ADDR", grpcAddr)
+}
+
+// validateListener checks that addr is a valid listen address and, if
+// live checks are enabled, that it can be bound.
+func (v *configValidator) validateListener(section, key, addr string) {
+	_, port, err := net.SplitHostPort(addr)
+	if err == nil {
+		_, err = net.LookupPort("tcp", port)
+	}
+	if err != nil {
+		v.add(section, key, configCheckError, err.Error())
+		return
+	}
+	if !v.live {
+		v.add(section, key, configCheckOK, "")
+		return
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		v.add(section, key, configCheckError, err.Error())
+		return
+	}
+	l.Close()
+	v.add(section, key, configCheckOK, "")
+}
+
+// formatConfigReportTabular writes a configuration report as a table.
+func formatConfigReportTabular(writer io.Writer, value interface{}) error {
+	r, ok := value.(configReport)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", r, value))
+	}
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.Wrap = true
+	table.AddRow("SECTION", "CHECK", "STATUS", "MESSAGE")
+	for _, c := range r.Checks {
+		table.AddRow(c.Section, c.Check, c.Status, c.Message)
+	}
+	fmt.Fprintln(writer, table)
+	if r.Valid {
+		fmt.Fprintln(writer, "configuration is valid")
+	} else {
+		fmt.Fprintln(writer, "configuration is not valid")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+)
+
+type validateConfigSuite struct{}
+
+var _ = gc.Suite(&validateConfigSuite{})
+
+const validConfig = `
+# JIMM configuration.
+JIMM_DSN=postgresql://jimm:jimm@db/jimm
+JIMM_UUID=3217dbc9-8ea9-4381-9e97-01eab0b3f6bb
+JIMM_ADMINS="jimm-test@canonical.com"
+JIMM_DNS_NAME=jimm.localhost
+JIMM_LISTEN_ADDR=0.0.0.0:80
+JIMM_OAUTH_ISSUER_URL=https://keycloak.localhost/realms/jimm
+JIMM_OAUTH_CLIENT_ID=jimm-device
+JIMM_OAUTH_CLIENT_SECRET=SwjDofnbDzJDm9iyfUhEp67FfUFMY8L4
+JIMM_OAUTH_SCOPES='openid profile email'
+JIMM_SESSION_COOKIE_MAX_AGE=86400
+JIMM_SESSION_SECRET_KEY=Xz2RkR9g87M75xfoumhEs5OmGziIX8D88Rk5YW8FSvkBPSgeK9t5AS9IvPDJ3NnB
+OPENFGA_SCHEME=http
+OPENFGA_HOST=openfga
+OPENFGA_PORT=8080
+OPENFGA_STORE=01GP1254CHWJC1MNGVB0WDG1T0
+OPENFGA_AUTH_MODEL=01GP1EC038KHGB6JJ2XXXXCXKB
+OPENFGA_TOKEN=jimm
+export VAULT_ADDR=http://vault:8200
+VAULT_PATH=/jimm-kv/
+VAULT_ROLE_ID=test-role-id
+VAULT_ROLE_SECRET_ID=test-secret-id
+`
+
+func (s *validateConfigSuite) writeConfig(c *gc.C, config string) string {
+	filename := filepath.Join(c.MkDir(), "jimm.env")
+	err := os.WriteFile(filename, []byte(config), 0600)
+	c.Assert(err, gc.IsNil)
+	return filename
+}
+
+func (s *validateConfigSuite) TestValidateConfigOffline(c *gc.C) {
+	filename := s.writeConfig(c, validConfig)
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewValidateConfigCommand(), "--offline", filename)
+	c.Assert(err, gc.IsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Check(out, gc.Matches, `(?s)valid: true\nchecks:\n.*`)
+	c.Check(strings.Contains(out, "status: error"), gc.Equals, false)
+	c.Check(out, gc.Matches, `(?s).*- section: openfga\n  check: store\n  status: skipped\n  message: offline\n.*`)
+}
+
+func (s *validateConfigSuite) TestValidateConfigInvalid(c *gc.C) {
+	filename := s.writeConfig(c, validConfig)
+	override := s.writeConfig(c, `
+JIMM_DSN=mysql://jimm@db/jimm
+JIMM_READ_REPLICA_DSN=sqlite://replica
+JIMM_UUID=not-a-uuid
+OPENFGA_PORT=http
+VAULT_ROLE_ID=
+JIMM_SESSION_SECRET_KEY=short
+JIMM_ADMIN_LISTEN_ADDR=0.0.0.0:80
+JIMM_ADMIN_GRPC_LISTEN_ADDR=0.0.0.0:9443
+`)
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewValidateConfigCommand(), "--offline", "--format", "json", filename, override)
+	c.Assert(err, gc.ErrorMatches, `configuration is not valid`)
+	out := cmdtesting.Stdout(ctx)
+	c.Check(out, gc.Matches, `(?s)\{"valid":false,.*`)
+	for _, expect := range []string{
+		`{"section":"database","check":"JIMM_DSN","status":"error","message":"unsupported DSN, must be a postgres DSN"}`,
+		`{"section":"database","check":"JIMM_READ_REPLICA_DSN","status":"error","message":"unsupported DSN, must be a postgres DSN"}`,
+		`{"section":"controller","check":"JIMM_UUID","status":"error","message":"not a valid controller UUID"}`,
+		`{"section":"oauth","check":"JIMM_SESSION_SECRET_KEY","status":"error","message":"must be at least 64 characters"}`,
+		`{"section":"openfga","check":"OPENFGA_PORT","status":"error","message":"not a valid port"}`,
+		`{"section":"vault","check":"VAULT_ROLE_ID","status":"error","message":"not set"}`,
+		`{"section":"listeners","check":"JIMM_ADMIN_LISTEN_ADDR","status":"error","message":"must be different to JIMM_LISTEN_ADDR"}`,
+		`{"section":"listeners","check":"JIMM_ADMIN_GRPC_CLIENT_CA_FILE","status":"error","message":"not set"}`,
+	} {
+		c.Check(strings.Contains(out, expect), gc.Equals, true, gc.Commentf("missing %s", expect))
+	}
+}
+
+func (s *validateConfigSuite) TestValidateConfigBadFile(c *gc.C) {
+	filename := s.writeConfig(c, "JIMM_DSN\n")
+	_, err := cmdtesting.RunCommand(c, cmd.NewValidateConfigCommand(), filename)
+	c.Assert(err, gc.ErrorMatches, `cannot read .*: line 1: expected KEY=VALUE`)
+}
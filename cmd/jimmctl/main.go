@@ -27,17 +27,51 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 	jimmcmd.Register(cmd.NewImportModelCommand())
 	jimmcmd.Register(cmd.NewListAuditEventsCommand())
 	jimmcmd.Register(cmd.NewListControllersCommand())
+	jimmcmd.Register(cmd.NewListCredentialsCommand())
+	jimmcmd.Register(cmd.NewListModelsCommand())
 	jimmcmd.Register(cmd.NewModelStatusCommand())
 	jimmcmd.Register(cmd.NewRemoveControllerCommand())
+	jimmcmd.Register(cmd.NewRemoveUserCommand())
 	jimmcmd.Register(cmd.NewRevokeAuditLogAccessCommand())
 	jimmcmd.Register(cmd.NewSetControllerDeprecatedCommand())
+	jimmcmd.Register(cmd.NewControllerCommand())
 	jimmcmd.Register(cmd.NewUpdateMigratedModelCommand())
 	jimmcmd.Register(cmd.NewAddCloudToControllerCommand())
 	jimmcmd.Register(cmd.NewRemoveCloudFromControllerCommand())
+	jimmcmd.Register(cmd.NewCloudRegionCommand())
+	jimmcmd.Register(cmd.NewControllerAccessCommand())
+	jimmcmd.Register(cmd.NewSessionsCommand())
+	jimmcmd.Register(cmd.NewUsageCommand())
 	jimmcmd.Register(cmd.NewAuthCommand())
 	jimmcmd.Register(cmd.NewCrossModelQueryCommand())
 	jimmcmd.Register(cmd.NewPurgeLogsCommand())
+	jimmcmd.Register(cmd.NewRotateSessionKeyCommand())
 	jimmcmd.Register(cmd.NewMigrateModelCommand())
+	jimmcmd.Register(cmd.NewMigrateControllerCredentialsCommand())
+	jimmcmd.Register(cmd.NewSetServiceAccountAllowedCIDRsCommand())
+	jimmcmd.Register(cmd.NewServiceAccountCommand())
+	jimmcmd.Register(cmd.NewTransferModelCommand())
+	jimmcmd.Register(cmd.NewSetModelLabelsCommand())
+	jimmcmd.Register(cmd.NewLimitsCommand())
+	jimmcmd.Register(cmd.NewQuotaCommand())
+	jimmcmd.Register(cmd.NewTaskCommand())
+	jimmcmd.Register(cmd.NewModelArchiveCommand())
+	jimmcmd.Register(cmd.NewModelConfigPoliciesCommand())
+	jimmcmd.Register(cmd.NewMaintenanceCommand())
+	jimmcmd.Register(cmd.NewNotificationsCommand())
+	jimmcmd.Register(cmd.NewMaintenanceWindowsCommand())
+	jimmcmd.Register(cmd.NewTemplatesCommand())
+	jimmcmd.Register(cmd.NewAliasesCommand())
+	jimmcmd.Register(cmd.NewDeprecatedFacadeUsageCommand())
+	jimmcmd.Register(cmd.NewBackupCommand())
+	jimmcmd.Register(cmd.NewRestoreCommand())
+	jimmcmd.Register(cmd.NewLoadTestCommand())
+	jimmcmd.Register(cmd.NewValidateConfigCommand())
+
+	// Developer commands are hidden unless explicitly enabled.
+	if os.Getenv(cmd.DeveloperCommandsEnvVar) != "" {
+		jimmcmd.Register(cmd.NewGenerateEstateCommand())
+	}
 	return jimmcmd
 }
 
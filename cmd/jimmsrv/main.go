@@ -4,7 +4,10 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"os"
 	"strconv"
@@ -15,9 +18,21 @@ import (
 	service "github.com/canonical/go-service"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	jimmsvc "github.com/canonical/jimm/v3/cmd/jimmsrv/service"
+	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/fips"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	"github.com/canonical/jimm/v3/internal/jujuapi"
+	"github.com/canonical/jimm/v3/internal/jujuclient"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/rpc"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 	"github.com/canonical/jimm/v3/version"
 )
 
@@ -52,6 +67,12 @@ func start(ctx context.Context, s *service.Service) error {
 	if addr == "" {
 		addr = ":http-alt"
 	}
+	// If an admin listen address is configured the administrative
+	// endpoints are only served on that address.
+	adminAddr := os.Getenv("JIMM_ADMIN_LISTEN_ADDR")
+	// If a gRPC admin listen address is configured the gRPC admin API is
+	// served on that address, authenticated with client certificates.
+	adminGRPCAddr := os.Getenv("JIMM_ADMIN_GRPC_LISTEN_ADDR")
 	macaroonExpiryDuration := 24 * time.Hour
 	durationString := os.Getenv("JIMM_MACAROON_EXPIRY_DURATION")
 	if durationString != "" {
@@ -83,6 +104,17 @@ func start(ctx context.Context, s *service.Service) error {
 		sessionTokenExpiryDuration = expiry
 	}
 
+	var auditLogRetentionPeriod time.Duration
+	durationString = os.Getenv("JIMM_AUDIT_LOG_RETENTION_PERIOD")
+	if durationString != "" {
+		period, err := time.ParseDuration(durationString)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse audit log retention period", zap.Error(err))
+			return err
+		}
+		auditLogRetentionPeriod = period
+	}
+
 	issuerURL := os.Getenv("JIMM_OAUTH_ISSUER_URL")
 	parsedIssuerURL, err := url.Parse(issuerURL)
 	if err != nil {
@@ -118,6 +150,12 @@ func start(ctx context.Context, s *service.Service) error {
 		return errors.E("no oauth client scopes present")
 	}
 
+	additionalIssuers, err := auth.ParseIssuerParams(os.Getenv("JIMM_OAUTH_ADDITIONAL_ISSUERS"))
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse additional oauth issuers", zap.Error(err))
+		return err
+	}
+
 	insecureSecretStorage := false
 	if _, ok := os.LookupEnv("INSECURE_SECRET_STORAGE"); ok {
 		insecureSecretStorage = true
@@ -142,13 +180,300 @@ func start(ctx context.Context, s *service.Service) error {
 		return errors.E("jimm session store secret must be at least 64 characters")
 	}
 
+	// Previous session keys are still accepted when verifying sessions,
+	// allowing JIMM_SESSION_SECRET_KEY to be rotated without logging out
+	// every user. Once the session token key has been rotated with
+	// jimmctl rotate-session-key the stored keys are used for session
+	// tokens instead, browser session cookies keep using these keys.
+	previousSessionSecretKeys := strings.Fields(os.Getenv("JIMM_SESSION_PREVIOUS_SECRET_KEYS"))
+	previousCookieSessionKeys := make([][]byte, len(previousSessionSecretKeys))
+	for i, k := range previousSessionSecretKeys {
+		previousCookieSessionKeys[i] = []byte(k)
+	}
+
 	corsAllowedOrigins := strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), " ")
 
 	logSQL, _ := strconv.ParseBool(os.Getenv("JIMM_LOG_SQL"))
 
+	var requireReason bool
+	if v := os.Getenv("JIMM_REQUIRE_PRIVILEGED_OPERATION_REASON"); v != "" {
+		requireReason, err = strconv.ParseBool(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse require privileged operation reason", zap.Error(err))
+			return err
+		}
+	}
+
+	legacyJEMAPI, _ := strconv.ParseBool(os.Getenv("JIMM_LEGACY_JEM_API"))
+
+	var hstsMaxAge time.Duration
+	if v := os.Getenv("JIMM_HSTS_MAX_AGE"); v != "" {
+		hstsMaxAge, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse HSTS max age", zap.Error(err))
+			return err
+		}
+	}
+
+	modelSummaryDebounce := time.Second
+	if v := os.Getenv("JIMM_MODEL_SUMMARY_DEBOUNCE"); v != "" {
+		modelSummaryDebounce, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse model summary debounce", zap.Error(err))
+			return err
+		}
+	}
+
+	tracingParams := tracing.Params{
+		Endpoint: os.Getenv("JIMM_TRACING_ENDPOINT"),
+	}
+	tracingParams.Insecure, _ = strconv.ParseBool(os.Getenv("JIMM_TRACING_INSECURE"))
+	if v := os.Getenv("JIMM_TRACING_SAMPLE_RATIO"); v != "" {
+		tracingParams.SampleRatio, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse tracing sample ratio", zap.Error(err))
+			return err
+		}
+	}
+
+	var loginThrottle jimm.LoginThrottleParams
+	if v := os.Getenv("JIMM_LOGIN_MAX_FAILURES"); v != "" {
+		loginThrottle.MaxFailures, err = strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse jimm login max failures")
+		}
+		loginThrottle.Window = 15 * time.Minute
+		if v := os.Getenv("JIMM_LOGIN_FAILURE_WINDOW"); v != "" {
+			loginThrottle.Window, err = time.ParseDuration(v)
+			if err != nil {
+				zapctx.Error(ctx, "failed to parse login failure window", zap.Error(err))
+				return err
+			}
+		}
+		loginThrottle.LockoutDuration = 15 * time.Minute
+		if v := os.Getenv("JIMM_LOGIN_LOCKOUT_DURATION"); v != "" {
+			loginThrottle.LockoutDuration, err = time.ParseDuration(v)
+			if err != nil {
+				zapctx.Error(ctx, "failed to parse login lockout duration", zap.Error(err))
+				return err
+			}
+		}
+		// Failures of an identity from any client and on any replica
+		// are counted in the database. The limit is higher than the
+		// limit for a single client so that one client cannot easily
+		// lock out every other client of the identity.
+		loginThrottle.MaxIdentityFailures = 10 * loginThrottle.MaxFailures
+		if v := os.Getenv("JIMM_LOGIN_MAX_IDENTITY_FAILURES"); v != "" {
+			loginThrottle.MaxIdentityFailures, err = strconv.Atoi(v)
+			if err != nil {
+				zapctx.Error(ctx, "failed to parse login max identity failures", zap.Error(err))
+				return err
+			}
+		}
+	}
+
+	var webhookAllowedNetworks []netip.Prefix
+	for _, v := range strings.Fields(os.Getenv("JIMM_WEBHOOK_ALLOWED_NETWORKS")) {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse webhook allowed network", zap.Error(err))
+			return err
+		}
+		webhookAllowedNetworks = append(webhookAllowedNetworks, prefix)
+	}
+
+	var trustedProxies []netip.Prefix
+	for _, v := range strings.Fields(os.Getenv("JIMM_TRUSTED_PROXIES")) {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse trusted proxy", zap.Error(err))
+			return err
+		}
+		trustedProxies = append(trustedProxies, prefix)
+	}
+
+	var fipsMode bool
+	if v := os.Getenv("JIMM_FIPS_MODE"); v != "" {
+		fipsMode, err = strconv.ParseBool(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse FIPS mode", zap.Error(err))
+			return err
+		}
+	}
+
+	controllerAffinities, err := jimm.ParseControllerAffinities(os.Getenv("JIMM_CONTROLLER_AFFINITIES"))
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse controller affinities", zap.Error(err))
+		return err
+	}
+
+	var reconcileInterval time.Duration
+	if v := os.Getenv("JIMM_RECONCILE_INTERVAL"); v != "" {
+		reconcileInterval, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse reconcile interval", zap.Error(err))
+			return err
+		}
+	}
+	reconcileRepair, _ := strconv.ParseBool(os.Getenv("JIMM_RECONCILE_REPAIR"))
+	validateCloudCredentials, _ := strconv.ParseBool(os.Getenv("JIMM_VALIDATE_CLOUD_CREDENTIALS"))
+	archiveDestroyedModels, _ := strconv.ParseBool(os.Getenv("JIMM_ARCHIVE_DESTROYED_MODELS"))
+
+	var identityProfileSyncInterval time.Duration
+	if v := os.Getenv("JIMM_IDENTITY_PROFILE_SYNC_INTERVAL"); v != "" {
+		identityProfileSyncInterval, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse identity profile sync interval", zap.Error(err))
+			return err
+		}
+	}
+
+	var controllerPasswordRotationInterval time.Duration
+	if v := os.Getenv("JIMM_CONTROLLER_PASSWORD_ROTATION_INTERVAL"); v != "" {
+		controllerPasswordRotationInterval, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse controller password rotation interval", zap.Error(err))
+			return err
+		}
+	}
+
+	var credentialExpiryWarning time.Duration
+	if v := os.Getenv("JIMM_CREDENTIAL_EXPIRY_WARNING"); v != "" {
+		credentialExpiryWarning, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse credential expiry warning", zap.Error(err))
+			return err
+		}
+	}
+
+	var migrationConcurrency int
+	if v := os.Getenv("JIMM_MIGRATION_CONCURRENCY"); v != "" {
+		migrationConcurrency, err = strconv.Atoi(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse migration concurrency", zap.Error(err))
+			return err
+		}
+	}
+
+	var workerLeaseDuration time.Duration
+	if v := os.Getenv("JIMM_WORKER_LEASE_DURATION"); v != "" {
+		workerLeaseDuration, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse worker lease duration", zap.Error(err))
+			return err
+		}
+	}
+
+	controllerEndpointWeights, err := rpc.ParseEndpointWeights(os.Getenv("JIMM_CONTROLLER_ENDPOINT_WEIGHTS"))
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse controller endpoint weights", zap.Error(err))
+		return err
+	}
+	var controllerDialStagger time.Duration
+	if v := os.Getenv("JIMM_CONTROLLER_DIAL_STAGGER"); v != "" {
+		controllerDialStagger, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse controller dial stagger", zap.Error(err))
+			return err
+		}
+	}
+
+	var maxConcurrentControllerDials int
+	if v := os.Getenv("JIMM_MAX_CONCURRENT_CONTROLLER_DIALS"); v != "" {
+		maxConcurrentControllerDials, err = strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse jimm max concurrent controller dials")
+		}
+	}
+
+	var rateLimit jujuapi.RateLimitParams
+	if v := os.Getenv("JIMM_RATE_LIMIT_USER_RPS"); v != "" {
+		rateLimit.UserRate, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return errors.E("unable to parse jimm rate limit user rps")
+		}
+	}
+	if v := os.Getenv("JIMM_RATE_LIMIT_USER_BURST"); v != "" {
+		rateLimit.UserBurst, err = strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse jimm rate limit user burst")
+		}
+	}
+	if v := os.Getenv("JIMM_RATE_LIMIT_USER_CONCURRENCY"); v != "" {
+		rateLimit.UserConcurrency, err = strconv.Atoi(v)
+		if err != nil {
+			return errors.E("unable to parse jimm rate limit user concurrency")
+		}
+	}
+	rateLimit.FacadeRates, err = jujuapi.ParseFacadeRates(os.Getenv("JIMM_RATE_LIMIT_FACADES"))
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse facade rate limits", zap.Error(err))
+		return err
+	}
+
+	var charmHubCacheTTL time.Duration
+	if v := os.Getenv("JIMM_CHARMHUB_CACHE_TTL"); v != "" {
+		charmHubCacheTTL, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse charmhub cache TTL", zap.Error(err))
+			return err
+		}
+	}
+	var defaultRegionPriority uint64
+	if v := os.Getenv("JIMM_DEFAULT_REGION_PRIORITY"); v != "" {
+		defaultRegionPriority, err = strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse default region priority", zap.Error(err))
+			return err
+		}
+	}
+	var charmHubCacheSize int
+	if v := os.Getenv("JIMM_CHARMHUB_CACHE_SIZE"); v != "" {
+		charmHubCacheSize, err = strconv.Atoi(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse charmhub cache size", zap.Error(err))
+			return err
+		}
+	}
+
+	// Chaos mode is only intended for staging deployments, so it must
+	// be explicitly requested before any of its settings are read.
+	var controllerChaos jujuclient.ChaosParams
+	switch mode := os.Getenv("JIMM_CHAOS_MODE"); mode {
+	case "":
+	case "staging":
+		controllerChaos, err = parseChaosParams()
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse chaos parameters", zap.Error(err))
+			return err
+		}
+	default:
+		err := errors.E(fmt.Sprintf("invalid chaos mode %q, chaos mode can only be enabled for staging", mode))
+		zapctx.Error(ctx, "failed to parse chaos mode", zap.Error(err))
+		return err
+	}
+
+	tlsParams := jimmhttp.TLSParams{
+		CertFile:     os.Getenv("JIMM_TLS_CERT_FILE"),
+		KeyFile:      os.Getenv("JIMM_TLS_KEY_FILE"),
+		MinVersion:   os.Getenv("JIMM_TLS_MIN_VERSION"),
+		CipherSuites: strings.Fields(os.Getenv("JIMM_TLS_CIPHER_SUITES")),
+		FIPS:         fips.Enabled(fipsMode),
+	}
+	if v := os.Getenv("JIMM_TLS_RELOAD_INTERVAL"); v != "" {
+		tlsParams.ReloadInterval, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse TLS reload interval", zap.Error(err))
+			return err
+		}
+	} else {
+		tlsParams.ReloadInterval = time.Minute
+	}
+
 	jimmsvc, err := jimmsvc.NewService(ctx, jimmsvc.Params{
 		ControllerUUID:    os.Getenv("JIMM_UUID"),
 		DSN:               os.Getenv("JIMM_DSN"),
+		ReadReplicaDSN:    os.Getenv("JIMM_READ_REPLICA_DSN"),
 		ControllerAdmins:  strings.Fields(os.Getenv("JIMM_ADMINS")),
 		VaultRoleID:       os.Getenv("VAULT_ROLE_ID"),
 		VaultRoleSecretID: os.Getenv("VAULT_ROLE_SECRET_ID"),
@@ -167,48 +492,110 @@ func start(ctx context.Context, s *service.Service) error {
 		PrivateKey:                    os.Getenv("BAKERY_PRIVATE_KEY"),
 		PublicKey:                     os.Getenv("BAKERY_PUBLIC_KEY"),
 		AuditLogRetentionPeriodInDays: os.Getenv("JIMM_AUDIT_LOG_RETENTION_PERIOD_IN_DAYS"),
+		AuditLogRetentionPeriod:       auditLogRetentionPeriod,
 		MacaroonExpiryDuration:        macaroonExpiryDuration,
 		JWTExpiryDuration:             jwtExpiryDuration,
 		InsecureSecretStorage:         insecureSecretStorage,
+		ControllerSecretStore:         os.Getenv("JIMM_CONTROLLER_SECRET_STORE"),
+		KubernetesSecretNamespace:     os.Getenv("JIMM_KUBERNETES_SECRET_NAMESPACE"),
 		OAuthAuthenticatorParams: jimmsvc.OAuthAuthenticatorParams{
-			IssuerURL:            issuerURL,
-			ClientID:             clientID,
-			ClientSecret:         clientSecret,
-			Scopes:               scopesParsed,
-			SessionTokenExpiry:   sessionTokenExpiryDuration,
-			SessionCookieMaxAge:  sessionCookieMaxAgeInt,
-			JWTSessionKey:        sessionSecretKey,
-			SecureSessionCookies: secureSessionCookies,
+			IssuerURL:              issuerURL,
+			ClientID:               clientID,
+			ClientSecret:           clientSecret,
+			Scopes:                 scopesParsed,
+			AdditionalIssuers:      additionalIssuers,
+			SessionTokenExpiry:     sessionTokenExpiryDuration,
+			SessionCookieMaxAge:    sessionCookieMaxAgeInt,
+			JWTSessionKey:          sessionSecretKey,
+			PreviousJWTSessionKeys: previousSessionSecretKeys,
+			SecureSessionCookies:   secureSessionCookies,
 		},
 		DashboardFinalRedirectURL: os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
 		CookieSessionKey:          []byte(sessionSecretKey),
+		PreviousCookieSessionKeys: previousCookieSessionKeys,
 		CorsAllowedOrigins:        corsAllowedOrigins,
+		TrustedProxies:            trustedProxies,
 		LogSQL:                    logSQL,
+
+		RequireReasonForPrivilegedOperations: requireReason,
+		HSTSMaxAge:                           hstsMaxAge,
+		LoginThrottle:                        loginThrottle,
+		ValidateCloudCredentials:             validateCloudCredentials,
+		ArchiveDestroyedModels:               archiveDestroyedModels,
+		DefaultRegionPriority:                uint(defaultRegionPriority),
+		Tracing:                              tracingParams,
+		SeparateAdminHandler:                 adminAddr != "",
+		FIPSMode:                             fipsMode,
+		ModelSummaryDebounce:                 modelSummaryDebounce,
+		ControllerSelector:                   os.Getenv("JIMM_CONTROLLER_SELECTOR"),
+		ControllerAffinities:                 controllerAffinities,
+		ReconcileInterval:                    reconcileInterval,
+		ReconcileRepair:                      reconcileRepair,
+		IdentityProfileSyncInterval:          identityProfileSyncInterval,
+		ControllerPasswordRotationInterval:   controllerPasswordRotationInterval,
+		CredentialExpiryWarning:              credentialExpiryWarning,
+		MigrationConcurrency:                 migrationConcurrency,
+		WorkerLeaseDuration:                  workerLeaseDuration,
+		ReplicaID:                            os.Getenv("JIMM_REPLICA_ID"),
+		SMTP: notify.SMTPTransport{
+			Addr:     os.Getenv("JIMM_SMTP_ADDR"),
+			From:     os.Getenv("JIMM_SMTP_FROM"),
+			Username: os.Getenv("JIMM_SMTP_USERNAME"),
+			Password: os.Getenv("JIMM_SMTP_PASSWORD"),
+		},
+		NotificationSecret:     os.Getenv("JIMM_NOTIFICATION_SECRET"),
+		WebhookAllowedNetworks: webhookAllowedNetworks,
+		LifecycleWebhooks:      strings.Fields(os.Getenv("JIMM_LIFECYCLE_WEBHOOKS")),
+		LifecycleWebhookSecret: os.Getenv("JIMM_LIFECYCLE_WEBHOOK_SECRET"),
+		LegacyJEMAPI:           legacyJEMAPI,
+
+		ControllerEndpointWeights:    controllerEndpointWeights,
+		ControllerDialStagger:        controllerDialStagger,
+		MaxConcurrentControllerDials: maxConcurrentControllerDials,
+		RateLimit:                    rateLimit,
+		ControllerChaos:              controllerChaos,
+		CharmHubCacheTTL:             charmHubCacheTTL,
+		CharmHubCacheSize:            charmHubCacheSize,
+		Branding: apiparams.Branding{
+			OrganisationName: os.Getenv("JIMM_BRANDING_ORGANISATION_NAME"),
+			LogoURL:          os.Getenv("JIMM_BRANDING_LOGO_URL"),
+			MessageOfTheDay:  os.Getenv("JIMM_MESSAGE_OF_THE_DAY"),
+		},
 	})
 	if err != nil {
 		return err
 	}
 
-	isLeader := os.Getenv("JIMM_IS_LEADER") != ""
+	// With worker leases every replica competes to run the leader-only
+	// workers, otherwise they only run on the replica marked as leader.
+	isLeader := os.Getenv("JIMM_IS_LEADER") != "" || workerLeaseDuration > 0
 	if isLeader {
-		s.Go(func() error { return jimmsvc.WatchControllers(ctx) }) // Deletes dead/dying models, updates model config.
+		s.Go(func() error { return jimmsvc.RunWorker(ctx, "controller-watcher", jimmsvc.WatchControllers) }) // Deletes dead/dying models, updates model config.
 	}
 	s.Go(func() error { return jimmsvc.WatchModelSummaries(ctx) })
 
 	if isLeader {
 		zapctx.Info(ctx, "attempting to start JWKS rotator and generate OAuth secret key")
 		s.Go(func() error {
-			if err := jimmsvc.StartJWKSRotator(ctx, time.NewTicker(time.Hour).C, time.Now().UTC().AddDate(0, 3, 0)); err != nil {
-				zapctx.Error(ctx, "failed to start JWKS rotator", zap.Error(err))
-				return err
-			}
-			return nil
+			return jimmsvc.RunWorker(ctx, "jwks-rotator", func(ctx context.Context) error {
+				ticker := time.NewTicker(time.Hour)
+				defer ticker.Stop()
+				if err := jimmsvc.StartJWKSRotator(ctx, ticker.C, time.Now().UTC().AddDate(0, 3, 0)); err != nil {
+					zapctx.Error(ctx, "failed to start JWKS rotator", zap.Error(err))
+					return err
+				}
+				<-ctx.Done()
+				return nil
+			})
 		})
 	}
 
 	if isLeader {
 		// No need for s.Go() since this routine doesn't return an error.
-		go jimmsvc.MonitorResources(ctx)
+		go jimmsvc.RunWorker(ctx, "resource-monitor", func(ctx context.Context) error {
+			jimmsvc.MonitorResources(ctx)
+			return nil
+		})
 	}
 
 	httpsrv := &http.Server{
@@ -216,18 +603,95 @@ func start(ctx context.Context, s *service.Service) error {
 		Handler:           jimmsvc,
 		ReadHeaderTimeout: time.Second * 5,
 	}
+	servers := []*http.Server{httpsrv}
+	if adminAddr != "" {
+		servers = append(servers, &http.Server{
+			Addr:              adminAddr,
+			Handler:           jimmsvc.AdminHandler(),
+			ReadHeaderTimeout: time.Second * 5,
+		})
+	}
+	var grpcsrv *grpc.Server
+	if adminGRPCAddr != "" {
+		grpcTLSParams := tlsParams
+		grpcTLSParams.ClientCAFile = os.Getenv("JIMM_ADMIN_GRPC_CLIENT_CA_FILE")
+		if !grpcTLSParams.Enabled() || grpcTLSParams.ClientCAFile == "" {
+			err := errors.E("the gRPC admin API requires JIMM_TLS_CERT_FILE, JIMM_TLS_KEY_FILE and JIMM_ADMIN_GRPC_CLIENT_CA_FILE")
+			zapctx.Error(ctx, "failed to configure gRPC admin API", zap.Error(err))
+			return err
+		}
+		grpcTLSConfig, err := jimmhttp.NewTLSConfig(ctx, grpcTLSParams)
+		if err != nil {
+			zapctx.Error(ctx, "failed to configure gRPC admin API TLS", zap.Error(err))
+			return err
+		}
+		lis, err := net.Listen("tcp", adminGRPCAddr)
+		if err != nil {
+			zapctx.Error(ctx, "failed to listen for gRPC admin API", zap.Error(err))
+			return err
+		}
+		grpcsrv = jimmsvc.AdminGRPCServer(grpc.Creds(credentials.NewTLS(grpcTLSConfig)))
+		s.Go(func() error { return grpcsrv.Serve(lis) })
+	}
 	s.OnShutdown(func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		zapctx.Warn(ctx, "server shutdown triggered")
-		err = httpsrv.Shutdown(ctx)
-		if err != nil {
-			zapctx.Error(ctx, "failed to shutdown server gracefully", zap.Error(err))
+		for _, srv := range servers {
+			if err := srv.Shutdown(ctx); err != nil {
+				zapctx.Error(ctx, "failed to shutdown server gracefully", zap.Error(err), zap.String("addr", srv.Addr))
+			}
+		}
+		if grpcsrv != nil {
+			grpcsrv.GracefulStop()
 		}
 		jimmsvc.Cleanup()
 	})
-	s.Go(httpsrv.ListenAndServe)
+	if tlsParams.Enabled() {
+		tlsConfig, err := jimmhttp.NewTLSConfig(ctx, tlsParams)
+		if err != nil {
+			zapctx.Error(ctx, "failed to configure TLS", zap.Error(err))
+			return err
+		}
+		for _, srv := range servers {
+			srv := srv
+			srv.TLSConfig = tlsConfig
+			s.Go(func() error { return srv.ListenAndServeTLS("", "") })
+		}
+	} else {
+		for _, srv := range servers {
+			s.Go(srv.ListenAndServe)
+		}
+	}
 	zapctx.Info(ctx, "Successfully started JIMM server")
 	return nil
 }
+
+// parseChaosParams reads the controller connection chaos parameters
+// from the environment.
+func parseChaosParams() (jujuclient.ChaosParams, error) {
+	var p jujuclient.ChaosParams
+	var err error
+	if v := os.Getenv("JIMM_CHAOS_DIAL_FAILURE_RATE"); v != "" {
+		if p.DialFailureRate, err = strconv.ParseFloat(v, 64); err != nil {
+			return p, err
+		}
+	}
+	if v := os.Getenv("JIMM_CHAOS_MAX_LATENCY"); v != "" {
+		if p.MaxLatency, err = time.ParseDuration(v); err != nil {
+			return p, err
+		}
+	}
+	if v := os.Getenv("JIMM_CHAOS_DISCONNECT_RATE"); v != "" {
+		if p.DisconnectRate, err = strconv.ParseFloat(v, 64); err != nil {
+			return p, err
+		}
+	}
+	if v := os.Getenv("JIMM_CHAOS_SEED"); v != "" {
+		if p.Seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return p, err
+		}
+	}
+	return p, p.Validate()
+}
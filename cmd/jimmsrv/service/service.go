@@ -6,8 +6,11 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -22,29 +25,43 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/credentialvalidator"
 	"github.com/canonical/jimm/v3/internal/dashboard"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/debugapi"
 	"github.com/canonical/jimm/v3/internal/discharger"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/fips"
+	"github.com/canonical/jimm/v3/internal/jemapi"
 	"github.com/canonical/jimm/v3/internal/jimm"
-	jimmcreds "github.com/canonical/jimm/v3/internal/jimm/credentials"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
 	"github.com/canonical/jimm/v3/internal/jujuapi"
 	"github.com/canonical/jimm/v3/internal/jujuclient"
+	"github.com/canonical/jimm/v3/internal/kubesecrets"
 	"github.com/canonical/jimm/v3/internal/logger"
 	"github.com/canonical/jimm/v3/internal/middleware"
+	"github.com/canonical/jimm/v3/internal/notifications"
+	"github.com/canonical/jimm/v3/internal/notify"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/pgnotify"
 	"github.com/canonical/jimm/v3/internal/pubsub"
 	"github.com/canonical/jimm/v3/internal/rebac_admin"
+	"github.com/canonical/jimm/v3/internal/rpc"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	"github.com/canonical/jimm/v3/internal/vault"
+	"github.com/canonical/jimm/v3/internal/webhook"
 	"github.com/canonical/jimm/v3/internal/wellknownapi"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 const (
@@ -78,6 +95,10 @@ type OAuthAuthenticatorParams struct {
 	// Scopes holds the scopes that you wish to retrieve.
 	Scopes []string
 
+	// AdditionalIssuers holds further OIDC issuers that identities may
+	// authenticate with, selected by the identity's email domain.
+	AdditionalIssuers []auth.IssuerParams
+
 	// SessionTokenExpiry holds the expiry duration for issued JWTs
 	// for user (CLI) to JIMM authentication.
 	SessionTokenExpiry time.Duration
@@ -92,6 +113,10 @@ type OAuthAuthenticatorParams struct {
 	// JWTSessionKey holds the secret key used for signing/verifying JWT tokens.
 	// See internal/auth/oauth2.go AuthenticationService.SessionSecretkey for more details.
 	JWTSessionKey string
+
+	// PreviousJWTSessionKeys holds JWT session keys that have been rotated
+	// out but are still accepted when verifying tokens.
+	PreviousJWTSessionKeys []string
 }
 
 // A Params structure contains the parameters required to initialise a new
@@ -106,6 +131,12 @@ type Params struct {
 	// will be used.
 	DSN string
 
+	// ReadReplicaDSN, if set, is the data source name of a read-only
+	// replica of the database. Heavy read queries, such as audit log
+	// queries and model listings, are sent to the replica while it is
+	// available.
+	ReadReplicaDSN string
+
 	// ControllerAdmins contains a list of users (or groups)
 	// that will be given the access-level "superuser" when they
 	// authenticate to the controller.
@@ -163,6 +194,12 @@ type Params struct {
 	// to keep an audit log for before purging it from the database.
 	AuditLogRetentionPeriodInDays string
 
+	// AuditLogRetentionPeriod is how long to keep an audit log for before
+	// purging it from the database. If it is set it takes precedence over
+	// AuditLogRetentionPeriodInDays, allowing retention periods that are
+	// not a whole number of days.
+	AuditLogRetentionPeriod time.Duration
+
 	// MacaroonExpiryDuration holds the expiry duration of authentication macaroons.
 	MacaroonExpiryDuration time.Duration
 
@@ -174,6 +211,17 @@ type Params struct {
 	// instead of dedicated secure storage. SHOULD NOT BE USED IN PRODUCTION.
 	InsecureSecretStorage bool
 
+	// ControllerSecretStore selects where the admin credentials and CA
+	// certificates of controllers are stored. It may be "vault" or
+	// "kubernetes". If it is empty controller credentials are held in
+	// the credential store and CA certificates in the database.
+	ControllerSecretStore string
+
+	// KubernetesSecretNamespace is the namespace in which controller
+	// secrets are stored when ControllerSecretStore is "kubernetes". If
+	// it is empty the namespace JIMM is running in is used.
+	KubernetesSecretNamespace string
+
 	// OAuthAuthenticatorParams holds parameters needed to configure an OAuthAuthenticator
 	// implementation.
 	OAuthAuthenticatorParams OAuthAuthenticatorParams
@@ -187,13 +235,187 @@ type Params struct {
 	// https://github.com/gorilla/securecookie/blob/main/securecookie.go#L124
 	CookieSessionKey []byte
 
+	// PreviousCookieSessionKeys holds cookie session keys that have been
+	// rotated out. Sessions signed with these keys are still accepted, but
+	// are re-signed with CookieSessionKey when next saved.
+	PreviousCookieSessionKeys [][]byte
+
 	// CorsAllowedOrigins represents all addresses that are valid for cross-origin
 	// requests. A wildcard '*' is accepted to allow all cross-origin requests.
 	CorsAllowedOrigins []string
 
+	// TrustedProxies holds the addresses of the reverse proxies in front
+	// of JIMM. The X-Forwarded-For header is only used to find the
+	// address of a client on requests received from these addresses.
+	TrustedProxies []netip.Prefix
+
 	// LogSQL determines whether ORM queries are printed when debug logs are enabled.
 	// This may leak secrets in logs when sensitive values are stored in the DB like OAuth tokens.
 	LogSQL bool
+
+	// RequireReasonForPrivilegedOperations determines whether callers
+	// must supply a reason when performing destructive or privileged
+	// operations. The reason is recorded in the audit log.
+	RequireReasonForPrivilegedOperations bool
+
+	// HSTSMaxAge, if non-zero, causes a Strict-Transport-Security header
+	// with the given max-age to be sent on every HTTP response.
+	HSTSMaxAge time.Duration
+
+	// LoginThrottle holds the parameters used to throttle failed logins.
+	// If MaxFailures is zero failed logins are not throttled.
+	LoginThrottle jimm.LoginThrottleParams
+
+	// ValidateCloudCredentials determines whether cloud credentials are
+	// checked with the cloud's provider before they are sent to
+	// controllers.
+	ValidateCloudCredentials bool
+
+	// ArchiveDestroyedModels determines whether a snapshot of a model's
+	// configuration and status is stored before the model is destroyed.
+	ArchiveDestroyedModels bool
+
+	// DefaultRegionPriority is the priority given to a newly added
+	// controller in the cloud regions, other than the one hosting its
+	// controller model, that it discovers. If this is zero the
+	// standard supported-region priority is used.
+	DefaultRegionPriority uint
+
+	// Tracing holds the parameters used to export OpenTelemetry traces
+	// of JIMM operations. If no endpoint is configured traces are not
+	// exported.
+	Tracing tracing.Params
+
+	// SeparateAdminHandler, if true, serves the administrative endpoints
+	// (/metrics, /rebac and /debug) from AdminHandler rather than from
+	// the main handler, so they can be bound to a different address.
+	// Profiling endpoints are only enabled in this mode.
+	SeparateAdminHandler bool
+
+	// FIPSMode restricts JIMM to FIPS approved cryptographic algorithms.
+	// Features that cannot operate within these restrictions, such as
+	// macaroon discharge for cross-model relations, are disabled and
+	// NewService fails if they are explicitly configured.
+	FIPSMode bool
+
+	// ModelSummaryDebounce, if non-zero, is the period over which
+	// successive model summary updates for the same model are coalesced
+	// before being published to ModelSummaryWatcher clients.
+	ModelSummaryDebounce time.Duration
+
+	// ControllerSelector is the name of the strategy used to choose the
+	// controller for new models and hosted clouds, see
+	// jimm.NewControllerSelector. If empty the region priority strategy
+	// is used.
+	ControllerSelector string
+
+	// ControllerAffinities holds the preferred controllers for user and
+	// cloud tags, used by the tag-affinity controller selector.
+	ControllerAffinities map[string][]string
+
+	// ReconcileInterval, if non-zero, is the period between comparisons
+	// of the database with the tuples in OpenFGA.
+	ReconcileInterval time.Duration
+
+	// ReconcileRepair determines whether differences found by the
+	// periodic reconciliation are repaired, or only logged.
+	ReconcileRepair bool
+
+	// IdentityProfileSyncInterval is the period between synchronising
+	// identity display names and emails from the identity provider. If
+	// it is zero an hour is used, if it is negative identity profiles
+	// are only synchronised at login.
+	IdentityProfileSyncInterval time.Duration
+
+	// ControllerPasswordRotationInterval, if non-zero, is the period
+	// between changes of the admin password JIMM uses on each
+	// controller. The new passwords are kept in the credential store.
+	ControllerPasswordRotationInterval time.Duration
+
+	// CredentialExpiryWarning is the period before a cloud credential
+	// expires during which it is flagged as expiring soon. If it is zero
+	// a week is used.
+	CredentialExpiryWarning time.Duration
+
+	// MigrationConcurrency is the maximum number of migrations queued
+	// with the migration scheduler that are run at once. If it is zero
+	// four migrations are run at once.
+	MigrationConcurrency int
+
+	// WorkerLeaseDuration, if non-zero, enables coordination of the
+	// background workers between replicas sharing a database. Each
+	// worker is then only run by the replica holding its lease.
+	WorkerLeaseDuration time.Duration
+
+	// ReplicaID uniquely identifies this replica when coordinating
+	// background workers. If it is empty an identifier is generated.
+	ReplicaID string
+
+	// SMTP holds the configuration of the SMTP server used to send
+	// notifications by email. If SMTP.Addr is empty notifications are
+	// not sent by email.
+	SMTP notify.SMTPTransport
+
+	// NotificationSecret is used to sign notifications delivered by the
+	// https transport.
+	NotificationSecret string
+
+	// WebhookAllowedNetworks lists networks that may receive model
+	// webhooks and https notifications even though their addresses are
+	// loopback, private or link-local.
+	WebhookAllowedNetworks []netip.Prefix
+
+	// LifecycleWebhooks holds the URLs to which lifecycle events, such
+	// as a model being created or a controller becoming unavailable,
+	// are posted.
+	LifecycleWebhooks []string
+
+	// LifecycleWebhookSecret is used to sign the lifecycle events posted
+	// to LifecycleWebhooks.
+	LifecycleWebhookSecret string
+
+	// LegacyJEMAPI, if true, serves the legacy JEM endpoints to list
+	// models, get a model and list controllers under /v2.
+	LegacyJEMAPI bool
+
+	// ControllerEndpointWeights, if set, weights the addresses of
+	// controllers so that those closest to this replica are dialed
+	// first. Addresses are otherwise ordered by their observed latency.
+	ControllerEndpointWeights []rpc.EndpointWeight
+
+	// ControllerDialStagger, if non-zero, is the delay between dialing
+	// successive controller addresses when the addresses are routed
+	// using ControllerEndpointWeights. Routing is also enabled if only
+	// this is set.
+	ControllerDialStagger time.Duration
+
+	// MaxConcurrentControllerDials, if positive, is the maximum number
+	// of controller connections that may be being dialed at once.
+	MaxConcurrentControllerDials int
+
+	// RateLimit holds the limits applied to requests made on controller
+	// connections. No limits are applied by default.
+	RateLimit jujuapi.RateLimitParams
+
+	// ControllerChaos configures the faults injected into controller
+	// connections to exercise the retry and failover paths. No faults
+	// are injected by default, this must only be enabled in staging
+	// deployments.
+	ControllerChaos jujuclient.ChaosParams
+
+	// CharmHubCacheTTL, if non-zero, is the time for which the
+	// responses to charm metadata requests proxied to controllers are
+	// cached. Caching is enabled if either this or CharmHubCacheSize is
+	// set.
+	CharmHubCacheTTL time.Duration
+
+	// CharmHubCacheSize, if non-zero, is the maximum total size in
+	// bytes of the cached charm metadata responses.
+	CharmHubCacheSize int
+
+	// Branding holds the organisation name, logo URL and message of the
+	// day shown to users when they log in.
+	Branding apiparams.Branding
 }
 
 // A Service is the implementation of a JIMM server.
@@ -201,7 +423,12 @@ type Service struct {
 	jimm jimm.JIMM
 
 	mux      *chi.Mux
+	adminMux *chi.Mux
 	cleanups []func() error
+
+	modelSummaryDebounce time.Duration
+	workers              jimm.WorkerCoordinator
+	apiParams            jujuapi.Params
 }
 
 func (s *Service) JIMM() *jimm.JIMM {
@@ -213,6 +440,20 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.mux.ServeHTTP(w, req)
 }
 
+// AdminGRPCServer returns a gRPC server serving JIMM's admin API with
+// the given options, which must include transport credentials that
+// require client certificates.
+func (s *Service) AdminGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	return jujuapi.NewAdminGRPCServer(&s.jimm, s.apiParams, opts...)
+}
+
+// AdminHandler returns the handler serving the administrative endpoints.
+// Unless the service was created with SeparateAdminHandler this is the
+// service itself.
+func (s *Service) AdminHandler() http.Handler {
+	return s.adminMux
+}
+
 // WatchControllers connects to all controllers and starts an AllWatcher
 // monitoring all changes to models. WatchControllers finishes when the
 // given context is canceled, or there is a fatal error watching models.
@@ -220,6 +461,17 @@ func (s *Service) WatchControllers(ctx context.Context) error {
 	w := jimm.Watcher{
 		Database: s.jimm.Database,
 		Dialer:   s.jimm.Dialer,
+		Notifier: jimm.ModelEventNotifiers{
+			&jimm.WebhookNotifier{
+				Database: s.jimm.Database,
+				Sender: &webhook.Sender{
+					AllowedNetworks: s.jimm.WebhookAllowedNetworks,
+				},
+			},
+			jimm.OwnerNotifier{JIMM: &s.jimm},
+		},
+		VersionNotifier: &s.jimm,
+		Lifecycle:       s.jimm.Lifecycle,
 	}
 	return w.Watch(ctx, 10*time.Minute)
 }
@@ -230,9 +482,12 @@ func (s *Service) WatchControllers(ctx context.Context) error {
 // summaries.
 func (s *Service) WatchModelSummaries(ctx context.Context) error {
 	w := jimm.Watcher{
-		Database: s.jimm.Database,
-		Dialer:   s.jimm.Dialer,
-		Pubsub:   s.jimm.Pubsub,
+		Database:        s.jimm.Database,
+		Dialer:          s.jimm.Dialer,
+		Pubsub:          s.jimm.Pubsub,
+		VersionNotifier: &s.jimm,
+
+		SummaryDebounce: s.modelSummaryDebounce,
 	}
 	return w.WatchAllModelSummaries(ctx, 10*time.Minute)
 }
@@ -246,6 +501,28 @@ func (s *Service) StartJWKSRotator(ctx context.Context, checkRotateRequired <-ch
 	return s.jimm.JWKService.StartJWKSRotator(ctx, checkRotateRequired, initialRotateRequiredTime)
 }
 
+// RunWorker runs the named background worker until the given context is
+// canceled or the worker returns. If worker coordination is enabled the
+// worker is only run while this replica holds the worker's lease.
+func (s *Service) RunWorker(ctx context.Context, name string, f func(context.Context) error) error {
+	return s.workers.Run(ctx, name, f)
+}
+
+// startWorker starts the named background worker, which runs from a call
+// to start until its context is canceled, see RunWorker.
+func (s *Service) startWorker(ctx context.Context, name string, start func(context.Context)) {
+	go func() {
+		err := s.RunWorker(ctx, name, func(ctx context.Context) error {
+			start(ctx)
+			<-ctx.Done()
+			return nil
+		})
+		if err != nil {
+			zapctx.Error(ctx, "background worker failed", zap.String("worker", name), zap.Error(err))
+		}
+	}()
+}
+
 // MonitorResources periodically updates metrics.
 func (s *Service) MonitorResources(ctx context.Context) {
 	s.jimm.UpdateMetrics(ctx)
@@ -280,8 +557,29 @@ func (s *Service) AddCleanup(f func() error) {
 func NewService(ctx context.Context, p Params) (*Service, error) {
 	const op = errors.Op("NewService")
 
+	if fips.Enabled(p.FIPSMode) {
+		// The bakery uses NaCl box encryption for third party caveats
+		// which is not FIPS approved.
+		if p.PrivateKey != "" || p.PublicKey != "" {
+			return nil, errors.E(op, errors.CodeServerConfiguration, "macaroon discharge keys cannot be configured in FIPS mode")
+		}
+		fips.RestrictClients()
+	}
+
 	s := new(Service)
+	shutdownTracing, err := tracing.Setup(ctx, p.Tracing)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeServerConfiguration, err)
+	}
+	s.AddCleanup(func() error {
+		return shutdownTracing(context.Background())
+	})
 	s.mux = chi.NewRouter()
+	s.adminMux = s.mux
+	if p.SeparateAdminHandler {
+		s.adminMux = chi.NewRouter()
+	}
+	s.modelSummaryDebounce = p.ModelSummaryDebounce
 
 	// Setup all dependency services
 
@@ -294,32 +592,110 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	}
 	s.jimm.UUID = p.ControllerUUID
 	s.jimm.Pubsub = &pubsub.Hub{MaxConcurrency: 50}
+	if p.LoginThrottle.MaxFailures > 0 {
+		s.jimm.LoginThrottle = jimm.NewLoginThrottle(p.LoginThrottle)
+	}
+	selector, err := jimm.NewControllerSelector(p.ControllerSelector, &s.jimm.Database, p.ControllerAffinities)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeServerConfiguration, err)
+	}
+	s.jimm.ControllerSelector = selector
+	if p.ValidateCloudCredentials {
+		s.jimm.CredentialValidator = &credentialvalidator.Validator{}
+	}
+	s.jimm.ArchiveDestroyedModels = p.ArchiveDestroyedModels
+	s.jimm.DefaultRegionPriority = p.DefaultRegionPriority
+	s.jimm.WebhookAllowedNetworks = p.WebhookAllowedNetworks
+	s.jimm.NotificationTransports = map[string]notify.Transport{
+		notify.TransportSlack: &notify.SlackTransport{},
+		notify.TransportHTTPS: &notify.HTTPSTransport{
+			Sender: &webhook.Sender{
+				AllowedNetworks: p.WebhookAllowedNetworks,
+			},
+			Secret: []byte(p.NotificationSecret),
+		},
+	}
+	if p.SMTP.Addr != "" {
+		smtp := p.SMTP
+		s.jimm.NotificationTransports[notify.TransportEmail] = &smtp
+	}
+	if len(p.LifecycleWebhooks) > 0 {
+		s.jimm.Lifecycle = &notifications.Dispatcher{
+			// Lifecycle endpoints are configured by administrators.
+			Sender:   &webhook.Sender{Unrestricted: true},
+			Database: &s.jimm.Database,
+		}
+		for _, u := range p.LifecycleWebhooks {
+			s.jimm.Lifecycle.Endpoints = append(s.jimm.Lifecycle.Endpoints, notifications.Endpoint{
+				URL:    u,
+				Secret: []byte(p.LifecycleWebhookSecret),
+			})
+		}
+	}
 
 	if p.DSN == "" {
 		return nil, errors.E(op, "missing DSN")
 	}
 
-	var err error
-	s.jimm.Database.DB, err = openDB(ctx, p.DSN, p.LogSQL)
+	s.jimm.Database.DB, err = openDB(ctx, p.DSN, p.LogSQL, true)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 	if err := s.jimm.Database.Migrate(ctx, false); err != nil {
 		return nil, errors.E(op, err)
 	}
+	if p.ReadReplicaDSN != "" {
+		// The replica is not required to be available at startup,
+		// reads use the primary until it is.
+		replicaDB, err := openDB(ctx, p.ReadReplicaDSN, p.LogSQL, false)
+		if err != nil {
+			return nil, errors.E(op, err, "cannot open read replica")
+		}
+		s.jimm.Database.ReadReplica = db.NewReadReplica(replicaDB)
+	}
+
+	bus := &pgnotify.Bus{
+		DB:  s.jimm.Database.DB,
+		DSN: p.DSN,
+	}
+	s.jimm.CacheInvalidationBus = bus
+	go func() {
+		// Every replica listens, so this is not a leased worker.
+		if err := bus.Listen(ctx, s.jimm.InvalidateLocalCache); err != nil && ctx.Err() == nil {
+			zapctx.Error(ctx, "cache invalidation listener failed", zap.Error(err))
+		}
+	}()
+
+	s.workers.Database = &s.jimm.Database
+	s.workers.Holder = p.ReplicaID
+	if s.workers.Holder == "" {
+		hostname, _ := os.Hostname()
+		s.workers.Holder = hostname + "-" + uuid.NewString()
+	}
+	s.workers.LeaseDuration = p.WorkerLeaseDuration
 
-	if p.AuditLogRetentionPeriodInDays != "" {
+	retentionPeriod := p.AuditLogRetentionPeriod
+	if retentionPeriod == 0 && p.AuditLogRetentionPeriodInDays != "" {
 		period, err := strconv.Atoi(p.AuditLogRetentionPeriodInDays)
 		if err != nil {
 			return nil, errors.E(op, "failed to parse audit log retention period")
 		}
-		if period < 0 {
-			return nil, errors.E(op, "retention period cannot be less than 0")
-		}
-		if period != 0 {
-			jimm.NewAuditLogCleanupService(s.jimm.Database, period).Start(ctx)
-		}
+		retentionPeriod = time.Duration(period) * 24 * time.Hour
 	}
+	if retentionPeriod < 0 {
+		return nil, errors.E(op, "retention period cannot be less than 0")
+	}
+	if retentionPeriod != 0 {
+		s.startWorker(ctx, "audit-log-cleanup", jimm.NewAuditLogCleanupService(s.jimm.Database, retentionPeriod).Start)
+	}
+	s.startWorker(ctx, "temporary-grant-cleanup", jimm.NewTemporaryGrantCleanupService(&s.jimm, time.Minute).Start)
+	s.startWorker(ctx, "session-cleanup", jimm.NewSessionCleanupService(s.jimm.Database, 30*time.Minute).Start)
+	s.startWorker(ctx, "usage-aggregation", jimm.NewUsageAggregationService(&s.jimm, 5*time.Minute).Start)
+	credentialExpiryWarning := p.CredentialExpiryWarning
+	if credentialExpiryWarning == 0 {
+		credentialExpiryWarning = 7 * 24 * time.Hour
+	}
+	s.startWorker(ctx, "credential-expiry", jimm.NewCredentialExpiryService(&s.jimm, time.Hour, credentialExpiryWarning).Start)
 
 	openFGAclient, err := newOpenFGAClient(ctx, p.OpenFGAParams)
 	if err != nil {
@@ -329,12 +705,23 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	if err := ensureControllerAdministrators(ctx, openFGAclient, p.ControllerUUID, p.ControllerAdmins); err != nil {
 		return nil, errors.E(op, err, "failed to ensure controller admins")
 	}
+	if p.ReconcileInterval > 0 {
+		s.startWorker(ctx, "reconcile", jimm.NewReconcileService(&s.jimm, p.ReconcileInterval, p.ReconcileRepair).Start)
+	}
 
 	if err := s.setupCredentialStore(ctx, p); err != nil {
 		return nil, errors.E(op, err)
 	}
+	migrationConcurrency := p.MigrationConcurrency
+	if migrationConcurrency == 0 {
+		migrationConcurrency = 4
+	}
+	s.startWorker(ctx, "migration-scheduler", jimm.NewMigrationSchedulerService(&s.jimm, time.Minute, migrationConcurrency).Start)
+	if p.ControllerPasswordRotationInterval > 0 {
+		s.startWorker(ctx, "controller-password-rotation", jimm.NewControllerPasswordRotationService(&s.jimm, p.ControllerPasswordRotationInterval).Start)
+	}
 
-	sessionStore, err := s.setupSessionStore(ctx, p.CookieSessionKey)
+	sessionStore, err := s.setupSessionStore(ctx, p.CookieSessionKey, p.PreviousCookieSessionKeys...)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -351,17 +738,19 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	authSvc, err := auth.NewAuthenticationService(
 		ctx,
 		auth.AuthenticationServiceParams{
-			IssuerURL:           p.OAuthAuthenticatorParams.IssuerURL,
-			ClientID:            p.OAuthAuthenticatorParams.ClientID,
-			ClientSecret:        p.OAuthAuthenticatorParams.ClientSecret,
-			Scopes:              p.OAuthAuthenticatorParams.Scopes,
-			SessionTokenExpiry:  p.OAuthAuthenticatorParams.SessionTokenExpiry,
-			SessionCookieMaxAge: p.OAuthAuthenticatorParams.SessionCookieMaxAge,
-			JWTSessionKey:       p.OAuthAuthenticatorParams.JWTSessionKey,
-			SecureCookies:       p.OAuthAuthenticatorParams.SecureSessionCookies,
-			Store:               &s.jimm.Database,
-			SessionStore:        sessionStore,
-			RedirectURL:         redirectUrl,
+			IssuerURL:              p.OAuthAuthenticatorParams.IssuerURL,
+			ClientID:               p.OAuthAuthenticatorParams.ClientID,
+			ClientSecret:           p.OAuthAuthenticatorParams.ClientSecret,
+			Scopes:                 p.OAuthAuthenticatorParams.Scopes,
+			AdditionalIssuers:      p.OAuthAuthenticatorParams.AdditionalIssuers,
+			SessionTokenExpiry:     p.OAuthAuthenticatorParams.SessionTokenExpiry,
+			SessionCookieMaxAge:    p.OAuthAuthenticatorParams.SessionCookieMaxAge,
+			JWTSessionKey:          p.OAuthAuthenticatorParams.JWTSessionKey,
+			PreviousJWTSessionKeys: p.OAuthAuthenticatorParams.PreviousJWTSessionKeys,
+			SecureCookies:          p.OAuthAuthenticatorParams.SecureSessionCookies,
+			Store:                  &s.jimm.Database,
+			SessionStore:           sessionStore,
+			RedirectURL:            redirectUrl,
 		},
 	)
 	s.jimm.OAuthAuthenticator = authSvc
@@ -369,6 +758,17 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		zapctx.Error(ctx, "failed to setup authentication service", zap.Error(err))
 		return nil, errors.E(op, err, "failed to setup authentication service")
 	}
+	// Session keys rotated with RotateSessionKey replace the configured
+	// keys.
+	if err := s.jimm.LoadSessionKeys(ctx); err != nil {
+		return nil, errors.E(op, err, "failed to load session keys")
+	}
+	if p.IdentityProfileSyncInterval == 0 {
+		p.IdentityProfileSyncInterval = time.Hour
+	}
+	if p.IdentityProfileSyncInterval > 0 {
+		s.startWorker(ctx, "identity-profile-sync", jimm.NewIdentityProfileSyncService(&s.jimm, p.IdentityProfileSyncInterval).Start)
+	}
 
 	if p.JWTExpiryDuration == 0 {
 		p.JWTExpiryDuration = 24 * time.Hour
@@ -380,10 +780,32 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		Store:  s.jimm.CredentialStore,
 		Expiry: p.JWTExpiryDuration,
 	})
-	s.jimm.Dialer = &jujuclient.Dialer{
-		ControllerCredentialsStore: s.jimm.CredentialStore,
+	dialer := &jujuclient.Dialer{
+		ControllerCredentialsStore: s.jimm.ControllerCredentialStore(),
 		JWTService:                 s.jimm.JWTService,
+		MaxConcurrentDials:         p.MaxConcurrentControllerDials,
+	}
+	if s.jimm.SecretStore != nil {
+		dialer.CACertificateStore = s.jimm.SecretStore
+	}
+	if len(p.ControllerEndpointWeights) > 0 || p.ControllerDialStagger > 0 {
+		dialer.Router, err = rpc.NewEndpointRouter(p.ControllerEndpointWeights, p.ControllerDialStagger)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+	if p.ControllerChaos.Enabled() {
+		if err := p.ControllerChaos.Validate(); err != nil {
+			return nil, errors.E(op, err)
+		}
+		zapctx.Warn(ctx, "chaos mode enabled, faults will be injected into controller connections",
+			zap.Float64("dial-failure-rate", p.ControllerChaos.DialFailureRate),
+			zap.Duration("max-latency", p.ControllerChaos.MaxLatency),
+			zap.Float64("disconnect-rate", p.ControllerChaos.DisconnectRate),
+		)
+		dialer.Chaos = jujuclient.NewChaos(p.ControllerChaos)
 	}
+	s.jimm.Dialer = dialer
 
 	if !p.DisableConnectionCache {
 		s.jimm.Dialer = jimm.CacheDialer(s.jimm.Dialer)
@@ -404,25 +826,79 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		AllowedMethods:   []string{"GET"},
 		AllowCredentials: true,
 	})
-	s.mux.Use(corsOpts.Handler)
+	muxes := []*chi.Mux{s.mux}
+	if s.adminMux != s.mux {
+		muxes = append(muxes, s.adminMux)
+	}
+	for _, mux := range muxes {
+		mux.Use(corsOpts.Handler)
+		if p.HSTSMaxAge > 0 {
+			mux.Use(middleware.HSTS(p.HSTSMaxAge, true))
+		}
+	}
 
 	// Setup all HTTP handlers.
 	mountHandler := func(path string, h jimmhttp.JIMMHttpHandler) {
 		s.mux.Mount(path, h.Routes())
 	}
 
-	s.mux.Mount("/metrics", promhttp.Handler())
+	// Administrative endpoints.
+	s.adminMux.Mount("/metrics", promhttp.Handler())
 
-	s.mux.Mount("/rebac", middleware.AuthenticateRebac("/rebac", rebacBackend.Handler(""), &s.jimm))
+	s.adminMux.Mount("/rebac", middleware.AuthenticateRebac("/rebac", rebacBackend.Handler(""), &s.jimm))
 
-	mountHandler(
-		"/debug",
-		debugapi.NewDebugHandler(
-			map[string]debugapi.StatusCheck{
-				"start_time": debugapi.ServerStartTime,
-			},
-		),
+	debugHandler := debugapi.NewDebugHandler(
+		map[string]debugapi.StatusCheck{
+			"start_time": debugapi.ServerStartTime,
+			"workers": debugapi.MakeStatusCheck("background workers", func(ctx context.Context) (interface{}, error) {
+				return s.workers.Status(ctx)
+			}),
+		},
 	)
+	debugHandler.Profiling = p.SeparateAdminHandler
+	s.adminMux.Mount("/debug", debugHandler.Routes())
+
+	// Health checks for load balancers and Kubernetes probes.
+	healthChecks := []jimmhttp.HealthCheck{{
+		Name:     "database",
+		Liveness: true,
+		Check: func(ctx context.Context) (interface{}, error) {
+			return nil, s.jimm.Database.Ping(ctx)
+		},
+	}, {
+		Name: "openfga",
+		Check: func(ctx context.Context) (interface{}, error) {
+			return nil, s.jimm.OpenFGAClient.Ping(ctx)
+		},
+	}, {
+		Name: "controller",
+		Check: func(ctx context.Context) (interface{}, error) {
+			return s.jimm.PingControllers(ctx)
+		},
+	}}
+	vs, ok := s.jimm.CredentialStore.(*vault.VaultStore)
+	if !ok {
+		vs, ok = s.jimm.SecretStore.(*vault.VaultStore)
+	}
+	if ok {
+		healthChecks = append(healthChecks, jimmhttp.HealthCheck{
+			Name: "vault",
+			Check: func(ctx context.Context) (interface{}, error) {
+				return nil, vs.Ping(ctx)
+			},
+		})
+	}
+	if ks, ok := s.jimm.SecretStore.(*kubesecrets.SecretStore); ok {
+		healthChecks = append(healthChecks, jimmhttp.HealthCheck{
+			Name: "kubernetes",
+			Check: func(ctx context.Context) (interface{}, error) {
+				return nil, ks.Ping(ctx)
+			},
+		})
+	}
+	healthHandler := jimmhttp.NewHealthHandler(healthChecks...)
+	s.mux.Get(jimmhttp.HealthzEndpoint, healthHandler.Healthz)
+	s.mux.Get(jimmhttp.ReadyzEndpoint, healthHandler.Readyz)
 	mountHandler(
 		"/.well-known",
 		wellknownapi.NewWellKnownHandler(s.jimm.CredentialStore),
@@ -434,6 +910,7 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		oauthHandler, err := jimmhttp.NewOAuthHandler(jimmhttp.OAuthHandlerParams{
 			Authenticator:             authSvc,
 			DashboardFinalRedirectURL: p.DashboardFinalRedirectURL,
+			Branding:                  p.Branding,
 		})
 		if err != nil {
 			zapctx.Error(ctx, "failed to setup authentication handler", zap.Error(err))
@@ -445,16 +922,32 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		)
 	}
 
-	macaroonDischarger, err := s.setupDischarger(p)
-	if err != nil {
-		return nil, errors.E(op, err, "failed to set up discharger")
+	if fips.Enabled(p.FIPSMode) {
+		zapctx.Info(ctx, "FIPS mode enabled, macaroon discharger disabled")
+	} else {
+		macaroonDischarger, err := s.setupDischarger(p)
+		if err != nil {
+			return nil, errors.E(op, err, "failed to set up discharger")
+		}
+		s.mux.Handle(localDischargePath+"/*", discharger.GetDischargerMux(macaroonDischarger, localDischargePath))
 	}
-	s.mux.Handle(localDischargePath+"/*", discharger.GetDischargerMux(macaroonDischarger, localDischargePath))
 
 	params := jujuapi.Params{
-		ControllerUUID: p.ControllerUUID,
-		PublicDNSName:  p.PublicDNSName,
+		ControllerUUID:                       p.ControllerUUID,
+		PublicDNSName:                        p.PublicDNSName,
+		RequireReasonForPrivilegedOperations: p.RequireReasonForPrivilegedOperations,
+		TrustedProxies:                       p.TrustedProxies,
+	}
+	if p.CharmHubCacheTTL > 0 || p.CharmHubCacheSize > 0 {
+		params.CharmHubCache = rpc.NewCharmHubCache(p.CharmHubCacheTTL, p.CharmHubCacheSize)
+	}
+	if p.Branding != (apiparams.Branding{}) {
+		params.Branding = &p.Branding
 	}
+	if p.RateLimit.UserRate > 0 || p.RateLimit.UserConcurrency > 0 || len(p.RateLimit.FacadeRates) > 0 {
+		params.RateLimiter = jujuapi.NewRateLimiter(p.RateLimit)
+	}
+	s.apiParams = params
 
 	// Websockets require extra care when cookies are used for authentication
 	// to avoid CSRF attacks. https://portswigger.net/web-security/websockets/cross-site-websocket-hijacking
@@ -465,6 +958,14 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		"/model/{uuid}/{type:charms|applications}",
 		jimmhttp.NewHTTPProxyHandler(&s.jimm),
 	)
+	mountHandler(
+		"/dashboard-links",
+		jimmhttp.NewDashboardLinkHandler(&s.jimm),
+	)
+
+	if p.LegacyJEMAPI {
+		mountHandler("/v2", jemapi.NewLegacyHandler(&s.jimm))
+	}
 
 	// If the request is not for a known path assume it is part of the dashboard.
 	// If dashboard location env var is not defined, do not handle a dashboard.
@@ -485,14 +986,17 @@ func (s *Service) setupDischarger(p Params) (*discharger.MacaroonDischarger, err
 		MacaroonExpiryDuration: p.MacaroonExpiryDuration,
 		ControllerUUID:         p.ControllerUUID,
 	}
-	MacaroonDischarger, err := discharger.NewMacaroonDischarger(cfg, &s.jimm.Database, s.jimm.OpenFGAClient)
+	MacaroonDischarger, err := discharger.NewMacaroonDischarger(cfg, s.jimm.RootKeyStore(), s.jimm.OpenFGAClient)
 	if err != nil {
 		return nil, errors.E(err)
 	}
 	return MacaroonDischarger, nil
 }
 
-func (s *Service) setupSessionStore(ctx context.Context, sessionSecret []byte) (*pgstore.PGStore, error) {
+// setupSessionStore creates the session store. New sessions are signed with
+// sessionSecret, sessions signed with any of the previousSecrets continue
+// to be accepted.
+func (s *Service) setupSessionStore(ctx context.Context, sessionSecret []byte, previousSecrets ...[]byte) (*pgstore.PGStore, error) {
 	const op = errors.Op("setupSessionStore")
 
 	if s.jimm.CredentialStore == nil {
@@ -504,7 +1008,13 @@ func (s *Service) setupSessionStore(ctx context.Context, sessionSecret []byte) (
 		return nil, errors.E(op, err)
 	}
 
-	store, err := pgstore.NewPGStoreFromPool(sqlDb, sessionSecret)
+	// The store takes alternating hash and encryption keys, the first pair
+	// is used for encoding and all pairs are tried when decoding.
+	keyPairs := [][]byte{sessionSecret, nil}
+	for _, secret := range previousSecrets {
+		keyPairs = append(keyPairs, secret, nil)
+	}
+	store, err := pgstore.NewPGStoreFromPool(sqlDb, keyPairs...)
 	if err != nil {
 		zapctx.Error(ctx, "failed to create session store", zap.Error(err))
 		return nil, errors.E(op, err, "failed to create session store")
@@ -519,7 +1029,9 @@ func (s *Service) setupSessionStore(ctx context.Context, sessionSecret []byte) (
 	return store, nil
 }
 
-func openDB(ctx context.Context, dsn string, logSQL bool) (*gorm.DB, error) {
+// openDB opens the database with the given DSN. If ping is true the
+// database must be reachable.
+func openDB(ctx context.Context, dsn string, logSQL, ping bool) (*gorm.DB, error) {
 	zapctx.Info(ctx, "connecting database")
 
 	var dialect gorm.Dialector
@@ -532,7 +1044,8 @@ func openDB(ctx context.Context, dsn string, logSQL bool) (*gorm.DB, error) {
 		return nil, errors.E(errors.CodeServerConfiguration, "unsupported DSN")
 	}
 	return gorm.Open(dialect, &gorm.Config{
-		Logger: logger.GormLogger{LogSQL: logSQL},
+		Logger:               logger.GormLogger{LogSQL: logSQL},
+		DisableAutomaticPing: !ping,
 		NowFunc: func() time.Time {
 			// This is to set the timestamp precision at the service level.
 			return time.Now().Truncate(time.Microsecond)
@@ -547,7 +1060,7 @@ func (s *Service) setupCredentialStore(ctx context.Context, p Params) error {
 	if p.InsecureSecretStorage {
 		zapctx.Warn(ctx, "using plaintext postgres for secret storage")
 		s.jimm.CredentialStore = &s.jimm.Database
-		return nil
+		return s.setupControllerSecretStore(ctx, p)
 	}
 
 	vs, err := newVaultStore(ctx, p)
@@ -557,13 +1070,76 @@ func (s *Service) setupCredentialStore(ctx context.Context, p Params) error {
 	}
 	if vs != nil {
 		s.jimm.CredentialStore = vs
-		return nil
+		return s.setupControllerSecretStore(ctx, p)
 	}
 
 	return errors.E(op, "jimm cannot start without a credential store")
 }
 
-func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, error) {
+// setupControllerSecretStore configures the store, if any, used for
+// controller secrets in place of the credential store.
+func (s *Service) setupControllerSecretStore(ctx context.Context, p Params) error {
+	const op = errors.Op("setupControllerSecretStore")
+
+	switch p.ControllerSecretStore {
+	case "":
+		return nil
+	case "vault":
+		vs, ok := s.jimm.CredentialStore.(*vault.VaultStore)
+		if !ok {
+			var err error
+			vs, err = newVaultStore(ctx, p)
+			if err != nil {
+				return errors.E(op, err)
+			}
+		}
+		if vs == nil {
+			return errors.E(op, errors.CodeServerConfiguration, "vault controller secret store requires vault to be configured")
+		}
+		s.jimm.SecretStore = vs
+	case "kubernetes":
+		ks, err := newKubernetesSecretStore(p)
+		if err != nil {
+			return errors.E(op, errors.CodeServerConfiguration, err)
+		}
+		s.jimm.SecretStore = ks
+	default:
+		return errors.E(op, errors.CodeServerConfiguration, fmt.Sprintf("unknown controller secret store %q", p.ControllerSecretStore))
+	}
+	zapctx.Info(ctx, "using controller secret store", zap.String("store", p.ControllerSecretStore))
+	return nil
+}
+
+// serviceAccountNamespaceFile holds the namespace of the pod JIMM is
+// running in when it is deployed in Kubernetes.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// newKubernetesSecretStore returns a store for controller secrets using
+// the Kubernetes API server of the cluster JIMM is running in.
+func newKubernetesSecretStore(p Params) (*kubesecrets.SecretStore, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	namespace := p.KubernetesSecretNamespace
+	if namespace == "" {
+		buf, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, err
+		}
+		namespace = strings.TrimSpace(string(buf))
+	}
+	return &kubesecrets.SecretStore{
+		Client:    client,
+		Namespace: namespace,
+	}, nil
+}
+
+func newVaultStore(ctx context.Context, p Params) (*vault.VaultStore, error) {
 	if p.VaultRoleID == "" || p.VaultRoleSecretID == "" {
 		return nil, nil
 	}
@@ -577,6 +1153,9 @@ func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, er
 	if p.VaultAddress != "" {
 		cfg.Address = p.VaultAddress
 	}
+	if t, ok := cfg.HttpClient.Transport.(*http.Transport); ok {
+		t.TLSClientConfig = fips.ClientTLSConfig(t.TLSClientConfig)
+	}
 
 	client, err := vaultapi.NewClient(cfg)
 	if err != nil {
@@ -56,6 +56,32 @@ func TestDefaultService(t *testing.T) {
 	c.Check(resp.StatusCode, qt.Equals, http.StatusOK)
 }
 
+func TestSeparateAdminHandler(t *testing.T) {
+	c := qt.New(t)
+
+	_, _, cofgaParams, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	p := jimmtest.NewTestJimmParams(c)
+	p.OpenFGAParams = cofgaParamsToJIMMOpenFGAParams(*cofgaParams)
+	p.InsecureSecretStorage = true
+	p.SeparateAdminHandler = true
+	svc, err := jimmsvc.NewService(context.Background(), p)
+	c.Assert(err, qt.IsNil)
+	defer svc.Cleanup()
+
+	for _, path := range []string{"/debug/info", "/metrics", "/debug/pprof/"} {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", path, nil)
+		c.Assert(err, qt.IsNil)
+		svc.AdminHandler().ServeHTTP(rr, req)
+		c.Check(rr.Code, qt.Equals, http.StatusOK, qt.Commentf("admin handler %s", path))
+
+		rr = httptest.NewRecorder()
+		svc.ServeHTTP(rr, req)
+		c.Check(rr.Code, qt.Equals, http.StatusNotFound, qt.Commentf("main handler %s", path))
+	}
+}
+
 func TestServiceDoesNotStartWithoutCredentialStore(t *testing.T) {
 	c := qt.New(t)
 
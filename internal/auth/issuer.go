@@ -0,0 +1,266 @@
+// Copyright 2024 Canonical.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// IssuerParams holds the parameters of an additional OIDC issuer that
+// identities may authenticate with.
+type IssuerParams struct {
+	// Name is the name of the issuer. Clients may select the issuer by
+	// giving its name as a hint when logging in. The name must be
+	// unique and must not contain a ".".
+	Name string `json:"name"`
+
+	// IssuerURL is the URL of the OAuth2.0 server.
+	IssuerURL string `json:"issuer-url"`
+
+	// ClientID holds the OAuth2.0 client id registered with the issuer.
+	ClientID string `json:"client-id"`
+
+	// ClientSecret holds the OAuth2.0 client secret registered with the
+	// issuer.
+	ClientSecret string `json:"client-secret"`
+
+	// Scopes holds the scopes requested from the issuer.
+	Scopes []string `json:"scopes"`
+
+	// EmailDomains holds the email domains of the identities
+	// authenticated by the issuer. Identities with an email address in
+	// one of these domains are always authenticated by this issuer and
+	// the issuer may not authenticate identities in any other domain.
+	// At least one domain is required.
+	EmailDomains []string `json:"email-domains"`
+
+	// EmailClaim is the claim holding the identity's email address. If
+	// this is not set the "email" claim is used.
+	EmailClaim string `json:"email-claim,omitempty"`
+
+	// NameClaim is the claim holding the identity's display name. If
+	// this is not set the display name is taken from the standard
+	// profile claims.
+	NameClaim string `json:"name-claim,omitempty"`
+}
+
+// ParseIssuerParams parses a JSON list of additional issuers, as used in
+// the JIMM_OAUTH_ADDITIONAL_ISSUERS environment variable. An empty string
+// holds no issuers.
+func ParseIssuerParams(s string) ([]IssuerParams, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var issuers []IssuerParams
+	if err := json.Unmarshal([]byte(s), &issuers); err != nil {
+		return nil, errors.E(errors.CodeBadRequest, err, "invalid issuers")
+	}
+	if err := validateIssuerParams(issuers); err != nil {
+		return nil, err
+	}
+	return issuers, nil
+}
+
+// issuer holds an OIDC issuer that identities may authenticate with.
+type issuer struct {
+	// name is the name of the issuer, it is empty for the default issuer.
+	name string
+	// url is the URL of the issuer, as it appears in id tokens.
+	url string
+
+	provider    *oidc.Provider
+	oauthConfig oauth2.Config
+
+	emailDomains []string
+	emailClaim   string
+	nameClaim    string
+}
+
+// newIssuer returns an issuer created from the given parameters.
+func newIssuer(ctx context.Context, p IssuerParams, redirectURL string) (*issuer, error) {
+	provider, err := oidc.NewProvider(ctx, p.IssuerURL)
+	if err != nil {
+		zapctx.Error(ctx, "failed to create oidc provider", zap.String("issuer", p.Name), zap.Error(err))
+		return nil, errors.E(errors.CodeServerConfiguration, err, "failed to create oidc provider")
+	}
+	domains := make([]string, len(p.EmailDomains))
+	for i, d := range p.EmailDomains {
+		domains[i] = strings.ToLower(d)
+	}
+	emailClaim := p.EmailClaim
+	if emailClaim == "" {
+		emailClaim = "email"
+	}
+	return &issuer{
+		name:     p.Name,
+		url:      strings.TrimSuffix(p.IssuerURL, "/"),
+		provider: provider,
+		oauthConfig: oauth2.Config{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       p.Scopes,
+			RedirectURL:  redirectURL,
+		},
+		emailDomains: domains,
+		emailClaim:   emailClaim,
+		nameClaim:    p.NameClaim,
+	}, nil
+}
+
+// validateIssuerParams checks that the additional issuers can be told
+// apart from each other and from the default issuer.
+func validateIssuerParams(issuers []IssuerParams) error {
+	names := make(map[string]bool)
+	domains := make(map[string]bool)
+	for _, p := range issuers {
+		if p.Name == "" || strings.Contains(p.Name, ".") {
+			return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("invalid issuer name %q", p.Name))
+		}
+		if names[p.Name] {
+			return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("duplicate issuer name %q", p.Name))
+		}
+		names[p.Name] = true
+		if len(p.EmailDomains) == 0 {
+			return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("issuer %q has no email domains", p.Name))
+		}
+		for _, d := range p.EmailDomains {
+			d = strings.ToLower(d)
+			if domains[d] {
+				return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("email domain %q is assigned to more than one issuer", d))
+			}
+			domains[d] = true
+		}
+	}
+	return nil
+}
+
+// claims returns the profile claims held by the given id token or
+// userinfo response, applying the issuer's claim mappings.
+func (iss *issuer) claims(c interface{ Claims(any) error }) (profileClaims, error) {
+	var claims profileClaims
+	if err := c.Claims(&claims); err != nil {
+		return claims, err
+	}
+	if iss.emailClaim == "email" && iss.nameClaim == "" {
+		return claims, nil
+	}
+	var raw map[string]any
+	if err := c.Claims(&raw); err != nil {
+		return claims, err
+	}
+	claims.Email, _ = raw[iss.emailClaim].(string)
+	if iss.nameClaim != "" {
+		if name, ok := raw[iss.nameClaim].(string); ok {
+			claims.Name = name
+		}
+	}
+	return claims, nil
+}
+
+type issuerContextKey struct{}
+
+// ContextWithIssuer adds the name of the issuer used to authenticate the
+// identity to the provided context. An empty name selects the default
+// issuer.
+func ContextWithIssuer(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, issuerContextKey{}, name)
+}
+
+// IssuerFromState returns the name of the issuer encoded in the state
+// returned by AuthCodeURL.
+func IssuerFromState(state string) string {
+	name, _, ok := strings.Cut(state, ".")
+	if !ok {
+		return ""
+	}
+	return name
+}
+
+// issuerFromContext returns the issuer named in the context, or the
+// default issuer if there is none.
+func (as *AuthenticationService) issuerFromContext(ctx context.Context) *issuer {
+	name, _ := ctx.Value(issuerContextKey{}).(string)
+	for _, iss := range as.issuers {
+		if iss.name == name {
+			return iss
+		}
+	}
+	return as.issuers[0]
+}
+
+// selectIssuer returns the issuer selected by a client's hint. The hint
+// may be the name of an issuer, an email address or an email domain. If
+// no issuer matches the hint the default issuer is returned.
+func (as *AuthenticationService) selectIssuer(hint string) *issuer {
+	if hint == "" {
+		return as.issuers[0]
+	}
+	for _, iss := range as.issuers[1:] {
+		if iss.name == hint {
+			return iss
+		}
+	}
+	if _, domain, ok := strings.Cut(hint, "@"); ok {
+		hint = domain
+	}
+	return as.issuerForDomain(hint)
+}
+
+// issuerForEmail returns the issuer that authenticates the identity with
+// the given email address.
+func (as *AuthenticationService) issuerForEmail(email string) *issuer {
+	_, domain, _ := strings.Cut(email, "@")
+	return as.issuerForDomain(domain)
+}
+
+// issuerForDomain returns the issuer that authenticates identities with
+// email addresses in the given domain.
+func (as *AuthenticationService) issuerForDomain(domain string) *issuer {
+	domain = strings.ToLower(domain)
+	for _, iss := range as.issuers[1:] {
+		if slices.Contains(iss.emailDomains, domain) {
+			return iss
+		}
+	}
+	return as.issuers[0]
+}
+
+// issuerForIDToken returns the issuer that issued the given raw id
+// token. The token's signature is not verified, the returned issuer must
+// be used to verify it. If the issuer cannot be determined from the token
+// the issuer from the context is returned.
+func (as *AuthenticationService) issuerForIDToken(ctx context.Context, rawIDToken string) *issuer {
+	t, err := jwt.ParseString(rawIDToken, jwt.WithVerify(false), jwt.WithValidate(false))
+	if err != nil {
+		return as.issuerFromContext(ctx)
+	}
+	if iss := as.issuerForURL(t.Issuer()); iss != nil {
+		return iss
+	}
+	return as.issuerFromContext(ctx)
+}
+
+// issuerForURL returns the issuer with the given URL, or nil if there is
+// none.
+func (as *AuthenticationService) issuerForURL(url string) *issuer {
+	url = strings.TrimSuffix(url, "/")
+	for _, iss := range as.issuers {
+		if iss.url == url {
+			return iss
+		}
+	}
+	return nil
+}
@@ -11,11 +11,14 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
 	stderrors "errors"
 	"fmt"
 	"net/http"
 	"net/mail"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -48,6 +51,21 @@ const (
 
 type sessionIdentityContextKey struct{}
 
+type remoteAddrContextKey struct{}
+
+// ContextWithRemoteAddr adds the network address of the client to the
+// provided context.
+func ContextWithRemoteAddr(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, remoteAddrContextKey{}, addr)
+}
+
+// RemoteAddrFromContext returns the network address of the client from the
+// context, or an empty string if it is not known.
+func RemoteAddrFromContext(ctx context.Context) string {
+	s, _ := ctx.Value(remoteAddrContextKey{}).(string)
+	return s
+}
+
 // ContextWithSessionIdentity adds the session identity id to the provided context.
 func ContextWithSessionIdentity(ctx context.Context, sessionIdentityId any) context.Context {
 	return context.WithValue(ctx, sessionIdentityContextKey{}, sessionIdentityId)
@@ -69,21 +87,28 @@ func SessionIdentityFromContext(ctx context.Context) string {
 
 // AuthenticationService handles authentication within JIMM.
 type AuthenticationService struct {
-	oauthConfig oauth2.Config
-	// provider holds a OIDC provider wrapper for the OAuth2.0 /x/oauth package,
-	// enabling UserInfo calls, wellknown retrieval and jwks verification.
-	provider *oidc.Provider
+	// issuers holds the OIDC issuers identities may authenticate with.
+	// The first issuer is the default issuer, each holds an OIDC
+	// provider wrapper for the OAuth2.0 /x/oauth package, enabling
+	// UserInfo calls, wellknown retrieval and jwks verification.
+	issuers []*issuer
 	// sessionTokenExpiry holds the expiry time for JIMM minted session tokens (JWTs).
 	sessionTokenExpiry time.Duration
 	// sessionCookieMaxAge holds the max age for session cookies in seconds.
 	sessionCookieMaxAge int
 	// secureCookies decides whether to set the secure flag on cookies.
 	secureCookies bool
+	// keyMu protects jwtSessionKey and previousJWTSessionKeys, which
+	// may be replaced by SetSessionKeys.
+	keyMu sync.RWMutex
 	// jwtSessionKey holds the secret key used for signing/verifying JWT tokens.
 	// According to https://datatracker.ietf.org/doc/html/rfc7518 minimum key lengths are
 	// HSXXX e.g. HS256 - 256 bits, RSA - at least 2048 bits.
 	// In JIMM we use HS256, requiring a minimum of 32 bytes for the secret key.
 	jwtSessionKey string
+	// previousJWTSessionKeys holds retired keys that are still accepted
+	// when verifying JWT tokens, but are never used for signing.
+	previousJWTSessionKeys []string
 	// The key algorithm to use for verifying/signing JWTs.
 	signingAlg jwa.KeyAlgorithm
 
@@ -97,6 +122,7 @@ type AuthenticationService struct {
 type IdentityStore interface {
 	GetIdentity(ctx context.Context, u *dbmodel.Identity) error
 	UpdateIdentity(ctx context.Context, u *dbmodel.Identity) error
+	AddSession(ctx context.Context, session *dbmodel.Session) error
 }
 
 // AuthenticationServiceParams holds the parameters to initialise
@@ -116,6 +142,13 @@ type AuthenticationServiceParams struct {
 	// Scopes holds the scopes that you wish to retrieve.
 	Scopes []string
 
+	// AdditionalIssuers holds OIDC issuers, other than the one at
+	// IssuerURL, that identities may authenticate with. Identities are
+	// authenticated by the issuer assigned their email domain, or by
+	// the issuer at IssuerURL if their domain is not assigned to any
+	// additional issuer.
+	AdditionalIssuers []IssuerParams
+
 	// SessionTokenExpiry holds the expiry time of minted JIMM session tokens (JWTs).
 	SessionTokenExpiry time.Duration
 
@@ -129,6 +162,12 @@ type AuthenticationServiceParams struct {
 	// See AuthenticationService.JWTSessionKey for more details.
 	JWTSessionKey string
 
+	// PreviousJWTSessionKeys holds keys that have been rotated out. Tokens
+	// signed with these keys are still accepted until they expire, which
+	// allows JWTSessionKey to be changed without invalidating every active
+	// session at once.
+	PreviousJWTSessionKeys []string
+
 	// RedirectURL is the URL for handling the exchange of authorisation
 	// codes into access tokens (and id tokens), for JIMM, this is expected
 	// to be the servers own callback endpoint registered under /auth/callback.
@@ -148,35 +187,46 @@ type AuthenticationServiceParams struct {
 func NewAuthenticationService(ctx context.Context, params AuthenticationServiceParams) (*AuthenticationService, error) {
 	const op = errors.Op("auth.NewAuthenticationService")
 
-	provider, err := oidc.NewProvider(ctx, params.IssuerURL)
-	if err != nil {
-		zapctx.Error(ctx, "failed to create oidc provider", zap.Error(err))
-		return nil, errors.E(op, errors.CodeServerConfiguration, err, "failed to create oidc provider")
+	if err := validateIssuerParams(params.AdditionalIssuers); err != nil {
+		return nil, errors.E(op, err)
+	}
+	issuerParams := append([]IssuerParams{{
+		IssuerURL:    params.IssuerURL,
+		ClientID:     params.ClientID,
+		ClientSecret: params.ClientSecret,
+		Scopes:       params.Scopes,
+	}}, params.AdditionalIssuers...)
+	issuers := make([]*issuer, len(issuerParams))
+	for i, p := range issuerParams {
+		iss, err := newIssuer(ctx, p, params.RedirectURL)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		issuers[i] = iss
 	}
 
 	return &AuthenticationService{
-		provider: provider,
-		oauthConfig: oauth2.Config{
-			ClientID:     params.ClientID,
-			ClientSecret: params.ClientSecret,
-			Endpoint:     provider.Endpoint(),
-			Scopes:       params.Scopes,
-			RedirectURL:  params.RedirectURL,
-		},
-		sessionTokenExpiry:  params.SessionTokenExpiry,
-		jwtSessionKey:       params.JWTSessionKey,
-		signingAlg:          jwa.HS256,
-		db:                  params.Store,
-		sessionStore:        params.SessionStore,
-		sessionCookieMaxAge: params.SessionCookieMaxAge,
-		secureCookies:       params.SecureCookies,
+		issuers:                issuers,
+		sessionTokenExpiry:     params.SessionTokenExpiry,
+		jwtSessionKey:          params.JWTSessionKey,
+		previousJWTSessionKeys: params.PreviousJWTSessionKeys,
+		signingAlg:             jwa.HS256,
+		db:                     params.Store,
+		sessionStore:           params.SessionStore,
+		sessionCookieMaxAge:    params.SessionCookieMaxAge,
+		secureCookies:          params.SecureCookies,
 	}, nil
 }
 
 // AuthCodeURL returns a URL that will be used to redirect a browser to the identity provider.
 // It also generates a random state string that was used as part of the auth code URL. The state string
 // is returned alongside the auth code URL and any errors that occured during state generation.
-func (as *AuthenticationService) AuthCodeURL() (string, string, error) {
+//
+// The identity provider is selected by the hint, which may be the name of
+// an issuer, an email address or an email domain. The name of a selected
+// issuer other than the default is encoded in the state, it can be
+// recovered with IssuerFromState.
+func (as *AuthenticationService) AuthCodeURL(hint string) (string, string, error) {
 	// Hydra requires the state parameter to be at least 8 characters.
 	// Note that state is primarily a guard against csrf attacks.
 	// A good reference is https://spring.io/blog/2011/11/30/cross-site-request-forgery-and-oauth2
@@ -189,10 +239,15 @@ func (as *AuthenticationService) AuthCodeURL() (string, string, error) {
 		return "", "", errors.E(op, fmt.Sprintf("failed to generate state secret: %s", err.Error()))
 	}
 	state := base64.RawURLEncoding.EncodeToString(b)
-	return as.oauthConfig.AuthCodeURL(state), state, nil
+	iss := as.selectIssuer(hint)
+	if iss.name != "" {
+		state = iss.name + "." + state
+	}
+	return iss.oauthConfig.AuthCodeURL(state), state, nil
 }
 
-// Exchange exchanges an authorisation code for an access token.
+// Exchange exchanges an authorisation code for an access token. The code
+// is exchanged with the issuer named in the context, see ContextWithIssuer.
 //
 // TODO(ale8k): How to test this? A callback has to be made and it needs to be valid,
 // this may need some thought as to whether its actually worth testing or are we
@@ -201,10 +256,11 @@ func (as *AuthenticationService) AuthCodeURL() (string, string, error) {
 func (as *AuthenticationService) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
 	const op = errors.Op("auth.AuthenticationService.Exchange")
 
-	t, err := as.oauthConfig.Exchange(
+	iss := as.issuerFromContext(ctx)
+	t, err := iss.oauthConfig.Exchange(
 		ctx,
 		code,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", iss.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		return nil, errors.E(op, err, "authorisation code exchange failed")
@@ -230,9 +286,10 @@ func (as *AuthenticationService) Exchange(ctx context.Context, code string) (*oa
 func (as *AuthenticationService) Device(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
 	const op = errors.Op("auth.AuthenticationService.Device")
 
-	resp, err := as.oauthConfig.DeviceAuth(
+	iss := as.issuerFromContext(ctx)
+	resp, err := iss.oauthConfig.DeviceAuth(
 		ctx,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", iss.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		zapctx.Error(ctx, "device auth call failed", zap.Error(err))
@@ -249,10 +306,11 @@ func (as *AuthenticationService) Device(ctx context.Context) (*oauth2.DeviceAuth
 func (as *AuthenticationService) DeviceAccessToken(ctx context.Context, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
 	const op = errors.Op("auth.AuthenticationService.DeviceAccessToken")
 
-	t, err := as.oauthConfig.DeviceAccessToken(
+	iss := as.issuerFromContext(ctx)
+	t, err := iss.oauthConfig.DeviceAccessToken(
 		ctx,
 		res,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", iss.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		return nil, errors.E(op, err, "device access token call failed")
@@ -262,7 +320,8 @@ func (as *AuthenticationService) DeviceAccessToken(ctx context.Context, res *oau
 }
 
 // ExtractAndVerifyIDToken extracts the id token from the extras claims of an oauth2 token
-// and performs signature verification of the token.
+// and performs signature verification of the token, using the keys of the
+// issuer named in the token.
 func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error) {
 	const op = errors.Op("auth.AuthenticationService.ExtractAndVerifyIDToken")
 
@@ -272,8 +331,9 @@ func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, oa
 		return nil, errors.E(op, "failed to extract id token")
 	}
 
-	verifier := as.provider.Verifier(&oidc.Config{
-		ClientID: as.oauthConfig.ClientID,
+	iss := as.issuerForIDToken(ctx, rawIDToken)
+	verifier := iss.provider.Verifier(&oidc.Config{
+		ClientID: iss.oauthConfig.ClientID,
 	})
 
 	token, err := verifier.Verify(ctx, rawIDToken)
@@ -285,21 +345,28 @@ func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, oa
 	return token, nil
 }
 
-// Email retrieves the users email from an id token via the email claim
+// Email retrieves the users email from an id token via the issuer's email
+// claim. Issuers may only authenticate identities in the email domains
+// assigned to them.
 func (as *AuthenticationService) Email(idToken *oidc.IDToken) (string, error) {
 	const op = errors.Op("auth.AuthenticationService.Email")
 
-	var claims struct {
-		Email         string `json:"email"`
-		EmailVerified bool   `json:"email_verified"` // TODO(ale8k): Add verification logic
-	}
+	// TODO(ale8k): Add email_verified verification logic
 	if idToken == nil {
 		return "", errors.E(op, "id token is nil")
 	}
+	iss := as.issuerForURL(idToken.Issuer)
+	if iss == nil {
+		return "", errors.E(op, errors.CodeUnauthorized, "unknown issuer")
+	}
 
-	if err := idToken.Claims(&claims); err != nil {
+	claims, err := iss.claims(idToken)
+	if err != nil {
 		return "", errors.E(op, err, "failed to extract claims")
 	}
+	if as.issuerForEmail(claims.Email) != iss {
+		return "", errors.E(op, errors.CodeUnauthorized, "issuer cannot authenticate identities in this email domain")
+	}
 
 	return claims.Email, nil
 }
@@ -317,7 +384,10 @@ func (as *AuthenticationService) MintSessionToken(email string) (string, error)
 		return "", errors.E(op, err, "failed to build access token")
 	}
 
-	freshToken, err := jwt.Sign(token, jwt.WithKey(as.signingAlg, []byte(as.jwtSessionKey)))
+	as.keyMu.RLock()
+	key := as.jwtSessionKey
+	as.keyMu.RUnlock()
+	freshToken, err := jwt.Sign(token, jwt.WithKey(as.signingAlg, []byte(key)))
 	if err != nil {
 		zapctx.Error(context.Background(), "failed to sign access token", zap.Error(err))
 		return "", errors.E(op, err, "failed to sign access token")
@@ -356,7 +426,7 @@ func (as *AuthenticationService) VerifySessionToken(token string) (_ jwt.Token,
 		return nil, errorFn(fmt.Sprintf("failed to decode token: %s", err))
 	}
 
-	parsedToken, err := jwt.Parse(decodedToken, jwt.WithKey(as.signingAlg, []byte(as.jwtSessionKey)))
+	parsedToken, err := as.parseSessionToken(decodedToken)
 	if err != nil {
 		if stderrors.Is(err, jwt.ErrTokenExpired()) {
 			return nil, errorFn("JIMM session token expired")
@@ -371,6 +441,48 @@ func (as *AuthenticationService) VerifySessionToken(token string) (_ jwt.Token,
 	return parsedToken, nil
 }
 
+// parseSessionToken parses and verifies the given token using the
+// current session key, falling back to any previous session keys.
+func (as *AuthenticationService) parseSessionToken(token []byte) (jwt.Token, error) {
+	as.keyMu.RLock()
+	current, previous := as.jwtSessionKey, as.previousJWTSessionKeys
+	as.keyMu.RUnlock()
+	parsedToken, err := jwt.Parse(token, jwt.WithKey(as.signingAlg, []byte(current)))
+	if err == nil || stderrors.Is(err, jwt.ErrTokenExpired()) {
+		return parsedToken, err
+	}
+	for _, key := range previous {
+		t, perr := jwt.Parse(token, jwt.WithKey(as.signingAlg, []byte(key)))
+		if perr == nil || stderrors.Is(perr, jwt.ErrTokenExpired()) {
+			return t, perr
+		}
+	}
+	return nil, err
+}
+
+// SessionKeys returns the keys used for session tokens. The first key is
+// used to sign new tokens, the remaining keys are previous keys that are
+// still accepted when verifying tokens.
+func (as *AuthenticationService) SessionKeys() []string {
+	as.keyMu.RLock()
+	defer as.keyMu.RUnlock()
+	return append([]string{as.jwtSessionKey}, as.previousJWTSessionKeys...)
+}
+
+// SetSessionKeys replaces the keys used for session tokens. The first key
+// is used to sign new tokens, every key is accepted when verifying
+// tokens. Tokens signed with a key that is not given are rejected from
+// then on. SetSessionKeys does nothing if no keys are given.
+func (as *AuthenticationService) SetSessionKeys(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	as.keyMu.Lock()
+	defer as.keyMu.Unlock()
+	as.jwtSessionKey = keys[0]
+	as.previousJWTSessionKeys = append([]string(nil), keys[1:]...)
+}
+
 // UpdateIdentity updates the database with the display name and access token set for the user.
 // And, if present, a refresh token.
 func (as *AuthenticationService) UpdateIdentity(ctx context.Context, email string, token *oauth2.Token) error {
@@ -397,6 +509,23 @@ func (as *AuthenticationService) UpdateIdentity(ctx context.Context, email strin
 	u.RefreshToken = token.RefreshToken
 	u.AccessTokenExpiry = token.Expiry
 	u.AccessTokenType = token.TokenType
+
+	// Tokens from the identity provider carry an id token with the
+	// identity's profile. Failing to read it must not prevent the
+	// identity from logging in, the profile will be synchronised later.
+	if _, ok := token.Extra("id_token").(string); ok {
+		if idToken, err := as.ExtractAndVerifyIDToken(ctx, token); err != nil {
+			zapctx.Warn(ctx, "cannot read identity profile from id token", zap.String("identity", u.Name), zap.Error(err))
+		} else {
+			claims, err := as.issuerForEmail(email).claims(idToken)
+			if err != nil {
+				zapctx.Warn(ctx, "cannot read identity profile from id token", zap.String("identity", u.Name), zap.Error(err))
+			} else {
+				claims.apply(u)
+			}
+		}
+	}
+
 	if err := db.UpdateIdentity(ctx, u); err != nil {
 		return errors.E(op, err)
 	}
@@ -404,7 +533,87 @@ func (as *AuthenticationService) UpdateIdentity(ctx context.Context, email strin
 	return nil
 }
 
-// VerifyClientCredentials verifies the provided client ID and client secret.
+// SyncIdentityProfile updates the display name and email of the given
+// identity from the identity provider's userinfo endpoint, using the
+// identity's stored tokens. If the access token has expired it is
+// refreshed and the new tokens are stored with the profile.
+func (as *AuthenticationService) SyncIdentityProfile(ctx context.Context, u *dbmodel.Identity) error {
+	const op = errors.Op("auth.AuthenticationService.SyncIdentityProfile")
+
+	if u.RefreshToken == "" && u.AccessToken == "" {
+		return errors.E(op, errors.CodeBadRequest, "identity has no tokens")
+	}
+	iss := as.issuerForEmail(u.Name)
+	tSrc := iss.oauthConfig.TokenSource(ctx, &oauth2.Token{
+		AccessToken:  u.AccessToken,
+		RefreshToken: u.RefreshToken,
+		Expiry:       u.AccessTokenExpiry,
+		TokenType:    u.AccessTokenType,
+	})
+	info, err := iss.provider.UserInfo(ctx, tSrc)
+	if err != nil {
+		return errors.E(op, err, "failed to retrieve userinfo")
+	}
+	claims, err := iss.claims(info)
+	if err != nil {
+		return errors.E(op, err, "failed to extract claims")
+	}
+
+	// The token source only contacts the identity provider again if
+	// the token has expired since UserInfo was called.
+	t, err := tSrc.Token()
+	if err != nil {
+		return errors.E(op, err, "failed to refresh token")
+	}
+	u.AccessToken = t.AccessToken
+	u.RefreshToken = t.RefreshToken
+	u.AccessTokenExpiry = t.Expiry
+	u.AccessTokenType = t.TokenType
+	claims.apply(u)
+
+	if err := as.db.UpdateIdentity(ctx, u); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// profileClaims holds the standard OIDC claims from which an identity's
+// profile is taken.
+type profileClaims struct {
+	Email             string `json:"email"`
+	Name              string `json:"name"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// displayName returns the display name given by the claims, preferring
+// the full name, then the given and family names, then the preferred
+// username.
+func (c profileClaims) displayName() string {
+	if name := strings.TrimSpace(c.Name); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(c.GivenName + " " + c.FamilyName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(c.PreferredUsername)
+}
+
+// apply sets the identity's profile from the claims. Fields the identity
+// provider does not supply are left unchanged.
+func (c profileClaims) apply(u *dbmodel.Identity) {
+	if name := c.displayName(); name != "" {
+		u.DisplayName = name
+	}
+	if c.Email != "" {
+		u.Email = c.Email
+	}
+	u.ProfileSyncedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+}
+
+// VerifyClientCredentials verifies the provided client ID and client secret
+// with the default issuer.
 func (as *AuthenticationService) VerifyClientCredentials(ctx context.Context, clientID string, clientSecret string) (err error) {
 	defer func() {
 		if err != nil {
@@ -417,9 +626,9 @@ func (as *AuthenticationService) VerifyClientCredentials(ctx context.Context, cl
 	cfg := clientcredentials.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		TokenURL:     as.oauthConfig.Endpoint.TokenURL,
-		Scopes:       as.oauthConfig.Scopes,
-		AuthStyle:    oauth2.AuthStyle(as.oauthConfig.Endpoint.AuthStyle),
+		TokenURL:     as.issuers[0].oauthConfig.Endpoint.TokenURL,
+		Scopes:       as.issuers[0].oauthConfig.Scopes,
+		AuthStyle:    oauth2.AuthStyle(as.issuers[0].oauthConfig.Endpoint.AuthStyle),
 	}
 
 	_, err = cfg.Token(ctx)
@@ -464,6 +673,9 @@ func (as *AuthenticationService) CreateBrowserSession(
 	if err = session.Save(r, w); err != nil {
 		return errors.E(op, err)
 	}
+	if err := as.recordBrowserSession(ctx, session); err != nil {
+		return errors.E(op, err)
+	}
 	return nil
 }
 
@@ -575,9 +787,13 @@ func (as *AuthenticationService) Whoami(ctx context.Context) (*params.WhoamiResp
 		return nil, errors.E(op, err)
 	}
 
+	email := u.Email
+	if email == "" {
+		email = u.Name
+	}
 	return &params.WhoamiResponse{
 		DisplayName: u.DisplayName,
-		Email:       u.Name,
+		Email:       email,
 	}, nil
 
 }
@@ -625,13 +841,13 @@ func (as *AuthenticationService) validateAndUpdateAccessToken(ctx context.Contex
 }
 
 // refreshIdentitiesToken creates a token source based on the expired token and performs
-// a manual token refresh, updating the identity afterwards.
+// a manual token refresh with the identity's issuer, updating the identity afterwards.
 //
 // This is to be called only when a token is expired.
 func (as *AuthenticationService) refreshIdentitiesToken(ctx context.Context, email string, t *oauth2.Token) error {
 	const op = errors.Op("auth.AuthenticationService.refreshIdentitiesToken")
 
-	tSrc := as.oauthConfig.TokenSource(ctx, t)
+	tSrc := as.issuerForEmail(email).oauthConfig.TokenSource(ctx, t)
 
 	// Get a new access and refresh token (token source only has Token())
 	newToken, err := tSrc.Token()
@@ -674,6 +890,25 @@ func (as *AuthenticationService) modifySession(session *sessions.Session, w http
 	if err := session.Save(req, w); err != nil {
 		return errors.E(op, err)
 	}
+	if err := as.recordBrowserSession(req.Context(), session); err != nil {
+		return errors.E(op, err)
+	}
 
 	return nil
 }
+
+// recordBrowserSession records the given browser session, with its
+// current expiry time, so that it can be listed and revoked. Sessions
+// without an ID or an identity are not recorded.
+func (as *AuthenticationService) recordBrowserSession(ctx context.Context, session *sessions.Session) error {
+	identityId, _ := session.Values[SessionIdentityKey].(string)
+	if session.ID == "" || identityId == "" {
+		return nil
+	}
+	return as.db.AddSession(ctx, &dbmodel.Session{
+		IdentityName: identityId,
+		Kind:         dbmodel.BrowserSession,
+		Key:          session.ID,
+		ExpiresAt:    time.Now().Add(time.Duration(session.Options.MaxAge) * time.Second),
+	})
+}
@@ -69,7 +69,7 @@ func TestAuthCodeURL(t *testing.T) {
 	authSvc, _, _, cleanup := setupTestAuthSvc(ctx, c, time.Hour)
 	defer cleanup()
 
-	url, state, err := authSvc.AuthCodeURL()
+	url, state, err := authSvc.AuthCodeURL("")
 	c.Assert(err, qt.IsNil)
 	c.Assert(
 		url,
@@ -79,6 +79,105 @@ func TestAuthCodeURL(t *testing.T) {
 	c.Assert(len(state), qt.Not(qt.Equals), 0)
 }
 
+// newTestIssuer starts a server that serves the OIDC discovery document
+// of an issuer, enough to configure an AuthenticationService.
+func newTestIssuer(c *qt.C) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issuer":%q,"authorization_endpoint":%q,"token_endpoint":%q,"jwks_uri":%q}`,
+			srv.URL, srv.URL+"/auth", srv.URL+"/token", srv.URL+"/jwks")
+	}))
+	c.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAuthCodeURLSelectsIssuer(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	defaultIssuer := newTestIssuer(c)
+	corpIssuer := newTestIssuer(c)
+	authSvc, err := auth.NewAuthenticationService(ctx, auth.AuthenticationServiceParams{
+		IssuerURL:   defaultIssuer.URL,
+		ClientID:    "jimm",
+		Scopes:      []string{oidc.ScopeOpenID, "email"},
+		RedirectURL: "http://localhost:8080/auth/callback",
+		AdditionalIssuers: []auth.IssuerParams{{
+			Name:         "corp",
+			IssuerURL:    corpIssuer.URL,
+			ClientID:     "jimm-corp",
+			Scopes:       []string{oidc.ScopeOpenID, "corp-profile"},
+			EmailDomains: []string{"corp.example.com"},
+		}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		hint       string
+		expectURL  string
+		expectName string
+	}{{
+		hint:      "",
+		expectURL: defaultIssuer.URL + "/auth",
+	}, {
+		hint:      "alice@example.com",
+		expectURL: defaultIssuer.URL + "/auth",
+	}, {
+		hint:       "corp",
+		expectURL:  corpIssuer.URL + "/auth",
+		expectName: "corp",
+	}, {
+		hint:       "alice@CORP.example.com",
+		expectURL:  corpIssuer.URL + "/auth",
+		expectName: "corp",
+	}, {
+		hint:       "corp.example.com",
+		expectURL:  corpIssuer.URL + "/auth",
+		expectName: "corp",
+	}}
+	for _, test := range tests {
+		c.Run(test.hint, func(c *qt.C) {
+			authURL, state, err := authSvc.AuthCodeURL(test.hint)
+			c.Assert(err, qt.IsNil)
+			u, err := url.Parse(authURL)
+			c.Assert(err, qt.IsNil)
+			c.Check(u.Scheme+"://"+u.Host+u.Path, qt.Equals, test.expectURL)
+			c.Check(u.Query().Get("state"), qt.Equals, state)
+			c.Check(auth.IssuerFromState(state), qt.Equals, test.expectName)
+		})
+	}
+}
+
+func TestNewAuthenticationServiceRejectsAmbiguousIssuers(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	srv := newTestIssuer(c)
+	_, err := auth.NewAuthenticationService(ctx, auth.AuthenticationServiceParams{
+		IssuerURL: srv.URL,
+		AdditionalIssuers: []auth.IssuerParams{{
+			Name:         "a",
+			IssuerURL:    srv.URL,
+			EmailDomains: []string{"example.com"},
+		}, {
+			Name:         "b",
+			IssuerURL:    srv.URL,
+			EmailDomains: []string{"EXAMPLE.com"},
+		}},
+	})
+	c.Check(err, qt.ErrorMatches, `email domain "example.com" is assigned to more than one issuer`)
+
+	_, err = auth.NewAuthenticationService(ctx, auth.AuthenticationServiceParams{
+		IssuerURL: srv.URL,
+		AdditionalIssuers: []auth.IssuerParams{{
+			Name:      "a",
+			IssuerURL: srv.URL,
+		}},
+	})
+	c.Check(err, qt.ErrorMatches, `issuer "a" has no email domains`)
+}
+
 // TestDevice is a unique test in that it runs through the entire device oauth2.0
 // flow and additionally ensures the id token is verified and correct.
 //
@@ -247,6 +346,85 @@ func TestSessionTokenValidatesEmail(t *testing.T) {
 	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
 }
 
+func TestSessionTokenKeyRotation(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	authSvc, db, sessionStore, cleanup := setupTestAuthSvc(ctx, c, time.Hour)
+	defer cleanup()
+
+	token, err := authSvc.MintSessionToken("jimm-test@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	newAuthSvc := func(previousKeys ...string) *auth.AuthenticationService {
+		svc, err := auth.NewAuthenticationService(ctx, auth.AuthenticationServiceParams{
+			IssuerURL:              "http://localhost:8082/realms/jimm",
+			ClientID:               "jimm-device",
+			ClientSecret:           "SwjDofnbDzJDm9iyfUhEp67FfUFMY8L4",
+			Scopes:                 []string{oidc.ScopeOpenID, "profile", "email"},
+			SessionTokenExpiry:     time.Hour,
+			RedirectURL:            "http://localhost:8080/auth/callback",
+			Store:                  db,
+			SessionStore:           sessionStore,
+			SessionCookieMaxAge:    60,
+			JWTSessionKey:          "rotated-secret-key",
+			PreviousJWTSessionKeys: previousKeys,
+		})
+		c.Assert(err, qt.IsNil)
+		return svc
+	}
+
+	// Tokens signed with a previous key are still accepted.
+	rotatedSvc := newAuthSvc("secret-key")
+	jwtToken, err := rotatedSvc.VerifySessionToken(token)
+	c.Assert(err, qt.IsNil)
+	c.Assert(jwtToken.Subject(), qt.Equals, "jimm-test@canonical.com")
+
+	// New tokens are signed with the current key.
+	newToken, err := rotatedSvc.MintSessionToken("jimm-test@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = authSvc.VerifySessionToken(newToken)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+
+	// Once the previous key is retired its tokens are rejected.
+	retiredSvc := newAuthSvc()
+	_, err = retiredSvc.VerifySessionToken(token)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+}
+
+func TestSetSessionKeys(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	authSvc, _, _, cleanup := setupTestAuthSvc(ctx, c, time.Hour)
+	defer cleanup()
+	c.Check(authSvc.SessionKeys(), qt.DeepEquals, []string{"secret-key"})
+
+	token, err := authSvc.MintSessionToken("jimm-test@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	// After one rotation the replaced key is still accepted.
+	authSvc.SetSessionKeys([]string{"rotated-key-1", "secret-key"})
+	_, err = authSvc.VerifySessionToken(token)
+	c.Assert(err, qt.IsNil)
+	newToken, err := authSvc.MintSessionToken("jimm-test@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	// After a second rotation it is rejected.
+	authSvc.SetSessionKeys([]string{"rotated-key-2", "rotated-key-1"})
+	_, err = authSvc.VerifySessionToken(token)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+	_, err = authSvc.VerifySessionToken(newToken)
+	c.Assert(err, qt.IsNil)
+	c.Check(authSvc.SessionKeys(), qt.DeepEquals, []string{"rotated-key-2", "rotated-key-1"})
+
+	// Setting no keys leaves the keys unchanged.
+	authSvc.SetSessionKeys(nil)
+	c.Check(authSvc.SessionKeys(), qt.DeepEquals, []string{"rotated-key-2", "rotated-key-1"})
+}
+
 func TestVerifyClientCredentials(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+const (
+	// azureManagementEndpoint is the Azure resource manager endpoint
+	// used if the cloud does not specify one.
+	azureManagementEndpoint = "https://management.azure.com"
+
+	// azureIdentityEndpoint is the Azure identity endpoint used if the
+	// cloud does not specify one.
+	azureIdentityEndpoint = "https://login.microsoftonline.com"
+
+	// azureAPIVersion is the resource manager API version used to read
+	// subscriptions.
+	azureAPIVersion = "2020-01-01"
+)
+
+var azureAuthorizationURIRegexp = regexp.MustCompile(`authorization_uri="([^"]*)"`)
+
+// validateAzure checks an Azure service principal credential by
+// obtaining a token for the service principal and reading the
+// credential's subscription with it.
+func validateAzure(ctx context.Context, v *Validator, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	if cred.AuthType != "service-principal-secret" {
+		return nil
+	}
+	if err := requireAttributes("azure", cred, "application-id", "application-password", "subscription-id"); err != nil {
+		return err
+	}
+	management := strings.TrimSuffix(v.endpoint("azure", cloud.Endpoint), "/")
+	if management == "" {
+		management = azureManagementEndpoint
+	}
+	identity := strings.TrimSuffix(v.endpoint("azure-identity", cloud.IdentityEndpoint), "/")
+	if identity == "" {
+		identity = azureIdentityEndpoint
+	}
+	subscriptionURL := fmt.Sprintf("%s/subscriptions/%s?api-version=%s", management, url.PathEscape(cred.Attributes["subscription-id"]), azureAPIVersion)
+
+	tenant, err := azureTenant(ctx, v.httpClient(), subscriptionURL)
+	if err != nil {
+		return err
+	}
+	cfg := clientcredentials.Config{
+		ClientID:     cred.Attributes["application-id"],
+		ClientSecret: cred.Attributes["application-password"],
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", identity, url.PathEscape(tenant)),
+		Scopes:       []string{management + "/.default"},
+	}
+	client := cfg.Client(context.WithValue(ctx, oauth2.HTTPClient, v.httpClient()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscriptionURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return oauth2Error("azure", cred.AuthType, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return &Error{
+			Provider:  "azure",
+			AuthType:  cred.AuthType,
+			Attribute: "subscription-id",
+			Reason:    "subscription not accessible to the service principal",
+		}
+	default:
+		return fmt.Errorf("unexpected response reading subscription: %s", resp.Status)
+	}
+}
+
+// azureTenant discovers the tenant that owns the subscription at the
+// given URL. An unauthenticated request for the subscription is refused
+// with a challenge naming the tenant's authorization URI.
+func azureTenant(ctx context.Context, client *http.Client, subscriptionURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscriptionURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return "", fmt.Errorf("unexpected response discovering tenant: %s", resp.Status)
+	}
+	match := azureAuthorizationURIRegexp.FindStringSubmatch(resp.Header.Get("WWW-Authenticate"))
+	if match == nil {
+		return "", fmt.Errorf("authorization_uri not found in challenge")
+	}
+	u, err := url.Parse(match[1])
+	if err != nil {
+		return "", err
+	}
+	tenant := strings.Trim(u.Path, "/")
+	if tenant == "" || strings.Contains(tenant, "/") {
+		return "", fmt.Errorf("invalid authorization_uri %q", match[1])
+	}
+	return tenant, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Canonical.
+
+// Package credentialvalidator checks cloud credentials against the APIs of
+// the cloud providers that issued them, so that credentials that the
+// provider would reject are not pushed to controllers.
+package credentialvalidator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// defaultTimeout is the time allowed for a provider to respond when no
+// timeout is configured.
+const defaultTimeout = 10 * time.Second
+
+// An Error is returned when a cloud provider rejects a credential.
+type Error struct {
+	// Provider is the provider type of the cloud, for example "ec2".
+	Provider string
+
+	// AuthType is the auth-type of the rejected credential.
+	AuthType string
+
+	// Attribute, if set, is the credential attribute that caused the
+	// credential to be rejected.
+	Attribute string
+
+	// Reason describes why the credential was rejected.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Attribute != "" {
+		return fmt.Sprintf("invalid %s credential: %s: %s", e.Provider, e.Attribute, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s credential: %s", e.Provider, e.Reason)
+}
+
+// ErrorCode returns the API error code for an invalid credential.
+func (e *Error) ErrorCode() string {
+	return apiparams.CodeCredentialInvalid
+}
+
+// Info returns the structured details of the error, as returned to API
+// clients.
+func (e *Error) Info() map[string]interface{} {
+	info := map[string]interface{}{
+		"provider":  e.Provider,
+		"auth-type": e.AuthType,
+		"reason":    e.Reason,
+	}
+	if e.Attribute != "" {
+		info["attribute"] = e.Attribute
+	}
+	return info
+}
+
+// A validateFunc checks a credential of a particular provider type. It
+// returns an *Error if the provider rejects the credential and nil if
+// the credential is accepted or cannot be checked. Any other error means
+// the provider could not be asked.
+type validateFunc func(ctx context.Context, v *Validator, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error
+
+// validators holds the validateFunc for each supported provider type.
+var validators = map[string]validateFunc{
+	"azure":     validateAzure,
+	"ec2":       validateEC2,
+	"gce":       validateGCE,
+	"openstack": validateOpenStack,
+}
+
+// A Validator checks cloud credentials with the API of the cloud's
+// provider. Only the provider types "azure", "ec2", "gce" and
+// "openstack" are checked, credentials for other clouds are always
+// accepted.
+type Validator struct {
+	// HTTPClient is the client used to contact the providers. If this
+	// is nil http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Timeout is the time allowed for a provider to respond. If this is
+	// zero ten seconds is used.
+	Timeout time.Duration
+
+	// endpoints overrides the well-known provider endpoints, it is
+	// only set in tests.
+	endpoints map[string]string
+}
+
+// Validate checks the given credential for the given cloud with the
+// cloud's provider. An *Error is returned if the provider rejects the
+// credential. A credential is accepted if the provider cannot be reached,
+// so that an unavailable provider does not prevent credentials being
+// updated; the failure is logged.
+func (v *Validator) Validate(ctx context.Context, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	f, ok := validators[cloud.Type]
+	if !ok {
+		return nil
+	}
+	timeout := v.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := f(ctx, v, cloud, cred)
+	if _, ok := err.(*Error); ok || err == nil {
+		return err
+	}
+	zapctx.Warn(ctx, "cannot validate cloud credential", zap.String("cloud", cloud.Name), zap.String("provider", cloud.Type), zap.Error(err))
+	return nil
+}
+
+// httpClient returns the HTTP client used to contact providers.
+func (v *Validator) httpClient() *http.Client {
+	if v.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return v.HTTPClient
+}
+
+// endpoint returns the endpoint with the given name, or def if it has not
+// been overridden.
+func (v *Validator) endpoint(name, def string) string {
+	if e, ok := v.endpoints[name]; ok {
+		return e
+	}
+	return def
+}
+
+// requireAttributes returns an *Error naming the first of the given
+// attributes missing from the credential.
+func requireAttributes(provider string, cred jujuparams.CloudCredential, attrs ...string) error {
+	for _, attr := range attrs {
+		if cred.Attributes[attr] == "" {
+			return &Error{
+				Provider:  provider,
+				AuthType:  cred.AuthType,
+				Attribute: attr,
+				Reason:    "attribute not set",
+			}
+		}
+	}
+	return nil
+}
+
+// cloudEndpoint returns the endpoint of the given cloud, preferring the
+// endpoint of the first region that has one.
+func cloudEndpoint(cloud *dbmodel.Cloud) string {
+	for _, r := range cloud.Regions {
+		if r.Endpoint != "" {
+			return r.Endpoint
+		}
+	}
+	return cloud.Endpoint
+}
@@ -0,0 +1,244 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/credentialvalidator"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestValidateUnknownProvider(t *testing.T) {
+	c := qt.New(t)
+
+	var v credentialvalidator.Validator
+	err := v.Validate(context.Background(), &dbmodel.Cloud{Name: "test", Type: "lxd"}, jujuparams.CloudCredential{
+		AuthType: "certificate",
+	})
+	c.Assert(err, qt.IsNil)
+}
+
+func TestValidateMissingAttribute(t *testing.T) {
+	c := qt.New(t)
+
+	var v credentialvalidator.Validator
+	err := v.Validate(context.Background(), &dbmodel.Cloud{Name: "aws", Type: "ec2"}, jujuparams.CloudCredential{
+		AuthType:   "access-key",
+		Attributes: map[string]string{"access-key": "AKIA"},
+	})
+	c.Assert(err, qt.ErrorMatches, `invalid ec2 credential: secret-key: attribute not set`)
+	c.Check(errors.E(err).(*errors.Error).Code, qt.Equals, errors.CodeCredentialInvalid)
+	verr, ok := err.(*credentialvalidator.Error)
+	c.Assert(ok, qt.IsTrue)
+	c.Check(verr.Info(), qt.DeepEquals, map[string]interface{}{
+		"provider":  "ec2",
+		"auth-type": "access-key",
+		"attribute": "secret-key",
+		"reason":    "attribute not set",
+	})
+}
+
+func TestValidateEC2(t *testing.T) {
+	c := qt.New(t)
+
+	valid := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if !valid {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><GetCallerIdentityResult><Arn>arn:aws:iam::123456789012:user/jimm</Arn><UserId>AIDA</UserId><Account>123456789012</Account></GetCallerIdentityResult><ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></GetCallerIdentityResponse>`)
+	}))
+	defer srv.Close()
+
+	var v credentialvalidator.Validator
+	v.SetEndpoint("ec2", srv.URL)
+	cloud := dbmodel.Cloud{Name: "aws", Type: "ec2", Regions: []dbmodel.CloudRegion{{Name: "eu-west-1"}}}
+	cred := jujuparams.CloudCredential{
+		AuthType:   "access-key",
+		Attributes: map[string]string{"access-key": "AKIA", "secret-key": "secret"},
+	}
+
+	err := v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.ErrorMatches, `invalid ec2 credential: The security token included in the request is invalid.`)
+
+	valid = true
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestValidateGCE(t *testing.T) {
+	c := qt.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, qt.IsNil)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	c.Assert(err, qt.IsNil)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	valid := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !valid {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer srv.Close()
+
+	var v credentialvalidator.Validator
+	v.SetEndpoint("gce", srv.URL)
+	cloud := dbmodel.Cloud{Name: "google", Type: "gce"}
+	cred := jujuparams.CloudCredential{
+		AuthType: "oauth2",
+		Attributes: map[string]string{
+			"client-email": "jimm@project.iam.gserviceaccount.com",
+			"private-key":  pemKey,
+		},
+	}
+
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.ErrorMatches, `invalid gce credential: Invalid JWT Signature.`)
+
+	valid = true
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.IsNil)
+
+	file, err := json.Marshal(map[string]string{
+		"client_email": "jimm@project.iam.gserviceaccount.com",
+		"private_key":  pemKey,
+	})
+	c.Assert(err, qt.IsNil)
+	err = v.Validate(context.Background(), &cloud, jujuparams.CloudCredential{
+		AuthType:   "jsonfile",
+		Attributes: map[string]string{"file": string(file)},
+	})
+	c.Assert(err, qt.IsNil)
+
+	cred.Attributes["private-key"] = "not a key"
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.ErrorMatches, `invalid gce credential: private-key: invalid private key`)
+}
+
+func TestValidateAzure(t *testing.T) {
+	c := qt.New(t)
+
+	const tenant = "00000000-0000-0000-0000-000000000001"
+	const subscription = "00000000-0000-0000-0000-000000000002"
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/subscriptions/"+subscription, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer authorization_uri="%s/%s", error="invalid_token"`, srv.URL, tenant))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"subscriptionId":"%s"}`, subscription)
+	})
+	mux.HandleFunc("/"+tenant+"/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"Invalid client secret provided."}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	})
+
+	var v credentialvalidator.Validator
+	cloud := dbmodel.Cloud{Name: "azure", Type: "azure", Endpoint: srv.URL, IdentityEndpoint: srv.URL}
+	cred := jujuparams.CloudCredential{
+		AuthType: "service-principal-secret",
+		Attributes: map[string]string{
+			"application-id":       "app",
+			"application-password": "wrong",
+			"subscription-id":      subscription,
+		},
+	}
+
+	err := v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.ErrorMatches, `invalid azure credential: Invalid client secret provided.`)
+
+	cred.Attributes["application-password"] = "secret"
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestValidateOpenStack(t *testing.T) {
+	c := qt.New(t)
+
+	var auth map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, qt.Equals, "/v3/auth/tokens")
+		auth = nil
+		err := json.NewDecoder(r.Body).Decode(&auth)
+		c.Check(err, qt.IsNil)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Content-Type") != "application/json" || !validKeystonePassword(auth) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"code":401,"message":"The request you have made requires authentication.","title":"Unauthorized"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token":{}}`)
+	}))
+	defer srv.Close()
+
+	var v credentialvalidator.Validator
+	cloud := dbmodel.Cloud{Name: "openstack", Type: "openstack", Endpoint: srv.URL + "/v3"}
+	cred := jujuparams.CloudCredential{
+		AuthType: "userpass",
+		Attributes: map[string]string{
+			"username":    "jimm",
+			"password":    "wrong",
+			"tenant-name": "project",
+			"domain-name": "example",
+		},
+	}
+
+	err := v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.ErrorMatches, `invalid openstack credential: The request you have made requires authentication.`)
+
+	cred.Attributes["password"] = "secret"
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.IsNil)
+	c.Check(auth["auth"].(map[string]interface{})["scope"], qt.DeepEquals, map[string]interface{}{
+		"project": map[string]interface{}{
+			"name":   "project",
+			"domain": map[string]interface{}{"name": "example"},
+		},
+	})
+
+	// A provider that cannot be reached does not prevent the
+	// credential being used.
+	srv.Close()
+	err = v.Validate(context.Background(), &cloud, cred)
+	c.Assert(err, qt.IsNil)
+}
+
+func validKeystonePassword(auth map[string]interface{}) bool {
+	a, _ := auth["auth"].(map[string]interface{})
+	identity, _ := a["identity"].(map[string]interface{})
+	password, _ := identity["password"].(map[string]interface{})
+	user, _ := password["user"].(map[string]interface{})
+	return user["name"] == "jimm" && user["password"] == "secret"
+}
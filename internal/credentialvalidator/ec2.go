@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+// ec2RejectedCodes holds the STS error codes returned for access keys
+// that AWS does not accept.
+var ec2RejectedCodes = map[string]bool{
+	"InvalidClientTokenId":  true,
+	"SignatureDoesNotMatch": true,
+	"AccessDenied":          true,
+	"ExpiredToken":          true,
+}
+
+// validateEC2 checks an AWS access-key credential by asking STS for the
+// identity that owns the key. Instance-role credentials can only be
+// checked from an EC2 instance and are always accepted.
+func validateEC2(ctx context.Context, v *Validator, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	if cred.AuthType != "access-key" {
+		return nil
+	}
+	if err := requireAttributes("ec2", cred, "access-key", "secret-key"); err != nil {
+		return err
+	}
+	region := "us-east-1"
+	if len(cloud.Regions) > 0 {
+		region = cloud.Regions[0].Name
+	}
+	opts := sts.Options{
+		Region:           region,
+		Credentials:      credentials.NewStaticCredentialsProvider(cred.Attributes["access-key"], cred.Attributes["secret-key"], ""),
+		HTTPClient:       v.httpClient(),
+		RetryMaxAttempts: 1,
+	}
+	if e := v.endpoint("ec2", ""); e != "" {
+		opts.BaseEndpoint = aws.String(e)
+	}
+	_, err := sts.New(opts).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	var apiErr smithy.APIError
+	if stderrors.As(err, &apiErr) && ec2RejectedCodes[apiErr.ErrorCode()] {
+		return &Error{
+			Provider: "ec2",
+			AuthType: cred.AuthType,
+			Reason:   apiErr.ErrorMessage(),
+		}
+	}
+	return err
+}
@@ -0,0 +1,11 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator
+
+// SetEndpoint overrides the named provider endpoint.
+func (v *Validator) SetEndpoint(name, url string) {
+	if v.endpoints == nil {
+		v.endpoints = make(map[string]string)
+	}
+	v.endpoints[name] = url
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	stderrors "errors"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+// gceTokenURL is the Google OAuth2.0 token endpoint.
+const gceTokenURL = "https://oauth2.googleapis.com/token"
+
+// gceScope is the scope requested when checking a GCE credential.
+const gceScope = "https://www.googleapis.com/auth/compute"
+
+// validateGCE checks a GCE service account credential by exchanging a
+// token signed with the account's private key for an access token.
+func validateGCE(ctx context.Context, v *Validator, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	var email, key string
+	switch cred.AuthType {
+	case "oauth2":
+		if err := requireAttributes("gce", cred, "client-email", "private-key"); err != nil {
+			return err
+		}
+		email, key = cred.Attributes["client-email"], cred.Attributes["private-key"]
+	case "jsonfile":
+		if err := requireAttributes("gce", cred, "file"); err != nil {
+			return err
+		}
+		var f struct {
+			ClientEmail string `json:"client_email"`
+			PrivateKey  string `json:"private_key"`
+		}
+		if err := json.Unmarshal([]byte(cred.Attributes["file"]), &f); err != nil || f.ClientEmail == "" || f.PrivateKey == "" {
+			return &Error{
+				Provider:  "gce",
+				AuthType:  cred.AuthType,
+				Attribute: "file",
+				Reason:    "not a service account key file",
+			}
+		}
+		email, key = f.ClientEmail, f.PrivateKey
+	default:
+		return nil
+	}
+
+	if !validPrivateKey(key) {
+		attr := "private-key"
+		if cred.AuthType == "jsonfile" {
+			attr = "file"
+		}
+		return &Error{
+			Provider:  "gce",
+			AuthType:  cred.AuthType,
+			Attribute: attr,
+			Reason:    "invalid private key",
+		}
+	}
+	cfg := jwt.Config{
+		Email:      email,
+		PrivateKey: []byte(key),
+		Scopes:     []string{gceScope},
+		TokenURL:   v.endpoint("gce", gceTokenURL),
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, v.httpClient())
+	_, err := cfg.TokenSource(ctx).Token()
+	return oauth2Error("gce", cred.AuthType, err)
+}
+
+// validPrivateKey reports whether the given key is a PEM encoded PKCS#1
+// or PKCS#8 private key, as used to sign service account tokens.
+func validPrivateKey(key string) bool {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return false
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return true
+	}
+	_, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	return err == nil
+}
+
+// oauth2Error converts an error from an OAuth2.0 token request into an
+// *Error if the token endpoint rejected the client's credentials.
+func oauth2Error(provider, authType string, err error) error {
+	var rerr *oauth2.RetrieveError
+	if !stderrors.As(err, &rerr) {
+		return err
+	}
+	if rerr.Response == nil || rerr.Response.StatusCode < 400 || rerr.Response.StatusCode >= 500 {
+		return err
+	}
+	reason := rerr.ErrorDescription
+	if reason == "" {
+		// Not every token source parses the error response.
+		var body struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(rerr.Body, &body) == nil {
+			reason = body.ErrorDescription
+			if reason == "" {
+				reason = body.Error
+			}
+		}
+	}
+	if reason == "" {
+		reason = rerr.ErrorCode
+	}
+	if reason == "" {
+		reason = rerr.Response.Status
+	}
+	return &Error{Provider: provider, AuthType: authType, Reason: reason}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package credentialvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+// validateOpenStack checks an OpenStack username and password credential
+// by requesting a token from the cloud's Keystone v3 identity service.
+// Access-key credentials and clouds using Keystone v2 are always
+// accepted.
+func validateOpenStack(ctx context.Context, v *Validator, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	if cred.AuthType != "userpass" || cred.Attributes["version"] == "2" {
+		return nil
+	}
+	if err := requireAttributes("openstack", cred, "username", "password"); err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(v.endpoint("openstack", cloudEndpoint(cloud)), "/")
+	if endpoint == "" {
+		return nil
+	}
+	if strings.HasSuffix(endpoint, "/v2.0") {
+		return nil
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/v3")
+
+	body, err := json.Marshal(keystoneAuthRequest(cred.Attributes))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v3/auth/tokens", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		var kerr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		reason := resp.Status
+		if json.NewDecoder(resp.Body).Decode(&kerr) == nil && kerr.Error.Message != "" {
+			reason = kerr.Error.Message
+		}
+		return &Error{
+			Provider: "openstack",
+			AuthType: cred.AuthType,
+			Reason:   reason,
+		}
+	default:
+		return fmt.Errorf("unexpected response from identity service: %s", resp.Status)
+	}
+}
+
+// keystoneAuthRequest returns a Keystone v3 password authentication
+// request for the given credential attributes, scoped to the
+// credential's project if it names one.
+func keystoneAuthRequest(attrs map[string]string) map[string]interface{} {
+	userDomain := attrs["user-domain-name"]
+	if userDomain == "" {
+		userDomain = attrs["domain-name"]
+	}
+	if userDomain == "" {
+		userDomain = "Default"
+	}
+	auth := map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods": []string{"password"},
+			"password": map[string]interface{}{
+				"user": map[string]interface{}{
+					"name":     attrs["username"],
+					"password": attrs["password"],
+					"domain":   map[string]string{"name": userDomain},
+				},
+			},
+		},
+	}
+	switch {
+	case attrs["tenant-id"] != "":
+		auth["scope"] = map[string]interface{}{
+			"project": map[string]string{"id": attrs["tenant-id"]},
+		}
+	case attrs["tenant-name"] != "":
+		projectDomain := attrs["project-domain-name"]
+		if projectDomain == "" {
+			projectDomain = attrs["domain-name"]
+		}
+		if projectDomain == "" {
+			projectDomain = "Default"
+		}
+		auth["scope"] = map[string]interface{}{
+			"project": map[string]interface{}{
+				"name":   attrs["tenant-name"],
+				"domain": map[string]string{"name": projectDomain},
+			},
+		}
+	}
+	return map[string]interface{}{"auth": auth}
+}
@@ -10,11 +10,14 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddApplicationOffer stores the application offer information.
 func (d *Database) AddApplicationOffer(ctx context.Context, offer *dbmodel.ApplicationOffer) (err error) {
 	const op = errors.Op("db.AddApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -36,6 +39,8 @@ func (d *Database) AddApplicationOffer(ctx context.Context, offer *dbmodel.Appli
 // UpdateApplicationOffer updates the application offer information.
 func (d *Database) UpdateApplicationOffer(ctx context.Context, offer *dbmodel.ApplicationOffer) (err error) {
 	const op = errors.Op("db.UpdateApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -77,6 +82,8 @@ func (d *Database) UpdateApplicationOffer(ctx context.Context, offer *dbmodel.Ap
 // offer UUID or URL.
 func (d *Database) GetApplicationOffer(ctx context.Context, offer *dbmodel.ApplicationOffer) (err error) {
 	const op = errors.Op("db.GetApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -112,6 +119,8 @@ func (d *Database) GetApplicationOffer(ctx context.Context, offer *dbmodel.Appli
 // DeleteApplicationOffer deletes the application offer.
 func (d *Database) DeleteApplicationOffer(ctx context.Context, offer *dbmodel.ApplicationOffer) (err error) {
 	const op = errors.Op("db.DeleteApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -154,6 +163,14 @@ func ApplicationOfferFilterByModel(modelName string) ApplicationOfferFilter {
 	}
 }
 
+// ApplicationOfferFilterByOwner filters application offers by the owner
+// of the offering model.
+func ApplicationOfferFilterByOwner(ownerName string) ApplicationOfferFilter {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("offers.model_id IN (SELECT id FROM models WHERE owner_identity_name = ?)", ownerName)
+	}
+}
+
 // ApplicationOfferFilterByApplication filters application offers by application name.
 func ApplicationOfferFilterByApplication(applicationName string) ApplicationOfferFilter {
 	return func(db *gorm.DB) *gorm.DB {
@@ -189,6 +206,8 @@ func ApplicationOfferFilterByEndpoint(endpoint dbmodel.ApplicationOfferRemoteEnd
 // FindApplicationOffers returns application offers matching criteria specified by the filters.
 func (d *Database) FindApplicationOffers(ctx context.Context, filters ...ApplicationOfferFilter) (_ []dbmodel.ApplicationOffer, err error) {
 	const op = errors.Op("db.FindApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if len(filters) == 0 {
 		return nil, errors.E(op, errors.CodeBadRequest, "no filters specified")
@@ -9,11 +9,14 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddAuditLogEntry adds a new entry to the audit log.
 func (d *Database) AddAuditLogEntry(ctx context.Context, ale *dbmodel.AuditLogEntry) (err error) {
 	const op = errors.Op("db.AddAuditLogEntry")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -49,10 +52,24 @@ type AuditLogFilter struct {
 	// were performed against a specific model.
 	Model string `json:"model,omitempty"`
 
+	// ModelUUID is used to filter the event log to only contain events
+	// that were performed against the model with the given UUID.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
 	// Method is used to filter the event log to only contain events that
 	// called a specific facade method.
 	Method string `json:"method,omitempty"`
 
+	// ConversationId is used to filter the event log to only contain
+	// events that are part of a specific conversation.
+	ConversationId string `json:"conversation-id,omitempty"`
+
+	// AfterTime and AfterID, if AfterID is not zero, restrict the event
+	// log to the events that follow the event with the given time and ID
+	// in the sort order. They are used to page through the audit log.
+	AfterTime time.Time `json:"after-time,omitempty"`
+	AfterID   uint      `json:"after-id,omitempty"`
+
 	// Offset is an offset that will be added when retrieving audit logs.
 	// An empty offset is equivalent to zero.
 	Offset int `json:"offset,omitempty"`
@@ -62,7 +79,7 @@ type AuditLogFilter struct {
 	Limit int `json:"limit,omitempty"`
 
 	// SortTime will sort by most recent first (time descending) when true.
-	// When false no explicit ordering will be applied.
+	// When false the oldest entries are returned first.
 	SortTime bool `json:"sortTime,omitempty"`
 }
 
@@ -71,6 +88,8 @@ type AuditLogFilter struct {
 // iteration stops immediately and the error is retuned unmodified.
 func (d *Database) ForEachAuditLogEntry(ctx context.Context, filter AuditLogFilter, f func(*dbmodel.AuditLogEntry) error) (err error) {
 	const op = errors.Op("db.ForEachAuditLogEntry")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -92,11 +111,25 @@ func (d *Database) ForEachAuditLogEntry(ctx context.Context, filter AuditLogFilt
 	if filter.Model != "" {
 		db = db.Where("model = ?", filter.Model)
 	}
+	if filter.ModelUUID != "" {
+		db = db.Where("model_uuid = ?", filter.ModelUUID)
+	}
 	if filter.Method != "" {
 		db = db.Where("facade_method = ?", filter.Method)
 	}
+	if filter.ConversationId != "" {
+		db = db.Where("conversation_id = ?", filter.ConversationId)
+	}
 	if filter.SortTime {
-		db = db.Order("time DESC")
+		if filter.AfterID != 0 {
+			db = db.Where("(time, id) < (?, ?)", filter.AfterTime, filter.AfterID)
+		}
+		db = db.Order("time DESC, id DESC")
+	} else {
+		if filter.AfterID != 0 {
+			db = db.Where("(time, id) > (?, ?)", filter.AfterTime, filter.AfterID)
+		}
+		db = db.Order("time, id")
 	}
 	db = db.Limit(filter.Limit)
 	db = db.Offset(filter.Offset)
@@ -125,6 +158,8 @@ func (d *Database) ForEachAuditLogEntry(ctx context.Context, filter AuditLogFilt
 // HARD deleting them from the database.
 func (d *Database) DeleteAuditLogsBefore(ctx context.Context, before time.Time) (_ int64, err error) {
 	const op = errors.Op("db.DeleteAuditLogsBefore")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return 0, errors.E(op, err)
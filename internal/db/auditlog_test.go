@@ -74,6 +74,7 @@ var testAuditLogEntries = []dbmodel.AuditLogEntry{{
 }, {
 	Time:        time.Date(2020, time.February, 20, 20, 2, 23, 0, time.UTC),
 	IdentityTag: names.NewUserTag("alice@canonical.com").String(),
+	ModelUUID:   "00000002-0000-0000-0000-000000000001",
 }}
 
 var forEachAuditLogEntryTests = []struct {
@@ -109,6 +110,18 @@ var forEachAuditLogEntryTests = []struct {
 		IdentityTag: names.NewUserTag("alice@canonical.com").String(),
 	},
 	expectEntries: []int{0, 1, 3},
+}, {
+	name: "ModelUUIDFilter",
+	filter: db.AuditLogFilter{
+		ModelUUID: "00000002-0000-0000-0000-000000000001",
+	},
+	expectEntries: []int{3},
+}, {
+	name: "SortTime",
+	filter: db.AuditLogFilter{
+		SortTime: true,
+	},
+	expectEntries: []int{3, 2, 1, 0},
 }}
 
 func (s *dbSuite) TestForEachAuditLogEntry(c *qt.C) {
@@ -140,6 +153,35 @@ func (s *dbSuite) TestForEachAuditLogEntry(c *qt.C) {
 		})
 	}
 
+	// Entries following a cursor are found in order of time and ID, so
+	// entries at the same time as the cursor are not skipped.
+	cursor := testAuditLogEntries[1]
+	for _, test := range []struct {
+		sortTime      bool
+		expectEntries []int
+	}{{
+		expectEntries: []int{2, 3},
+	}, {
+		sortTime:      true,
+		expectEntries: []int{0},
+	}} {
+		var ales []dbmodel.AuditLogEntry
+		filter := db.AuditLogFilter{
+			AfterTime: cursor.Time,
+			AfterID:   cursor.ID,
+			SortTime:  test.sortTime,
+		}
+		err := s.Database.ForEachAuditLogEntry(ctx, filter, func(ale *dbmodel.AuditLogEntry) error {
+			ales = append(ales, *ale)
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(ales, qt.HasLen, len(test.expectEntries))
+		for i := range ales {
+			c.Check(ales[i], qt.DeepEquals, testAuditLogEntries[test.expectEntries[i]])
+		}
+	}
+
 	var calls int
 	testError := errors.E("a test error")
 	err = s.Database.ForEachAuditLogEntry(context.Background(), db.AuditLogFilter{}, func(_ *dbmodel.AuditLogEntry) error {
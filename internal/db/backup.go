@@ -0,0 +1,273 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// versionsTable is the table holding the database schema version, which
+// is recorded in a dump rather than dumped with the other tables.
+const versionsTable = "versions"
+
+// excludedTables holds the tables that are neither dumped nor replaced
+// when a dump is loaded. They hold credentials and state that belong to
+// a particular deployment (sessions, login failure counts, macaroon root
+// keys and the secrets table, which holds the JWKS and session signing
+// keys as well as any credentials stored in the database) or that are
+// too large to hold in a single dump (the audit log). The http_sessions table is created by the
+// HTTP session store rather than by a migration.
+var excludedTables = map[string]bool{
+	"audit_log":      true,
+	"http_sessions":  true,
+	"login_failures": true,
+	"root_keys":      true,
+	"secrets":        true,
+	"sessions":       true,
+}
+
+// excludedColumns holds, for each table, the columns that are left out
+// of a dump because they hold credentials. Loaded rows have NULL in
+// these columns.
+var excludedColumns = map[string][]string{
+	"identities": {"access_token", "access_token_expiry", "access_token_type", "refresh_token"},
+}
+
+// A TableDump holds the rows of a database table, each encoded as a JSON
+// object keyed by column name.
+type TableDump struct {
+	// Name is the name of the table.
+	Name string
+
+	// Rows holds the rows of the table.
+	Rows []json.RawMessage
+}
+
+// Dump reads every row of every table in the database, other than the
+// versions table and the excluded tables, and the version of the
+// database schema. Credential columns, such as the OAuth tokens stored
+// for identities, are left out of the dumped rows. All tables are
+// read in a single read-only transaction, so the dump is a consistent
+// snapshot of the database. Tables are returned in an order in which
+// every table follows the tables it references, so that they can be
+// loaded in order.
+func (d *Database) Dump(ctx context.Context) (_ dbmodel.Version, _ []TableDump, err error) {
+	const op = errors.Op("db.Dump")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return dbmodel.Version{}, nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var v dbmodel.Version
+	var dump []TableDump
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("component = ?", dbmodel.Component).First(&v).Error; err != nil {
+			return err
+		}
+		tables, err := orderedTables(tx)
+		if err != nil {
+			return err
+		}
+		for _, t := range tables {
+			rows, err := tx.Raw(fmt.Sprintf("SELECT %s FROM %s t", rowJSON(t), quoteIdentifier(t))).Rows()
+			if err != nil {
+				return err
+			}
+			td := TableDump{Name: t, Rows: []json.RawMessage{}}
+			for rows.Next() {
+				var row []byte
+				if err := rows.Scan(&row); err != nil {
+					rows.Close()
+					return err
+				}
+				td.Rows = append(td.Rows, row)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			dump = append(dump, td)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return dbmodel.Version{}, nil, errors.E(op, dbError(err))
+	}
+	return v, dump, nil
+}
+
+// Load replaces the contents of every table in the database, other than
+// the versions table and the excluded tables, with the rows in the given dump and resets the ID
+// sequences to follow the loaded rows. Tables must be given in an order
+// in which every table follows the tables it references, as returned by
+// Dump. The dump must have been taken from a database with the same
+// schema version, otherwise an error with a code of CodeBadRequest is
+// returned. The database is only changed if the whole dump is loaded.
+// Truncating the identities table also removes the sessions of the
+// deployment the dump is loaded into, whose users must log in again.
+func (d *Database) Load(ctx context.Context, v dbmodel.Version, dump []TableDump) (err error) {
+	const op = errors.Op("db.Load")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if v.Component != dbmodel.Component || v.Major != dbmodel.Major || v.Minor != dbmodel.Minor {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("backup has database version %d.%d, expected %d.%d", v.Major, v.Minor, dbmodel.Major, dbmodel.Minor))
+	}
+
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tables, err := orderedTables(tx)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]bool, len(tables))
+		quoted := make([]string, len(tables))
+		for i, t := range tables {
+			known[t] = true
+			quoted[i] = quoteIdentifier(t)
+		}
+		for _, td := range dump {
+			if !known[td.Name] {
+				return errors.E(errors.CodeBadRequest, fmt.Sprintf("backup contains unknown table %q", td.Name))
+			}
+		}
+		if len(quoted) > 0 {
+			if err := tx.Exec("TRUNCATE " + strings.Join(quoted, ", ") + " CASCADE").Error; err != nil {
+				return err
+			}
+		}
+		for _, td := range dump {
+			t := quoteIdentifier(td.Name)
+			stmt := fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_record(NULL::%s, ?::json)", t, t)
+			for _, row := range td.Rows {
+				if err := tx.Exec(stmt, string(row)).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return resetSequences(tx)
+	})
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// rowJSON returns the SQL expression that encodes a row of the given
+// table, aliased as t, as a JSON object without its excluded columns.
+func rowJSON(table string) string {
+	cols := excludedColumns[table]
+	if len(cols) == 0 {
+		return "row_to_json(t)"
+	}
+	quoted := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = "'" + strings.ReplaceAll(c, "'", "''") + "'"
+	}
+	return "(to_jsonb(t) - ARRAY[" + strings.Join(quoted, ", ") + "]::text[])::json"
+}
+
+// orderedTables returns the tables in the current schema, other than the
+// versions table and the excluded tables, ordered such that every table follows the tables it
+// references through foreign keys.
+func orderedTables(tx *gorm.DB) ([]string, error) {
+	var tables []string
+	if err := tx.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() ORDER BY tablename").Scan(&tables).Error; err != nil {
+		return nil, err
+	}
+	var refs []struct {
+		Child  string
+		Parent string
+	}
+	err := tx.Raw(`SELECT c.relname AS child, p.relname AS parent
+		FROM pg_constraint f
+		JOIN pg_class c ON c.oid = f.conrelid
+		JOIN pg_class p ON p.oid = f.confrelid
+		WHERE f.contype = 'f' AND f.connamespace = current_schema()::regnamespace`).Scan(&refs).Error
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string][]string)
+	for _, r := range refs {
+		if r.Child != r.Parent {
+			parents[r.Child] = append(parents[r.Child], r.Parent)
+		}
+	}
+
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+	var visit func(string)
+	visit = func(t string) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		ps := parents[t]
+		sort.Strings(ps)
+		for _, p := range ps {
+			visit(p)
+		}
+		if t != versionsTable && !excludedTables[t] {
+			ordered = append(ordered, t)
+		}
+	}
+	for _, t := range tables {
+		visit(t)
+	}
+	return ordered, nil
+}
+
+// resetSequences sets every sequence owned by a table column in the
+// current schema so that the next value follows the largest value in
+// the column.
+func resetSequences(tx *gorm.DB) error {
+	var seqs []struct {
+		Seq string
+		Tbl string
+		Col string
+	}
+	err := tx.Raw(`SELECT s.relname AS seq, t.relname AS tbl, a.attname AS col
+		FROM pg_class s
+		JOIN pg_depend d ON d.objid = s.oid
+		JOIN pg_class t ON t.oid = d.refobjid
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid
+		WHERE s.relkind = 'S' AND d.deptype IN ('a', 'i') AND s.relnamespace = current_schema()::regnamespace`).Scan(&seqs).Error
+	if err != nil {
+		return err
+	}
+	for _, s := range seqs {
+		stmt := fmt.Sprintf("SELECT setval(?, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)", quoteIdentifier(s.Col), quoteIdentifier(s.Tbl))
+		if err := tx.Exec(stmt, quoteIdentifier(s.Seq)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quoteIdentifier quotes the given SQL identifier.
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
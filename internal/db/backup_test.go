@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestDumpUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, _, err := d.Dump(context.Background())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestDumpExcludesCredentials(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.GetIdentity(ctx, u), qt.IsNil)
+	u.AccessToken = "access-token"
+	u.RefreshToken = "refresh-token"
+	u.AccessTokenType = "bearer"
+	u.AccessTokenExpiry = time.Now().Add(time.Hour)
+	c.Assert(s.Database.UpdateIdentity(ctx, u), qt.IsNil)
+
+	err = s.Database.AddSession(ctx, &dbmodel.Session{
+		IdentityName: u.Name,
+		Kind:         dbmodel.DeviceSession,
+		Key:          "device-1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	c.Assert(err, qt.IsNil)
+	secret := dbmodel.NewSecret("oauth", "oauthSessionKeys", []byte(`["session-key"]`))
+	err = s.Database.UpsertSecret(ctx, &secret)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AddAuditLogEntry(ctx, &dbmodel.AuditLogEntry{
+		Time:        time.Now(),
+		IdentityTag: u.Tag().String(),
+	})
+	c.Assert(err, qt.IsNil)
+
+	v, dump, err := s.Database.Dump(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v.Minor, qt.Equals, dbmodel.Minor)
+
+	var identities *db.TableDump
+	for i, td := range dump {
+		switch td.Name {
+		case "audit_log", "http_sessions", "root_keys", "secrets", "sessions", "versions":
+			c.Errorf("dump contains table %q", td.Name)
+		case "identities":
+			identities = &dump[i]
+		}
+	}
+	c.Assert(identities, qt.IsNotNil)
+	c.Assert(identities.Rows, qt.HasLen, 1)
+	var row map[string]any
+	c.Assert(json.Unmarshal(identities.Rows[0], &row), qt.IsNil)
+	c.Check(row["name"], qt.Equals, u.Name)
+	for _, col := range []string{"access_token", "access_token_expiry", "access_token_type", "refresh_token"} {
+		_, ok := row[col]
+		c.Check(ok, qt.IsFalse, qt.Commentf("column %s", col))
+	}
+
+	// Loading the dump keeps the audit log.
+	err = s.Database.Load(ctx, v, dump)
+	c.Assert(err, qt.IsNil)
+	var n int
+	err = s.Database.DB.Raw("SELECT COUNT(*) FROM audit_log").Scan(&n).Error
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 1)
+	u2, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.FetchIdentity(ctx, u2), qt.IsNil)
+	c.Check(u2.RefreshToken, qt.Equals, "")
+
+	// Loading the dump keeps the deployment's secrets.
+	err = s.Database.DB.Raw("SELECT COUNT(*) FROM secrets").Scan(&n).Error
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 1)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddCloud adds the given cloud to the database. AddCloud returns an error
@@ -18,6 +19,8 @@ import (
 // same name.
 func (d *Database) AddCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 	const op = errors.Op("db.AddCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -42,6 +45,8 @@ func (d *Database) AddCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 // CodeNotFound will be returned.
 func (d *Database) GetCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 	const op = errors.Op("db.GetCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -66,6 +71,8 @@ func (d *Database) GetCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 // GetClouds retrieves all the clouds from the database.
 func (d *Database) GetClouds(ctx context.Context) (_ []dbmodel.Cloud, err error) {
 	const op = errors.Op("db.GetClouds")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -88,6 +95,8 @@ func (d *Database) GetClouds(ctx context.Context) (_ []dbmodel.Cloud, err error)
 // it remove any information - this is an additive method.
 func (d *Database) UpdateCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 	const op = errors.Op("db.UpdateCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -133,6 +142,8 @@ func preloadCloud(prefix string, db *gorm.DB) *gorm.DB {
 // region with the same name on the cloud.
 func (d *Database) AddCloudRegion(ctx context.Context, cr *dbmodel.CloudRegion) (err error) {
 	const op = errors.Op("db.AddCloudRegion")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -156,6 +167,8 @@ func (d *Database) AddCloudRegion(ctx context.Context, cr *dbmodel.CloudRegion)
 // provider type.
 func (d *Database) FindRegion(ctx context.Context, providerType, name string) (_ *dbmodel.CloudRegion, err error) {
 	const op = errors.Op("db.FindRegion")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -178,6 +191,8 @@ func (d *Database) FindRegion(ctx context.Context, providerType, name string) (_
 // DeleteCloud deletes the given cloud.
 func (d *Database) DeleteCloud(ctx context.Context, c *dbmodel.Cloud) (err error) {
 	const op = errors.Op("db.DeleteCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -194,9 +209,34 @@ func (d *Database) DeleteCloud(ctx context.Context, c *dbmodel.Cloud) (err error
 	return nil
 }
 
+// DeleteCloudRegion deletes the given cloud region, along with the
+// priorities of the controllers serving it. A region that still hosts
+// models cannot be deleted.
+func (d *Database) DeleteCloudRegion(ctx context.Context, cr *dbmodel.CloudRegion) (err error) {
+	const op = errors.Op("db.DeleteCloudRegion")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Unscoped().Delete(cr).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // DeleteCloudRegionControllerPriority deletes the given cloud region controller priority entry.
 func (d *Database) DeleteCloudRegionControllerPriority(ctx context.Context, c *dbmodel.CloudRegionControllerPriority) (err error) {
 	const op = errors.Op("db.DeleteCloudRegionControllerPriority")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
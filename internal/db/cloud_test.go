@@ -313,6 +313,60 @@ func (s *dbSuite) TestDeleteCloud(c *qt.C) {
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
 }
 
+func TestDeleteCloudRegionUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.DeleteCloudRegion(context.Background(), nil)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestDeleteCloudRegion(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: testp
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+controllers:
+- name: test
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+`)
+	env.PopulateDB(c, *s.Database)
+
+	cl := dbmodel.Cloud{Name: "test-cloud"}
+	err = s.Database.GetCloud(ctx, &cl)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cl.Regions, qt.HasLen, 2)
+
+	region := cl.Region("test-region-2")
+	c.Assert(region.ID, qt.Not(qt.Equals), uint(0))
+	err = s.Database.DeleteCloudRegion(ctx, &region)
+	c.Assert(err, qt.IsNil)
+
+	cl2 := dbmodel.Cloud{Name: "test-cloud"}
+	err = s.Database.GetCloud(ctx, &cl2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cl2.Regions, qt.HasLen, 1)
+	c.Check(cl2.Regions[0].Name, qt.Equals, "test-region-1")
+
+	// The region can be added again once deleted.
+	err = s.Database.AddCloudRegion(ctx, &dbmodel.CloudRegion{CloudName: "test-cloud", Name: "test-region-2"})
+	c.Assert(err, qt.IsNil)
+}
+
 func TestDeleteCloudRegionControllerPriorityUnconfiguredDatabase(t *testing.T) {
 	c := qt.New(t)
 
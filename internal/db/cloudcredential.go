@@ -5,17 +5,22 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // SetCloudCredential upserts the cloud credential information.
 func (d *Database) SetCloudCredential(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
 	const op = errors.Op("db.SetCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -35,7 +40,7 @@ func (d *Database) SetCloudCredential(ctx context.Context, cred *dbmodel.CloudCr
 			{Name: "owner_identity_name"},
 			{Name: "name"},
 		},
-		DoUpdates: clause.AssignmentColumns([]string{"auth_type", "label", "attributes_in_vault", "attributes", "valid"}),
+		DoUpdates: clause.AssignmentColumns([]string{"auth_type", "label", "attributes_in_vault", "attributes", "valid", "expires_at", "expiring_soon"}),
 	}).Create(&cred).Error; err != nil {
 		return errors.E(op, dbError(err))
 	}
@@ -46,6 +51,8 @@ func (d *Database) SetCloudCredential(ctx context.Context, cred *dbmodel.CloudCr
 // cloud, owner and name.
 func (d *Database) GetCloudCredential(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
 	const op = errors.Op("db.GetCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -76,6 +83,8 @@ func (d *Database) GetCloudCredential(ctx context.Context, cred *dbmodel.CloudCr
 // credentials for that cloud.
 func (d *Database) ForEachCloudCredential(ctx context.Context, identityName, cloud string, f func(*dbmodel.CloudCredential) error) (err error) {
 	const op = errors.Op("db.ForEachCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -116,9 +125,80 @@ func (d *Database) ForEachCloudCredential(ctx context.Context, identityName, clo
 	return nil
 }
 
+// CloudCredentialFilter holds the criteria used to select cloud
+// credentials in ListCloudCredentials. Empty fields match every
+// credential.
+type CloudCredentialFilter struct {
+	// OwnerIdentityName selects the credentials owned by the identity
+	// with this name.
+	OwnerIdentityName string
+
+	// CloudName selects the credentials for this cloud.
+	CloudName string
+
+	// AuthType selects the credentials with this auth-type.
+	AuthType string
+
+	// Valid, if set, selects the credentials known to be valid when
+	// true, or known to be invalid when false. Credentials whose
+	// validity is unknown only match a nil Valid.
+	Valid *bool
+
+	// Offset is the number of matching credentials to skip.
+	Offset int
+
+	// Limit is the maximum number of credentials to return. A value of
+	// zero will ignore the limit.
+	Limit int
+}
+
+// ListCloudCredentials returns the cloud credentials that match the given
+// filter, ordered by cloud, owner and name. The models using each
+// credential are loaded.
+func (d *Database) ListCloudCredentials(ctx context.Context, filter CloudCredentialFilter) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("db.ListCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if filter.OwnerIdentityName != "" {
+		db = db.Where("owner_identity_name = ?", filter.OwnerIdentityName)
+	}
+	if filter.CloudName != "" {
+		db = db.Where("cloud_name = ?", filter.CloudName)
+	}
+	if filter.AuthType != "" {
+		db = db.Where("auth_type = ?", filter.AuthType)
+	}
+	if filter.Valid != nil {
+		db = db.Where("valid = ?", *filter.Valid)
+	}
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	db = db.Offset(filter.Offset)
+	db = db.Order("cloud_name, owner_identity_name, name")
+
+	var creds []dbmodel.CloudCredential
+	if err := db.Preload("Models").Find(&creds).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return creds, nil
+}
+
 // DeleteCloudCredential removes the given CloudCredential from the database.
 func (d *Database) DeleteCloudCredential(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
 	const op = errors.Op("db.DeleteCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -135,3 +215,64 @@ func (d *Database) DeleteCloudCredential(ctx context.Context, cred *dbmodel.Clou
 	}
 	return nil
 }
+
+// ListExpiringCloudCredentials returns the cloud credentials that expire
+// before the given time, ordered by expiry time. If identityName is not
+// empty only the credentials owned by that identity are returned.
+func (d *Database) ListExpiringCloudCredentials(ctx context.Context, identityName string, before time.Time) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("db.ListExpiringCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Where("expires_at IS NOT NULL AND expires_at < ?", before)
+	if identityName != "" {
+		db = db.Where("owner_identity_name = ?", identityName)
+	}
+	var creds []dbmodel.CloudCredential
+	if err := db.Order("expires_at asc").Find(&creds).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return creds, nil
+}
+
+// MarkExpiringCloudCredentials sets the ExpiringSoon flag on every cloud
+// credential that expires before the given time, and clears it on every
+// other credential. The number of credentials expiring before the given
+// time is returned.
+func (d *Database) MarkExpiringCloudCredentials(ctx context.Context, before time.Time) (_ int, err error) {
+	const op = errors.Op("db.MarkExpiringCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	expiring := "expires_at IS NOT NULL AND expires_at < ?"
+	var count int64
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&dbmodel.CloudCredential{}).Where(expiring+" AND NOT expiring_soon", before).Update("expiring_soon", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&dbmodel.CloudCredential{}).Where("expiring_soon AND NOT ("+expiring+")", before).Update("expiring_soon", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&dbmodel.CloudCredential{}).Where(expiring, before).Count(&count).Error
+	})
+	if err != nil {
+		return 0, errors.E(op, dbError(err))
+	}
+	return int(count), nil
+}
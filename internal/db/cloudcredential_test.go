@@ -302,3 +302,69 @@ func (s *dbSuite) TestForEachCloudCredential(c *qt.C) {
 		})
 	}
 }
+
+func (s *dbSuite) TestListCloudCredentials(c *qt.C) {
+	ctx := context.Background()
+
+	env := jimmtest.ParseEnvironment(c, forEachCloudCredentialEnv)
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, *s.Database)
+
+	cred := dbmodel.CloudCredential{CloudName: "cloud-2", OwnerIdentityName: "bob@canonical.com", Name: "cred-4"}
+	err = s.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	cred.AuthType = "userpass"
+	cred.Valid = sql.NullBool{Bool: false, Valid: true}
+	err = s.Database.SetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+
+	invalid := false
+	tests := []struct {
+		name              string
+		filter            db.CloudCredentialFilter
+		expectCredentials []string
+	}{{
+		name: "All",
+		expectCredentials: []string{
+			"cloud-1/alice@canonical.com/cred-1",
+			"cloud-1/alice@canonical.com/cred-5",
+			"cloud-1/bob@canonical.com/cred-2",
+			"cloud-2/alice@canonical.com/cred-3",
+			"cloud-2/bob@canonical.com/cred-4",
+		},
+	}, {
+		name:   "OwnerAndCloud",
+		filter: db.CloudCredentialFilter{OwnerIdentityName: "alice@canonical.com", CloudName: "cloud-1"},
+		expectCredentials: []string{
+			"cloud-1/alice@canonical.com/cred-1",
+			"cloud-1/alice@canonical.com/cred-5",
+		},
+	}, {
+		name:              "AuthType",
+		filter:            db.CloudCredentialFilter{AuthType: "userpass"},
+		expectCredentials: []string{"cloud-2/bob@canonical.com/cred-4"},
+	}, {
+		name:              "Invalid",
+		filter:            db.CloudCredentialFilter{Valid: &invalid},
+		expectCredentials: []string{"cloud-2/bob@canonical.com/cred-4"},
+	}, {
+		name:   "Page",
+		filter: db.CloudCredentialFilter{Offset: 1, Limit: 2},
+		expectCredentials: []string{
+			"cloud-1/alice@canonical.com/cred-5",
+			"cloud-1/bob@canonical.com/cred-2",
+		},
+	}}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			creds, err := s.Database.ListCloudCredentials(ctx, test.filter)
+			c.Assert(err, qt.IsNil)
+			var paths []string
+			for _, cred := range creds {
+				paths = append(paths, cred.Path())
+			}
+			c.Check(paths, qt.DeepEquals, test.expectCredentials)
+		})
+	}
+}
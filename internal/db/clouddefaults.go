@@ -11,11 +11,14 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // SetCloudDefaults sets default model setting values for the specified cloud/region.
 func (d *Database) SetCloudDefaults(ctx context.Context, defaults *dbmodel.CloudDefaults) (err error) {
 	const op = errors.Op("db.SetCloudDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -74,6 +77,8 @@ func (d *Database) SetCloudDefaults(ctx context.Context, defaults *dbmodel.Cloud
 // UnsetCloudDefaults unsets default model setting values for the specified cloud/region.
 func (d *Database) UnsetCloudDefaults(ctx context.Context, defaults *dbmodel.CloudDefaults, keys []string) (err error) {
 	const op = errors.Op("db.UpsertCloudDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -125,6 +130,8 @@ func (d *Database) UnsetCloudDefaults(ctx context.Context, defaults *dbmodel.Clo
 // CloudDefaults fetches cloud defaults based on user, cloud name or id and region name.
 func (d *Database) CloudDefaults(ctx context.Context, defaults *dbmodel.CloudDefaults) (err error) {
 	const op = errors.Op("db.CloudDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -159,6 +166,8 @@ func (d *Database) CloudDefaults(ctx context.Context, defaults *dbmodel.CloudDef
 // ModelDefaultsForCloud returns the default config values for the specified cloud.
 func (d *Database) ModelDefaultsForCloud(ctx context.Context, user *dbmodel.Identity, cloud names.CloudTag) (_ []dbmodel.CloudDefaults, err error) {
 	const op = errors.Op("db.ModelDefaultsForCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
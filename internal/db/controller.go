@@ -10,11 +10,14 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddController stores the controller information.
 func (d *Database) AddController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.AddController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -35,6 +38,8 @@ func (d *Database) AddController(ctx context.Context, controller *dbmodel.Contro
 // controller UUID or name.
 func (d *Database) GetController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.GetController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -66,6 +71,8 @@ func (d *Database) GetController(ctx context.Context, controller *dbmodel.Contro
 // changes to a controller's CloudRegions or Models.
 func (d *Database) UpdateController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.UpdateController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if controller.ID == 0 {
 		return errors.E(op, errors.CodeNotFound, `controller not found`)
@@ -90,6 +97,8 @@ func (d *Database) UpdateController(ctx context.Context, controller *dbmodel.Con
 // DeleteController removes the specified controller from the database.
 func (d *Database) DeleteController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.DeleteController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if controller.ID == 0 {
 		return errors.E(op, errors.CodeNotFound, `controller not found`)
 	}
@@ -121,6 +130,8 @@ func (d *Database) DeleteController(ctx context.Context, controller *dbmodel.Con
 // will stop immediately and the error will be returned unmodified.
 func (d *Database) ForEachController(ctx context.Context, f func(*dbmodel.Controller) error) (err error) {
 	const op = errors.Op("db.ForEachController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -158,6 +169,8 @@ func (d *Database) ForEachController(ctx context.Context, f func(*dbmodel.Contro
 // error will be returned unmodified.
 func (d *Database) ForEachControllerModel(ctx context.Context, ctl *dbmodel.Controller, f func(m *dbmodel.Model) error) (err error) {
 	const op = errors.Op("db.ForEachControllerModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -183,6 +196,8 @@ func (d *Database) ForEachControllerModel(ctx context.Context, ctl *dbmodel.Cont
 // UpsertControllerConfig upserts the controller config.
 func (d *Database) UpsertControllerConfig(ctx context.Context, cfg *dbmodel.ControllerConfig) (err error) {
 	const op = errors.Op("db.UpsertControllerConfig")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -205,6 +220,8 @@ func (d *Database) UpsertControllerConfig(ctx context.Context, cfg *dbmodel.Cont
 
 func (d *Database) GetControllerConfig(ctx context.Context, cfg *dbmodel.ControllerConfig) (err error) {
 	const op = errors.Op("db.GetControllerConfig")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // A Database provides access to the database model. A Database instance
@@ -23,6 +24,10 @@ type Database struct {
 	// DB contains the gorm database storing the data.
 	DB *gorm.DB
 
+	// ReadReplica, if set, is a read-only replica of DB to which heavy
+	// read queries are sent, see ReadFromReplica.
+	ReadReplica *ReadReplica
+
 	// migrated holds whether the database has been successfully migrated
 	// to the current database version. The value of migrated should always
 	// be read using atomic.LoadUint32 and will contain a 0 if the
@@ -45,6 +50,8 @@ func (d *Database) Transaction(f func(*Database) error) error {
 	return d.DB.Transaction(func(tx *gorm.DB) error {
 		d := *d
 		d.DB = tx
+		// Reads in a transaction must see the transaction's writes.
+		d.ReadReplica = nil
 		return f(&d)
 	})
 }
@@ -58,8 +65,10 @@ func (d *Database) Transaction(f func(*Database) error) error {
 // then the migration will be performed no matter what the current version
 // is. The force parameter should only be set when the migration is
 // initiated by a user request.
-func (d *Database) Migrate(ctx context.Context, force bool) error {
+func (d *Database) Migrate(ctx context.Context, force bool) (err error) {
 	const op = errors.Op("db.Migrate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if d == nil || d.DB == nil {
 		return errors.E(op, errors.CodeServerConfiguration, "database not configured")
 	}
@@ -114,6 +123,25 @@ func (d *Database) ready() error {
 	return nil
 }
 
+// Ping checks that the database is ready and can be reached.
+func (d *Database) Ping(ctx context.Context) (err error) {
+	const op = errors.Op("db.Ping")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // Close closes open connections to the underlying database backend.
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
@@ -123,6 +151,11 @@ func (d *Database) Close() error {
 	if err := sqlDB.Close(); err != nil {
 		return errors.E(err, "failed to close database connection")
 	}
+	if d.ReadReplica != nil {
+		if err := d.ReadReplica.Close(); err != nil {
+			return errors.E(err, "failed to close read replica connection")
+		}
+	}
 	return nil
 }
 
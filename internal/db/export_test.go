@@ -2,6 +2,8 @@
 
 package db
 
+import "time"
+
 var (
 	JwksKind                   = jwksKind
 	JwksPublicKeyTag           = jwksPublicKeyTag
@@ -12,3 +14,12 @@ var (
 	OAuthSessionStoreSecretTag = oauthSessionStoreSecretTag
 	NewUUID                    = &newUUID
 )
+
+// SetReplicaAvailable records the availability of the replica as if it
+// had just been checked.
+func SetReplicaAvailable(r *ReadReplica, available bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checked = time.Now()
+	r.available = available
+}
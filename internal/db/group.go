@@ -10,6 +10,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 var newUUID = uuid.NewString
@@ -17,6 +18,8 @@ var newUUID = uuid.NewString
 // AddGroup adds a new group.
 func (d *Database) AddGroup(ctx context.Context, name string) (ge *dbmodel.GroupEntry, err error) {
 	const op = errors.Op("db.AddGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -39,6 +42,8 @@ func (d *Database) AddGroup(ctx context.Context, name string) (ge *dbmodel.Group
 // CountGroups returns a count of the number of groups that exist.
 func (d *Database) CountGroups(ctx context.Context) (count int, err error) {
 	const op = errors.Op("db.CountGroups")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return 0, errors.E(op, err)
 	}
@@ -58,6 +63,8 @@ func (d *Database) CountGroups(ctx context.Context) (count int, err error) {
 // GetGroup returns a GroupEntry with the specified name.
 func (d *Database) GetGroup(ctx context.Context, group *dbmodel.GroupEntry) (err error) {
 	const op = errors.Op("db.GetGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -87,6 +94,8 @@ func (d *Database) GetGroup(ctx context.Context, group *dbmodel.GroupEntry) (err
 // will stop immediately and the error will be returned unmodified.
 func (d *Database) ForEachGroup(ctx context.Context, limit, offset int, f func(*dbmodel.GroupEntry) error) (err error) {
 	const op = errors.Op("db.ForEachGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -122,6 +131,8 @@ func (d *Database) ForEachGroup(ctx context.Context, limit, offset int, f func(*
 // UpdateGroup updates the group identified by its ID.
 func (d *Database) UpdateGroup(ctx context.Context, group *dbmodel.GroupEntry) (err error) {
 	const op = errors.Op("db.UpdateGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if group.ID == 0 {
 		return errors.E(errors.CodeNotFound)
@@ -147,6 +158,8 @@ func (d *Database) UpdateGroup(ctx context.Context, group *dbmodel.GroupEntry) (
 // RemoveGroup removes the group identified by its ID.
 func (d *Database) RemoveGroup(ctx context.Context, group *dbmodel.GroupEntry) (err error) {
 	const op = errors.Op("db.RemoveGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if group.ID == 0 {
 		return errors.E(errors.CodeNotFound)
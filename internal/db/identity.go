@@ -4,10 +4,12 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // GetIdentity loads the details for the identity identified by name. If
@@ -22,6 +24,8 @@ import (
 // GetIdentity returns an error with CodeNotFound if the identity name is invalid.
 func (d *Database) GetIdentity(ctx context.Context, u *dbmodel.Identity) (err error) {
 	const op = errors.Op("db.GetIdentity")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if u.Name == "" {
 		return errors.E(op, errors.CodeNotFound, `invalid identity name ""`)
@@ -48,6 +52,8 @@ func (d *Database) GetIdentity(ctx context.Context, u *dbmodel.Identity) (err er
 // FetchIdentity returns an error with CodeNotFound if the identity name is invalid.
 func (d *Database) FetchIdentity(ctx context.Context, u *dbmodel.Identity) (err error) {
 	const op = errors.Op("db.FetchIdentity")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if u.Name == "" {
 		return errors.E(op, errors.CodeNotFound, `invalid identity name ""`)
@@ -76,6 +82,8 @@ func (d *Database) FetchIdentity(ctx context.Context, u *dbmodel.Identity) (err
 // invalid.
 func (d *Database) UpdateIdentity(ctx context.Context, u *dbmodel.Identity) (err error) {
 	const op = errors.Op("db.UpdateIdentity")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -99,6 +107,8 @@ func (d *Database) UpdateIdentity(ctx context.Context, u *dbmodel.Identity) (err
 // GetIdentityCloudCredentials fetches identity's cloud credentials for the specified cloud.
 func (d *Database) GetIdentityCloudCredentials(ctx context.Context, u *dbmodel.Identity, cloud string) (_ []dbmodel.CloudCredential, err error) {
 	const op = errors.Op("db.GetIdentityCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if u.Name == "" || cloud == "" {
 		return nil, errors.E(op, errors.CodeNotFound, `cloudcredential not found`)
@@ -125,6 +135,8 @@ func (d *Database) GetIdentityCloudCredentials(ctx context.Context, u *dbmodel.I
 // will stop immediately and the error will be returned unmodified.
 func (d *Database) ForEachIdentity(ctx context.Context, limit, offset int, f func(*dbmodel.Identity) error) (err error) {
 	const op = errors.Op("db.ForEachUSer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -162,6 +174,8 @@ func (d *Database) ForEachIdentity(ctx context.Context, limit, offset int, f fun
 // CountIdentities counts the number of identities.
 func (d *Database) CountIdentities(ctx context.Context) (_ int, err error) {
 	const op = errors.Op("db.CountIdentities")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return 0, errors.E(op, err)
@@ -178,3 +192,31 @@ func (d *Database) CountIdentities(ctx context.Context) (_ int, err error) {
 	}
 	return int(count), nil
 }
+
+// ListIdentitiesForProfileSync returns up to limit identities that have
+// OAuth2.0 tokens and whose profile has not been synchronised from the
+// identity provider since the given time, least recently synchronised
+// first.
+func (d *Database) ListIdentitiesForProfileSync(ctx context.Context, syncedBefore time.Time, limit int) (_ []dbmodel.Identity, err error) {
+	const op = errors.Op("db.ListIdentitiesForProfileSync")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var identities []dbmodel.Identity
+	db := d.DB.WithContext(ctx)
+	db = db.Where("refresh_token <> '' AND disabled = FALSE")
+	db = db.Where("profile_synced_at IS NULL OR profile_synced_at < ?", syncedBefore)
+	db = db.Order("profile_synced_at ASC NULLS FIRST").Order("id")
+	if err := db.Limit(limit).Find(&identities).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return identities, nil
+}
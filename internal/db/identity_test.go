@@ -4,8 +4,10 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 
@@ -230,3 +232,49 @@ func (s *dbSuite) TestForEachIdentityError(c *qt.C) {
 	c.Assert(err, qt.IsNotNil)
 	c.Assert(err.Error(), qt.Equals, errTest.Error())
 }
+
+func TestListIdentitiesForProfileSyncUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.ListIdentitiesForProfileSync(context.Background(), time.Now(), 10)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestListIdentitiesForProfileSync(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	add := func(name, refreshToken string, syncedAt *time.Time) {
+		id, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		err = s.Database.GetIdentity(ctx, id)
+		c.Assert(err, qt.IsNil)
+		id.RefreshToken = refreshToken
+		if syncedAt != nil {
+			id.ProfileSyncedAt = sql.NullTime{Time: *syncedAt, Valid: true}
+		}
+		err = s.Database.UpdateIdentity(ctx, id)
+		c.Assert(err, qt.IsNil)
+	}
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+	add("alice@canonical.com", "alice-token", &old)
+	add("bob@canonical.com", "bob-token", nil)
+	add("charlie@canonical.com", "charlie-token", &recent)
+	add("dave@canonical.com", "", nil)
+
+	identities, err := s.Database.ListIdentitiesForProfileSync(ctx, now.Add(-24*time.Hour), 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities, qt.HasLen, 2)
+	c.Check(identities[0].Name, qt.Equals, "bob@canonical.com")
+	c.Check(identities[1].Name, qt.Equals, "alice@canonical.com")
+
+	identities, err = s.Database.ListIdentitiesForProfileSync(ctx, now, 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities, qt.HasLen, 1)
+	c.Check(identities[0].Name, qt.Equals, "bob@canonical.com")
+}
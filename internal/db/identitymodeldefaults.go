@@ -10,11 +10,14 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // SetIdentityModelDefaults sets default model setting values for the controller.
 func (d *Database) SetIdentityModelDefaults(ctx context.Context, defaults *dbmodel.IdentityModelDefaults) (err error) {
 	const op = errors.Op("db.SetIdentityModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -66,6 +69,8 @@ func (d *Database) SetIdentityModelDefaults(ctx context.Context, defaults *dbmod
 // IdentityModelDefaults fetches identities defaults.
 func (d *Database) IdentityModelDefaults(ctx context.Context, defaults *dbmodel.IdentityModelDefaults) (err error) {
 	const op = errors.Op("db.IdentityModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
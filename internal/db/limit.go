@@ -0,0 +1,166 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// SetLimit stores the given limit, replacing the value of any existing
+// limit with the same entity and scope.
+func (d *Database) SetLimit(ctx context.Context, limit *dbmodel.Limit) (err error) {
+	const op = errors.Op("db.SetLimit")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "entity"},
+			{Name: "scope"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "value"}),
+	}).Create(limit).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// RemoveLimit removes the limit with the entity and scope given in the
+// limit. If there is no such limit an error with a code of CodeNotFound
+// is returned.
+func (d *Database) RemoveLimit(ctx context.Context, limit *dbmodel.Limit) (err error) {
+	const op = errors.Op("db.RemoveLimit")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Where("entity = ? AND scope = ?", limit.Entity, limit.Scope).Delete(&dbmodel.Limit{})
+	if result.Error != nil {
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return errors.E(op, errors.CodeNotFound, "limit not found")
+	}
+	return nil
+}
+
+// ListLimits returns the limits with any of the given scopes, or every
+// limit if no scopes are given, ordered by scope and entity.
+func (d *Database) ListLimits(ctx context.Context, scopes ...string) (_ []dbmodel.Limit, err error) {
+	const op = errors.Op("db.ListLimits")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if len(scopes) > 0 {
+		db = db.Where("scope IN ?", scopes)
+	}
+	var limits []dbmodel.Limit
+	if err := db.Order("scope asc, entity asc").Find(&limits).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return limits, nil
+}
+
+// ModelUsage holds the resources used by a set of models.
+type ModelUsage struct {
+	Models   int64
+	Machines int64
+	Cores    int64
+	Units    int64
+}
+
+// GetModelUsage returns the resources used by the models owned by a user,
+// hosted on a controller, or deployed to a cloud. The kind is one of
+// "user", "controller" or "cloud" and id is the name of the user,
+// controller or cloud.
+func (d *Database) GetModelUsage(ctx context.Context, kind, id string) (_ ModelUsage, err error) {
+	const op = errors.Op("db.GetModelUsage")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return ModelUsage{}, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Model(&dbmodel.Model{}).Select(
+		"COUNT(*) AS models, COALESCE(SUM(models.machines), 0) AS machines, COALESCE(SUM(models.cores), 0) AS cores, COALESCE(SUM(models.units), 0) AS units",
+	)
+	switch kind {
+	case "user":
+		db = db.Where("models.owner_identity_name = ?", id)
+	case "controller":
+		db = db.Joins("JOIN controllers ON controllers.id = models.controller_id").Where("controllers.name = ?", id)
+	case "cloud":
+		db = db.Joins("JOIN cloud_regions ON cloud_regions.id = models.cloud_region_id").Where("cloud_regions.cloud_name = ?", id)
+	default:
+		return ModelUsage{}, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid usage scope %q", kind))
+	}
+	var usage ModelUsage
+	if err := db.Scan(&usage).Error; err != nil {
+		return ModelUsage{}, errors.E(op, dbError(err))
+	}
+	return usage, nil
+}
+
+// LockModelUsage locks the usage within each of the given limit scopes,
+// such as "user-alice@canonical.com", until the end of the current
+// transaction. Other transactions locking any of the same scopes wait
+// until the transaction ends, so usage can be checked and changed
+// without racing. It must be called within a Transaction.
+func (d *Database) LockModelUsage(ctx context.Context, scopes ...string) (err error) {
+	const op = errors.Op("db.LockModelUsage")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	// Locks are always taken in the same order to avoid deadlocks.
+	scopes = append([]string(nil), scopes...)
+	sort.Strings(scopes)
+	db := d.DB.WithContext(ctx)
+	for _, s := range scopes {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "limit-usage-"+s).Error; err != nil {
+			return errors.E(op, dbError(err))
+		}
+	}
+	return nil
+}
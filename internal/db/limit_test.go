@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestSetLimitUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetLimit(context.Background(), &dbmodel.Limit{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestLimits(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.SetLimit(ctx, &dbmodel.Limit{Entity: "models", Scope: "user", Value: 5})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetLimit(ctx, &dbmodel.Limit{Entity: "models", Scope: "user-alice@canonical.com", Value: 10})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetLimit(ctx, &dbmodel.Limit{Entity: "models", Scope: "user-alice@canonical.com", Value: 20})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetLimit(ctx, &dbmodel.Limit{Entity: "cores", Scope: "cloud-aws", Value: 100})
+	c.Assert(err, qt.IsNil)
+
+	limits, err := s.Database.ListLimits(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(limits, qt.HasLen, 3)
+	c.Check(limits[0].Scope, qt.Equals, "cloud-aws")
+	c.Check(limits[1].Scope, qt.Equals, "user")
+	c.Check(limits[2].Scope, qt.Equals, "user-alice@canonical.com")
+	c.Check(limits[2].Value, qt.Equals, int64(20))
+
+	limits, err = s.Database.ListLimits(ctx, "user", "user-bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(limits, qt.HasLen, 1)
+	c.Check(limits[0].Value, qt.Equals, int64(5))
+
+	err = s.Database.RemoveLimit(ctx, &dbmodel.Limit{Entity: "models", Scope: "user"})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.RemoveLimit(ctx, &dbmodel.Limit{Entity: "models", Scope: "user"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	usage, err := s.Database.GetModelUsage(ctx, "user", "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(usage, qt.Equals, db.ModelUsage{})
+
+	_, err = s.Database.GetModelUsage(ctx, "model", "model-1")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddLoginFailure records a failed login by the given identity at the
+// given time. Failures are counted over window, once maxFailures have
+// been counted logins by the identity are locked out for lockout and
+// AddLoginFailure reports true. The count is shared by every JIMM
+// replica using the database.
+func (d *Database) AddLoginFailure(ctx context.Context, identityName string, now time.Time, window time.Duration, maxFailures int, lockout time.Duration) (_ bool, err error) {
+	const op = errors.Op("db.AddLoginFailure")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return false, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var locked bool
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lf := dbmodel.LoginFailure{
+			IdentityName: identityName,
+			WindowStart:  now,
+			ExpiresAt:    now.Add(window),
+		}
+		// Create the record if there is none so that it can be locked.
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lf).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("identity_name = ?", identityName).First(&lf).Error; err != nil {
+			return err
+		}
+		if now.Sub(lf.WindowStart) > window {
+			lf.Failures = 0
+			lf.WindowStart = now
+		}
+		lf.Failures++
+		if lf.Failures >= maxFailures && !now.Before(lf.LockedUntil) {
+			lf.LockedUntil = now.Add(lockout)
+			lf.Failures = 0
+			lf.WindowStart = now
+			locked = true
+		}
+		lf.ExpiresAt = lf.WindowStart.Add(window)
+		if lf.LockedUntil.After(lf.ExpiresAt) {
+			lf.ExpiresAt = lf.LockedUntil
+		}
+		return tx.Save(&lf).Error
+	})
+	if err != nil {
+		return false, errors.E(op, dbError(err))
+	}
+	return locked, nil
+}
+
+// GetLoginLockedUntil returns the time until which logins by the given
+// identity are locked out. The zero time is returned if the identity is
+// not locked out.
+func (d *Database) GetLoginLockedUntil(ctx context.Context, identityName string) (_ time.Time, err error) {
+	const op = errors.Op("db.GetLoginLockedUntil")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return time.Time{}, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var lf dbmodel.LoginFailure
+	if err := d.DB.WithContext(ctx).Where("identity_name = ?", identityName).First(&lf).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.E(op, err)
+	}
+	return lf.LockedUntil, nil
+}
+
+// DeleteLoginFailures removes the failed logins recorded for the given
+// identity, unless the identity is locked out at the given time.
+func (d *Database) DeleteLoginFailures(ctx context.Context, identityName string, now time.Time) (err error) {
+	const op = errors.Op("db.DeleteLoginFailures")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Where("identity_name = ? AND locked_until <= ?", identityName, now).Delete(&dbmodel.LoginFailure{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteExpiredLoginFailures removes all failed login records that
+// expired before the given time.
+func (d *Database) DeleteExpiredLoginFailures(ctx context.Context, before time.Time) (_ int64, err error) {
+	const op = errors.Op("db.DeleteExpiredLoginFailures")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Where("expires_at < ?", before).Delete(&dbmodel.LoginFailure{})
+	if result.Error != nil {
+		return 0, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddLoginFailureUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.AddLoginFailure(context.Background(), "alice@canonical.com", time.Now(), time.Minute, 3, time.Minute)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestLoginFailures(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	lockedUntil, err := s.Database.GetLoginLockedUntil(ctx, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(lockedUntil.IsZero(), qt.IsTrue)
+
+	// Failures outside the window are not counted together.
+	locked, err := s.Database.AddLoginFailure(ctx, "alice@canonical.com", now, time.Minute, 2, 10*time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(locked, qt.IsFalse)
+	locked, err = s.Database.AddLoginFailure(ctx, "alice@canonical.com", now.Add(2*time.Minute), time.Minute, 2, 10*time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(locked, qt.IsFalse)
+
+	// A success clears the failures.
+	err = s.Database.DeleteLoginFailures(ctx, "alice@canonical.com", now.Add(2*time.Minute))
+	c.Assert(err, qt.IsNil)
+	locked, err = s.Database.AddLoginFailure(ctx, "alice@canonical.com", now.Add(3*time.Minute), time.Minute, 2, 10*time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(locked, qt.IsFalse)
+
+	locked, err = s.Database.AddLoginFailure(ctx, "alice@canonical.com", now.Add(3*time.Minute), time.Minute, 2, 10*time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(locked, qt.IsTrue)
+	lockedUntil, err = s.Database.GetLoginLockedUntil(ctx, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(lockedUntil.Equal(now.Add(13*time.Minute)), qt.IsTrue)
+
+	// A lockout is not cleared by a success.
+	err = s.Database.DeleteLoginFailures(ctx, "alice@canonical.com", now.Add(4*time.Minute))
+	c.Assert(err, qt.IsNil)
+	lockedUntil, err = s.Database.GetLoginLockedUntil(ctx, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(lockedUntil.Equal(now.Add(13*time.Minute)), qt.IsTrue)
+
+	// The record is removed once it has expired.
+	removed, err := s.Database.DeleteExpiredLoginFailures(ctx, now.Add(12*time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Check(removed, qt.Equals, int64(0))
+	removed, err = s.Database.DeleteExpiredLoginFailures(ctx, now.Add(14*time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Check(removed, qt.Equals, int64(1))
+}
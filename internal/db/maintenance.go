@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// maintenanceModeID is the ID of the only maintenance mode row.
+const maintenanceModeID = 1
+
+// GetMaintenanceMode returns the current maintenance mode. If the
+// maintenance mode has never been set a disabled maintenance mode is
+// returned.
+func (d *Database) GetMaintenanceMode(ctx context.Context) (_ *dbmodel.MaintenanceMode, err error) {
+	const op = errors.Op("db.GetMaintenanceMode")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var mm []dbmodel.MaintenanceMode
+	if err := d.DB.WithContext(ctx).Where("id = ?", maintenanceModeID).Limit(1).Find(&mm).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	if len(mm) == 0 {
+		return &dbmodel.MaintenanceMode{ID: maintenanceModeID}, nil
+	}
+	return &mm[0], nil
+}
+
+// SetMaintenanceMode stores the given maintenance mode, replacing the
+// current maintenance mode.
+func (d *Database) SetMaintenanceMode(ctx context.Context, mm *dbmodel.MaintenanceMode) (err error) {
+	const op = errors.Op("db.SetMaintenanceMode")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	mm.ID = maintenanceModeID
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "enabled", "message", "set_by"}),
+	}).Create(mm).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
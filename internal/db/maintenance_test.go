@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestGetMaintenanceModeUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.GetMaintenanceMode(context.Background())
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestMaintenanceMode(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	mm, err := s.Database.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+
+	err = s.Database.SetMaintenanceMode(ctx, &dbmodel.MaintenanceMode{
+		Enabled: true,
+		Message: "upgrading",
+		SetBy:   "alice@canonical.com",
+	})
+	c.Assert(err, qt.IsNil)
+
+	mm, err = s.Database.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsTrue)
+	c.Check(mm.Message, qt.Equals, "upgrading")
+	c.Check(mm.SetBy, qt.Equals, "alice@canonical.com")
+
+	err = s.Database.SetMaintenanceMode(ctx, &dbmodel.MaintenanceMode{
+		SetBy: "bob@canonical.com",
+	})
+	c.Assert(err, qt.IsNil)
+
+	mm, err = s.Database.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+	c.Check(mm.Message, qt.Equals, "")
+	c.Check(mm.SetBy, qt.Equals, "bob@canonical.com")
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddMaintenanceWindow stores the given maintenance window.
+func (d *Database) AddMaintenanceWindow(ctx context.Context, w *dbmodel.MaintenanceWindow) (err error) {
+	const op = errors.Op("db.AddMaintenanceWindow")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Omit("Controller").Create(w).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetMaintenanceWindow fills in the given maintenance window, which is
+// found using its ID. If there is no such window an error with a code of
+// CodeNotFound is returned.
+func (d *Database) GetMaintenanceWindow(ctx context.Context, w *dbmodel.MaintenanceWindow) (err error) {
+	const op = errors.Op("db.GetMaintenanceWindow")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Preload("Controller").First(w, w.ID).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteMaintenanceWindow removes the given maintenance window.
+func (d *Database) DeleteMaintenanceWindow(ctx context.Context, w *dbmodel.MaintenanceWindow) (err error) {
+	const op = errors.Op("db.DeleteMaintenanceWindow")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(w).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListMaintenanceWindows returns the maintenance windows that end after
+// the given time, ordered by start time. If controllerID is not zero only
+// the windows for that controller are returned.
+func (d *Database) ListMaintenanceWindows(ctx context.Context, controllerID uint, after time.Time) (_ []dbmodel.MaintenanceWindow, err error) {
+	const op = errors.Op("db.ListMaintenanceWindows")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Preload("Controller").Where("ends_at > ?", after)
+	if controllerID != 0 {
+		db = db.Where("controller_id = ?", controllerID)
+	}
+	var windows []dbmodel.MaintenanceWindow
+	if err := db.Order("starts_at, id").Find(&windows).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return windows, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddMaintenanceWindowUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddMaintenanceWindow(context.Background(), &dbmodel.MaintenanceWindow{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestMaintenanceWindows(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+	}
+	err = s.Database.AddCloud(ctx, &cloud)
+	c.Assert(err, qt.IsNil)
+
+	ctl1 := dbmodel.Controller{
+		Name:      "controller-1",
+		UUID:      "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName: "test-cloud",
+	}
+	err = s.Database.AddController(ctx, &ctl1)
+	c.Assert(err, qt.IsNil)
+	ctl2 := dbmodel.Controller{
+		Name:      "controller-2",
+		UUID:      "00000000-0000-0000-0000-0000-0000000000002",
+		CloudName: "test-cloud",
+	}
+	err = s.Database.AddController(ctx, &ctl2)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	past := dbmodel.MaintenanceWindow{
+		ControllerID: ctl1.ID,
+		StartsAt:     now.Add(-2 * time.Hour),
+		EndsAt:       now.Add(-time.Hour),
+		CreatedBy:    "alice@canonical.com",
+	}
+	err = s.Database.AddMaintenanceWindow(ctx, &past)
+	c.Assert(err, qt.IsNil)
+	future := dbmodel.MaintenanceWindow{
+		ControllerID: ctl1.ID,
+		StartsAt:     now.Add(time.Hour),
+		EndsAt:       now.Add(2 * time.Hour),
+		Reason:       "upgrade to 3.6",
+		CreatedBy:    "alice@canonical.com",
+	}
+	err = s.Database.AddMaintenanceWindow(ctx, &future)
+	c.Assert(err, qt.IsNil)
+	active := dbmodel.MaintenanceWindow{
+		ControllerID: ctl2.ID,
+		StartsAt:     now.Add(-time.Hour),
+		EndsAt:       now.Add(time.Hour),
+		CreatedBy:    "alice@canonical.com",
+	}
+	err = s.Database.AddMaintenanceWindow(ctx, &active)
+	c.Assert(err, qt.IsNil)
+
+	invalid := dbmodel.MaintenanceWindow{
+		ControllerID: ctl2.ID,
+		StartsAt:     now,
+		EndsAt:       now,
+		CreatedBy:    "alice@canonical.com",
+	}
+	err = s.Database.AddMaintenanceWindow(ctx, &invalid)
+	c.Check(err, qt.Not(qt.IsNil))
+
+	windows, err := s.Database.ListMaintenanceWindows(ctx, 0, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(windows, qt.HasLen, 2)
+	c.Check(windows[0].ID, qt.Equals, active.ID)
+	c.Check(windows[0].Controller.Name, qt.Equals, "controller-2")
+	c.Check(windows[1].ID, qt.Equals, future.ID)
+
+	windows, err = s.Database.ListMaintenanceWindows(ctx, ctl1.ID, time.Time{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(windows, qt.HasLen, 2)
+	c.Check(windows[0].ID, qt.Equals, past.ID)
+	c.Check(windows[1].ID, qt.Equals, future.ID)
+
+	w := dbmodel.MaintenanceWindow{ID: future.ID}
+	err = s.Database.GetMaintenanceWindow(ctx, &w)
+	c.Assert(err, qt.IsNil)
+	c.Check(w.Reason, qt.Equals, "upgrade to 3.6")
+	c.Check(w.Controller.Name, qt.Equals, "controller-1")
+	c.Check(w.Active(now), qt.IsFalse)
+	c.Check(w.Active(now.Add(90*time.Minute)), qt.IsTrue)
+
+	err = s.Database.DeleteMaintenanceWindow(ctx, &w)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetMaintenanceWindow(ctx, &w)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Windows are removed with their controller.
+	err = s.Database.DeleteController(ctx, &ctl2)
+	c.Assert(err, qt.IsNil)
+	windows, err = s.Database.ListMaintenanceWindows(ctx, 0, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(windows, qt.HasLen, 0)
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddMigration stores the given migration.
+func (d *Database) AddMigration(ctx context.Context, m *dbmodel.Migration) (err error) {
+	const op = errors.Op("db.AddMigration")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(m).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateMigration updates the stored state of the given migration.
+func (d *Database) UpdateMigration(ctx context.Context, m *dbmodel.Migration) (err error) {
+	const op = errors.Op("db.UpdateMigration")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Save(m).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// MigrationFilter holds the criteria used to select migrations in
+// ListMigrations. Empty fields match every migration.
+type MigrationFilter struct {
+	// ModelUUID selects the migrations of the model with this UUID.
+	ModelUUID string
+
+	// Statuses selects the migrations in any of these states.
+	Statuses []string
+
+	// Limit is the maximum number of migrations to return. A value of
+	// zero will ignore the limit.
+	Limit int
+}
+
+// ListMigrations returns the migrations that match the given filter,
+// oldest first.
+func (d *Database) ListMigrations(ctx context.Context, filter MigrationFilter) (_ []dbmodel.Migration, err error) {
+	const op = errors.Op("db.ListMigrations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if filter.ModelUUID != "" {
+		db = db.Where("model_uuid = ?", filter.ModelUUID)
+	}
+	if len(filter.Statuses) > 0 {
+		db = db.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	var migrations []dbmodel.Migration
+	if err := db.Order("id").Find(&migrations).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return migrations, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func (s *dbSuite) TestMigrations(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.GetIdentity(ctx, u), qt.IsNil)
+
+	m1 := dbmodel.Migration{
+		ModelUUID:        "00000002-0000-0000-0000-000000000001",
+		ModelName:        "model-1",
+		SourceController: "controller-1",
+		TargetController: "controller-2",
+		IdentityName:     u.Name,
+		Status:           dbmodel.MigrationQueued,
+	}
+	err = s.Database.AddMigration(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	m2 := m1
+	m2.ModelUUID = "00000002-0000-0000-0000-000000000002"
+	m2.ModelName = "model-2"
+	err = s.Database.AddMigration(ctx, &m2)
+	c.Assert(err, qt.IsNil)
+
+	m1.Status = dbmodel.MigrationStarted
+	m1.MigrationID = "00000002-0000-0000-0000-000000000001:0"
+	err = s.Database.UpdateMigration(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+
+	migrations, err := s.Database.ListMigrations(ctx, db.MigrationFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 2)
+	c.Check(migrations[0].ID, qt.Equals, m1.ID)
+	c.Check(migrations[0].MigrationID, qt.Equals, m1.MigrationID)
+
+	migrations, err = s.Database.ListMigrations(ctx, db.MigrationFilter{Statuses: []string{dbmodel.MigrationQueued}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].ModelName, qt.Equals, "model-2")
+
+	migrations, err = s.Database.ListMigrations(ctx, db.MigrationFilter{ModelUUID: m1.ModelUUID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].Status, qt.Equals, dbmodel.MigrationStarted)
+}
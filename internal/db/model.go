@@ -10,6 +10,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddModel stores the model information.
@@ -17,6 +18,8 @@ import (
 //     model with the same name already exists.
 func (d *Database) AddModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.AddModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -37,6 +40,8 @@ func (d *Database) AddModel(ctx context.Context, model *dbmodel.Model) (err erro
 // model UUID.
 func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.GetModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -78,6 +83,8 @@ func (d *Database) GetModel(ctx context.Context, model *dbmodel.Model) (err erro
 // GetModelsUsingCredential returns all models that use the specified credentials.
 func (d *Database) GetModelsUsingCredential(ctx context.Context, credentialID uint) (_ []dbmodel.Model, err error) {
 	const op = errors.Op("db.GetModelsUsingCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -95,9 +102,49 @@ func (d *Database) GetModelsUsingCredential(ctx context.Context, credentialID ui
 	return models, nil
 }
 
+// CountModelsUsingCredentials returns the number of models using each of
+// the given cloud credentials, keyed by credential ID. Credentials not
+// used by any model are not included.
+func (d *Database) CountModelsUsingCredentials(ctx context.Context, credentialIDs []uint) (_ map[uint]int, err error) {
+	const op = errors.Op("db.CountModelsUsingCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	counts := make(map[uint]int)
+	if len(credentialIDs) == 0 {
+		return counts, nil
+	}
+	db := d.DB.WithContext(ctx)
+	var rows []struct {
+		CloudCredentialID uint
+		Count             int
+	}
+	result := db.Model(&dbmodel.Model{}).
+		Select("cloud_credential_id, count(*) AS count").
+		Where("cloud_credential_id IN ?", credentialIDs).
+		Group("cloud_credential_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, errors.E(op, dbError(result.Error))
+	}
+	for _, r := range rows {
+		counts[r.CloudCredentialID] = r.Count
+	}
+	return counts, nil
+}
+
 // UpdateModel updates the model information.
 func (d *Database) UpdateModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.UpdateModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -113,9 +160,32 @@ func (d *Database) UpdateModel(ctx context.Context, model *dbmodel.Model) (err e
 	return nil
 }
 
+// UpdateModelLastActivity stores the LastActivity time of the given
+// model, which must have its ID set. No other fields are updated.
+func (d *Database) UpdateModelLastActivity(ctx context.Context, model *dbmodel.Model) (err error) {
+	const op = errors.Op("db.UpdateModelLastActivity")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(model).UpdateColumn("last_activity", model.LastActivity).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // DeleteModel removes the model information from the database.
 func (d *Database) DeleteModel(ctx context.Context, model *dbmodel.Model) (err error) {
 	const op = errors.Op("db.DeleteModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -136,6 +206,8 @@ func (d *Database) DeleteModel(ctx context.Context, model *dbmodel.Model) (err e
 // will stop immediately and the error will be returned unmodified.
 func (d *Database) ForEachModel(ctx context.Context, f func(m *dbmodel.Model) error) (err error) {
 	const op = errors.Op("db.ForEachModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -178,6 +250,8 @@ func (d *Database) ForEachModel(ctx context.Context, f func(m *dbmodel.Model) er
 // the provided modelUUIDs slice.
 func (d *Database) GetModelsByUUID(ctx context.Context, modelUUIDs []string) (_ []dbmodel.Model, err error) {
 	const op = errors.Op("db.GetModelsByUUID")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
@@ -219,8 +293,10 @@ func preloadModel(prefix string, db *gorm.DB) *gorm.DB {
 
 // GetModelsByController retrieves a list of models hosted on the specified controller.
 // Note that because we do not preload here, foreign key references will be empty.
-func (d *Database) GetModelsByController(ctx context.Context, ctl dbmodel.Controller) ([]dbmodel.Model, error) {
+func (d *Database) GetModelsByController(ctx context.Context, ctl dbmodel.Controller) (_ []dbmodel.Model, err error) {
 	const op = errors.Op("db.GetModelsByController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
@@ -233,9 +309,76 @@ func (d *Database) GetModelsByController(ctx context.Context, ctl dbmodel.Contro
 	return models, nil
 }
 
+// CountModelsByControllers returns the number of models hosted on each
+// controller, keyed by controller ID. Controllers hosting no models are
+// not included.
+func (d *Database) CountModelsByControllers(ctx context.Context) (_ map[uint]int, err error) {
+	const op = errors.Op("db.CountModelsByControllers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var rows []struct {
+		ControllerID uint
+		Count        int
+	}
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(&dbmodel.Model{}).Select("controller_id, count(*) AS count").Group("controller_id").Scan(&rows).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	counts := make(map[uint]int, len(rows))
+	for _, r := range rows {
+		counts[r.ControllerID] = r.Count
+	}
+	return counts, nil
+}
+
+// CountModelsByCloudRegions returns the number of models hosted in each
+// of the given cloud regions, keyed by region ID. Regions hosting no
+// models are not included.
+func (d *Database) CountModelsByCloudRegions(ctx context.Context, regionIDs []uint) (_ map[uint]int, err error) {
+	const op = errors.Op("db.CountModelsByCloudRegions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	counts := make(map[uint]int)
+	if len(regionIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		CloudRegionID uint
+		Count         int
+	}
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(&dbmodel.Model{}).Select("cloud_region_id, count(*) AS count").Where("cloud_region_id IN ?", regionIDs).Group("cloud_region_id").Scan(&rows).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	for _, r := range rows {
+		counts[r.CloudRegionID] = r.Count
+	}
+	return counts, nil
+}
+
 // CountModelsByController counts the number of models hosted on a controller.
-func (d *Database) CountModelsByController(ctx context.Context, ctl dbmodel.Controller) (int, error) {
+func (d *Database) CountModelsByController(ctx context.Context, ctl dbmodel.Controller) (_ int, err error) {
 	const op = errors.Op("db.CountModelsByController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return 0, errors.E(op, err)
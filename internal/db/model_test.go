@@ -466,6 +466,10 @@ func (s *dbSuite) TestGetModelsUsingCredential(c *qt.C) {
 	models, err = s.Database.GetModelsUsingCredential(context.Background(), 0)
 	c.Assert(err, qt.IsNil)
 	c.Assert(models, qt.HasLen, 0)
+
+	counts, err := s.Database.CountModelsUsingCredentials(context.Background(), []uint{cred1.ID, cred2.ID, 0})
+	c.Assert(err, qt.IsNil)
+	c.Check(counts, qt.DeepEquals, map[uint]int{cred1.ID: 1, cred2.ID: 1})
 }
 
 func TestForEachModelUnconfiguredDatabase(t *testing.T) {
@@ -788,3 +792,34 @@ func (s *dbSuite) TestCountModelsByController(c *qt.C) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(count, qt.Equals, 3)
 }
+
+func TestCountModelsByCloudRegionsUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.CountModelsByCloudRegions(context.Background(), []uint{1})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestCountModelsByCloudRegions(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testCountModelsByControllerEnv)
+	env.PopulateDB(c, *s.Database)
+
+	cloud := dbmodel.Cloud{Name: "test"}
+	err = s.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, qt.IsNil)
+	regionID := cloud.Regions[0].ID
+
+	counts, err := s.Database.CountModelsByCloudRegions(ctx, []uint{regionID, regionID + 1000})
+	c.Assert(err, qt.IsNil)
+	c.Check(counts, qt.DeepEquals, map[uint]int{regionID: 3})
+
+	counts, err = s.Database.CountModelsByCloudRegions(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(counts, qt.HasLen, 0)
+}
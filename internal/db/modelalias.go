@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// SetModelAlias stores the given model alias. If the identity already
+// has an alias with the same name it is changed to refer to the alias's
+// model.
+func (d *Database) SetModelAlias(ctx context.Context, alias *dbmodel.ModelAlias) (err error) {
+	const op = errors.Op("db.SetModelAlias")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	err = d.DB.WithContext(ctx).Omit("Model").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "identity_name"}, {Name: "alias"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "model_id"}),
+	}).Create(alias).Error
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelAlias fills in the given model alias, which is found using its
+// identity name and alias. The UUID of the model is also filled in. If
+// there is no such alias an error with a code of CodeNotFound is
+// returned.
+func (d *Database) GetModelAlias(ctx context.Context, alias *dbmodel.ModelAlias) (err error) {
+	const op = errors.Op("db.GetModelAlias")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Preload("Model")
+	if err := db.Where("identity_name = ? AND alias = ?", alias.IdentityName, alias.Alias).First(alias).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListModelAliases returns the model aliases owned by the given
+// identity, ordered by alias.
+func (d *Database) ListModelAliases(ctx context.Context, identityName string) (_ []dbmodel.ModelAlias, err error) {
+	const op = errors.Op("db.ListModelAliases")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var aliases []dbmodel.ModelAlias
+	db := d.DB.WithContext(ctx).Preload("Model")
+	if err := db.Where("identity_name = ?", identityName).Order("alias").Find(&aliases).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return aliases, nil
+}
+
+// DeleteModelAlias removes the given model alias.
+func (d *Database) DeleteModelAlias(ctx context.Context, alias *dbmodel.ModelAlias) (err error) {
+	const op = errors.Op("db.DeleteModelAlias")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(alias).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
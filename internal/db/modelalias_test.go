@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestSetModelAliasUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetModelAlias(context.Background(), &dbmodel.ModelAlias{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestModelAliases(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.DB.Create(&u).Error, qt.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name:    "test-cloud",
+		Type:    "test-provider",
+		Regions: []dbmodel.CloudRegion{{Name: "test-region"}},
+	}
+	c.Assert(s.Database.DB.Create(&cloud).Error, qt.IsNil)
+
+	cred := dbmodel.CloudCredential{
+		Name:              "test-cred",
+		CloudName:         cloud.Name,
+		OwnerIdentityName: u.Name,
+		AuthType:          "empty",
+	}
+	c.Assert(s.Database.DB.Create(&cred).Error, qt.IsNil)
+
+	controller := dbmodel.Controller{
+		Name:        "test-controller",
+		UUID:        "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName:   "test-cloud",
+		CloudRegion: "test-region",
+	}
+	err = s.Database.AddController(ctx, &controller)
+	c.Assert(err, qt.IsNil)
+
+	var models []dbmodel.Model
+	for _, uuid := range []string{"00000002-0000-0000-0000-000000000001", "00000002-0000-0000-0000-000000000002"} {
+		m := dbmodel.Model{
+			Name:              "model-" + uuid[len(uuid)-1:],
+			UUID:              sql.NullString{String: uuid, Valid: true},
+			OwnerIdentityName: u.Name,
+			ControllerID:      controller.ID,
+			CloudRegionID:     cloud.Regions[0].ID,
+			CloudCredentialID: cred.ID,
+		}
+		c.Assert(s.Database.DB.Create(&m).Error, qt.IsNil)
+		models = append(models, m)
+	}
+
+	a1 := dbmodel.ModelAlias{IdentityName: u.Name, Alias: "prod", ModelID: models[0].ID}
+	err = s.Database.SetModelAlias(ctx, &a1)
+	c.Assert(err, qt.IsNil)
+	a2 := dbmodel.ModelAlias{IdentityName: u.Name, Alias: "dev", ModelID: models[1].ID}
+	err = s.Database.SetModelAlias(ctx, &a2)
+	c.Assert(err, qt.IsNil)
+
+	a := dbmodel.ModelAlias{IdentityName: u.Name, Alias: "prod"}
+	err = s.Database.GetModelAlias(ctx, &a)
+	c.Assert(err, qt.IsNil)
+	c.Check(a.Model.UUID.String, qt.Equals, "00000002-0000-0000-0000-000000000001")
+
+	// Setting an existing alias changes the model it refers to.
+	err = s.Database.SetModelAlias(ctx, &dbmodel.ModelAlias{IdentityName: u.Name, Alias: "prod", ModelID: models[1].ID})
+	c.Assert(err, qt.IsNil)
+	a = dbmodel.ModelAlias{IdentityName: u.Name, Alias: "prod"}
+	err = s.Database.GetModelAlias(ctx, &a)
+	c.Assert(err, qt.IsNil)
+	c.Check(a.ID, qt.Equals, a1.ID)
+	c.Check(a.Model.UUID.String, qt.Equals, "00000002-0000-0000-0000-000000000002")
+
+	aliases, err := s.Database.ListModelAliases(ctx, u.Name)
+	c.Assert(err, qt.IsNil)
+	c.Assert(aliases, qt.HasLen, 2)
+	c.Check(aliases[0].Alias, qt.Equals, "dev")
+	c.Check(aliases[1].Alias, qt.Equals, "prod")
+
+	aliases, err = s.Database.ListModelAliases(ctx, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(aliases, qt.HasLen, 0)
+
+	err = s.Database.DeleteModelAlias(ctx, &a)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetModelAlias(ctx, &a)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Aliases are removed with their model.
+	err = s.Database.DeleteModel(ctx, &models[1])
+	c.Assert(err, qt.IsNil)
+	a = dbmodel.ModelAlias{IdentityName: u.Name, Alias: "dev"}
+	err = s.Database.GetModelAlias(ctx, &a)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddModelArchive stores the given model archive.
+func (d *Database) AddModelArchive(ctx context.Context, a *dbmodel.ModelArchive) (err error) {
+	const op = errors.Op("db.AddModelArchive")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(a).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelArchive fills in the given model archive from the most recent
+// archive of the model with the same ModelUUID. If the model has not
+// been archived an error with a code of CodeNotFound is returned.
+func (d *Database) GetModelArchive(ctx context.Context, a *dbmodel.ModelArchive) (err error) {
+	const op = errors.Op("db.GetModelArchive")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_uuid = ?", a.ModelUUID).Order("created_at DESC, id DESC").First(a).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func (s *dbSuite) TestModelArchives(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	a := dbmodel.ModelArchive{ModelUUID: "00000002-0000-0000-0000-000000000001"}
+	err = s.Database.GetModelArchive(ctx, &a)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	for _, name := range []string{"model-1", "model-1-again"} {
+		err = s.Database.AddModelArchive(ctx, &dbmodel.ModelArchive{
+			ModelUUID:         "00000002-0000-0000-0000-000000000001",
+			ModelName:         name,
+			OwnerIdentityName: "alice@canonical.com",
+			ControllerName:    "controller-1",
+			ArchivedBy:        "bob@canonical.com",
+			Config:            dbmodel.Map{"default-series": "jammy"},
+			Status:            dbmodel.Map{"applications": map[string]interface{}{}},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	// The most recent archive is returned.
+	err = s.Database.GetModelArchive(ctx, &a)
+	c.Assert(err, qt.IsNil)
+	c.Check(a.ModelName, qt.Equals, "model-1-again")
+	c.Check(a.Config, qt.DeepEquals, dbmodel.Map{"default-series": "jammy"})
+	c.Check(a.ArchivedBy, qt.Equals, "bob@canonical.com")
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// SetModelConfigPolicy stores the given model config policy, replacing
+// any existing policy for the same key.
+func (d *Database) SetModelConfigPolicy(ctx context.Context, policy *dbmodel.ModelConfigPolicy) (err error) {
+	const op = errors.Op("db.SetModelConfigPolicy")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "action", "value", "description"}),
+	}).Create(policy).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// RemoveModelConfigPolicy removes the policy for the key given in the
+// policy. If there is no such policy an error with a code of
+// CodeNotFound is returned.
+func (d *Database) RemoveModelConfigPolicy(ctx context.Context, policy *dbmodel.ModelConfigPolicy) (err error) {
+	const op = errors.Op("db.RemoveModelConfigPolicy")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Where("key = ?", policy.Key).Delete(&dbmodel.ModelConfigPolicy{})
+	if result.Error != nil {
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return errors.E(op, errors.CodeNotFound, "model config policy not found")
+	}
+	return nil
+}
+
+// ListModelConfigPolicies returns every model config policy, ordered by
+// key.
+func (d *Database) ListModelConfigPolicies(ctx context.Context) (_ []dbmodel.ModelConfigPolicy, err error) {
+	const op = errors.Op("db.ListModelConfigPolicies")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var policies []dbmodel.ModelConfigPolicy
+	if err := d.DB.WithContext(ctx).Order("key asc").Find(&policies).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return policies, nil
+}
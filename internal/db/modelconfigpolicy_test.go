@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestSetModelConfigPolicyUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetModelConfigPolicy(context.Background(), &dbmodel.ModelConfigPolicy{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestModelConfigPolicies(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.SetModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{
+		Key:    "logging-config",
+		Action: "force",
+		Value:  sql.NullString{String: "<root>=INFO", Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{
+		Key:    "firewall-mode",
+		Action: "forbid",
+		Value:  sql.NullString{String: "none", Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{
+		Key:         "logging-config",
+		Action:      "force",
+		Value:       sql.NullString{String: "<root>=DEBUG", Valid: true},
+		Description: "debug logging is required",
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.SetModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{
+		Key:    "default-series",
+		Action: "force",
+	})
+	c.Check(err, qt.Not(qt.IsNil))
+
+	policies, err := s.Database.ListModelConfigPolicies(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(policies, qt.HasLen, 2)
+	c.Check(policies[0].Key, qt.Equals, "firewall-mode")
+	c.Check(policies[1].Key, qt.Equals, "logging-config")
+	c.Check(policies[1].Value.String, qt.Equals, "<root>=DEBUG")
+	c.Check(policies[1].Description, qt.Equals, "debug logging is required")
+
+	err = s.Database.RemoveModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{Key: "firewall-mode"})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.RemoveModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{Key: "firewall-mode"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	policies, err = s.Database.ListModelConfigPolicies(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(policies, qt.HasLen, 1)
+}
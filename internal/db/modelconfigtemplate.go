@@ -0,0 +1,101 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// SetModelConfigTemplate stores the given model config template. If the
+// owner already has a template with the same name its config and the
+// identities it is shared with are replaced.
+func (d *Database) SetModelConfigTemplate(ctx context.Context, t *dbmodel.ModelConfigTemplate) (err error) {
+	const op = errors.Op("db.SetModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	err = d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner_identity_name"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "config", "shared_with"}),
+	}).Create(t).Error
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelConfigTemplate fills in the given model config template, which
+// is found using its owner and name. If there is no such template an
+// error with a code of CodeNotFound is returned.
+func (d *Database) GetModelConfigTemplate(ctx context.Context, t *dbmodel.ModelConfigTemplate) (err error) {
+	const op = errors.Op("db.GetModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("owner_identity_name = ? AND name = ?", t.OwnerIdentityName, t.Name).First(t).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteModelConfigTemplate removes the given model config template.
+func (d *Database) DeleteModelConfigTemplate(ctx context.Context, t *dbmodel.ModelConfigTemplate) (err error) {
+	const op = errors.Op("db.DeleteModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(t).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListModelConfigTemplates returns every model config template, ordered
+// by owner and name.
+func (d *Database) ListModelConfigTemplates(ctx context.Context) (_ []dbmodel.ModelConfigTemplate, err error) {
+	const op = errors.Op("db.ListModelConfigTemplates")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var templates []dbmodel.ModelConfigTemplate
+	if err := d.DB.WithContext(ctx).Order("owner_identity_name, name").Find(&templates).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return templates, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestSetModelConfigTemplateUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetModelConfigTemplate(context.Background(), &dbmodel.ModelConfigTemplate{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestModelConfigTemplates(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com"} {
+		i, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		c.Assert(s.Database.DB.Create(i).Error, qt.IsNil)
+	}
+
+	t1 := dbmodel.ModelConfigTemplate{
+		OwnerIdentityName: "bob@canonical.com",
+		Name:              "default",
+		Config:            dbmodel.Map{"logging-config": "<root>=INFO"},
+	}
+	err = s.Database.SetModelConfigTemplate(ctx, &t1)
+	c.Assert(err, qt.IsNil)
+	t2 := dbmodel.ModelConfigTemplate{
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "default",
+		Config:            dbmodel.Map{"automatically-retry-hooks": false},
+		SharedWith:        dbmodel.Strings{"bob@canonical.com"},
+	}
+	err = s.Database.SetModelConfigTemplate(ctx, &t2)
+	c.Assert(err, qt.IsNil)
+
+	t := dbmodel.ModelConfigTemplate{OwnerIdentityName: "alice@canonical.com", Name: "default"}
+	err = s.Database.GetModelConfigTemplate(ctx, &t)
+	c.Assert(err, qt.IsNil)
+	c.Check(t.Config, qt.DeepEquals, t2.Config)
+	c.Check(t.SharedWith, qt.DeepEquals, t2.SharedWith)
+	c.Check(t.Path(), qt.Equals, "alice@canonical.com/default")
+
+	update := dbmodel.ModelConfigTemplate{
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "default",
+		Config:            dbmodel.Map{"automatically-retry-hooks": true},
+	}
+	err = s.Database.SetModelConfigTemplate(ctx, &update)
+	c.Assert(err, qt.IsNil)
+	t = dbmodel.ModelConfigTemplate{OwnerIdentityName: "alice@canonical.com", Name: "default"}
+	err = s.Database.GetModelConfigTemplate(ctx, &t)
+	c.Assert(err, qt.IsNil)
+	c.Check(t.ID, qt.Equals, t2.ID)
+	c.Check(t.Config, qt.DeepEquals, update.Config)
+	c.Check(t.SharedWith, qt.HasLen, 0)
+
+	templates, err := s.Database.ListModelConfigTemplates(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(templates, qt.HasLen, 2)
+	c.Check(templates[0].Path(), qt.Equals, "alice@canonical.com/default")
+	c.Check(templates[1].Path(), qt.Equals, "bob@canonical.com/default")
+
+	err = s.Database.DeleteModelConfigTemplate(ctx, &t)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetModelConfigTemplate(ctx, &t)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
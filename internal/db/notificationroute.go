@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddNotificationRoute stores the given notification route. If an
+// identical route already exists an error with a code of
+// CodeAlreadyExists is returned.
+func (d *Database) AddNotificationRoute(ctx context.Context, route *dbmodel.NotificationRoute) (err error) {
+	const op = errors.Op("db.AddNotificationRoute")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(route).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetNotificationRoute fills in the given notification route, which is
+// found using its ID. If there is no such route an error with a code of
+// CodeNotFound is returned.
+func (d *Database) GetNotificationRoute(ctx context.Context, route *dbmodel.NotificationRoute) (err error) {
+	const op = errors.Op("db.GetNotificationRoute")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).First(route, route.ID).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteNotificationRoute removes the given notification route.
+func (d *Database) DeleteNotificationRoute(ctx context.Context, route *dbmodel.NotificationRoute) (err error) {
+	const op = errors.Op("db.DeleteNotificationRoute")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(route).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListNotificationRoutes returns the notification routes for the
+// subjects of the given kind with the given IDs, ordered by ID. If
+// subjectKind is empty all routes are returned.
+func (d *Database) ListNotificationRoutes(ctx context.Context, subjectKind string, subjectIDs ...string) (_ []dbmodel.NotificationRoute, err error) {
+	const op = errors.Op("db.ListNotificationRoutes")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if subjectKind != "" {
+		if len(subjectIDs) == 0 {
+			return nil, nil
+		}
+		db = db.Where("subject_kind = ? AND subject_id IN ?", subjectKind, subjectIDs)
+	}
+	var routes []dbmodel.NotificationRoute
+	if err := db.Order("id asc").Find(&routes).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return routes, nil
+}
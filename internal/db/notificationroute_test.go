@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddNotificationRouteUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddNotificationRoute(context.Background(), &dbmodel.NotificationRoute{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestNotificationRoutes(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	r1 := dbmodel.NotificationRoute{
+		SubjectKind: "user",
+		SubjectID:   "alice@canonical.com",
+		Transport:   "email",
+		Destination: "alice@canonical.com",
+	}
+	err = s.Database.AddNotificationRoute(ctx, &r1)
+	c.Assert(err, qt.IsNil)
+	r2 := dbmodel.NotificationRoute{
+		SubjectKind: "group",
+		SubjectID:   "00000000-0000-0000-0000-000000000001",
+		Kind:        "usage-alert",
+		Transport:   "slack",
+		Destination: "https://hooks.slack.com/services/x",
+	}
+	err = s.Database.AddNotificationRoute(ctx, &r2)
+	c.Assert(err, qt.IsNil)
+
+	dup := r1
+	dup.ID = 0
+	err = s.Database.AddNotificationRoute(ctx, &dup)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	routes, err := s.Database.ListNotificationRoutes(ctx, "user", "alice@canonical.com", "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(routes, qt.HasLen, 1)
+	c.Check(routes[0].ID, qt.Equals, r1.ID)
+
+	routes, err = s.Database.ListNotificationRoutes(ctx, "group")
+	c.Assert(err, qt.IsNil)
+	c.Check(routes, qt.HasLen, 0)
+
+	routes, err = s.Database.ListNotificationRoutes(ctx, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(routes, qt.HasLen, 2)
+
+	r := dbmodel.NotificationRoute{ID: r2.ID}
+	err = s.Database.GetNotificationRoute(ctx, &r)
+	c.Assert(err, qt.IsNil)
+	c.Check(r.Destination, qt.Equals, r2.Destination)
+
+	err = s.Database.DeleteNotificationRoute(ctx, &r)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetNotificationRoute(ctx, &r)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func (s *dbSuite) TestWebhookDeliveries(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	d1 := dbmodel.WebhookDelivery{
+		Event:   "model-created",
+		URL:     "https://example.com/hook",
+		Payload: []byte(`{"event":"model-created"}`),
+		Status:  dbmodel.WebhookDeliveryPending,
+	}
+	err = s.Database.AddWebhookDelivery(ctx, &d1)
+	c.Assert(err, qt.IsNil)
+	d2 := dbmodel.WebhookDelivery{
+		Event:   "controller-unavailable",
+		URL:     "https://example.com/hook",
+		Payload: []byte(`{"event":"controller-unavailable"}`),
+		Status:  dbmodel.WebhookDeliveryPending,
+	}
+	err = s.Database.AddWebhookDelivery(ctx, &d2)
+	c.Assert(err, qt.IsNil)
+
+	d1.Status = dbmodel.WebhookDeliveryFailed
+	d1.Error = "webhook returned status 500"
+	err = s.Database.UpdateWebhookDelivery(ctx, &d1)
+	c.Assert(err, qt.IsNil)
+
+	deliveries, err := s.Database.ListWebhookDeliveries(ctx, "", 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deliveries, qt.HasLen, 2)
+	c.Check(deliveries[0].ID, qt.Equals, d2.ID)
+
+	deliveries, err = s.Database.ListWebhookDeliveries(ctx, dbmodel.WebhookDeliveryFailed, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deliveries, qt.HasLen, 1)
+	c.Check(deliveries[0].Error, qt.Equals, "webhook returned status 500")
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+const (
+	// replicaCheckInterval is the minimum time between checks of the
+	// availability of a read replica.
+	replicaCheckInterval = 10 * time.Second
+
+	// replicaCheckTimeout is the time allowed for a read replica to
+	// respond to an availability check.
+	replicaCheckTimeout = 2 * time.Second
+)
+
+// A ReadReplica is a read-only replica of the primary database. A
+// ReadReplica is safe to use from multiple goroutines.
+type ReadReplica struct {
+	// DB contains the gorm database connected to the replica.
+	DB *gorm.DB
+
+	mu        sync.Mutex
+	checking  bool
+	checked   time.Time
+	available bool
+}
+
+// NewReadReplica returns a ReadReplica using the given database.
+func NewReadReplica(db *gorm.DB) *ReadReplica {
+	return &ReadReplica{DB: db}
+}
+
+// isAvailable reports whether the replica was available when last
+// checked, checking again if the last check is older than
+// replicaCheckInterval. While a check is in progress the result of the
+// previous check is used.
+func (r *ReadReplica) isAvailable(ctx context.Context) bool {
+	r.mu.Lock()
+	if r.checking || time.Since(r.checked) < replicaCheckInterval {
+		defer r.mu.Unlock()
+		return r.available
+	}
+	r.checking = true
+	r.mu.Unlock()
+	return r.check(ctx)
+}
+
+// check pings the replica and records whether it is available.
+func (r *ReadReplica) check(ctx context.Context) bool {
+	err := r.ping(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil && r.available {
+		zapctx.Warn(ctx, "database read replica unavailable", zap.Error(err))
+	}
+	if err == nil && !r.available {
+		zapctx.Info(ctx, "database read replica available")
+	}
+	r.checking = false
+	r.checked = time.Now()
+	r.available = err == nil
+	return r.available
+}
+
+func (r *ReadReplica) ping(ctx context.Context) error {
+	sqlDB, err := r.DB.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// ReadFromReplica calls f with a Database that reads from the read
+// replica, if one is configured and available, otherwise f is called
+// with d. If f fails and the replica is found to have become unavailable
+// then f is called again with d. f must not modify the database and must
+// tolerate its results lagging slightly behind the primary. Within a
+// transaction f is always called with the transaction.
+func (d *Database) ReadFromReplica(ctx context.Context, f func(*Database) error) error {
+	if d.ReadReplica == nil {
+		return f(d)
+	}
+	if !d.ReadReplica.isAvailable(ctx) {
+		servermon.DBReplicaFallbackCount.Inc()
+		return f(d)
+	}
+	rd := *d
+	rd.DB = d.ReadReplica.DB
+	rd.ReadReplica = nil
+	err := f(&rd)
+	if err == nil || ctx.Err() != nil || d.ReadReplica.check(ctx) {
+		return err
+	}
+	servermon.DBReplicaFallbackCount.Inc()
+	return f(d)
+}
+
+// Close closes open connections to the read replica.
+func (r *ReadReplica) Close() error {
+	sqlDB, err := r.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
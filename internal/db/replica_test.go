@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// unreachableDB returns a gorm database that cannot connect to its
+// server.
+func unreachableDB(c *qt.C) *gorm.DB {
+	gdb, err := gorm.Open(postgres.Open("postgres://jimm@127.0.0.1:1/jimm?connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+	})
+	c.Assert(err, qt.IsNil)
+	return gdb
+}
+
+func TestReadFromReplica(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	primary := unreachableDB(c)
+	replica := db.NewReadReplica(unreachableDB(c))
+	database := db.Database{DB: primary}
+
+	var calls []string
+	read := func(d *db.Database) error {
+		if d.DB == replica.DB {
+			calls = append(calls, "replica")
+			return errors.E("connection lost")
+		}
+		calls = append(calls, "primary")
+		return nil
+	}
+
+	// Without a replica the primary is used.
+	err := database.ReadFromReplica(ctx, read)
+	c.Assert(err, qt.IsNil)
+	c.Check(calls, qt.DeepEquals, []string{"primary"})
+
+	// An unreachable replica is not used.
+	database.ReadReplica = replica
+	calls = nil
+	err = database.ReadFromReplica(ctx, read)
+	c.Assert(err, qt.IsNil)
+	c.Check(calls, qt.DeepEquals, []string{"primary"})
+
+	// If the replica fails and is found to be unreachable the read is
+	// retried on the primary.
+	db.SetReplicaAvailable(replica, true)
+	calls = nil
+	err = database.ReadFromReplica(ctx, read)
+	c.Assert(err, qt.IsNil)
+	c.Check(calls, qt.DeepEquals, []string{"replica", "primary"})
+}
@@ -3,6 +3,7 @@
 package db
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
@@ -12,11 +13,16 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // GetKey implements Backing.GetKey.
 func (d *Database) GetKey(id []byte) (_ dbrootkeystore.RootKey, err error) {
-	const op = errors.Op("db.FindLatestKey")
+	const op = errors.Op("db.GetKey")
+	// Backing methods are not given a context, so each one starts a new
+	// trace.
+	_, span := tracing.Start(context.Background(), string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return dbrootkeystore.RootKey{}, bakery.ErrNotFound
@@ -46,6 +52,8 @@ func (d *Database) GetKey(id []byte) (_ dbrootkeystore.RootKey, err error) {
 // FindLatestKey implements Backing.FindLatestKey.
 func (d *Database) FindLatestKey(createdAfter, expiresAfter, expiresBefore time.Time) (_ dbrootkeystore.RootKey, err error) {
 	const op = errors.Op("db.FindLatestKey")
+	_, span := tracing.Start(context.Background(), string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return dbrootkeystore.RootKey{}, bakery.ErrNotFound
@@ -76,6 +84,8 @@ func (d *Database) FindLatestKey(createdAfter, expiresAfter, expiresBefore time.
 // InsertKey implements Backing.InsertKey.
 func (d *Database) InsertKey(key dbrootkeystore.RootKey) (err error) {
 	const op = errors.Op("db.InsertKey")
+	_, span := tracing.Start(context.Background(), string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -96,3 +106,55 @@ func (d *Database) InsertKey(key dbrootkeystore.RootKey) (err error) {
 	}
 	return nil
 }
+
+// ForEachRootKey iterates through every macaroon root key that has not
+// expired, ordered by creation time, calling the given function for each
+// one. If the function returns an error the iteration stops and the error
+// is returned unmodified.
+func (d *Database) ForEachRootKey(ctx context.Context, f func(*dbmodel.RootKey) error) (err error) {
+	const op = errors.Op("db.ForEachRootKey")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var rks []dbmodel.RootKey
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("expires > ?", time.Now()).Order("created_at asc").Find(&rks).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	for i := range rks {
+		if err := f(&rks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRootKeys removes every macaroon root key from the database. The
+// number of keys removed is returned.
+func (d *Database) DeleteRootKeys(ctx context.Context) (_ int64, err error) {
+	const op = errors.Op("db.DeleteRootKeys")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&dbmodel.RootKey{})
+	if result.Error != nil {
+		return 0, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
 
 	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 )
 
@@ -104,3 +105,44 @@ func (s *dbSuite) TestFindLatestKey(c *qt.C) {
 	c.Assert(err, qt.IsNil)
 	c.Check(rk, qt.DeepEquals, rks[8])
 }
+
+func TestForEachRootKeyUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.ForEachRootKey(context.Background(), nil)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestForEachRootKeyAndDeleteRootKeys(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	for i, expires := range []time.Time{now.Add(-time.Hour), now.Add(time.Hour), now.Add(2 * time.Hour)} {
+		err := s.Database.InsertKey(dbrootkeystore.RootKey{
+			Id:      []byte(fmt.Sprintf("test-%d", i)),
+			Created: now.Add(time.Duration(i-3) * time.Hour),
+			Expires: expires,
+			RootKey: []byte(fmt.Sprintf("secret %d", i)),
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	var ids []string
+	err = s.Database.ForEachRootKey(ctx, func(rk *dbmodel.RootKey) error {
+		ids = append(ids, string(rk.ID))
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []string{"test-1", "test-2"})
+
+	n, err := s.Database.DeleteRootKeys(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(3))
+
+	_, err = s.Database.GetKey([]byte("test-1"))
+	c.Check(err, qt.Equals, bakery.ErrNotFound)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 const (
@@ -32,12 +33,15 @@ const (
 	oauthKeyTag       = "oauthKey"
 	//nolint:gosec // Thinks credentials hardcoded.
 	oauthSessionStoreSecretTag = "oauthSessionStoreSecret"
+	oauthSessionKeysTag        = "oauthSessionKeys"
 )
 
 // UpsertSecret stores secret information.
 //   - updates the secret's time and data if it already exists
 func (d *Database) UpsertSecret(ctx context.Context, secret *dbmodel.Secret) (err error) {
 	const op = errors.Op("db.AddSecret")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
@@ -60,6 +64,8 @@ func (d *Database) UpsertSecret(ctx context.Context, secret *dbmodel.Secret) (er
 // GetSecret gets the secret with the specified type and tag.
 func (d *Database) GetSecret(ctx context.Context, secret *dbmodel.Secret) (err error) {
 	const op = errors.Op("db.GetSecret")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if secret.Tag == "" || secret.Type == "" {
 		return errors.E(op, "missing secret tag and type", errors.CodeBadRequest)
@@ -90,6 +96,8 @@ func (d *Database) GetSecret(ctx context.Context, secret *dbmodel.Secret) (err e
 // Delete secret deletes the secret with the specified type and tag.
 func (d *Database) DeleteSecret(ctx context.Context, secret *dbmodel.Secret) (err error) {
 	const op = errors.Op("db.DeleteSecret")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if secret.Tag == "" || secret.Type == "" {
 		return errors.E(op, "missing secret tag and type", errors.CodeBadRequest)
@@ -114,6 +122,8 @@ func (d *Database) DeleteSecret(ctx context.Context, secret *dbmodel.Secret) (er
 // Get retrieves the attributes for the given cloud credential from the DB.
 func (d *Database) Get(ctx context.Context, tag names.CloudCredentialTag) (_ map[string]string, err error) {
 	const op = errors.Op("database.Get")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
@@ -141,6 +151,8 @@ func (d *Database) Get(ctx context.Context, tag names.CloudCredentialTag) (_ map
 // Put stores the attributes associated with a cloud-credential in the DB.
 func (d *Database) Put(ctx context.Context, tag names.CloudCredentialTag, attr map[string]string) (err error) {
 	const op = errors.Op("database.Put")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -163,6 +175,8 @@ func (d *Database) Put(ctx context.Context, tag names.CloudCredentialTag, attr m
 // It is expected for this interface that a non-existent controller credential return empty username/password.
 func (d *Database) GetControllerCredentials(ctx context.Context, controllerName string) (_ string, _ string, err error) {
 	const op = errors.Op("database.GetControllerCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return "", "", errors.E(op, err)
@@ -201,6 +215,8 @@ func (d *Database) GetControllerCredentials(ctx context.Context, controllerName
 // PutControllerCredentials stores the controller credentials in the DB.
 func (d *Database) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) (err error) {
 	const op = errors.Op("database.PutControllerCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -225,6 +241,8 @@ func (d *Database) PutControllerCredentials(ctx context.Context, controllerName
 // CleanupJWKS removes all secrets associated with the JWKS process.
 func (d *Database) CleanupJWKS(ctx context.Context) (err error) {
 	const op = errors.Op("database.CleanupJWKS")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -258,6 +276,8 @@ func (d *Database) CleanupJWKS(ctx context.Context) (err error) {
 // GetJWKS returns the current key set stored within the DB.
 func (d *Database) GetJWKS(ctx context.Context) (_ jwk.Set, err error) {
 	const op = errors.Op("database.GetJWKS")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
@@ -284,6 +304,8 @@ func (d *Database) GetJWKS(ctx context.Context) (_ jwk.Set, err error) {
 // GetJWKSPrivateKey returns the current private key for the active JWKS
 func (d *Database) GetJWKSPrivateKey(ctx context.Context) (_ []byte, err error) {
 	const op = errors.Op("database.GetJWKSPrivateKey")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return nil, errors.E(op, err)
@@ -311,6 +333,8 @@ func (d *Database) GetJWKSPrivateKey(ctx context.Context) (_ []byte, err error)
 // GetJWKSExpiry returns the expiry of the active JWKS.
 func (d *Database) GetJWKSExpiry(ctx context.Context) (_ time.Time, err error) {
 	const op = errors.Op("database.GetJWKSExpiry")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return time.Time{}, errors.E(op, err)
@@ -338,6 +362,8 @@ func (d *Database) GetJWKSExpiry(ctx context.Context) (_ time.Time, err error) {
 // PutJWKS puts a JWKS into the DB.
 func (d *Database) PutJWKS(ctx context.Context, jwks jwk.Set) (err error) {
 	const op = errors.Op("database.PutJWKS")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -360,6 +386,8 @@ func (d *Database) PutJWKS(ctx context.Context, jwks jwk.Set) (err error) {
 // PutJWKSPrivateKey persists the private key associated with the current JWKS within the DB.
 func (d *Database) PutJWKSPrivateKey(ctx context.Context, pem []byte) (err error) {
 	const op = errors.Op("database.PutJWKSPrivateKey")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -381,6 +409,8 @@ func (d *Database) PutJWKSPrivateKey(ctx context.Context, pem []byte) (err error
 // PutJWKSExpiry sets the expiry time for the current JWKS within the DB.
 func (d *Database) PutJWKSExpiry(ctx context.Context, expiry time.Time) (err error) {
 	const op = errors.Op("database.PutJWKSExpiry")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -402,6 +432,8 @@ func (d *Database) PutJWKSExpiry(ctx context.Context, expiry time.Time) (err err
 // CleanupOAuthSecrets removes all secrets associated with OAuth.
 func (d *Database) CleanupOAuthSecrets(ctx context.Context) (err error) {
 	const op = errors.Op("database.CleanupOAuthSecrets")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := d.ready(); err != nil {
 		return errors.E(op, err)
@@ -423,3 +455,99 @@ func (d *Database) CleanupOAuthSecrets(ctx context.Context) (err error) {
 	}
 	return nil
 }
+
+// GetSessionKeys returns the keys used to sign and verify session tokens,
+// the key used to sign new tokens first. If no keys have been stored an
+// error with the code CodeNotFound is returned.
+func (d *Database) GetSessionKeys(ctx context.Context) (_ []string, err error) {
+	const op = errors.Op("database.GetSessionKeys")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	secret := dbmodel.NewSecret(oauthKind, oauthSessionKeysTag, nil)
+	if err := d.GetSecret(ctx, &secret); err != nil {
+		return nil, errors.E(op, err)
+	}
+	var keys []string
+	if err := json.Unmarshal(secret.Data, &keys); err != nil {
+		zapctx.Error(ctx, "failed to unmarshal session keys", zap.Error(err))
+		return nil, errors.E(op, err)
+	}
+	return keys, nil
+}
+
+// PutSessionKeys stores the keys used to sign and verify session tokens,
+// replacing any keys already stored. The key used to sign new tokens
+// must be first.
+func (d *Database) PutSessionKeys(ctx context.Context, keys []string) (err error) {
+	const op = errors.Op("database.PutSessionKeys")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	keysJson, err := json.Marshal(keys)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	secret := dbmodel.NewSecret(oauthKind, oauthSessionKeysTag, keysJson)
+	if err := d.UpsertSecret(ctx, &secret); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// LockSessionKeys returns the stored session keys and locks them until
+// the end of the current transaction, so that they can be replaced
+// without losing a concurrent update. If no keys have been stored the
+// given keys are stored first. LockSessionKeys must be called within a
+// transaction.
+func (d *Database) LockSessionKeys(ctx context.Context, keys []string) (_ []string, err error) {
+	const op = errors.Op("database.LockSessionKeys")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	keysJson, err := json.Marshal(keys)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	secret := dbmodel.NewSecret(oauthKind, oauthSessionKeysTag, keysJson)
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&secret).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	secret = dbmodel.Secret{}
+	err = db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tag = ? AND type = ?", oauthSessionKeysTag, oauthKind).
+		First(&secret).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	if err := json.Unmarshal(secret.Data, &keys); err != nil {
+		zapctx.Error(ctx, "failed to unmarshal session keys", zap.Error(err))
+		return nil, errors.E(op, err)
+	}
+	return keys, nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
 )
 
 var testTime = time.Date(2013, 7, 26, 0, 0, 0, 0, time.UTC)
@@ -279,3 +280,18 @@ func (s *dbSuite) TestCleanupJWKS(c *qt.C) {
 	c.Assert(s.Database.DB.Model(&dbmodel.Secret{}).Count(&count).Error, qt.IsNil)
 	c.Assert(count, qt.Equals, int64(0))
 }
+
+func (s *dbSuite) TestPutAndGetSessionKeys(c *qt.C) {
+	err := s.Database.Migrate(context.Background(), true)
+	c.Assert(err, qt.Equals, nil)
+	ctx := context.Background()
+
+	_, err = s.Database.GetSessionKeys(ctx)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	c.Assert(s.Database.PutSessionKeys(ctx, []string{"key-1"}), qt.IsNil)
+	c.Assert(s.Database.PutSessionKeys(ctx, []string{"key-2", "key-1"}), qt.IsNil)
+	keys, err := s.Database.GetSessionKeys(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(keys, qt.DeepEquals, []string{"key-2", "key-1"})
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddServiceAccount stores the given service account. If the service
+// account is already stored its creator and purpose are replaced.
+func (d *Database) AddServiceAccount(ctx context.Context, sa *dbmodel.ServiceAccount) (err error) {
+	const op = errors.Op("db.AddServiceAccount")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Identity").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "identity_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "creator_identity_name", "purpose"}),
+	}).Create(sa).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetServiceAccount fills in the given service account, and its
+// identity, from the service account with the same IdentityName. If no
+// such service account exists an error with a code of CodeNotFound is
+// returned.
+func (d *Database) GetServiceAccount(ctx context.Context, sa *dbmodel.ServiceAccount) (err error) {
+	const op = errors.Op("db.GetServiceAccount")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Preload("Identity").Where("identity_name = ?", sa.IdentityName).First(sa).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListServiceAccounts returns all the stored service accounts, with
+// their identities, ordered by name.
+func (d *Database) ListServiceAccounts(ctx context.Context) (_ []dbmodel.ServiceAccount, err error) {
+	const op = errors.Op("db.ListServiceAccounts")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var sas []dbmodel.ServiceAccount
+	if err := d.DB.WithContext(ctx).Preload("Identity").Order("identity_name").Find(&sas).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return sas, nil
+}
+
+// DeleteServiceAccount removes the stored metadata of the given service
+// account. The service account's identity is not removed.
+func (d *Database) DeleteServiceAccount(ctx context.Context, sa *dbmodel.ServiceAccount) (err error) {
+	const op = errors.Op("db.DeleteServiceAccount")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Where("identity_name = ?", sa.IdentityName).Delete(&dbmodel.ServiceAccount{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
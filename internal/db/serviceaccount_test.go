@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func (s *dbSuite) TestServiceAccounts(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"alice@canonical.com", "svc-2@serviceaccount", "svc-1@serviceaccount"} {
+		i, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		c.Assert(s.Database.GetIdentity(ctx, i), qt.IsNil)
+	}
+
+	sa := dbmodel.ServiceAccount{
+		IdentityName:        "svc-1@serviceaccount",
+		CreatorIdentityName: "alice@canonical.com",
+		Purpose:             "ci",
+	}
+	err = s.Database.AddServiceAccount(ctx, &sa)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AddServiceAccount(ctx, &dbmodel.ServiceAccount{
+		IdentityName: "svc-2@serviceaccount",
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Adding an existing service account replaces its metadata.
+	err = s.Database.AddServiceAccount(ctx, &dbmodel.ServiceAccount{
+		IdentityName:        "svc-1@serviceaccount",
+		CreatorIdentityName: "alice@canonical.com",
+		Purpose:             "deployments",
+	})
+	c.Assert(err, qt.IsNil)
+
+	sa1 := dbmodel.ServiceAccount{IdentityName: "svc-1@serviceaccount"}
+	err = s.Database.GetServiceAccount(ctx, &sa1)
+	c.Assert(err, qt.IsNil)
+	c.Check(sa1.Purpose, qt.Equals, "deployments")
+	c.Check(sa1.Identity.Name, qt.Equals, "svc-1@serviceaccount")
+
+	sas, err := s.Database.ListServiceAccounts(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sas, qt.HasLen, 2)
+	c.Check(sas[0].IdentityName, qt.Equals, "svc-1@serviceaccount")
+	c.Check(sas[1].IdentityName, qt.Equals, "svc-2@serviceaccount")
+
+	err = s.Database.DeleteServiceAccount(ctx, &sa1)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetServiceAccount(ctx, &dbmodel.ServiceAccount{IdentityName: "svc-1@serviceaccount"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// The identity is kept.
+	i := dbmodel.Identity{Name: "svc-1@serviceaccount"}
+	err = s.Database.FetchIdentity(ctx, &i)
+	c.Assert(err, qt.IsNil)
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddSession stores the given session. If a session with the same key
+// already exists it is updated, a revoked session remains revoked.
+func (d *Database) AddSession(ctx context.Context, session *dbmodel.Session) (err error) {
+	const op = errors.Op("db.AddSession")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "identity_name", "kind", "expires_at"}),
+	})
+	if err := db.Create(session).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetSession retrieves the session with the key given in the session. If
+// the session cannot be found an error with a code of CodeNotFound is
+// returned.
+func (d *Database) GetSession(ctx context.Context, session *dbmodel.Session) (err error) {
+	const op = errors.Op("db.GetSession")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Where("key = ?", session.Key).First(session).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListSessions returns the sessions that have neither expired nor been
+// revoked at the given time, ordered by ID. If identityName is not empty
+// only the sessions of that identity are returned.
+func (d *Database) ListSessions(ctx context.Context, identityName string, now time.Time) (_ []dbmodel.Session, err error) {
+	const op = errors.Op("db.ListSessions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Where("expires_at > ? AND revoked_at IS NULL", now)
+	if identityName != "" {
+		db = db.Where("identity_name = ?", identityName)
+	}
+	var sessions []dbmodel.Session
+	if err := db.Order("id asc").Find(&sessions).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return sessions, nil
+}
+
+// RevokeSessions marks every unexpired session of the given identity as
+// revoked at the given time and returns the number of sessions revoked.
+// The stored state of any revoked browser sessions is removed from the
+// session store so that their cookies can no longer be used.
+func (d *Database) RevokeSessions(ctx context.Context, identityName string, now time.Time) (_ int, err error) {
+	const op = errors.Op("db.RevokeSessions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var revoked int
+	err = d.Transaction(func(d *Database) error {
+		db := d.DB.WithContext(ctx)
+		var sessions []dbmodel.Session
+		if err := db.Where("identity_name = ? AND expires_at > ? AND revoked_at IS NULL", identityName, now).Find(&sessions).Error; err != nil {
+			return err
+		}
+		var ids []uint
+		var browserKeys []string
+		for _, s := range sessions {
+			ids = append(ids, s.ID)
+			if s.Kind == dbmodel.BrowserSession {
+				browserKeys = append(browserKeys, s.Key)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		err := db.Model(&dbmodel.Session{}).Where("id IN ?", ids).Update("revoked_at", sql.NullTime{Time: now, Valid: true}).Error
+		if err != nil {
+			return err
+		}
+		if len(browserKeys) > 0 {
+			if err := deleteStoredBrowserSessions(db, browserKeys); err != nil {
+				return err
+			}
+		}
+		revoked = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, errors.E(op, dbError(err))
+	}
+	return revoked, nil
+}
+
+// DeleteExpiredSessions removes all sessions that expired before the
+// given time.
+func (d *Database) DeleteExpiredSessions(ctx context.Context, before time.Time) (_ int64, err error) {
+	const op = errors.Op("db.DeleteExpiredSessions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Where("expires_at < ?", before).Delete(&dbmodel.Session{})
+	if result.Error != nil {
+		return 0, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+// deleteStoredBrowserSessions removes the sessions with the given keys
+// from the table maintained by the browser session store, if that table
+// exists.
+func deleteStoredBrowserSessions(db *gorm.DB, keys []string) error {
+	var exists bool
+	if err := db.Raw("SELECT to_regclass('http_sessions') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	for _, key := range keys {
+		// The session store holds keys as bytea.
+		if err := db.Exec("DELETE FROM http_sessions WHERE key = ?", []byte(key)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddSessionUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddSession(context.Background(), &dbmodel.Session{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestSessions(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com"} {
+		u, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		c.Assert(s.Database.GetIdentity(ctx, u), qt.IsNil)
+	}
+	// The browser session store maintains its own table.
+	err = s.Database.DB.Exec("CREATE TABLE http_sessions (id BIGSERIAL PRIMARY KEY, key BYTEA, data BYTEA, created_on TIMESTAMPTZ, modified_on TIMESTAMPTZ, expires_on TIMESTAMPTZ)").Error
+	c.Assert(err, qt.IsNil)
+	err = s.Database.DB.Exec("INSERT INTO http_sessions (key, data) VALUES ('browser-1', ''), ('browser-2', '')").Error
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	s1 := dbmodel.Session{
+		IdentityName: "alice@canonical.com",
+		Kind:         dbmodel.BrowserSession,
+		Key:          "browser-1",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	err = s.Database.AddSession(ctx, &s1)
+	c.Assert(err, qt.IsNil)
+	s2 := dbmodel.Session{
+		IdentityName: "alice@canonical.com",
+		Kind:         dbmodel.DeviceSession,
+		Key:          "device-1",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	err = s.Database.AddSession(ctx, &s2)
+	c.Assert(err, qt.IsNil)
+	s3 := dbmodel.Session{
+		IdentityName: "bob@canonical.com",
+		Kind:         dbmodel.BrowserSession,
+		Key:          "browser-2",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	err = s.Database.AddSession(ctx, &s3)
+	c.Assert(err, qt.IsNil)
+	s4 := dbmodel.Session{
+		IdentityName: "bob@canonical.com",
+		Kind:         dbmodel.DeviceSession,
+		Key:          "device-2",
+		ExpiresAt:    now.Add(-time.Minute),
+	}
+	err = s.Database.AddSession(ctx, &s4)
+	c.Assert(err, qt.IsNil)
+
+	// Adding a session with an existing key updates it.
+	s1.ExpiresAt = now.Add(2 * time.Hour)
+	s1.ID = 0
+	err = s.Database.AddSession(ctx, &s1)
+	c.Assert(err, qt.IsNil)
+	session := dbmodel.Session{Key: "browser-1"}
+	err = s.Database.GetSession(ctx, &session)
+	c.Assert(err, qt.IsNil)
+	c.Check(session.ExpiresAt.Equal(now.Add(2*time.Hour)), qt.IsTrue)
+
+	sessions, err := s.Database.ListSessions(ctx, "", now)
+	c.Assert(err, qt.IsNil)
+	c.Check(sessions, qt.HasLen, 3)
+
+	sessions, err = s.Database.ListSessions(ctx, "alice@canonical.com", now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 2)
+	c.Check(sessions[0].Key, qt.Equals, "browser-1")
+	c.Check(sessions[1].Key, qt.Equals, "device-1")
+
+	revoked, err := s.Database.RevokeSessions(ctx, "alice@canonical.com", now)
+	c.Assert(err, qt.IsNil)
+	c.Check(revoked, qt.Equals, 2)
+
+	sessions, err = s.Database.ListSessions(ctx, "", now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 1)
+	c.Check(sessions[0].Key, qt.Equals, "browser-2")
+
+	session = dbmodel.Session{Key: "device-1"}
+	err = s.Database.GetSession(ctx, &session)
+	c.Assert(err, qt.IsNil)
+	c.Check(session.RevokedAt.Valid, qt.IsTrue)
+
+	// Re-recording a revoked session does not restore it.
+	s2.ID = 0
+	err = s.Database.AddSession(ctx, &s2)
+	c.Assert(err, qt.IsNil)
+	sessions, err = s.Database.ListSessions(ctx, "alice@canonical.com", now)
+	c.Assert(err, qt.IsNil)
+	c.Check(sessions, qt.HasLen, 0)
+
+	var keys []string
+	err = s.Database.DB.Raw("SELECT convert_from(key, 'UTF8') FROM http_sessions ORDER BY key").Scan(&keys).Error
+	c.Assert(err, qt.IsNil)
+	c.Check(keys, qt.DeepEquals, []string{"browser-2"})
+
+	deleted, err := s.Database.DeleteExpiredSessions(ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(1))
+
+	session = dbmodel.Session{Key: "device-2"}
+	err = s.Database.GetSession(ctx, &session)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddTask stores the given task.
+func (d *Database) AddTask(ctx context.Context, task *dbmodel.Task) (err error) {
+	const op = errors.Op("db.AddTask")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(task).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetTask fills in the given task from the task with the same ID. If no
+// such task exists an error with a code of CodeNotFound is returned.
+func (d *Database) GetTask(ctx context.Context, task *dbmodel.Task) (err error) {
+	const op = errors.Op("db.GetTask")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if task.ID == 0 {
+		return errors.E(op, errors.CodeNotFound, "task not found")
+	}
+	if err := d.DB.WithContext(ctx).First(task, task.ID).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateTask updates the stored state of the given task.
+func (d *Database) UpdateTask(ctx context.Context, task *dbmodel.Task) (err error) {
+	const op = errors.Op("db.UpdateTask")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Save(task).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
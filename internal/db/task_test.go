@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func (s *dbSuite) TestTasks(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Database.GetIdentity(ctx, u), qt.IsNil)
+
+	task := dbmodel.Task{
+		Kind:         dbmodel.TaskKindAddModel,
+		IdentityName: u.Name,
+		Status:       dbmodel.TaskPending,
+	}
+	err = s.Database.AddTask(ctx, &task)
+	c.Assert(err, qt.IsNil)
+	c.Assert(task.ID, qt.Not(qt.Equals), uint(0))
+
+	task.Status = dbmodel.TaskSucceeded
+	task.Result = "00000002-0000-0000-0000-000000000001"
+	err = s.Database.UpdateTask(ctx, &task)
+	c.Assert(err, qt.IsNil)
+
+	t2 := dbmodel.Task{ID: task.ID}
+	err = s.Database.GetTask(ctx, &t2)
+	c.Assert(err, qt.IsNil)
+	c.Check(t2.Status, qt.Equals, dbmodel.TaskSucceeded)
+	c.Check(t2.Result, qt.Equals, "00000002-0000-0000-0000-000000000001")
+	c.Check(t2.Done(), qt.IsTrue)
+
+	err = s.Database.GetTask(ctx, &dbmodel.Task{ID: task.ID + 1})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddTemporaryGrant stores the given temporary grant.
+func (d *Database) AddTemporaryGrant(ctx context.Context, grant *dbmodel.TemporaryGrant) (err error) {
+	const op = errors.Op("db.AddTemporaryGrant")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(grant).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListTemporaryGrants returns all temporary grants that expire after the
+// given time, ordered by expiry.
+func (d *Database) ListTemporaryGrants(ctx context.Context, after time.Time) (_ []dbmodel.TemporaryGrant, err error) {
+	const op = errors.Op("db.ListTemporaryGrants")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var grants []dbmodel.TemporaryGrant
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("expires_at > ?", after).Order("expires_at asc, id asc").Find(&grants).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return grants, nil
+}
+
+// ListExpiredTemporaryGrants returns all temporary grants that expired at
+// or before the given time.
+func (d *Database) ListExpiredTemporaryGrants(ctx context.Context, before time.Time) (_ []dbmodel.TemporaryGrant, err error) {
+	const op = errors.Op("db.ListExpiredTemporaryGrants")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var grants []dbmodel.TemporaryGrant
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("expires_at <= ?", before).Order("expires_at asc, id asc").Find(&grants).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return grants, nil
+}
+
+// DeleteTemporaryGrant removes the given temporary grant.
+func (d *Database) DeleteTemporaryGrant(ctx context.Context, grant *dbmodel.TemporaryGrant) (err error) {
+	const op = errors.Op("db.DeleteTemporaryGrant")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(grant).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddTemporaryGrantUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddTemporaryGrant(context.Background(), &dbmodel.TemporaryGrant{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestTemporaryGrants(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	g1 := dbmodel.TemporaryGrant{
+		Object:       "user-alice@canonical.com",
+		Relation:     "administrator",
+		TargetObject: "controller-jimm",
+		GrantedBy:    "bob@canonical.com",
+		ExpiresAt:    now.Add(-time.Minute),
+	}
+	err = s.Database.AddTemporaryGrant(ctx, &g1)
+	c.Assert(err, qt.IsNil)
+	g2 := dbmodel.TemporaryGrant{
+		Object:       "user-eve@canonical.com",
+		Relation:     "administrator",
+		TargetObject: "controller-jimm",
+		GrantedBy:    "bob@canonical.com",
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	err = s.Database.AddTemporaryGrant(ctx, &g2)
+	c.Assert(err, qt.IsNil)
+
+	active, err := s.Database.ListTemporaryGrants(ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(active, qt.HasLen, 1)
+	c.Check(active[0].ID, qt.Equals, g2.ID)
+
+	expired, err := s.Database.ListExpiredTemporaryGrants(ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Assert(expired, qt.HasLen, 1)
+	c.Check(expired[0].ID, qt.Equals, g1.ID)
+
+	err = s.Database.DeleteTemporaryGrant(ctx, &expired[0])
+	c.Assert(err, qt.IsNil)
+
+	expired, err = s.Database.ListExpiredTemporaryGrants(ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(expired, qt.HasLen, 0)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// accrueModelUsageSQL adds the usage of every model with machines or
+// units, at their current counts, to the model's record for a day.
+const accrueModelUsageSQL = `
+INSERT INTO usage_records (created_at, updated_at, date, model_uuid, model_name, owner_identity_name, controller_name, machine_hours, unit_hours)
+SELECT @now, @now, @date, models.uuid, models.name, models.owner_identity_name, controllers.name, models.machines * @hours, models.units * @hours
+FROM models JOIN controllers ON controllers.id = models.controller_id
+WHERE models.uuid IS NOT NULL AND (models.machines > 0 OR models.units > 0)
+ON CONFLICT (model_uuid, date) DO UPDATE SET
+	updated_at = EXCLUDED.updated_at,
+	model_name = EXCLUDED.model_name,
+	owner_identity_name = EXCLUDED.owner_identity_name,
+	controller_name = EXCLUDED.controller_name,
+	machine_hours = usage_records.machine_hours + EXCLUDED.machine_hours,
+	unit_hours = usage_records.unit_hours + EXCLUDED.unit_hours`
+
+// AccrueModelUsage adds the given number of hours, multiplied by each
+// model's current machine and unit counts, to the usage recorded for
+// each model on the UTC day containing date.
+func (d *Database) AccrueModelUsage(ctx context.Context, date time.Time, hours float64) (err error) {
+	const op = errors.Op("db.AccrueModelUsage")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	err = d.DB.WithContext(ctx).Exec(accrueModelUsageSQL, map[string]interface{}{
+		"now":   time.Now(),
+		"date":  date.UTC().Format(time.DateOnly),
+		"hours": hours,
+	}).Error
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListUsageRecords returns the usage records for the UTC days from from
+// to to inclusive, ordered by date and model name.
+func (d *Database) ListUsageRecords(ctx context.Context, from, to time.Time) (_ []dbmodel.UsageRecord, err error) {
+	const op = errors.Op("db.ListUsageRecords")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var records []dbmodel.UsageRecord
+	db := d.DB.WithContext(ctx).Where("date >= ? AND date <= ?", from.UTC().Format(time.DateOnly), to.UTC().Format(time.DateOnly))
+	if err := db.Order("date asc, model_name asc, model_uuid asc").Find(&records).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return records, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAccrueModelUsageUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AccrueModelUsage(context.Background(), time.Now(), 1)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestModelUsage(c *qt.C) {
+	ctx := context.Background()
+	env := initTestEnvironment(c, s.Database)
+
+	day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	// Models without machines or units accrue no usage.
+	err := s.Database.AccrueModelUsage(ctx, day1, 1)
+	c.Assert(err, qt.IsNil)
+	records, err := s.Database.ListUsageRecords(ctx, day1, day2)
+	c.Assert(err, qt.IsNil)
+	c.Check(records, qt.HasLen, 0)
+
+	env.model.Machines = 2
+	env.model.Units = 3
+	err = s.Database.UpdateModel(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.AccrueModelUsage(ctx, day1, 1.5)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AccrueModelUsage(ctx, day1, 0.5)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.AccrueModelUsage(ctx, day2, 1)
+	c.Assert(err, qt.IsNil)
+
+	records, err = s.Database.ListUsageRecords(ctx, day1, day2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(records, qt.HasLen, 2)
+	c.Check(records[0].Date.Format(time.DateOnly), qt.Equals, "2024-01-01")
+	c.Check(records[0].ModelUUID, qt.Equals, env.model.UUID.String)
+	c.Check(records[0].ModelName, qt.Equals, "test-model")
+	c.Check(records[0].OwnerIdentityName, qt.Equals, "bob@canonical.com")
+	c.Check(records[0].ControllerName, qt.Equals, "test-controller")
+	c.Check(records[0].MachineHours, qt.Equals, 4.0)
+	c.Check(records[0].UnitHours, qt.Equals, 6.0)
+	c.Check(records[1].Date.Format(time.DateOnly), qt.Equals, "2024-01-02")
+	c.Check(records[1].MachineHours, qt.Equals, 2.0)
+	c.Check(records[1].UnitHours, qt.Equals, 3.0)
+
+	// Records are kept after the model is deleted.
+	err = s.Database.DeleteModel(ctx, &env.model)
+	c.Assert(err, qt.IsNil)
+	records, err = s.Database.ListUsageRecords(ctx, day2, day2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(records, qt.HasLen, 1)
+	c.Check(records[0].ModelName, qt.Equals, "test-model")
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddModelWebhook stores the given model webhook.
+func (d *Database) AddModelWebhook(ctx context.Context, webhook *dbmodel.ModelWebhook) (err error) {
+	const op = errors.Op("db.AddModelWebhook")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(webhook).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelWebhook retrieves the model webhook with the ID given in the
+// webhook. If the webhook cannot be found an error with a code of
+// CodeNotFound is returned.
+func (d *Database) GetModelWebhook(ctx context.Context, webhook *dbmodel.ModelWebhook) (err error) {
+	const op = errors.Op("db.GetModelWebhook")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).First(webhook, webhook.ID).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListModelWebhooks returns the webhooks registered on the model with
+// the given ID, ordered by ID.
+func (d *Database) ListModelWebhooks(ctx context.Context, modelID uint) (_ []dbmodel.ModelWebhook, err error) {
+	const op = errors.Op("db.ListModelWebhooks")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var webhooks []dbmodel.ModelWebhook
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ?", modelID).Order("id asc").Find(&webhooks).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return webhooks, nil
+}
+
+// DeleteModelWebhook removes the given model webhook.
+func (d *Database) DeleteModelWebhook(ctx context.Context, webhook *dbmodel.ModelWebhook) (err error) {
+	const op = errors.Op("db.DeleteModelWebhook")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(webhook).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
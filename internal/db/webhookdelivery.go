@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddWebhookDelivery stores the given webhook delivery.
+func (d *Database) AddWebhookDelivery(ctx context.Context, delivery *dbmodel.WebhookDelivery) (err error) {
+	const op = errors.Op("db.AddWebhookDelivery")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Create(delivery).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateWebhookDelivery updates the stored outcome of the given webhook
+// delivery.
+func (d *Database) UpdateWebhookDelivery(ctx context.Context, delivery *dbmodel.WebhookDelivery) (err error) {
+	const op = errors.Op("db.UpdateWebhookDelivery")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Save(delivery).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the webhook deliveries with the given
+// status, most recent first. If status is empty deliveries with any
+// status are returned. At most limit deliveries are returned, if limit
+// is positive.
+func (d *Database) ListWebhookDeliveries(ctx context.Context, status string, limit int) (_ []dbmodel.WebhookDelivery, err error) {
+	const op = errors.Op("db.ListWebhookDeliveries")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	var deliveries []dbmodel.WebhookDelivery
+	if err := db.Order("id desc").Find(&deliveries).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return deliveries, nil
+}
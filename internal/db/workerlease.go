@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AcquireWorkerLease attempts to acquire the lease on the named worker
+// for the given holder. The lease is acquired if it is not held, has
+// expired, or is already held by the holder, and is then set to expire
+// after the given duration. Expiry is measured using the database's clock
+// so that replicas need not agree on the time. AcquireWorkerLease reports
+// whether the holder now holds the lease.
+func (d *Database) AcquireWorkerLease(ctx context.Context, name, holder string, duration time.Duration) (_ bool, err error) {
+	const op = errors.Op("db.AcquireWorkerLease")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return false, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Exec(`
+		INSERT INTO worker_leases (name, holder, expires_at)
+		VALUES (?, ?, NOW() + make_interval(secs => ?))
+		ON CONFLICT (name) DO UPDATE
+		SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE worker_leases.holder = EXCLUDED.holder OR worker_leases.expires_at < NOW()`,
+		name, holder, duration.Seconds(),
+	)
+	if result.Error != nil {
+		return false, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseWorkerLease releases the lease on the named worker if it is held
+// by the given holder, allowing another replica to acquire it immediately.
+func (d *Database) ReleaseWorkerLease(ctx context.Context, name, holder string) (err error) {
+	const op = errors.Op("db.ReleaseWorkerLease")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("name = ? AND holder = ?", name, holder).Delete(&dbmodel.WorkerLease{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListWorkerLeases returns all worker leases, including expired leases,
+// ordered by worker name.
+func (d *Database) ListWorkerLeases(ctx context.Context) (_ []dbmodel.WorkerLease, err error) {
+	const op = errors.Op("db.ListWorkerLeases")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var leases []dbmodel.WorkerLease
+	if err := d.DB.WithContext(ctx).Order("name asc").Find(&leases).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return leases, nil
+}
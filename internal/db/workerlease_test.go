@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAcquireWorkerLeaseUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.AcquireWorkerLease(context.Background(), "worker", "replica-1", time.Minute)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestWorkerLeases(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	ok, err := s.Database.AcquireWorkerLease(ctx, "worker", "replica-1", time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsTrue)
+
+	// The lease is held, so another replica cannot take it.
+	ok, err = s.Database.AcquireWorkerLease(ctx, "worker", "replica-2", time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsFalse)
+
+	// The holder can renew the lease.
+	ok, err = s.Database.AcquireWorkerLease(ctx, "worker", "replica-1", -time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsTrue)
+
+	// The lease has now expired, so another replica can take it.
+	ok, err = s.Database.AcquireWorkerLease(ctx, "worker", "replica-2", time.Minute)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsTrue)
+
+	leases, err := s.Database.ListWorkerLeases(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(leases, qt.HasLen, 1)
+	c.Check(leases[0].Name, qt.Equals, "worker")
+	c.Check(leases[0].Holder, qt.Equals, "replica-2")
+
+	// Releasing a lease held by another replica has no effect.
+	err = s.Database.ReleaseWorkerLease(ctx, "worker", "replica-1")
+	c.Assert(err, qt.IsNil)
+	leases, err = s.Database.ListWorkerLeases(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(leases, qt.HasLen, 1)
+
+	err = s.Database.ReleaseWorkerLease(ctx, "worker", "replica-2")
+	c.Assert(err, qt.IsNil)
+	leases, err = s.Database.ListWorkerLeases(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(leases, qt.HasLen, 0)
+}
@@ -22,6 +22,10 @@ type AuditLogEntry struct {
 	// by JIMM.
 	Model string `gorm:"index"`
 
+	// ModelUUID contains the UUID of the model accessed. Will be empty
+	// when accessing controller facades.
+	ModelUUID string `gorm:"column:model_uuid;index"`
+
 	// ConversationId contains a unique ID per websocket request.
 	ConversationId string
 
@@ -71,6 +75,7 @@ func (e AuditLogEntry) ToAPIAuditEvent() apiparams.AuditEvent {
 	ale.ObjectId = e.ObjectId
 	ale.UserTag = e.IdentityTag
 	ale.Model = e.Model
+	ale.ModelUUID = e.ModelUUID
 	ale.IsResponse = e.IsResponse
 	ale.Errors = nil
 	if e.IsResponse {
@@ -178,6 +178,10 @@ type CloudRegion struct {
 	// Config contains the configuration associated with this region.
 	Config Map
 
+	// Disabled records whether the region is closed for the placement of
+	// new models. Existing models in the region are unaffected.
+	Disabled bool `gorm:"not null;default:FALSE"`
+
 	// Controllers contains any controllers that can provide service for
 	// this cloud-region.
 	Controllers []CloudRegionControllerPriority
@@ -41,6 +41,14 @@ type CloudCredential struct {
 	// Valid stores whether the cloud-credential is known to be valid.
 	Valid sql.NullBool
 
+	// ExpiresAt holds the time at which the credential expires, if it
+	// is known.
+	ExpiresAt sql.NullTime
+
+	// ExpiringSoon records whether the credential is about to expire.
+	// This is maintained by the credential expiry worker.
+	ExpiringSoon bool
+
 	// Models contains the models using this credential.
 	Models []Model
 }
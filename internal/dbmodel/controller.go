@@ -54,6 +54,18 @@ type Controller struct {
 	// Useful for local dev to avoid TLS issues.
 	TLSHostname string `gorm:"column:tls_hostname"`
 
+	// SRVName is a DNS SRV name, for example
+	// "_juju-api._tcp.controller.example.com", that is resolved when
+	// dialing the controller to find its API endpoints. If it is set the
+	// resolved endpoints are used in place of Addresses, so that changes
+	// to a HA controller's units do not need to be made in JIMM.
+	SRVName string `gorm:"column:srv_name"`
+
+	// DashboardURL is the URL of the Juju Dashboard serving this
+	// controller, if any. JIMM links to models on the controller relative
+	// to this URL.
+	DashboardURL string `gorm:"column:dashboard_url;not null;default:''"`
+
 	// CloudName is the name of the cloud which is hosting this
 	// controller.
 	CloudName string
@@ -66,6 +78,11 @@ type Controller struct {
 	// therefore no new models or clouds will be added to the controller.
 	Deprecated bool `gorm:"not null;default:FALSE"`
 
+	// Draining records whether this controller is being drained for
+	// maintenance, and therefore no new models will be placed on the
+	// controller.
+	Draining bool `gorm:"not null;default:FALSE"`
+
 	// AgentVersion holds the string representation of the controller's
 	// agent version.
 	AgentVersion string
@@ -114,6 +131,8 @@ func (c Controller) ToAPIControllerInfo() apiparams.ControllerInfo {
 	ci.UUID = c.UUID
 	ci.Username = c.AdminIdentityName
 	ci.PublicAddress = c.PublicAddress
+	ci.SRVName = c.SRVName
+	ci.DashboardURL = c.DashboardURL
 	for _, hps := range c.Addresses {
 		for _, hp := range hps {
 			ci.APIAddresses = append(ci.APIAddresses, net.JoinHostPort(hp.Value, strconv.Itoa(hp.Port)))
@@ -124,12 +143,19 @@ func (c Controller) ToAPIControllerInfo() apiparams.ControllerInfo {
 	ci.CloudRegion = c.CloudRegion
 	ci.Username = c.AdminIdentityName
 	ci.AgentVersion = c.AgentVersion
+	for _, cr := range c.CloudRegions {
+		ci.CloudRegions = append(ci.CloudRegions, cr.CloudRegion.Cloud.Name+"/"+cr.CloudRegion.Name)
+	}
 	switch {
 	case c.UnavailableSince.Valid:
 		ci.Status = jujuparams.EntityStatus{
 			Status: "unavailable",
 			Since:  &c.UnavailableSince.Time,
 		}
+	case c.Draining:
+		ci.Status = jujuparams.EntityStatus{
+			Status: "draining",
+		}
 	case c.Deprecated:
 		ci.Status = jujuparams.EntityStatus{
 			Status: "deprecated",
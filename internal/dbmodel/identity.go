@@ -47,6 +47,16 @@ type Identity struct {
 	// DisplayName is the display name of the identity.
 	DisplayName string `gorm:"not null"`
 
+	// Email is the email address of the identity, as given by the
+	// identity provider. It is empty until the identity's profile has
+	// been synchronised.
+	Email string `gorm:"not null;default:''"`
+
+	// ProfileSyncedAt is the time the identity's display name and email
+	// were last synchronised from the identity provider. It is only
+	// valid if the profile has been synchronised at least once.
+	ProfileSyncedAt sql.NullTime
+
 	// LastLogin is the time the identity last authenticated to the JIMM
 	// server. LastLogin will only be a valid time if the identity has
 	// authenticated at least once.
@@ -81,6 +91,11 @@ type Identity struct {
 
 	// AccessTokenType is the type for the token, typically bearer.
 	AccessTokenType string
+
+	// AllowedCIDRs holds the network ranges, in CIDR notation, from which
+	// a service account may authenticate. An empty list places no
+	// restriction on the client address.
+	AllowedCIDRs Strings `gorm:"column:allowed_cidrs"`
 }
 
 // Tag returns a names.Tag for the identity.
@@ -0,0 +1,25 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A Limit restricts the amount of a resource that may be used within a
+// scope, for example the number of models a user may own.
+type Limit struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Entity is the kind of resource that is limited.
+	Entity string
+
+	// Scope is what the limit applies to. It is either a single user,
+	// controller or cloud in the form "<kind>-<id>", for example
+	// "user-alice@canonical.com", or just a kind, such as "user", in
+	// which case it is the default limit for everything of that kind.
+	Scope string
+
+	// Value is the maximum amount of the resource that may be used.
+	Value int64
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A LoginFailure counts the failed logins of an identity. Failures are
+// counted across every JIMM replica and every client address. The
+// identity is not required to exist.
+type LoginFailure struct {
+	// IdentityName holds the name of the identity that failed to log
+	// in.
+	IdentityName string `gorm:"primaryKey"`
+
+	// Failures holds the number of failed logins since WindowStart.
+	Failures int
+
+	// WindowStart holds the time from which failures are counted.
+	WindowStart time.Time
+
+	// LockedUntil holds the time until which logins by the identity are
+	// refused.
+	LockedUntil time.Time
+
+	// ExpiresAt holds the time after which the record no longer affects
+	// logins and may be removed.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,27 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// MaintenanceMode records whether JIMM is in maintenance mode. While in
+// maintenance mode JIMM rejects requests that would modify its state.
+// There is only ever a single MaintenanceMode, shared by all replicas.
+type MaintenanceMode struct {
+	// ID is always 1.
+	ID uint `gorm:"primaryKey"`
+
+	// UpdatedAt holds the time the maintenance mode was last changed.
+	UpdatedAt time.Time
+
+	// Enabled is true if JIMM is in maintenance mode.
+	Enabled bool
+
+	// Message holds a message, set by the administrator, that is
+	// returned to clients whose requests are rejected.
+	Message string
+
+	// SetBy holds the name of the identity that last changed the
+	// maintenance mode.
+	SetBy string
+}
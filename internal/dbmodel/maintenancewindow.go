@@ -0,0 +1,35 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A MaintenanceWindow is a period, declared by an administrator, during
+// which a controller is expected to be under maintenance. During the
+// window JIMM does not report the controller as unavailable, does not
+// start migrations to or from it and warns users creating models on it.
+type MaintenanceWindow struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// ControllerID is the ID of the controller the window applies to.
+	ControllerID uint
+	Controller   Controller
+
+	// StartsAt holds the time the window starts.
+	StartsAt time.Time
+
+	// EndsAt holds the time the window ends.
+	EndsAt time.Time
+
+	// Reason is a free-text description of the maintenance.
+	Reason string
+
+	// CreatedBy holds the name of the identity that declared the window.
+	CreatedBy string
+}
+
+// Active reports whether the window includes the given time.
+func (w MaintenanceWindow) Active(t time.Time) bool {
+	return !t.Before(w.StartsAt) && t.Before(w.EndsAt)
+}
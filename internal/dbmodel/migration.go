@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// The states of a scheduled migration.
+const (
+	// MigrationQueued is the state of a migration waiting to be run.
+	MigrationQueued = "queued"
+
+	// MigrationPrechecking is the state of a migration whose pre-checks
+	// are being run against the source and target controllers.
+	MigrationPrechecking = "prechecking"
+
+	// MigrationStarted is the state of a migration that the source
+	// controller has accepted and is performing.
+	MigrationStarted = "started"
+
+	// MigrationSucceeded is the state of a migration whose model has
+	// been recorded as hosted by the target controller.
+	MigrationSucceeded = "succeeded"
+
+	// MigrationFailed is the state of a migration that failed its
+	// pre-checks or could not be started.
+	MigrationFailed = "failed"
+)
+
+// A Migration records the progress of a model migration queued with the
+// migration scheduler.
+type Migration struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// ModelUUID is the UUID of the model being migrated.
+	ModelUUID string
+
+	// ModelName is the name of the model being migrated.
+	ModelName string
+
+	// SourceController is the name of the controller hosting the model
+	// when the migration was queued.
+	SourceController string
+
+	// TargetController is the name of the controller the model is being
+	// migrated to.
+	TargetController string
+
+	// IdentityName is the name of the identity that queued the migration.
+	IdentityName string
+
+	// Status is the state of the migration, one of "queued",
+	// "prechecking", "started", "succeeded" or "failed".
+	Status string
+
+	// MigrationID is the ID of the migration on the source controller,
+	// once it has been started.
+	MigrationID string
+
+	// Error holds the reason the migration failed, if it did.
+	Error string
+
+	// CompletedAt holds the time at which the migration succeeded or
+	// failed.
+	CompletedAt sql.NullTime
+}
+
+// Done returns whether the migration has finished.
+func (m Migration) Done() bool {
+	return m.Status == MigrationSucceeded || m.Status == MigrationFailed
+}
+
+// ToAPIMigrationStatus converts a migration to a JIMM API
+// MigrationStatus.
+func (m Migration) ToAPIMigrationStatus() apiparams.MigrationStatus {
+	status := apiparams.MigrationStatus{
+		ID:               m.ID,
+		ModelUUID:        m.ModelUUID,
+		ModelName:        m.ModelName,
+		SourceController: m.SourceController,
+		TargetController: m.TargetController,
+		Status:           m.Status,
+		MigrationID:      m.MigrationID,
+		Error:            m.Error,
+		Queued:           m.CreatedAt,
+	}
+	if m.CompletedAt.Valid {
+		completed := m.CompletedAt.Time
+		status.Completed = &completed
+	}
+	return status
+}
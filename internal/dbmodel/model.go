@@ -77,6 +77,14 @@ type Model struct {
 	// Units contains the count of machines in the model.
 	Units int64
 
+	// Labels holds the free-form key/value labels set on the model in
+	// JIMM. Labels are not sent to the model's controller.
+	Labels StringMap
+
+	// LastActivity holds the time activity, such as a unit changing or
+	// a client connecting through JIMM, was last seen in the model.
+	LastActivity sql.NullTime
+
 	// Offers are the ApplicationOffers attached to the model.
 	Offers []ApplicationOffer
 }
@@ -97,6 +105,15 @@ func (m Model) ResourceTag() names.ModelTag {
 	return names.ModelTag{}
 }
 
+// LastActive returns the time activity was last seen in the model. If no
+// activity has been recorded the time the model was created is returned.
+func (m Model) LastActive() time.Time {
+	if m.LastActivity.Valid {
+		return m.LastActivity.Time
+	}
+	return m.CreatedAt
+}
+
 // SetTag sets the UUID of the model to the given tag.
 func (m *Model) SetTag(t names.ModelTag) {
 	m.UUID.String = t.Id()
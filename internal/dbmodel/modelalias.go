@@ -0,0 +1,23 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A ModelAlias is a name a user has given to a model, which may be used
+// in place of the model's tag.
+type ModelAlias struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// IdentityName is the name of the identity that owns the alias.
+	IdentityName string
+
+	// Alias is the alias, which is unique for the identity.
+	Alias string
+
+	// ModelID is the ID of the model the alias refers to.
+	ModelID uint
+	Model   Model
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A ModelArchive holds a snapshot of a model taken before it was
+// destroyed, so that the model's final state can be retrieved after the
+// model has been removed.
+type ModelArchive struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// ModelUUID is the UUID of the archived model.
+	ModelUUID string
+
+	// ModelName is the name of the archived model.
+	ModelName string
+
+	// OwnerIdentityName is the name of the identity that owned the
+	// model.
+	OwnerIdentityName string
+
+	// ControllerName is the name of the controller that hosted the
+	// model.
+	ControllerName string
+
+	// ArchivedBy is the name of the identity that destroyed the model.
+	ArchivedBy string
+
+	// Config holds the model's configuration values.
+	Config Map
+
+	// Status holds the JSON encoding of the model's full status, which
+	// includes its applications and machines.
+	Status Map
+}
+
+// ToAPIModelArchive converts a model archive to a JIMM API ModelArchive.
+func (a ModelArchive) ToAPIModelArchive() apiparams.ModelArchive {
+	return apiparams.ModelArchive{
+		ModelUUID:  a.ModelUUID,
+		ModelName:  a.ModelName,
+		Owner:      a.OwnerIdentityName,
+		Controller: a.ControllerName,
+		ArchivedBy: a.ArchivedBy,
+		ArchivedAt: a.CreatedAt,
+		Config:     a.Config,
+		Status:     a.Status,
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// A ModelConfigPolicy restricts the value of a model config key in every
+// model managed by JIMM.
+type ModelConfigPolicy struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Key is the model config key to which the policy applies. There
+	// is at most one policy for each key.
+	Key string
+
+	// Action is either "forbid" or "force". A forbid policy prevents
+	// the key being set to Value, or being set at all if Value is
+	// null. A force policy requires the key to have Value.
+	Action string
+
+	// Value is the value forbidden or forced by the policy.
+	Value sql.NullString
+
+	// Description explains the policy to users whose requests violate
+	// it.
+	Description string
+}
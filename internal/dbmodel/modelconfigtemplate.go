@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A ModelConfigTemplate is a named set of model config values that may
+// be applied to a model when it is created.
+type ModelConfigTemplate struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// OwnerIdentityName is the name of the identity that owns the
+	// template.
+	OwnerIdentityName string
+
+	// Name is the name of the template, which is unique for the owner.
+	Name string
+
+	// Config holds the model config values set by the template.
+	Config Map
+
+	// SharedWith holds the names of the identities, other than the
+	// owner, that may use the template.
+	SharedWith Strings
+}
+
+// Path returns the path of the template, in the form <owner>/<name>.
+func (t ModelConfigTemplate) Path() string {
+	return t.OwnerIdentityName + "/" + t.Name
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A NotificationRoute determines where notifications for a user, or for
+// the members of a group, are delivered.
+type NotificationRoute struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// SubjectKind is the kind of entity the route is for, either "user"
+	// or "group".
+	SubjectKind string
+
+	// SubjectID holds the name of the user or the UUID of the group the
+	// route is for.
+	SubjectID string
+
+	// Kind is the kind of notification delivered along the route. If
+	// this is empty notifications of every kind are delivered.
+	Kind string
+
+	// Transport is the name of the transport used to deliver the
+	// notifications.
+	Transport string
+
+	// Destination is the transport specific destination of the
+	// notifications, such as an email address.
+	Destination string
+}
+
+// Matches reports whether notifications of the given kind are delivered
+// along the route.
+func (r NotificationRoute) Matches(kind string) bool {
+	return r.Kind == "" || r.Kind == kind
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A ServiceAccount holds the metadata JIMM records about a service
+// account it manages. The service account's identity, which records
+// whether it is disabled, is held in the identities table.
+type ServiceAccount struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// IdentityName is the name of the service account's identity, its
+	// client ID with the @serviceaccount domain.
+	IdentityName string
+	Identity     Identity `gorm:"foreignKey:IdentityName;references:Name"`
+
+	// CreatorIdentityName is the name of the identity that added the
+	// service account to JIMM. It is empty for service accounts added
+	// before this was recorded.
+	CreatorIdentityName string
+
+	// Purpose is a free-form description of what the service account is
+	// used for.
+	Purpose string
+}
+
+// ToAPIServiceAccount converts a service account to a JIMM API
+// ServiceAccount.
+func (sa ServiceAccount) ToAPIServiceAccount() apiparams.ServiceAccount {
+	return apiparams.ServiceAccount{
+		ClientID:  sa.IdentityName,
+		Creator:   sa.CreatorIdentityName,
+		Purpose:   sa.Purpose,
+		CreatedAt: sa.CreatedAt,
+		Disabled:  sa.Identity.Disabled,
+	}
+}
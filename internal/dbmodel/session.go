@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const (
+	// BrowserSession is the kind of session created by a browser login,
+	// identified by a session cookie.
+	BrowserSession = "browser"
+
+	// DeviceSession is the kind of session created by a device login,
+	// identified by a JIMM session token.
+	DeviceSession = "device"
+)
+
+// A Session records a login session created by the OAuth authenticator so
+// that administrators can list and revoke it.
+type Session struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// IdentityName holds the name of the identity that logged in.
+	IdentityName string
+
+	// Kind holds the kind of session, either BrowserSession or
+	// DeviceSession.
+	Kind string
+
+	// Key identifies the session. For browser sessions this is the key
+	// of the session in the session store, for device sessions it is a
+	// hash of the session token.
+	Key string
+
+	// ExpiresAt holds the time at which the session expires.
+	ExpiresAt time.Time
+
+	// RevokedAt holds the time at which the session was revoked, if it
+	// has been.
+	RevokedAt sql.NullTime
+}
+
+// ToAPISession converts a session to a JIMM API Session. The session key
+// is not included.
+func (s Session) ToAPISession() apiparams.Session {
+	return apiparams.Session{
+		ID:        s.ID,
+		User:      s.IdentityName,
+		Kind:      s.Kind,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: s.ExpiresAt.Format(time.RFC3339),
+	}
+}
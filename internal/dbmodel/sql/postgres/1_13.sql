@@ -0,0 +1,14 @@
+-- 1_13.sql is a migration that adds a table to track time-bounded
+-- relations granted in OpenFGA.
+CREATE TABLE IF NOT EXISTS temporary_grants (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	object TEXT NOT NULL,
+	relation TEXT NOT NULL,
+	target_object TEXT NOT NULL,
+	granted_by TEXT NOT NULL,
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_temporary_grants_expires_at ON temporary_grants (expires_at);
+
+UPDATE versions SET major=1, minor=13 WHERE component='jimmdb';
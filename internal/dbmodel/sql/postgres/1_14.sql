@@ -0,0 +1,6 @@
+-- 1_14.sql is a migration that adds an allowlist of network ranges
+-- to identities, used to restrict where service accounts may
+-- authenticate from.
+ALTER TABLE identities ADD COLUMN allowed_cidrs BYTEA;
+
+UPDATE versions SET major=1, minor=14 WHERE component='jimmdb';
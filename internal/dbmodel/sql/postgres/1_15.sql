@@ -0,0 +1,14 @@
+-- 1_15.sql is a migration that adds a table of webhooks registered on
+-- models to be notified of status transitions.
+CREATE TABLE IF NOT EXISTS model_webhooks (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	created_by TEXT NOT NULL,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	events BYTEA
+);
+CREATE INDEX IF NOT EXISTS idx_model_webhooks_model_id ON model_webhooks (model_id);
+
+UPDATE versions SET major=1, minor=15 WHERE component='jimmdb';
@@ -0,0 +1,9 @@
+-- 1_16.sql is a migration that adds a table of leases used to elect
+-- which JIMM replica runs each background worker.
+CREATE TABLE IF NOT EXISTS worker_leases (
+	name TEXT PRIMARY KEY,
+	holder TEXT NOT NULL,
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+UPDATE versions SET major=1, minor=16 WHERE component='jimmdb';
@@ -0,0 +1,13 @@
+-- 1_17.sql is a migration that adds a table of limits on the resources
+-- that may be used by users, controllers and clouds.
+CREATE TABLE IF NOT EXISTS limits (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	entity TEXT NOT NULL,
+	scope TEXT NOT NULL,
+	value BIGINT NOT NULL,
+	UNIQUE (entity, scope)
+);
+
+UPDATE versions SET major=1, minor=17 WHERE component='jimmdb';
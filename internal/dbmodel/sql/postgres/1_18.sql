@@ -0,0 +1,11 @@
+-- 1_18.sql is a migration that adds a table holding JIMM's maintenance
+-- mode. The table holds at most a single row.
+CREATE TABLE IF NOT EXISTS maintenance_modes (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	updated_at TIMESTAMP WITH TIME ZONE,
+	enabled BOOLEAN NOT NULL DEFAULT FALSE,
+	message TEXT NOT NULL DEFAULT '',
+	set_by TEXT NOT NULL DEFAULT ''
+);
+
+UPDATE versions SET major=1, minor=18 WHERE component='jimmdb';
@@ -0,0 +1,15 @@
+-- 1_19.sql is a migration that adds a table of the routes along which
+-- notifications for users and groups are delivered.
+CREATE TABLE IF NOT EXISTS notification_routes (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	subject_kind TEXT NOT NULL,
+	subject_id TEXT NOT NULL,
+	kind TEXT NOT NULL DEFAULT '',
+	transport TEXT NOT NULL,
+	destination TEXT NOT NULL,
+	UNIQUE (subject_kind, subject_id, kind, transport, destination)
+);
+CREATE INDEX IF NOT EXISTS idx_notification_routes_subject ON notification_routes (subject_kind, subject_id);
+
+UPDATE versions SET major=1, minor=19 WHERE component='jimmdb';
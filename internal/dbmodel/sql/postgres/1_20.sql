@@ -0,0 +1,14 @@
+-- 1_20.sql is a migration that adds a table of the named sets of model
+-- config values that may be applied when a model is created.
+CREATE TABLE IF NOT EXISTS model_config_templates (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	owner_identity_name TEXT NOT NULL,
+	name TEXT NOT NULL,
+	config BYTEA,
+	shared_with BYTEA,
+	UNIQUE (owner_identity_name, name)
+);
+
+UPDATE versions SET major=1, minor=20 WHERE component='jimmdb';
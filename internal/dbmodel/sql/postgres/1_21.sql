@@ -0,0 +1,13 @@
+-- 1_21.sql is a migration that adds a table of the aliases users have
+-- given to models.
+CREATE TABLE IF NOT EXISTS model_aliases (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	identity_name TEXT NOT NULL,
+	alias TEXT NOT NULL,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	UNIQUE (identity_name, alias)
+);
+
+UPDATE versions SET major=1, minor=21 WHERE component='jimmdb';
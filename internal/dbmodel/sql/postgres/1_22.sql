@@ -0,0 +1,6 @@
+-- 1_22.sql is a migration that adds an srv_name column to the controller
+-- table, holding a DNS SRV name that is resolved to find the controller's
+-- API endpoints.
+ALTER TABLE controllers ADD COLUMN srv_name TEXT;
+
+UPDATE versions SET major=1, minor=22 WHERE component='jimmdb';
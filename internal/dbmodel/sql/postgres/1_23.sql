@@ -0,0 +1,15 @@
+-- 1_23.sql is a migration that adds a table of the maintenance windows
+-- declared for controllers.
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	controller_id BIGINT NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	created_by TEXT NOT NULL,
+	CHECK (ends_at > starts_at)
+);
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_controller_id ON maintenance_windows (controller_id, ends_at);
+
+UPDATE versions SET major=1, minor=23 WHERE component='jimmdb';
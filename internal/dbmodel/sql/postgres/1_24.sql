@@ -0,0 +1,14 @@
+-- 1_24.sql is a migration that adds a table of the policies applied to
+-- model config.
+CREATE TABLE IF NOT EXISTS model_config_policies (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	key TEXT NOT NULL UNIQUE,
+	action TEXT NOT NULL CHECK (action IN ('forbid', 'force')),
+	value TEXT,
+	description TEXT NOT NULL DEFAULT '',
+	CHECK (action <> 'force' OR value IS NOT NULL)
+);
+
+UPDATE versions SET major=1, minor=24 WHERE component='jimmdb';
@@ -0,0 +1,6 @@
+-- 1_25.sql is a migration that adds the profile synchronised from the
+-- identity provider to identities.
+ALTER TABLE identities ADD COLUMN IF NOT EXISTS email TEXT NOT NULL DEFAULT '';
+ALTER TABLE identities ADD COLUMN IF NOT EXISTS profile_synced_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE versions SET major=1, minor=25 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_26.sql is a migration that adds a dashboard_url column to the
+-- controller table, holding the URL of the controller's Juju Dashboard.
+ALTER TABLE controllers ADD COLUMN IF NOT EXISTS dashboard_url TEXT NOT NULL DEFAULT '';
+
+UPDATE versions SET major=1, minor=26 WHERE component='jimmdb';
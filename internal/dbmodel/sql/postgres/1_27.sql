@@ -0,0 +1,8 @@
+-- 1_27.sql is a migration that adds a model_uuid column to the audit log
+-- so that entries can be found by model UUID, and an index used to page
+-- through the audit log in time order.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS model_uuid TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_audit_log_model_uuid ON audit_log (model_uuid);
+CREATE INDEX IF NOT EXISTS idx_audit_log_time_id ON audit_log (time, id);
+
+UPDATE versions SET major=1, minor=27 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_28.sql is a migration that adds a draining flag to controllers. No
+-- new models are placed on a draining controller.
+ALTER TABLE controllers ADD COLUMN IF NOT EXISTS draining BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE versions SET major=1, minor=28 WHERE component='jimmdb';
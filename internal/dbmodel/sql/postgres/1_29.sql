@@ -0,0 +1,16 @@
+-- 1_29.sql is a migration that adds a table recording the delivery of
+-- lifecycle events to the configured webhook endpoints.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	event TEXT NOT NULL,
+	url TEXT NOT NULL,
+	payload BYTEA,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	delivered_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+
+UPDATE versions SET major=1, minor=29 WHERE component='jimmdb';
@@ -0,0 +1,6 @@
+-- 1_30.sql is a migration that records when cloud credentials expire and
+-- whether they are about to expire.
+ALTER TABLE cloud_credentials ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE cloud_credentials ADD COLUMN IF NOT EXISTS expiring_soon BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE versions SET major=1, minor=30 WHERE component='jimmdb';
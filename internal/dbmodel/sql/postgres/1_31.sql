@@ -0,0 +1,17 @@
+-- 1_31.sql is a migration that adds a table tracking the progress of
+-- long-running operations, such as asynchronous model creation.
+CREATE TABLE IF NOT EXISTS tasks (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	kind TEXT NOT NULL,
+	identity_name TEXT NOT NULL REFERENCES identities (name) ON DELETE CASCADE,
+	status TEXT NOT NULL,
+	progress TEXT NOT NULL DEFAULT '',
+	error TEXT NOT NULL DEFAULT '',
+	result TEXT NOT NULL DEFAULT '',
+	completed_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_tasks_identity_name ON tasks (identity_name);
+
+UPDATE versions SET major=1, minor=31 WHERE component='jimmdb';
@@ -0,0 +1,20 @@
+-- 1_32.sql is a migration that adds a table recording the progress of
+-- model migrations queued with the migration scheduler.
+CREATE TABLE IF NOT EXISTS migrations (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_uuid TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	source_controller TEXT NOT NULL,
+	target_controller TEXT NOT NULL,
+	identity_name TEXT NOT NULL REFERENCES identities (name) ON DELETE CASCADE,
+	status TEXT NOT NULL,
+	migration_id TEXT NOT NULL DEFAULT '',
+	error TEXT NOT NULL DEFAULT '',
+	completed_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_migrations_model_uuid ON migrations (model_uuid);
+CREATE INDEX IF NOT EXISTS idx_migrations_status ON migrations (status);
+
+UPDATE versions SET major=1, minor=32 WHERE component='jimmdb';
@@ -0,0 +1,4 @@
+-- 1_33.sql is a migration that adds free-form labels to models.
+ALTER TABLE models ADD COLUMN IF NOT EXISTS labels BYTEA;
+
+UPDATE versions SET major=1, minor=33 WHERE component='jimmdb';
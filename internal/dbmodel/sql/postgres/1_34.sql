@@ -0,0 +1,17 @@
+-- 1_34.sql is a migration that adds a table recording who added each
+-- service account to JIMM, and why. Existing service accounts are
+-- recorded with no creator or purpose.
+CREATE TABLE IF NOT EXISTS service_accounts (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	identity_name TEXT NOT NULL UNIQUE REFERENCES identities (name) ON DELETE CASCADE,
+	creator_identity_name TEXT NOT NULL DEFAULT '',
+	purpose TEXT NOT NULL DEFAULT ''
+);
+INSERT INTO service_accounts (created_at, updated_at, identity_name)
+	SELECT created_at, updated_at, name FROM identities
+	WHERE name LIKE '%@serviceaccount' AND deleted_at IS NULL
+	ON CONFLICT DO NOTHING;
+
+UPDATE versions SET major=1, minor=34 WHERE component='jimmdb';
@@ -0,0 +1,16 @@
+-- 1_35.sql is a migration that adds a table holding the snapshots of
+-- models taken before they are destroyed.
+CREATE TABLE IF NOT EXISTS model_archives (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	model_uuid TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	owner_identity_name TEXT NOT NULL,
+	controller_name TEXT NOT NULL,
+	archived_by TEXT NOT NULL,
+	config JSONB,
+	status JSONB
+);
+CREATE INDEX IF NOT EXISTS idx_model_archives_model_uuid ON model_archives (model_uuid);
+
+UPDATE versions SET major=1, minor=35 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_36.sql is a migration that adds a flag to cloud regions recording
+-- whether the region is closed for the placement of new models.
+ALTER TABLE cloud_regions ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE versions SET major=1, minor=36 WHERE component='jimmdb';
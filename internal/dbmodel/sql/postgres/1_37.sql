@@ -0,0 +1,7 @@
+-- 1_37.sql is a migration that records when activity was last seen in
+-- each model. Existing models are treated as active when the migration
+-- is run.
+ALTER TABLE models ADD COLUMN IF NOT EXISTS last_activity TIMESTAMP WITH TIME ZONE;
+UPDATE models SET last_activity = NOW() WHERE last_activity IS NULL;
+
+UPDATE versions SET major=1, minor=37 WHERE component='jimmdb';
@@ -0,0 +1,17 @@
+-- 1_38.sql is a migration that records the browser and device sessions
+-- created by the OAuth authenticator so that they can be listed and
+-- revoked.
+CREATE TABLE IF NOT EXISTS sessions (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	identity_name TEXT NOT NULL REFERENCES identities (name) ON DELETE CASCADE,
+	kind TEXT NOT NULL,
+	key TEXT NOT NULL UNIQUE,
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	revoked_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_identity_name ON sessions (identity_name);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions (expires_at);
+
+UPDATE versions SET major=1, minor=38 WHERE component='jimmdb';
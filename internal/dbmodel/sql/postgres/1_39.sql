@@ -0,0 +1,19 @@
+-- 1_39.sql is a migration that adds a table holding the daily machine
+-- and unit usage of each model. Records are kept after the model is
+-- destroyed so that usage can still be reported.
+CREATE TABLE IF NOT EXISTS usage_records (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	date DATE NOT NULL,
+	model_uuid TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	owner_identity_name TEXT NOT NULL,
+	controller_name TEXT NOT NULL,
+	machine_hours DOUBLE PRECISION NOT NULL DEFAULT 0,
+	unit_hours DOUBLE PRECISION NOT NULL DEFAULT 0,
+	UNIQUE (model_uuid, date)
+);
+CREATE INDEX IF NOT EXISTS idx_usage_records_date ON usage_records (date);
+
+UPDATE versions SET major=1, minor=39 WHERE component='jimmdb';
@@ -0,0 +1,13 @@
+-- 1_40.sql is a migration that adds a table counting the failed logins
+-- of each identity, so that every JIMM replica enforces the same
+-- lockout.
+CREATE TABLE IF NOT EXISTS login_failures (
+	identity_name TEXT NOT NULL PRIMARY KEY,
+	failures INTEGER NOT NULL DEFAULT 0,
+	window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+	locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_login_failures_expires_at ON login_failures (expires_at);
+
+UPDATE versions SET major=1, minor=40 WHERE component='jimmdb';
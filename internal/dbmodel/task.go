@@ -0,0 +1,79 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// The kinds of task.
+const (
+	// TaskKindAddModel is the kind of task that creates a model.
+	TaskKindAddModel = "add-model"
+)
+
+// The states of a task.
+const (
+	TaskPending   = "pending"
+	TaskRunning   = "running"
+	TaskSucceeded = "succeeded"
+	TaskFailed    = "failed"
+)
+
+// A Task records the progress of a long-running operation started on
+// behalf of an identity, such as the asynchronous creation of a model.
+type Task struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Kind is the kind of operation the task performs, for example
+	// "add-model".
+	Kind string
+
+	// IdentityName is the name of the identity that started the task.
+	IdentityName string
+
+	// Status is the state of the task, one of "pending", "running",
+	// "succeeded" or "failed".
+	Status string
+
+	// Progress holds a description of the current step of the task.
+	Progress string
+
+	// Error holds the reason the task failed, if it did.
+	Error string
+
+	// Result holds the result of a successful task. For an add-model
+	// task this is the UUID of the created model.
+	Result string
+
+	// CompletedAt holds the time at which the task succeeded or failed.
+	CompletedAt sql.NullTime
+}
+
+// Done returns whether the task has finished.
+func (t Task) Done() bool {
+	return t.Status == TaskSucceeded || t.Status == TaskFailed
+}
+
+// ToAPITaskStatus converts a task to a JIMM API TaskStatus.
+func (t Task) ToAPITaskStatus() apiparams.TaskStatus {
+	status := apiparams.TaskStatus{
+		TaskID:   t.ID,
+		Kind:     t.Kind,
+		Status:   t.Status,
+		Progress: t.Progress,
+		Error:    t.Error,
+		Result:   t.Result,
+		Created:  t.CreatedAt,
+	}
+	if t.CompletedAt.Valid {
+		completed := t.CompletedAt.Time
+		status.Completed = &completed
+	}
+	return status
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A TemporaryGrant holds information about a relation that has been
+// granted for a limited period of time. The relation is removed from
+// OpenFGA once it has expired.
+type TemporaryGrant struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// Object, Relation and TargetObject hold the relationship tuple
+	// that was granted, in the form supplied by the client.
+	Object       string
+	Relation     string
+	TargetObject string
+
+	// GrantedBy holds the name of the identity that created the grant.
+	GrantedBy string
+
+	// ExpiresAt holds the time at which the grant expires.
+	ExpiresAt time.Time
+}
+
+// Tuple returns the relationship tuple held by the grant.
+func (g TemporaryGrant) Tuple() apiparams.RelationshipTuple {
+	return apiparams.RelationshipTuple{
+		Object:       g.Object,
+		Relation:     g.Relation,
+		TargetObject: g.TargetObject,
+	}
+}
+
+// ToAPITemporaryGrant converts a temporary grant to a JIMM API
+// TemporaryGrant.
+func (g TemporaryGrant) ToAPITemporaryGrant() apiparams.TemporaryGrant {
+	return apiparams.TemporaryGrant{
+		Tuple:     g.Tuple(),
+		GrantedBy: g.GrantedBy,
+		CreatedAt: g.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: g.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A UsageRecord holds the machine and unit usage of a model on a single
+// day. The model's details are copied into the record so that usage can
+// be reported after the model has been destroyed.
+type UsageRecord struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Date is the UTC day the usage was accrued on.
+	Date time.Time `gorm:"type:date"`
+
+	// ModelUUID is the UUID of the model.
+	ModelUUID string
+
+	// ModelName is the name of the model when usage was last accrued.
+	ModelName string
+
+	// OwnerIdentityName is the name of the identity that owned the
+	// model when usage was last accrued.
+	OwnerIdentityName string
+
+	// ControllerName is the name of the controller hosting the model
+	// when usage was last accrued.
+	ControllerName string
+
+	// MachineHours is the number of machine-hours accrued by the model
+	// during the day.
+	MachineHours float64
+
+	// UnitHours is the number of unit-hours accrued by the model during
+	// the day.
+	UnitHours float64
+}
+
+// ToAPIUsageRecord converts a usage record to a JIMM API UsageRecord.
+// Records without a date, holding the usage over a whole report period,
+// have an empty date.
+func (r UsageRecord) ToAPIUsageRecord() apiparams.UsageRecord {
+	var date string
+	if !r.Date.IsZero() {
+		date = r.Date.Format(time.DateOnly)
+	}
+	return apiparams.UsageRecord{
+		Date:         date,
+		ModelUUID:    r.ModelUUID,
+		ModelName:    r.ModelName,
+		Owner:        r.OwnerIdentityName,
+		Controller:   r.ControllerName,
+		MachineHours: r.MachineHours,
+		UnitHours:    r.UnitHours,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/juju/names/v5"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A ModelWebhook is a webhook registered on a model. The webhook URL is
+// called whenever the model undergoes one of the selected status
+// transitions.
+type ModelWebhook struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	// ModelID is the ID of the model the webhook is registered on.
+	ModelID uint
+
+	// CreatedBy holds the name of the identity that registered the
+	// webhook.
+	CreatedBy string
+
+	// URL is the address to which notifications are posted.
+	URL string
+
+	// Secret is the key used to sign notifications so that the
+	// receiver can verify they were sent by JIMM.
+	Secret string
+
+	// Events holds the status transitions that trigger a notification.
+	Events Strings
+}
+
+// HasEvent reports whether the webhook is triggered by the given event.
+func (w ModelWebhook) HasEvent(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ToAPIModelWebhook converts a model webhook to a JIMM API ModelWebhook.
+// The secret is not included.
+func (w ModelWebhook) ToAPIModelWebhook(mt names.ModelTag) apiparams.ModelWebhook {
+	return apiparams.ModelWebhook{
+		ID:        w.ID,
+		ModelTag:  mt.String(),
+		URL:       w.URL,
+		Events:    []string(w.Events),
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
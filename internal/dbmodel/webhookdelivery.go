@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// The states of a webhook delivery.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// A WebhookDelivery records the delivery of a lifecycle event to one of
+// the webhook endpoints configured for JIMM.
+type WebhookDelivery struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Event is the name of the event delivered.
+	Event string
+
+	// URL is the address the event was posted to.
+	URL string
+
+	// Payload holds the JSON encoded event.
+	Payload []byte
+
+	// Status is the state of the delivery, one of "pending",
+	// "delivered" or "failed".
+	Status string
+
+	// Error holds the reason the delivery failed, if it did.
+	Error string
+
+	// DeliveredAt holds the time at which the event was accepted by the
+	// endpoint.
+	DeliveredAt sql.NullTime
+}
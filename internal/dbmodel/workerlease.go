@@ -0,0 +1,20 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// A WorkerLease records which JIMM replica is currently running a
+// background worker. A replica may only run the worker while it holds an
+// unexpired lease.
+type WorkerLease struct {
+	// Name is the name of the worker.
+	Name string `gorm:"primaryKey"`
+
+	// Holder identifies the replica holding the lease.
+	Holder string
+
+	// ExpiresAt holds the time at which the lease expires unless it is
+	// renewed by the holder.
+	ExpiresAt time.Time
+}
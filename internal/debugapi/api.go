@@ -4,6 +4,7 @@ package debugapi
 import (
 	"context"
 	"net/http"
+	"net/http/pprof"
 	"sync"
 	"time"
 
@@ -19,6 +20,10 @@ import (
 type DebugHandler struct {
 	Router       *chi.Mux
 	StatusChecks map[string]StatusCheck
+
+	// Profiling enables the net/http/pprof endpoints under /pprof. These
+	// should only be enabled when the handler is not publicly reachable.
+	Profiling bool
 }
 
 // NewDebugHandler returns a new debug handler
@@ -31,6 +36,13 @@ func (dh *DebugHandler) Routes() chi.Router {
 	dh.SetupMiddleware()
 	dh.Router.Get("/info", dh.Info)
 	dh.Router.Get("/status", dh.Status)
+	if dh.Profiling {
+		dh.Router.HandleFunc("/pprof/cmdline", pprof.Cmdline)
+		dh.Router.HandleFunc("/pprof/profile", pprof.Profile)
+		dh.Router.HandleFunc("/pprof/symbol", pprof.Symbol)
+		dh.Router.HandleFunc("/pprof/trace", pprof.Trace)
+		dh.Router.HandleFunc("/pprof/*", pprof.Index)
+	}
 	return dh.Router
 }
 
@@ -17,7 +17,6 @@ import (
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
-	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
@@ -33,7 +32,9 @@ type MacaroonDischargerConfig struct {
 	ControllerUUID         string
 }
 
-func NewMacaroonDischarger(cfg MacaroonDischargerConfig, db *db.Database, ofgaClient *openfga.OFGAClient) (*MacaroonDischarger, error) {
+// NewMacaroonDischarger returns a MacaroonDischarger that stores its
+// macaroon root keys in the given store.
+func NewMacaroonDischarger(cfg MacaroonDischargerConfig, rootKeys dbrootkeystore.Backing, ofgaClient *openfga.OFGAClient) (*MacaroonDischarger, error) {
 	var kp bakery.KeyPair
 	if cfg.PublicKey == "" || cfg.PrivateKey == "" {
 		return nil, errors.E("missing bakery private/public key")
@@ -51,7 +52,7 @@ func NewMacaroonDischarger(cfg MacaroonDischargerConfig, db *db.Database, ofgaCl
 		bakery.BakeryParams{
 			Checker: checker,
 			RootKeyStore: dbrootkeystore.NewRootKeys(100, nil).NewStore(
-				db,
+				rootKeys,
 				dbrootkeystore.Policy{
 					ExpiryDuration: cfg.MacaroonExpiryDuration,
 				},
@@ -127,6 +127,10 @@ const (
 	CodeFailedToResolveTupleResource Code = "failed resolve resource"
 	CodeOpenFGARequestFailed         Code = "failed request to OpenFGA"
 	CodeJWKSRetrievalFailed          Code = "jwks retrieval failure"
+	CodeQuotaLimitExceeded           Code = jujuparams.CodeQuotaLimitExceeded
+	CodePolicyViolation              Code = apiparams.CodePolicyViolation
+	CodeCredentialInvalid            Code = apiparams.CodeCredentialInvalid
+	CodeTooManyRequests              Code = apiparams.CodeTooManyRequests
 )
 
 // ErrorCode returns the error code from the given error.
@@ -0,0 +1,11 @@
+// Copyright 2024 Canonical.
+
+//go:build boringcrypto
+
+package fips
+
+// Importing fipsonly restricts all crypto/tls configurations to FIPS
+// approved settings.
+import _ "crypto/tls/fipsonly"
+
+const buildEnabled = true
@@ -0,0 +1,7 @@
+// Copyright 2024 Canonical.
+
+//go:build !boringcrypto
+
+package fips
+
+const buildEnabled = false
@@ -0,0 +1,5 @@
+// Copyright 2024 Canonical.
+
+package fips
+
+var ClientsRestricted = &clientsRestricted
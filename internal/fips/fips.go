@@ -0,0 +1,107 @@
+// Copyright 2024 Canonical.
+
+// Package fips contains the checks used when JIMM runs in FIPS mode, in
+// which only FIPS 140 approved cryptographic algorithms may be used.
+//
+// FIPS mode may be requested at runtime, or enabled at build time by
+// building with GOEXPERIMENT=boringcrypto, in which case it is always on.
+//
+// When FIPS mode is requested at runtime JIMM's own TLS listeners are
+// configured with the approved algorithms, and RestrictClients restricts
+// the connections JIMM makes to controllers, webhooks, the identity
+// provider and Vault.
+package fips
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// Enabled reports whether FIPS mode should be used given the runtime
+// setting requested. FIPS mode is always enabled in builds using a
+// validated cryptographic module.
+func Enabled(requested bool) bool {
+	return requested || buildEnabled
+}
+
+// CipherSuites holds the TLS 1.2 cipher suites that use only FIPS
+// approved algorithms. TLS 1.3 suites are not configurable in
+// crypto/tls.
+var CipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// CurvePreferences holds the FIPS approved elliptic curves for use in
+// TLS key exchange.
+var CurvePreferences = []tls.CurveID{
+	tls.CurveP256,
+	tls.CurveP384,
+	tls.CurveP521,
+}
+
+// CheckTLS returns an error if the given minimum TLS version or any of
+// the given cipher suites are not FIPS approved.
+func CheckTLS(minVersion uint16, cipherSuites []uint16) error {
+	const op = errors.Op("fips.CheckTLS")
+	if minVersion < tls.VersionTLS12 {
+		return errors.E(op, errors.CodeServerConfiguration, "FIPS mode requires a minimum TLS version of 1.2")
+	}
+	for _, id := range cipherSuites {
+		if !approvedCipherSuite(id) {
+			return errors.E(op, errors.CodeServerConfiguration, fmt.Sprintf("cipher suite %s is not allowed in FIPS mode", tls.CipherSuiteName(id)))
+		}
+	}
+	return nil
+}
+
+func approvedCipherSuite(id uint16) bool {
+	for _, approved := range CipherSuites {
+		if id == approved {
+			return true
+		}
+	}
+	return false
+}
+
+// clientsRestricted records whether RestrictClients has been called.
+var clientsRestricted atomic.Bool
+
+// RestrictClients restricts the TLS connections made by JIMM to FIPS
+// approved algorithms. It changes http.DefaultTransport, which is used by
+// HTTP clients without a transport of their own, so it must be called
+// before any connections are made. Clients that configure their own TLS
+// settings must use ClientTLSConfig.
+func RestrictClients() {
+	clientsRestricted.Store(true)
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.TLSClientConfig = ClientTLSConfig(t.TLSClientConfig)
+	}
+}
+
+// ClientTLSConfig returns the given TLS client configuration restricted
+// to FIPS approved algorithms if RestrictClients has been called,
+// otherwise the configuration is returned unchanged. A nil configuration
+// is treated as the default configuration.
+func ClientTLSConfig(c *tls.Config) *tls.Config {
+	if !clientsRestricted.Load() {
+		return c
+	}
+	if c == nil {
+		c = new(tls.Config)
+	} else {
+		c = c.Clone()
+	}
+	if c.MinVersion < tls.VersionTLS12 {
+		c.MinVersion = tls.VersionTLS12
+	}
+	c.CipherSuites = CipherSuites
+	c.CurvePreferences = CurvePreferences
+	return c
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package fips_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/fips"
+)
+
+func TestCheckTLS(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(fips.CheckTLS(tls.VersionTLS12, nil), qt.IsNil)
+	c.Check(fips.CheckTLS(tls.VersionTLS13, fips.CipherSuites), qt.IsNil)
+
+	err := fips.CheckTLS(tls.VersionTLS11, nil)
+	c.Check(err, qt.ErrorMatches, "FIPS mode requires a minimum TLS version of 1.2")
+
+	err = fips.CheckTLS(tls.VersionTLS12, []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256})
+	c.Check(err, qt.ErrorMatches, "cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not allowed in FIPS mode")
+}
+
+func TestEnabled(t *testing.T) {
+	c := qt.New(t)
+	c.Check(fips.Enabled(true), qt.IsTrue)
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	c := qt.New(t)
+
+	config := &tls.Config{ServerName: "controller"}
+	c.Check(fips.ClientTLSConfig(config), qt.Equals, config)
+	c.Check(fips.ClientTLSConfig(nil), qt.IsNil)
+
+	transport := http.DefaultTransport.(*http.Transport)
+	defaultConfig := transport.TLSClientConfig
+	c.Cleanup(func() {
+		transport.TLSClientConfig = defaultConfig
+		fips.ClientsRestricted.Store(false)
+	})
+	fips.RestrictClients()
+
+	restricted := fips.ClientTLSConfig(config)
+	c.Check(restricted.ServerName, qt.Equals, "controller")
+	c.Check(restricted.MinVersion, qt.Equals, uint16(tls.VersionTLS12))
+	c.Check(restricted.CipherSuites, qt.DeepEquals, fips.CipherSuites)
+	c.Check(restricted.CurvePreferences, qt.DeepEquals, fips.CurvePreferences)
+	c.Check(config.CipherSuites, qt.IsNil)
+
+	c.Check(fips.ClientTLSConfig(nil).CipherSuites, qt.DeepEquals, fips.CipherSuites)
+	c.Check(transport.TLSClientConfig.CipherSuites, qt.DeepEquals, fips.CipherSuites)
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 Canonical.
+
+// Package jemapi serves a subset of the legacy JEM REST API using JIMM,
+// so that existing clients keep working while they move to the JIMM API.
+package jemapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/middleware"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// legacyDomain is the domain of the users known to JEM, which is not
+// included in legacy user names.
+const legacyDomain = "@external"
+
+// legacyControllerOwner is the owner used in legacy controller paths.
+const legacyControllerOwner = "admin"
+
+// JIMM holds the JIMM methods used by the legacy API.
+type JIMM interface {
+	middleware.JIMMAuthner
+	ForEachUserModel(ctx context.Context, user *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
+	ListControllers(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
+}
+
+// LegacyHandler serves the legacy JEM endpoints to list models, get a
+// model and list controllers. Clients authenticate using basic
+// authentication with a JIMM session token as the password.
+// Implements jimmhttp.JIMMHttpHandler.
+type LegacyHandler struct {
+	Router *chi.Mux
+	JIMM   JIMM
+}
+
+// NewLegacyHandler returns a new LegacyHandler.
+func NewLegacyHandler(j JIMM) *LegacyHandler {
+	return &LegacyHandler{Router: chi.NewRouter(), JIMM: j}
+}
+
+// Routes returns the grouped routers routes with group specific middlewares.
+func (h *LegacyHandler) Routes() chi.Router {
+	h.SetupMiddleware()
+	h.Router.Get("/model", h.ListModels)
+	h.Router.Get("/model/{user}/{name}", h.GetModel)
+	h.Router.Get("/controller", h.ListControllers)
+	return h.Router
+}
+
+// SetupMiddleware applies authentication and response middlewares.
+func (h *LegacyHandler) SetupMiddleware() {
+	h.Router.Use(
+		func(next http.Handler) http.Handler {
+			return middleware.AuthenticateWithSessionTokenViaBasicAuth(next, h.JIMM)
+		},
+		render.SetContentType(render.ContentTypeJSON),
+	)
+}
+
+// ModelResponse holds the details of a model in the legacy format.
+type ModelResponse struct {
+	Path           string     `json:"path"`
+	UUID           string     `json:"uuid"`
+	ControllerUUID string     `json:"controller-uuid"`
+	ControllerPath string     `json:"controller-path"`
+	Creator        string     `json:"creator,omitempty"`
+	CreateTime     *time.Time `json:"create-time,omitempty"`
+	Life           string     `json:"life,omitempty"`
+	Cloud          string     `json:"cloud,omitempty"`
+	Region         string     `json:"region,omitempty"`
+	Credential     string     `json:"credential,omitempty"`
+}
+
+// ListModelsResponse holds the response of a list models request.
+type ListModelsResponse struct {
+	Models []ModelResponse `json:"models"`
+}
+
+// ControllerResponse holds the details of a controller in the legacy
+// format.
+type ControllerResponse struct {
+	Path             string            `json:"path"`
+	Public           bool              `json:"public"`
+	UnavailableSince *time.Time        `json:"unavailable-since,omitempty"`
+	Location         map[string]string `json:"location,omitempty"`
+	Version          string            `json:"version,omitempty"`
+}
+
+// ListControllersResponse holds the response of a list controllers
+// request.
+type ListControllersResponse struct {
+	Controllers []ControllerResponse `json:"controllers"`
+}
+
+// Error is an error in the legacy format.
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// ListModels handles /model, returning the models the user can access.
+func (h *LegacyHandler) ListModels(w http.ResponseWriter, r *http.Request) {
+	const op = errors.Op("jemapi.ListModels")
+	ctx := r.Context()
+
+	user, err := middleware.IdentityFromContext(ctx)
+	if err != nil {
+		writeError(ctx, w, r, errors.E(op, err))
+		return
+	}
+	resp := ListModelsResponse{Models: []ModelResponse{}}
+	err = h.JIMM.ForEachUserModel(ctx, user, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+		resp.Models = append(resp.Models, modelResponse(m))
+		return nil
+	})
+	if err != nil {
+		writeError(ctx, w, r, errors.E(op, err))
+		return
+	}
+	render.JSON(w, r, resp)
+}
+
+// GetModel handles /model/{user}/{name}, returning the model with the
+// given owner and name if the user can access it.
+func (h *LegacyHandler) GetModel(w http.ResponseWriter, r *http.Request) {
+	const op = errors.Op("jemapi.GetModel")
+	ctx := r.Context()
+
+	user, err := middleware.IdentityFromContext(ctx)
+	if err != nil {
+		writeError(ctx, w, r, errors.E(op, err))
+		return
+	}
+	owner := modernUser(chi.URLParam(r, "user"))
+	name := chi.URLParam(r, "name")
+
+	errFound := errors.E("found")
+	var resp ModelResponse
+	err = h.JIMM.ForEachUserModel(ctx, user, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+		if m.OwnerIdentityName != owner || m.Name != name {
+			return nil
+		}
+		resp = modelResponse(m)
+		return errFound
+	})
+	switch err {
+	case errFound:
+		render.JSON(w, r, resp)
+	case nil:
+		writeError(ctx, w, r, errors.E(op, errors.CodeNotFound, "model "+chi.URLParam(r, "user")+"/"+name+" not found"))
+	default:
+		writeError(ctx, w, r, errors.E(op, err))
+	}
+}
+
+// ListControllers handles /controller, returning the controllers known
+// to JIMM. Only JIMM administrators may list controllers.
+func (h *LegacyHandler) ListControllers(w http.ResponseWriter, r *http.Request) {
+	const op = errors.Op("jemapi.ListControllers")
+	ctx := r.Context()
+
+	user, err := middleware.IdentityFromContext(ctx)
+	if err != nil {
+		writeError(ctx, w, r, errors.E(op, err))
+		return
+	}
+	controllers, err := h.JIMM.ListControllers(ctx, user)
+	if err != nil {
+		writeError(ctx, w, r, errors.E(op, err))
+		return
+	}
+	resp := ListControllersResponse{Controllers: []ControllerResponse{}}
+	for _, ctl := range controllers {
+		cr := ControllerResponse{
+			Path:    legacyControllerOwner + "/" + ctl.Name,
+			Public:  !ctl.Deprecated,
+			Version: ctl.AgentVersion,
+		}
+		if ctl.UnavailableSince.Valid {
+			t := ctl.UnavailableSince.Time
+			cr.UnavailableSince = &t
+		}
+		if ctl.CloudName != "" {
+			cr.Location = map[string]string{"cloud": ctl.CloudName}
+			if ctl.CloudRegion != "" {
+				cr.Location["region"] = ctl.CloudRegion
+			}
+		}
+		resp.Controllers = append(resp.Controllers, cr)
+	}
+	render.JSON(w, r, resp)
+}
+
+// modelResponse converts the model to the legacy format.
+func modelResponse(m *dbmodel.Model) ModelResponse {
+	resp := ModelResponse{
+		Path:           legacyUser(m.OwnerIdentityName) + "/" + m.Name,
+		UUID:           m.UUID.String,
+		ControllerUUID: m.Controller.UUID,
+		ControllerPath: legacyControllerOwner + "/" + m.Controller.Name,
+		Creator:        legacyUser(m.OwnerIdentityName),
+		Life:           m.Life,
+		Cloud:          m.CloudRegion.Cloud.Name,
+		Region:         m.CloudRegion.Name,
+	}
+	if !m.CreatedAt.IsZero() {
+		t := m.CreatedAt
+		resp.CreateTime = &t
+	}
+	if m.CloudCredential.Name != "" {
+		resp.Credential = m.CloudCredential.CloudName + "/" + legacyUser(m.CloudCredential.OwnerIdentityName) + "/" + m.CloudCredential.Name
+	}
+	return resp
+}
+
+// legacyUser returns the legacy name of the given user.
+func legacyUser(name string) string {
+	return strings.TrimSuffix(name, legacyDomain)
+}
+
+// modernUser returns the JIMM name of the given legacy user.
+func modernUser(name string) string {
+	if strings.Contains(name, "@") {
+		return name
+	}
+	return name + legacyDomain
+}
+
+// writeError writes the error in the legacy format with a status
+// appropriate to its code.
+func writeError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	code := errors.ErrorCode(err)
+	switch code {
+	case errors.CodeUnauthorized:
+		// The user has been authenticated, but is not allowed to
+		// make the request.
+		status = http.StatusForbidden
+	case errors.CodeNotFound:
+		status = http.StatusNotFound
+	case errors.CodeBadRequest:
+		status = http.StatusBadRequest
+	default:
+		zapctx.Error(ctx, "legacy API request failed", zap.Error(err))
+	}
+	w.WriteHeader(status)
+	render.JSON(w, r, Error{Message: err.Error(), Code: string(code)})
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 Canonical.
+
+package jemapi_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jemapi"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// testJIMM is a JIMM holding fixed models and controllers. It accepts
+// the session token "alice-token" for alice and "admin-token" for an
+// administrator.
+type testJIMM struct {
+	models      []dbmodel.Model
+	controllers []dbmodel.Controller
+}
+
+func (j *testJIMM) AuthenticateBrowserSession(ctx context.Context, _ http.ResponseWriter, _ *http.Request) (context.Context, error) {
+	return ctx, errors.E(errors.CodeNotImplemented)
+}
+
+func (j *testJIMM) LoginWithSessionToken(_ context.Context, token string) (*openfga.User, error) {
+	switch token {
+	case "alice-token":
+		return openfga.NewUser(&dbmodel.Identity{Name: "alice@external"}, nil), nil
+	case "admin-token":
+		u := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, nil)
+		u.JimmAdmin = true
+		return u, nil
+	}
+	return nil, errors.E(errors.CodeUnauthorized)
+}
+
+func (j *testJIMM) UserLogin(context.Context, string) (*openfga.User, error) {
+	return nil, errors.E(errors.CodeNotImplemented)
+}
+
+func (j *testJIMM) ForEachUserModel(_ context.Context, user *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+	for i := range j.models {
+		if !user.JimmAdmin && j.models[i].OwnerIdentityName != user.Name {
+			continue
+		}
+		if err := f(&j.models[i], jujuparams.ModelAdminAccess); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *testJIMM) ListControllers(_ context.Context, user *openfga.User) ([]dbmodel.Controller, error) {
+	if !user.JimmAdmin {
+		return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	return j.controllers, nil
+}
+
+func newTestJIMM() *testJIMM {
+	ctl := dbmodel.Controller{
+		Name:         "ctl-1",
+		UUID:         "00000001-0000-0000-0000-000000000000",
+		CloudName:    "aws",
+		CloudRegion:  "eu-west-1",
+		AgentVersion: "3.5.0",
+	}
+	model := func(owner, name, uuid string) dbmodel.Model {
+		return dbmodel.Model{
+			Name:              name,
+			UUID:              sql.NullString{String: uuid, Valid: true},
+			OwnerIdentityName: owner,
+			Controller:        ctl,
+			Life:              "alive",
+			CloudRegion: dbmodel.CloudRegion{
+				Name:  "eu-west-1",
+				Cloud: dbmodel.Cloud{Name: "aws"},
+			},
+			CloudCredential: dbmodel.CloudCredential{
+				CloudName:         "aws",
+				OwnerIdentityName: owner,
+				Name:              "cred",
+			},
+		}
+	}
+	return &testJIMM{
+		models: []dbmodel.Model{
+			model("alice@external", "model-1", "00000002-0000-0000-0000-000000000000"),
+			model("bob@canonical.com", "model-2", "00000003-0000-0000-0000-000000000000"),
+		},
+		controllers: []dbmodel.Controller{ctl},
+	}
+}
+
+func serve(c *qt.C, token, path string, v any) int {
+	h := jemapi.NewLegacyHandler(newTestJIMM()).Routes()
+	req := httptest.NewRequest("GET", path, nil)
+	if token != "" {
+		req.SetBasicAuth("", token)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if v != nil && rr.Code == http.StatusOK {
+		c.Assert(json.Unmarshal(rr.Body.Bytes(), v), qt.IsNil)
+	}
+	return rr.Code
+}
+
+func TestListModels(t *testing.T) {
+	c := qt.New(t)
+
+	var resp jemapi.ListModelsResponse
+	c.Assert(serve(c, "alice-token", "/model", &resp), qt.Equals, http.StatusOK)
+	c.Check(resp, qt.DeepEquals, jemapi.ListModelsResponse{
+		Models: []jemapi.ModelResponse{{
+			Path:           "alice/model-1",
+			UUID:           "00000002-0000-0000-0000-000000000000",
+			ControllerUUID: "00000001-0000-0000-0000-000000000000",
+			ControllerPath: "admin/ctl-1",
+			Creator:        "alice",
+			Life:           "alive",
+			Cloud:          "aws",
+			Region:         "eu-west-1",
+			Credential:     "aws/alice/cred",
+		}},
+	})
+
+	c.Check(serve(c, "", "/model", nil), qt.Equals, http.StatusUnauthorized)
+	c.Check(serve(c, "bad-token", "/model", nil), qt.Equals, http.StatusUnauthorized)
+}
+
+func TestGetModel(t *testing.T) {
+	c := qt.New(t)
+
+	var resp jemapi.ModelResponse
+	c.Assert(serve(c, "alice-token", "/model/alice/model-1", &resp), qt.Equals, http.StatusOK)
+	c.Check(resp.UUID, qt.Equals, "00000002-0000-0000-0000-000000000000")
+
+	// Models the user cannot access are not found.
+	c.Check(serve(c, "alice-token", "/model/bob@canonical.com/model-2", nil), qt.Equals, http.StatusNotFound)
+
+	c.Assert(serve(c, "admin-token", "/model/bob@canonical.com/model-2", &resp), qt.Equals, http.StatusOK)
+	c.Check(resp.Path, qt.Equals, "bob@canonical.com/model-2")
+}
+
+func TestListControllers(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(serve(c, "alice-token", "/controller", nil), qt.Equals, http.StatusForbidden)
+
+	var resp jemapi.ListControllersResponse
+	c.Assert(serve(c, "admin-token", "/controller", &resp), qt.Equals, http.StatusOK)
+	c.Check(resp, qt.DeepEquals, jemapi.ListControllersResponse{
+		Controllers: []jemapi.ControllerResponse{{
+			Path:     "admin/ctl-1",
+			Public:   true,
+			Location: map[string]string{"cloud": "aws", "region": "eu-west-1"},
+			Version:  "3.5.0",
+		}},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
 
@@ -196,8 +198,10 @@ func (auth *JWTGenerator) SetTags(mt names.ModelTag, ct names.ControllerTag) {
 	auth.ct = ct
 }
 
-// SetTags implements TokenGenerator
+// GetUser implements TokenGenerator
 func (auth *JWTGenerator) GetUser() names.UserTag {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
 	if auth.user != nil {
 		return auth.user.ResourceTag()
 	}
@@ -308,8 +312,10 @@ func (auth *JWTGenerator) MakeToken(ctx context.Context, permissionMap map[strin
 // to cachedPerms if they exist. If the user does not have any of the desired permissions then an
 // error is returned.
 // Note that cachedPerms map is modified and returned.
-func (j *JIMM) CheckPermission(ctx context.Context, user *openfga.User, cachedPerms map[string]string, desiredPerms map[string]interface{}) (map[string]string, error) {
+func (j *JIMM) CheckPermission(ctx context.Context, user *openfga.User, cachedPerms map[string]string, desiredPerms map[string]interface{}) (_ map[string]string, err error) {
 	const op = errors.Op("jimm.CheckPermission")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	for key, val := range desiredPerms {
 		if _, ok := cachedPerms[key]; !ok {
 			stringVal, ok := val.(string)
@@ -338,8 +344,10 @@ func (j *JIMM) CheckPermission(ctx context.Context, user *openfga.User, cachedPe
 }
 
 // GrantAuditLogAccess grants audit log access for the target user.
-func (j *JIMM) GrantAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error {
+func (j *JIMM) GrantAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) (err error) {
 	const op = errors.Op("jimm.GrantAuditLogAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	access := user.GetControllerAccess(ctx, j.ResourceTag())
 	if access != ofganames.AdministratorRelation {
@@ -348,7 +356,7 @@ func (j *JIMM) GrantAuditLogAccess(ctx context.Context, user *openfga.User, targ
 
 	targetUser := &dbmodel.Identity{}
 	targetUser.SetTag(targetUserTag)
-	err := j.Database.GetIdentity(ctx, targetUser)
+	err = j.Database.GetIdentity(ctx, targetUser)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -361,8 +369,10 @@ func (j *JIMM) GrantAuditLogAccess(ctx context.Context, user *openfga.User, targ
 }
 
 // RevokeAuditLogAccess revokes audit log access for the target user.
-func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error {
+func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) (err error) {
 	const op = errors.Op("jimm.RevokeAuditLogAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	access := user.GetControllerAccess(ctx, j.ResourceTag())
 	if access != ofganames.AdministratorRelation {
@@ -371,7 +381,7 @@ func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, tar
 
 	targetUser := &dbmodel.Identity{}
 	targetUser.SetTag(targetUserTag)
-	err := j.Database.GetIdentity(ctx, targetUser)
+	err = j.Database.GetIdentity(ctx, targetUser)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -383,6 +393,98 @@ func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, tar
 	return nil
 }
 
+// controllerAccessLevels holds the JIMM controller access levels that may
+// be granted or revoked, in increasing order.
+var controllerAccessLevels = []string{"login", "add-model", "superuser"}
+
+// checkControllerAccessLevel checks that the given access level is a
+// valid JIMM controller access level.
+func checkControllerAccessLevel(access string) error {
+	if !slices.Contains(controllerAccessLevels, access) {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid controller access level %q", access))
+	}
+	return nil
+}
+
+// GrantControllerAccess grants the given JIMM controller access level to
+// each of the given users. Only JIMM administrators may grant controller
+// access. The returned slice holds the error, if any, from granting
+// access to the user at the same index.
+//
+// Every identity known to JIMM may log in and add-model access is
+// granted per cloud, so granting "login" or "add-model" only ensures that
+// the identity exists. Granting "superuser" makes the user a JIMM
+// administrator.
+func (j *JIMM) GrantControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) (_ []error, err error) {
+	const op = errors.Op("jimm.GrantControllerAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := checkControllerAccessLevel(access); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	errs := make([]error, len(users))
+	for i, ut := range users {
+		targetUser := &dbmodel.Identity{}
+		targetUser.SetTag(ut)
+		if err := j.Database.GetIdentity(ctx, targetUser); err != nil {
+			errs[i] = errors.E(op, err)
+			continue
+		}
+		if access != "superuser" {
+			continue
+		}
+		err := openfga.NewUser(targetUser, j.OpenFGAClient).SetControllerAccess(ctx, j.ResourceTag(), ofganames.AdministratorRelation)
+		if err != nil {
+			errs[i] = errors.E(op, err)
+		}
+	}
+	return errs, nil
+}
+
+// RevokeControllerAccess revokes the given JIMM controller access level,
+// and any level above it, from each of the given users. Only JIMM
+// administrators may revoke controller access. The returned slice holds
+// the error, if any, from revoking access from the user at the same
+// index.
+//
+// As in juju, revoking a level also revokes the levels above it, so
+// revoking any level removes the user's administrator relation with
+// JIMM. Identities cannot be prevented from logging in to JIMM, so a user
+// whose "login" access is revoked may still log in with no further
+// access.
+func (j *JIMM) RevokeControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) (_ []error, err error) {
+	const op = errors.Op("jimm.RevokeControllerAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := checkControllerAccessLevel(access); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	errs := make([]error, len(users))
+	for i, ut := range users {
+		targetUser := &dbmodel.Identity{}
+		targetUser.SetTag(ut)
+		if err := j.Database.FetchIdentity(ctx, targetUser); err != nil {
+			errs[i] = errors.E(op, err)
+			continue
+		}
+		err := openfga.NewUser(targetUser, j.OpenFGAClient).UnsetControllerAccess(ctx, j.ResourceTag(), ofganames.AdministratorRelation)
+		if err != nil {
+			errs[i] = errors.E(op, err)
+		}
+	}
+	return errs, nil
+}
+
 // ToJAASTag converts a tag used in OpenFGA authorization model to a
 // tag used in JAAS.
 func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error) {
@@ -689,8 +791,10 @@ func (j *JIMM) parseAndValidateTag(ctx context.Context, key string) (*ofganames.
 }
 
 // AddGroup creates a group within JIMMs DB for reference by OpenFGA.
-func (j *JIMM) AddGroup(ctx context.Context, user *openfga.User, name string) (*dbmodel.GroupEntry, error) {
+func (j *JIMM) AddGroup(ctx context.Context, user *openfga.User, name string) (_ *dbmodel.GroupEntry, err error) {
 	const op = errors.Op("jimm.AddGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -704,8 +808,10 @@ func (j *JIMM) AddGroup(ctx context.Context, user *openfga.User, name string) (*
 }
 
 // CountGroups returns the number of groups that exist.
-func (j *JIMM) CountGroups(ctx context.Context, user *openfga.User) (int, error) {
+func (j *JIMM) CountGroups(ctx context.Context, user *openfga.User) (_ int, err error) {
 	const op = errors.Op("jimm.CountGroups")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -741,8 +847,10 @@ func (j *JIMM) GetGroupByName(ctx context.Context, user *openfga.User, name stri
 }
 
 // RenameGroup renames a group in JIMM's DB.
-func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, newName string) error {
+func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, newName string) (err error) {
 	const op = errors.Op("jimm.RenameGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -751,7 +859,7 @@ func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, new
 	group := &dbmodel.GroupEntry{
 		Name: oldName,
 	}
-	err := j.Database.GetGroup(ctx, group)
+	err = j.Database.GetGroup(ctx, group)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -764,8 +872,10 @@ func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, new
 }
 
 // RemoveGroup removes a group within JIMMs DB for reference by OpenFGA.
-func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string) error {
+func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string) (err error) {
 	const op = errors.Op("jimm.RemoveGroup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -774,7 +884,7 @@ func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string)
 	group := &dbmodel.GroupEntry{
 		Name: name,
 	}
-	err := j.Database.GetGroup(ctx, group)
+	err = j.Database.GetGroup(ctx, group)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -790,15 +900,17 @@ func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string)
 }
 
 // ListGroups returns a list of groups known to JIMM.
-func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.GroupEntry, error) {
+func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) (_ []dbmodel.GroupEntry, err error) {
 	const op = errors.Op("jimm.ListGroups")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
 	var groups []dbmodel.GroupEntry
-	err := j.Database.ForEachGroup(ctx, filter.Limit(), filter.Offset(), func(ge *dbmodel.GroupEntry) error {
+	err = j.Database.ForEachGroup(ctx, filter.Limit(), filter.Offset(), func(ge *dbmodel.GroupEntry) error {
 		groups = append(groups, *ge)
 		return nil
 	})
@@ -10,12 +10,15 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	"github.com/canonical/jimm/v3/pkg/names"
 )
 
 // LoginDevice starts the device login flow.
-func (j *JIMM) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+func (j *JIMM) LoginDevice(ctx context.Context) (_ *oauth2.DeviceAuthResponse, err error) {
 	const op = errors.Op("jimm.LoginDevice")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	resp, err := j.OAuthAuthenticator.Device(ctx)
 	if err != nil {
 		return nil, errors.E(op, err)
@@ -29,9 +32,15 @@ func (j *JIMM) AuthenticateBrowserSession(ctx context.Context, w http.ResponseWr
 }
 
 // GetDeviceSessionToken polls an OIDC server while a user logs in and returns a session token scoped to the user's identity.
-func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
+func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (_ string, err error) {
 	const op = errors.Op("jimm.GetDeviceSessionToken")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
+	// Failures before the identity is known come from the identity
+	// provider, which throttles them itself, and are not counted: keying
+	// them on the client address alone would lock out every user behind
+	// the same proxy.
 	token, err := j.OAuthAuthenticator.DeviceAccessToken(ctx, deviceOAuthResponse)
 	if err != nil {
 		return "", errors.E(op, err)
@@ -46,6 +55,10 @@ func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *o
 	if err != nil {
 		return "", errors.E(op, err)
 	}
+	if err := j.checkLoginThrottle(ctx, email); err != nil {
+		return "", errors.E(op, err)
+	}
+	j.recordLoginResult(ctx, "GetDeviceSessionToken", email, nil)
 
 	if err := j.OAuthAuthenticator.UpdateIdentity(ctx, email, token); err != nil {
 		return "", errors.E(op, err)
@@ -55,13 +68,18 @@ func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *o
 	if err != nil {
 		return "", errors.E(op, err)
 	}
+	if err := j.recordDeviceSession(ctx, email, encToken); err != nil {
+		return "", errors.E(op, err)
+	}
 
 	return string(encToken), nil
 }
 
 // LoginClientCredentials verifies a user's client ID and secret before the user is logged in.
-func (j *JIMM) LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error) {
+func (j *JIMM) LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (_ *openfga.User, err error) {
 	const op = errors.Op("jimm.LoginClientCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	// We expect the client to send the service account ID "as-is" and because we know that this is a clientCredentials login,
 	// we can append the @serviceaccount domain to the clientID (if not already present).
 	clientIdWithDomain, err := names.EnsureValidServiceAccountId(clientID)
@@ -69,23 +87,47 @@ func (j *JIMM) LoginClientCredentials(ctx context.Context, clientID string, clie
 		return nil, errors.E(op, err)
 	}
 
+	if err := j.checkLoginThrottle(ctx, clientIdWithDomain); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	err = j.OAuthAuthenticator.VerifyClientCredentials(ctx, clientID, clientSecret)
+	j.recordLoginResult(ctx, "LoginWithClientCredentials", clientIdWithDomain, err)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
+	if err := j.checkServiceAccountLogin(ctx, clientIdWithDomain); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	return j.UserLogin(ctx, clientIdWithDomain)
 }
 
 // LoginWithSessionToken verifies a user's session token before the user is logged in.
-func (j *JIMM) LoginWithSessionToken(ctx context.Context, sessionToken string) (*openfga.User, error) {
+func (j *JIMM) LoginWithSessionToken(ctx context.Context, sessionToken string) (_ *openfga.User, err error) {
 	const op = errors.Op("jimm.LoginWithSessionToken")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	// Session tokens are signed by JIMM and cannot be guessed, so
+	// expired or invalid tokens are not counted as failed logins. A
+	// lockout of the identity the token claims is still enforced.
+	if claimed := sessionTokenSubject(sessionToken); claimed != "" {
+		if err := j.checkLoginThrottle(ctx, claimed); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
 	jwtToken, err := j.OAuthAuthenticator.VerifySessionToken(sessionToken)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
+	if err := j.checkDeviceSession(ctx, sessionToken); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	email := jwtToken.Subject()
+	j.recordLoginResult(ctx, "LoginWithSessionToken", email, nil)
 	return j.UserLogin(ctx, email)
 }
 
@@ -95,8 +137,10 @@ func (j *JIMM) LoginWithSessionToken(ctx context.Context, sessionToken string) (
 // [WSHandler.ServerHTTP] during the upgrade from an HTTP connection to a websocket. The user's identity is stored
 // and passed to this function with the assumption that the cookie contained a valid session. This function is far from
 // the session cookie logic due to the separation between the HTTP layer and Juju's RPC mechanism.
-func (j *JIMM) LoginWithSessionCookie(ctx context.Context, identityID string) (*openfga.User, error) {
+func (j *JIMM) LoginWithSessionCookie(ctx context.Context, identityID string) (_ *openfga.User, err error) {
 	const op = errors.Op("jimm.LoginWithSessionCookie")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if identityID == "" {
 		return nil, errors.E(op, "missing cookie identity")
 	}
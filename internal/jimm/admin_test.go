@@ -13,9 +13,14 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"golang.org/x/oauth2"
 
+	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
 
 func TestLoginDevice(t *testing.T) {
@@ -40,10 +45,17 @@ func TestGetDeviceSessionToken(t *testing.T) {
 	pollingChan := make(chan string, 1)
 	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, pollingChan)
 	jimm := jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
 		OAuthAuthenticator: &mockAuthenticator,
 	}
+	ctx := context.Background()
+	err := jimm.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
 	pollingChan <- "user-foo"
-	token, err := jimm.GetDeviceSessionToken(context.Background(), nil)
+	token, err := jimm.GetDeviceSessionToken(ctx, nil)
 	c.Assert(err, qt.IsNil)
 	c.Assert(token, qt.Not(qt.Equals), "")
 	decodedToken, err := base64.StdEncoding.DecodeString(token)
@@ -51,6 +63,57 @@ func TestGetDeviceSessionToken(t *testing.T) {
 	parsedToken, err := jwt.ParseInsecure([]byte(decodedToken))
 	c.Assert(err, qt.IsNil)
 	c.Assert(parsedToken.Subject(), qt.Equals, "user-foo@canonical.com")
+
+	sessions, err := jimm.Database.ListSessions(ctx, "user-foo@canonical.com", time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 1)
+	c.Check(sessions[0].Kind, qt.Equals, dbmodel.DeviceSession)
+	c.Check(sessions[0].ExpiresAt.Unix(), qt.Equals, parsedToken.Expiration().Unix())
+}
+
+func TestLoginWithRevokedSessionToken(t *testing.T) {
+	c := qt.New(t)
+	pollingChan := make(chan string, 1)
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, pollingChan)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OAuthAuthenticator: &mockAuthenticator,
+		OpenFGAClient:      client,
+	}
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	pollingChan <- "alice"
+	token, err := j.GetDeviceSessionToken(ctx, nil)
+	c.Assert(err, qt.IsNil)
+
+	user, err := j.LoginWithSessionToken(ctx, token)
+	c.Assert(err, qt.IsNil)
+	c.Check(user.Name, qt.Equals, "alice@canonical.com")
+
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, client)
+	_, err = j.RevokeSessions(ctx, bob, "alice@canonical.com")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, client)
+	admin.JimmAdmin = true
+	revoked, err := j.RevokeSessions(ctx, admin, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(revoked, qt.Equals, 1)
+
+	_, err = j.LoginWithSessionToken(ctx, token)
+	c.Check(err, qt.ErrorMatches, `JIMM session revoked`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+
+	sessions, err := j.ListSessions(ctx, admin, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(sessions, qt.HasLen, 0)
 }
 
 func TestLoginClientCredentials(t *testing.T) {
@@ -79,6 +142,68 @@ func TestLoginClientCredentials(t *testing.T) {
 	c.Assert(user.Name, qt.Equals, "my-svc-acc@serviceaccount")
 }
 
+func TestLoginClientCredentialsAllowedCIDRs(t *testing.T) {
+	c := qt.New(t)
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OAuthAuthenticator: &mockAuthenticator,
+		OpenFGAClient:      client,
+	}
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	admin.JimmAdmin = true
+	svcAccTag := jimmnames.NewServiceAccountTag("my-svc-acc@serviceaccount")
+
+	err = j.SetServiceAccountAllowedCIDRs(ctx, admin, svcAccTag, []string{"not-a-cidr"})
+	c.Assert(err, qt.ErrorMatches, `invalid CIDR "not-a-cidr"`)
+
+	nonAdmin := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, client)
+	err = j.SetServiceAccountAllowedCIDRs(ctx, nonAdmin, svcAccTag, []string{"10.0.0.0/8"})
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	// Restrictions cannot be set on service accounts that do not exist.
+	err = j.SetServiceAccountAllowedCIDRs(ctx, admin, svcAccTag, []string{"10.0.0.0/8"})
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.AddServiceAccount(ctx, admin, svcAccTag.Id(), "")
+	c.Assert(err, qt.IsNil)
+	err = j.SetServiceAccountAllowedCIDRs(ctx, admin, svcAccTag, []string{"10.0.0.0/8"})
+	c.Assert(err, qt.IsNil)
+
+	allowedCtx := auth.ContextWithRemoteAddr(ctx, "10.1.2.3:45678")
+	user, err := j.LoginClientCredentials(allowedCtx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+	c.Assert(user.Name, qt.Equals, "my-svc-acc@serviceaccount")
+
+	deniedCtx := auth.ContextWithRemoteAddr(ctx, "192.168.1.1:45678")
+	_, err = j.LoginClientCredentials(deniedCtx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.ErrorMatches, "login from this address is not allowed")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	var logs []dbmodel.AuditLogEntry
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "LoginWithClientCredentials"}, func(ale *dbmodel.AuditLogEntry) error {
+		logs = append(logs, *ale)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 1)
+	c.Check(logs[0].IdentityTag, qt.Equals, "user-my-svc-acc@serviceaccount")
+
+	err = j.SetServiceAccountAllowedCIDRs(ctx, admin, svcAccTag, nil)
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginClientCredentials(deniedCtx, "my-svc-acc", "foo-secret")
+	c.Assert(err, qt.IsNil)
+}
+
 func TestLoginWithSessionToken(t *testing.T) {
 	c := qt.New(t)
 	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
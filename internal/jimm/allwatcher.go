@@ -0,0 +1,267 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// allModelWatcherAccessPeriod is how often an AllModelWatcher refreshes
+// the set of models the user may see.
+var allModelWatcherAccessPeriod = time.Minute
+
+// allModelWatcherBuffer is the maximum number of deltas an
+// AllModelWatcher holds for its client. The Juju AllWatcher protocol has
+// no way to tell a client that deltas were missed, so once the buffer is
+// full the watcher fails, see OverflowDisconnect.
+var allModelWatcherBuffer = 10000
+
+// An AllModelWatcher multiplexes the all-model watcher deltas from a
+// number of controllers into a single stream, filtered to the models a
+// user has access to.
+type AllModelWatcher struct {
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+	buffer   *watchBuffer[jujuparams.Delta]
+	errs     chan error
+
+	mu      sync.RWMutex
+	allowed map[string]bool
+}
+
+// WatchAllModels starts an AllModelWatcher for the given user. Deltas are
+// returned for every model the user can read, on every controller that
+// hosts one of them. If modelUUIDs is not empty the deltas are further
+// restricted to just those models, all of which must be readable by the
+// user. The watcher outlives the given context and continues until it is
+// stopped.
+func (j *JIMM) WatchAllModels(ctx context.Context, user *openfga.User, modelUUIDs []string) (_ *AllModelWatcher, err error) {
+	const op = errors.Op("jimm.WatchAllModels")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	models, err := j.allModelWatcherModels(ctx, user, modelUUIDs)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for _, uuid := range modelUUIDs {
+		if _, ok := models[uuid]; !ok {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	controllers := make(map[uint]dbmodel.Controller)
+	allowed := make(map[string]bool, len(models))
+	for uuid, m := range models {
+		allowed[uuid] = true
+		controllers[m.ControllerID] = m.Controller
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	w := &AllModelWatcher{
+		cancel:  cancel,
+		buffer:  newWatchBuffer[jujuparams.Delta]("all-models", allModelWatcherBuffer, OverflowDisconnect),
+		errs:    make(chan error, len(controllers)),
+		allowed: allowed,
+	}
+	for _, ctl := range controllers {
+		ctl := ctl
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			ctx := zapctx.WithFields(ctx, zap.String("controller", ctl.Name))
+			if err := w.watchController(ctx, j, &ctl); err != nil && ctx.Err() == nil {
+				zapctx.Error(ctx, "all model watcher failed", zap.Error(err))
+				w.errs <- err
+			}
+		}()
+	}
+	// Refresh access as soon as it is changed through JIMM, so that
+	// deltas stop being sent as soon as access is revoked.
+	wake := make(chan struct{}, 1)
+	if j.Pubsub != nil {
+		unsub, err := j.Pubsub.Subscribe(AccessChangedTopic, func(string, interface{}) {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		})
+		if err != nil {
+			cancel()
+			w.wg.Wait()
+			return nil, errors.E(op, err)
+		}
+		go func() {
+			<-ctx.Done()
+			unsub()
+		}()
+	}
+	go w.refreshAccess(ctx, wake, func(ctx context.Context) (map[string]dbmodel.Model, error) {
+		return j.allModelWatcherModels(ctx, user, modelUUIDs)
+	})
+	servermon.WatchersActive.WithLabelValues("all-models").Inc()
+	return w, nil
+}
+
+// allModelWatcherModels returns the models, keyed by UUID, that the user
+// can read. If modelUUIDs is not empty only those models are returned.
+func (j *JIMM) allModelWatcherModels(ctx context.Context, user *openfga.User, modelUUIDs []string) (map[string]dbmodel.Model, error) {
+	var filter map[string]bool
+	if len(modelUUIDs) > 0 {
+		filter = make(map[string]bool, len(modelUUIDs))
+		for _, uuid := range modelUUIDs {
+			filter[uuid] = true
+		}
+	}
+	models := make(map[string]dbmodel.Model)
+	f := func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+		if filter == nil || filter[m.UUID.String] {
+			models[m.UUID.String] = *m
+		}
+		return nil
+	}
+	var err error
+	if user.JimmAdmin {
+		err = j.ForEachModel(ctx, user, f)
+	} else {
+		err = j.ForEachUserModel(ctx, user, f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// watchController runs an all-model watcher on the given controller,
+// forwarding deltas for allowed models until the context is cancelled or
+// the client falls too far behind.
+func (w *AllModelWatcher) watchController(ctx context.Context, j *JIMM, ctl *dbmodel.Controller) error {
+	api, err := j.dialController(ctx, ctl)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	id, err := api.WatchAllModels(ctx)
+	if err != nil {
+		return err
+	}
+	// Stopping the controller watcher causes any blocked call to
+	// AllModelWatcherNext to return.
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+		}
+		if err := api.AllModelWatcherStop(context.Background(), id); err != nil {
+			zapctx.Debug(ctx, "failed to stop all model watcher", zap.Error(err))
+		}
+	}()
+
+	for {
+		deltas, err := api.AllModelWatcherNext(ctx, id)
+		if err != nil {
+			return err
+		}
+		deltas = w.filter(deltas)
+		if len(deltas) == 0 {
+			continue
+		}
+		if !w.buffer.push(deltas...) {
+			zapctx.Warn(ctx, "all model watcher client fell behind, stopping watcher")
+			w.cancel()
+			return nil
+		}
+	}
+}
+
+// filter returns the deltas that belong to allowed models.
+func (w *AllModelWatcher) filter(deltas []jujuparams.Delta) []jujuparams.Delta {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	filtered := deltas[:0]
+	for _, d := range deltas {
+		if w.allowed[d.Entity.EntityId().ModelUUID] {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// refreshAccess periodically, and whenever wake is signalled, updates the
+// set of allowed models so that revoked access takes effect on running
+// watchers.
+func (w *AllModelWatcher) refreshAccess(ctx context.Context, wake <-chan struct{}, f func(context.Context) (map[string]dbmodel.Model, error)) {
+	ticker := time.NewTicker(allModelWatcherAccessPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wake:
+		}
+		models, err := f(ctx)
+		if err != nil {
+			zapctx.Error(ctx, "failed to list user models", zap.Error(err))
+			continue
+		}
+		allowed := make(map[string]bool, len(models))
+		for uuid := range models {
+			allowed[uuid] = true
+		}
+		w.mu.Lock()
+		w.allowed = allowed
+		w.mu.Unlock()
+	}
+}
+
+// Next returns all the deltas received since the previous call, blocking
+// until some are available, the given context is cancelled, or one of the
+// underlying controller watchers fails. If the client has fallen too far
+// behind Next returns an error and the watcher must be restarted.
+func (w *AllModelWatcher) Next(ctx context.Context) ([]jujuparams.Delta, error) {
+	const op = errors.Op("jimm.AllModelWatcher.Next")
+
+	for {
+		deltas, _, err := w.buffer.take()
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if len(deltas) > 0 {
+			return deltas, nil
+		}
+		select {
+		case <-w.buffer.ready:
+		case err := <-w.errs:
+			return nil, errors.E(op, err)
+		case <-ctx.Done():
+			return nil, errors.E(op, ctx.Err())
+		}
+	}
+}
+
+// Stop stops the watcher and waits for the controller watchers to finish.
+func (w *AllModelWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		servermon.WatchersActive.WithLabelValues("all-models").Dec()
+	})
+	w.cancel()
+	w.wg.Wait()
+	return nil
+}
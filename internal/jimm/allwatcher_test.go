@@ -0,0 +1,134 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const watchAllModelsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  users:
+  - user: alice@canonical.com
+    access: admin
+  - user: bob@canonical.com
+    access: read
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  users:
+  - user: alice@canonical.com
+    access: admin
+`
+
+func TestWatchAllModels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var mu sync.Mutex
+	var stopped bool
+	stop := make(chan struct{})
+	sent := false
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				WatchAllModels_: func(context.Context) (string, error) {
+					return "1", nil
+				},
+				AllModelWatcherNext_: func(ctx context.Context, id string) ([]jujuparams.Delta, error) {
+					mu.Lock()
+					first := !sent
+					sent = true
+					mu.Unlock()
+					if first {
+						return []jujuparams.Delta{{
+							Entity: &jujuparams.MachineInfo{ModelUUID: "00000002-0000-0000-0000-000000000001", Id: "0"},
+						}, {
+							Entity: &jujuparams.MachineInfo{ModelUUID: "00000002-0000-0000-0000-000000000002", Id: "0"},
+						}}, nil
+					}
+					<-stop
+					return nil, ctx.Err()
+				},
+				AllModelWatcherStop_: func(context.Context, string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					if !stopped {
+						stopped = true
+						close(stop)
+					}
+					return nil
+				},
+			},
+		},
+	}
+	c.Assert(j.Database.Migrate(ctx, false), qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, watchAllModelsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbUser := env.User("bob@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	_, err = j.WatchAllModels(ctx, user, []string{"00000002-0000-0000-0000-000000000002"})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	w, err := j.WatchAllModels(ctx, user, nil)
+	c.Assert(err, qt.IsNil)
+
+	nextCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	deltas, err := w.Next(nextCtx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deltas, qt.HasLen, 1)
+	c.Check(deltas[0].Entity.EntityId().ModelUUID, qt.Equals, "00000002-0000-0000-0000-000000000001")
+
+	c.Assert(w.Stop(), qt.IsNil)
+	mu.Lock()
+	defer mu.Unlock()
+	c.Check(stopped, qt.IsTrue)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // AddApplicationOfferParams holds parameters for the Offer method.
@@ -35,8 +36,10 @@ type AddApplicationOfferParams struct {
 }
 
 // Offer creates a new application offer.
-func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer AddApplicationOfferParams) error {
+func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer AddApplicationOfferParams) (err error) {
 	const op = errors.Op("jimm.Offer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	model := dbmodel.Model{
 		UUID: sql.NullString{
@@ -179,8 +182,10 @@ func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer AddApplicati
 // GetApplicationOfferConsumeDetails consume the application offer
 // specified by details.ApplicationOfferDetails.OfferURL and completes
 // the rest of the details.
-func (j *JIMM) GetApplicationOfferConsumeDetails(ctx context.Context, user *openfga.User, details *jujuparams.ConsumeOfferDetails, v bakery.Version) error {
+func (j *JIMM) GetApplicationOfferConsumeDetails(ctx context.Context, user *openfga.User, details *jujuparams.ConsumeOfferDetails, v bakery.Version) (err error) {
 	const op = errors.Op("jimm.GetApplicationOfferConsumeDetails")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	offer := dbmodel.ApplicationOffer{
 		URL: details.Offer.OfferURL,
@@ -297,13 +302,15 @@ func (j *JIMM) listApplicationOfferUsers(ctx context.Context, offer names.Applic
 }
 
 // GetApplicationOffer returns details of the offer with the specified URL.
-func (j *JIMM) GetApplicationOffer(ctx context.Context, user *openfga.User, offerURL string) (*jujuparams.ApplicationOfferAdminDetailsV5, error) {
+func (j *JIMM) GetApplicationOffer(ctx context.Context, user *openfga.User, offerURL string) (_ *jujuparams.ApplicationOfferAdminDetailsV5, err error) {
 	const op = errors.Op("jimm.GetApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	offer := dbmodel.ApplicationOffer{
 		URL: offerURL,
 	}
-	err := j.Database.GetApplicationOffer(ctx, &offer)
+	err = j.Database.GetApplicationOffer(ctx, &offer)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			return nil, errors.E(op, err, "application offer not found")
@@ -359,8 +366,10 @@ func (j *JIMM) GetApplicationOffer(ctx context.Context, user *openfga.User, offe
 }
 
 // GrantOfferAccess grants rights for an application offer.
-func (j *JIMM) GrantOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) error {
+func (j *JIMM) GrantOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error) {
 	const op = errors.Op("jimm.GrantOfferAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	identity, err := dbmodel.NewIdentity(ut.Id())
 	if err != nil {
@@ -423,6 +432,8 @@ func determineAccessLevelAfterGrant(currentAccessLevel, grantAccessLevel string)
 // RevokeOfferAccess revokes rights for an application offer.
 func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error) {
 	const op = errors.Op("jimm.RevokeOfferAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	identity, err := dbmodel.NewIdentity(ut.Id())
 	if err != nil {
@@ -475,10 +486,12 @@ func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerU
 }
 
 // DestroyOffer removes the application offer.
-func (j *JIMM) DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) error {
+func (j *JIMM) DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) (err error) {
 	const op = errors.Op("jimm.DestroyOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
-	err := j.doApplicationOfferAdmin(ctx, user, offerURL, func(offer *dbmodel.ApplicationOffer, api API) error {
+	err = j.doApplicationOfferAdmin(ctx, user, offerURL, func(offer *dbmodel.ApplicationOffer, api API) error {
 		if err := api.DestroyApplicationOffer(ctx, offerURL, force); err != nil {
 			return err
 		}
@@ -506,14 +519,16 @@ func (j *JIMM) DestroyOffer(ctx context.Context, user *openfga.User, offerURL st
 
 // UpdateApplicationOffer fetches offer details from the controller and updates the
 // application offer in JIMM DB.
-func (j *JIMM) UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error {
+func (j *JIMM) UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) (err error) {
 	const op = errors.Op("jimm.UpdateApplicationOffer")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	offer := dbmodel.ApplicationOffer{
 		UUID: offerUUID,
 	}
 
-	err := j.Database.GetApplicationOffer(ctx, &offer)
+	err = j.Database.GetApplicationOffer(ctx, &offer)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			return errors.E(op, err, "application offer not found")
@@ -592,8 +607,10 @@ func (j *JIMM) getUserOfferAccess(ctx context.Context, user *openfga.User, offer
 }
 
 // FindApplicationOffers returns details of offers matching the specified filter.
-func (j *JIMM) FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
+func (j *JIMM) FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) (_ []jujuparams.ApplicationOfferAdminDetailsV5, err error) {
 	const op = errors.Op("jimm.FindApplicationOffers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if len(filters) == 0 {
 		return nil, errors.E(op, errors.CodeBadRequest, "at least one filter must be specified")
@@ -646,6 +663,12 @@ func (j *JIMM) applicationOfferFilters(ctx context.Context, jujuFilters ...jujup
 				db.ApplicationOfferFilterByModel(f.ModelName),
 			)
 		}
+		if f.OwnerName != "" {
+			filters = append(
+				filters,
+				db.ApplicationOfferFilterByOwner(f.OwnerName),
+			)
+		}
 		if f.ApplicationName != "" {
 			filters = append(
 				filters,
@@ -700,8 +723,10 @@ func (j *JIMM) applicationOfferFilters(ctx context.Context, jujuFilters ...jujup
 }
 
 // ListApplicationOffers returns details of offers matching the specified filter.
-func (j *JIMM) ListApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
+func (j *JIMM) ListApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) (_ []jujuparams.ApplicationOfferAdminDetailsV5, err error) {
 	const op = errors.Op("jimm.ListApplicationOffers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	type modelKey struct {
 		name          string
@@ -127,10 +127,10 @@ func (o recorder) HandleReply(r rpc.Request, header *rpc.Header, body interface{
 }
 
 // AuditLogCleanupService is a service capable of cleaning up audit logs
-// on a defined retention period. The retention period is in DAYS.
+// on a defined retention period.
 type auditLogCleanupService struct {
-	auditLogRetentionPeriodInDays int
-	db                            db.Database
+	retentionPeriod time.Duration
+	db              db.Database
 }
 
 // pollTimeOfDay holds the time hour, minutes and seconds to poll at.
@@ -145,11 +145,11 @@ var pollDuration = pollTimeOfDay{
 }
 
 // NewAuditLogCleanupService returns a service capable of cleaning up audit logs
-// on a defined retention period. The retention period is in DAYS.
-func NewAuditLogCleanupService(db db.Database, auditLogRetentionPeriodInDays int) *auditLogCleanupService {
+// older than the given retention period.
+func NewAuditLogCleanupService(db db.Database, retentionPeriod time.Duration) *auditLogCleanupService {
 	return &auditLogCleanupService{
-		auditLogRetentionPeriodInDays: auditLogRetentionPeriodInDays,
-		db:                            db,
+		retentionPeriod: retentionPeriod,
+		db:              db,
 	}
 }
 
@@ -167,7 +167,7 @@ func (a *auditLogCleanupService) poll(ctx context.Context) {
 	for {
 		select {
 		case <-time.After(calculateNextPollDuration(time.Now().UTC())):
-			retentionDate := time.Now().AddDate(0, 0, -(a.auditLogRetentionPeriodInDays))
+			retentionDate := time.Now().Add(-a.retentionPeriod)
 			deleted, err := a.db.DeleteAuditLogsBefore(ctx, retentionDate)
 			if err != nil {
 				zapctx.Error(ctx, "failed to cleanup audit logs", zap.Error(err))
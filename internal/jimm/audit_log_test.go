@@ -58,7 +58,7 @@ func TestAuditLogCleanupServicePurgesLogs(t *testing.T) {
 	jimm.PollDuration.Hours = now.Hour()
 	jimm.PollDuration.Minutes = now.Minute()
 	jimm.PollDuration.Seconds = now.Second() + 2
-	svc := jimm.NewAuditLogCleanupService(db, 1)
+	svc := jimm.NewAuditLogCleanupService(db, 24*time.Hour)
 	svc.Start(ctx)
 
 	// Check 2 were purged
@@ -0,0 +1,185 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AuditEventTopic is the pub-sub topic on which every audit log entry
+// added by JIMM is published.
+const AuditEventTopic = "jimm-audit-event"
+
+// auditEventWatcherBuffer is the maximum number of entries an
+// AuditEventWatcher holds for its client. Once it is full the oldest
+// entries are discarded, see OverflowDropOldest.
+const auditEventWatcherBuffer = 1000
+
+// An AuditEventWatcher returns audit log entries as they are added.
+type AuditEventWatcher struct {
+	checkAccess func(context.Context) error
+	filter      db.AuditLogFilter
+	unsub       func()
+	started     atomic.Bool
+
+	buffer *watchBuffer[dbmodel.AuditLogEntry]
+	done   chan struct{}
+	once   sync.Once
+
+	mu sync.Mutex
+	// unacked holds the entries returned by the last call to Next,
+	// which are returned again if the watcher is resumed before Next
+	// is next called.
+	unacked []dbmodel.AuditLogEntry
+	resumed bool
+}
+
+// WatchAuditEvents starts an AuditEventWatcher that returns audit log
+// entries matching the given filter as they are added. Only the identity,
+// model and method fields of the filter are used. The user must be able
+// to view the audit log.
+func (j *JIMM) WatchAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) (_ *AuditEventWatcher, err error) {
+	const op = errors.Op("jimm.WatchAuditEvents")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	checkAccess := func(ctx context.Context) error {
+		if user.GetAuditLogViewerAccess(ctx, j.ResourceTag()) != ofganames.AuditLogViewerRelation {
+			return errors.E(errors.CodeUnauthorized, "unauthorized")
+		}
+		return nil
+	}
+	if err := checkAccess(ctx); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if j.Pubsub == nil {
+		return nil, errors.E(op, errors.CodeNotSupported, "audit event watching not available")
+	}
+
+	w := &AuditEventWatcher{
+		checkAccess: checkAccess,
+		filter:      filter,
+		buffer:      newWatchBuffer[dbmodel.AuditLogEntry]("audit-events", auditEventWatcherBuffer, OverflowDropOldest),
+		done:        make(chan struct{}),
+	}
+	unsubEvents, err := j.Pubsub.Subscribe(AuditEventTopic, w.handle)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// Wake any blocked call to Next when access changes so that a
+	// watcher whose user can no longer view the audit log is failed
+	// promptly.
+	unsubAccess, err := j.Pubsub.Subscribe(AccessChangedTopic, func(string, interface{}) {
+		w.buffer.wake()
+	})
+	if err != nil {
+		unsubEvents()
+		return nil, errors.E(op, err)
+	}
+	w.unsub = func() {
+		unsubEvents()
+		unsubAccess()
+	}
+	// The hub replays the most recent entry to new subscribers, which
+	// happens before Subscribe returns. Only entries added after the
+	// watcher started are wanted.
+	w.started.Store(true)
+	servermon.WatchersActive.WithLabelValues("audit-events").Inc()
+	return w, nil
+}
+
+func (w *AuditEventWatcher) handle(_ string, content interface{}) {
+	if !w.started.Load() {
+		return
+	}
+	entry, ok := content.(dbmodel.AuditLogEntry)
+	if !ok || !w.match(&entry) {
+		return
+	}
+	w.buffer.push(entry)
+}
+
+func (w *AuditEventWatcher) match(entry *dbmodel.AuditLogEntry) bool {
+	if w.filter.IdentityTag != "" && entry.IdentityTag != w.filter.IdentityTag {
+		return false
+	}
+	if w.filter.Model != "" && entry.Model != w.filter.Model {
+		return false
+	}
+	if w.filter.ModelUUID != "" && entry.ModelUUID != w.filter.ModelUUID {
+		return false
+	}
+	if w.filter.Method != "" && entry.FacadeMethod != w.filter.Method {
+		return false
+	}
+	return true
+}
+
+// Next returns the audit log entries added since the previous call,
+// blocking until there is at least one. If the watcher fell behind and
+// entries were discarded the number discarded is also returned, in which
+// case the client should query the audit log to resynchronise. The
+// user's access to the audit log is checked again on every call.
+func (w *AuditEventWatcher) Next(ctx context.Context) ([]dbmodel.AuditLogEntry, int, error) {
+	const op = errors.Op("jimm.AuditEventWatcher.Next")
+
+	w.mu.Lock()
+	if w.resumed {
+		w.buffer.requeue(w.unacked)
+		w.resumed = false
+	}
+	w.unacked = nil
+	w.mu.Unlock()
+
+	for {
+		if err := w.checkAccess(ctx); err != nil {
+			return nil, 0, errors.E(op, err)
+		}
+		entries, dropped, err := w.buffer.take()
+		if err != nil {
+			return nil, 0, errors.E(op, err)
+		}
+		if len(entries) > 0 || dropped > 0 {
+			w.mu.Lock()
+			w.unacked = entries
+			w.mu.Unlock()
+			return entries, dropped, nil
+		}
+		select {
+		case <-w.buffer.ready:
+		case <-w.done:
+			return nil, 0, errors.E(op, "watcher stopped")
+		case <-ctx.Done():
+			return nil, 0, errors.E(op, ctx.Err())
+		}
+	}
+}
+
+// Resume causes the next call to Next to return the entries returned by
+// the previous call again, ahead of any new entries. It is used when a
+// client reconnects, as it may not have received the previous entries.
+func (w *AuditEventWatcher) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.resumed = true
+}
+
+// Stop stops the watcher.
+func (w *AuditEventWatcher) Stop() error {
+	w.once.Do(func() {
+		w.unsub()
+		close(w.done)
+		servermon.WatchersActive.WithLabelValues("audit-events").Dec()
+	})
+	return nil
+}
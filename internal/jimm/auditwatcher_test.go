@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/pubsub"
+)
+
+func TestWatchAuditEvents(t *testing.T) {
+	c := qt.New(t)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+		Pubsub:        &pubsub.Hub{MaxConcurrency: 10},
+	}
+
+	ctx := context.Background()
+
+	err = j.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	admin := openfga.NewUser(alice, client)
+	err = admin.SetControllerAccess(ctx, j.ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	eve, err := dbmodel.NewIdentity("eve@canonical.com")
+	c.Assert(err, qt.IsNil)
+	unprivileged := openfga.NewUser(eve, client)
+
+	// An entry added before the watcher starts is not returned.
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC(),
+		IdentityTag:  admin.Identity.Tag().String(),
+		FacadeMethod: "Deploy",
+	})
+
+	_, err = j.WatchAuditEvents(ctx, unprivileged, db.AuditLogFilter{})
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	w, err := j.WatchAuditEvents(ctx, admin, db.AuditLogFilter{Method: "Deploy"})
+	c.Assert(err, qt.IsNil)
+	defer w.Stop()
+
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC(),
+		IdentityTag:  admin.Identity.Tag().String(),
+		FacadeMethod: "AddModel",
+	})
+	entry := dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC(),
+		IdentityTag:  admin.Identity.Tag().String(),
+		Model:        "TestModel",
+		FacadeMethod: "Deploy",
+	}
+	j.AddAuditLogEntry(&entry)
+
+	nextCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	entries, dropped, err := w.Next(nextCtx)
+	c.Assert(err, qt.IsNil)
+	c.Check(dropped, qt.Equals, 0)
+	c.Assert(entries, qt.HasLen, 1)
+	c.Check(entries[0].ID, qt.Equals, entry.ID)
+	c.Check(entries[0].FacadeMethod, qt.Equals, "Deploy")
+
+	c.Assert(w.Stop(), qt.IsNil)
+	_, _, err = w.Next(nextCtx)
+	c.Assert(err, qt.ErrorMatches, "watcher stopped")
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// A Backup holds a snapshot of JIMM's state, suitable for restoring into
+// a fresh deployment.
+type Backup struct {
+	// Time holds the time the backup was taken.
+	Time time.Time
+
+	// Version holds the version of the database schema the tables were
+	// dumped from.
+	Version dbmodel.Version
+
+	// Tables holds the contents of the database tables.
+	Tables []db.TableDump
+
+	// Tuples holds the relation tuples stored in OpenFGA.
+	Tuples []openfga.Tuple
+}
+
+// Backup returns a snapshot of JIMM's database and the relation tuples
+// stored in OpenFGA. The database tables are read in a single
+// transaction, the tuples are read afterwards, so JIMM should be put in
+// maintenance mode while a backup is taken to ensure the two are
+// consistent. Sessions, macaroon root keys, the audit log and OAuth
+// tokens are not included, nor are any secrets held in Vault. Only JIMM
+// administrators may take a backup.
+func (j *JIMM) Backup(ctx context.Context, user *openfga.User) (_ *Backup, err error) {
+	const op = errors.Op("jimm.Backup")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	b := Backup{
+		Time: time.Now().UTC().Round(time.Millisecond),
+	}
+	b.Version, b.Tables, err = j.Database.Dump(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	b.Tuples, err = j.readAllTuples(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	tables := make(map[string]int, len(b.Tables))
+	for _, t := range b.Tables {
+		tables[t.Name] = len(t.Rows)
+	}
+	params, _ := json.Marshal(map[string]any{
+		"tables": tables,
+		"tuples": len(b.Tuples),
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         b.Time,
+		FacadeName:   "JIMM",
+		FacadeMethod: "Backup",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return &b, nil
+}
+
+// Restore replaces the contents of JIMM's database with the tables in
+// the given backup and adds any of the backed up relation tuples that
+// are not already in OpenFGA. A backup may only be restored into a
+// fresh deployment that has no controllers, and only into a database
+// with the same schema version as the one the backup was taken from. The
+// deployment's audit log is kept and the restore is recorded in it. Only JIMM
+// administrators may restore a backup.
+func (j *JIMM) Restore(ctx context.Context, user *openfga.User, b *Backup) (err error) {
+	const op = errors.Op("jimm.Restore")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var controllers int
+	err = j.Database.ForEachController(ctx, func(*dbmodel.Controller) error {
+		controllers++
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if controllers > 0 {
+		return errors.E(op, errors.CodeBadRequest, "cannot restore into a deployment that has controllers")
+	}
+
+	if err := j.Database.Load(ctx, b.Version, b.Tables); err != nil {
+		return errors.E(op, err)
+	}
+
+	existing, err := j.readAllTuples(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	present := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		present[tupleKey(t)] = true
+	}
+	var missing []openfga.Tuple
+	for _, t := range b.Tuples {
+		if present[tupleKey(t)] {
+			continue
+		}
+		present[tupleKey(t)] = true
+		if t.Object.Kind == openfga.UserType && t.Object.ID == ofganames.EveryoneUser {
+			// Tuples are read with the everyone user in place of the
+			// wildcard, which must be restored before writing.
+			t.Object = ofganames.ConvertTagWithRelation(names.NewUserTag(ofganames.EveryoneUser), t.Object.Relation)
+		}
+		missing = append(missing, t)
+	}
+	for _, ts := range chunkTuples(missing, reconcilePageSize) {
+		if err := j.OpenFGAClient.AddRelation(ctx, ts...); err != nil {
+			return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+	}
+
+	j.invalidateCaches(ctx, MaintenanceModeCache, ControllerVersionCache)
+	j.notifyAccessChanged(AccessChange{})
+
+	tables := make(map[string]int, len(b.Tables))
+	for _, t := range b.Tables {
+		tables[t.Name] = len(t.Rows)
+	}
+	params, _ := json.Marshal(map[string]any{
+		"backup-time": b.Time,
+		"tables":      tables,
+		"tuples":      len(missing),
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "Restore",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return nil
+}
+
+// readAllTuples reads every relation tuple stored in OpenFGA.
+func (j *JIMM) readAllTuples(ctx context.Context) ([]openfga.Tuple, error) {
+	var all []openfga.Tuple
+	var ct string
+	for {
+		tuples, next, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{}, reconcilePageSize, ct)
+		if err != nil {
+			return nil, errors.E(errors.CodeOpenFGARequestFailed, err)
+		}
+		all = append(all, tuples...)
+		if next == "" {
+			return all, nil
+		}
+		ct = next
+	}
+}
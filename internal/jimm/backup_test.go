@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+func TestBackupRestore(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, controller, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	tuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}
+	err = ofgaClient.AddRelation(ctx, tuple)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&user, ofgaClient)
+	_, err = j.Backup(ctx, u)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	b, err := j.Backup(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(b.Version.Major, qt.Equals, dbmodel.Major)
+	c.Check(b.Version.Minor, qt.Equals, dbmodel.Minor)
+	c.Check(b.Tuples, qt.Contains, tuple)
+	tables := make(map[string]int)
+	for i, t := range b.Tables {
+		tables[t.Name] = i
+	}
+	c.Check(tables["controllers"] < tables["models"], qt.IsTrue)
+	c.Check(tables["users"] < tables["models"], qt.IsTrue)
+
+	// A backup cannot be restored over an existing deployment.
+	err = j.Restore(ctx, u, b)
+	c.Check(err, qt.ErrorMatches, `cannot restore into a deployment that has controllers`)
+
+	c.Run("restore into a fresh deployment", func(c *qt.C) {
+		ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+		c.Assert(err, qt.IsNil)
+		j2 := &jimm.JIMM{
+			UUID: j.UUID,
+			Database: db.Database{
+				DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+			},
+			OpenFGAClient: ofgaClient,
+		}
+		err = j2.Database.Migrate(ctx, false)
+		c.Assert(err, qt.IsNil)
+
+		u := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+		u.JimmAdmin = true
+		err = j2.Restore(ctx, u, b)
+		c.Assert(err, qt.IsNil)
+
+		ctl := dbmodel.Controller{Name: controller.Name}
+		err = j2.Database.GetController(ctx, &ctl)
+		c.Assert(err, qt.IsNil)
+		c.Check(ctl.UUID, qt.Equals, controller.UUID)
+
+		m := dbmodel.Model{UUID: model.UUID}
+		err = j2.Database.GetModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+		c.Check(m.Name, qt.Equals, model.Name)
+
+		ok, err := ofgaClient.CheckRelation(ctx, tuple, false)
+		c.Assert(err, qt.IsNil)
+		c.Check(ok, qt.IsTrue)
+
+		// New rows are given IDs following the restored rows.
+		ctl2 := dbmodel.Controller{
+			Name:      "controller-2",
+			UUID:      uuid.NewString(),
+			CloudName: controller.CloudName,
+		}
+		err = j2.Database.AddController(ctx, &ctl2)
+		c.Assert(err, qt.IsNil)
+		c.Check(ctl2.ID > ctl.ID, qt.IsTrue)
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+)
+
+// Names of the caches that may be invalidated through a
+// CacheInvalidationBus.
+const (
+	// MaintenanceModeCache is the cache of the maintenance mode.
+	MaintenanceModeCache = "maintenance-mode"
+
+	// ControllerVersionCache is the cache of the earliest controller
+	// version.
+	ControllerVersionCache = "controller-version"
+
+	// SessionKeysCache is the set of keys used for session tokens.
+	SessionKeysCache = "session-keys"
+)
+
+// A CacheInvalidationBus tells every JIMM replica, including this one,
+// that a cache must be invalidated. Each replica is expected to call
+// InvalidateLocalCache with the name of the cache on receipt.
+type CacheInvalidationBus interface {
+	// Notify sends a notification that the named cache is no longer
+	// valid.
+	Notify(ctx context.Context, cache string) error
+}
+
+// InvalidateLocalCache discards this replica's copy of the named cache so
+// that it is read from the database when next used. If cache is empty
+// all caches are discarded. Unknown cache names are ignored so that
+// replicas running different versions can share a bus.
+func (j *JIMM) InvalidateLocalCache(cache string) {
+	if cache == "" || cache == MaintenanceModeCache {
+		j.maintenance.mu.Lock()
+		j.maintenance.expires = time.Time{}
+		j.maintenance.mu.Unlock()
+	}
+	if cache == "" || cache == ControllerVersionCache {
+		j.InvalidateEarliestControllerVersion()
+	}
+	if cache == "" || cache == SessionKeysCache {
+		j.reloadSessionKeys()
+	}
+}
+
+// invalidateCaches discards the named caches on this replica and, if
+// there is a CacheInvalidationBus, on every other replica.
+func (j *JIMM) invalidateCaches(ctx context.Context, caches ...string) {
+	for _, cache := range caches {
+		j.InvalidateLocalCache(cache)
+		j.notifyCacheInvalidation(ctx, cache)
+	}
+}
+
+// notifyCacheInvalidation tells the other replicas, if there is a
+// CacheInvalidationBus, that the named cache is no longer valid. A
+// failure is logged, the other replicas will see the change when their
+// cached copy expires.
+func (j *JIMM) notifyCacheInvalidation(ctx context.Context, cache string) {
+	if j.CacheInvalidationBus == nil {
+		return
+	}
+	if err := j.CacheInvalidationBus.Notify(ctx, cache); err != nil {
+		zapctx.Warn(ctx, "failed to notify cache invalidation", zap.String("cache", cache), zap.Error(err))
+	}
+}
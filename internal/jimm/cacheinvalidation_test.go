@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// testCacheInvalidationBus delivers notifications synchronously to a
+// set of JIMM replicas.
+type testCacheInvalidationBus struct {
+	replicas []*jimm.JIMM
+	notified []string
+}
+
+func (b *testCacheInvalidationBus) Notify(_ context.Context, cache string) error {
+	b.notified = append(b.notified, cache)
+	for _, j := range b.replicas {
+		j.InvalidateLocalCache(cache)
+	}
+	return nil
+}
+
+func TestMaintenanceModeCacheInvalidation(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	bus := new(testCacheInvalidationBus)
+	j1 := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient:        ofgaClient,
+		CacheInvalidationBus: bus,
+	}
+	err = j1.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	j2 := &jimm.JIMM{
+		Database:             j1.Database,
+		CacheInvalidationBus: bus,
+	}
+	bus.replicas = []*jimm.JIMM{j1, j2}
+
+	// Populate the cache on the second replica.
+	mm, err := j2.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+
+	u := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, ofgaClient)
+	u.JimmAdmin = true
+	err = j1.SetMaintenanceMode(ctx, u, true, "upgrading")
+	c.Assert(err, qt.IsNil)
+	c.Check(bus.notified, qt.DeepEquals, []string{jimm.MaintenanceModeCache})
+
+	// The second replica sees the change without waiting for its cache
+	// to expire.
+	mm, err = j2.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsTrue)
+	c.Check(mm.Message, qt.Equals, "upgrading")
+
+	// Without a notification the second replica keeps its cached copy.
+	mm.Enabled = false
+	err = j1.Database.SetMaintenanceMode(ctx, mm)
+	c.Assert(err, qt.IsNil)
+	mm, err = j2.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsTrue)
+
+	// An empty cache name invalidates every cache.
+	j2.InvalidateLocalCache("")
+	mm, err = j2.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // GetUserCloudAccess returns users access level for the specified cloud.
@@ -32,8 +33,10 @@ func (j *JIMM) GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud
 // error with a code of CodeUnauthorized is returned. If the user only has
 // add-model access to the cloud then the returned Users field will only
 // contain the authentcated user.
-func (j *JIMM) GetCloud(ctx context.Context, user *openfga.User, tag names.CloudTag) (dbmodel.Cloud, error) {
+func (j *JIMM) GetCloud(ctx context.Context, user *openfga.User, tag names.CloudTag) (_ dbmodel.Cloud, err error) {
 	const op = errors.Op("jimm.GetCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var cl dbmodel.Cloud
 	cl.SetTag(tag)
@@ -65,8 +68,10 @@ func (j *JIMM) GetCloud(ctx context.Context, user *openfga.User, tag names.Cloud
 // true then f will be called with all clouds known to JIMM. If f returns
 // an error then iteration will stop immediately and the error will be
 // returned unchanged. The given function should not update the database.
-func (j *JIMM) ForEachUserCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error {
+func (j *JIMM) ForEachUserCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) (err error) {
 	const op = errors.Op("jimm.ForEachUserCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	clouds, err := j.Database.GetClouds(ctx)
 	if err != nil {
@@ -92,8 +97,10 @@ func (j *JIMM) ForEachUserCloud(ctx context.Context, user *openfga.User, f func(
 // error is returned unmodified. If the given user is not a controller
 // superuser then an error with the code CodeUnauthorized is returned. The
 // given function should not update the database.
-func (j *JIMM) ForEachCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error {
+func (j *JIMM) ForEachCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) (err error) {
 	const op = errors.Op("jimm.ForEachCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -144,8 +151,10 @@ var DefaultReservedCloudNames = []string{
 // created on this JAAS system an error with a code of CodeIncompatibleClouds
 // will be returned. If there is an error returned by the controller when
 // creating the cloud then that error code will be preserved.
-func (j *JIMM) AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error {
+func (j *JIMM) AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) (err error) {
 	const op = errors.Op("jimm.AddCloudToController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	controller, err := j.getControllerByName(ctx, controllerName)
 	if err != nil {
@@ -187,8 +196,10 @@ func (j *JIMM) AddCloudToController(ctx context.Context, user *openfga.User, con
 // code of CodeIncompatibleClouds will be returned. If there is an error
 // returned by the controller when creating the cloud then that error code
 // will be preserved.
-func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error {
+func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) (err error) {
 	const op = errors.Op("jimm.AddHostedCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	// NOTE (alesstimec) The default JIMM access right for every user is
 	// "login". Previously the code checked:
@@ -238,6 +249,10 @@ func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names
 		return errors.E(op, errors.CodeIncompatibleClouds, fmt.Sprintf("cloud already hosted %q", cloud.HostCloudRegion))
 	}
 
+	if len(region.Controllers) == 0 {
+		return errors.E(op, errors.CodeIncompatibleClouds, fmt.Sprintf("no controllers available for %q", cloud.HostCloudRegion))
+	}
+
 	// Create the cloud locally, to reserve the name.
 	var dbCloud dbmodel.Cloud
 	dbCloud.FromJujuCloud(cloud)
@@ -247,8 +262,14 @@ func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names
 	}
 
 	// Create the cloud on a host.
-	shuffleRegionControllers(region.Controllers)
-	controller := region.Controllers[0].Controller
+	selected, err := j.selectController(ctx, ControllerSelectionRequest{
+		Owner: user.ResourceTag(),
+		Cloud: region.Cloud.ResourceTag(),
+	}, region.Controllers)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	controller := selected.Controller
 
 	ccloud, err := j.addControllerCloud(ctx, &controller, user.ResourceTag(), tag, cloud, force)
 	if err != nil {
@@ -386,8 +407,10 @@ func (j *JIMM) doCloudAdmin(ctx context.Context, user *openfga.User, ct names.Cl
 // CodeNotFound is returned. If the authenticated user does not have admin
 // access to the cloud then an error with the code CodeUnauthorized is
 // returned.
-func (j *JIMM) GrantCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error {
+func (j *JIMM) GrantCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) (err error) {
 	const op = errors.Op("jimm.GrantCloudAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	targetRelation, err := ToCloudRelation(access)
 	if err != nil {
@@ -451,8 +474,10 @@ func (j *JIMM) GrantCloudAccess(ctx context.Context, user *openfga.User, ct name
 // CodeNotFound is returned. If the authenticated user does not have admin
 // access to the cloud then an error with the code CodeUnauthorized is
 // returned.
-func (j *JIMM) RevokeCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error {
+func (j *JIMM) RevokeCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) (err error) {
 	const op = errors.Op("jimm.RevokeCloudAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	targetRelation, err := ToCloudRelation(access)
 	if err != nil {
@@ -526,10 +551,12 @@ func (j *JIMM) RevokeCloudAccess(ctx context.Context, user *openfga.User, ct nam
 // authenticated user does not have admin access to the cloud then an error
 // with the code CodeUnauthorized is returned. If the RemoveClouds API call
 // returns an error the error code is not masked.
-func (j *JIMM) RemoveCloud(ctx context.Context, user *openfga.User, ct names.CloudTag) error {
+func (j *JIMM) RemoveCloud(ctx context.Context, user *openfga.User, ct names.CloudTag) (err error) {
 	const op = errors.Op("jimm.RemoveCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
-	err := j.doCloudAdmin(ctx, user, ct, func(c *dbmodel.Cloud, api API) error {
+	err = j.doCloudAdmin(ctx, user, ct, func(c *dbmodel.Cloud, api API) error {
 		// Note: JIMM doesn't attempt to determine if the cloud is
 		// used by any models before attempting to remove it. JIMM
 		// relies on the controller failing the RemoveClouds API
@@ -557,9 +584,13 @@ func (j *JIMM) RemoveCloud(ctx context.Context, user *openfga.User, ct names.Clo
 // that host the cloud. If the given user is not a controller superuser or
 // an admin on the cloud an error is returned with a code of
 // CodeUnauthorized. If the cloud with the given name cannot be found then
-// an error with the code CodeNotFound is returned.
-func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error {
+// an error with the code CodeNotFound is returned. Regions missing from
+// the new definition are removed, if any of them still hosts models an
+// error with the code CodeBadRequest is returned and nothing is changed.
+func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) (err error) {
 	const op = errors.Op("jimm.UpdateCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var c dbmodel.Cloud
 	c.SetTag(ct)
@@ -576,6 +607,12 @@ func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.Clo
 		// an unauthorized error.
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
+	if len(cloud.Regions) == 0 {
+		return errors.E(op, errors.CodeBadRequest, "cloud must have at least one region")
+	}
+	if err := checkCloudRegionsUnused(ctx, &j.Database, removedCloudRegions(c, cloud)); err != nil {
+		return errors.E(op, err)
+	}
 
 	var controllers []dbmodel.Controller
 	seen := make(map[uint]bool)
@@ -606,6 +643,18 @@ func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.Clo
 		if err := db.GetCloud(ctx, &c); err != nil {
 			return err
 		}
+		// A model may have been added to a removed region since it
+		// was checked, so the regions are checked again before they
+		// are deleted.
+		removed := removedCloudRegions(c, cloud)
+		if err := checkCloudRegionsUnused(ctx, db, removed); err != nil {
+			return err
+		}
+		for _, r := range removed {
+			if err := db.DeleteCloudRegion(ctx, &r); err != nil {
+				return err
+			}
+		}
 		c.FromJujuCloud(cloud)
 		for i := range c.Regions {
 			if len(c.Regions[i].Controllers) == 0 {
@@ -626,13 +675,53 @@ func (j *JIMM) UpdateCloud(ctx context.Context, user *openfga.User, ct names.Clo
 	return nil
 }
 
+// checkCloudRegionsUnused returns an error with the code CodeBadRequest
+// if any of the given regions hosts a model.
+func checkCloudRegionsUnused(ctx context.Context, d *db.Database, regions []dbmodel.CloudRegion) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(regions))
+	for i, r := range regions {
+		ids[i] = r.ID
+	}
+	counts, err := d.CountModelsByCloudRegions(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, r := range regions {
+		if n := counts[r.ID]; n > 0 {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("cannot remove region %q: region hosts %d model(s)", r.Name, n))
+		}
+	}
+	return nil
+}
+
+// removedCloudRegions returns the regions of the given cloud that are not
+// present in the given updated cloud definition.
+func removedCloudRegions(c dbmodel.Cloud, cloud jujuparams.Cloud) []dbmodel.CloudRegion {
+	keep := make(map[string]bool, len(cloud.Regions))
+	for _, r := range cloud.Regions {
+		keep[r.Name] = true
+	}
+	var removed []dbmodel.CloudRegion
+	for _, r := range c.Regions {
+		if !keep[r.Name] {
+			removed = append(removed, r)
+		}
+	}
+	return removed
+}
+
 // RemoveCloudFromController removes the given cloud from the JAAS controller.
 // If the cloud or the controller are not found then an error with the code
 // CodeNotFound is returned. If the authenticated user does not have admin
 // access to the cloud then an error with the code CodeUnauthorized is returned.
 // If the RemoveClouds API call returns an error the error code is not masked.
-func (j *JIMM) RemoveCloudFromController(ctx context.Context, user *openfga.User, controllerName string, ct names.CloudTag) error {
+func (j *JIMM) RemoveCloudFromController(ctx context.Context, user *openfga.User, controllerName string, ct names.CloudTag) (err error) {
 	const op = errors.Op("jimm.RemoveCloudFromController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var cloud dbmodel.Cloud
 	cloud.SetTag(ct)
@@ -806,3 +895,38 @@ func (j *JIMM) addCloudToDatabase(ctx context.Context, controller *dbmodel.Contr
 
 	return dbCloud, nil
 }
+
+// SetCloudRegionDisabled records whether the given region of a cloud is
+// disabled. New models cannot be placed in a disabled region, existing
+// models in the region are unaffected. Only JIMM administrators may
+// enable or disable cloud regions.
+func (j *JIMM) SetCloudRegionDisabled(ctx context.Context, user *openfga.User, ct names.CloudTag, regionName string, disabled bool) (err error) {
+	const op = errors.Op("jimm.SetCloudRegionDisabled")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err = j.Database.Transaction(func(db *db.Database) error {
+		cloud := dbmodel.Cloud{
+			Name: ct.Id(),
+		}
+		if err := db.GetCloud(ctx, &cloud); err != nil {
+			return err
+		}
+		for i := range cloud.Regions {
+			if cloud.Regions[i].Name != regionName {
+				continue
+			}
+			cloud.Regions[i].Disabled = disabled
+			return db.UpdateCloud(ctx, &cloud)
+		}
+		return errors.E(errors.CodeNotFound, fmt.Sprintf("cloud region %s/%s not found", ct.Id(), regionName))
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ package jimm_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -1567,6 +1568,51 @@ users:
   controller-access: superuser
 `
 
+const updateCloudRegionsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: region-1
+  - name: region-2
+  - name: region-3
+  users:
+  - user: alice@canonical.com
+    access: admin
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: region-1
+    priority: 10
+  - cloud: test-cloud
+    region: region-2
+    priority: 1
+  - cloud: test-cloud
+    region: region-3
+    priority: 1
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: region-2
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
+
 var updateCloudTests = []struct {
 	name            string
 	env             string
@@ -1705,11 +1751,15 @@ var updateCloudTests = []struct {
 		expectError:     `unauthorized`,
 		expectErrorCode: errors.CodeUnauthorized,
 	}, {
-		name:        "DialError",
-		env:         updateCloudTestEnv,
-		dialError:   errors.E("test dial error"),
-		username:    "alice@canonical.com",
-		cloud:       "test",
+		name:      "DialError",
+		env:       updateCloudTestEnv,
+		dialError: errors.E("test dial error"),
+		username:  "alice@canonical.com",
+		cloud:     "test",
+		update: jujuparams.Cloud{
+			Type:    "kubernetes",
+			Regions: []jujuparams.CloudRegion{{Name: "default"}},
+		},
 		expectError: `test dial error`,
 	}, {
 		name: "APIError",
@@ -1717,9 +1767,99 @@ var updateCloudTests = []struct {
 		updateCloud: func(context.Context, names.CloudTag, jujuparams.Cloud) error {
 			return errors.E("test error")
 		},
-		username:    "alice@canonical.com",
-		cloud:       "test",
+		username: "alice@canonical.com",
+		cloud:    "test",
+		update: jujuparams.Cloud{
+			Type:    "kubernetes",
+			Regions: []jujuparams.CloudRegion{{Name: "default"}},
+		},
 		expectError: `test error`,
+	}, {
+		name:     "NoRegions",
+		env:      updateCloudTestEnv,
+		username: "alice@canonical.com",
+		cloud:    "test",
+		update: jujuparams.Cloud{
+			Type: "kubernetes",
+		},
+		expectError:     `cloud must have at least one region`,
+		expectErrorCode: errors.CodeBadRequest,
+	}, {
+		name: "AddAndRemoveRegions",
+		env:  updateCloudRegionsTestEnv,
+		updateCloud: func(_ context.Context, ct names.CloudTag, c jujuparams.Cloud) error {
+			if len(c.Regions) != 3 {
+				return errors.E("unexpected regions")
+			}
+			return nil
+		},
+		username: "alice@canonical.com",
+		cloud:    "test-cloud",
+		update: jujuparams.Cloud{
+			Type:      "test-provider",
+			AuthTypes: []string{"empty"},
+			Regions: []jujuparams.CloudRegion{{
+				Name: "region-1",
+			}, {
+				Name: "region-2",
+			}, {
+				Name:     "region-4",
+				Endpoint: "https://region4.example.com",
+			}},
+		},
+		expectCloud: dbmodel.Cloud{
+			Name:      "test-cloud",
+			Type:      "test-provider",
+			AuthTypes: dbmodel.Strings{"empty"},
+			Regions: []dbmodel.CloudRegion{{
+				Name: "region-1",
+				Controllers: []dbmodel.CloudRegionControllerPriority{{
+					Controller: dbmodel.Controller{
+						Name:        "controller-1",
+						UUID:        "00000001-0000-0000-0000-000000000001",
+						CloudName:   "test-cloud",
+						CloudRegion: "region-1",
+					},
+					Priority: 10,
+				}},
+			}, {
+				Name: "region-2",
+				Controllers: []dbmodel.CloudRegionControllerPriority{{
+					Controller: dbmodel.Controller{
+						Name:        "controller-1",
+						UUID:        "00000001-0000-0000-0000-000000000001",
+						CloudName:   "test-cloud",
+						CloudRegion: "region-1",
+					},
+					Priority: 1,
+				}},
+			}, {
+				Name:     "region-4",
+				Endpoint: "https://region4.example.com",
+				Controllers: []dbmodel.CloudRegionControllerPriority{{
+					Controller: dbmodel.Controller{
+						Name:        "controller-1",
+						UUID:        "00000001-0000-0000-0000-000000000001",
+						CloudName:   "test-cloud",
+						CloudRegion: "region-1",
+					},
+					Priority: 1,
+				}},
+			}},
+		},
+	}, {
+		name:     "RemoveRegionHostingModels",
+		env:      updateCloudRegionsTestEnv,
+		username: "alice@canonical.com",
+		cloud:    "test-cloud",
+		update: jujuparams.Cloud{
+			Type: "test-provider",
+			Regions: []jujuparams.CloudRegion{{
+				Name: "region-1",
+			}},
+		},
+		expectError:     `cannot remove region "region-2": region hosts 1 model\(s\)`,
+		expectErrorCode: errors.CodeBadRequest,
 	}}
 
 func TestUpdateCloud(t *testing.T) {
@@ -1776,6 +1916,77 @@ func TestUpdateCloud(t *testing.T) {
 	}
 }
 
+func TestUpdateCloudRegionGainsModel(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, updateCloudRegionsTestEnv)
+	api := &jimmtest.API{}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API:          api,
+			UUID:         "00000001-0000-0000-0000-000000000001",
+			AgentVersion: "1",
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	// A model is added to region-3 after the regions being removed
+	// have been checked, but before they are deleted.
+	api.UpdateCloud_ = func(ctx context.Context, ct names.CloudTag, _ jujuparams.Cloud) error {
+		var cloud dbmodel.Cloud
+		cloud.SetTag(ct)
+		if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+			return err
+		}
+		m := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true}}
+		if err := j.Database.GetModel(ctx, &m); err != nil {
+			return err
+		}
+		m2 := dbmodel.Model{
+			Name:              "model-2",
+			UUID:              sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true},
+			OwnerIdentityName: m.OwnerIdentityName,
+			ControllerID:      m.ControllerID,
+			CloudRegionID:     cloud.Region("region-3").ID,
+			CloudCredentialID: m.CloudCredentialID,
+			Life:              m.Life,
+		}
+		return j.Database.AddModel(ctx, &m2)
+	}
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	tag := names.NewCloudTag("test-cloud")
+	err = j.UpdateCloud(ctx, user, tag, jujuparams.Cloud{
+		Type: "test-provider",
+		Regions: []jujuparams.CloudRegion{{
+			Name: "region-1",
+		}, {
+			Name: "region-2",
+		}},
+	})
+	c.Check(err, qt.ErrorMatches, `cannot remove region "region-3": region hosts 1 model\(s\)`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	var cloud dbmodel.Cloud
+	cloud.SetTag(tag)
+	err = j.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, qt.IsNil)
+	c.Check(cloud.Regions, qt.HasLen, 3)
+}
+
 const removeCloudFromControllerTestEnv = `clouds:
 - name: test-cloud
   type: test-provider
@@ -1964,3 +2175,108 @@ func TestRemoveFromControllerCloud(t *testing.T) {
 		})
 	}
 }
+
+const setCloudRegionDisabledTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- name: test-credential-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+`
+
+func TestSetCloudRegionDisabled(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:]),
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, setCloudRegionDisabledTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, client)
+	admin.JimmAdmin = true
+
+	ct := names.NewCloudTag("test-cloud")
+	err = j.SetCloudRegionDisabled(ctx, alice, ct, "test-region-1", true)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.SetCloudRegionDisabled(ctx, admin, ct, "test-region-3", true)
+	c.Check(err, qt.ErrorMatches, `cloud region test-cloud/test-region-3 not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.SetCloudRegionDisabled(ctx, admin, ct, "test-region-1", true)
+	c.Assert(err, qt.IsNil)
+
+	args := jimm.ModelCreateArgs{
+		Name:            "test-model",
+		Owner:           alice.ResourceTag(),
+		Cloud:           ct,
+		CloudRegion:     "test-region-1",
+		CloudCredential: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/test-credential-1"),
+	}
+	_, err = j.AddModel(ctx, alice, &args)
+	c.Check(err, qt.ErrorMatches, `cloud region test-cloud/test-region-1 is disabled`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	// Models created without a region are placed in an enabled region.
+	args.CloudRegion = ""
+	mi, err := j.AddModel(ctx, alice, &args)
+	c.Assert(err, qt.IsNil)
+	c.Check(mi.CloudRegion, qt.Equals, "test-region-2")
+
+	err = j.SetCloudRegionDisabled(ctx, admin, ct, "test-region-1", false)
+	c.Assert(err, qt.IsNil)
+	cloud := dbmodel.Cloud{Name: "test-cloud"}
+	err = j.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, qt.IsNil)
+	c.Check(cloud.Region("test-region-1").Disabled, qt.IsFalse)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
@@ -15,9 +16,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/canonical/jimm/v3/internal/cloudcred"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notifications"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // GetCloudCredential retrieves the given credential from the database. The
@@ -27,8 +31,10 @@ import (
 // of CodeNotFound will be returned. If the given user is not a controller
 // superuser or the owner of the credentials then an error with a code of
 // CodeUnauthorized will be returned.
-func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error) {
+func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (_ *dbmodel.CloudCredential, err error) {
 	const op = errors.Op("jimm.GetCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin && user.Name != tag.Owner().Id() {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -37,7 +43,7 @@ func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag n
 	var credential dbmodel.CloudCredential
 	credential.SetTag(tag)
 
-	err := j.Database.GetCloudCredential(ctx, &credential)
+	err = j.Database.GetCloudCredential(ctx, &credential)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -48,8 +54,10 @@ func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag n
 
 // RevokeCloudCredential checks that the credential with the given path
 // can be revoked  and revokes the credential.
-func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error {
+func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) (err error) {
 	const op = errors.Op("jimm.RevokeCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if user.Name != tag.Owner().Id() {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -58,7 +66,7 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 	var credential dbmodel.CloudCredential
 	credential.SetTag(tag)
 
-	err := j.Database.GetCloudCredential(ctx, &credential)
+	err = j.Database.GetCloudCredential(ctx, &credential)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			// It is not an error to revoke an non-existent credential
@@ -88,6 +96,97 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 		return errors.E(op, err)
 	}
 
+	if err := j.revokeCloudCredential(ctx, &credential, cloudControllers(&cloud)); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RevokeCloudCredentialArgs holds the arguments for revoking a single
+// cloud credential with RevokeCloudCredentials.
+type RevokeCloudCredentialArgs struct {
+	// Tag is the tag of the credential to revoke.
+	Tag names.CloudCredentialTag
+
+	// Force revokes the credential even if it is still used by models.
+	Force bool
+}
+
+// RevokeCloudCredentials revokes many cloud credentials at once. The
+// models using every credential are counted in a single query and the
+// credentials are revoked on their controllers in parallel. The returned
+// slice holds the error, if any, from revoking each credential, in the
+// same order as args. As with RevokeCloudCredential, revoking a
+// credential that does not exist is not an error.
+func (j *JIMM) RevokeCloudCredentials(ctx context.Context, user *dbmodel.Identity, args []RevokeCloudCredentialArgs) []error {
+	const op = errors.Op("jimm.RevokeCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer span.End()
+
+	errs := make([]error, len(args))
+	credentials := make([]*dbmodel.CloudCredential, len(args))
+	var ids []uint
+	for i, arg := range args {
+		if user.Name != arg.Tag.Owner().Id() {
+			errs[i] = errors.E(op, errors.CodeUnauthorized, "unauthorized")
+			continue
+		}
+		var credential dbmodel.CloudCredential
+		credential.SetTag(arg.Tag)
+		if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+			if errors.ErrorCode(err) != errors.CodeNotFound {
+				errs[i] = errors.E(op, err)
+			}
+			continue
+		}
+		credentials[i] = &credential
+		ids = append(ids, credential.ID)
+	}
+
+	counts, err := j.Database.CountModelsUsingCredentials(ctx, ids)
+	if err != nil {
+		for i := range credentials {
+			if credentials[i] != nil {
+				errs[i] = errors.E(op, err)
+			}
+		}
+		return errs
+	}
+
+	clouds := make(map[string]*dbmodel.Cloud)
+	var wg sync.WaitGroup
+	for i, credential := range credentials {
+		if credential == nil {
+			continue
+		}
+		if n := counts[credential.ID]; n > 0 && !args[i].Force {
+			errs[i] = errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cloud credential still used by %d model(s)", n))
+			continue
+		}
+		cloud, ok := clouds[credential.CloudName]
+		if !ok {
+			cloud = &dbmodel.Cloud{Name: credential.CloudName}
+			if err := j.Database.GetCloud(ctx, cloud); err != nil {
+				errs[i] = errors.E(op, err)
+				continue
+			}
+			clouds[credential.CloudName] = cloud
+		}
+		wg.Add(1)
+		go func(i int, credential *dbmodel.CloudCredential, controllers []dbmodel.Controller) {
+			defer wg.Done()
+			if err := j.revokeCloudCredential(ctx, credential, controllers); err != nil {
+				errs[i] = errors.E(op, err)
+			}
+		}(i, credential, cloudControllers(cloud))
+	}
+	wg.Wait()
+	return errs
+}
+
+// cloudControllers returns the controllers hosting any region of the
+// given cloud.
+func cloudControllers(cloud *dbmodel.Cloud) []dbmodel.Controller {
 	var controllers []dbmodel.Controller
 	seen := make(map[uint]bool)
 	for _, region := range cloud.Regions {
@@ -99,39 +198,69 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 			controllers = append(controllers, cr.Controller)
 		}
 	}
+	return controllers
+}
 
-	err = j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
+// revokeCloudCredential revokes the given credential on all the given
+// controllers and then removes it from the database.
+func (j *JIMM) revokeCloudCredential(ctx context.Context, credential *dbmodel.CloudCredential, controllers []dbmodel.Controller) error {
+	tag := credential.ResourceTag()
+	err := j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
 		err := api.RevokeCredential(ctx, tag)
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			err = nil
 		}
 		return err
 	})
-
 	if err != nil {
-		return errors.E(op, err)
+		return err
 	}
 
-	err = j.Database.DeleteCloudCredential(ctx, &credential)
-	if err != nil {
-		return errors.E(op, err, "failed to revoke credential in local database")
+	if err := j.Database.DeleteCloudCredential(ctx, credential); err != nil {
+		return errors.E(err, "failed to revoke credential in local database")
 	}
 	return nil
 }
 
+// A CredentialValidator checks cloud credentials with the API of the
+// cloud's provider.
+type CredentialValidator interface {
+	// Validate returns an error with the code CodeCredentialInvalid if
+	// the cloud's provider rejects the given credential.
+	Validate(ctx context.Context, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error
+}
+
 // UpdateCloudCredentialArgs holds arguments for the cloud credential update
 type UpdateCloudCredentialArgs struct {
 	CredentialTag names.CloudCredentialTag
 	Credential    jujuparams.CloudCredential
 	SkipCheck     bool
 	SkipUpdate    bool
+
+	// ExpiresAt, if set, is the time at which the credential expires.
+	// If this is nil any previously recorded expiry time is kept.
+	ExpiresAt *time.Time
+
+	// DryRun, if set, checks the credential against every controller
+	// hosting models that use it and reports the impact on each model,
+	// without storing the credential or deploying it to any controller.
+	// SkipCheck and SkipUpdate are ignored in a dry run.
+	DryRun bool
 }
 
 // UpdateCloudCredential checks that the credential can be updated
 // and updates it in the local database and all controllers
-// to which it is deployed.
-func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, args UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error) {
+// to which it is deployed. If args.DryRun is set only the check is
+// performed.
+func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, args UpdateCloudCredentialArgs) (_ []jujuparams.UpdateCredentialModelResult, err error) {
 	const op = errors.Op("jimm.UpdateCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if args.DryRun {
+		args.SkipCheck = false
+		args.SkipUpdate = true
+	}
 
 	var resultMu sync.Mutex
 	var result []jujuparams.UpdateCredentialModelResult
@@ -150,7 +279,7 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	var credential dbmodel.CloudCredential
 	credential.SetTag(args.CredentialTag)
 
-	err := j.Database.GetCloudCredential(ctx, &credential)
+	err = j.Database.GetCloudCredential(ctx, &credential)
 	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
 		return result, errors.E(op, err)
 	}
@@ -178,7 +307,16 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 
 	credential.AuthType = args.Credential.AuthType
 	credential.Attributes = args.Credential.Attributes
+	if args.ExpiresAt != nil {
+		credential.ExpiresAt = sql.NullTime{Time: args.ExpiresAt.UTC(), Valid: true}
+		credential.ExpiringSoon = false
+	}
 
+	if !args.SkipCheck && j.CredentialValidator != nil {
+		if err := j.CredentialValidator.Validate(ctx, &cloud, args.Credential); err != nil {
+			return result, errors.E(op, err)
+		}
+	}
 	if !args.SkipCheck {
 		err := j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
 			models, err := j.updateControllerCloudCredential(ctx, &credential, api.CheckCredentialModels)
@@ -223,6 +361,11 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	if err != nil {
 		return result, errors.E(op, err)
 	}
+	j.Lifecycle.Dispatch(ctx, notifications.Event{
+		Event:           notifications.EventCredentialUpdated,
+		CloudCredential: args.CredentialTag.Id(),
+		Identity:        user.Name,
+	})
 	return result, nil
 }
 
@@ -291,8 +434,10 @@ func (j *JIMM) updateControllerCloudCredential(
 // calling the function will not contain any attributes,
 // GetCloudCredentialAttributes should be used to retrive the credential
 // attributes if needed. The given function should not update the database.
-func (j *JIMM) ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error {
+func (j *JIMM) ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) (err error) {
 	const op = errors.Op("jimm.ForEachUserCloudCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var cloud string
 	if ct != (names.CloudTag{}) {
@@ -301,7 +446,7 @@ func (j *JIMM) ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identi
 
 	errStop := errors.E("stop")
 	var iterErr error
-	err := j.Database.ForEachCloudCredential(ctx, u.Name, cloud, func(cred *dbmodel.CloudCredential) error {
+	err = j.Database.ForEachCloudCredential(ctx, u.Name, cloud, func(cred *dbmodel.CloudCredential) error {
 		cred.Attributes = nil
 		iterErr = f(cred)
 		if iterErr != nil {
@@ -317,6 +462,33 @@ func (j *JIMM) ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identi
 	return err
 }
 
+// ListCloudCredentials returns the cloud credentials that match the given
+// filter. JIMM administrators may list the credentials of any identity,
+// other users may only list their own, if the filter does not name an
+// owner only the user's credentials are returned. The returned
+// credentials do not contain any attributes, GetCloudCredentialAttributes
+// should be used to retrieve them if needed.
+func (j *JIMM) ListCloudCredentials(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("jimm.ListCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		if filter.OwnerIdentityName != "" && filter.OwnerIdentityName != user.Name {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+		filter.OwnerIdentityName = user.Name
+	}
+	creds, err := j.Database.ListCloudCredentials(ctx, filter)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for i := range creds {
+		creds[i].Attributes = nil
+	}
+	return creds, nil
+}
+
 // GetCloudCredentialAttributes retrieves the attributes for a cloud
 // credential. If hidden is true then returned credentials will include
 // hidden attributes, otherwise a list of redacted attributes will be
@@ -325,6 +497,8 @@ func (j *JIMM) ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identi
 // the code CodeUnauthorized.
 func (j *JIMM) GetCloudCredentialAttributes(ctx context.Context, user *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error) {
 	const op = errors.Op("jimm.GetCloudCredentialAttributes")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if hidden {
 		// Controller superusers cannot read hidden credential attributes.
@@ -382,3 +556,149 @@ func (j *JIMM) getCloudCredentialAttributes(ctx context.Context, cred *dbmodel.C
 	}
 	return attr, nil
 }
+
+// CloudCredentialRotation holds the new auth type and attributes for a
+// cloud credential being rotated.
+type CloudCredentialRotation struct {
+	CredentialTag names.CloudCredentialTag
+	Credential    jujuparams.CloudCredential
+	// SkipCheck, if set, skips validating the new credential.
+	SkipCheck bool
+}
+
+// credentialRotation holds the state of a single credential rotation.
+type credentialRotation struct {
+	credential  dbmodel.CloudCredential
+	previous    dbmodel.CloudCredential
+	controllers []dbmodel.Controller
+}
+
+// RotateCloudCredentials replaces the attributes of each of the given
+// existing cloud credentials. Unless SkipCheck is set, the new
+// attributes of every credential are first validated with the
+// CredentialValidator, if one is configured; if any credential is
+// invalid no credential is changed. The new attributes of every
+// credential are then checked against the models using the credential
+// on every controller. If any check fails no credential is changed, and the model
+// results of the check are returned with an error with a code of
+// CodeBadRequest. Otherwise the credentials are updated in turn in JIMM
+// and on the controllers. If any update fails every credential that has
+// been updated is restored to its previous attributes before the error is
+// returned. The user must own every credential or be a JIMM
+// administrator.
+func (j *JIMM) RotateCloudCredentials(ctx context.Context, user *openfga.User, rotations []CloudCredentialRotation) (_ []jujuparams.UpdateCredentialModelResult, err error) {
+	const op = errors.Op("jimm.RotateCloudCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	seen := make(map[names.CloudCredentialTag]bool, len(rotations))
+	rs := make([]credentialRotation, len(rotations))
+	for i, rotation := range rotations {
+		if seen[rotation.CredentialTag] {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("credential %q specified more than once", rotation.CredentialTag.Id()))
+		}
+		seen[rotation.CredentialTag] = true
+		if user.Tag() != rotation.CredentialTag.Owner() && !user.JimmAdmin {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+
+		r := &rs[i]
+		r.previous.SetTag(rotation.CredentialTag)
+		if err := j.Database.GetCloudCredential(ctx, &r.previous); err != nil {
+			return nil, errors.E(op, err)
+		}
+		attrs, err := j.getCloudCredentialAttributes(ctx, &r.previous)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		r.previous.Attributes = attrs
+		r.credential = r.previous
+		r.credential.AuthType = rotation.Credential.AuthType
+		r.credential.Attributes = rotation.Credential.Attributes
+		if !rotation.SkipCheck && j.CredentialValidator != nil {
+			var cloud dbmodel.Cloud
+			cloud.SetTag(names.NewCloudTag(r.previous.CloudName))
+			if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+				return nil, errors.E(op, err)
+			}
+			if err := j.CredentialValidator.Validate(ctx, &cloud, rotation.Credential); err != nil {
+				return nil, errors.E(op, err)
+			}
+		}
+
+		models, err := j.Database.GetModelsUsingCredential(ctx, r.previous.ID)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		controllers := make(map[uint]bool)
+		for _, model := range models {
+			if controllers[model.ControllerID] {
+				continue
+			}
+			controllers[model.ControllerID] = true
+			r.controllers = append(r.controllers, model.Controller)
+		}
+	}
+
+	var resultMu sync.Mutex
+	var result []jujuparams.UpdateCredentialModelResult
+	for i := range rs {
+		r := &rs[i]
+		err := j.forEachController(ctx, r.controllers, func(_ *dbmodel.Controller, api API) error {
+			models, err := j.updateControllerCloudCredential(ctx, &r.credential, api.CheckCredentialModels)
+			resultMu.Lock()
+			defer resultMu.Unlock()
+			result = append(result, models...)
+			return err
+		})
+		if err != nil {
+			return result, errors.E(op, err)
+		}
+	}
+	for _, r := range result {
+		if len(r.Errors) > 0 {
+			return result, errors.E(op, errors.CodeBadRequest, "credential check failed, no credentials rotated")
+		}
+	}
+
+	for i := range rs {
+		r := &rs[i]
+		err := j.updateCredential(ctx, &r.credential)
+		if err == nil {
+			err = j.forEachController(ctx, r.controllers, func(_ *dbmodel.Controller, api API) error {
+				_, err := j.updateControllerCloudCredential(ctx, &r.credential, api.UpdateCredential)
+				return err
+			})
+		}
+		if err != nil {
+			// The failed credential may have been updated in JIMM or on
+			// some of its controllers so it is restored along with every
+			// credential that was rotated before it.
+			j.restoreCloudCredentials(ctx, rs[:i+1])
+			return result, errors.E(op, err, fmt.Sprintf("cannot rotate credential %q, credentials restored", r.credential.Tag().Id()))
+		}
+	}
+	return result, nil
+}
+
+// restoreCloudCredentials restores the previous attributes of the given
+// rotated credentials in JIMM and on their controllers. Failures are only
+// logged, as there is nothing more that can be done to recover.
+func (j *JIMM) restoreCloudCredentials(ctx context.Context, rs []credentialRotation) {
+	for i := range rs {
+		r := &rs[i]
+		tag := r.previous.Tag().Id()
+		if err := j.updateCredential(ctx, &r.previous); err != nil {
+			zapctx.Error(ctx, "cannot restore credential", zap.String("credential", tag), zap.Error(err))
+		}
+		err := j.forEachController(ctx, r.controllers, func(ctl *dbmodel.Controller, api API) error {
+			if _, err := j.updateControllerCloudCredential(ctx, &r.previous, api.UpdateCredential); err != nil {
+				zapctx.Error(ctx, "cannot restore credential on controller", zap.String("credential", tag), zap.String("controller", ctl.Name), zap.Error(err))
+			}
+			return nil
+		})
+		if err != nil {
+			zapctx.Error(ctx, "cannot restore credential on controllers", zap.String("credential", tag), zap.Error(err))
+		}
+	}
+}
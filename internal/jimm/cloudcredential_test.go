@@ -861,6 +861,78 @@ users:
 	c.Assert(err, qt.IsNil)
 }
 
+type credentialValidatorFunc func(context.Context, *dbmodel.Cloud, jujuparams.CloudCredential) error
+
+func (f credentialValidatorFunc) Validate(ctx context.Context, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+	return f(ctx, cloud, cred)
+}
+
+func TestUpdateCloudCredentialRejectedByValidator(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: test-cloud
+  type: `+jimmtest.TestProviderType+`
+  regions:
+  - name: default
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+		OpenFGAClient: client,
+		CredentialValidator: credentialValidatorFunc(func(_ context.Context, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+			c.Check(cloud.Name, qt.Equals, "test-cloud")
+			if cred.Attributes["secret"] != "valid" {
+				return errors.E(errors.CodeCredentialInvalid, "invalid test credential")
+			}
+			return nil
+		}),
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/test")
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType:   "userpass",
+			Attributes: map[string]string{"secret": "invalid"},
+		},
+	})
+	c.Assert(err, qt.ErrorMatches, "invalid test credential")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeCredentialInvalid)
+
+	var cred dbmodel.CloudCredential
+	cred.SetTag(tag)
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType:   "userpass",
+			Attributes: map[string]string{"secret": "valid"},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+}
+
 func TestRevokeCloudCredential(t *testing.T) {
 	c := qt.New(t)
 
@@ -1273,6 +1345,123 @@ func TestRevokeCloudCredential(t *testing.T) {
 	}
 }
 
+const revokeCloudCredentialsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: cred-2
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: cred-3
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 0
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+models:
+- name: model-1
+  owner: alice@canonical.com
+  uuid: 00000002-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-2
+  controller: controller-1
+`
+
+func TestRevokeCloudCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	revoked := make(map[string]int)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				RevokeCredential_: func(_ context.Context, tag names.CloudCredentialTag) error {
+					mu.Lock()
+					defer mu.Unlock()
+					revoked[tag.Id()]++
+					return nil
+				},
+			},
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, revokeCloudCredentialsTestEnv)
+	env.PopulateDB(c, j.Database)
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+
+	errs := j.RevokeCloudCredentials(ctx, &u, []jimm.RevokeCloudCredentialArgs{{
+		Tag: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1"),
+	}, {
+		Tag: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-2"),
+	}, {
+		Tag: names.NewCloudCredentialTag("test-cloud/bob@canonical.com/cred-1"),
+	}, {
+		Tag: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/no-such-cred"),
+	}, {
+		Tag:   names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-3"),
+		Force: true,
+	}})
+	c.Assert(errs, qt.HasLen, 5)
+	c.Check(errs[0], qt.IsNil)
+	c.Check(errs[1], qt.ErrorMatches, `cloud credential still used by 1 model\(s\)`)
+	c.Check(errors.ErrorCode(errs[2]), qt.Equals, errors.CodeUnauthorized)
+	c.Check(errs[3], qt.IsNil)
+	c.Check(errs[4], qt.IsNil)
+
+	// Each revoked credential is revoked on both controllers.
+	c.Check(revoked, qt.DeepEquals, map[string]int{
+		"test-cloud/alice@canonical.com/cred-1": 2,
+		"test-cloud/alice@canonical.com/cred-3": 2,
+	})
+
+	for name, expectCode := range map[string]errors.Code{
+		"cred-1": errors.CodeNotFound,
+		"cred-2": "",
+		"cred-3": errors.CodeNotFound,
+	} {
+		cred := dbmodel.CloudCredential{
+			CloudName:         "test-cloud",
+			OwnerIdentityName: "alice@canonical.com",
+			Name:              name,
+		}
+		err := j.Database.GetCloudCredential(ctx, &cred)
+		c.Check(errors.ErrorCode(err), qt.Equals, expectCode, qt.Commentf(name))
+	}
+}
+
 func TestGetCloudCredential(t *testing.T) {
 	c := qt.New(t)
 
@@ -1802,3 +1991,343 @@ func (s testCloudCredentialAttributeStore) GetOAuthSecret(ctx context.Context) (
 func (s testCloudCredentialAttributeStore) PutOAuthSecret(ctx context.Context, raw []byte) error {
 	return errors.E(errors.CodeNotImplemented)
 }
+
+//nolint:gosec // Thinks credentials hardcoded.
+const rotateCloudCredentialsEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- name: cred-1
+  cloud: test-cloud
+  owner: alice@canonical.com
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: old-password-1
+- name: cred-2
+  cloud: test-cloud
+  owner: alice@canonical.com
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: old-password-2
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+- name: model-2
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-2
+  owner: alice@canonical.com
+users:
+- username: alice@canonical.com
+- username: bob@canonical.com
+`
+
+var rotateCloudCredentialsTests = []struct {
+	name                  string
+	username              string
+	checkCredentialModels func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error)
+	updateCredential      func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error)
+	validator             jimm.CredentialValidator
+	expectError           string
+	expectErrorCode       errors.Code
+	expectPasswords       []string
+}{{
+	name:            "Rotated",
+	username:        "alice@canonical.com",
+	expectPasswords: []string{"new-password-1", "new-password-2"},
+}, {
+	name:            "Unauthorized",
+	username:        "bob@canonical.com",
+	expectError:     `unauthorized`,
+	expectErrorCode: errors.CodeUnauthorized,
+	expectPasswords: []string{"old-password-1", "old-password-2"},
+}, {
+	name:     "CheckFailed",
+	username: "alice@canonical.com",
+	checkCredentialModels: func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		if cred.Tag != names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-2").String() {
+			return nil, nil
+		}
+		return []jujuparams.UpdateCredentialModelResult{{
+			ModelUUID: "00000002-0000-0000-0000-000000000002",
+			ModelName: "model-2",
+			Errors: []jujuparams.ErrorResult{{
+				Error: &jujuparams.Error{Message: "invalid credential"},
+			}},
+		}}, nil
+	},
+	expectError:     `credential check failed, no credentials rotated`,
+	expectErrorCode: errors.CodeBadRequest,
+	expectPasswords: []string{"old-password-1", "old-password-2"},
+}, {
+	name:     "UpdateFailed",
+	username: "alice@canonical.com",
+	updateCredential: func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		if cred.Credential.Attributes["password"] == "new-password-2" {
+			return nil, errors.E("test error")
+		}
+		return nil, nil
+	},
+	expectError:     `cannot rotate credential "test-cloud/alice@canonical.com/cred-2", credentials restored`,
+	expectPasswords: []string{"old-password-1", "old-password-2"},
+}, {
+	name:     "RejectedByValidator",
+	username: "alice@canonical.com",
+	checkCredentialModels: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		return nil, errors.E("unexpected check")
+	},
+	updateCredential: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		return nil, errors.E("unexpected update")
+	},
+	validator: credentialValidatorFunc(func(_ context.Context, cloud *dbmodel.Cloud, cred jujuparams.CloudCredential) error {
+		if cloud.Name != "test-cloud" {
+			return errors.E("incorrect cloud")
+		}
+		if cred.Attributes["password"] == "new-password-2" {
+			return errors.E(errors.CodeCredentialInvalid, "invalid test credential")
+		}
+		return nil
+	}),
+	expectError:     `invalid test credential`,
+	expectErrorCode: errors.CodeCredentialInvalid,
+	expectPasswords: []string{"old-password-1", "old-password-2"},
+}}
+
+func TestRotateCloudCredentials(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range rotateCloudCredentialsTests {
+		c.Run(test.name, func(c *qt.C) {
+			ctx := context.Background()
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.name)
+			c.Assert(err, qt.IsNil)
+
+			noop := func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+				return nil, nil
+			}
+			if test.checkCredentialModels == nil {
+				test.checkCredentialModels = noop
+			}
+			if test.updateCredential == nil {
+				test.updateCredential = noop
+			}
+			env := jimmtest.ParseEnvironment(c, rotateCloudCredentialsEnv)
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: &jimmtest.Dialer{
+					API: &jimmtest.API{
+						CheckCredentialModels_: test.checkCredentialModels,
+						UpdateCredential_:      test.updateCredential,
+					},
+				},
+				OpenFGAClient:       client,
+				CredentialValidator: test.validator,
+			}
+			err = j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+			env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+			u := env.User(test.username).DBObject(c, j.Database)
+			user := openfga.NewUser(&u, client)
+			var rotations []jimm.CloudCredentialRotation
+			for i := 1; i <= 2; i++ {
+				rotations = append(rotations, jimm.CloudCredentialRotation{
+					CredentialTag: names.NewCloudCredentialTag(fmt.Sprintf("test-cloud/alice@canonical.com/cred-%d", i)),
+					Credential: jujuparams.CloudCredential{
+						AuthType: "userpass",
+						Attributes: map[string]string{
+							"username": "alice",
+							"password": fmt.Sprintf("new-password-%d", i),
+						},
+					},
+				})
+			}
+			_, err = j.RotateCloudCredentials(ctx, user, rotations)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				if test.expectErrorCode != "" {
+					c.Check(errors.ErrorCode(err), qt.Equals, test.expectErrorCode)
+				}
+			} else {
+				c.Assert(err, qt.IsNil)
+			}
+
+			for i, password := range test.expectPasswords {
+				var cred dbmodel.CloudCredential
+				cred.SetTag(rotations[i].CredentialTag)
+				err := j.Database.GetCloudCredential(ctx, &cred)
+				c.Assert(err, qt.IsNil)
+				c.Check(cred.Attributes["password"], qt.Equals, password)
+			}
+		})
+	}
+}
+
+func TestListCloudCredentials(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialExpiryTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	adminUser := openfga.NewUser(&alice, client)
+	adminUser.JimmAdmin = true
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bobUser := openfga.NewUser(&bob, client)
+
+	creds, err := j.ListCloudCredentials(ctx, adminUser, db.CloudCredentialFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 2)
+
+	creds, err = j.ListCloudCredentials(ctx, adminUser, db.CloudCredentialFilter{OwnerIdentityName: "bob@canonical.com"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].Path(), qt.Equals, "test/bob@canonical.com/cred-2")
+
+	// Users only see their own credentials.
+	creds, err = j.ListCloudCredentials(ctx, bobUser, db.CloudCredentialFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].Path(), qt.Equals, "test/bob@canonical.com/cred-2")
+	c.Check(creds[0].Attributes, qt.IsNil)
+
+	_, err = j.ListCloudCredentials(ctx, bobUser, db.CloudCredentialFilter{OwnerIdentityName: "alice@canonical.com"})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
+
+const updateCloudCredentialDryRunTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: old-password
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+`
+
+func TestUpdateCloudCredentialDryRun(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var checked []jujuparams.TaggedCredential
+	api := &jimmtest.API{
+		SupportsCheckCredentialModels_: true,
+		CheckCredentialModels_: func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+			checked = append(checked, cred)
+			return []jujuparams.UpdateCredentialModelResult{{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				ModelName: "model-1",
+			}}, nil
+		},
+		UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+			return nil, errors.E("unexpected credential update")
+		},
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, updateCloudCredentialDryRunTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	result, err := j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "alice",
+				"password": "new-password",
+			},
+		},
+		// A dry run always performs the check.
+		SkipCheck: true,
+		DryRun:    true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(result, qt.DeepEquals, []jujuparams.UpdateCredentialModelResult{{
+		ModelUUID: "00000002-0000-0000-0000-000000000001",
+		ModelName: "model-1",
+	}})
+	c.Assert(checked, qt.HasLen, 1)
+	c.Check(checked[0].Credential.Attributes["password"], qt.Equals, "new-password")
+
+	// The stored credential is unchanged.
+	cred := dbmodel.CloudCredential{}
+	cred.SetTag(tag)
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.Attributes["password"], qt.Equals, "old-password")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 const (
@@ -17,8 +18,10 @@ const (
 )
 
 // SetModelDefaults writes new default model setting values for the specified cloud/region.
-func (j *JIMM) SetModelDefaults(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) error {
+func (j *JIMM) SetModelDefaults(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) (err error) {
 	const op = errors.Op("jimm.SetModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var keys strings.Builder
 	var needComma bool
@@ -39,7 +42,7 @@ func (j *JIMM) SetModelDefaults(ctx context.Context, user *dbmodel.Identity, clo
 	cloud := dbmodel.Cloud{
 		Name: cloudTag.Id(),
 	}
-	err := j.Database.GetCloud(ctx, &cloud)
+	err = j.Database.GetCloud(ctx, &cloud)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -67,8 +70,10 @@ func (j *JIMM) SetModelDefaults(ctx context.Context, user *dbmodel.Identity, clo
 }
 
 // UnsetModelDefaults resets  default model setting values for the specified cloud/region.
-func (j *JIMM) UnsetModelDefaults(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, keys []string) error {
+func (j *JIMM) UnsetModelDefaults(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, keys []string) (err error) {
 	const op = errors.Op("jimm.UnsetModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	defaults := dbmodel.CloudDefaults{
 		IdentityName: user.Name,
@@ -77,7 +82,7 @@ func (j *JIMM) UnsetModelDefaults(ctx context.Context, user *dbmodel.Identity, c
 		},
 		Region: region,
 	}
-	err := j.Database.UnsetCloudDefaults(ctx, &defaults, keys)
+	err = j.Database.UnsetCloudDefaults(ctx, &defaults, keys)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -85,8 +90,10 @@ func (j *JIMM) UnsetModelDefaults(ctx context.Context, user *dbmodel.Identity, c
 }
 
 // ModelDefaultsForCloud returns the default config values for the specified cloud.
-func (j *JIMM) ModelDefaultsForCloud(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error) {
+func (j *JIMM) ModelDefaultsForCloud(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (_ jujuparams.ModelDefaultsResult, err error) {
 	const op = errors.Op("jimm.ModelDefaultsForCloud")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	result := jujuparams.ModelDefaultsResult{
 		Config: make(map[string]jujuparams.ModelDefaults),
 	}
@@ -7,7 +7,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller/controller"
 	jujuparams "github.com/juju/juju/rpc/params"
@@ -21,8 +26,11 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm/credentials"
+	"github.com/canonical/jimm/v3/internal/notify"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // ControllerClient defines an interface of the juju controller api client
@@ -81,6 +89,14 @@ type addControllerTransactor struct {
 	jujuClouds []dbmodel.Cloud
 	controller *dbmodel.Controller
 	tx         *db.Database
+
+	// newRegions holds the names, in the form "<cloud>/<region>", of
+	// the cloud regions that were not known to JIMM before the
+	// controller was added.
+	newRegions map[string]bool
+
+	// discovered holds the cloud regions found on the controller.
+	discovered []DiscoveredCloudRegion
 }
 
 // newAddControllerTransactor creates a new addControllerTransactor.
@@ -90,6 +106,7 @@ func newAddControllerTransactor(j *JIMM, jujuClouds []dbmodel.Cloud, ctl *dbmode
 		jujuClouds: jujuClouds,
 		controller: ctl,
 		tx:         tx,
+		newRegions: make(map[string]bool),
 	}
 }
 
@@ -127,6 +144,7 @@ func (act *addControllerTransactor) addCloudRegions(ctx context.Context, cloud d
 			zapctx.Error(ctx, "failed to add cloud region", zaputil.Error(err))
 			return cloud, err
 		}
+		act.newRegions[cloud.Name+"/"+reg.Name] = true
 	}
 	return cloud, nil
 }
@@ -141,7 +159,8 @@ func (act *addControllerTransactor) addCloudRegions(ctx context.Context, cloud d
 //
 //  1. Priority supported:
 //     If the region is NOT the same as the controllers region,
-//     it holds this priority.
+//     it holds this priority, or JIMM's DefaultRegionPriority if
+//     that is set.
 //  2. Priority deployed:
 //     If the region is the same as the controller model,
 //     it holds this priority.
@@ -153,7 +172,10 @@ func (act *addControllerTransactor) setCloudRegionControllerPriorities(cloud dbm
 	for _, cr := range regions {
 		reg := cloud.Region(cr.Name)
 
-		priority := dbmodel.CloudRegionControllerPrioritySupported
+		var priority uint = dbmodel.CloudRegionControllerPrioritySupported
+		if act.jimm.DefaultRegionPriority != 0 {
+			priority = act.jimm.DefaultRegionPriority
+		}
 
 		if cloud.Name == act.controller.CloudName && cr.Name == act.controller.CloudRegion {
 			priority = dbmodel.CloudRegionControllerPriorityDeployed
@@ -161,8 +183,13 @@ func (act *addControllerTransactor) setCloudRegionControllerPriorities(cloud dbm
 
 		act.controller.CloudRegions = append(act.controller.CloudRegions, dbmodel.CloudRegionControllerPriority{
 			CloudRegion: reg,
-			//nolint:gosec
-			Priority: uint(priority),
+			Priority:    priority,
+		})
+		act.discovered = append(act.discovered, DiscoveredCloudRegion{
+			CloudName:  cloud.Name,
+			RegionName: cr.Name,
+			Priority:   priority,
+			New:        act.newRegions[cloud.Name+"/"+cr.Name],
 		})
 	}
 }
@@ -204,60 +231,145 @@ func (act *addControllerTransactor) Run(ctx context.Context) error {
 
 // addControllerTx stores the clouds, regions, cloud region priorities and the controller itself in the database determined
 // from the incoming Juju API.Clouds() call.
-func addControllerTx(ctx context.Context, j *JIMM, jujuClouds []dbmodel.Cloud, ctl *dbmodel.Controller) error {
-	return j.Database.Transaction(func(tx *db.Database) error {
-		return newAddControllerTransactor(j, jujuClouds, ctl, tx).Run(ctx)
+func addControllerTx(ctx context.Context, j *JIMM, jujuClouds []dbmodel.Cloud, ctl *dbmodel.Controller) ([]DiscoveredCloudRegion, error) {
+	var discovered []DiscoveredCloudRegion
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		act := newAddControllerTransactor(j, jujuClouds, ctl, tx)
+		if err := act.Run(ctx); err != nil {
+			return err
+		}
+		discovered = act.discovered
+		return nil
+	})
+	slices.SortFunc(discovered, func(a, b DiscoveredCloudRegion) int {
+		if a.CloudName != b.CloudName {
+			return strings.Compare(a.CloudName, b.CloudName)
+		}
+		return strings.Compare(a.RegionName, b.RegionName)
 	})
+	return discovered, err
+}
+
+// checkDuplicateController checks that the given controller does not
+// refer to the same juju controller as one already known to JIMM. A
+// controller is considered a duplicate if it has the same UUID, the same
+// public address, the same SRV name or the same set of API addresses as
+// an existing controller. Controllers with the same name are left for the
+// database to reject. If a duplicate is found an error with a code of
+// CodeAlreadyExists is returned naming the existing controller.
+func (j *JIMM) checkDuplicateController(ctx context.Context, ctl *dbmodel.Controller) error {
+	addrs := ctl.ToAPIControllerInfo().APIAddresses
+	slices.Sort(addrs)
+
+	return j.Database.ForEachController(ctx, func(existing *dbmodel.Controller) error {
+		if existing.Name == ctl.Name {
+			return nil
+		}
+		if ctl.UUID != "" && existing.UUID == ctl.UUID {
+			return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("controller %q has the same UUID as existing controller %q", ctl.Name, existing.Name))
+		}
+		if ctl.PublicAddress != "" && existing.PublicAddress == ctl.PublicAddress {
+			return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("controller %q has the same public address as existing controller %q", ctl.Name, existing.Name))
+		}
+		if ctl.SRVName != "" && strings.EqualFold(existing.SRVName, ctl.SRVName) {
+			return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("controller %q has the same SRV name as existing controller %q", ctl.Name, existing.Name))
+		}
+		existingAddrs := existing.ToAPIControllerInfo().APIAddresses
+		slices.Sort(existingAddrs)
+		if len(addrs) > 0 && slices.Equal(addrs, existingAddrs) {
+			return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("controller %q has the same API addresses as existing controller %q", ctl.Name, existing.Name))
+		}
+		return nil
+	})
+}
+
+// A DiscoveredCloudRegion is a cloud region found on a controller when
+// it was added to JIMM.
+type DiscoveredCloudRegion struct {
+	// CloudName is the name of the cloud containing the region.
+	CloudName string
+
+	// RegionName is the name of the region.
+	RegionName string
+
+	// Priority is the priority given to the controller for the region.
+	Priority uint
+
+	// New is true if the cloud region was not known to JIMM before the
+	// controller was added.
+	New bool
+}
+
+// AddController adds the specified controller to JIMM. See
+// AddControllerWithDiscovery for details.
+func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error {
+	_, err := j.AddControllerWithDiscovery(ctx, user, ctl)
+	return err
 }
 
-// AddController adds the specified controller to JIMM. Only
+// AddControllerWithDiscovery adds the specified controller to JIMM. Only
 // controller-admin level users may add new controllers. If the user adding
 // the controller is not authorized then an error with a code of
 // CodeUnauthorized will be returned. If there already exists a controller
-// with the same name as the controller being added then an error with a
-// code of CodeAlreadyExists will be returned. If the controller cannot be
-// contacted then an error with a code of CodeConnectionFailed will be
-// returned.
-func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error {
+// with the same name, UUID or endpoints as the controller being added then
+// an error with a code of CodeAlreadyExists will be returned. If the
+// controller cannot be contacted then an error with a code of
+// CodeConnectionFailed will be returned.
+//
+// All the clouds and regions available on the controller are imported
+// into JIMM. The controller is given a priority of
+// dbmodel.CloudRegionControllerPriorityDeployed in the region hosting its
+// controller model and JIMM's DefaultRegionPriority in every other
+// region. The discovered cloud regions are returned, sorted by cloud and
+// region name.
+func (j *JIMM) AddControllerWithDiscovery(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) (_ []DiscoveredCloudRegion, err error) {
 	const op = errors.Op("jimm.AddController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := j.checkJimmAdmin(user); err != nil {
-		return err
+		return nil, err
 	}
 
 	api, err := j.dialController(ctx, ctl)
 	if err != nil {
-		return errors.E(op, "failed to dial the controller", err)
+		return nil, errors.E(op, "failed to dial the controller", err)
 	}
 	defer api.Close()
 
 	modelSummary, err := getControllerModelSummary(ctx, api)
 	if err != nil {
-		return errors.E(op, err, "failed to get model summary")
+		return nil, errors.E(op, err, "failed to get model summary")
 	}
 
 	cloudName, err := getCloudNameFromModelSummary(modelSummary)
 	if err != nil {
-		return errors.E(op, err, "failed to parse the cloud tag")
+		return nil, errors.E(op, err, "failed to parse the cloud tag")
 	}
 
 	ctl.CloudName = cloudName
 	ctl.CloudRegion = modelSummary.CloudRegion
 	// TODO(mhilton) add the controller model?
 
+	if !j.AllowDuplicateControllers {
+		if err := j.checkDuplicateController(ctx, ctl); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
 	clouds, err := api.Clouds(ctx)
 	if err != nil {
-		return errors.E(op, err, "failed to fetch controller clouds")
+		return nil, errors.E(op, err, "failed to fetch controller clouds")
 	}
 
 	dbClouds := convertJujuCloudsToDbClouds(clouds)
 
 	// TODO(ale8k): This shouldn't be necessary to check, but tests need updating
 	// to set insecure credential store explicitly.
-	if j.CredentialStore != nil {
-		err := j.CredentialStore.PutControllerCredentials(ctx, ctl.Name, ctl.AdminIdentityName, ctl.AdminPassword)
+	if cs := j.ControllerCredentialStore(); cs != nil {
+		err := cs.PutControllerCredentials(ctx, ctl.Name, ctl.AdminIdentityName, ctl.AdminPassword)
 		if err != nil {
-			return errors.E(op, err, "failed to store controller credentials")
+			return nil, errors.E(op, err, "failed to store controller credentials")
 		}
 	}
 
@@ -266,14 +378,28 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 	ctl.AdminIdentityName = ""
 	ctl.AdminPassword = ""
 
-	if err := addControllerTx(ctx, j, dbClouds, ctl); err != nil {
+	// If a secret store is configured the CA certificate is kept there
+	// rather than in the database. It is restored on the controller
+	// once it has been added so that it is reported to the caller.
+	caCert := ctl.CACertificate
+	if j.SecretStore != nil && caCert != "" {
+		if err := j.SecretStore.PutControllerCACertificate(ctx, ctl.Name, caCert); err != nil {
+			return nil, errors.E(op, err, "failed to store controller CA certificate")
+		}
+		ctl.CACertificate = ""
+	}
+
+	discovered, err := addControllerTx(ctx, j, dbClouds, ctl)
+	ctl.CACertificate = caCert
+	if err != nil {
 		zapctx.Error(ctx, "failed to add controller", zaputil.Error(err))
 		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
-			return errors.E(op, err, fmt.Sprintf("controller %q already exists", ctl.Name))
+			return nil, errors.E(op, err, fmt.Sprintf("controller %q already exists", ctl.Name))
 		}
 
-		return errors.E(op, err)
+		return nil, errors.E(op, err)
 	}
+	j.invalidateCaches(ctx, ControllerVersionCache)
 
 	for _, cloud := range dbClouds {
 		// If this cloud is the one used by the controller model then
@@ -308,15 +434,65 @@ func (j *JIMM) AddController(ctx context.Context, user *openfga.User, ctl *dbmod
 		)
 	}
 
-	return nil
+	return discovered, nil
+}
+
+// controllerVersionCacheDuration is the length of time the earliest
+// controller version is cached before it is computed again. The cache is
+// invalidated whenever this replica sees a controller added, removed or
+// change version, changes made elsewhere take up to this long to be seen.
+const controllerVersionCacheDuration = 10 * time.Minute
+
+// controllerVersionCache holds the most recently computed earliest
+// controller version.
+type controllerVersionCache struct {
+	mu      sync.Mutex
+	version version.Number
+	expires time.Time
 }
 
 // EarliestControllerVersion returns the earliest agent version
 // that any of the available public controllers is known to be running.
 // If there are no available controllers or none of their versions are
-// known, it returns the zero version.
-func (j *JIMM) EarliestControllerVersion(ctx context.Context) (version.Number, error) {
+// known, it returns the zero version. The result is cached until a
+// controller is added, removed or changes version.
+func (j *JIMM) EarliestControllerVersion(ctx context.Context) (_ version.Number, err error) {
 	const op = errors.Op("jimm.EarliestControllerVersion")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	j.controllerVersion.mu.Lock()
+	defer j.controllerVersion.mu.Unlock()
+	if time.Now().Before(j.controllerVersion.expires) {
+		return j.controllerVersion.version, nil
+	}
+	v, err := j.earliestControllerVersion(ctx)
+	if err != nil {
+		return version.Number{}, errors.E(op, err)
+	}
+	j.controllerVersion.version = v
+	j.controllerVersion.expires = time.Now().Add(controllerVersionCacheDuration)
+	return v, nil
+}
+
+// InvalidateEarliestControllerVersion discards the cached earliest
+// controller version so that it is computed again when next requested.
+func (j *JIMM) InvalidateEarliestControllerVersion() {
+	j.controllerVersion.mu.Lock()
+	defer j.controllerVersion.mu.Unlock()
+	j.controllerVersion.expires = time.Time{}
+}
+
+// ControllerVersionChanged implements ControllerVersionNotifier by
+// invalidating the cached earliest controller version on every replica.
+func (j *JIMM) ControllerVersionChanged(ctx context.Context, ctl *dbmodel.Controller) {
+	j.invalidateCaches(ctx, ControllerVersionCache)
+}
+
+// earliestControllerVersion computes the earliest agent version of all
+// the controllers in the database.
+func (j *JIMM) earliestControllerVersion(ctx context.Context) (version.Number, error) {
+	const op = errors.Op("jimm.earliestControllerVersion")
 	var v *version.Number
 
 	err := j.Database.ForEachController(ctx, func(controller *dbmodel.Controller) error {
@@ -350,8 +526,10 @@ func (j *JIMM) EarliestControllerVersion(ctx context.Context) (version.Number, e
 
 // GetJimmControllerAccess returns the JIMM controller access level for the
 // requested user.
-func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error) {
+func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (_ string, err error) {
 	const op = errors.Op("jimm.GetJIMMControllerAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	// If the authenticated user is requesting the access level
 	// for him/her-self then we return that - either the user
@@ -394,8 +572,10 @@ func (j *JIMM) GetUserControllerAccess(ctx context.Context, user *openfga.User,
 }
 
 // ImportModel imports model with the specified UUID from the controller.
-func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error {
+func (j *JIMM) ImportModel(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) (err error) {
 	const op = errors.Op("jimm.ImportModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if err := j.checkJimmAdmin(user); err != nil {
 		return err
@@ -564,14 +744,16 @@ func (j *JIMM) handleModelDeltas(ctx context.Context, controller *dbmodel.Contro
 
 // SetControllerConfig changes the value of specified controller configuration
 // settings.
-func (j *JIMM) SetControllerConfig(ctx context.Context, user *openfga.User, args jujuparams.ControllerConfigSet) error {
+func (j *JIMM) SetControllerConfig(ctx context.Context, user *openfga.User, args jujuparams.ControllerConfigSet) (err error) {
 	const op = errors.Op("jimm.SetControllerConfig")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	err := j.Database.Transaction(func(tx *db.Database) error {
+	err = j.Database.Transaction(func(tx *db.Database) error {
 		config := dbmodel.ControllerConfig{
 			Name: "jimm",
 		}
@@ -594,13 +776,15 @@ func (j *JIMM) SetControllerConfig(ctx context.Context, user *openfga.User, args
 }
 
 // GetControllerConfig returns jimm's controller config.
-func (j *JIMM) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error) {
+func (j *JIMM) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (_ *dbmodel.ControllerConfig, err error) {
 	const op = errors.Op("jimm.GetControllerConfig")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	config := dbmodel.ControllerConfig{
 		Name:   "jimm",
 		Config: make(map[string]interface{}),
 	}
-	err := j.Database.GetControllerConfig(ctx, &config)
+	err = j.Database.GetControllerConfig(ctx, &config)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			return &config, nil
@@ -612,8 +796,10 @@ func (j *JIMM) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*d
 
 // UpdateMigratedModel asserts that the model has been migrated to the
 // specified controller and updates the internal model representation.
-func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) error {
+func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) (err error) {
 	const op = errors.Op("jimm.UpdateMigratedModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -625,7 +811,7 @@ func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, mode
 			Valid:  true,
 		},
 	}
-	err := j.Database.GetModel(ctx, &model)
+	err = j.Database.GetModel(ctx, &model)
 	if err != nil {
 		if errors.ErrorCode(err) == errors.CodeNotFound {
 			return errors.E(op, "model not found", errors.CodeModelNotFound)
@@ -643,37 +829,54 @@ func (j *JIMM) UpdateMigratedModel(ctx context.Context, user *openfga.User, mode
 		}
 		return errors.E(op, err)
 	}
+	if err := j.updateMigratedModel(ctx, &model, &targetController); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
 
+// updateMigratedModel asserts that the model has been migrated to the
+// given controller and updates the internal model representation,
+// recording any scheduled migration of the model as having succeeded.
+func (j *JIMM) updateMigratedModel(ctx context.Context, model *dbmodel.Model, targetController *dbmodel.Controller) error {
 	// check the model is known to the controller
-	api, err := j.dial(ctx, &targetController, names.ModelTag{})
+	api, err := j.dial(ctx, targetController, names.ModelTag{})
 	if err != nil {
-		return errors.E(op, err)
+		return err
 	}
 	defer api.Close()
 
 	err = api.ModelInfo(ctx, &jujuparams.ModelInfo{
-		UUID: modelTag.Id(),
+		UUID: model.UUID.String,
 	})
 	if err != nil {
-		return errors.E(op, err)
+		return err
 	}
 
-	model.Controller = targetController
+	model.Controller = *targetController
 	model.ControllerID = targetController.ID
-	err = j.Database.UpdateModel(ctx, &model)
+	err = j.Database.UpdateModel(ctx, model)
 	if err != nil {
 		zapctx.Error(ctx, "failed to update model", zap.String("model", model.UUID.String), zaputil.Error(err))
-		return errors.E(op, err)
+		return err
 	}
+	j.completeScheduledMigration(ctx, model.UUID.String, targetController.Name)
 
+	j.sendNotification(ctx, model.OwnerIdentityName, notify.Notification{
+		Kind:    notify.KindMigrationComplete,
+		Subject: fmt.Sprintf("Model %s migrated", model.Name),
+		Message: fmt.Sprintf("Model %s/%s (%s) has been migrated to controller %s.", model.OwnerIdentityName, model.Name, model.UUID.String, targetController.Name),
+	})
 	return nil
 }
 
 // InitiateMigration triggers the migration of the specified model to a target controller.
 // externalMigration indicates whether this model is moving to a controller managed by
 // JIMM or not.
-func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
+func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (_ jujuparams.InitiateMigrationResult, err error) {
 	const op = errors.Op("jimm.InitiateMigration")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	result := jujuparams.InitiateMigrationResult{
 		ModelTag: spec.ModelTag,
@@ -715,6 +918,19 @@ func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec j
 		return result, errors.E(op, "failed to retrieve the model from the database", err)
 	}
 
+	// Migrations are deferred while either controller is in a
+	// maintenance window. The target may not be known to JIMM.
+	migrationControllers := []*dbmodel.Controller{&model.Controller}
+	targetController := dbmodel.Controller{UUID: targetControllerTag.Id()}
+	if err := j.Database.GetController(ctx, &targetController); err == nil {
+		migrationControllers = append(migrationControllers, &targetController)
+	} else if errors.ErrorCode(err) != errors.CodeNotFound {
+		return result, errors.E(op, err)
+	}
+	if err := j.checkMigrationMaintenance(ctx, migrationControllers...); err != nil {
+		return result, errors.E(op, err)
+	}
+
 	api, err := j.dial(ctx, &model.Controller, names.ModelTag{})
 	if err != nil {
 		return result, errors.E(op, "failed to dial the controller", err)
@@ -738,3 +954,98 @@ func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec j
 	}
 	return result, nil
 }
+
+// A MigrateControllerCredentialsResult holds the result of a
+// MigrateControllerCredentials call.
+type MigrateControllerCredentialsResult struct {
+	// Controllers holds the names of the controllers whose admin
+	// credentials were moved to the credential store.
+	Controllers []string
+
+	// Skipped holds the names of the controllers whose admin
+	// credentials were left in the database because only one of the
+	// username and password is set.
+	Skipped []string
+
+	// RootKeys holds the number of macaroon root keys that were moved
+	// to the credential store.
+	RootKeys int
+}
+
+// MigrateControllerCredentials moves any controller admin credentials that
+// are still stored in the controllers table into the configured credential
+// store, clearing them from the database record. Controllers that hold
+// only one of a username and password are skipped and reported, as
+// storing an incomplete pair would remove any credentials already in the
+// store. If the credential store can hold macaroon root keys, the
+// unexpired root keys are also moved into it and all root keys are
+// removed from the database. This is intended to be run once on
+// deployments created before controller credentials were kept in the
+// credential store; subsequent runs are no-ops.
+func (j *JIMM) MigrateControllerCredentials(ctx context.Context, user *openfga.User) (_ *MigrateControllerCredentialsResult, err error) {
+	const op = errors.Op("jimm.MigrateControllerCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	cs := j.ControllerCredentialStore()
+	if cs == nil {
+		return nil, errors.E(op, errors.CodeServerConfiguration, "credential store not configured")
+	}
+
+	var result MigrateControllerCredentialsResult
+	var controllers []dbmodel.Controller
+	err = j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		switch {
+		case ctl.AdminIdentityName == "" && ctl.AdminPassword == "":
+		case ctl.AdminIdentityName == "" || ctl.AdminPassword == "":
+			result.Skipped = append(result.Skipped, ctl.Name)
+		default:
+			controllers = append(controllers, *ctl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	for _, ctl := range controllers {
+		err := cs.PutControllerCredentials(ctx, ctl.Name, ctl.AdminIdentityName, ctl.AdminPassword)
+		if err != nil {
+			return &result, errors.E(op, err, fmt.Sprintf("failed to store credentials for controller %q", ctl.Name))
+		}
+		ctl.AdminIdentityName = ""
+		ctl.AdminPassword = ""
+		if err := j.Database.UpdateController(ctx, &ctl); err != nil {
+			return &result, errors.E(op, err)
+		}
+		result.Controllers = append(result.Controllers, ctl.Name)
+	}
+
+	rks := j.RootKeyStore()
+	if rks == credentials.RootKeyStore(&j.Database) {
+		return &result, nil
+	}
+	err = j.Database.ForEachRootKey(ctx, func(rk *dbmodel.RootKey) error {
+		err := rks.InsertKey(dbrootkeystore.RootKey{
+			Id:      rk.ID,
+			Created: rk.CreatedAt,
+			Expires: rk.Expires,
+			RootKey: rk.RootKey,
+		})
+		if err != nil {
+			return err
+		}
+		result.RootKeys++
+		return nil
+	})
+	if err != nil {
+		return &result, errors.E(op, err, "failed to store root keys")
+	}
+	if _, err := j.Database.DeleteRootKeys(ctx); err != nil {
+		return &result, errors.E(op, err)
+	}
+	return &result, nil
+}
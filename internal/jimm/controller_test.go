@@ -12,6 +12,8 @@ import (
 
 	"github.com/canonical/ofga"
 	qt "github.com/frankban/quicktest"
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/juju/juju/api/base"
@@ -176,17 +178,216 @@ func TestAddController(t *testing.T) {
 		Name:              "test-controller-2",
 		AdminIdentityName: "admin",
 		AdminPassword:     "5ecret",
-		PublicAddress:     "example.com:443",
+		PublicAddress:     "example2.com:443",
 	}
 	err = j.AddController(context.Background(), alice, &ctl3)
-	c.Assert(err, qt.IsNil)
+	c.Assert(err, qt.ErrorMatches, `controller "test-controller-2" has the same UUID as existing controller "test-controller"`)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
 
 	ctl4 := dbmodel.Controller{
+		Name:              "test-controller-2",
+		UUID:              "00000001-0000-0000-0000-000000000001",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+		PublicAddress:     "example.com:443",
+	}
+	err = j.AddController(context.Background(), alice, &ctl4)
+	c.Assert(err, qt.ErrorMatches, `controller "test-controller-2" has the same public address as existing controller "test-controller"`)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	ctl5 := dbmodel.Controller{
+		Name:              "test-controller-2",
+		UUID:              "00000001-0000-0000-0000-000000000001",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+		PublicAddress:     "example2.com:443",
+	}
+	err = j.AddController(context.Background(), alice, &ctl5)
+	c.Assert(err, qt.IsNil)
+
+	ctl6 := dbmodel.Controller{
 		Name: "test-controller-2",
 	}
-	err = j.Database.GetController(ctx, &ctl4)
+	err = j.Database.GetController(ctx, &ctl6)
 	c.Assert(err, qt.IsNil)
-	c.Check(ctl4, qt.CmpEquals(cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(dbmodel.CloudRegion{})), ctl3)
+	c.Check(ctl6, qt.CmpEquals(cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(dbmodel.CloudRegion{})), ctl5)
+}
+
+func TestAddControllerWithDiscovery(t *testing.T) {
+	c := qt.New(t)
+
+	regions := []jujuparams.CloudRegion{{Name: "eu-west-1"}, {Name: "eu-west-2"}}
+	api := &jimmtest.API{
+		Clouds_: func(context.Context) (map[names.CloudTag]jujuparams.Cloud, error) {
+			return map[names.CloudTag]jujuparams.Cloud{
+				names.NewCloudTag("aws"): {
+					Type:      "ec2",
+					AuthTypes: []string{"userpass"},
+					Regions:   regions,
+				},
+			}, nil
+		},
+		ControllerModelSummary_: func(_ context.Context, ms *jujuparams.ModelSummary) error {
+			ms.Name = "controller"
+			ms.UUID = "5fddf0ed-83d5-47e8-ae7b-a4b27fc04a9f"
+			ms.ControllerUUID = jimmtest.DefaultControllerUUID
+			ms.IsController = true
+			ms.CloudTag = "cloud-aws"
+			ms.CloudRegion = "eu-west-1"
+			ms.AgentVersion = newVersion("1.2.3")
+			return nil
+		},
+	}
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+		OpenFGAClient:             client,
+		AllowDuplicateControllers: true,
+		DefaultRegionPriority:     5,
+	}
+
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := openfga.NewUser(u, client)
+	alice.JimmAdmin = true
+
+	ctl1 := dbmodel.Controller{
+		Name:          "controller-1",
+		PublicAddress: "example.com:443",
+	}
+	discovered, err := j.AddControllerWithDiscovery(ctx, alice, &ctl1)
+	c.Assert(err, qt.IsNil)
+	c.Check(discovered, qt.DeepEquals, []jimm.DiscoveredCloudRegion{{
+		CloudName:  "aws",
+		RegionName: "eu-west-1",
+		Priority:   dbmodel.CloudRegionControllerPriorityDeployed,
+		New:        true,
+	}, {
+		CloudName:  "aws",
+		RegionName: "eu-west-2",
+		Priority:   5,
+		New:        true,
+	}})
+
+	regions = []jujuparams.CloudRegion{{Name: "eu-west-1"}, {Name: "eu-west-3"}}
+	ctl2 := dbmodel.Controller{
+		Name:          "controller-2",
+		PublicAddress: "example2.com:443",
+	}
+	discovered, err = j.AddControllerWithDiscovery(ctx, alice, &ctl2)
+	c.Assert(err, qt.IsNil)
+	c.Check(discovered, qt.DeepEquals, []jimm.DiscoveredCloudRegion{{
+		CloudName:  "aws",
+		RegionName: "eu-west-1",
+		Priority:   dbmodel.CloudRegionControllerPriorityDeployed,
+	}, {
+		CloudName:  "aws",
+		RegionName: "eu-west-3",
+		Priority:   5,
+		New:        true,
+	}})
+
+	cloud := dbmodel.Cloud{Name: "aws"}
+	err = j.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, qt.IsNil)
+	c.Check(cloud.Regions, qt.HasLen, 3)
+}
+
+func TestAddControllerWithSecretStore(t *testing.T) {
+	c := qt.New(t)
+
+	api := &jimmtest.API{
+		Clouds_: func(context.Context) (map[names.CloudTag]jujuparams.Cloud, error) {
+			return map[names.CloudTag]jujuparams.Cloud{
+				names.NewCloudTag("aws"): {
+					Type:      "ec2",
+					AuthTypes: []string{"userpass"},
+					Regions:   []jujuparams.CloudRegion{{Name: "eu-west-1"}},
+				},
+			}, nil
+		},
+		ControllerModelSummary_: func(_ context.Context, ms *jujuparams.ModelSummary) error {
+			ms.Name = "controller"
+			ms.UUID = "5fddf0ed-83d5-47e8-ae7b-a4b27fc04a9f"
+			ms.ControllerUUID = jimmtest.DefaultControllerUUID
+			ms.IsController = true
+			ms.CloudTag = "cloud-aws"
+			ms.CloudRegion = "eu-west-1"
+			ms.AgentVersion = newVersion("1.2.3")
+			return nil
+		},
+	}
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	credentialStore := jimmtest.NewInMemoryCredentialStore()
+	secretStore := jimmtest.NewInMemoryCredentialStore()
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+		OpenFGAClient:   client,
+		CredentialStore: credentialStore,
+		SecretStore:     secretStore,
+	}
+
+	ctx := context.Background()
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := openfga.NewUser(u, client)
+	alice.JimmAdmin = true
+
+	ctl := dbmodel.Controller{
+		Name:              "controller-1",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+		CACertificate:     "CA CERT",
+		PublicAddress:     "example.com:443",
+	}
+	err = j.AddController(ctx, alice, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.CACertificate, qt.Equals, "CA CERT")
+
+	username, password, err := secretStore.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "5ecret")
+	_, _, err = credentialStore.GetControllerCredentials(ctx, "controller-1")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	caCert, err := secretStore.GetControllerCACertificate(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(caCert, qt.Equals, "CA CERT")
+
+	dbCtl := dbmodel.Controller{Name: "controller-1"}
+	err = j.Database.GetController(ctx, &dbCtl)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCtl.CACertificate, qt.Equals, "")
+
+	err = j.LoadControllerCACertificate(ctx, &dbCtl)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCtl.CACertificate, qt.Equals, "CA CERT")
 }
 
 func TestAddControllerWithVault(t *testing.T) {
@@ -425,6 +626,23 @@ func TestEarliestControllerVersion(t *testing.T) {
 	v, err := j.EarliestControllerVersion(ctx)
 	c.Assert(err, qt.Equals, nil)
 	c.Assert(v, qt.DeepEquals, semversion.MustParse("2.1.0"))
+
+	ctl := dbmodel.Controller{Name: "test3"}
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	ctl.AgentVersion = "3.1.0"
+	err = j.Database.UpdateController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+
+	// The earliest version is cached until a change is observed.
+	v, err = j.EarliestControllerVersion(ctx)
+	c.Assert(err, qt.Equals, nil)
+	c.Assert(v, qt.DeepEquals, semversion.MustParse("2.1.0"))
+
+	j.ControllerVersionChanged(ctx, &ctl)
+	v, err = j.EarliestControllerVersion(ctx)
+	c.Assert(err, qt.Equals, nil)
+	c.Assert(v, qt.DeepEquals, semversion.MustParse("3.1.0"))
 }
 
 const testImportModelEnv = `
@@ -1199,6 +1417,139 @@ models:
   agent-version: 1.2.3
 `
 
+// rootKeyCredentialStore is an in-memory credential store that can also
+// hold macaroon root keys.
+type rootKeyCredentialStore struct {
+	*jimmtest.InMemoryCredentialStore
+
+	keys []dbrootkeystore.RootKey
+}
+
+func (s *rootKeyCredentialStore) GetKey(id []byte) (dbrootkeystore.RootKey, error) {
+	for _, rk := range s.keys {
+		if string(rk.Id) == string(id) {
+			return rk, nil
+		}
+	}
+	return dbrootkeystore.RootKey{}, bakery.ErrNotFound
+}
+
+func (s *rootKeyCredentialStore) FindLatestKey(createdAfter, expiresAfter, expiresBefore time.Time) (dbrootkeystore.RootKey, error) {
+	return dbrootkeystore.RootKey{}, nil
+}
+
+func (s *rootKeyCredentialStore) InsertKey(rk dbrootkeystore.RootKey) error {
+	s.keys = append(s.keys, rk)
+	return nil
+}
+
+func TestMigrateControllerCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	store := &rootKeyCredentialStore{
+		InMemoryCredentialStore: jimmtest.NewInMemoryCredentialStore(),
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		CredentialStore: store,
+		OpenFGAClient:   client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	ctl1 := dbmodel.Controller{
+		Name:              "controller-1",
+		UUID:              "00000001-0000-0000-0000-000000000001",
+		AdminIdentityName: "admin",
+		AdminPassword:     "5ecret",
+	}
+	err = j.Database.AddController(ctx, &ctl1)
+	c.Assert(err, qt.IsNil)
+	ctl2 := dbmodel.Controller{
+		Name: "controller-2",
+		UUID: "00000001-0000-0000-0000-000000000002",
+	}
+	err = j.Database.AddController(ctx, &ctl2)
+	c.Assert(err, qt.IsNil)
+	ctl3 := dbmodel.Controller{
+		Name:              "controller-3",
+		UUID:              "00000001-0000-0000-0000-000000000003",
+		AdminIdentityName: "admin",
+	}
+	err = j.Database.AddController(ctx, &ctl3)
+	c.Assert(err, qt.IsNil)
+	err = store.PutControllerCredentials(ctx, "controller-3", "admin", "0ld-5ecret")
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	rk := dbrootkeystore.RootKey{
+		Id:      []byte("root-key-1"),
+		Created: now,
+		Expires: now.Add(time.Hour),
+		RootKey: []byte("secret"),
+	}
+	err = j.Database.InsertKey(rk)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = j.MigrateControllerCredentials(ctx, openfga.NewUser(u, client))
+	c.Assert(err, qt.ErrorMatches, `unauthorized`)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u, err = dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := openfga.NewUser(u, client)
+	alice.JimmAdmin = true
+
+	result, err := j.MigrateControllerCredentials(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(result, qt.DeepEquals, &jimm.MigrateControllerCredentialsResult{
+		Controllers: []string{"controller-1"},
+		Skipped:     []string{"controller-3"},
+		RootKeys:    1,
+	})
+
+	username, password, err := store.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "5ecret")
+
+	ctl := dbmodel.Controller{Name: "controller-1"}
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.AdminIdentityName, qt.Equals, "")
+	c.Check(ctl.AdminPassword, qt.Equals, "")
+
+	// The incomplete credentials are left alone, as are those already
+	// in the store.
+	ctl = dbmodel.Controller{Name: "controller-3"}
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.AdminIdentityName, qt.Equals, "admin")
+	username, password, err = store.GetControllerCredentials(ctx, "controller-3")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "0ld-5ecret")
+
+	c.Check(store.keys, qt.DeepEquals, []dbrootkeystore.RootKey{rk})
+	_, err = j.Database.GetKey(rk.Id)
+	c.Check(err, qt.Equals, bakery.ErrNotFound)
+
+	result, err = j.MigrateControllerCredentials(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(result.Controllers, qt.HasLen, 0)
+	c.Check(result.Skipped, qt.DeepEquals, []string{"controller-3"})
+	c.Check(result.RootKeys, qt.Equals, 0)
+}
+
 func TestUpdateMigratedModel(t *testing.T) {
 	c := qt.New(t)
 
@@ -0,0 +1,160 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// RotateControllerAdminPasswords changes the password of JIMM's admin
+// user on every controller and stores the new passwords in the
+// credential store. The names of the controllers whose passwords were
+// changed are returned. A failure to rotate one controller's password is
+// logged and does not prevent the others being rotated; the last such
+// error is returned.
+//
+// JIMM's API connections to controllers authenticate with JWTs, so
+// changing the admin password does not affect established or in-flight
+// requests. The password is only used when new HTTP streams are proxied
+// to a controller, and when a controller is the target of a migration,
+// and in both cases it is read from the credential store at the time of
+// use.
+//
+// Controllers whose credentials are still held in the database, rather
+// than the credential store, are skipped: their credentials must first
+// be moved with MigrateControllerCredentials.
+func (j *JIMM) RotateControllerAdminPasswords(ctx context.Context) (_ []string, err error) {
+	const op = errors.Op("jimm.RotateControllerAdminPasswords")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if j.ControllerCredentialStore() == nil {
+		return nil, errors.E(op, errors.CodeServerConfiguration, "credential store not configured")
+	}
+
+	var controllers []dbmodel.Controller
+	err = j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		controllers = append(controllers, *ctl)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var rotated []string
+	var rotateErr error
+	for i := range controllers {
+		ctl := &controllers[i]
+		if ctl.AdminIdentityName != "" || ctl.AdminPassword != "" {
+			zapctx.Warn(ctx, "not rotating controller admin password held in the database", zap.String("controller", ctl.Name))
+			continue
+		}
+		if err := j.rotateControllerAdminPassword(ctx, ctl); err != nil {
+			zapctx.Error(ctx, "failed to rotate controller admin password", zap.String("controller", ctl.Name), zap.Error(err))
+			rotateErr = errors.E(op, err, fmt.Sprintf("failed to rotate admin password for controller %q", ctl.Name))
+			continue
+		}
+		rotated = append(rotated, ctl.Name)
+	}
+	return rotated, rotateErr
+}
+
+// rotateControllerAdminPassword changes the admin password on the given
+// controller and stores the new password. If the new password cannot be
+// stored the previous password is restored on the controller.
+func (j *JIMM) rotateControllerAdminPassword(ctx context.Context, ctl *dbmodel.Controller) error {
+	username, oldPassword, err := j.ControllerCredentialStore().GetControllerCredentials(ctx, ctl.Name)
+	if err != nil {
+		return err
+	}
+	if username == "" || oldPassword == "" {
+		return errors.E(errors.CodeNotFound, "controller credentials not found")
+	}
+	newPassword, err := newControllerPassword()
+	if err != nil {
+		return err
+	}
+
+	api, err := j.dial(ctx, ctl, names.ModelTag{})
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	user := names.NewUserTag(username)
+	if err := api.SetPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+	if err := j.ControllerCredentialStore().PutControllerCredentials(ctx, ctl.Name, username, newPassword); err != nil {
+		if rerr := api.SetPassword(ctx, user, oldPassword); rerr != nil {
+			// The controller now has a password that JIMM does not
+			// know, it must be reset by the controller's operator.
+			zapctx.Error(ctx, "failed to restore controller admin password", zap.String("controller", ctl.Name), zap.Error(rerr))
+		}
+		return errors.E(err, "failed to store controller credentials")
+	}
+	return nil
+}
+
+// newControllerPassword returns a new random controller admin password.
+func newControllerPassword() (string, error) {
+	var buf [24]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
+
+// controllerPasswordRotationService periodically rotates the admin
+// passwords JIMM uses on its controllers.
+type controllerPasswordRotationService struct {
+	jimm     *JIMM
+	interval time.Duration
+}
+
+// NewControllerPasswordRotationService returns a service that rotates
+// the controller admin passwords every interval.
+func NewControllerPasswordRotationService(j *JIMM, interval time.Duration) *controllerPasswordRotationService {
+	return &controllerPasswordRotationService{
+		jimm:     j,
+		interval: interval,
+	}
+}
+
+// Start starts a routine which periodically rotates the controller admin
+// passwords.
+func (s *controllerPasswordRotationService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *controllerPasswordRotationService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			rotated, err := s.jimm.RotateControllerAdminPasswords(ctx)
+			if err != nil {
+				zapctx.Error(ctx, "failed to rotate controller admin passwords", zap.Error(err))
+			}
+			zapctx.Info(ctx, "rotated controller admin passwords", zap.Strings("controllers", rotated))
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting controller password rotation")
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+const rotateControllerAdminPasswordsTestEnv = `clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region-1
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  agent-version: 3.3.0
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+  agent-version: 3.3.0
+`
+
+// failingControllerCredentialStore is a credential store that cannot
+// store controller credentials.
+type failingControllerCredentialStore struct {
+	*jimmtest.InMemoryCredentialStore
+}
+
+func (failingControllerCredentialStore) PutControllerCredentials(context.Context, string, string, string) error {
+	return errors.E("store unavailable")
+}
+
+func TestRotateControllerAdminPasswords(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	passwords := make(map[string]string)
+	store := jimmtest.NewInMemoryCredentialStore()
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		CredentialStore: store,
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				SetPassword_: func(_ context.Context, user names.UserTag, password string) error {
+					passwords[user.Id()] = password
+					return nil
+				},
+			},
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, rotateControllerAdminPasswordsTestEnv)
+	env.PopulateDB(c, j.Database)
+
+	err = store.PutControllerCredentials(ctx, "controller-1", "admin", "old-password")
+	c.Assert(err, qt.IsNil)
+
+	// controller-2 has no stored credentials, so cannot be rotated, but
+	// this does not prevent controller-1 being rotated.
+	rotated, err := j.RotateControllerAdminPasswords(ctx)
+	c.Check(err, qt.ErrorMatches, `failed to rotate admin password for controller "controller-2"`)
+	c.Check(rotated, qt.DeepEquals, []string{"controller-1"})
+
+	username, password, err := store.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Not(qt.Equals), "old-password")
+	c.Check(passwords["admin"], qt.Equals, password)
+
+	// If the new password cannot be stored the old password is restored
+	// on the controller.
+	j.CredentialStore = failingControllerCredentialStore{store}
+	rotated, err = j.RotateControllerAdminPasswords(ctx)
+	c.Check(err, qt.Not(qt.IsNil))
+	c.Check(rotated, qt.HasLen, 0)
+	c.Check(passwords["admin"], qt.Equals, password)
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// ControllerSelectionRequest describes the request for which a
+// controller is being selected.
+type ControllerSelectionRequest struct {
+	// Owner is the owner of the model or cloud being created.
+	Owner names.UserTag
+
+	// Cloud is the cloud on which the model or cloud will be hosted.
+	Cloud names.CloudTag
+}
+
+// A ControllerSelector chooses which of the controllers able to host a
+// new model or cloud is used.
+type ControllerSelector interface {
+	// SelectController returns the candidate to use for the given
+	// request. Candidates is never empty and may be reordered by the
+	// selector.
+	SelectController(ctx context.Context, req ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error)
+}
+
+// Controller selector names understood by NewControllerSelector.
+const (
+	ControllerSelectorRegionPriority = "region-priority"
+	ControllerSelectorLeastLoaded    = "least-loaded"
+	ControllerSelectorRoundRobin     = "round-robin"
+	ControllerSelectorTagAffinity    = "tag-affinity"
+)
+
+// NewControllerSelector returns the built-in ControllerSelector with the
+// given name. An empty name selects ControllerSelectorRegionPriority.
+// The affinities are only used by ControllerSelectorTagAffinity, see
+// TagAffinitySelector.
+func NewControllerSelector(name string, database ModelCounter, affinities map[string][]string) (ControllerSelector, error) {
+	switch name {
+	case "", ControllerSelectorRegionPriority:
+		return RegionPrioritySelector{}, nil
+	case ControllerSelectorLeastLoaded:
+		return LeastLoadedSelector{Database: database}, nil
+	case ControllerSelectorRoundRobin:
+		return &RoundRobinSelector{}, nil
+	case ControllerSelectorTagAffinity:
+		return TagAffinitySelector{Affinities: affinities}, nil
+	default:
+		return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("unknown controller selector %q", name))
+	}
+}
+
+// ParseControllerAffinities parses affinities in the form
+// "<tag>=<controller>[,<controller>...]" separated by whitespace, for
+// example "user-alice@canonical.com=ctl-1 cloud-aws=ctl-2,ctl-3".
+func ParseControllerAffinities(s string) (map[string][]string, error) {
+	affinities := make(map[string][]string)
+	for _, f := range strings.Fields(s) {
+		tag, controllers, ok := strings.Cut(f, "=")
+		if !ok || tag == "" || controllers == "" {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid controller affinity %q", f))
+		}
+		if _, err := names.ParseTag(tag); err != nil {
+			return nil, errors.E(errors.CodeBadRequest, err)
+		}
+		affinities[tag] = append(affinities[tag], strings.Split(controllers, ",")...)
+	}
+	return affinities, nil
+}
+
+// selectController selects a controller from the candidates using the
+// configured ControllerSelector.
+func (j *JIMM) selectController(ctx context.Context, req ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	var s ControllerSelector = RegionPrioritySelector{}
+	if j.ControllerSelector != nil {
+		s = j.ControllerSelector
+	}
+	return s.SelectController(ctx, req, candidates)
+}
+
+// RegionPrioritySelector selects a random controller from those with the
+// highest priority for the cloud region. This is the default selector.
+type RegionPrioritySelector struct{}
+
+// SelectController implements ControllerSelector.
+func (RegionPrioritySelector) SelectController(_ context.Context, _ ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	shuffleRegionControllers(candidates)
+	return &candidates[0], nil
+}
+
+// ModelCounter counts the models hosted on a controller.
+type ModelCounter interface {
+	CountModelsByController(ctx context.Context, ctl dbmodel.Controller) (int, error)
+}
+
+// LeastLoadedSelector selects the controller hosting the fewest models.
+// Ties are broken using the region priority.
+type LeastLoadedSelector struct {
+	Database ModelCounter
+}
+
+// SelectController implements ControllerSelector.
+func (s LeastLoadedSelector) SelectController(ctx context.Context, _ ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	const op = errors.Op("jimm.LeastLoadedSelector.SelectController")
+
+	shuffleRegionControllers(candidates)
+	counts := make(map[uint]int, len(candidates))
+	for _, c := range candidates {
+		if _, ok := counts[c.ControllerID]; ok {
+			continue
+		}
+		n, err := s.Database.CountModelsByController(ctx, c.Controller)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		counts[c.ControllerID] = n
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return counts[candidates[i].ControllerID] < counts[candidates[j].ControllerID]
+	})
+	return &candidates[0], nil
+}
+
+// RoundRobinSelector selects each candidate controller in turn. A
+// RoundRobinSelector must not be copied after first use.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	next uint
+}
+
+// SelectController implements ControllerSelector.
+func (s *RoundRobinSelector) SelectController(_ context.Context, _ ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Controller.Name < candidates[j].Controller.Name
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &candidates[s.next%uint(len(candidates))]
+	s.next++
+	return c, nil
+}
+
+// TagAffinitySelector prefers controllers that have an affinity with the
+// owner or cloud of the request. Affinities maps a user or cloud tag to
+// the names of the controllers to use for it, owner affinities take
+// precedence over cloud affinities. If no preferred controller is a
+// candidate then all candidates are considered. The final choice is made
+// using the region priority.
+type TagAffinitySelector struct {
+	Affinities map[string][]string
+}
+
+// SelectController implements ControllerSelector.
+func (s TagAffinitySelector) SelectController(ctx context.Context, req ControllerSelectionRequest, candidates []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	for _, tag := range []names.Tag{req.Owner, req.Cloud} {
+		if tag.Id() == "" {
+			continue
+		}
+		preferred := make(map[string]bool)
+		for _, name := range s.Affinities[tag.String()] {
+			preferred[name] = true
+		}
+		var matched []dbmodel.CloudRegionControllerPriority
+		for _, c := range candidates {
+			if preferred[c.Controller.Name] {
+				matched = append(matched, c)
+			}
+		}
+		if len(matched) > 0 {
+			return RegionPrioritySelector{}.SelectController(ctx, req, matched)
+		}
+	}
+	return RegionPrioritySelector{}.SelectController(ctx, req, candidates)
+}
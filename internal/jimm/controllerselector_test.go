@@ -0,0 +1,113 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+func selectorCandidates() []dbmodel.CloudRegionControllerPriority {
+	return []dbmodel.CloudRegionControllerPriority{{
+		ControllerID: 1,
+		Controller:   dbmodel.Controller{ID: 1, Name: "controller-1"},
+		Priority:     dbmodel.CloudRegionControllerPrioritySupported,
+	}, {
+		ControllerID: 2,
+		Controller:   dbmodel.Controller{ID: 2, Name: "controller-2"},
+		Priority:     dbmodel.CloudRegionControllerPriorityDeployed,
+	}, {
+		ControllerID: 3,
+		Controller:   dbmodel.Controller{ID: 3, Name: "controller-3"},
+		Priority:     dbmodel.CloudRegionControllerPrioritySupported,
+	}}
+}
+
+type modelCounter map[string]int
+
+func (c modelCounter) CountModelsByController(_ context.Context, ctl dbmodel.Controller) (int, error) {
+	return c[ctl.Name], nil
+}
+
+func TestControllerSelectors(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	c.Run("region priority", func(c *qt.C) {
+		s, err := jimm.NewControllerSelector("", nil, nil)
+		c.Assert(err, qt.IsNil)
+		sel, err := s.SelectController(ctx, jimm.ControllerSelectionRequest{}, selectorCandidates())
+		c.Assert(err, qt.IsNil)
+		c.Check(sel.Controller.Name, qt.Equals, "controller-2")
+	})
+
+	c.Run("least loaded", func(c *qt.C) {
+		s, err := jimm.NewControllerSelector(jimm.ControllerSelectorLeastLoaded, modelCounter{
+			"controller-1": 3,
+			"controller-2": 5,
+			"controller-3": 1,
+		}, nil)
+		c.Assert(err, qt.IsNil)
+		sel, err := s.SelectController(ctx, jimm.ControllerSelectionRequest{}, selectorCandidates())
+		c.Assert(err, qt.IsNil)
+		c.Check(sel.Controller.Name, qt.Equals, "controller-3")
+	})
+
+	c.Run("round robin", func(c *qt.C) {
+		s, err := jimm.NewControllerSelector(jimm.ControllerSelectorRoundRobin, nil, nil)
+		c.Assert(err, qt.IsNil)
+		var selected []string
+		for i := 0; i < 4; i++ {
+			sel, err := s.SelectController(ctx, jimm.ControllerSelectionRequest{}, selectorCandidates())
+			c.Assert(err, qt.IsNil)
+			selected = append(selected, sel.Controller.Name)
+		}
+		c.Check(selected, qt.DeepEquals, []string{"controller-1", "controller-2", "controller-3", "controller-1"})
+	})
+
+	c.Run("tag affinity", func(c *qt.C) {
+		affinities, err := jimm.ParseControllerAffinities("user-alice@canonical.com=controller-3 cloud-aws=controller-1,controller-4")
+		c.Assert(err, qt.IsNil)
+		s, err := jimm.NewControllerSelector(jimm.ControllerSelectorTagAffinity, nil, affinities)
+		c.Assert(err, qt.IsNil)
+
+		sel, err := s.SelectController(ctx, jimm.ControllerSelectionRequest{
+			Owner: names.NewUserTag("alice@canonical.com"),
+			Cloud: names.NewCloudTag("aws"),
+		}, selectorCandidates())
+		c.Assert(err, qt.IsNil)
+		c.Check(sel.Controller.Name, qt.Equals, "controller-3")
+
+		sel, err = s.SelectController(ctx, jimm.ControllerSelectionRequest{
+			Owner: names.NewUserTag("bob@canonical.com"),
+			Cloud: names.NewCloudTag("aws"),
+		}, selectorCandidates())
+		c.Assert(err, qt.IsNil)
+		c.Check(sel.Controller.Name, qt.Equals, "controller-1")
+
+		sel, err = s.SelectController(ctx, jimm.ControllerSelectionRequest{
+			Owner: names.NewUserTag("bob@canonical.com"),
+			Cloud: names.NewCloudTag("gce"),
+		}, selectorCandidates())
+		c.Assert(err, qt.IsNil)
+		c.Check(sel.Controller.Name, qt.Equals, "controller-2")
+	})
+
+	c.Run("unknown selector", func(c *qt.C) {
+		_, err := jimm.NewControllerSelector("random", nil, nil)
+		c.Check(err, qt.ErrorMatches, `unknown controller selector "random"`)
+	})
+}
+
+func TestParseControllerAffinitiesError(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := jimm.ParseControllerAffinities("user-alice@canonical.com")
+	c.Check(err, qt.ErrorMatches, `invalid controller affinity "user-alice@canonical.com"`)
+}
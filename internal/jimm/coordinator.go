@@ -0,0 +1,181 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// leaseReleaseTimeout is the time allowed to release a worker's lease
+// once the worker has stopped.
+const leaseReleaseTimeout = 5 * time.Second
+
+// A LeaseDatabase stores the leases that determine which replica runs
+// each background worker.
+type LeaseDatabase interface {
+	AcquireWorkerLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error)
+	ReleaseWorkerLease(ctx context.Context, name, holder string) error
+	ListWorkerLeases(ctx context.Context) ([]dbmodel.WorkerLease, error)
+}
+
+// A WorkerCoordinator ensures that each of JIMM's background workers is
+// run by only one replica at a time when several replicas share a
+// database. Each worker has its own lease, so different workers may run
+// on different replicas. A WorkerCoordinator must not be copied after
+// first use.
+type WorkerCoordinator struct {
+	// Database holds the worker leases.
+	Database LeaseDatabase
+
+	// Holder uniquely identifies this replica.
+	Holder string
+
+	// LeaseDuration is the time for which a lease is held without being
+	// renewed. Leases are renewed three times per LeaseDuration. If
+	// LeaseDuration is zero there is no coordination and every worker is
+	// run immediately.
+	LeaseDuration time.Duration
+
+	mu      sync.Mutex
+	leading map[string]bool
+}
+
+// A WorkerStatus describes the leadership of a background worker.
+type WorkerStatus struct {
+	// Leader is true if this replica is running the worker.
+	Leader bool `json:"leader"`
+
+	// Holder identifies the replica holding the worker's lease, if any.
+	Holder string `json:"holder,omitempty"`
+
+	// ExpiresAt holds the time the lease expires unless renewed.
+	ExpiresAt *time.Time `json:"expires-at,omitempty"`
+}
+
+// Run runs f whenever this replica holds the lease on the named worker.
+// The context passed to f is canceled if the lease is lost, f is started
+// again if the lease is later reacquired. Run returns when the given
+// context is canceled or f returns, in which case the lease is released
+// and the error from f is returned.
+func (c *WorkerCoordinator) Run(ctx context.Context, name string, f func(context.Context) error) error {
+	const op = errors.Op("jimm.WorkerCoordinator.Run")
+
+	if c.LeaseDuration <= 0 {
+		c.setLeading(name, true)
+		defer c.setLeading(name, false)
+		return f(ctx)
+	}
+	if c.Holder == "" {
+		return errors.E(op, errors.CodeServerConfiguration, "worker lease holder not specified")
+	}
+	c.setLeading(name, false)
+
+	var cancel context.CancelFunc
+	var done chan error
+	stop := func() {
+		if cancel == nil {
+			return
+		}
+		cancel()
+		<-done
+		cancel, done = nil, nil
+		c.setLeading(name, false)
+		zapctx.Info(ctx, "stopped worker", zap.String("worker", name))
+	}
+	defer func() {
+		stop()
+		rctx, rcancel := context.WithTimeout(context.WithoutCancel(ctx), leaseReleaseTimeout)
+		defer rcancel()
+		if err := c.Database.ReleaseWorkerLease(rctx, name, c.Holder); err != nil {
+			zapctx.Error(ctx, "failed to release worker lease", zap.String("worker", name), zap.Error(err))
+		}
+	}()
+
+	ticker := time.NewTicker(c.LeaseDuration / 3)
+	defer ticker.Stop()
+	var deadline time.Time
+	for {
+		start := time.Now()
+		ok, err := c.Database.AcquireWorkerLease(ctx, name, c.Holder, c.LeaseDuration)
+		if err != nil {
+			zapctx.Error(ctx, "failed to acquire worker lease", zap.String("worker", name), zap.Error(err))
+			// Keep running only while the lease is certain to be
+			// held until the next attempt to renew it.
+			ok = cancel != nil && time.Now().Add(c.LeaseDuration/3).Before(deadline)
+		} else if ok {
+			deadline = start.Add(c.LeaseDuration)
+		}
+		switch {
+		case ok && cancel == nil:
+			wctx, wcancel := context.WithCancel(ctx)
+			cancel, done = wcancel, make(chan error, 1)
+			go func() {
+				done <- f(wctx)
+			}()
+			c.setLeading(name, true)
+			zapctx.Info(ctx, "started worker", zap.String("worker", name))
+		case !ok && cancel != nil:
+			stop()
+		}
+
+		select {
+		case <-ticker.C:
+		case err := <-done:
+			cancel()
+			cancel, done = nil, nil
+			c.setLeading(name, false)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Status returns the status of every worker run by this coordinator,
+// keyed by worker name. If leases are in use, the status of workers run
+// only by other replicas is also included.
+func (c *WorkerCoordinator) Status(ctx context.Context) (map[string]WorkerStatus, error) {
+	const op = errors.Op("jimm.WorkerCoordinator.Status")
+
+	status := make(map[string]WorkerStatus)
+	c.mu.Lock()
+	for name, leading := range c.leading {
+		status[name] = WorkerStatus{Leader: leading}
+	}
+	c.mu.Unlock()
+	if c.LeaseDuration <= 0 {
+		return status, nil
+	}
+
+	leases, err := c.Database.ListWorkerLeases(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for _, l := range leases {
+		ws := status[l.Name]
+		ws.Holder = l.Holder
+		ws.ExpiresAt = &l.ExpiresAt
+		status[l.Name] = ws
+	}
+	return status, nil
+}
+
+func (c *WorkerCoordinator) setLeading(name string, leading bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.leading == nil {
+		c.leading = make(map[string]bool)
+	}
+	c.leading[name] = leading
+}
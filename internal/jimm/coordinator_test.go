@@ -0,0 +1,179 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+// leaseDatabase is an in-memory jimm.LeaseDatabase.
+type leaseDatabase struct {
+	mu     sync.Mutex
+	leases map[string]dbmodel.WorkerLease
+	err    error
+}
+
+func (d *leaseDatabase) AcquireWorkerLease(_ context.Context, name, holder string, duration time.Duration) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return false, d.err
+	}
+	if d.leases == nil {
+		d.leases = make(map[string]dbmodel.WorkerLease)
+	}
+	l, ok := d.leases[name]
+	if ok && l.Holder != holder && l.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	d.leases[name] = dbmodel.WorkerLease{Name: name, Holder: holder, ExpiresAt: time.Now().Add(duration)}
+	return true, nil
+}
+
+func (d *leaseDatabase) ReleaseWorkerLease(_ context.Context, name, holder string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if l, ok := d.leases[name]; ok && l.Holder == holder {
+		delete(d.leases, name)
+	}
+	return nil
+}
+
+func (d *leaseDatabase) ListWorkerLeases(context.Context) ([]dbmodel.WorkerLease, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var leases []dbmodel.WorkerLease
+	for _, l := range d.leases {
+		leases = append(leases, l)
+	}
+	return leases, nil
+}
+
+func (d *leaseDatabase) setError(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
+
+func TestWorkerCoordinatorSingleLeader(t *testing.T) {
+	c := qt.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := &leaseDatabase{}
+	c1 := &jimm.WorkerCoordinator{Database: db, Holder: "replica-1", LeaseDuration: 150 * time.Millisecond}
+	c2 := &jimm.WorkerCoordinator{Database: db, Holder: "replica-2", LeaseDuration: 150 * time.Millisecond}
+
+	started := make(chan string, 2)
+	worker := func(holder string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			started <- holder
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}
+	ctx1, cancel1 := context.WithCancel(ctx)
+	done1 := make(chan error)
+	go func() { done1 <- c1.Run(ctx1, "worker", worker("replica-1")) }()
+	c.Assert(<-started, qt.Equals, "replica-1")
+
+	done2 := make(chan error)
+	go func() { done2 <- c2.Run(ctx, "worker", worker("replica-2")) }()
+	select {
+	case h := <-started:
+		c.Fatalf("worker started on %s while lease held", h)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	status, err := c2.Status(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(status["worker"].Leader, qt.IsFalse)
+	c.Check(status["worker"].Holder, qt.Equals, "replica-1")
+
+	// Stopping the first replica releases the lease.
+	cancel1()
+	c.Assert(<-done1, qt.IsNil)
+	c.Assert(<-started, qt.Equals, "replica-2")
+
+	status, err = c2.Status(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(status["worker"].Leader, qt.IsTrue)
+	c.Check(status["worker"].Holder, qt.Equals, "replica-2")
+
+	cancel()
+	c.Assert(<-done2, qt.IsNil)
+}
+
+func TestWorkerCoordinatorLeaseLost(t *testing.T) {
+	c := qt.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := &leaseDatabase{}
+	wc := &jimm.WorkerCoordinator{Database: db, Holder: "replica-1", LeaseDuration: 150 * time.Millisecond}
+
+	started := make(chan struct{}, 1)
+	stopped := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- wc.Run(ctx, "worker", func(ctx context.Context) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			stopped <- struct{}{}
+			return nil
+		})
+	}()
+	<-started
+
+	db.setError(errors.E("database unavailable"))
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		c.Fatal("worker not stopped after lease lost")
+	}
+	db.setError(nil)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		c.Fatal("worker not restarted after lease reacquired")
+	}
+	cancel()
+	c.Assert(<-done, qt.IsNil)
+}
+
+func TestWorkerCoordinatorWorkerError(t *testing.T) {
+	c := qt.New(t)
+
+	db := &leaseDatabase{}
+	wc := &jimm.WorkerCoordinator{Database: db, Holder: "replica-1", LeaseDuration: time.Minute}
+	err := wc.Run(context.Background(), "worker", func(context.Context) error {
+		return errors.E("test error")
+	})
+	c.Check(err, qt.ErrorMatches, `test error`)
+
+	leases, err := db.ListWorkerLeases(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Check(leases, qt.HasLen, 0)
+}
+
+func TestWorkerCoordinatorWithoutLeases(t *testing.T) {
+	c := qt.New(t)
+
+	var wc jimm.WorkerCoordinator
+	err := wc.Run(context.Background(), "worker", func(ctx context.Context) error {
+		status, err := wc.Status(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(status, qt.DeepEquals, map[string]jimm.WorkerStatus{"worker": {Leader: true}})
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+}
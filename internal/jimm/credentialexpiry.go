@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// SetCloudCredentialExpiry records the time at which the given cloud
+// credential expires. If expiresAt is nil the credential is recorded as
+// not expiring. Only the owner of the credential, or a JIMM
+// administrator, may set its expiry.
+func (j *JIMM) SetCloudCredentialExpiry(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, expiresAt *time.Time) (err error) {
+	const op = errors.Op("jimm.SetCloudCredentialExpiry")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if user.Tag() != tag.Owner() && !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	var cred dbmodel.CloudCredential
+	cred.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &cred); err != nil {
+		return errors.E(op, err)
+	}
+	cred.ExpiresAt = sql.NullTime{}
+	if expiresAt != nil {
+		cred.ExpiresAt = sql.NullTime{Time: expiresAt.UTC(), Valid: true}
+	}
+	cred.ExpiringSoon = false
+	if err := j.Database.SetCloudCredential(ctx, &cred); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListExpiringCredentials returns the cloud credentials that expire within
+// the given duration, including any that have already expired. JIMM
+// administrators see every such credential, other users only see their
+// own.
+func (j *JIMM) ListExpiringCredentials(ctx context.Context, user *openfga.User, within time.Duration) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("jimm.ListExpiringCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	var owner string
+	if !user.JimmAdmin {
+		owner = user.Name
+	}
+	creds, err := j.Database.ListExpiringCloudCredentials(ctx, owner, time.Now().Add(within))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return creds, nil
+}
+
+// MarkExpiringCredentials flags the cloud credentials that expire before
+// the given time as expiring soon, and updates the metric reporting the
+// number of such credentials. The number of credentials flagged is
+// returned.
+func (j *JIMM) MarkExpiringCredentials(ctx context.Context, before time.Time) (_ int, err error) {
+	const op = errors.Op("jimm.MarkExpiringCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	n, err := j.Database.MarkExpiringCloudCredentials(ctx, before)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	servermon.CloudCredentialsExpiringSoon.Set(float64(n))
+	return n, nil
+}
+
+// credentialExpiryService periodically flags the cloud credentials that
+// are about to expire.
+type credentialExpiryService struct {
+	jimm     *JIMM
+	interval time.Duration
+	warning  time.Duration
+}
+
+// NewCredentialExpiryService returns a service that, every interval,
+// flags the cloud credentials that expire within the warning period.
+func NewCredentialExpiryService(j *JIMM, interval, warning time.Duration) *credentialExpiryService {
+	return &credentialExpiryService{
+		jimm:     j,
+		interval: interval,
+		warning:  warning,
+	}
+}
+
+// Start starts a routine which periodically flags the cloud credentials
+// that are about to expire.
+func (s *credentialExpiryService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *credentialExpiryService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.jimm.MarkExpiringCredentials(ctx, time.Now().Add(s.warning))
+		if err != nil {
+			zapctx.Error(ctx, "failed to mark expiring cloud credentials", zap.Error(err))
+		} else if n > 0 {
+			zapctx.Warn(ctx, "cloud credentials are about to expire", zap.Int("count", n))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting credential expiry polling")
+			return
+		}
+	}
+}
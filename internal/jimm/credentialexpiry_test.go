@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const credentialExpiryTestEnv = `clouds:
+- name: test
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: cred-1
+  cloud: test
+  owner: alice@canonical.com
+  type: empty
+- name: cred-2
+  cloud: test
+  owner: bob@canonical.com
+  type: empty
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+`
+
+func TestCredentialExpiry(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialExpiryTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	adminUser := openfga.NewUser(&alice, client)
+	adminUser.JimmAdmin = true
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bobUser := openfga.NewUser(&bob, client)
+
+	cred1 := names.NewCloudCredentialTag("test/alice@canonical.com/cred-1")
+	cred2 := names.NewCloudCredentialTag("test/bob@canonical.com/cred-2")
+
+	soon := now.Add(24 * time.Hour)
+	later := now.Add(60 * 24 * time.Hour)
+	err = j.SetCloudCredentialExpiry(ctx, bobUser, cred1, &soon)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	err = j.SetCloudCredentialExpiry(ctx, adminUser, cred1, &soon)
+	c.Assert(err, qt.IsNil)
+	err = j.SetCloudCredentialExpiry(ctx, bobUser, cred2, &later)
+	c.Assert(err, qt.IsNil)
+
+	creds, err := j.ListExpiringCredentials(ctx, adminUser, 7*24*time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].ResourceTag(), qt.Equals, cred1)
+	c.Check(creds[0].ExpiresAt.Time.Equal(soon), qt.IsTrue)
+
+	creds, err = j.ListExpiringCredentials(ctx, bobUser, 90*24*time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].ResourceTag(), qt.Equals, cred2)
+
+	n, err := j.MarkExpiringCredentials(ctx, now.Add(7*24*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 1)
+	cred := dbmodel.CloudCredential{}
+	cred.SetTag(cred1)
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.ExpiringSoon, qt.IsTrue)
+
+	// Updating the expiry clears the flag.
+	err = j.SetCloudCredentialExpiry(ctx, adminUser, cred1, nil)
+	c.Assert(err, qt.IsNil)
+	n, err = j.MarkExpiringCredentials(ctx, now.Add(7*24*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 0)
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.ExpiringSoon, qt.IsFalse)
+	c.Check(cred.ExpiresAt.Valid, qt.IsFalse)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
 	"github.com/juju/names/v5"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 )
@@ -26,13 +27,7 @@ type CredentialStore interface {
 	// Put stores the attributes of a cloud credential.
 	Put(context.Context, names.CloudCredentialTag, map[string]string) error
 
-	// GetControllerCredentials retrieves the credentials for the given controller from a vault
-	// service.
-	GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error)
-
-	// PutControllerCredentials stores the controller credentials in a vault
-	// service.
-	PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error
+	ControllerCredentialStore
 
 	// CleanupJWKS removes all secrets associated with the JWKS process.
 	CleanupJWKS(ctx context.Context) error
@@ -55,3 +50,41 @@ type CredentialStore interface {
 	// PutJWKSExpiry sets the expiry time for the current JWKS within the store.
 	PutJWKSExpiry(ctx context.Context, expiry time.Time) error
 }
+
+// A ControllerCredentialStore is a store for the admin credentials JIMM
+// uses to connect to controllers.
+type ControllerCredentialStore interface {
+	// GetControllerCredentials retrieves the credentials for the given controller from a vault
+	// service.
+	GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error)
+
+	// PutControllerCredentials stores the controller credentials in a vault
+	// service.
+	PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error
+}
+
+// A SecretStore is a store for the secrets JIMM uses to connect to
+// controllers, that is their admin credentials and CA certificates. A
+// SecretStore may be configured separately from the CredentialStore so
+// that these secrets are kept out of JIMM's database.
+type SecretStore interface {
+	ControllerCredentialStore
+
+	// GetControllerCACertificate retrieves the CA certificate of the
+	// given controller. If no certificate is stored an empty string is
+	// returned.
+	GetControllerCACertificate(ctx context.Context, controllerName string) (string, error)
+
+	// PutControllerCACertificate stores the CA certificate of the given
+	// controller. Storing an empty certificate removes any stored
+	// certificate.
+	PutControllerCACertificate(ctx context.Context, controllerName string, caCert string) error
+}
+
+// A RootKeyStore is a store for the macaroon root keys used by JIMM's
+// discharger. A SecretStore or CredentialStore that also implements
+// RootKeyStore is used to hold the root keys, otherwise they are kept in
+// JIMM's database.
+type RootKeyStore interface {
+	dbrootkeystore.Backing
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// ModelDashboardURL returns the URL of the given model in the Juju
+// Dashboard of the controller hosting it. The user must have read access
+// to the model. The model's UUID is given in the "model-uuid" query
+// parameter, and a session token for the user is given in the URL
+// fragment so that the dashboard can log in to JIMM on the user's behalf
+// without the token being sent to the dashboard's server. The token is
+// recorded as a device session so that it can be revoked.
+func (j *JIMM) ModelDashboardURL(ctx context.Context, user *openfga.User, modelUUID string) (_ string, err error) {
+	const op = errors.Op("jimm.ModelDashboardURL")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if modelUUID == "" {
+		return "", errors.E(op, errors.CodeBadRequest, "model UUID not specified")
+	}
+	ok, err := user.IsModelReader(ctx, names.NewModelTag(modelUUID))
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	if !ok {
+		return "", errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	model := dbmodel.Model{
+		UUID: sql.NullString{String: modelUUID, Valid: true},
+	}
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		return "", errors.E(op, err)
+	}
+
+	u, err := controllerDashboardURL(&model.Controller)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	u = u.JoinPath("models", model.OwnerIdentityName, model.Name)
+	u.RawQuery = url.Values{"model-uuid": {modelUUID}}.Encode()
+	if err := j.addDashboardCredentials(ctx, user, u); err != nil {
+		return "", errors.E(op, err)
+	}
+	return u.String(), nil
+}
+
+// ControllerDashboardURL returns the URL of the Juju Dashboard of the
+// named controller, with a session token for the user in the URL
+// fragment. The token is recorded as a device session so that it can be
+// revoked. Only JIMM administrators may open the dashboard of a
+// controller.
+func (j *JIMM) ControllerDashboardURL(ctx context.Context, user *openfga.User, controllerName string) (_ string, err error) {
+	const op = errors.Op("jimm.ControllerDashboardURL")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return "", errors.E(op, err)
+	}
+	ctl, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	u, err := controllerDashboardURL(ctl)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	if err := j.addDashboardCredentials(ctx, user, u); err != nil {
+		return "", errors.E(op, err)
+	}
+	return u.String(), nil
+}
+
+// controllerDashboardURL returns the parsed dashboard URL of the given
+// controller.
+func controllerDashboardURL(ctl *dbmodel.Controller) (*url.URL, error) {
+	if ctl.DashboardURL == "" {
+		return nil, errors.E(errors.CodeNotFound, fmt.Sprintf("controller %q does not have a dashboard", ctl.Name))
+	}
+	u, err := url.Parse(ctl.DashboardURL)
+	if err != nil {
+		return nil, errors.E(err)
+	}
+	return u, nil
+}
+
+// addDashboardCredentials sets the fragment of the given dashboard URL to
+// a session token for the user and records the token as a device
+// session.
+func (j *JIMM) addDashboardCredentials(ctx context.Context, user *openfga.User, u *url.URL) (err error) {
+	const op = errors.Op("jimm.addDashboardCredentials")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if j.OAuthAuthenticator == nil {
+		return errors.E(op, errors.CodeServerConfiguration, "authenticator not configured")
+	}
+	token, err := j.OAuthAuthenticator.MintSessionToken(user.Name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.recordDeviceSession(ctx, user.Name, token); err != nil {
+		return errors.E(op, err)
+	}
+	u.Fragment = url.Values{"session-token": {token}}.Encode()
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestModelDashboardURL(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := db.Database{
+		DB: jimmtest.PostgresDB(c, time.Now),
+	}
+	err := db.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	jimmUUID := uuid.NewString()
+	env := initializeEnvironment(c, ctx, &db, client, jimmUUID)
+
+	authenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	j := &jimm.JIMM{
+		UUID:               jimmUUID,
+		Database:           db,
+		OpenFGAClient:      client,
+		OAuthAuthenticator: &authenticator,
+	}
+	model := env.models[0]
+	alice := openfga.NewUser(&env.users[0], client)
+
+	_, err = j.ModelDashboardURL(ctx, alice, model.UUID.String)
+	c.Check(err, qt.ErrorMatches, `controller "test-controller-1" does not have a dashboard`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	ctl := env.controllers[0]
+	ctl.DashboardURL = "https://dashboard.example.com/base"
+	err = db.UpdateController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+
+	s, err := j.ModelDashboardURL(ctx, alice, model.UUID.String)
+	c.Assert(err, qt.IsNil)
+	u, err := url.Parse(s)
+	c.Assert(err, qt.IsNil)
+	c.Check(u.Host, qt.Equals, "dashboard.example.com")
+	c.Check(u.Path, qt.Equals, "/base/models/alice@canonical.com/test-model")
+	c.Check(u.Query().Get("model-uuid"), qt.Equals, model.UUID.String)
+	fragment, err := url.ParseQuery(u.Fragment)
+	c.Assert(err, qt.IsNil)
+	token := fragment.Get("session-token")
+	c.Check(token, qt.Not(qt.Equals), "")
+
+	// The token is recorded as a device session and can be revoked.
+	sessions, err := j.ListSessions(ctx, &openfga.User{Identity: &env.users[0], JimmAdmin: true}, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(sessions, qt.HasLen, 1)
+	c.Check(sessions[0].Kind, qt.Equals, dbmodel.DeviceSession)
+	_, err = j.LoginWithSessionToken(ctx, token)
+	c.Assert(err, qt.IsNil)
+	_, err = db.RevokeSessions(ctx, "alice@canonical.com", time.Now())
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginWithSessionToken(ctx, token)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+
+	// A user without access to the model cannot find its dashboard.
+	mallory, err := dbmodel.NewIdentity("mallory@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = j.ModelDashboardURL(ctx, openfga.NewUser(mallory, client), model.UUID.String)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Only JIMM administrators can open controller dashboards.
+	_, err = j.ControllerDashboardURL(ctx, alice, ctl.Name)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// maxDeprecatedFacadeClients is the maximum number of clients for which
+// deprecated facade usage is recorded. Calls from further clients are
+// still logged, but are not included in the usage report.
+const maxDeprecatedFacadeClients = 1000
+
+// A DeprecatedFacadeUsage records the calls made by a client on a
+// deprecated facade version.
+type DeprecatedFacadeUsage struct {
+	// Facade and Version identify the deprecated facade version.
+	Facade  string
+	Version int
+
+	// IdentityName holds the name of the identity that made the calls,
+	// this is empty for calls made before logging in.
+	IdentityName string
+
+	// RemoteAddr holds the network address of the client.
+	RemoteAddr string
+
+	// Calls holds the number of calls made.
+	Calls int64
+
+	// FirstCall and LastCall hold the times of the first and last
+	// calls made.
+	FirstCall time.Time
+	LastCall  time.Time
+}
+
+// deprecatedFacadeUsageKey identifies a client of a deprecated facade
+// version.
+type deprecatedFacadeUsageKey struct {
+	facade       string
+	version      int
+	identityName string
+	remoteAddr   string
+}
+
+// deprecatedFacadeUsageRecorder holds the usage of deprecated facade
+// versions since JIMM started.
+type deprecatedFacadeUsageRecorder struct {
+	mu    sync.Mutex
+	usage map[deprecatedFacadeUsageKey]*DeprecatedFacadeUsage
+}
+
+// RecordDeprecatedFacadeUsage records a call on a deprecated version of a
+// facade by the given user, which is nil if the client has not logged
+// in. The client is identified by the user and the remote address held
+// in the context. Usage is held in memory, so the report only covers
+// calls made since JIMM started.
+func (j *JIMM) RecordDeprecatedFacadeUsage(ctx context.Context, user *openfga.User, facade string, version int) {
+	key := deprecatedFacadeUsageKey{
+		facade:     facade,
+		version:    version,
+		remoteAddr: auth.RemoteAddrFromContext(ctx),
+	}
+	if user != nil && user.Identity != nil {
+		key.identityName = user.Name
+	}
+	now := time.Now().UTC()
+
+	r := &j.deprecatedFacadeUsage
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.usage[key]; ok {
+		u.Calls++
+		u.LastCall = now
+		return
+	}
+	if len(r.usage) >= maxDeprecatedFacadeClients {
+		return
+	}
+	if r.usage == nil {
+		r.usage = make(map[deprecatedFacadeUsageKey]*DeprecatedFacadeUsage)
+	}
+	r.usage[key] = &DeprecatedFacadeUsage{
+		Facade:       facade,
+		Version:      version,
+		IdentityName: key.identityName,
+		RemoteAddr:   key.remoteAddr,
+		Calls:        1,
+		FirstCall:    now,
+		LastCall:     now,
+	}
+}
+
+// ListDeprecatedFacadeUsage returns the recorded usage of deprecated
+// facade versions, ordered by facade, version, identity and address.
+// Only JIMM administrators may list the usage.
+func (j *JIMM) ListDeprecatedFacadeUsage(ctx context.Context, user *openfga.User) (_ []DeprecatedFacadeUsage, err error) {
+	const op = errors.Op("jimm.ListDeprecatedFacadeUsage")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	r := &j.deprecatedFacadeUsage
+	r.mu.Lock()
+	usage := make([]DeprecatedFacadeUsage, 0, len(r.usage))
+	for _, u := range r.usage {
+		usage = append(usage, *u)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if a.Facade != b.Facade {
+			return a.Facade < b.Facade
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		if a.IdentityName != b.IdentityName {
+			return a.IdentityName < b.IdentityName
+		}
+		return a.RemoteAddr < b.RemoteAddr
+	})
+	return usage, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestDeprecatedFacadeUsage(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{}
+	ctx := auth.ContextWithRemoteAddr(context.Background(), "10.0.0.1:1234")
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, nil)
+	admin.JimmAdmin = true
+
+	j.RecordDeprecatedFacadeUsage(ctx, nil, "Admin", 3)
+	j.RecordDeprecatedFacadeUsage(ctx, alice, "Admin", 3)
+	j.RecordDeprecatedFacadeUsage(ctx, alice, "Admin", 3)
+	j.RecordDeprecatedFacadeUsage(context.Background(), alice, "Admin", 2)
+
+	_, err := j.ListDeprecatedFacadeUsage(ctx, alice)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	usage, err := j.ListDeprecatedFacadeUsage(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Assert(usage, qt.HasLen, 3)
+	for _, u := range usage {
+		c.Check(u.FirstCall.IsZero(), qt.IsFalse)
+		c.Check(u.LastCall.Before(u.FirstCall), qt.IsFalse)
+	}
+
+	c.Check(usage[0].Version, qt.Equals, 2)
+	c.Check(usage[0].IdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(usage[0].RemoteAddr, qt.Equals, "")
+	c.Check(usage[0].Calls, qt.Equals, int64(1))
+
+	c.Check(usage[1].Version, qt.Equals, 3)
+	c.Check(usage[1].IdentityName, qt.Equals, "")
+	c.Check(usage[1].RemoteAddr, qt.Equals, "10.0.0.1:1234")
+	c.Check(usage[1].Calls, qt.Equals, int64(1))
+
+	c.Check(usage[2].Version, qt.Equals, 3)
+	c.Check(usage[2].IdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(usage[2].Calls, qt.Equals, int64(2))
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// A DrainMigration describes the migration of a model away from a
+// draining controller.
+type DrainMigration struct {
+	// Model is the model being migrated.
+	Model names.ModelTag
+
+	// TargetController is the name of the controller the model is
+	// being migrated to.
+	TargetController string
+
+	// MigrationID is the ID of the migration, if it was started.
+	MigrationID string
+
+	// Err holds the error that prevented the migration from starting,
+	// if any.
+	Err error
+}
+
+// DrainController records if the controller is draining. No new models
+// are placed on a draining controller. If migrate is true every model on
+// the controller is migrated to another controller hosting the same
+// cloud region. A model that cannot be migrated does not prevent the
+// migration of the others, the outcome for each model is returned.
+func (j *JIMM) DrainController(ctx context.Context, user *openfga.User, controllerName string, draining, migrate bool) (_ []DrainMigration, err error) {
+	const op = errors.Op("jimm.DrainController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if migrate && !draining {
+		return nil, errors.E(op, errors.CodeBadRequest, "models can only be migrated from a draining controller")
+	}
+
+	ctl := dbmodel.Controller{
+		Name: controllerName,
+	}
+	err = j.Database.Transaction(func(db *db.Database) error {
+		if err := db.GetController(ctx, &ctl); err != nil {
+			return err
+		}
+		ctl.Draining = draining
+		return db.UpdateController(ctx, &ctl)
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !migrate {
+		return nil, nil
+	}
+
+	models, err := j.Database.GetModelsByController(ctx, ctl)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	clouds := make(map[string]*dbmodel.Cloud)
+	migrations := make([]DrainMigration, 0, len(models))
+	for _, m := range models {
+		mt := m.ResourceTag()
+		migration := DrainMigration{Model: mt}
+		target, err := j.drainTarget(ctx, clouds, mt, ctl.ID)
+		if err != nil {
+			migration.Err = err
+			migrations = append(migrations, migration)
+			continue
+		}
+		migration.TargetController = target.Name
+		result, err := j.InitiateInternalMigration(ctx, user, mt, target.Name)
+		switch {
+		case err != nil:
+			migration.Err = err
+		case result.Error != nil:
+			migration.Err = result.Error
+		default:
+			migration.MigrationID = result.MigrationId
+		}
+		if migration.Err != nil {
+			zapctx.Warn(ctx, "cannot migrate model from draining controller", zap.String("model", mt.Id()), zap.String("controller", ctl.Name), zap.Error(migration.Err))
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// drainTarget selects the controller that the given model is migrated to
+// when draining the controller with the given ID. The clouds map caches
+// the clouds loaded while draining a controller.
+func (j *JIMM) drainTarget(ctx context.Context, clouds map[string]*dbmodel.Cloud, mt names.ModelTag, controllerID uint) (*dbmodel.Controller, error) {
+	m := dbmodel.Model{}
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, err
+	}
+	cloud, ok := clouds[m.CloudRegion.Cloud.Name]
+	if !ok {
+		cloud = &dbmodel.Cloud{Name: m.CloudRegion.Cloud.Name}
+		if err := j.Database.GetCloud(ctx, cloud); err != nil {
+			return nil, err
+		}
+		clouds[cloud.Name] = cloud
+	}
+	var candidates []dbmodel.CloudRegionControllerPriority
+	for _, r := range cloud.Regions {
+		if r.ID != m.CloudRegionID {
+			continue
+		}
+		for _, c := range r.Controllers {
+			if c.ControllerID != controllerID {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	candidates, err := undrainedControllers(candidates)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err = j.controllersWithinLimits(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := j.selectController(ctx, ControllerSelectionRequest{
+		Owner: names.NewUserTag(m.OwnerIdentityName),
+		Cloud: cloud.ResourceTag(),
+	}, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return &selected.Controller, nil
+}
+
+// undrainedControllers returns the candidate controllers that are not
+// draining. An error is returned if every candidate is draining.
+func undrainedControllers(candidates []dbmodel.CloudRegionControllerPriority) ([]dbmodel.CloudRegionControllerPriority, error) {
+	var result []dbmodel.CloudRegionControllerPriority
+	for _, c := range candidates {
+		if !c.Controller.Draining {
+			result = append(result, c)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.E("no controller available to host the model")
+	}
+	return result, nil
+}
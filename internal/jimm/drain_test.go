@@ -0,0 +1,157 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestDrainController(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testSetControllerDeprecatedEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	tests := []struct {
+		about         string
+		user          dbmodel.Identity
+		jimmAdmin     bool
+		draining      bool
+		migrate       bool
+		expectedError string
+	}{{
+		about:     "superuser can drain a controller",
+		user:      env.User("alice@canonical.com").DBObject(c, j.Database),
+		jimmAdmin: true,
+		draining:  true,
+	}, {
+		about:     "superuser can stop draining a controller",
+		user:      env.User("alice@canonical.com").DBObject(c, j.Database),
+		jimmAdmin: true,
+		draining:  false,
+	}, {
+		about:         "models cannot be migrated from a controller that is not draining",
+		user:          env.User("alice@canonical.com").DBObject(c, j.Database),
+		jimmAdmin:     true,
+		draining:      false,
+		migrate:       true,
+		expectedError: "models can only be migrated from a draining controller",
+	}, {
+		about:         "user without access rights cannot drain a controller",
+		user:          env.User("eve@canonical.com").DBObject(c, j.Database),
+		draining:      true,
+		expectedError: "unauthorized",
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			user := openfga.NewUser(&test.user, client)
+			user.JimmAdmin = test.jimmAdmin
+			migrations, err := j.DrainController(ctx, user, "test1", test.draining, test.migrate)
+			if test.expectedError != "" {
+				c.Assert(err, qt.ErrorMatches, test.expectedError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(migrations, qt.HasLen, 0)
+			controller := dbmodel.Controller{
+				Name: "test1",
+			}
+			err = j.Database.GetController(ctx, &controller)
+			c.Assert(err, qt.IsNil)
+			c.Assert(controller.Draining, qt.Equals, test.draining)
+		})
+	}
+}
+
+func TestDrainControllerMigratesModelsWithoutTarget(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testDrainControllerEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	user.JimmAdmin = true
+
+	migrations, err := j.DrainController(ctx, user, "test1", true, true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].Model.Id(), qt.Equals, "00000002-0000-0000-0000-000000000001")
+	c.Check(migrations[0].MigrationID, qt.Equals, "")
+	c.Check(migrations[0].Err, qt.ErrorMatches, "no controller available to host the model")
+}
+
+const testDrainControllerEnv = `clouds:
+- name: test
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: test-cred
+  cloud: test
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: test1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test
+  region: test-region
+  agent-version: 3.2.1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: test1
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  owner: alice@canonical.com
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
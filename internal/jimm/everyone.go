@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// everyoneDefaultRelations holds, for each kind of resource, the
+// relations that may be granted to every user. Administrator relations
+// are never allowed.
+var everyoneDefaultRelations = map[string][]openfga.Relation{
+	names.CloudTagKind:            {ofganames.CanAddModelRelation},
+	names.ModelTagKind:            {ofganames.ReaderRelation, ofganames.WriterRelation},
+	names.ApplicationOfferTagKind: {ofganames.ReaderRelation, ofganames.ConsumerRelation},
+	jimmnames.GroupTagKind:        {ofganames.MemberRelation},
+}
+
+// everyoneTag returns the OpenFGA tag representing every user.
+func everyoneTag() *ofganames.Tag {
+	return ofganames.ConvertTag(names.NewUserTag(ofganames.EveryoneUser))
+}
+
+// ListEveryoneDefaults returns the relations granted to every user via
+// the everyone@external user. Only JIMM administrators may list them.
+func (j *JIMM) ListEveryoneDefaults(ctx context.Context, user *openfga.User) (_ []openfga.Tuple, err error) {
+	const op = errors.Op("jimm.ListEveryoneDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var defaults []openfga.Tuple
+	for _, kind := range []string{names.CloudTagKind, names.ModelTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind} {
+		kt, err := ofganames.BlankKindTag(kind)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		var ct string
+		for {
+			tuples, next, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{
+				Object: everyoneTag(),
+				Target: kt,
+			}, reconcilePageSize, ct)
+			if err != nil {
+				return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+			}
+			defaults = append(defaults, tuples...)
+			if next == "" {
+				break
+			}
+			ct = next
+		}
+	}
+	return defaults, nil
+}
+
+// SetEveryoneDefault grants, or if granted is false revokes, the given
+// relation on the target to every user. Only relations that do not give
+// administrative access may be set. Each change is recorded in the audit
+// log, setting a default to its current state is not an error and is not
+// recorded. Only JIMM administrators may set defaults.
+func (j *JIMM) SetEveryoneDefault(ctx context.Context, user *openfga.User, target string, relation string, granted bool) (err error) {
+	const op = errors.Op("jimm.SetEveryoneDefault")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	targetTag, err := j.parseAndValidateTag(ctx, target)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if targetTag.ID == "" {
+		return errors.E(op, errors.CodeBadRequest, "target must identify a single resource")
+	}
+	rel, err := ofganames.ParseRelation(relation)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if !isEveryoneDefaultRelation(string(targetTag.Kind), rel) {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("relation %q cannot be granted to everyone on %s", relation, targetTag.Kind))
+	}
+
+	t := openfga.Tuple{
+		Object:   everyoneTag(),
+		Relation: rel,
+		Target:   targetTag,
+	}
+	existing, _, err := j.OpenFGAClient.ReadRelatedObjects(ctx, t, 1, "")
+	if err != nil {
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	if (len(existing) > 0) == granted {
+		// Nothing to change.
+		return nil
+	}
+	if granted {
+		err = j.OpenFGAClient.AddRelation(ctx, t)
+	} else {
+		err = j.OpenFGAClient.RemoveRelation(ctx, t)
+	}
+	if err != nil {
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	j.notifyAccessChanged(AccessChange{})
+
+	params, _ := json.Marshal(map[string]any{
+		"target":   targetTag.String(),
+		"relation": string(rel),
+		"granted":  granted,
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "SetEveryoneDefault",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return nil
+}
+
+// isEveryoneDefaultRelation reports whether the relation may be granted
+// to every user on resources of the given kind.
+func isEveryoneDefaultRelation(kind string, relation openfga.Relation) bool {
+	for _, r := range everyoneDefaultRelations[kind] {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+func TestEveryoneDefaults(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, _, _, _, cloud, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, ofgaClient)
+
+	err = j.SetEveryoneDefault(ctx, u, cloud.ResourceTag().String(), "can_addmodel", true)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	err = j.SetEveryoneDefault(ctx, u, cloud.ResourceTag().String(), "administrator", true)
+	c.Assert(err, qt.ErrorMatches, `relation "administrator" cannot be granted to everyone on cloud`)
+
+	err = j.SetEveryoneDefault(ctx, u, cloud.ResourceTag().String(), "can_addmodel", true)
+	c.Assert(err, qt.IsNil)
+	// Granting an existing default is not an error.
+	err = j.SetEveryoneDefault(ctx, u, cloud.ResourceTag().String(), "can_addmodel", true)
+	c.Assert(err, qt.IsNil)
+
+	defaults, err := j.ListEveryoneDefaults(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults, qt.DeepEquals, []openfga.Tuple{{
+		// Tuples are returned with the everyone user in place of the
+		// wildcard.
+		Object:   &ofganames.Tag{Kind: openfga.UserType, ID: ofganames.EveryoneUser},
+		Relation: ofganames.CanAddModelRelation,
+		Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+	}})
+
+	err = j.SetEveryoneDefault(ctx, u, cloud.ResourceTag().String(), "can_addmodel", false)
+	c.Assert(err, qt.IsNil)
+
+	defaults, err = j.ListEveryoneDefaults(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults, qt.HasLen, 0)
+}
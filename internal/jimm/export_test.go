@@ -10,6 +10,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/notify"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
@@ -22,6 +23,8 @@ var (
 	FillMigrationTarget            = fillMigrationTarget
 	InitiateMigration              = &initiateMigration
 	ResolveTag                     = resolveTag
+	NewCoalescingPublisher         = newCoalescingPublisher
+	NewChangedPublisher            = newChangedPublisher
 )
 
 func WatchController(w *Watcher, ctx context.Context, ctl *dbmodel.Controller) error {
@@ -65,3 +68,24 @@ func (j *JIMM) UpdateUserLastLogin(ctx context.Context, identifier string) error
 func (j *JIMM) EveryoneUser() *openfga.User {
 	return j.everyoneUser()
 }
+
+func (j *JIMM) ControllersWithinLimits(ctx context.Context, candidates []dbmodel.CloudRegionControllerPriority) ([]dbmodel.CloudRegionControllerPriority, error) {
+	return j.controllersWithinLimits(ctx, candidates)
+}
+
+func (j *JIMM) CheckUserLimits(ctx context.Context, identityName string, add db.ModelUsage) error {
+	s, err := j.userLimitScope(ctx, identityName)
+	if err != nil {
+		return err
+	}
+	_, err = j.checkLimits(ctx, &j.Database, add, s)
+	return err
+}
+
+func (j *JIMM) SendNotification(ctx context.Context, identityName string, n notify.Notification) {
+	j.sendNotification(ctx, identityName, n)
+}
+
+func (j *JIMM) ModelConfigTemplates(ctx context.Context, user *openfga.User, config map[string]any) ([]map[string]any, map[string]any, error) {
+	return j.modelConfigTemplates(ctx, user, config)
+}
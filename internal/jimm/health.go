@@ -0,0 +1,48 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// PingControllers checks that at least one of the controllers attached
+// to JIMM can be dialed. Controllers are tried in name order until one
+// is reached, the name of that controller is returned. If there are no
+// controllers, or none can be reached, an error with a code of
+// CodeConnectionFailed is returned.
+func (j *JIMM) PingControllers(ctx context.Context) (_ string, err error) {
+	const op = errors.Op("jimm.PingControllers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	var controllers []dbmodel.Controller
+	err = j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		controllers = append(controllers, *ctl)
+		return nil
+	})
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	if len(controllers) == 0 {
+		return "", errors.E(op, errors.CodeConnectionFailed, "no controllers")
+	}
+	for i := range controllers {
+		api, err := j.dial(ctx, &controllers[i], names.ModelTag{})
+		if err != nil {
+			zapctx.Warn(ctx, "cannot dial controller", zap.String("controller", controllers[i].Name), zap.Error(err))
+			continue
+		}
+		api.Close()
+		return controllers[i].Name, nil
+	}
+	return "", errors.E(op, errors.CodeConnectionFailed, "no controllers can be reached")
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestPingControllers(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	dialer := &jimmtest.Dialer{
+		API: &jimmtest.API{},
+	}
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: dialer,
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.PingControllers(ctx)
+	c.Check(err, qt.ErrorMatches, `no controllers`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeConnectionFailed)
+
+	err = j.Database.AddController(ctx, &dbmodel.Controller{
+		Name: "controller-1",
+		UUID: "00000001-0000-0000-0000-000000000001",
+	})
+	c.Assert(err, qt.IsNil)
+
+	name, err := j.PingControllers(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(name, qt.Equals, "controller-1")
+	c.Check(dialer.IsClosed(), qt.IsTrue)
+
+	dialer.Err = errors.E("dial error")
+	_, err = j.PingControllers(ctx)
+	c.Check(err, qt.ErrorMatches, `no controllers can be reached`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeConnectionFailed)
+}
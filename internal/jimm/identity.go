@@ -9,12 +9,15 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // FetchIdentity fetches the user specified by the username and returns the user if it is found.
 // Or error "record not found".
-func (j *JIMM) FetchIdentity(ctx context.Context, id string) (*openfga.User, error) {
+func (j *JIMM) FetchIdentity(ctx context.Context, id string) (_ *openfga.User, err error) {
 	const op = errors.Op("jimm.FetchIdentity")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	identity, err := dbmodel.NewIdentity(id)
 	if err != nil {
@@ -30,15 +33,17 @@ func (j *JIMM) FetchIdentity(ctx context.Context, id string) (*openfga.User, err
 }
 
 // ListIdentities lists a page of users in our database and parse them into openfga entities.
-func (j *JIMM) ListIdentities(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]openfga.User, error) {
+func (j *JIMM) ListIdentities(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) (_ []openfga.User, err error) {
 	const op = errors.Op("jimm.ListIdentities")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
 	var identities []openfga.User
-	err := j.Database.ForEachIdentity(ctx, filter.Limit(), filter.Offset(), func(ge *dbmodel.Identity) error {
+	err = j.Database.ForEachIdentity(ctx, filter.Limit(), filter.Offset(), func(ge *dbmodel.Identity) error {
 		u := openfga.NewUser(ge, j.OpenFGAClient)
 		identities = append(identities, *u)
 		return nil
@@ -50,8 +55,10 @@ func (j *JIMM) ListIdentities(ctx context.Context, user *openfga.User, filter pa
 }
 
 // CountIdentities returns the count of all the identities in our database.
-func (j *JIMM) CountIdentities(ctx context.Context, user *openfga.User) (int, error) {
+func (j *JIMM) CountIdentities(ctx context.Context, user *openfga.User) (_ int, err error) {
 	const op = errors.Op("jimm.CountIdentities")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
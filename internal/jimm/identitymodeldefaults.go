@@ -7,11 +7,14 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // SetIdentityModelDefaults writes new default model setting values for the user.
-func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, identity *dbmodel.Identity, configs map[string]interface{}) error {
+func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, identity *dbmodel.Identity, configs map[string]interface{}) (err error) {
 	const op = errors.Op("jimm.SetIdentityModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	for k := range configs {
 		if k == agentVersionKey {
@@ -19,7 +22,7 @@ func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, identity *dbmodel.I
 		}
 	}
 
-	err := j.Database.SetIdentityModelDefaults(ctx, &dbmodel.IdentityModelDefaults{
+	err = j.Database.SetIdentityModelDefaults(ctx, &dbmodel.IdentityModelDefaults{
 		IdentityName: identity.Name,
 		Defaults:     configs,
 	})
@@ -30,13 +33,15 @@ func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, identity *dbmodel.I
 }
 
 // IdnetityModelDefaults returns the default config values for the identity.
-func (j *JIMM) IdentityModelDefaults(ctx context.Context, identity *dbmodel.Identity) (map[string]interface{}, error) {
+func (j *JIMM) IdentityModelDefaults(ctx context.Context, identity *dbmodel.Identity) (_ map[string]interface{}, err error) {
 	const op = errors.Op("jimm.UserModelDefaults")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	defaults := dbmodel.IdentityModelDefaults{
 		IdentityName: identity.Name,
 	}
-	err := j.Database.IdentityModelDefaults(ctx, &defaults)
+	err = j.Database.IdentityModelDefaults(ctx, &defaults)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+const (
+	// identityProfileSyncAge is the age after which an identity's
+	// profile is synchronised again from the identity provider.
+	identityProfileSyncAge = 24 * time.Hour
+
+	// identityProfileSyncBatch is the maximum number of identities
+	// synchronised by each call to SyncIdentityProfiles.
+	identityProfileSyncBatch = 100
+)
+
+// SyncIdentityProfiles synchronises the display name and email of
+// identities whose profile has never been synchronised, or was last
+// synchronised before the given time, from the identity provider. Only
+// identities with stored OAuth2.0 tokens can be synchronised. Failing to
+// synchronise an identity is logged and does not prevent others being
+// synchronised. The number of identities synchronised is returned.
+func (j *JIMM) SyncIdentityProfiles(ctx context.Context, syncedBefore time.Time) (_ int, err error) {
+	const op = errors.Op("jimm.SyncIdentityProfiles")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if j.OAuthAuthenticator == nil {
+		return 0, errors.E(op, errors.CodeServerConfiguration, "authenticator not configured")
+	}
+	identities, err := j.Database.ListIdentitiesForProfileSync(ctx, syncedBefore, identityProfileSyncBatch)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	synced := 0
+	for i := range identities {
+		if err := j.OAuthAuthenticator.SyncIdentityProfile(ctx, &identities[i]); err != nil {
+			zapctx.Warn(ctx, "cannot synchronise identity profile", zap.String("identity", identities[i].Name), zap.Error(err))
+			continue
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// identityProfileSyncService periodically synchronises identity profiles
+// from the identity provider.
+type identityProfileSyncService struct {
+	jimm     *JIMM
+	interval time.Duration
+}
+
+// NewIdentityProfileSyncService returns a service that synchronises
+// identity profiles from the identity provider every interval.
+func NewIdentityProfileSyncService(j *JIMM, interval time.Duration) *identityProfileSyncService {
+	return &identityProfileSyncService{
+		jimm:     j,
+		interval: interval,
+	}
+}
+
+// Start starts a routine which periodically synchronises identity
+// profiles.
+func (s *identityProfileSyncService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *identityProfileSyncService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			synced, err := s.jimm.SyncIdentityProfiles(ctx, time.Now().Add(-identityProfileSyncAge))
+			if err != nil {
+				zapctx.Error(ctx, "failed to synchronise identity profiles", zap.Error(err))
+				continue
+			}
+			zapctx.Debug(ctx, "identity profiles synchronised", zap.Int("count", synced))
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting identity profile sync polling")
+			return
+		}
+	}
+}
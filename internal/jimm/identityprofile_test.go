@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+// profileAuthenticator synchronises identity profiles from a fixed set
+// of display names.
+type profileAuthenticator struct {
+	jimm.OAuthAuthenticator
+
+	db    *db.Database
+	names map[string]string
+}
+
+func (a profileAuthenticator) SyncIdentityProfile(ctx context.Context, identity *dbmodel.Identity) error {
+	name, ok := a.names[identity.Name]
+	if !ok {
+		return errors.New("unknown identity")
+	}
+	identity.DisplayName = name
+	identity.Email = identity.Name
+	identity.ProfileSyncedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return a.db.UpdateIdentity(ctx, identity)
+}
+
+func TestSyncIdentityProfiles(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	j.OAuthAuthenticator = profileAuthenticator{
+		db: &j.Database,
+		names: map[string]string{
+			"alice@canonical.com": "Alice Liddell",
+		},
+	}
+
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com", "charlie@canonical.com"} {
+		identity, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		err = j.Database.GetIdentity(ctx, identity)
+		c.Assert(err, qt.IsNil)
+		if name != "charlie@canonical.com" {
+			identity.RefreshToken = "refresh-token"
+			err = j.Database.UpdateIdentity(ctx, identity)
+			c.Assert(err, qt.IsNil)
+		}
+	}
+
+	synced, err := j.SyncIdentityProfiles(ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Check(synced, qt.Equals, 1)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	err = j.Database.FetchIdentity(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(alice.DisplayName, qt.Equals, "Alice Liddell")
+	c.Check(alice.ProfileSyncedAt.Valid, qt.IsTrue)
+
+	// Alice has been synchronised recently so only bob, who cannot be
+	// synchronised, is attempted again.
+	synced, err = j.SyncIdentityProfiles(ctx, time.Now().Add(-time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(synced, qt.Equals, 0)
+}
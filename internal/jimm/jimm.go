@@ -8,6 +8,7 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"strings"
 	"time"
@@ -29,9 +30,12 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm/credentials"
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
+	"github.com/canonical/jimm/v3/internal/notifications"
+	"github.com/canonical/jimm/v3/internal/notify"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/internal/pubsub"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 var (
@@ -86,6 +90,71 @@ type JIMM struct {
 	// OAuthAuthenticator is responsible for handling authentication
 	// via OAuth2.0 AND JWT access tokens to JIMM.
 	OAuthAuthenticator OAuthAuthenticator
+
+	// AllowDuplicateControllers disables the check in AddController
+	// that rejects a controller with the same UUID or endpoints as an
+	// existing controller. This is only intended for testing, where the
+	// same controller is occasionally added with different names.
+	AllowDuplicateControllers bool
+
+	// LoginThrottle, if set, tracks failed logins and locks out
+	// identities and sources that fail too often.
+	LoginThrottle *LoginThrottle
+
+	// ControllerSelector, if set, chooses the controller used when
+	// creating models and hosted clouds. If it is nil a
+	// RegionPrioritySelector is used.
+	ControllerSelector ControllerSelector
+
+	// CredentialValidator, if set, checks cloud credentials with the
+	// cloud's provider before they are sent to controllers.
+	CredentialValidator CredentialValidator
+
+	// NotificationTransports holds the transports, keyed by name, that
+	// may be used to deliver notifications to users. If this is empty no
+	// notifications are sent.
+	NotificationTransports map[string]notify.Transport
+
+	// Lifecycle, if set, posts lifecycle events, such as a model being
+	// created, to the configured webhook endpoints.
+	Lifecycle *notifications.Dispatcher
+
+	// ArchiveDestroyedModels, if set, makes DestroyModel store a final
+	// snapshot of a model's configuration and status before destroying
+	// it. See GetModelArchive.
+	ArchiveDestroyedModels bool
+
+	// DefaultRegionPriority is the priority given to a newly added
+	// controller in the cloud regions, other than the one hosting its
+	// controller model, that it discovers. If this is zero
+	// dbmodel.CloudRegionControllerPrioritySupported is used.
+	DefaultRegionPriority uint
+
+	// SecretStore, if set, holds the secrets used to connect to
+	// controllers in place of the CredentialStore. Controller CA
+	// certificates are also kept in the SecretStore, rather than the
+	// database, when one is configured.
+	SecretStore credentials.SecretStore
+
+	// WebhookAllowedNetworks lists networks to which model webhooks may
+	// be registered even though their addresses are loopback, private
+	// or link-local.
+	WebhookAllowedNetworks []netip.Prefix
+
+	// CacheInvalidationBus, if set, is used to tell other JIMM replicas
+	// sharing the database to discard cached state that has been
+	// changed by this replica.
+	CacheInvalidationBus CacheInvalidationBus
+
+	// maintenance caches the maintenance mode read from the database.
+	maintenance maintenanceCache
+
+	// controllerVersion caches the earliest controller version.
+	controllerVersion controllerVersionCache
+
+	// deprecatedFacadeUsage records the clients calling deprecated
+	// facade versions.
+	deprecatedFacadeUsage deprecatedFacadeUsageRecorder
 }
 
 // ResourceTag returns JIMM's controller tag stating its UUID.
@@ -93,6 +162,42 @@ func (j *JIMM) ResourceTag() names.ControllerTag {
 	return names.NewControllerTag(j.UUID)
 }
 
+// AccessChangedTopic is the pub-sub topic on which an AccessChange is
+// published whenever JIMM changes the access an identity has to a
+// resource.
+const AccessChangedTopic = "jimm-access-changed"
+
+// An AccessChange is the message published on AccessChangedTopic.
+type AccessChange struct {
+	// Identity holds the name of the identity whose access changed. If
+	// it is empty the change may affect any identity, for example when
+	// the members of a group change.
+	Identity string
+
+	// Model holds the UUID of the model to which access changed. If it
+	// is empty the change may affect any model.
+	Model string
+}
+
+// Affects reports whether the change may have altered the access the
+// given identity has to the given model.
+func (c AccessChange) Affects(identity, model string) bool {
+	if c.Identity != "" && c.Identity != identity && c.Identity != ofganames.EveryoneUser {
+		return false
+	}
+	return c.Model == "" || c.Model == model
+}
+
+// notifyAccessChanged informs any subscribers to AccessChangedTopic that
+// access has changed. Only changes made through this JIMM instance are
+// published, subscribers should also poll to see changes made elsewhere.
+func (j *JIMM) notifyAccessChanged(change AccessChange) {
+	if j.Pubsub == nil {
+		return
+	}
+	j.Pubsub.Publish(AccessChangedTopic, change)
+}
+
 // DB returns the database used by JIMM.
 func (j *JIMM) DB() *db.Database {
 	return &j.Database
@@ -153,10 +258,24 @@ type OAuthAuthenticator interface {
 	// to indicate to the client to retry login.
 	VerifySessionToken(token string) (jwt.Token, error)
 
+	// SessionKeys returns the keys used for session tokens, the key used
+	// to sign new tokens first.
+	SessionKeys() []string
+
+	// SetSessionKeys replaces the keys used for session tokens. The first
+	// key is used to sign new tokens, every key is accepted when verifying
+	// them.
+	SetSessionKeys(keys []string)
+
 	// UpdateIdentity updates the database with the display name and access token set for the user.
 	// And, if present, a refresh token.
 	UpdateIdentity(ctx context.Context, email string, token *oauth2.Token) error
 
+	// SyncIdentityProfile updates the display name and email of the
+	// identity from the identity provider, using the identity's stored
+	// tokens.
+	SyncIdentityProfile(ctx context.Context, identity *dbmodel.Identity) error
+
 	// VerifyClientCredentials verifies the provided client ID and client secret.
 	VerifyClientCredentials(ctx context.Context, clientID string, clientSecret string) error
 
@@ -171,6 +290,46 @@ func (j *JIMM) GetCredentialStore() credentials.CredentialStore {
 	return j.CredentialStore
 }
 
+// ControllerCredentialStore returns the store holding the admin
+// credentials JIMM uses to connect to controllers. This is the
+// SecretStore if one is configured, otherwise the CredentialStore. If
+// neither is configured nil is returned.
+func (j *JIMM) ControllerCredentialStore() credentials.ControllerCredentialStore {
+	if j.SecretStore != nil {
+		return j.SecretStore
+	}
+	if j.CredentialStore == nil {
+		return nil
+	}
+	return j.CredentialStore
+}
+
+// RootKeyStore returns the store holding the macaroon root keys used by
+// JIMM's discharger. This is the controller credential store if it can
+// hold root keys, otherwise JIMM's database.
+func (j *JIMM) RootKeyStore() credentials.RootKeyStore {
+	if rks, ok := j.ControllerCredentialStore().(credentials.RootKeyStore); ok {
+		return rks
+	}
+	return &j.Database
+}
+
+// LoadControllerCACertificate fills in the CA certificate of the given
+// controller from the SecretStore if the controller record does not hold
+// one. Controllers without a stored certificate are left unchanged.
+func (j *JIMM) LoadControllerCACertificate(ctx context.Context, ctl *dbmodel.Controller) error {
+	const op = errors.Op("jimm.LoadControllerCACertificate")
+	if ctl.CACertificate != "" || j.SecretStore == nil {
+		return nil
+	}
+	caCert, err := j.SecretStore.GetControllerCACertificate(ctx, ctl.Name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	ctl.CACertificate = caCert
+	return nil
+}
+
 type permission struct {
 	resource string
 	relation string
@@ -295,6 +454,14 @@ type API interface {
 	// filter.
 	ListApplicationOffers(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 
+	// MigrationTargetPrechecks checks that the controller can accept
+	// the migration of the described model.
+	MigrationTargetPrechecks(context.Context, *jujuparams.MigrationModelInfo) error
+
+	// ModelGet returns the configuration of the model the API is
+	// connected to.
+	ModelGet(context.Context) (map[string]interface{}, error)
+
 	// ModelInfo fetches a model's ModelInfo.
 	ModelInfo(context.Context, *jujuparams.ModelInfo) error
 
@@ -337,6 +504,10 @@ type API interface {
 	// RevokeModelAccess revokes model access from a user.
 	RevokeModelAccess(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 
+	// SetPassword changes the password of a local user on the
+	// controller.
+	SetPassword(context.Context, names.UserTag, string) error
+
 	// SupportsCheckCredentialModels returns true if the
 	// CheckCredentialModels method can be used.
 	SupportsCheckCredentialModels() bool
@@ -408,6 +579,10 @@ func (j *JIMM) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
 	redactSensitiveParams(ale)
 	if err := j.Database.AddAuditLogEntry(ctx, ale); err != nil {
 		zapctx.Error(ctx, "cannot store audit log entry", zap.Error(err), zap.Any("entry", *ale))
+		return
+	}
+	if j.Pubsub != nil {
+		j.Pubsub.Publish(AuditEventTopic, *ale)
 	}
 }
 
@@ -433,18 +608,25 @@ func redactSensitiveParams(ale *dbmodel.AuditLogEntry) {
 }
 
 // FindAuditEvents returns audit events matching the given filter.
-func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error) {
+func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) (_ []dbmodel.AuditLogEntry, err error) {
 	const op = errors.Op("jimm.FindAuditEvents")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	access := user.GetAuditLogViewerAccess(ctx, j.ResourceTag())
 	if access != ofganames.AuditLogViewerRelation {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	// Audit queries can be expensive so are sent to the read replica,
+	// if there is one.
 	var entries []dbmodel.AuditLogEntry
-	err := j.Database.ForEachAuditLogEntry(ctx, filter, func(entry *dbmodel.AuditLogEntry) error {
-		entries = append(entries, *entry)
-		return nil
+	err = j.Database.ReadFromReplica(ctx, func(d *db.Database) error {
+		entries = entries[:0]
+		return d.ForEachAuditLogEntry(ctx, filter, func(entry *dbmodel.AuditLogEntry) error {
+			entries = append(entries, *entry)
+			return nil
+		})
 	})
 	if err != nil {
 		return nil, errors.E(op, err)
@@ -454,8 +636,10 @@ func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter d
 }
 
 // ControllerInfo returns info about a controller connected to JIMM.
-func (j *JIMM) ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error) {
+func (j *JIMM) ControllerInfo(ctx context.Context, name string) (_ *dbmodel.Controller, err error) {
 	const op = errors.Op("jimm.ListControllers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	ctl := dbmodel.Controller{
 		Name: name,
 	}
@@ -466,29 +650,65 @@ func (j *JIMM) ControllerInfo(ctx context.Context, name string) (*dbmodel.Contro
 }
 
 // ListControllers returns a list of controllers the user has access to.
-func (j *JIMM) ListControllers(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error) {
+// The cloud regions hosted by each controller are included.
+func (j *JIMM) ListControllers(ctx context.Context, user *openfga.User) (_ []dbmodel.Controller, err error) {
 	const op = errors.Op("jimm.ListControllers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
 	var controllers []dbmodel.Controller
-	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+	err = j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
 		controllers = append(controllers, *c)
 		return nil
 	})
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	for i := range controllers {
+		if err := j.Database.GetController(ctx, &controllers[i]); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
 
 	return controllers, nil
 }
 
+// ControllerModelCounts returns the number of models hosted on each
+// controller, keyed by controller name.
+func (j *JIMM) ControllerModelCounts(ctx context.Context, user *openfga.User) (_ map[string]int, err error) {
+	const op = errors.Op("jimm.ControllerModelCounts")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	counts, err := j.Database.CountModelsByControllers(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	result := make(map[string]int, len(counts))
+	err = j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+		result[c.Name] = counts[c.ID]
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return result, nil
+}
+
 // SetControllerDeprecated records if the controller is to be deprecated.
 // No new models or clouds can be added to a deprecated controller.
-func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error {
+func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) (err error) {
 	const op = errors.Op("jimm.SetControllerDeprecated")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -497,7 +717,7 @@ func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User,
 	// Update the local database with the updated cloud definition. We
 	// do this in a transaction so that the local view cannot finish in
 	// an inconsistent state.
-	err := j.Database.Transaction(func(db *db.Database) error {
+	err = j.Database.Transaction(func(db *db.Database) error {
 		c := dbmodel.Controller{
 			Name: controllerName,
 		}
@@ -515,8 +735,10 @@ func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User,
 }
 
 // RemoveController removes a controller.
-func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error {
+func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) (err error) {
 	const op = errors.Op("jimm.RemoveController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -525,7 +747,7 @@ func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, control
 	// Update the local database with the updated cloud definition. We
 	// do this in a transaction so that the local view cannot finish in
 	// an inconsistent state.
-	err := j.Database.Transaction(func(db *db.Database) error {
+	err = j.Database.Transaction(func(db *db.Database) error {
 		c := dbmodel.Controller{
 			Name: controllerName,
 		}
@@ -558,13 +780,16 @@ func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, control
 	if err != nil {
 		return errors.E(op, err)
 	}
+	j.invalidateCaches(ctx, ControllerVersionCache)
 
 	return nil
 }
 
 // FullModelStatus returns the full status of the juju model.
-func (j *JIMM) FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error) {
+func (j *JIMM) FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (_ *jujuparams.FullStatus, err error) {
 	const op = errors.Op("jimm.RemoveController")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -576,7 +801,7 @@ func (j *JIMM) FullModelStatus(ctx context.Context, user *openfga.User, modelTag
 			Valid:  true,
 		},
 	}
-	err := j.Database.GetModel(ctx, &model)
+	err = j.Database.GetModel(ctx, &model)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -596,7 +821,7 @@ func (j *JIMM) FullModelStatus(ctx context.Context, user *openfga.User, modelTag
 
 type migrationControllerID = uint
 
-func fillMigrationTarget(db db.Database, credStore credentials.CredentialStore, controllerName string) (jujuparams.MigrationTargetInfo, migrationControllerID, error) {
+func fillMigrationTarget(db db.Database, credStore credentials.ControllerCredentialStore, secretStore credentials.SecretStore, controllerName string) (jujuparams.MigrationTargetInfo, migrationControllerID, error) {
 	dbController := dbmodel.Controller{
 		Name: controllerName,
 	}
@@ -618,6 +843,12 @@ func fillMigrationTarget(db db.Database, credStore credentials.CredentialStore,
 	if adminUser == "" || adminPass == "" {
 		return jujuparams.MigrationTargetInfo{}, 0, errors.E("missing target controller credentials")
 	}
+	if dbController.CACertificate == "" && secretStore != nil {
+		dbController.CACertificate, err = secretStore.GetControllerCACertificate(ctx, controllerName)
+		if err != nil {
+			return jujuparams.MigrationTargetInfo{}, 0, err
+		}
+	}
 	// Should we verify controller can access the cloud where the model is currently hosted?
 	apiControllerInfo := dbController.ToAPIControllerInfo()
 	targetInfo := jujuparams.MigrationTargetInfo{
@@ -632,10 +863,12 @@ func fillMigrationTarget(db db.Database, credStore credentials.CredentialStore,
 }
 
 // InitiateInternalMigration initiates a model migration between two controllers within JIMM.
-func (j *JIMM) InitiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error) {
+func (j *JIMM) InitiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (_ jujuparams.InitiateMigrationResult, err error) {
 	const op = errors.Op("jimm.InitiateInternalMigration")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
-	migrationTarget, _, err := fillMigrationTarget(j.Database, j.CredentialStore, targetController)
+	migrationTarget, _, err := fillMigrationTarget(j.Database, j.ControllerCredentialStore(), j.SecretStore, targetController)
 	if err != nil {
 		return jujuparams.InitiateMigrationResult{}, errors.E(op, err)
 	}
@@ -295,6 +295,41 @@ func TestListControllers(t *testing.T) {
 	}
 }
 
+func TestControllerModelCounts(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testDrainControllerEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+
+	_, err = j.ControllerModelCounts(ctx, user)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	user.JimmAdmin = true
+	counts, err := j.ControllerModelCounts(ctx, user)
+	c.Assert(err, qt.IsNil)
+	c.Check(counts, qt.DeepEquals, map[string]int{"test1": 1})
+}
+
 const testSetControllerDeprecatedEnv = `clouds:
 - name: test
   type: test
@@ -821,7 +856,7 @@ func TestFillMigrationTarget(t *testing.T) {
 			env := jimmtest.ParseEnvironment(c, fillMigrationTargetTestEnv)
 			env.PopulateDB(c, db)
 
-			res, controllerID, err := jimm.FillMigrationTarget(db, store, test.controllerName)
+			res, controllerID, err := jimm.FillMigrationTarget(db, store, nil, test.controllerName)
 			if test.expectedError != "" {
 				c.Assert(err, qt.ErrorMatches, test.expectedError)
 				c.Assert(controllerID, qt.Equals, uint(0))
@@ -925,3 +960,37 @@ func TestInitiateInternalMigration(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessChangeAffects(t *testing.T) {
+	c := qt.New(t)
+
+	const model = "00000002-0000-0000-0000-000000000001"
+	tests := []struct {
+		change jimm.AccessChange
+		expect bool
+	}{{
+		change: jimm.AccessChange{},
+		expect: true,
+	}, {
+		change: jimm.AccessChange{Identity: "alice@canonical.com", Model: model},
+		expect: true,
+	}, {
+		change: jimm.AccessChange{Identity: "bob@canonical.com", Model: model},
+		expect: false,
+	}, {
+		change: jimm.AccessChange{Identity: "alice@canonical.com", Model: "00000002-0000-0000-0000-000000000002"},
+		expect: false,
+	}, {
+		change: jimm.AccessChange{Identity: "alice@canonical.com"},
+		expect: true,
+	}, {
+		change: jimm.AccessChange{Model: model},
+		expect: true,
+	}, {
+		change: jimm.AccessChange{Identity: "everyone@external", Model: model},
+		expect: true,
+	}}
+	for _, test := range tests {
+		c.Check(test.change.Affects("alice@canonical.com", model), qt.Equals, test.expect, qt.Commentf("%+v", test.change))
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// The entities that may be limited.
+const (
+	LimitEntityModels   = "models"
+	LimitEntityMachines = "machines"
+	LimitEntityCores    = "cores"
+	LimitEntityUnits    = "units"
+)
+
+// limitEntities holds the entities that may be limited.
+var limitEntities = []string{LimitEntityModels, LimitEntityMachines, LimitEntityCores, LimitEntityUnits}
+
+// A limitScope identifies a single user, group, controller or cloud to
+// which limits apply.
+type limitScope struct {
+	kind string
+	id   string
+
+	// groups holds the IDs of the groups a user is a member of. The
+	// limits on each group apply to the user if the user has no limit of
+	// their own.
+	groups []string
+}
+
+// String returns the scope in the form used by dbmodel.Limit.
+func (s limitScope) String() string {
+	return s.kind + "-" + s.id
+}
+
+// parseLimitScope parses a limit scope, which may be just a kind if
+// the limit is a default.
+func parseLimitScope(scope string) (limitScope, error) {
+	kind, id, _ := strings.Cut(scope, "-")
+	var valid bool
+	switch kind {
+	case names.UserTagKind:
+		valid = id == "" || names.IsValidUser(id)
+	case names.CloudTagKind:
+		valid = id == "" || names.IsValidCloud(id)
+	case names.ControllerTagKind:
+		valid = true
+	case jimmnames.GroupTagKind:
+		valid = jimmnames.IsValidGroupName(id) || jimmnames.IsValidGroupId(id)
+	}
+	if !valid || strings.HasSuffix(scope, "-") {
+		return limitScope{}, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid limit scope %q", scope))
+	}
+	return limitScope{kind: kind, id: id}, nil
+}
+
+// usageOf returns the amount of the given entity in the usage.
+func usageOf(u db.ModelUsage, entity string) int64 {
+	switch entity {
+	case LimitEntityModels:
+		return u.Models
+	case LimitEntityMachines:
+		return u.Machines
+	case LimitEntityCores:
+		return u.Cores
+	case LimitEntityUnits:
+		return u.Units
+	}
+	return 0
+}
+
+// A LimitUsage is a limit along with the current usage of the limited
+// resource.
+type LimitUsage struct {
+	dbmodel.Limit
+
+	// Usage holds the amount of the resource used within the limit's
+	// scope. It is nil for default limits.
+	Usage *int64
+}
+
+// SetLimit sets the maximum amount of the given entity that may be used
+// within the scope. The scope is either a single user, group, controller
+// or cloud, for example "user-alice@canonical.com", "group-team-a" or
+// "controller-ctl-1", or just "user", "controller" or "cloud" to set the
+// default for every user, controller or cloud without a limit of its
+// own. Limits on a user or cloud apply to the models owned by the user or
+// deployed to the cloud. Limits on a group apply to each member of the
+// group that has no limit of their own, if a user is a member of several
+// groups the lowest of their limits applies. Limits on a controller apply to the models it hosts, and a
+// controller at its limit is not selected for new models. Limits are
+// checked when resources are created, so existing usage may exceed a
+// newly set limit. Only JIMM administrators may set limits.
+func (j *JIMM) SetLimit(ctx context.Context, user *openfga.User, entity, scope string, value int64) (err error) {
+	const op = errors.Op("jimm.SetLimit")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := validateLimit(entity, scope); err != nil {
+		return errors.E(op, err)
+	}
+	if value < 0 {
+		return errors.E(op, errors.CodeBadRequest, "limit cannot be negative")
+	}
+	dbScope, err := j.resolveLimitScope(ctx, scope)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	limit := dbmodel.Limit{
+		Entity: entity,
+		Scope:  dbScope,
+		Value:  value,
+	}
+	if err := j.Database.SetLimit(ctx, &limit); err != nil {
+		return errors.E(op, err)
+	}
+	j.auditAdminCall(user, "SetLimit", map[string]any{"entity": entity, "scope": scope, "value": value})
+	return nil
+}
+
+// RemoveLimit removes the limit on the given entity within the scope,
+// see SetLimit. Only JIMM administrators may remove limits.
+func (j *JIMM) RemoveLimit(ctx context.Context, user *openfga.User, entity, scope string) (err error) {
+	const op = errors.Op("jimm.RemoveLimit")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := validateLimit(entity, scope); err != nil {
+		return errors.E(op, err)
+	}
+	dbScope, err := j.resolveLimitScope(ctx, scope)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.RemoveLimit(ctx, &dbmodel.Limit{Entity: entity, Scope: dbScope}); err != nil {
+		return errors.E(op, err)
+	}
+	j.auditAdminCall(user, "RemoveLimit", map[string]any{"entity": entity, "scope": scope})
+	return nil
+}
+
+// ListLimits returns every limit along with the current usage of each
+// limit that is not a default or a group limit. Group limits are returned
+// with the scope naming the group. Only JIMM administrators may list
+// limits.
+func (j *JIMM) ListLimits(ctx context.Context, user *openfga.User) (_ []LimitUsage, err error) {
+	const op = errors.Op("jimm.ListLimits")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	limits, err := j.Database.ListLimits(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	usages := make(map[string]db.ModelUsage)
+	result := make([]LimitUsage, len(limits))
+	for i, l := range limits {
+		result[i].Limit = l
+		s, err := parseLimitScope(l.Scope)
+		if err != nil || s.id == "" {
+			continue
+		}
+		if s.kind == jimmnames.GroupTagKind {
+			group := dbmodel.GroupEntry{UUID: s.id}
+			if err := j.Database.GetGroup(ctx, &group); err == nil {
+				result[i].Scope = jimmnames.GroupTagKind + "-" + group.Name
+			}
+			continue
+		}
+		u, ok := usages[l.Scope]
+		if !ok {
+			// Usage reports are read from the read replica, if
+			// there is one, limits are always enforced using the
+			// primary.
+			err = j.Database.ReadFromReplica(ctx, func(d *db.Database) error {
+				u, err = d.GetModelUsage(ctx, s.kind, s.id)
+				return err
+			})
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			usages[l.Scope] = u
+		}
+		n := usageOf(u, l.Entity)
+		result[i].Usage = &n
+	}
+	return result, nil
+}
+
+// validateLimit checks that the given entity can be limited within the
+// scope.
+func validateLimit(entity, scope string) error {
+	valid := false
+	for _, e := range limitEntities {
+		valid = valid || e == entity
+	}
+	if !valid {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid limit entity %q", entity))
+	}
+	_, err := parseLimitScope(scope)
+	return err
+}
+
+// resolveLimitScope returns the scope as stored in the database. Groups
+// are stored by ID so that limits follow a group that is renamed.
+func (j *JIMM) resolveLimitScope(ctx context.Context, scope string) (string, error) {
+	s, err := parseLimitScope(scope)
+	if err != nil || s.kind != jimmnames.GroupTagKind {
+		return scope, err
+	}
+	group := dbmodel.GroupEntry{Name: s.id}
+	if jimmnames.IsValidGroupId(s.id) {
+		group = dbmodel.GroupEntry{UUID: s.id}
+	}
+	if err := j.Database.GetGroup(ctx, &group); err != nil {
+		return "", err
+	}
+	return jimmnames.GroupTagKind + "-" + group.UUID, nil
+}
+
+// userLimitScope returns the limit scope for the given user, including
+// the groups the user is a member of.
+func (j *JIMM) userLimitScope(ctx context.Context, identityName string) (limitScope, error) {
+	s := limitScope{kind: names.UserTagKind, id: identityName}
+	if j.OpenFGAClient == nil {
+		return s, nil
+	}
+	groups, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTag(names.NewUserTag(identityName)), ofganames.MemberRelation, openfga.GroupType, nil)
+	if err != nil {
+		return limitScope{}, err
+	}
+	for _, g := range groups {
+		s.groups = append(s.groups, g.ID)
+	}
+	return s, nil
+}
+
+// auditAdminCall records a change made by an administrative JIMM method,
+// such as one changing the limits, in the audit log.
+func (j *JIMM) auditAdminCall(user *openfga.User, method string, args map[string]any) {
+	params, _ := json.Marshal(args)
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+}
+
+// usageAlertPercent is the percentage of a user's limit at which the
+// user is sent a usage alert.
+const usageAlertPercent = 80
+
+// checkLimits checks, using the given database, that using the additional
+// resources within each of the given scopes does not exceed any limit
+// that applies to the scope. If a limit would be exceeded an error with a
+// code of CodeQuotaLimitExceeded is returned. Otherwise a usage alert is
+// returned for each user whose usage the additional resources take past
+// usageAlertPercent of one of their limits. The alerts should be sent
+// with sendUsageAlerts once the resources have been used.
+func (j *JIMM) checkLimits(ctx context.Context, d *db.Database, add db.ModelUsage, scopes ...limitScope) ([]notify.Notification, error) {
+	var alerts []notify.Notification
+	for _, s := range scopes {
+		scopes := []string{s.kind, s.String()}
+		for _, g := range s.groups {
+			scopes = append(scopes, jimmnames.GroupTagKind+"-"+g)
+		}
+		limits, err := d.ListLimits(ctx, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		effective := make(map[string]int64)
+		precedence := make(map[string]int)
+		for _, l := range limits {
+			// A limit on a single user, controller or cloud takes
+			// precedence over the lowest of the limits on the user's
+			// groups, which takes precedence over the default.
+			p := 0
+			switch {
+			case l.Scope == s.String():
+				p = 2
+			case l.Scope != s.kind:
+				p = 1
+			}
+			v, ok := effective[l.Entity]
+			if !ok || p > precedence[l.Entity] || (p == precedence[l.Entity] && l.Value < v) {
+				effective[l.Entity] = l.Value
+				precedence[l.Entity] = p
+			}
+		}
+		if len(effective) == 0 {
+			continue
+		}
+		usage, err := d.GetModelUsage(ctx, s.kind, s.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range limitEntities {
+			limit, ok := effective[entity]
+			if !ok {
+				continue
+			}
+			used, total := usageOf(usage, entity), usageOf(usage, entity)+usageOf(add, entity)
+			if total > limit {
+				return nil, errors.E(errors.CodeQuotaLimitExceeded, fmt.Sprintf("%s limit of %d exceeded for %s %s", entity, limit, s.kind, s.id))
+			}
+			if s.kind == names.UserTagKind && used*100 < limit*usageAlertPercent && total*100 >= limit*usageAlertPercent {
+				alerts = append(alerts, notify.Notification{
+					Kind:      notify.KindUsageAlert,
+					Recipient: s.id,
+					Subject:   fmt.Sprintf("Approaching %s limit", entity),
+					Message:   fmt.Sprintf("You are now using %d of your limit of %d %s.", total, limit, entity),
+				})
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// sendUsageAlerts sends the usage alerts returned by checkLimits.
+func (j *JIMM) sendUsageAlerts(ctx context.Context, alerts []notify.Notification) {
+	for _, n := range alerts {
+		j.sendNotification(ctx, n.Recipient, n)
+	}
+}
+
+// lockLimitScopes locks the usage within each of the given scopes until
+// the end of the transaction using d, see db.LockModelUsage.
+func lockLimitScopes(ctx context.Context, d *db.Database, scopes ...limitScope) error {
+	keys := make([]string, len(scopes))
+	for i, s := range scopes {
+		keys[i] = s.String()
+	}
+	return d.LockModelUsage(ctx, keys...)
+}
+
+// controllersWithinLimits returns the candidate controllers that can host
+// another model without exceeding their limits. If there are none an
+// error with a code of CodeQuotaLimitExceeded is returned.
+func (j *JIMM) controllersWithinLimits(ctx context.Context, candidates []dbmodel.CloudRegionControllerPriority) ([]dbmodel.CloudRegionControllerPriority, error) {
+	within := make(map[string]bool)
+	var result []dbmodel.CloudRegionControllerPriority
+	for _, c := range candidates {
+		ok, checked := within[c.Controller.Name]
+		if !checked {
+			_, err := j.checkLimits(ctx, &j.Database, db.ModelUsage{Models: 1}, limitScope{kind: names.ControllerTagKind, id: c.Controller.Name})
+			if err != nil && errors.ErrorCode(err) != errors.CodeQuotaLimitExceeded {
+				return nil, err
+			}
+			ok = err == nil
+			within[c.Controller.Name] = ok
+		}
+		if ok {
+			result = append(result, c)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.E(errors.CodeQuotaLimitExceeded, "no controller can host another model")
+	}
+	return result, nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestLimits(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, controller, _, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, ofgaClient)
+
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "user", 1)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	err = j.SetLimit(ctx, u, "applications", "user", 1)
+	c.Check(err, qt.ErrorMatches, `invalid limit entity "applications"`)
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "model", 1)
+	c.Check(err, qt.ErrorMatches, `invalid limit scope "model"`)
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "user-", 1)
+	c.Check(err, qt.ErrorMatches, `invalid limit scope "user-"`)
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "user", -1)
+	c.Check(err, qt.ErrorMatches, `limit cannot be negative`)
+
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "user", 5)
+	c.Assert(err, qt.IsNil)
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "controller-"+controller.Name, 2)
+	c.Assert(err, qt.IsNil)
+	// Setting an existing limit replaces its value.
+	err = j.SetLimit(ctx, u, jimm.LimitEntityModels, "controller-"+controller.Name, 1)
+	c.Assert(err, qt.IsNil)
+
+	limits, err := j.ListLimits(ctx, u)
+	c.Assert(err, qt.IsNil)
+	one := int64(1)
+	c.Check(limits, qt.CmpEquals(cmpopts.IgnoreTypes(time.Time{}), cmpopts.IgnoreFields(dbmodel.Limit{}, "ID")), []jimm.LimitUsage{{
+		Limit: dbmodel.Limit{Entity: jimm.LimitEntityModels, Scope: "controller-" + controller.Name, Value: 1},
+		Usage: &one,
+	}, {
+		Limit: dbmodel.Limit{Entity: jimm.LimitEntityModels, Scope: "user", Value: 5},
+	}})
+
+	// The controller already hosts one model, so cannot host another.
+	candidates := []dbmodel.CloudRegionControllerPriority{{Controller: controller}}
+	_, err = j.ControllersWithinLimits(ctx, candidates)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeQuotaLimitExceeded)
+
+	err = j.RemoveLimit(ctx, u, jimm.LimitEntityModels, "controller-"+controller.Name)
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveLimit(ctx, u, jimm.LimitEntityModels, "controller-"+controller.Name)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	within, err := j.ControllersWithinLimits(ctx, candidates)
+	c.Assert(err, qt.IsNil)
+	c.Check(within, qt.HasLen, 1)
+}
+
+func TestGroupLimits(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// The user already owns one model.
+	user, _, _, _, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	group, err := j.Database.AddGroup(ctx, "team-a")
+	c.Assert(err, qt.IsNil)
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag(user.Name)),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(group.UUID)),
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = j.SetLimit(ctx, admin, jimm.LimitEntityModels, "group-no-such-group", 1)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.SetLimit(ctx, admin, jimm.LimitEntityModels, "user", 5)
+	c.Assert(err, qt.IsNil)
+	err = j.SetLimit(ctx, admin, jimm.LimitEntityModels, "group-team-a", 1)
+	c.Assert(err, qt.IsNil)
+
+	limits, err := j.ListLimits(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(limits, qt.CmpEquals(cmpopts.IgnoreTypes(time.Time{}), cmpopts.IgnoreFields(dbmodel.Limit{}, "ID")), []jimm.LimitUsage{{
+		Limit: dbmodel.Limit{Entity: jimm.LimitEntityModels, Scope: "group-team-a", Value: 1},
+	}, {
+		Limit: dbmodel.Limit{Entity: jimm.LimitEntityModels, Scope: "user", Value: 5},
+	}})
+
+	// The group limit takes precedence over the default.
+	err = j.CheckUserLimits(ctx, user.Name, db.ModelUsage{Models: 1})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeQuotaLimitExceeded)
+
+	// The user's own limit takes precedence over the group limit.
+	err = j.SetLimit(ctx, admin, jimm.LimitEntityModels, "user-"+user.Name, 2)
+	c.Assert(err, qt.IsNil)
+	err = j.CheckUserLimits(ctx, user.Name, db.ModelUsage{Models: 1})
+	c.Check(err, qt.IsNil)
+
+	err = j.RemoveLimit(ctx, admin, jimm.LimitEntityModels, "group-team-a")
+	c.Assert(err, qt.IsNil)
+}
+
+const addModelLimitsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: cred-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 0
+`
+
+func TestAddModelLimits(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	newModel := createModel(`
+status:
+  status: started
+life: alive
+`[1:])
+	transport := testTransport{sent: make(chan string, 10)}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+					err := newModel(ctx, args, mi)
+					mi.UUID = uuid.NewString()
+					return err
+				},
+			},
+		},
+		OpenFGAClient: ofgaClient,
+		NotificationTransports: map[string]notify.Transport{
+			"test": transport,
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, addModelLimitsTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, ofgaClient)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+	err = j.SetLimit(ctx, admin, jimm.LimitEntityModels, "user", 3)
+	c.Assert(err, qt.IsNil)
+
+	addModel := func(owner, name string) error {
+		identity := env.User(owner).DBObject(c, j.Database)
+		u := openfga.NewUser(&identity, ofgaClient)
+		args := jimm.ModelCreateArgs{}
+		err := args.FromJujuModelCreateArgs(&jujuparams.ModelCreateArgs{
+			Name:               name,
+			OwnerTag:           names.NewUserTag(owner).String(),
+			CloudTag:           names.NewCloudTag("test-cloud").String(),
+			CloudRegion:        "test-region-1",
+			CloudCredentialTag: names.NewCloudCredentialTag("test-cloud/" + owner + "/cred-1").String(),
+		})
+		c.Assert(err, qt.IsNil)
+		_, err = j.AddModel(ctx, u, &args)
+		return err
+	}
+	for _, owner := range []string{"alice@canonical.com", "bob@canonical.com"} {
+		identity := env.User(owner).DBObject(c, j.Database)
+		_, err = j.AddNotificationRoute(ctx, openfga.NewUser(&identity, ofgaClient), "", notify.KindUsageAlert, "test", owner)
+		c.Assert(err, qt.IsNil)
+	}
+
+	// Users without permission to add models are not sent usage alerts.
+	for i := 0; i < 3; i++ {
+		err = addModel("bob@canonical.com", fmt.Sprintf("model-%d", i))
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	}
+
+	// Concurrent requests cannot exceed the limit, and the usage alert
+	// is sent once.
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = addModel("alice@canonical.com", fmt.Sprintf("model-%d", i))
+		}(i)
+	}
+	wg.Wait()
+	var added, exceeded int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case errors.ErrorCode(err) == errors.CodeQuotaLimitExceeded:
+			exceeded++
+		default:
+			c.Errorf("unexpected error: %v", err)
+		}
+	}
+	c.Check(added, qt.Equals, 3)
+	c.Check(exceeded, qt.Equals, 2)
+
+	c.Check(<-transport.sent, qt.Equals, "usage-alert alice@canonical.com alice@canonical.com")
+	select {
+	case sent := <-transport.sent:
+		c.Errorf("unexpected notification %q", sent)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
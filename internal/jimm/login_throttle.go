@@ -0,0 +1,241 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// LoginThrottleParams holds the parameters used to configure a
+// LoginThrottle.
+type LoginThrottleParams struct {
+	// MaxFailures is the number of failed logins allowed from an
+	// identity and source within Window before further attempts are
+	// locked out.
+	MaxFailures int
+
+	// Window is the period over which failed logins are counted.
+	Window time.Duration
+
+	// LockoutDuration is how long logins are refused once MaxFailures
+	// or MaxIdentityFailures has been reached.
+	LockoutDuration time.Duration
+
+	// MaxIdentityFailures is the number of failed logins allowed from
+	// an identity within Window, from any source and on any JIMM
+	// replica, before further attempts are locked out. These failures
+	// are counted in the database. If it is zero only failures from a
+	// single source on a single replica are limited.
+	MaxIdentityFailures int
+}
+
+// A LoginThrottle tracks failed logins per identity and source address
+// and locks out further attempts once too many have failed. The counts
+// are held in memory, so they only limit a single client connecting to
+// a single replica. JIMM additionally counts the failures of each
+// identity in the database when MaxIdentityFailures is set.
+type LoginThrottle struct {
+	params LoginThrottleParams
+
+	mu       sync.Mutex
+	attempts map[loginKey]*loginAttempts
+}
+
+type loginKey struct {
+	identity string
+	source   string
+}
+
+type loginAttempts struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// NewLoginThrottle returns a new LoginThrottle using the given
+// parameters.
+func NewLoginThrottle(p LoginThrottleParams) *LoginThrottle {
+	return &LoginThrottle{
+		params:   p,
+		attempts: make(map[loginKey]*loginAttempts),
+	}
+}
+
+// Check returns an error with a code of CodeUnauthorized if logins for
+// the given identity and source are currently locked out. A nil
+// LoginThrottle never locks out logins.
+func (t *LoginThrottle) Check(identity, source string, now time.Time) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.attempts[loginKey{identity, source}]
+	if ok && now.Before(a.lockedUntil) {
+		return errors.E(errors.CodeUnauthorized, "too many failed login attempts, try again later")
+	}
+	return nil
+}
+
+// Failure records a failed login for the given identity and source. It
+// reports whether this failure caused logins to be locked out.
+func (t *LoginThrottle) Failure(identity, source string, now time.Time) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(now)
+
+	k := loginKey{identity, source}
+	a, ok := t.attempts[k]
+	if !ok || now.Sub(a.windowStart) > t.params.Window {
+		a = &loginAttempts{windowStart: now}
+		t.attempts[k] = a
+	}
+	a.failures++
+	if a.failures >= t.params.MaxFailures && !now.Before(a.lockedUntil) {
+		a.lockedUntil = now.Add(t.params.LockoutDuration)
+		a.failures = 0
+		a.windowStart = now
+		return true
+	}
+	return false
+}
+
+// Success clears any failed logins recorded for the given identity and
+// source.
+func (t *LoginThrottle) Success(identity, source string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.attempts, loginKey{identity, source})
+}
+
+// prune removes records that no longer affect logins. The caller must
+// hold t.mu.
+func (t *LoginThrottle) prune(now time.Time) {
+	for k, a := range t.attempts {
+		if now.Sub(a.windowStart) > t.params.Window && !now.Before(a.lockedUntil) {
+			delete(t.attempts, k)
+		}
+	}
+}
+
+// checkLoginThrottle returns an error if logins for the given identity
+// from the client address held in the context are locked out, or if
+// logins for the identity are locked out on every replica.
+func (j *JIMM) checkLoginThrottle(ctx context.Context, identity string) error {
+	now := time.Now()
+	if err := j.LoginThrottle.Check(identity, loginSource(ctx), now); err != nil {
+		return err
+	}
+	if j.LoginThrottle == nil || j.LoginThrottle.params.MaxIdentityFailures <= 0 || identity == "" {
+		return nil
+	}
+	lockedUntil, err := j.Database.GetLoginLockedUntil(ctx, identity)
+	if err != nil {
+		return err
+	}
+	if now.Before(lockedUntil) {
+		return errors.E(errors.CodeUnauthorized, "too many failed login attempts, try again later")
+	}
+	return nil
+}
+
+// recordLoginResult records the result of a login attempt with the
+// LoginThrottle and, if MaxIdentityFailures is set, in the database. If
+// the failure causes a lockout a warning is logged and an entry is added
+// to the audit log.
+func (j *JIMM) recordLoginResult(ctx context.Context, method, identity string, loginErr error) {
+	if j.LoginThrottle == nil {
+		return
+	}
+	source := loginSource(ctx)
+	now := time.Now()
+	shared := j.LoginThrottle.params.MaxIdentityFailures > 0 && identity != ""
+	if loginErr == nil {
+		j.LoginThrottle.Success(identity, source)
+		if shared {
+			if err := j.Database.DeleteLoginFailures(ctx, identity, now); err != nil {
+				zapctx.Error(ctx, "failed to clear login failures", zap.String("identity", identity), zap.Error(err))
+			}
+		}
+		return
+	}
+	locked := j.LoginThrottle.Failure(identity, source, now)
+	if shared {
+		p := j.LoginThrottle.params
+		identityLocked, err := j.Database.AddLoginFailure(ctx, identity, now, p.Window, p.MaxIdentityFailures, p.LockoutDuration)
+		if err != nil {
+			zapctx.Error(ctx, "failed to record login failure", zap.String("identity", identity), zap.Error(err))
+		}
+		locked = locked || identityLocked
+	}
+	if !locked {
+		return
+	}
+
+	zapctx.Warn(ctx, "login locked out after repeated failures", zap.String("identity", identity), zap.String("source", source))
+	params, _ := json.Marshal(map[string]string{
+		"identity": identity,
+		"source":   source,
+	})
+	errs, _ := json.Marshal(map[string]string{
+		"error": "too many failed login attempts",
+	})
+	entry := &dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "Admin",
+		FacadeMethod: method,
+		IsResponse:   true,
+		Params:       params,
+		Errors:       errs,
+	}
+	if identity != "" {
+		if i, err := dbmodel.NewIdentity(identity); err == nil {
+			entry.IdentityTag = i.Tag().String()
+		}
+	}
+	j.AddAuditLogEntry(entry)
+}
+
+// sessionTokenSubject returns the identity claimed by the given session
+// token without verifying it, or an empty string if the token cannot be
+// parsed.
+func sessionTokenSubject(token string) string {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ""
+	}
+	t, err := jwt.ParseInsecure(decoded)
+	if err != nil {
+		return ""
+	}
+	return t.Subject()
+}
+
+// loginSource returns the host part of the client address held in the
+// context.
+func loginSource(ctx context.Context) string {
+	addr := auth.RemoteAddrFromContext(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	c := qt.New(t)
+
+	throttle := jimm.NewLoginThrottle(jimm.LoginThrottleParams{
+		MaxFailures:     3,
+		Window:          time.Minute,
+		LockoutDuration: 10 * time.Minute,
+	})
+	now := time.Now()
+
+	c.Check(throttle.Failure("alice@canonical.com", "10.0.0.1", now), qt.IsFalse)
+	c.Check(throttle.Failure("alice@canonical.com", "10.0.0.1", now.Add(time.Second)), qt.IsFalse)
+	c.Check(throttle.Check("alice@canonical.com", "10.0.0.1", now.Add(2*time.Second)), qt.IsNil)
+	c.Check(throttle.Failure("alice@canonical.com", "10.0.0.1", now.Add(2*time.Second)), qt.IsTrue)
+
+	err := throttle.Check("alice@canonical.com", "10.0.0.1", now.Add(3*time.Second))
+	c.Check(err, qt.ErrorMatches, "too many failed login attempts, try again later")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Other sources and identities are unaffected.
+	c.Check(throttle.Check("alice@canonical.com", "10.0.0.2", now.Add(3*time.Second)), qt.IsNil)
+	c.Check(throttle.Check("bob@canonical.com", "10.0.0.1", now.Add(3*time.Second)), qt.IsNil)
+
+	// The lockout expires.
+	c.Check(throttle.Check("alice@canonical.com", "10.0.0.1", now.Add(11*time.Minute)), qt.IsNil)
+
+	// Failures outside the window are not counted together.
+	c.Check(throttle.Failure("bob@canonical.com", "10.0.0.1", now), qt.IsFalse)
+	c.Check(throttle.Failure("bob@canonical.com", "10.0.0.1", now.Add(2*time.Minute)), qt.IsFalse)
+	c.Check(throttle.Failure("bob@canonical.com", "10.0.0.1", now.Add(3*time.Minute)), qt.IsFalse)
+
+	// A successful login clears previous failures.
+	throttle.Success("bob@canonical.com", "10.0.0.1")
+	c.Check(throttle.Failure("bob@canonical.com", "10.0.0.1", now.Add(3*time.Minute)), qt.IsFalse)
+
+	// A nil throttle never locks out.
+	var nilThrottle *jimm.LoginThrottle
+	c.Check(nilThrottle.Failure("alice@canonical.com", "10.0.0.1", now), qt.IsFalse)
+	c.Check(nilThrottle.Check("alice@canonical.com", "10.0.0.1", now), qt.IsNil)
+}
+
+func TestLoginThrottleSharedSource(t *testing.T) {
+	c := qt.New(t)
+
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OAuthAuthenticator: &mockAuthenticator,
+		OpenFGAClient:      client,
+		LoginThrottle: jimm.NewLoginThrottle(jimm.LoginThrottleParams{
+			MaxFailures:     2,
+			Window:          time.Minute,
+			LockoutDuration: 10 * time.Minute,
+		}),
+	}
+	err = j.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	// alice and bob connect through the same proxy.
+	ctx := auth.ContextWithRemoteAddr(context.Background(), "10.0.0.1:1234")
+	sessionToken := func(email string) string {
+		token, err := jwt.NewBuilder().Subject(email).Build()
+		c.Assert(err, qt.IsNil)
+		b, err := jwt.NewSerializer().Serialize(token)
+		c.Assert(err, qt.IsNil)
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	// Invalid session tokens are not counted as failed logins.
+	for i := 0; i < 5; i++ {
+		_, err = j.LoginWithSessionToken(ctx, "invalid-token")
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+	}
+	_, err = j.LoginWithSessionToken(ctx, sessionToken("alice@canonical.com"))
+	c.Assert(err, qt.IsNil)
+
+	// Lock out alice from the shared address.
+	now := time.Now()
+	j.LoginThrottle.Failure("alice@canonical.com", "10.0.0.1", now)
+	j.LoginThrottle.Failure("alice@canonical.com", "10.0.0.1", now)
+
+	_, err = j.LoginWithSessionToken(ctx, sessionToken("alice@canonical.com"))
+	c.Check(err, qt.ErrorMatches, "too many failed login attempts, try again later")
+
+	// bob is unaffected.
+	user, err := j.LoginWithSessionToken(ctx, sessionToken("bob@canonical.com"))
+	c.Assert(err, qt.IsNil)
+	c.Check(user.Name, qt.Equals, "bob@canonical.com")
+}
+
+func TestLoginThrottleIdentityFailures(t *testing.T) {
+	c := qt.New(t)
+
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+	j := jimm.JIMM{
+		UUID: "foo",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OAuthAuthenticator: &mockAuthenticator,
+		OpenFGAClient:      client,
+		LoginThrottle: jimm.NewLoginThrottle(jimm.LoginThrottleParams{
+			MaxFailures:         2,
+			Window:              time.Minute,
+			LockoutDuration:     10 * time.Minute,
+			MaxIdentityFailures: 3,
+		}),
+	}
+	err = j.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	sessionToken := func(email string) string {
+		token, err := jwt.NewBuilder().Subject(email).Build()
+		c.Assert(err, qt.IsNil)
+		b, err := jwt.NewSerializer().Serialize(token)
+		c.Assert(err, qt.IsNil)
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	// Failures for alice from different addresses, possibly recorded by
+	// other replicas, are counted together.
+	ctx := context.Background()
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		locked, err := j.Database.AddLoginFailure(ctx, "alice@canonical.com", now, time.Minute, 3, 10*time.Minute)
+		c.Assert(err, qt.IsNil)
+		c.Check(locked, qt.Equals, i == 2)
+	}
+
+	ctx = auth.ContextWithRemoteAddr(ctx, "10.0.0.9:1234")
+	_, err = j.LoginWithSessionToken(ctx, sessionToken("alice@canonical.com"))
+	c.Check(err, qt.ErrorMatches, "too many failed login attempts, try again later")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// bob is unaffected.
+	user, err := j.LoginWithSessionToken(ctx, sessionToken("bob@canonical.com"))
+	c.Assert(err, qt.IsNil)
+	c.Check(user.Name, qt.Equals, "bob@canonical.com")
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// maintenanceCacheDuration is the length of time the maintenance mode is
+// cached before it is read from the database again. Changes made by
+// other replicas take up to this long to be seen unless the replicas
+// share a CacheInvalidationBus.
+const maintenanceCacheDuration = 5 * time.Second
+
+// maintenanceCache holds the most recently read maintenance mode.
+type maintenanceCache struct {
+	mu      sync.Mutex
+	mode    dbmodel.MaintenanceMode
+	expires time.Time
+}
+
+// GetMaintenanceMode returns JIMM's current maintenance mode. Any
+// authenticated user may see the maintenance mode, so no user is
+// required.
+func (j *JIMM) GetMaintenanceMode(ctx context.Context) (_ *dbmodel.MaintenanceMode, err error) {
+	const op = errors.Op("jimm.GetMaintenanceMode")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	j.maintenance.mu.Lock()
+	defer j.maintenance.mu.Unlock()
+	if time.Now().Before(j.maintenance.expires) {
+		mm := j.maintenance.mode
+		return &mm, nil
+	}
+	mm, err := j.Database.GetMaintenanceMode(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	j.maintenance.mode = *mm
+	j.maintenance.expires = time.Now().Add(maintenanceCacheDuration)
+	return mm, nil
+}
+
+// SetMaintenanceMode enables, or if enabled is false disables,
+// maintenance mode. While in maintenance mode requests that would modify
+// JIMM's state are rejected and the given message is returned to the
+// client. The change is recorded in the audit log. Only JIMM
+// administrators may set the maintenance mode.
+func (j *JIMM) SetMaintenanceMode(ctx context.Context, user *openfga.User, enabled bool, message string) (err error) {
+	const op = errors.Op("jimm.SetMaintenanceMode")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if !enabled {
+		message = ""
+	}
+	mm := dbmodel.MaintenanceMode{
+		Enabled: enabled,
+		Message: message,
+		SetBy:   user.Name,
+	}
+	if err := j.Database.SetMaintenanceMode(ctx, &mm); err != nil {
+		return errors.E(op, err)
+	}
+
+	j.maintenance.mu.Lock()
+	j.maintenance.mode = mm
+	j.maintenance.expires = time.Now().Add(maintenanceCacheDuration)
+	j.maintenance.mu.Unlock()
+	j.notifyCacheInvalidation(ctx, MaintenanceModeCache)
+
+	params, _ := json.Marshal(map[string]any{
+		"enabled": enabled,
+		"message": message,
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "SetMaintenanceMode",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return nil
+}
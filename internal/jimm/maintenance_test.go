@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	mm, err := j.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+
+	u := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, ofgaClient)
+	err = j.SetMaintenanceMode(ctx, u, true, "upgrading")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	err = j.SetMaintenanceMode(ctx, u, true, "upgrading")
+	c.Assert(err, qt.IsNil)
+
+	mm, err = j.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsTrue)
+	c.Check(mm.Message, qt.Equals, "upgrading")
+	c.Check(mm.SetBy, qt.Equals, "alice@canonical.com")
+
+	// The change is seen by other JIMM instances using the database.
+	j2 := &jimm.JIMM{Database: j.Database}
+	mm, err = j2.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsTrue)
+
+	err = j.SetMaintenanceMode(ctx, u, false, "ignored")
+	c.Assert(err, qt.IsNil)
+	mm, err = j.GetMaintenanceMode(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(mm.Enabled, qt.IsFalse)
+	c.Check(mm.Message, qt.Equals, "")
+
+	var logs []dbmodel.AuditLogEntry
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "SetMaintenanceMode"}, func(ale *dbmodel.AuditLogEntry) error {
+		logs = append(logs, *ale)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(logs, qt.HasLen, 2)
+}
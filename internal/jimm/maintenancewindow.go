@@ -0,0 +1,163 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddMaintenanceWindow declares a maintenance window for the named
+// controller between the given times. Only JIMM administrators may
+// declare maintenance windows.
+func (j *JIMM) AddMaintenanceWindow(ctx context.Context, user *openfga.User, controllerName string, startsAt, endsAt time.Time, reason string) (_ *dbmodel.MaintenanceWindow, err error) {
+	const op = errors.Op("jimm.AddMaintenanceWindow")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !endsAt.After(startsAt) {
+		return nil, errors.E(op, errors.CodeBadRequest, "maintenance window must end after it starts")
+	}
+	if !endsAt.After(time.Now()) {
+		return nil, errors.E(op, errors.CodeBadRequest, "maintenance window has already ended")
+	}
+
+	ctl := dbmodel.Controller{Name: controllerName}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		return nil, errors.E(op, err)
+	}
+	w := dbmodel.MaintenanceWindow{
+		ControllerID: ctl.ID,
+		Controller:   ctl,
+		StartsAt:     startsAt.UTC(),
+		EndsAt:       endsAt.UTC(),
+		Reason:       reason,
+		CreatedBy:    user.Name,
+	}
+	if err := j.Database.AddMaintenanceWindow(ctx, &w); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &w, nil
+}
+
+// RemoveMaintenanceWindow removes the maintenance window with the given
+// ID. Only JIMM administrators may remove maintenance windows.
+func (j *JIMM) RemoveMaintenanceWindow(ctx context.Context, user *openfga.User, id uint) (err error) {
+	const op = errors.Op("jimm.RemoveMaintenanceWindow")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return errors.E(op, err)
+	}
+	w := dbmodel.MaintenanceWindow{ID: id}
+	if err := j.Database.GetMaintenanceWindow(ctx, &w); err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.DeleteMaintenanceWindow(ctx, &w); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListMaintenanceWindows returns the maintenance windows for the named
+// controller, or for every controller if controllerName is empty, ordered
+// by start time. Windows that have ended are only returned if all is
+// true. Only JIMM administrators may list maintenance windows.
+func (j *JIMM) ListMaintenanceWindows(ctx context.Context, user *openfga.User, controllerName string, all bool) (_ []dbmodel.MaintenanceWindow, err error) {
+	const op = errors.Op("jimm.ListMaintenanceWindows")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	var controllerID uint
+	if controllerName != "" {
+		ctl := dbmodel.Controller{Name: controllerName}
+		if err := j.Database.GetController(ctx, &ctl); err != nil {
+			return nil, errors.E(op, err)
+		}
+		controllerID = ctl.ID
+	}
+	var after time.Time
+	if !all {
+		after = time.Now()
+	}
+	windows, err := j.Database.ListMaintenanceWindows(ctx, controllerID, after)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return windows, nil
+}
+
+// nextMaintenanceWindow returns the maintenance window for the given
+// controller that is active at the given time or, if there is none, the
+// next one to start. If the controller has no such window nil is
+// returned.
+func (j *JIMM) nextMaintenanceWindow(ctx context.Context, ctl *dbmodel.Controller, t time.Time) (*dbmodel.MaintenanceWindow, error) {
+	windows, err := j.Database.ListMaintenanceWindows(ctx, ctl.ID, t)
+	if err != nil || len(windows) == 0 {
+		return nil, err
+	}
+	return &windows[0], nil
+}
+
+// checkMigrationMaintenance returns an error if any of the given
+// controllers is in a maintenance window, so that migrations to or from
+// the controller are deferred until the window has ended.
+func (j *JIMM) checkMigrationMaintenance(ctx context.Context, controllers ...*dbmodel.Controller) error {
+	now := time.Now()
+	for _, ctl := range controllers {
+		w, err := j.nextMaintenanceWindow(ctx, ctl, now)
+		if err != nil {
+			return err
+		}
+		if w != nil && w.Active(now) {
+			return errors.E(errors.CodeForbidden, fmt.Sprintf("controller %q is in a maintenance window until %s, retry the migration after it ends", ctl.Name, w.EndsAt.UTC().Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
+
+// warnMaintenanceWindow warns the user when they have created a model on
+// a controller with a current or upcoming maintenance window. Failures
+// are only logged.
+func (j *JIMM) warnMaintenanceWindow(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller, modelName string) {
+	now := time.Now()
+	w, err := j.nextMaintenanceWindow(ctx, ctl, now)
+	if err != nil {
+		zapctx.Error(ctx, "cannot check controller maintenance windows", zap.String("controller", ctl.Name), zap.Error(err))
+		return
+	}
+	if w == nil {
+		return
+	}
+	when := fmt.Sprintf("is scheduled for maintenance from %s", w.StartsAt.UTC().Format(time.RFC3339))
+	if w.Active(now) {
+		when = "is under maintenance"
+	}
+	msg := fmt.Sprintf("Model %s was created on controller %s, which %s until %s.", modelName, ctl.Name, when, w.EndsAt.UTC().Format(time.RFC3339))
+	if w.Reason != "" {
+		msg += " Reason: " + w.Reason
+	}
+	zapctx.Warn(ctx, "model created on controller with maintenance window", zap.String("controller", ctl.Name), zap.String("model", modelName), zap.Uint("window", w.ID))
+	j.sendNotification(ctx, user.Name, notify.Notification{
+		Kind:    notify.KindControllerMaintenance,
+		Subject: fmt.Sprintf("Controller %s maintenance", ctl.Name),
+		Message: msg,
+	})
+}
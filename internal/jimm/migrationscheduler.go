@@ -0,0 +1,403 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/juju/core/life"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// ScheduleMigration queues the migration of the given model to the named
+// target controller. Queued migrations are pre-checked and started by the
+// migration scheduler service. Only JIMM administrators may schedule
+// migrations.
+func (j *JIMM) ScheduleMigration(ctx context.Context, user *openfga.User, mt names.ModelTag, targetController string) (_ *dbmodel.Migration, err error) {
+	const op = errors.Op("jimm.ScheduleMigration")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var model dbmodel.Model
+	model.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeModelNotFound, "model not found")
+		}
+		return nil, errors.E(op, err)
+	}
+	target := dbmodel.Controller{Name: targetController}
+	if err := j.Database.GetController(ctx, &target); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeNotFound, "controller not found")
+		}
+		return nil, errors.E(op, err)
+	}
+	if target.ID == model.ControllerID {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("model %q is already hosted on controller %q", mt.Id(), target.Name))
+	}
+
+	pending, err := j.Database.ListMigrations(ctx, db.MigrationFilter{
+		ModelUUID: mt.Id(),
+		Statuses:  pendingMigrationStatuses,
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if len(pending) > 0 {
+		return nil, errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("model %q already has a migration in progress", mt.Id()))
+	}
+
+	m := dbmodel.Migration{
+		ModelUUID:        mt.Id(),
+		ModelName:        model.Name,
+		SourceController: model.Controller.Name,
+		TargetController: target.Name,
+		IdentityName:     user.Name,
+		Status:           dbmodel.MigrationQueued,
+	}
+	if err := j.Database.AddMigration(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &m, nil
+}
+
+// pendingMigrationStatuses holds the states of migrations that have not
+// yet finished.
+var pendingMigrationStatuses = []string{
+	dbmodel.MigrationQueued,
+	dbmodel.MigrationPrechecking,
+	dbmodel.MigrationStarted,
+}
+
+// PendingMigrationStatuses returns the states of migrations that have not
+// yet finished, for use in a MigrationFilter.
+func PendingMigrationStatuses() []string {
+	return append([]string(nil), pendingMigrationStatuses...)
+}
+
+// ListMigrations returns the scheduled migrations matching the given
+// filter. Only JIMM administrators may list migrations.
+func (j *JIMM) ListMigrations(ctx context.Context, user *openfga.User, filter db.MigrationFilter) (_ []dbmodel.Migration, err error) {
+	const op = errors.Op("jimm.ListMigrations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	migrations, err := j.Database.ListMigrations(ctx, filter)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return migrations, nil
+}
+
+// RunScheduledMigrations advances the scheduled migrations. Started
+// migrations are checked for completion, then queued migrations are
+// pre-checked and started, oldest first, such that no more than
+// concurrency migrations are in progress at once. RunScheduledMigrations
+// returns once every migration it started has been accepted or rejected
+// by its source controller.
+func (j *JIMM) RunScheduledMigrations(ctx context.Context, concurrency int) (err error) {
+	const op = errors.Op("jimm.RunScheduledMigrations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	started, err := j.Database.ListMigrations(ctx, db.MigrationFilter{
+		Statuses: []string{dbmodel.MigrationStarted},
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	inProgress := 0
+	for i := range started {
+		j.checkStartedMigration(ctx, &started[i])
+		if !started[i].Done() {
+			inProgress++
+		}
+	}
+	if inProgress >= concurrency {
+		return nil
+	}
+
+	queued, err := j.Database.ListMigrations(ctx, db.MigrationFilter{
+		Statuses: []string{dbmodel.MigrationQueued},
+		Limit:    concurrency - inProgress,
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	var wg sync.WaitGroup
+	for i := range queued {
+		wg.Add(1)
+		go func(m *dbmodel.Migration) {
+			defer wg.Done()
+			j.startScheduledMigration(ctx, m)
+		}(&queued[i])
+	}
+	wg.Wait()
+	return nil
+}
+
+// RequeueInterruptedMigrations returns any migrations left in the
+// prechecking state, by a scheduler that stopped while checking them, to
+// the queue.
+func (j *JIMM) RequeueInterruptedMigrations(ctx context.Context) (err error) {
+	const op = errors.Op("jimm.RequeueInterruptedMigrations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	migrations, err := j.Database.ListMigrations(ctx, db.MigrationFilter{
+		Statuses: []string{dbmodel.MigrationPrechecking},
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for i := range migrations {
+		migrations[i].Status = dbmodel.MigrationQueued
+		if err := j.Database.UpdateMigration(ctx, &migrations[i]); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// startScheduledMigration runs the pre-checks for the given queued
+// migration and, if they pass, asks the source controller to start the
+// migration. The outcome is recorded in the migration.
+func (j *JIMM) startScheduledMigration(ctx context.Context, m *dbmodel.Migration) {
+	m.Status = dbmodel.MigrationPrechecking
+	j.updateMigration(ctx, m)
+
+	err := j.startMigration(ctx, m)
+	if err != nil {
+		zapctx.Warn(ctx, "cannot start scheduled migration", zap.Uint("migration-id", m.ID), zap.String("model", m.ModelUUID), zap.Error(err))
+		j.failMigration(ctx, m, err.Error())
+		return
+	}
+	m.Status = dbmodel.MigrationStarted
+	j.updateMigration(ctx, m)
+}
+
+// startMigration pre-checks the given migration against its source and
+// target controllers then initiates it on the source controller.
+func (j *JIMM) startMigration(ctx context.Context, m *dbmodel.Migration) error {
+	const op = errors.Op("jimm.startMigration")
+
+	model := dbmodel.Model{UUID: sql.NullString{String: m.ModelUUID, Valid: true}}
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		return errors.E(op, err)
+	}
+	target := dbmodel.Controller{Name: m.TargetController}
+	if err := j.Database.GetController(ctx, &target); err != nil {
+		return errors.E(op, err)
+	}
+	if model.ControllerID == target.ID {
+		return errors.E(op, fmt.Sprintf("model is already hosted on controller %q", target.Name))
+	}
+
+	// Source pre-checks: the model must be alive and not already
+	// migrating.
+	info, err := j.sourceModelInfo(ctx, &model)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if info.Life != life.Alive {
+		return errors.E(op, fmt.Sprintf("model is %s", info.Life))
+	}
+	if info.Migration != nil && info.Migration.End == nil {
+		return errors.E(op, "model is already being migrated")
+	}
+
+	// Target pre-checks: the target controller must be able to accept
+	// the model.
+	mi := jujuparams.MigrationModelInfo{
+		UUID:     m.ModelUUID,
+		Name:     info.Name,
+		OwnerTag: info.OwnerTag,
+	}
+	if info.AgentVersion != nil {
+		mi.AgentVersion = *info.AgentVersion
+	}
+	if model.Controller.AgentVersion != "" {
+		mi.ControllerAgentVersion, err = version.Parse(model.Controller.AgentVersion)
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+	api, err := j.dial(ctx, &target, names.ModelTag{})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+	if err := api.MigrationTargetPrechecks(ctx, &mi); err != nil {
+		return errors.E(op, err, fmt.Sprintf("target controller pre-checks failed: %s", err))
+	}
+
+	user, err := j.getUser(ctx, m.IdentityName)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	result, err := j.InitiateInternalMigration(ctx, user, model.ResourceTag(), target.Name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if result.Error != nil {
+		return errors.E(op, result.Error)
+	}
+	m.MigrationID = result.MigrationId
+	return nil
+}
+
+// checkStartedMigration determines whether the given started migration
+// has finished. A migration has succeeded once the target controller
+// hosts the model, and has failed if the source controller reports that
+// the migration ended while it still hosts the model.
+func (j *JIMM) checkStartedMigration(ctx context.Context, m *dbmodel.Migration) {
+	target := dbmodel.Controller{Name: m.TargetController}
+	if err := j.Database.GetController(ctx, &target); err != nil {
+		zapctx.Warn(ctx, "cannot get migration target controller", zap.Uint("migration-id", m.ID), zap.Error(err))
+		return
+	}
+	model := dbmodel.Model{UUID: sql.NullString{String: m.ModelUUID, Valid: true}}
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		zapctx.Warn(ctx, "cannot get migrating model", zap.Uint("migration-id", m.ID), zap.Error(err))
+		return
+	}
+	err := j.updateMigratedModel(ctx, &model, &target)
+	if err == nil {
+		// updateMigratedModel has recorded the migration's success.
+		m.Status = dbmodel.MigrationSucceeded
+		return
+	}
+	zapctx.Debug(ctx, "model not yet hosted by migration target", zap.Uint("migration-id", m.ID), zap.Error(err))
+
+	info, err := j.sourceModelInfo(ctx, &model)
+	if err != nil {
+		zapctx.Warn(ctx, "cannot get migrating model info", zap.Uint("migration-id", m.ID), zap.Error(err))
+		return
+	}
+	if info.Migration != nil && info.Migration.End != nil {
+		j.failMigration(ctx, m, info.Migration.Status)
+	}
+}
+
+// sourceModelInfo returns the model information reported by the
+// controller hosting the given model.
+func (j *JIMM) sourceModelInfo(ctx context.Context, model *dbmodel.Model) (*jujuparams.ModelInfo, error) {
+	api, err := j.dial(ctx, &model.Controller, names.ModelTag{})
+	if err != nil {
+		return nil, err
+	}
+	defer api.Close()
+	info := jujuparams.ModelInfo{UUID: model.UUID.String}
+	if err := api.ModelInfo(ctx, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// completeScheduledMigration records the scheduled migration of the given
+// model to the given controller, if there is one, as having succeeded.
+func (j *JIMM) completeScheduledMigration(ctx context.Context, modelUUID, targetController string) {
+	migrations, err := j.Database.ListMigrations(ctx, db.MigrationFilter{
+		ModelUUID: modelUUID,
+		Statuses:  []string{dbmodel.MigrationStarted},
+	})
+	if err != nil {
+		zapctx.Warn(ctx, "cannot list scheduled migrations", zap.String("model", modelUUID), zap.Error(err))
+		return
+	}
+	for i := range migrations {
+		if migrations[i].TargetController != targetController {
+			continue
+		}
+		migrations[i].Status = dbmodel.MigrationSucceeded
+		migrations[i].CompletedAt = sql.NullTime{Time: time.Now().UTC().Round(time.Millisecond), Valid: true}
+		j.updateMigration(ctx, &migrations[i])
+	}
+}
+
+// failMigration records the given migration as having failed with the
+// given reason.
+func (j *JIMM) failMigration(ctx context.Context, m *dbmodel.Migration, reason string) {
+	m.Status = dbmodel.MigrationFailed
+	m.Error = reason
+	m.CompletedAt = sql.NullTime{Time: time.Now().UTC().Round(time.Millisecond), Valid: true}
+	j.updateMigration(ctx, m)
+}
+
+// updateMigration records the state of the given migration, logging any
+// failure.
+func (j *JIMM) updateMigration(ctx context.Context, m *dbmodel.Migration) {
+	if err := j.Database.UpdateMigration(ctx, m); err != nil {
+		zapctx.Error(ctx, "cannot update migration", zap.Uint("migration-id", m.ID), zap.Error(err))
+	}
+}
+
+// migrationSchedulerService periodically runs the scheduled migrations.
+type migrationSchedulerService struct {
+	jimm        *JIMM
+	interval    time.Duration
+	concurrency int
+}
+
+// NewMigrationSchedulerService returns a service that, every interval,
+// advances the scheduled migrations, running at most concurrency
+// migrations at once.
+func NewMigrationSchedulerService(j *JIMM, interval time.Duration, concurrency int) *migrationSchedulerService {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &migrationSchedulerService{
+		jimm:        j,
+		interval:    interval,
+		concurrency: concurrency,
+	}
+}
+
+// Start starts a routine which periodically runs the scheduled
+// migrations.
+func (s *migrationSchedulerService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *migrationSchedulerService) poll(ctx context.Context) {
+	if err := s.jimm.RequeueInterruptedMigrations(ctx); err != nil {
+		zapctx.Error(ctx, "failed to requeue interrupted migrations", zap.Error(err))
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.jimm.RunScheduledMigrations(ctx, s.concurrency); err != nil {
+			zapctx.Error(ctx, "failed to run scheduled migrations", zap.Error(err))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting migration scheduler")
+			return
+		}
+	}
+}
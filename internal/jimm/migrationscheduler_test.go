@@ -0,0 +1,216 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/juju/core/life"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const testMigrationSchedulerEnv = `clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: test-cred
+  cloud: test-cloud
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+  agent-version: 3.3.0
+  admin-user: admin
+  admin-password: password
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+  agent-version: 3.3.0
+  admin-user: admin
+  admin-password: password
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-cred
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
+
+func newMigrationSchedulerJIMM(c *qt.C, api *jimmtest.API) (*jimm.JIMM, *openfga.User) {
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testMigrationSchedulerEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+	user.JimmAdmin = true
+	return j, user
+}
+
+func TestScheduleMigration(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j, user := newMigrationSchedulerJIMM(c, &jimmtest.API{})
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+
+	_, err := j.ScheduleMigration(ctx, user, mt, "controller-1")
+	c.Check(err, qt.ErrorMatches, `model "00000002-0000-0000-0000-000000000001" is already hosted on controller "controller-1"`)
+
+	_, err = j.ScheduleMigration(ctx, user, mt, "no-such-controller")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	m, err := j.ScheduleMigration(ctx, user, mt, "controller-2")
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Status, qt.Equals, dbmodel.MigrationQueued)
+	c.Check(m.ModelName, qt.Equals, "model-1")
+	c.Check(m.SourceController, qt.Equals, "controller-1")
+	c.Check(m.TargetController, qt.Equals, "controller-2")
+
+	_, err = j.ScheduleMigration(ctx, user, mt, "controller-2")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	nonAdmin := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, j.OpenFGAClient)
+	_, err = j.ScheduleMigration(ctx, nonAdmin, mt, "controller-2")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	_, err = j.ListMigrations(ctx, nonAdmin, db.MigrationFilter{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	migrations, err := j.ListMigrations(ctx, user, db.MigrationFilter{Statuses: jimm.PendingMigrationStatuses()})
+	c.Assert(err, qt.IsNil)
+	c.Check(migrations, qt.HasLen, 1)
+}
+
+func TestRunScheduledMigrations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var prechecked *jujuparams.MigrationModelInfo
+	api := &jimmtest.API{
+		ModelInfo_: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+			// The same API serves the source and target controllers,
+			// so once started the migration is seen to have completed.
+			mi.Name = "model-1"
+			mi.OwnerTag = names.NewUserTag("alice@canonical.com").String()
+			mi.Life = life.Alive
+			return nil
+		},
+		MigrationTargetPrechecks_: func(_ context.Context, mi *jujuparams.MigrationModelInfo) error {
+			prechecked = mi
+			return nil
+		},
+	}
+	j, user := newMigrationSchedulerJIMM(c, api)
+	c.Patch(jimm.InitiateMigration, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
+		return jujuparams.InitiateMigrationResult{ModelTag: spec.ModelTag, MigrationId: "migration-1"}, nil
+	})
+
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+	m, err := j.ScheduleMigration(ctx, user, mt, "controller-2")
+	c.Assert(err, qt.IsNil)
+
+	err = j.RunScheduledMigrations(ctx, 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(prechecked, qt.Not(qt.IsNil))
+	c.Check(prechecked.UUID, qt.Equals, mt.Id())
+	c.Check(prechecked.ControllerAgentVersion.String(), qt.Equals, "3.3.0")
+
+	migrations, err := j.ListMigrations(ctx, user, db.MigrationFilter{ModelUUID: mt.Id()})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].ID, qt.Equals, m.ID)
+	c.Check(migrations[0].Status, qt.Equals, dbmodel.MigrationStarted)
+	c.Check(migrations[0].MigrationID, qt.Equals, "migration-1")
+
+	err = j.RunScheduledMigrations(ctx, 1)
+	c.Assert(err, qt.IsNil)
+
+	migrations, err = j.ListMigrations(ctx, user, db.MigrationFilter{ModelUUID: mt.Id()})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].Status, qt.Equals, dbmodel.MigrationSucceeded)
+	c.Check(migrations[0].CompletedAt.Valid, qt.IsTrue)
+
+	model := dbmodel.Model{}
+	model.SetTag(mt)
+	err = j.Database.GetModel(ctx, &model)
+	c.Assert(err, qt.IsNil)
+	c.Check(model.Controller.Name, qt.Equals, "controller-2")
+}
+
+func TestRunScheduledMigrationsPrecheckFailure(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	api := &jimmtest.API{
+		ModelInfo_: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+			mi.Life = life.Alive
+			return nil
+		},
+		MigrationTargetPrechecks_: func(context.Context, *jujuparams.MigrationModelInfo) error {
+			return errors.E("model with same UUID already exists")
+		},
+	}
+	j, user := newMigrationSchedulerJIMM(c, api)
+	var initiated bool
+	c.Patch(jimm.InitiateMigration, func(context.Context, *jimm.JIMM, *openfga.User, jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
+		initiated = true
+		return jujuparams.InitiateMigrationResult{}, nil
+	})
+
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+	_, err := j.ScheduleMigration(ctx, user, mt, "controller-2")
+	c.Assert(err, qt.IsNil)
+
+	err = j.RunScheduledMigrations(ctx, 1)
+	c.Assert(err, qt.IsNil)
+
+	migrations, err := j.ListMigrations(ctx, user, db.MigrationFilter{ModelUUID: mt.Id()})
+	c.Assert(err, qt.IsNil)
+	c.Assert(migrations, qt.HasLen, 1)
+	c.Check(migrations[0].Status, qt.Equals, dbmodel.MigrationFailed)
+	c.Check(migrations[0].Error, qt.Equals, `target controller pre-checks failed: model with same UUID already exists`)
+	c.Check(initiated, qt.IsFalse)
+}
@@ -5,6 +5,7 @@ package jimm
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -19,10 +20,14 @@ import (
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notifications"
+	"github.com/canonical/jimm/v3/internal/notify"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // shuffle is used to randomize the order in which possible controllers
@@ -110,6 +115,8 @@ type modelBuilder struct {
 	cloudRegionID uint
 	model         *dbmodel.Model
 	modelInfo     *jujuparams.ModelInfo
+	limitScopes   []limitScope
+	usageAlerts   []notify.Notification
 }
 
 // Error returns the error that occurred in the process
@@ -236,12 +243,12 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 		b.err = errors.E("cloud not specified")
 		return b
 	}
-	// if the region is not specified, we pick the first cloud region
-	// with any associated controllers
+	// if the region is not specified, we pick the first enabled cloud
+	// region with any associated controllers
 	if region == "" {
 		for _, r := range b.cloud.Regions {
 			regionControllers := r.Controllers
-			if len(regionControllers) == 0 {
+			if len(regionControllers) == 0 || r.Disabled {
 				continue
 			}
 			region = r.Name
@@ -253,19 +260,34 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 		if r.Name != region {
 			continue
 		}
+		if r.Disabled {
+			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("cloud region %s/%s is disabled", b.cloud.Name, region))
+			return b
+		}
 		// consider all possible controllers for that region
 		regionControllers := r.Controllers
 		if len(regionControllers) == 0 {
 			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported cloud region %s/%s", b.cloud.Name, region))
 			return b
 		}
-		// shuffle controllers
-		shuffleRegionControllers(regionControllers)
-
-		// and select the first controller in the slice
+		regionControllers, err := undrainedControllers(regionControllers)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		regionControllers, err = b.jimm.controllersWithinLimits(b.ctx, regionControllers)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		selected, err := b.jimm.selectController(b.ctx, b.selectionRequest(), regionControllers)
+		if err != nil {
+			b.err = err
+			return b
+		}
 		b.cloudRegion = region
-		b.cloudRegionID = regionControllers[0].CloudRegionID
-		b.controller = &regionControllers[0].Controller
+		b.cloudRegionID = selected.CloudRegionID
+		b.controller = &selected.Controller
 
 		break
 	}
@@ -295,6 +317,17 @@ func (b *modelBuilder) WithCloudCredential(credentialTag names.CloudCredentialTa
 	return b
 }
 
+// WithLimits returns a builder that checks the limits within the given
+// scopes, as well as those of the selected controller, when the model is
+// stored.
+func (b *modelBuilder) WithLimits(scopes ...limitScope) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.limitScopes = append(b.limitScopes, scopes...)
+	return b
+}
+
 // CreateDatabaseModel stores temporary model information.
 func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 	if b.err != nil {
@@ -347,12 +380,30 @@ func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 		CloudRegionID:     b.cloudRegionID,
 	}
 
-	err := b.jimm.Database.AddModel(b.ctx, b.model)
+	// The limits are checked in the same transaction as the model is
+	// stored, with the usage locked, so that concurrent requests cannot
+	// both take the last of a limit.
+	scopes := append(b.limitScopes, limitScope{kind: names.ControllerTagKind, id: b.controller.Name})
+	err := b.jimm.Database.Transaction(func(tx *db.Database) error {
+		if err := lockLimitScopes(b.ctx, tx, scopes...); err != nil {
+			return err
+		}
+		alerts, err := b.jimm.checkLimits(b.ctx, tx, db.ModelUsage{Models: 1}, scopes...)
+		if err != nil {
+			return err
+		}
+		b.usageAlerts = alerts
+		return tx.AddModel(b.ctx, b.model)
+	})
 	if err != nil {
-		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+		switch errors.ErrorCode(err) {
+		case errors.CodeQuotaLimitExceeded:
+			b.err = err
+			return b
+		case errors.CodeAlreadyExists:
 			b.err = errors.E(err, fmt.Sprintf("model %s/%s already exists", b.owner.Name, b.name))
 			return b
-		} else {
+		default:
 			zapctx.Error(b.ctx, "failed to store model information", zaputil.Error(err))
 			b.err = errors.E(err, "failed to store model information")
 			return b
@@ -414,6 +465,9 @@ func (b *modelBuilder) selectCloudRegion() error {
 
 	var regionControllers []dbmodel.CloudRegionControllerPriority
 	for _, r := range b.cloud.Regions {
+		if r.Disabled {
+			continue
+		}
 		regionControllers = append(regionControllers, r.Controllers...)
 	}
 
@@ -422,15 +476,37 @@ func (b *modelBuilder) selectCloudRegion() error {
 		return errors.E(fmt.Sprintf("unsupported cloud %s", b.cloud.Name))
 	}
 
-	// shuffle controllers according to their priority
-	shuffleRegionControllers(regionControllers)
-
-	b.cloudRegionID = regionControllers[0].CloudRegionID
-	b.controller = &regionControllers[0].Controller
+	regionControllers, err := undrainedControllers(regionControllers)
+	if err != nil {
+		return err
+	}
+	regionControllers, err = b.jimm.controllersWithinLimits(b.ctx, regionControllers)
+	if err != nil {
+		return err
+	}
+	selected, err := b.jimm.selectController(b.ctx, b.selectionRequest(), regionControllers)
+	if err != nil {
+		return err
+	}
+	b.cloudRegionID = selected.CloudRegionID
+	b.controller = &selected.Controller
 
 	return nil
 }
 
+// selectionRequest returns the ControllerSelectionRequest describing the
+// model being built.
+func (b *modelBuilder) selectionRequest() ControllerSelectionRequest {
+	var req ControllerSelectionRequest
+	if b.owner != nil {
+		req.Owner = b.owner.ResourceTag()
+	}
+	if b.cloud != nil {
+		req.Cloud = b.cloud.ResourceTag()
+	}
+	return req
+}
+
 func (b *modelBuilder) selectCloudCredentials() error {
 	if b.owner == nil {
 		return errors.E("user not specified")
@@ -553,6 +629,8 @@ func (b *modelBuilder) JujuModelInfo() *jujuparams.ModelInfo {
 // AddModel adds the specified model to JIMM.
 func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (_ *jujuparams.ModelInfo, err error) {
 	const op = errors.Op("jimm.AddModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	owner, err := dbmodel.NewIdentity(args.Owner.Id())
 	if err != nil {
@@ -569,6 +647,19 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	templateConfigs, config, err := j.modelConfigTemplates(ctx, user, args.Config)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	policies, err := j.Database.ListModelConfigPolicies(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := checkModelConfigPolicies(policies, config, nil); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	builder := newModelBuilder(ctx, j)
 	builder = builder.WithOwner(owner)
 	builder = builder.WithName(args.Name)
@@ -603,11 +694,6 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, err)
 	}
 
-	builder = builder.WithCloudRegion(args.CloudRegion)
-	if err := builder.Error(); err != nil {
-		return nil, errors.E(op, err)
-	}
-
 	// at this point we know which cloud will host the model and
 	// we must check the user has add-model permission on the cloud
 	canAddModel, err := openfga.NewUser(owner, j.OpenFGAClient).IsAllowedAddModel(ctx, builder.cloud.ResourceTag())
@@ -618,6 +704,18 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	// The limits are checked when the model is stored.
+	ownerScope, err := j.userLimitScope(ctx, owner.Name)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	builder = builder.WithLimits(ownerScope, limitScope{kind: names.CloudTagKind, id: builder.cloud.Name})
+
+	builder = builder.WithCloudRegion(args.CloudRegion)
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	// fetch cloud region defaults
 	if args.Cloud != (names.CloudTag{}) && builder.cloudRegion != "" {
 		cloudRegionDefaults := dbmodel.CloudDefaults{
@@ -634,9 +732,21 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		builder = builder.WithConfig(cloudRegionDefaults.Defaults)
 	}
 
+	// apply any requested templates, in order, over the defaults
+	for _, cfg := range templateConfigs {
+		builder = builder.WithConfig(cfg)
+	}
+
 	// last but not least, use the provided config values
 	// overriding all defaults
-	builder = builder.WithConfig(args.Config)
+	builder = builder.WithConfig(config)
+
+	// forced values override the defaults and templates, the values
+	// requested have already been checked against the policies.
+	builder = builder.WithConfig(forcedModelConfig(policies))
+	if err := checkModelConfigPolicies(policies, builder.config, nil); err != nil {
+		return nil, errors.E(op, err)
+	}
 
 	if args.CloudCredential != (names.CloudCredentialTag{}) {
 		builder = builder.WithCloudCredential(args.CloudCredential)
@@ -669,6 +779,15 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 	if err := j.addModelPermissions(ctx, ownerUser, modelTag, controllerTag); err != nil {
 		return nil, errors.E(op, err)
 	}
+	j.sendUsageAlerts(ctx, builder.usageAlerts)
+	j.warnMaintenanceWindow(ctx, user, builder.controller, args.Name)
+	j.Lifecycle.Dispatch(ctx, notifications.Event{
+		Event:      notifications.EventModelCreated,
+		ModelUUID:  mi.UUID,
+		ModelName:  mi.Name,
+		Controller: builder.controller.Name,
+		Identity:   user.Name,
+	})
 	return mi, nil
 }
 
@@ -717,8 +836,10 @@ func (j *JIMM) addModelPermissions(ctx context.Context, owner *openfga.User, mt
 // error will have the code CodeNotFound. If the given user does not have
 // access to the model then the returned error will have the code
 // CodeUnauthorized.
-func (j *JIMM) ModelInfo(ctx context.Context, user *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error) {
+func (j *JIMM) ModelInfo(ctx context.Context, user *openfga.User, mt names.ModelTag) (_ *jujuparams.ModelInfo, err error) {
 	const op = errors.Op("jimm.ModelInfo")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var m dbmodel.Model
 	m.SetTag(mt)
@@ -814,11 +935,13 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 // the model doesn't exist then the returned error will have the code
 // CodeNotFound, If the given user does not have admin access to the model
 // then the returned error will have the code CodeUnauthorized.
-func (j *JIMM) ModelStatus(ctx context.Context, user *openfga.User, mt names.ModelTag) (*jujuparams.ModelStatus, error) {
+func (j *JIMM) ModelStatus(ctx context.Context, user *openfga.User, mt names.ModelTag) (_ *jujuparams.ModelStatus, err error) {
 	const op = errors.Op("jimm.ModelStatus")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var ms jujuparams.ModelStatus
-	err := j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
 		ms.ModelTag = mt.String()
 		return api.ModelStatus(ctx, &ms)
 	})
@@ -838,34 +961,37 @@ func (j *JIMM) ModelStatus(ctx context.Context, user *openfga.User, mt names.Mod
 // returned unmodified and iteration will stop immediately. The given
 // function should not update the database.
 func (j *JIMM) ForEachUserModel(ctx context.Context, user *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
-	const op = errors.Op("jimm.ForEachUserModel")
+	return j.ForEachUserModelMatching(ctx, user, LabelSelector{}, f)
+}
 
-	errStop := errors.E("stop")
-	var iterErr error
-	err := j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
-		model := *m
+// ForEachUserModelMatching is like ForEachUserModel but only calls the
+// given function for models with labels selected by the given selector.
+// Models are filtered by label before the user's access is checked.
+func (j *JIMM) ForEachUserModelMatching(ctx context.Context, user *openfga.User, sel LabelSelector, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) (err error) {
+	const op = errors.Op("jimm.ForEachUserModelMatching")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
+	models, err := j.listModels(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for i := range models {
+		model := &models[i]
+		if !sel.Matches(model.Labels) {
+			continue
+		}
 		access, err := j.GetUserModelAccess(ctx, user, model.ResourceTag())
 		if err != nil {
 			return errors.E(op, err)
 		}
 		if access == "read" || access == "write" || access == "admin" {
-			if err := f(&model, jujuparams.UserAccessPermission(access)); err != nil {
-				iterErr = err
-				return errStop
+			if err := f(model, jujuparams.UserAccessPermission(access)); err != nil {
+				return err
 			}
-			return nil
 		}
-		return nil
-	})
-	switch err {
-	case nil:
-		return nil
-	case errStop:
-		return iterErr
-	default:
-		return errors.E(op, err)
 	}
+	return nil
 }
 
 // ForEachModel calls the given function once for each model in the system.
@@ -875,30 +1001,40 @@ func (j *JIMM) ForEachUserModel(ctx context.Context, user *openfga.User, f func(
 // the user is not a controller admin. If the given function returns an
 // error the error will be returned unmodified and iteration will stop
 // immediately. The given function should not update the database.
-func (j *JIMM) ForEachModel(ctx context.Context, user *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+func (j *JIMM) ForEachModel(ctx context.Context, user *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) (err error) {
 	const op = errors.Op("jimm.ForEachModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
-	errStop := errors.E("stop")
-	var iterErr error
-	err := j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
-		if err := f(m, jujuparams.UserAccessPermission("admin")); err != nil {
-			iterErr = err
-			return errStop
-		}
-		return nil
-	})
-	switch err {
-	case nil:
-		return nil
-	case errStop:
-		return iterErr
-	default:
+	models, err := j.listModels(ctx)
+	if err != nil {
 		return errors.E(op, err)
 	}
+	for i := range models {
+		if err := f(&models[i], jujuparams.UserAccessPermission("admin")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listModels returns every model. Models are read from the database's
+// read replica, if one is available, so may lag slightly behind recent
+// changes.
+func (j *JIMM) listModels(ctx context.Context) ([]dbmodel.Model, error) {
+	var models []dbmodel.Model
+	err := j.Database.ReadFromReplica(ctx, func(d *db.Database) error {
+		models = models[:0]
+		return d.ForEachModel(ctx, func(m *dbmodel.Model) error {
+			models = append(models, *m)
+			return nil
+		})
+	})
+	return models, err
 }
 
 // GrantModelAccess grants the given access level on the given model to
@@ -906,8 +1042,10 @@ func (j *JIMM) ForEachModel(ctx context.Context, user *openfga.User, f func(*dbm
 // CodeNotFound is returned. If the authenticated user does not have
 // admin access to the model then an error with the code CodeUnauthorized
 // is returned.
-func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
+func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) (err error) {
 	const op = errors.Op("jimm.GrantModelAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	targetRelation, err := ToModelRelation(string(access))
 	if err != nil {
@@ -970,6 +1108,163 @@ func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt name
 		)
 		return errors.E(op, err)
 	}
+	j.notifyAccessChanged(AccessChange{Identity: ut.Id(), Model: mt.Id()})
+	return nil
+}
+
+// TransferModelOwnership makes the given user the owner of the model.
+// The new owner is given administrator access to the model on its
+// controller and in OpenFGA, and the previous owner's direct
+// administrator access is removed. Juju cannot change the owner of an
+// existing model, so only JIMM's record of the owner changes; the
+// controller continues to report the original owner. The transfer
+// fails with CodeAlreadyExists if the new owner already owns a model
+// with the same name, and fails if the model would take the new owner
+// over any of their limits. If the transfer fails the database and
+// OpenFGA are left unchanged, and any administrator access granted on
+// the controller is revoked again. Juju reduces a revoked administrator
+// to write access, so a new owner that previously had no access, or
+// only read access, keeps write access on the controller. The change is
+// recorded in the audit log. The authenticated user must be an
+// administrator of the model.
+func (j *JIMM) TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag) (err error) {
+	const op = errors.Op("jimm.TransferModelOwnership")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	var previousOwner string
+	var alerts []notify.Notification
+	err = j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+		owner := &dbmodel.Identity{}
+		owner.SetTag(newOwner)
+		if err := j.Database.GetIdentity(ctx, owner); err != nil {
+			return err
+		}
+		if m.OwnerIdentityName == owner.Name {
+			return errors.E(errors.CodeBadRequest, "user already owns the model")
+		}
+		if err := j.checkModelNameAvailable(ctx, owner.Name, m.Name); err != nil {
+			return err
+		}
+		ownerScope, err := j.userLimitScope(ctx, owner.Name)
+		if err != nil {
+			return err
+		}
+		alerts, err = j.checkLimits(ctx, &j.Database, db.ModelUsage{
+			Models:   1,
+			Machines: m.Machines,
+			Cores:    m.Cores,
+			Units:    m.Units,
+		}, ownerScope)
+		if err != nil {
+			return err
+		}
+		granted := true
+		err = api.GrantModelAccess(ctx, mt, newOwner, jujuparams.ModelAdminAccess)
+		if err != nil {
+			if !strings.Contains(err.Error(), "already has") {
+				return err
+			}
+			granted = false
+		}
+		previousOwner = m.OwnerIdentityName
+		if err := j.transferModelOwnership(ctx, m, owner); err != nil {
+			if granted {
+				if err := api.RevokeModelAccess(ctx, mt, newOwner, jujuparams.ModelAdminAccess); err != nil {
+					zapctx.Error(ctx, "failed to revoke model access after failed transfer", zap.Error(err), zap.String("model", mt.Id()))
+				}
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	params, _ := json.Marshal(map[string]string{
+		"model":          mt.String(),
+		"previous-owner": names.NewUserTag(previousOwner).String(),
+		"owner":          newOwner.String(),
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        mt.Id(),
+		FacadeName:   "JIMM",
+		FacadeMethod: "TransferModelOwnership",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	j.sendUsageAlerts(ctx, alerts)
+	return nil
+}
+
+// checkModelNameAvailable returns an error with the code
+// CodeAlreadyExists if the given identity already owns a model with the
+// given name.
+func (j *JIMM) checkModelNameAvailable(ctx context.Context, owner, name string) error {
+	m := dbmodel.Model{
+		OwnerIdentityName: owner,
+		Name:              name,
+	}
+	err := j.Database.GetModel(ctx, &m)
+	switch {
+	case err == nil:
+		return errors.E(errors.CodeAlreadyExists, fmt.Sprintf("%s already owns a model named %q", owner, name))
+	case errors.ErrorCode(err) == errors.CodeNotFound:
+		return nil
+	default:
+		return err
+	}
+}
+
+// transferModelOwnership records the new owner of the model in the
+// database and moves the owner's administrator relation in OpenFGA. The
+// model's controller is not updated. The caller must check that the new
+// owner has no model with the same name. If any step fails the
+// completed steps are undone.
+func (j *JIMM) transferModelOwnership(ctx context.Context, m *dbmodel.Model, newOwner *dbmodel.Identity) error {
+	previousOwner := m.Owner
+	mt := m.ResourceTag()
+	ownerTuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(newOwner.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(mt),
+	}
+	// The OpenFGA changes are made inside the transaction so that the
+	// database update is rolled back if they fail.
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		m.OwnerIdentityName = newOwner.Name
+		m.Owner = *newOwner
+		if err := tx.UpdateModel(ctx, m); err != nil {
+			return err
+		}
+		addedOwner := true
+		if err := j.OpenFGAClient.AddRelation(ctx, ownerTuple); err != nil {
+			if !strings.Contains(err.Error(), "cannot write a tuple which already exists") {
+				return errors.E(errors.CodeOpenFGARequestFailed, err)
+			}
+			addedOwner = false
+		}
+		if previousOwner.Name == "" {
+			return nil
+		}
+		if err := openfga.NewUser(&previousOwner, j.OpenFGAClient).UnsetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+			if addedOwner {
+				if err := j.OpenFGAClient.RemoveRelation(ctx, ownerTuple); err != nil {
+					zapctx.Error(ctx, "failed to remove new owner's model access", zap.Error(err), zap.String("model", mt.Id()))
+				}
+			}
+			return errors.E(errors.CodeOpenFGARequestFailed, err)
+		}
+		return nil
+	})
+	if err != nil {
+		m.OwnerIdentityName = previousOwner.Name
+		m.Owner = previousOwner
+		return err
+	}
+	j.notifyAccessChanged(AccessChange{Model: mt.Id()})
 	return nil
 }
 
@@ -978,8 +1273,10 @@ func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt name
 // CodeNotFound is returned. If the authenticated user does not have admin
 // access to the model, and is not attempting to revoke their own access,
 // then an error with the code CodeUnauthorized is returned.
-func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
+func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) (err error) {
 	const op = errors.Op("jimm.RevokeModelAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	targetRelation, err := ToModelRelation(string(access))
 	if err != nil {
@@ -1059,6 +1356,7 @@ func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt nam
 		)
 		return errors.E(op, err)
 	}
+	j.notifyAccessChanged(AccessChange{Identity: ut.Id(), Model: mt.Id()})
 	return nil
 }
 
@@ -1066,10 +1364,17 @@ func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt nam
 // given user is not a controller superuser or a model admin an error
 // with a code of CodeUnauthorized is returned. Any error returned from
 // the juju API will not have it's code masked.
-func (j *JIMM) DestroyModel(ctx context.Context, user *openfga.User, mt names.ModelTag, destroyStorage, force *bool, maxWait, timeout *time.Duration) error {
+func (j *JIMM) DestroyModel(ctx context.Context, user *openfga.User, mt names.ModelTag, destroyStorage, force *bool, maxWait, timeout *time.Duration) (err error) {
 	const op = errors.Op("jimm.DestroyModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
-	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+		if j.ArchiveDestroyedModels {
+			if err := j.archiveModel(ctx, user, m); err != nil {
+				return err
+			}
+		}
 		if err := api.DestroyModel(ctx, mt, destroyStorage, force, maxWait, timeout); err != nil {
 			return err
 		}
@@ -1093,15 +1398,85 @@ func (j *JIMM) DestroyModel(ctx context.Context, user *openfga.User, mt names.Mo
 	return nil
 }
 
+// archiveModel stores a snapshot of the given model's configuration and
+// full status, which includes its applications and machines, as a model
+// archive.
+func (j *JIMM) archiveModel(ctx context.Context, user *openfga.User, m *dbmodel.Model) error {
+	const op = errors.Op("jimm.archiveModel")
+
+	api, err := j.dial(ctx, &m.Controller, m.ResourceTag())
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	config, err := api.ModelGet(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	fullStatus, err := api.Status(ctx, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	// Store the status as it would be returned to a client, rather than
+	// as a go structure.
+	buf, err := json.Marshal(fullStatus)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	var status dbmodel.Map
+	if err := json.Unmarshal(buf, &status); err != nil {
+		return errors.E(op, err)
+	}
+
+	archive := dbmodel.ModelArchive{
+		ModelUUID:         m.UUID.String,
+		ModelName:         m.Name,
+		OwnerIdentityName: m.OwnerIdentityName,
+		ControllerName:    m.Controller.Name,
+		ArchivedBy:        user.Name,
+		Config:            config,
+		Status:            status,
+	}
+	if err := j.Database.AddModelArchive(ctx, &archive); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GetModelArchive returns the most recent archive of the model with the
+// given UUID. Only JIMM administrators may retrieve model archives. If
+// the model has not been archived an error with a code of CodeNotFound
+// is returned.
+func (j *JIMM) GetModelArchive(ctx context.Context, user *openfga.User, modelUUID string) (_ *dbmodel.ModelArchive, err error) {
+	const op = errors.Op("jimm.GetModelArchive")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	archive := dbmodel.ModelArchive{
+		ModelUUID: modelUUID,
+	}
+	if err := j.Database.GetModelArchive(ctx, &archive); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &archive, nil
+}
+
 // DumpModel retrieves a database-agnostic dump of the given model from its
 // juju controller. If simplified is true a simpllified dump is requested.
 // If the given user is not a controller superuser or a model admin an
 // error with the code CodeUnauthorized is returned.
-func (j *JIMM) DumpModel(ctx context.Context, user *openfga.User, mt names.ModelTag, simplified bool) (string, error) {
+func (j *JIMM) DumpModel(ctx context.Context, user *openfga.User, mt names.ModelTag, simplified bool) (_ string, err error) {
 	const op = errors.Op("jimm.DumpModel")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var dump string
-	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
 		var err error
 		dump, err = api.DumpModel(ctx, mt, simplified)
 		return err
@@ -1115,11 +1490,13 @@ func (j *JIMM) DumpModel(ctx context.Context, user *openfga.User, mt names.Model
 // DumpModelDB retrieves a database dump of the given model from its juju
 // controller. If the given user is not a controller superuser or a model
 // admin an error with the code CodeUnauthorized is returned.
-func (j *JIMM) DumpModelDB(ctx context.Context, user *openfga.User, mt names.ModelTag) (map[string]interface{}, error) {
+func (j *JIMM) DumpModelDB(ctx context.Context, user *openfga.User, mt names.ModelTag) (_ map[string]interface{}, err error) {
 	const op = errors.Op("jimm.DumpModelDB")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	var dump map[string]interface{}
-	err := j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(m *dbmodel.Model, api API) error {
 		var err error
 		dump, err = api.DumpModelDB(ctx, mt)
 		return err
@@ -1136,10 +1513,12 @@ func (j *JIMM) DumpModelDB(ctx context.Context, user *openfga.User, mt names.Mod
 // error returned from the API will have the code maintained therefore if
 // the controller doesn't support the ValidateModelUpgrades command the
 // CodeNotImplemented error code will be propagated back to the client.
-func (j *JIMM) ValidateModelUpgrade(ctx context.Context, user *openfga.User, mt names.ModelTag, force bool) error {
+func (j *JIMM) ValidateModelUpgrade(ctx context.Context, user *openfga.User, mt names.ModelTag, force bool) (err error) {
 	const op = errors.Op("jimm.ValidateModelUpgrade")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
-	err := j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
+	err = j.doModelAdmin(ctx, user, mt, func(_ *dbmodel.Model, api API) error {
 		return api.ValidateModelUpgrade(ctx, mt, force)
 	})
 	if err != nil {
@@ -1218,8 +1597,10 @@ var allowedModelAccess = map[string]map[string]bool{
 
 // ChangeModelCredential changes the credential used with a model on both
 // the controller and the local database.
-func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error {
+func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) (err error) {
 	const op = errors.Op("jimm.ChangeModelCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	if !user.JimmAdmin && user.Tag() != cloudCredentialTag.Owner() {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -1228,7 +1609,7 @@ func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, mo
 	credential := dbmodel.CloudCredential{}
 	credential.SetTag(cloudCredentialTag)
 
-	err := j.Database.GetCloudCredential(ctx, &credential)
+	err = j.Database.GetCloudCredential(ctx, &credential)
 	if err != nil {
 		return errors.E(op, err)
 	}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	"github.com/canonical/jimm/v3/pkg/api/params"
 )
 
@@ -25,8 +26,11 @@ import (
 // If a result is erroneous, for example, bad data type parsing, the resulting struct field
 // Errors will contain a map from model UUID -> []error. Otherwise, the Results field
 // will contain model UUID -> []Jq result.
-func (j *JIMM) QueryModelsJq(ctx context.Context, modelUUIDs []string, jqQuery string) (params.CrossModelQueryResponse, error) {
+func (j *JIMM) QueryModelsJq(ctx context.Context, modelUUIDs []string, jqQuery string) (_ params.CrossModelQueryResponse, err error) {
 	op := errors.Op("QueryModels")
+	ctx, span := tracing.Start(ctx, "jimm.QueryModelsJq")
+	defer tracing.End(span, &err)
+
 	results := params.CrossModelQueryResponse{
 		Results: make(map[string][]any),
 		Errors:  make(map[string][]string),
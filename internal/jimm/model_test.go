@@ -2960,6 +2960,258 @@ func TestRevokeModelAccess(t *testing.T) {
 	}
 }
 
+const transferModelOwnershipNameInUseEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: bob@canonical.com
+  life: alive
+  users:
+  - user: bob@canonical.com
+    access: admin
+`
+
+var transferModelOwnershipTests = []struct {
+	name             string
+	env              string
+	grantModelAccess func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
+	username         string
+	uuid             string
+	newOwner         string
+	expectError      string
+	expectErrorCode  errors.Code
+}{{
+	name:            "NotFound",
+	username:        "alice@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000002",
+	newOwner:        "bob@canonical.com",
+	expectError:     `model not found`,
+	expectErrorCode: errors.CodeNotFound,
+}, {
+	name:            "Unauthorized",
+	username:        "bob@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000001",
+	newOwner:        "bob@canonical.com",
+	expectError:     `unauthorized`,
+	expectErrorCode: errors.CodeUnauthorized,
+}, {
+	name:            "AlreadyOwner",
+	username:        "alice@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000001",
+	newOwner:        "alice@canonical.com",
+	expectError:     `user already owns the model`,
+	expectErrorCode: errors.CodeBadRequest,
+}, {
+	name: "NameInUse",
+	env:  transferModelOwnershipNameInUseEnv,
+	grantModelAccess: func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error {
+		return errors.E("unexpected grant")
+	},
+	username:        "alice@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000001",
+	newOwner:        "bob@canonical.com",
+	expectError:     `bob@canonical.com already owns a model named "model-1"`,
+	expectErrorCode: errors.CodeAlreadyExists,
+}, {
+	name: "APIError",
+	grantModelAccess: func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error {
+		return errors.E("api error")
+	},
+	username:    "alice@canonical.com",
+	uuid:        "00000002-0000-0000-0000-000000000001",
+	newOwner:    "bob@canonical.com",
+	expectError: `api error`,
+}, {
+	name: "Success",
+	grantModelAccess: func(_ context.Context, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
+		if mt.Id() != "00000002-0000-0000-0000-000000000001" {
+			return errors.E("incorrect model uuid")
+		}
+		if ut.Id() != "bob@canonical.com" {
+			return errors.E("incorrect user")
+		}
+		if access != jujuparams.ModelAdminAccess {
+			return errors.E("incorrect access")
+		}
+		return nil
+	},
+	username: "alice@canonical.com",
+	uuid:     "00000002-0000-0000-0000-000000000001",
+	newOwner: "bob@canonical.com",
+}, {
+	name: "SuperuserAlreadyHasAccess",
+	grantModelAccess: func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error {
+		return errors.E(`user already has "admin" access or greater`)
+	},
+	username: "charlie@canonical.com",
+	uuid:     "00000002-0000-0000-0000-000000000001",
+	newOwner: "bob@canonical.com",
+}}
+
+func TestTransferModelOwnership(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range transferModelOwnershipTests {
+		c.Run(test.name, func(c *qt.C) {
+			ctx := context.Background()
+
+			dialer := &jimmtest.Dialer{
+				API: &jimmtest.API{
+					GrantModelAccess_: test.grantModelAccess,
+				},
+			}
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.name)
+			c.Assert(err, qt.IsNil)
+
+			j := &jimm.JIMM{
+				UUID:          uuid.NewString(),
+				OpenFGAClient: client,
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: dialer,
+			}
+			err = j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			envDef := test.env
+			if envDef == "" {
+				envDef = destroyModelTestEnv
+			}
+			env := jimmtest.ParseEnvironment(c, envDef)
+			env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+			dbUser := env.User(test.username).DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, client)
+
+			mt := names.NewModelTag(test.uuid)
+			err = j.TransferModelOwnership(ctx, user, mt, names.NewUserTag(test.newOwner))
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				if test.expectErrorCode != "" {
+					c.Check(errors.ErrorCode(err), qt.Equals, test.expectErrorCode)
+				}
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(dialer.IsClosed(), qt.IsTrue)
+
+			m := dbmodel.Model{
+				UUID: sql.NullString{
+					String: test.uuid,
+					Valid:  true,
+				},
+			}
+			err = j.Database.GetModel(ctx, &m)
+			c.Assert(err, qt.IsNil)
+			c.Check(m.OwnerIdentityName, qt.Equals, test.newOwner)
+
+			newOwner := env.User(test.newOwner).DBObject(c, j.Database)
+			access := openfga.NewUser(&newOwner, client).GetModelAccess(ctx, mt)
+			c.Check(access, qt.Equals, ofganames.AdministratorRelation)
+			previousOwner := env.User("alice@canonical.com").DBObject(c, j.Database)
+			access = openfga.NewUser(&previousOwner, client).GetModelAccess(ctx, mt)
+			c.Check(access, qt.Equals, ofganames.NoRelation)
+		})
+	}
+}
+
+func TestTransferModelOwnershipRollback(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var revoked []string
+	api := &jimmtest.API{
+		RevokeModelAccess_: func(_ context.Context, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
+			revoked = append(revoked, fmt.Sprintf("%s %s %s", mt.Id(), ut.Id(), access))
+			return nil
+		},
+	}
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{API: api},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, destroyModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	// Simulate bob creating a model with the same name after the name
+	// check, so that the database update fails after the controller
+	// grant.
+	api.GrantModelAccess_ = func(ctx context.Context, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
+		m := dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}}
+		if err := j.Database.GetModel(ctx, &m); err != nil {
+			return err
+		}
+		m2 := dbmodel.Model{
+			Name:              m.Name,
+			UUID:              sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true},
+			OwnerIdentityName: ut.Id(),
+			ControllerID:      m.ControllerID,
+			CloudRegionID:     m.CloudRegionID,
+			CloudCredentialID: m.CloudCredentialID,
+			Life:              m.Life,
+		}
+		return j.Database.AddModel(ctx, &m2)
+	}
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+	err = j.TransferModelOwnership(ctx, user, mt, names.NewUserTag("bob@canonical.com"))
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(revoked, qt.DeepEquals, []string{"00000002-0000-0000-0000-000000000001 bob@canonical.com admin"})
+
+	m := dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.OwnerIdentityName, qt.Equals, "alice@canonical.com")
+
+	alice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	c.Check(openfga.NewUser(&alice, client).GetModelAccess(ctx, mt), qt.Equals, ofganames.AdministratorRelation)
+	bob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	c.Check(openfga.NewUser(&bob, client).GetModelAccess(ctx, mt), qt.Equals, ofganames.WriterRelation)
+}
+
 const destroyModelTestEnv = `clouds:
 - name: test-cloud
   type: test-provider
@@ -3130,6 +3382,82 @@ func TestDestroyModel(t *testing.T) {
 	}
 }
 
+func TestDestroyModelArchive(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var destroyed bool
+	dialer := &jimmtest.Dialer{
+		API: &jimmtest.API{
+			DestroyModel_: func(context.Context, names.ModelTag, *bool, *bool, *time.Duration, *time.Duration) error {
+				destroyed = true
+				return nil
+			},
+			ModelGet_: func(context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{"default-series": "jammy"}, nil
+			},
+			Status_: func(context.Context, []string) (*jujuparams.FullStatus, error) {
+				return &jujuparams.FullStatus{
+					Applications: map[string]jujuparams.ApplicationStatus{
+						"app-1": {Charm: "ch:app"},
+					},
+				}, nil
+			},
+		},
+	}
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer:                 dialer,
+		ArchiveDestroyedModels: true,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, destroyModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbCharlie := env.User("charlie@canonical.com").DBObject(c, j.Database)
+	charlie := openfga.NewUser(&dbCharlie, client)
+	charlie.JimmAdmin = true
+
+	const modelUUID = "00000002-0000-0000-0000-000000000001"
+	_, err = j.GetModelArchive(ctx, charlie, modelUUID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.DestroyModel(ctx, alice, names.NewModelTag(modelUUID), nil, nil, nil, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(destroyed, qt.IsTrue)
+
+	_, err = j.GetModelArchive(ctx, alice, modelUUID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	archive, err := j.GetModelArchive(ctx, charlie, modelUUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(archive.ModelName, qt.Equals, "model-1")
+	c.Check(archive.OwnerIdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(archive.ControllerName, qt.Equals, "controller-1")
+	c.Check(archive.ArchivedBy, qt.Equals, "alice@canonical.com")
+	c.Check(archive.Config, qt.DeepEquals, dbmodel.Map{"default-series": "jammy"})
+	c.Check(archive.Status["applications"], qt.Not(qt.IsNil))
+
+	// If the snapshot cannot be taken the model is not destroyed.
+	destroyed = false
+	dialer.API.(*jimmtest.API).Status_ = nil
+	err = j.DestroyModel(ctx, alice, names.NewModelTag(modelUUID), nil, nil, nil, nil)
+	c.Check(err, qt.Not(qt.IsNil))
+	c.Check(destroyed, qt.IsFalse)
+}
+
 var dumpModelTests = []struct {
 	name            string
 	env             string
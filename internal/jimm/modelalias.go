@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// ResolveModelTag returns the tag of the model referred to by ref, which
+// may be a model tag, a model UUID, a path of the form <owner>/<name> or
+// one of the user's model aliases. ResolveModelTag does not check that
+// the user may access the model.
+func (j *JIMM) ResolveModelTag(ctx context.Context, user *openfga.User, ref string) (_ names.ModelTag, err error) {
+	const op = errors.Op("jimm.ResolveModelTag")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if mt, err := names.ParseModelTag(ref); err == nil {
+		return mt, nil
+	}
+	if names.IsValidModel(ref) {
+		return names.NewModelTag(ref), nil
+	}
+	if ref == "" {
+		return names.ModelTag{}, errors.E(op, errors.CodeBadRequest, "model not specified")
+	}
+	if owner, name, ok := strings.Cut(ref, "/"); ok {
+		m := dbmodel.Model{OwnerIdentityName: owner, Name: name}
+		if owner == "" || name == "" {
+			return names.ModelTag{}, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model path %q", ref))
+		}
+		if err := j.Database.GetModel(ctx, &m); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				return names.ModelTag{}, errors.E(op, errors.CodeNotFound, fmt.Sprintf("model %q not found", ref))
+			}
+			return names.ModelTag{}, errors.E(op, err)
+		}
+		return m.ResourceTag(), nil
+	}
+	alias := dbmodel.ModelAlias{IdentityName: user.Name, Alias: ref}
+	if err := j.Database.GetModelAlias(ctx, &alias); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return names.ModelTag{}, errors.E(op, errors.CodeNotFound, fmt.Sprintf("model %q not found", ref))
+		}
+		return names.ModelTag{}, errors.E(op, err)
+	}
+	return alias.Model.ResourceTag(), nil
+}
+
+// SetModelAlias gives the model referred to by ref the given alias for
+// the user, replacing any model the alias previously referred to. The
+// user must have access to the model.
+func (j *JIMM) SetModelAlias(ctx context.Context, user *openfga.User, alias, ref string) (_ *dbmodel.ModelAlias, err error) {
+	const op = errors.Op("jimm.SetModelAlias")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !names.IsValidModelName(alias) || names.IsValidModel(alias) {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid alias %q", alias))
+	}
+	mt, err := j.ResolveModelTag(ctx, user, ref)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.GetModelAccess(ctx, mt) == ofganames.NoRelation {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	a := dbmodel.ModelAlias{
+		IdentityName: user.Name,
+		Alias:        alias,
+		ModelID:      m.ID,
+	}
+	if err := j.Database.SetModelAlias(ctx, &a); err != nil {
+		return nil, errors.E(op, err)
+	}
+	a.Model = m
+	return &a, nil
+}
+
+// ListModelAliases returns the user's model aliases.
+func (j *JIMM) ListModelAliases(ctx context.Context, user *openfga.User) (_ []dbmodel.ModelAlias, err error) {
+	const op = errors.Op("jimm.ListModelAliases")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	aliases, err := j.Database.ListModelAliases(ctx, user.Name)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return aliases, nil
+}
+
+// RemoveModelAlias removes the user's model alias with the given name.
+func (j *JIMM) RemoveModelAlias(ctx context.Context, user *openfga.User, alias string) (err error) {
+	const op = errors.Op("jimm.RemoveModelAlias")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	a := dbmodel.ModelAlias{IdentityName: user.Name, Alias: alias}
+	if err := j.Database.GetModelAlias(ctx, &a); err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.DeleteModelAlias(ctx, &a); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestModelAliases(t *testing.T) {
+	ctx := context.Background()
+	c := qt.New(t)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelInfoTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	dave := dbmodel.Identity{Name: "dave@canonical.com"}
+	err = j.Database.GetIdentity(ctx, &dave)
+	c.Assert(err, qt.IsNil)
+	mt := names.NewModelTag(env.Models[0].UUID)
+
+	// Models may be referred to by tag, UUID or path.
+	for _, ref := range []string{mt.String(), mt.Id(), "alice@canonical.com/model-1"} {
+		got, err := j.ResolveModelTag(ctx, bob, ref)
+		c.Assert(err, qt.IsNil, qt.Commentf(ref))
+		c.Check(got, qt.Equals, mt)
+	}
+	_, err = j.ResolveModelTag(ctx, bob, "alice@canonical.com/no-such-model")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	_, err = j.ResolveModelTag(ctx, bob, "prod")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.SetModelAlias(ctx, bob, "Not/Valid", mt.Id())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	_, err = j.SetModelAlias(ctx, openfga.NewUser(&dave, client), "prod", mt.Id())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	a, err := j.SetModelAlias(ctx, bob, "prod", "alice@canonical.com/model-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(a.Model.UUID.String, qt.Equals, mt.Id())
+
+	// Aliases are resolved for the user that owns them.
+	got, err := j.ResolveModelTag(ctx, bob, "prod")
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.Equals, mt)
+	_, err = j.ResolveModelTag(ctx, openfga.NewUser(&dave, client), "prod")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	aliases, err := j.ListModelAliases(ctx, bob)
+	c.Assert(err, qt.IsNil)
+	c.Assert(aliases, qt.HasLen, 1)
+	c.Check(aliases[0].Alias, qt.Equals, "prod")
+	c.Check(aliases[0].Model.UUID.String, qt.Equals, mt.Id())
+
+	err = j.RemoveModelAlias(ctx, bob, "prod")
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveModelAlias(ctx, bob, "prod")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
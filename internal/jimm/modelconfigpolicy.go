@@ -0,0 +1,166 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// The actions a model config policy may take.
+const (
+	ModelConfigPolicyForbid = "forbid"
+	ModelConfigPolicyForce  = "force"
+)
+
+// SetModelConfigPolicy sets the policy for the given model config key,
+// replacing any existing policy for the key. A "forbid" policy prevents
+// the key being set to the given value or, if value is nil, being set at
+// all. A "force" policy requires the key to have the given value, which
+// is then set on every new model. Policies are checked when models are
+// created and when model config is set through JIMM, so existing models
+// may violate a newly set policy. Only JIMM administrators may set model
+// config policies.
+func (j *JIMM) SetModelConfigPolicy(ctx context.Context, user *openfga.User, key, action string, value *string, description string) (err error) {
+	const op = errors.Op("jimm.SetModelConfigPolicy")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if key == "" {
+		return errors.E(op, errors.CodeBadRequest, "model config key not specified")
+	}
+	switch action {
+	case ModelConfigPolicyForbid:
+	case ModelConfigPolicyForce:
+		if value == nil {
+			return errors.E(op, errors.CodeBadRequest, "a force policy requires a value")
+		}
+	default:
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model config policy action %q", action))
+	}
+	policy := dbmodel.ModelConfigPolicy{
+		Key:         key,
+		Action:      action,
+		Description: description,
+	}
+	dbmodel.SetNullString(&policy.Value, value)
+	if err := j.Database.SetModelConfigPolicy(ctx, &policy); err != nil {
+		return errors.E(op, err)
+	}
+	j.auditAdminCall(user, "SetModelConfigPolicy", map[string]any{"key": key, "action": action, "value": value})
+	return nil
+}
+
+// RemoveModelConfigPolicy removes the policy for the given model config
+// key. Only JIMM administrators may remove model config policies.
+func (j *JIMM) RemoveModelConfigPolicy(ctx context.Context, user *openfga.User, key string) (err error) {
+	const op = errors.Op("jimm.RemoveModelConfigPolicy")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.Database.RemoveModelConfigPolicy(ctx, &dbmodel.ModelConfigPolicy{Key: key}); err != nil {
+		return errors.E(op, err)
+	}
+	j.auditAdminCall(user, "RemoveModelConfigPolicy", map[string]any{"key": key})
+	return nil
+}
+
+// ListModelConfigPolicies returns every model config policy, ordered by
+// key. Policies apply to every user so any user may list them.
+func (j *JIMM) ListModelConfigPolicies(ctx context.Context, user *openfga.User) (_ []dbmodel.ModelConfigPolicy, err error) {
+	const op = errors.Op("jimm.ListModelConfigPolicies")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	policies, err := j.Database.ListModelConfigPolicies(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return policies, nil
+}
+
+// CheckModelConfig checks that setting the given model config values and
+// unsetting the given keys does not violate any model config policy. If
+// it does an error with a code of CodePolicyViolation is returned.
+func (j *JIMM) CheckModelConfig(ctx context.Context, set map[string]any, unset []string) (err error) {
+	const op = errors.Op("jimm.CheckModelConfig")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	policies, err := j.Database.ListModelConfigPolicies(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := checkModelConfigPolicies(policies, set, unset); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// checkModelConfigPolicies returns an error with a code of
+// CodePolicyViolation describing every policy that would be violated by
+// setting the given values and unsetting the given keys.
+func checkModelConfigPolicies(policies []dbmodel.ModelConfigPolicy, set map[string]any, unset []string) error {
+	var violations []string
+	for _, p := range policies {
+		var msg string
+		v, isSet := set[p.Key]
+		switch {
+		case p.Action == ModelConfigPolicyForce && isSet && configValueString(v) != p.Value.String:
+			msg = fmt.Sprintf("%s must be %q", p.Key, p.Value.String)
+		case p.Action == ModelConfigPolicyForce && slices.Contains(unset, p.Key):
+			msg = fmt.Sprintf("%s cannot be unset", p.Key)
+		case p.Action == ModelConfigPolicyForbid && isSet && !p.Value.Valid:
+			msg = fmt.Sprintf("%s cannot be set", p.Key)
+		case p.Action == ModelConfigPolicyForbid && isSet && configValueString(v) == p.Value.String:
+			msg = fmt.Sprintf("%s cannot be %q", p.Key, p.Value.String)
+		default:
+			continue
+		}
+		if p.Description != "" {
+			msg += " (" + p.Description + ")"
+		}
+		violations = append(violations, msg)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return errors.E(errors.CodePolicyViolation, "model config policy violation: "+strings.Join(violations, "; "))
+}
+
+// forcedModelConfig returns the model config values set by the force
+// policies.
+func forcedModelConfig(policies []dbmodel.ModelConfigPolicy) map[string]any {
+	cfg := make(map[string]any)
+	for _, p := range policies {
+		if p.Action == ModelConfigPolicyForce {
+			cfg[p.Key] = p.Value.String
+		}
+	}
+	return cfg
+}
+
+// configValueString returns the string form of a model config value, so
+// that values given as strings, such as "false", match values given as
+// other types, such as false.
+func configValueString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
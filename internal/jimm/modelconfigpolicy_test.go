@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestModelConfigPolicies(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, _, _, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, ofgaClient)
+
+	none, info := "none", "<root>=INFO"
+	err = j.SetModelConfigPolicy(ctx, u, "firewall-mode", jimm.ModelConfigPolicyForbid, &none, "")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	err = j.SetModelConfigPolicy(ctx, u, "firewall-mode", "require", &none, "")
+	c.Check(err, qt.ErrorMatches, `invalid model config policy action "require"`)
+	err = j.SetModelConfigPolicy(ctx, u, "logging-config", jimm.ModelConfigPolicyForce, nil, "")
+	c.Check(err, qt.ErrorMatches, `a force policy requires a value`)
+
+	err = j.SetModelConfigPolicy(ctx, u, "firewall-mode", jimm.ModelConfigPolicyForbid, &none, "models must be firewalled")
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelConfigPolicy(ctx, u, "logging-config", jimm.ModelConfigPolicyForce, &info, "")
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelConfigPolicy(ctx, u, "automatically-retry-hooks", jimm.ModelConfigPolicyForbid, nil, "")
+	c.Assert(err, qt.IsNil)
+
+	policies, err := j.ListModelConfigPolicies(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Assert(policies, qt.HasLen, 3)
+	c.Check(policies[0].Key, qt.Equals, "automatically-retry-hooks")
+	c.Check(policies[0].Value.Valid, qt.IsFalse)
+
+	err = j.CheckModelConfig(ctx, map[string]any{"firewall-mode": "instance", "logging-config": "<root>=INFO"}, nil)
+	c.Check(err, qt.IsNil)
+
+	err = j.CheckModelConfig(ctx, map[string]any{"firewall-mode": "none"}, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodePolicyViolation)
+	c.Check(err, qt.ErrorMatches, `model config policy violation: firewall-mode cannot be "none" \(models must be firewalled\)`)
+
+	err = j.CheckModelConfig(ctx, map[string]any{"automatically-retry-hooks": false, "logging-config": "<root>=DEBUG"}, nil)
+	c.Check(err, qt.ErrorMatches, `model config policy violation: automatically-retry-hooks cannot be set; logging-config must be "<root>=INFO"`)
+
+	err = j.CheckModelConfig(ctx, nil, []string{"logging-config"})
+	c.Check(err, qt.ErrorMatches, `model config policy violation: logging-config cannot be unset`)
+
+	err = j.RemoveModelConfigPolicy(ctx, u, "firewall-mode")
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveModelConfigPolicy(ctx, u, "firewall-mode")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.CheckModelConfig(ctx, map[string]any{"firewall-mode": "none"}, nil)
+	c.Check(err, qt.IsNil)
+}
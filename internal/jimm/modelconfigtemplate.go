@@ -0,0 +1,200 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// ModelConfigTemplatesKey is the model config key that may be given when
+// adding a model to name the model config templates to apply. The value
+// is a comma-separated list of template paths, the templates are applied
+// in order before any other config values given.
+const ModelConfigTemplatesKey = "jimm-templates"
+
+// parseModelConfigTemplatePath parses a template path of the form
+// <owner>/<name>. If the path has no owner the template is owned by the
+// given user.
+func parseModelConfigTemplatePath(user *openfga.User, path string) (dbmodel.ModelConfigTemplate, error) {
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok {
+		owner, name = user.Name, path
+	}
+	if owner == "" || name == "" || strings.Contains(name, "/") {
+		return dbmodel.ModelConfigTemplate{}, errors.E(errors.CodeBadRequest, "invalid template path "+path)
+	}
+	return dbmodel.ModelConfigTemplate{OwnerIdentityName: owner, Name: name}, nil
+}
+
+// canReadModelConfigTemplate reports whether the user may read and use
+// the template. Templates may be used by their owner, JIMM
+// administrators and the identities the template is shared with.
+func canReadModelConfigTemplate(user *openfga.User, t *dbmodel.ModelConfigTemplate) bool {
+	if user.JimmAdmin || t.OwnerIdentityName == user.Name {
+		return true
+	}
+	for _, name := range t.SharedWith {
+		if name == user.Name || name == ofganames.EveryoneUser {
+			return true
+		}
+	}
+	return false
+}
+
+// SetModelConfigTemplate creates, or replaces, the model config template
+// with the given path. Templates may only be set by their owner or by a
+// JIMM administrator.
+func (j *JIMM) SetModelConfigTemplate(ctx context.Context, user *openfga.User, path string, config map[string]any, sharedWith []string) (_ *dbmodel.ModelConfigTemplate, err error) {
+	const op = errors.Op("jimm.SetModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	t, err := parseModelConfigTemplatePath(user, path)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if t.OwnerIdentityName != user.Name && !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if _, ok := config[ModelConfigTemplatesKey]; ok {
+		return nil, errors.E(op, errors.CodeBadRequest, "templates cannot include "+ModelConfigTemplatesKey)
+	}
+	if err := j.Database.GetIdentity(ctx, &dbmodel.Identity{Name: t.OwnerIdentityName}); err != nil {
+		return nil, errors.E(op, err)
+	}
+	t.Config = config
+	t.SharedWith = sharedWith
+	if err := j.Database.SetModelConfigTemplate(ctx, &t); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	params, _ := json.Marshal(map[string]any{
+		"path":        t.Path(),
+		"config":      config,
+		"shared-with": sharedWith,
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "SetModelConfigTemplate",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return &t, nil
+}
+
+// GetModelConfigTemplate returns the model config template with the
+// given path. If the template does not exist, or the user may not read
+// it, an error with a code of CodeNotFound is returned.
+func (j *JIMM) GetModelConfigTemplate(ctx context.Context, user *openfga.User, path string) (_ *dbmodel.ModelConfigTemplate, err error) {
+	const op = errors.Op("jimm.GetModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	t, err := parseModelConfigTemplatePath(user, path)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.Database.GetModelConfigTemplate(ctx, &t); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !canReadModelConfigTemplate(user, &t) {
+		return nil, errors.E(op, errors.CodeNotFound, "template "+path+" not found")
+	}
+	return &t, nil
+}
+
+// ListModelConfigTemplates returns the model config templates the user
+// may read.
+func (j *JIMM) ListModelConfigTemplates(ctx context.Context, user *openfga.User) (_ []dbmodel.ModelConfigTemplate, err error) {
+	const op = errors.Op("jimm.ListModelConfigTemplates")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	templates, err := j.Database.ListModelConfigTemplates(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var readable []dbmodel.ModelConfigTemplate
+	for _, t := range templates {
+		if canReadModelConfigTemplate(user, &t) {
+			readable = append(readable, t)
+		}
+	}
+	return readable, nil
+}
+
+// RemoveModelConfigTemplate removes the model config template with the
+// given path. Templates may only be removed by their owner or by a JIMM
+// administrator. Removing a template does not change the config of
+// models created with it.
+func (j *JIMM) RemoveModelConfigTemplate(ctx context.Context, user *openfga.User, path string) (err error) {
+	const op = errors.Op("jimm.RemoveModelConfigTemplate")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	t, err := j.GetModelConfigTemplate(ctx, user, path)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if t.OwnerIdentityName != user.Name && !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.Database.DeleteModelConfigTemplate(ctx, t); err != nil {
+		return errors.E(op, err)
+	}
+
+	params, _ := json.Marshal(map[string]any{
+		"path": t.Path(),
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "RemoveModelConfigTemplate",
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+	return nil
+}
+
+// modelConfigTemplates resolves the templates named in the given model
+// config, returning the config of each template in the order given and
+// a copy of the config with the templates key removed.
+func (j *JIMM) modelConfigTemplates(ctx context.Context, user *openfga.User, config map[string]any) ([]map[string]any, map[string]any, error) {
+	v, ok := config[ModelConfigTemplatesKey]
+	if !ok {
+		return nil, config, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, nil, errors.E(errors.CodeBadRequest, ModelConfigTemplatesKey+" must be a string")
+	}
+	remaining := make(map[string]any, len(config)-1)
+	for k, v := range config {
+		if k != ModelConfigTemplatesKey {
+			remaining[k] = v
+		}
+	}
+	var configs []map[string]any
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		t, err := j.GetModelConfigTemplate(ctx, user, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		configs = append(configs, t.Config)
+	}
+	return configs, remaining, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+func TestModelConfigTemplates(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	users := make(map[string]*openfga.User)
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com", "charlie@canonical.com", "admin@canonical.com"} {
+		i, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		err = j.Database.GetIdentity(ctx, i)
+		c.Assert(err, qt.IsNil)
+		users[name] = openfga.NewUser(i, ofgaClient)
+	}
+	alice := users["alice@canonical.com"]
+	bob := users["bob@canonical.com"]
+	charlie := users["charlie@canonical.com"]
+	admin := users["admin@canonical.com"]
+	admin.JimmAdmin = true
+
+	// Users may set their own templates.
+	tmpl, err := j.SetModelConfigTemplate(ctx, alice, "base", map[string]any{"logging-config": "<root>=INFO", "automatically-retry-hooks": false}, []string{"bob@canonical.com"})
+	c.Assert(err, qt.IsNil)
+	c.Check(tmpl.Path(), qt.Equals, "alice@canonical.com/base")
+	_, err = j.SetModelConfigTemplate(ctx, alice, "alice@canonical.com/debug", map[string]any{"logging-config": "<root>=DEBUG"}, nil)
+	c.Assert(err, qt.IsNil)
+
+	// But not the templates of other users.
+	_, err = j.SetModelConfigTemplate(ctx, bob, "alice@canonical.com/base", map[string]any{}, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Administrators may set templates for anyone.
+	_, err = j.SetModelConfigTemplate(ctx, admin, "charlie@canonical.com/public", map[string]any{"update-status-hook-interval": "10m"}, []string{ofganames.EveryoneUser})
+	c.Assert(err, qt.IsNil)
+
+	// Templates cannot refer to other templates.
+	_, err = j.SetModelConfigTemplate(ctx, alice, "nested", map[string]any{jimm.ModelConfigTemplatesKey: "base"}, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	_, err = j.SetModelConfigTemplate(ctx, alice, "alice@canonical.com/a/b", nil, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	// Shared templates are readable, others are not found.
+	tmpl, err = j.GetModelConfigTemplate(ctx, bob, "alice@canonical.com/base")
+	c.Assert(err, qt.IsNil)
+	c.Check(tmpl.Config, qt.DeepEquals, dbmodel.Map{"logging-config": "<root>=INFO", "automatically-retry-hooks": false})
+	_, err = j.GetModelConfigTemplate(ctx, bob, "alice@canonical.com/debug")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	_, err = j.GetModelConfigTemplate(ctx, bob, "charlie@canonical.com/public")
+	c.Check(err, qt.IsNil)
+
+	templates, err := j.ListModelConfigTemplates(ctx, bob)
+	c.Assert(err, qt.IsNil)
+	var paths []string
+	for _, t := range templates {
+		paths = append(paths, t.Path())
+	}
+	c.Check(paths, qt.DeepEquals, []string{"alice@canonical.com/base", "charlie@canonical.com/public"})
+
+	templates, err = j.ListModelConfigTemplates(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(templates, qt.HasLen, 3)
+
+	// Templates named in the model config are resolved in order.
+	configs, config, err := j.ModelConfigTemplates(ctx, bob, map[string]any{
+		jimm.ModelConfigTemplatesKey: "alice@canonical.com/base, charlie@canonical.com/public",
+		"logging-config":             "<root>=WARNING",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(configs, qt.DeepEquals, []map[string]any{
+		{"logging-config": "<root>=INFO", "automatically-retry-hooks": false},
+		{"update-status-hook-interval": "10m"},
+	})
+	c.Check(config, qt.DeepEquals, map[string]any{"logging-config": "<root>=WARNING"})
+
+	_, _, err = j.ModelConfigTemplates(ctx, charlie, map[string]any{jimm.ModelConfigTemplatesKey: "alice@canonical.com/base"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Only the owner, or an administrator, may remove a template.
+	err = j.RemoveModelConfigTemplate(ctx, bob, "alice@canonical.com/base")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	err = j.RemoveModelConfigTemplate(ctx, alice, "base")
+	c.Assert(err, qt.IsNil)
+	_, err = j.GetModelConfigTemplate(ctx, alice, "base")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
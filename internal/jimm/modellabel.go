@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// maxModelLabels is the maximum number of labels that may be set on a
+// model.
+const maxModelLabels = 64
+
+var (
+	// labelKeyRegexp matches valid label keys. Keys start and end with
+	// an alphanumeric character and may contain '.', '_', '-' and '/'.
+	labelKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,61}[a-zA-Z0-9])?$`)
+
+	// labelValueRegexp matches valid label values. Values may be empty
+	// but may not contain the characters used in label selectors.
+	labelValueRegexp = regexp.MustCompile(`^[a-zA-Z0-9._/@:-]{0,255}$`)
+)
+
+// SetModelLabels replaces the labels on the given model with the given
+// labels. An empty set of labels removes all the model's labels. Labels
+// are only recorded in JIMM. The authenticated user must be an
+// administrator of the model.
+func (j *JIMM) SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string) (err error) {
+	const op = errors.Op("jimm.SetModelLabels")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := validateModelLabels(labels); err != nil {
+		return errors.E(op, err)
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.GetModelAccess(ctx, mt) != ofganames.AdministratorRelation {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	m.Labels = nil
+	if len(labels) > 0 {
+		m.Labels = dbmodel.StringMap(labels)
+	}
+	if err := j.Database.UpdateModel(ctx, &m); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// validateModelLabels checks that the given labels may be set on a model.
+func validateModelLabels(labels map[string]string) error {
+	if len(labels) > maxModelLabels {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("too many labels, a model may have at most %d", maxModelLabels))
+	}
+	for k, v := range labels {
+		if !labelKeyRegexp.MatchString(k) {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid label key %q", k))
+		}
+		if !labelValueRegexp.MatchString(v) {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid value for label %q", k))
+		}
+	}
+	return nil
+}
+
+// A labelRequirement is a single requirement of a LabelSelector.
+type labelRequirement struct {
+	key   string
+	value string
+	// op is one of "=", "!=" or "" for a requirement that the key is
+	// present with any value.
+	op string
+}
+
+// matches returns whether the given labels meet the requirement.
+func (r labelRequirement) matches(labels map[string]string) bool {
+	v, ok := labels[r.key]
+	switch r.op {
+	case "=":
+		return ok && v == r.value
+	case "!=":
+		return !ok || v != r.value
+	default:
+		return ok
+	}
+}
+
+// A LabelSelector selects models by their labels. The zero LabelSelector
+// selects every model.
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+// ParseLabelSelector parses a label selector. A selector is a comma
+// separated list of requirements, all of which must be met. Each
+// requirement is one of "key=value", "key!=value" or "key", which
+// requires that the label is set with any value. An empty selector
+// selects every model.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var sel LabelSelector
+	if strings.TrimSpace(s) == "" {
+		return sel, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		var r labelRequirement
+		if k, v, ok := strings.Cut(part, "!="); ok {
+			r = labelRequirement{key: k, value: v, op: "!="}
+		} else if k, v, ok := strings.Cut(part, "="); ok {
+			r = labelRequirement{key: k, value: v, op: "="}
+		} else {
+			r = labelRequirement{key: part}
+		}
+		r.key = strings.TrimSpace(r.key)
+		r.value = strings.TrimSpace(r.value)
+		if !labelKeyRegexp.MatchString(r.key) || !labelValueRegexp.MatchString(r.value) {
+			return LabelSelector{}, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid label selector %q", part))
+		}
+		sel.requirements = append(sel.requirements, r)
+	}
+	return sel, nil
+}
+
+// Matches returns whether the given labels meet every requirement of the
+// selector.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	c := qt.New(t)
+
+	labels := map[string]string{"team": "payments", "env": "prod"}
+	tests := []struct {
+		selector    string
+		expectMatch bool
+		expectError string
+	}{
+		{selector: "", expectMatch: true},
+		{selector: "team=payments", expectMatch: true},
+		{selector: "team=payments,env=prod", expectMatch: true},
+		{selector: " team = payments , env ", expectMatch: true},
+		{selector: "team=payments,env=dev", expectMatch: false},
+		{selector: "env!=dev", expectMatch: true},
+		{selector: "env!=prod", expectMatch: false},
+		{selector: "region!=eu", expectMatch: true},
+		{selector: "region", expectMatch: false},
+		{selector: "team=payments,", expectError: `invalid label selector ""`},
+		{selector: "=payments", expectError: `invalid label selector "=payments"`},
+		{selector: "team=pay,ments=x=y", expectError: `invalid label selector "ments=x=y"`},
+	}
+	for _, test := range tests {
+		c.Run(test.selector, func(c *qt.C) {
+			sel, err := jimm.ParseLabelSelector(test.selector)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(sel.Matches(labels), qt.Equals, test.expectMatch)
+		})
+	}
+}
+
+func TestSetModelLabels(t *testing.T) {
+	ctx := context.Background()
+	c := qt.New(t)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelInfoTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	mt := names.NewModelTag(env.Models[0].UUID)
+
+	// Only model administrators may set labels.
+	err = j.SetModelLabels(ctx, bob, mt, map[string]string{"team": "payments"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.SetModelLabels(ctx, alice, mt, map[string]string{"team,env": "payments"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.SetModelLabels(ctx, alice, mt, map[string]string{"team": "payments", "env": "prod"})
+	c.Assert(err, qt.IsNil)
+
+	m := dbmodel.Model{}
+	m.SetTag(mt)
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Labels, qt.DeepEquals, dbmodel.StringMap{"team": "payments", "env": "prod"})
+
+	// Models are selected by their labels before access is checked.
+	match := func(selector string) []string {
+		sel, err := jimm.ParseLabelSelector(selector)
+		c.Assert(err, qt.IsNil)
+		var models []string
+		err = j.ForEachUserModelMatching(ctx, bob, sel, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+			models = append(models, m.Name)
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+		return models
+	}
+	c.Check(match("team=payments,env=prod"), qt.DeepEquals, []string{"model-1"})
+	c.Check(match("env=dev"), qt.HasLen, 0)
+
+	// Setting no labels removes them all.
+	err = j.SetModelLabels(ctx, alice, mt, nil)
+	c.Assert(err, qt.IsNil)
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Labels, qt.IsNil)
+	c.Check(match("team"), qt.HasLen, 0)
+}
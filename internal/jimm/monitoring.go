@@ -10,11 +10,15 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // UpdateMetrics updates metrics for the total numbers of controllers
 // managed by JIMM as well as how many model each controller manages.
 func (j *JIMM) UpdateMetrics(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "jimm.UpdateMetrics")
+	defer span.End()
+
 	controllerCount := 0
 	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
 		controllerCount++
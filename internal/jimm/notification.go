@@ -0,0 +1,238 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// A NotificationRoute is a notification route along with the tag of the
+// user or group it is for.
+type NotificationRoute struct {
+	dbmodel.NotificationRoute
+
+	// Subject is the tag of the user or group the route is for, for
+	// example "user-alice@canonical.com" or "group-team-a".
+	Subject string
+}
+
+// AddNotificationRoute adds a route along which notifications of the
+// given kind for the given subject are delivered using the named
+// transport. If kind is empty notifications of every kind are delivered.
+// The subject is the tag of a user or group, if it is empty the route is
+// added for the authenticated user. Users may add routes for themselves,
+// only JIMM administrators may add routes for other users or for groups.
+func (j *JIMM) AddNotificationRoute(ctx context.Context, user *openfga.User, subject, kind, transport, destination string) (_ *NotificationRoute, err error) {
+	const op = errors.Op("jimm.AddNotificationRoute")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	route := NotificationRoute{
+		Subject: subject,
+		NotificationRoute: dbmodel.NotificationRoute{
+			Kind:        kind,
+			Transport:   transport,
+			Destination: destination,
+		},
+	}
+	if route.Subject == "" {
+		route.Subject = user.Tag().String()
+	}
+	kindStr, id, _ := strings.Cut(route.Subject, "-")
+	switch kindStr {
+	case names.UserTagKind:
+		if !names.IsValidUser(id) {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid subject %q", subject))
+		}
+		if id != user.Name && !user.JimmAdmin {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+		route.SubjectID = id
+	case jimmnames.GroupTagKind:
+		if !user.JimmAdmin {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+		group := dbmodel.GroupEntry{Name: id}
+		if err := j.Database.GetGroup(ctx, &group); err != nil {
+			return nil, errors.E(op, err)
+		}
+		route.SubjectID = group.UUID
+	default:
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid subject %q", subject))
+	}
+	route.SubjectKind = kindStr
+
+	if kind != "" && !slices.Contains(notify.Kinds, kind) {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("unknown notification kind %q", kind))
+	}
+	t, ok := j.NotificationTransports[transport]
+	if !ok {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("notification transport %q not available", transport))
+	}
+	if err := t.ValidateDestination(destination); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	if err := j.Database.AddNotificationRoute(ctx, &route.NotificationRoute); err != nil {
+		return nil, errors.E(op, err)
+	}
+	j.auditNotificationRoute(user, "AddNotificationRoute", route)
+	return &route, nil
+}
+
+// ListNotificationRoutes returns the notification routes for the
+// authenticated user. JIMM administrators are returned the routes for all
+// users and groups.
+func (j *JIMM) ListNotificationRoutes(ctx context.Context, user *openfga.User) (_ []NotificationRoute, err error) {
+	const op = errors.Op("jimm.ListNotificationRoutes")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	var routes []dbmodel.NotificationRoute
+	if user.JimmAdmin {
+		routes, err = j.Database.ListNotificationRoutes(ctx, "")
+	} else {
+		routes, err = j.Database.ListNotificationRoutes(ctx, names.UserTagKind, user.Name)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	result := make([]NotificationRoute, len(routes))
+	for i, r := range routes {
+		result[i] = NotificationRoute{
+			NotificationRoute: r,
+			Subject:           j.notificationSubject(ctx, r),
+		}
+	}
+	return result, nil
+}
+
+// RemoveNotificationRoute removes the notification route with the given
+// ID. Users may remove their own routes, JIMM administrators may remove
+// any route.
+func (j *JIMM) RemoveNotificationRoute(ctx context.Context, user *openfga.User, id uint) (err error) {
+	const op = errors.Op("jimm.RemoveNotificationRoute")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	r := dbmodel.NotificationRoute{ID: id}
+	if err := j.Database.GetNotificationRoute(ctx, &r); err != nil {
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin && (r.SubjectKind != names.UserTagKind || r.SubjectID != user.Name) {
+		// Don't reveal the existence of other users' routes.
+		return errors.E(op, errors.CodeNotFound, "notification route not found")
+	}
+	if err := j.Database.DeleteNotificationRoute(ctx, &r); err != nil {
+		return errors.E(op, err)
+	}
+	j.auditNotificationRoute(user, "RemoveNotificationRoute", NotificationRoute{
+		NotificationRoute: r,
+		Subject:           j.notificationSubject(ctx, r),
+	})
+	return nil
+}
+
+// notificationSubject returns the tag of the subject of the given route.
+// Groups are identified by name if the group still exists.
+func (j *JIMM) notificationSubject(ctx context.Context, r dbmodel.NotificationRoute) string {
+	if r.SubjectKind == jimmnames.GroupTagKind {
+		group := dbmodel.GroupEntry{UUID: r.SubjectID}
+		if err := j.Database.GetGroup(ctx, &group); err == nil {
+			return jimmnames.GroupTagKind + "-" + group.Name
+		}
+	}
+	return r.SubjectKind + "-" + r.SubjectID
+}
+
+// auditNotificationRoute records a change to a notification route in the
+// audit log. Destinations may contain secrets, such as Slack webhook
+// URLs, so only the transport is recorded.
+func (j *JIMM) auditNotificationRoute(user *openfga.User, method string, r NotificationRoute) {
+	params, _ := json.Marshal(map[string]any{
+		"id":        r.ID,
+		"subject":   r.Subject,
+		"kind":      r.Kind,
+		"transport": r.Transport,
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		IdentityTag:  user.Tag().String(),
+		Params:       params,
+	})
+}
+
+// sendNotification delivers the given notification to the identity with
+// the given name along every matching route for the identity and for
+// the groups it is a member of. Notifications are delivered in the
+// background and failures are only logged.
+func (j *JIMM) sendNotification(ctx context.Context, identityName string, n notify.Notification) {
+	if len(j.NotificationTransports) == 0 {
+		return
+	}
+	n.Recipient = identityName
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+
+	routes, err := j.Database.ListNotificationRoutes(ctx, names.UserTagKind, identityName)
+	if err != nil {
+		zapctx.Error(ctx, "cannot list notification routes", zap.Error(err))
+		return
+	}
+	if j.OpenFGAClient != nil {
+		groups, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTag(names.NewUserTag(identityName)), ofganames.MemberRelation, openfga.GroupType, nil)
+		if err != nil {
+			zapctx.Warn(ctx, "cannot list groups for notification", zap.Error(err))
+		}
+		groupIDs := make([]string, len(groups))
+		for i, g := range groups {
+			groupIDs[i] = g.ID
+		}
+		groupRoutes, err := j.Database.ListNotificationRoutes(ctx, jimmnames.GroupTagKind, groupIDs...)
+		if err != nil {
+			zapctx.Error(ctx, "cannot list notification routes", zap.Error(err))
+		}
+		routes = append(routes, groupRoutes...)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	sent := make(map[[2]string]bool)
+	for _, r := range routes {
+		key := [2]string{r.Transport, r.Destination}
+		if !r.Matches(n.Kind) || sent[key] {
+			continue
+		}
+		sent[key] = true
+		t, ok := j.NotificationTransports[r.Transport]
+		if !ok {
+			zapctx.Warn(ctx, "notification transport not available", zap.String("transport", r.Transport), zap.Uint("route-id", r.ID))
+			continue
+		}
+		r := r
+		go func() {
+			if err := t.Send(ctx, r.Destination, n); err != nil {
+				zapctx.Warn(ctx, "notification delivery failed", zap.Uint("route-id", r.ID), zap.String("kind", n.Kind), zap.Error(err))
+			}
+		}()
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// testTransport is a notify.Transport that records the destinations of
+// the notifications it is sent.
+type testTransport struct {
+	sent chan string
+}
+
+func (t testTransport) ValidateDestination(destination string) error {
+	if destination == "invalid" {
+		return errors.E(errors.CodeBadRequest, "invalid destination")
+	}
+	return nil
+}
+
+func (t testTransport) Send(_ context.Context, destination string, n notify.Notification) error {
+	t.sent <- n.Kind + " " + n.Recipient + " " + destination
+	return nil
+}
+
+func TestNotificationRoutes(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	transport := testTransport{sent: make(chan string, 10)}
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+		NotificationTransports: map[string]notify.Transport{
+			"test": transport,
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	group, err := j.Database.AddGroup(ctx, "team-a")
+	c.Assert(err, qt.IsNil)
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("alice@canonical.com")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(group.UUID)),
+	})
+	c.Assert(err, qt.IsNil)
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, ofgaClient)
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	// Users may add routes for themselves.
+	r1, err := j.AddNotificationRoute(ctx, alice, "", notify.KindUsageAlert, "test", "alice-usage")
+	c.Assert(err, qt.IsNil)
+	c.Check(r1.Subject, qt.Equals, "user-alice@canonical.com")
+
+	_, err = j.AddNotificationRoute(ctx, alice, "user-bob@canonical.com", "", "test", "bob")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	_, err = j.AddNotificationRoute(ctx, alice, "group-team-a", "", "test", "team-a")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	_, err = j.AddNotificationRoute(ctx, alice, "", "no-such-kind", "test", "alice")
+	c.Check(err, qt.ErrorMatches, `unknown notification kind "no-such-kind"`)
+	_, err = j.AddNotificationRoute(ctx, alice, "", "", "no-such-transport", "alice")
+	c.Check(err, qt.ErrorMatches, `notification transport "no-such-transport" not available`)
+	_, err = j.AddNotificationRoute(ctx, alice, "", "", "test", "invalid")
+	c.Check(err, qt.ErrorMatches, `invalid destination`)
+
+	// Administrators may add routes for groups.
+	r2, err := j.AddNotificationRoute(ctx, admin, "group-team-a", "", "test", "team-a")
+	c.Assert(err, qt.IsNil)
+	c.Check(r2.Subject, qt.Equals, "group-team-a")
+	c.Check(r2.SubjectID, qt.Equals, group.UUID)
+
+	routes, err := j.ListNotificationRoutes(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Assert(routes, qt.HasLen, 1)
+	c.Check(routes[0].ID, qt.Equals, r1.ID)
+	routes, err = j.ListNotificationRoutes(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(routes, qt.HasLen, 2)
+
+	// Notifications are delivered along the user's and group's routes
+	// that match the notification kind.
+	j.SendNotification(ctx, "alice@canonical.com", notify.Notification{Kind: notify.KindUsageAlert})
+	sent := []string{<-transport.sent, <-transport.sent}
+	sort.Strings(sent)
+	c.Check(sent, qt.DeepEquals, []string{
+		"usage-alert alice@canonical.com alice-usage",
+		"usage-alert alice@canonical.com team-a",
+	})
+	j.SendNotification(ctx, "alice@canonical.com", notify.Notification{Kind: notify.KindMigrationComplete})
+	c.Check(<-transport.sent, qt.Equals, "migration-complete alice@canonical.com team-a")
+
+	// Users cannot remove routes that are not theirs.
+	err = j.RemoveNotificationRoute(ctx, alice, r2.ID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	err = j.RemoveNotificationRoute(ctx, alice, r1.ID)
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveNotificationRoute(ctx, admin, r2.ID)
+	c.Assert(err, qt.IsNil)
+	routes, err = j.ListNotificationRoutes(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	c.Check(routes, qt.HasLen, 0)
+}
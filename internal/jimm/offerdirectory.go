@@ -0,0 +1,101 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// An OfferDirectoryFilter restricts the offers returned by SearchOffers.
+// Empty fields match every offer.
+type OfferDirectoryFilter struct {
+	// Interface matches offers with an endpoint using the interface.
+	Interface string
+
+	// ApplicationName matches offers of the named application.
+	ApplicationName string
+
+	// OwnerName matches offers in models owned by the named identity.
+	OwnerName string
+}
+
+// An OfferDirectoryEntry is an offer found by SearchOffers.
+type OfferDirectoryEntry struct {
+	// Offer is the stored offer, with its model and controller.
+	Offer dbmodel.ApplicationOffer
+
+	// Access is the user's access level to the offer, either "consume"
+	// or "admin".
+	Access jujuparams.OfferAccessPermission
+}
+
+// SearchOffers returns the offers the user can consume that match the
+// given filter, across every controller known to JIMM, ordered by offer
+// URL. Offers are found from the offer records stored by JIMM, so no
+// controller is contacted and the user does not need to know the URL of
+// an offer to find it.
+func (j *JIMM) SearchOffers(ctx context.Context, user *openfga.User, filter OfferDirectoryFilter) (_ []OfferDirectoryEntry, err error) {
+	const op = errors.Op("jimm.SearchOffers")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	consumable, err := user.ListApplicationOffers(ctx, ofganames.ConsumerRelation)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	administered, err := user.ListApplicationOffers(ctx, ofganames.AdministratorRelation)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	filters := []db.ApplicationOfferFilter{
+		db.ApplicationOfferFilterByUUID(append(consumable, administered...)),
+	}
+	if filter.Interface != "" {
+		filters = append(filters, db.ApplicationOfferFilterByEndpoint(dbmodel.ApplicationOfferRemoteEndpoint{
+			Interface: filter.Interface,
+		}))
+	}
+	if filter.ApplicationName != "" {
+		filters = append(filters, db.ApplicationOfferFilterByApplication(filter.ApplicationName))
+	}
+	if filter.OwnerName != "" {
+		filters = append(filters, db.ApplicationOfferFilterByOwner(filter.OwnerName))
+	}
+	offers, err := j.Database.FindApplicationOffers(ctx, filters...)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// An offer with more than one endpoint using the interface is
+	// found once for each endpoint.
+	sort.Slice(offers, func(i, k int) bool {
+		return offers[i].URL < offers[k].URL
+	})
+	offers = slices.CompactFunc(offers, func(a, b dbmodel.ApplicationOffer) bool {
+		return a.UUID == b.UUID
+	})
+
+	entries := make([]OfferDirectoryEntry, len(offers))
+	for i, offer := range offers {
+		entries[i] = OfferDirectoryEntry{
+			Offer:  offer,
+			Access: jujuparams.OfferConsumeAccess,
+		}
+		if slices.Contains(administered, offer.UUID) {
+			entries[i].Access = jujuparams.OfferAdminAccess
+		}
+	}
+	return entries, nil
+}
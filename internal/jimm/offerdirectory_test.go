@@ -0,0 +1,143 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestSearchOffers(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	tests := []struct {
+		about          string
+		user           func(*environment) dbmodel.Identity
+		filter         jimm.OfferDirectoryFilter
+		expectedAccess jujuparams.OfferAccessPermission
+	}{{
+		about: "offer consumer finds the offer",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		expectedAccess: jujuparams.OfferConsumeAccess,
+	}, {
+		about: "offer admin finds the offer",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[1]
+		},
+		expectedAccess: jujuparams.OfferAdminAccess,
+	}, {
+		about: "model admin finds the offer",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[0]
+		},
+		expectedAccess: jujuparams.OfferAdminAccess,
+	}, {
+		about: "offer reader cannot find the offer",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[3]
+		},
+	}, {
+		about: "user without access cannot find the offer",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[4]
+		},
+	}, {
+		about: "search by interface",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		filter:         jimm.OfferDirectoryFilter{Interface: "mysql"},
+		expectedAccess: jujuparams.OfferConsumeAccess,
+	}, {
+		about: "search by unknown interface",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		filter: jimm.OfferDirectoryFilter{Interface: "postgresql"},
+	}, {
+		about: "search by application name",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		filter:         jimm.OfferDirectoryFilter{ApplicationName: "test-app"},
+		expectedAccess: jujuparams.OfferConsumeAccess,
+	}, {
+		about: "search by owner",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		filter:         jimm.OfferDirectoryFilter{OwnerName: "alice@canonical.com"},
+		expectedAccess: jujuparams.OfferConsumeAccess,
+	}, {
+		about: "search by another owner",
+		user: func(env *environment) dbmodel.Identity {
+			return env.users[2]
+		},
+		filter: jimm.OfferDirectoryFilter{OwnerName: "bob@canonical.com"},
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			db := db.Database{
+				DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+			}
+			err := db.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.about)
+			c.Assert(err, qt.IsNil)
+
+			jimmUUID := uuid.NewString()
+			env := initializeEnvironment(c, ctx, &db, client, jimmUUID)
+
+			offer := env.applicationOffers[0]
+			offer.Endpoints = []dbmodel.ApplicationOfferRemoteEndpoint{{
+				Name:      "db",
+				Role:      "provider",
+				Interface: "mysql",
+			}, {
+				Name:      "db-admin",
+				Role:      "provider",
+				Interface: "mysql",
+			}}
+			err = db.UpdateApplicationOffer(ctx, &offer)
+			c.Assert(err, qt.IsNil)
+
+			j := &jimm.JIMM{
+				UUID:          jimmUUID,
+				Database:      db,
+				OpenFGAClient: client,
+			}
+
+			user := test.user(env)
+			entries, err := j.SearchOffers(ctx, openfga.NewUser(&user, client), test.filter)
+			c.Assert(err, qt.IsNil)
+			if test.expectedAccess == "" {
+				c.Check(entries, qt.HasLen, 0)
+				return
+			}
+			c.Assert(entries, qt.HasLen, 1)
+			c.Check(entries[0].Offer.URL, qt.Equals, "test-offer-url")
+			c.Check(entries[0].Offer.Model.Name, qt.Equals, "test-model")
+			c.Check(entries[0].Offer.Model.Controller.Name, qt.Equals, "test-controller-1")
+			c.Check(entries[0].Offer.Endpoints, qt.HasLen, 2)
+			c.Check(entries[0].Access, qt.Equals, test.expectedAccess)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// A coalescingPublisher is a Publisher that holds each message for a
+// short window before publishing it. Any further messages for the same
+// model received during the window replace the held message, so that a
+// burst of updates results in a single publication of the latest one.
+type coalescingPublisher struct {
+	publisher Publisher
+	window    time.Duration
+
+	mu      sync.Mutex
+	pending map[string]interface{}
+	timers  map[string]*time.Timer
+}
+
+func newCoalescingPublisher(p Publisher, window time.Duration) *coalescingPublisher {
+	return &coalescingPublisher{
+		publisher: p,
+		window:    window,
+		pending:   make(map[string]interface{}),
+		timers:    make(map[string]*time.Timer),
+	}
+}
+
+// Publish implements Publisher. The returned channel is closed once the
+// message has been accepted, which is before it is published.
+func (p *coalescingPublisher) Publish(model string, content interface{}) <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending[model] = content
+	if _, ok := p.timers[model]; !ok {
+		p.timers[model] = time.AfterFunc(p.window, func() {
+			p.flush(model)
+		})
+	}
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (p *coalescingPublisher) flush(model string) {
+	p.mu.Lock()
+	content, ok := p.pending[model]
+	delete(p.pending, model)
+	delete(p.timers, model)
+	p.mu.Unlock()
+	if ok {
+		p.publisher.Publish(model, content)
+	}
+}
+
+// Flush immediately publishes all held messages.
+func (p *coalescingPublisher) Flush() {
+	p.mu.Lock()
+	models := make([]string, 0, len(p.timers))
+	for model, t := range p.timers {
+		t.Stop()
+		models = append(models, model)
+	}
+	p.mu.Unlock()
+	for _, model := range models {
+		p.flush(model)
+	}
+}
+
+// A changedPublisher is a Publisher that only publishes a message if it
+// differs from the last message published for the same model. Controllers
+// resend the summaries of models that have not changed, publishing them
+// again would wake every subscribed model summary watcher for no reason.
+type changedPublisher struct {
+	publisher Publisher
+
+	mu   sync.Mutex
+	last map[string]interface{}
+}
+
+func newChangedPublisher(p Publisher) *changedPublisher {
+	return &changedPublisher{
+		publisher: p,
+		last:      make(map[string]interface{}),
+	}
+}
+
+// Publish implements Publisher. If the message is unchanged the returned
+// channel is already closed.
+func (p *changedPublisher) Publish(model string, content interface{}) <-chan struct{} {
+	p.mu.Lock()
+	last, ok := p.last[model]
+	if ok && reflect.DeepEqual(last, content) {
+		p.mu.Unlock()
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	p.last[model] = content
+	p.mu.Unlock()
+	return p.publisher.Publish(model, content)
+}
+
+// Forget removes the last message published for the given model, so
+// that the next message for the model is always published. It is called
+// when a model is removed so that the publisher does not hold messages
+// for models that no longer exist.
+func (p *changedPublisher) Forget(model string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.last, model)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+func TestCoalescingPublisher(t *testing.T) {
+	c := qt.New(t)
+
+	publisher := &testPublisher{}
+	p := jimm.NewCoalescingPublisher(publisher, 50*time.Millisecond)
+	for i := 0; i < 10; i++ {
+		<-p.Publish("model-1", i)
+	}
+	<-p.Publish("model-2", "a")
+
+	publisher.mu.Lock()
+	c.Check(publisher.messages, qt.HasLen, 0)
+	publisher.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		publisher.mu.Lock()
+		n := len(publisher.messages)
+		publisher.mu.Unlock()
+		if n == 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	publisher.mu.Lock()
+	c.Check(publisher.messages, qt.ContentEquals, []interface{}{9, "a"})
+	publisher.mu.Unlock()
+
+	<-p.Publish("model-1", 10)
+	p.Flush()
+	publisher.mu.Lock()
+	c.Check(publisher.messages, qt.ContentEquals, []interface{}{9, "a", 10})
+	publisher.mu.Unlock()
+}
+
+func TestChangedPublisher(t *testing.T) {
+	c := qt.New(t)
+
+	publisher := &testPublisher{}
+	p := jimm.NewChangedPublisher(publisher)
+	<-p.Publish("model-1", []string{"a"})
+	<-p.Publish("model-1", []string{"a"})
+	<-p.Publish("model-2", []string{"a"})
+	<-p.Publish("model-1", []string{"b"})
+	<-p.Publish("model-1", []string{"a"})
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	c.Check(publisher.messages, qt.DeepEquals, []interface{}{[]string{"a"}, []string{"a"}, []string{"b"}, []string{"a"}})
+}
+
+func TestChangedPublisherForget(t *testing.T) {
+	c := qt.New(t)
+
+	publisher := &testPublisher{}
+	p := jimm.NewChangedPublisher(publisher)
+	<-p.Publish("model-1", []string{"a"})
+	p.Forget("model-1")
+	<-p.Publish("model-1", []string{"a"})
+	<-p.Publish("model-1", []string{"a"})
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	c.Check(publisher.messages, qt.DeepEquals, []interface{}{[]string{"a"}, []string{"a"}})
+}
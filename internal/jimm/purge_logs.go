@@ -11,13 +11,17 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // PurgeLogs removes all audit logs before the given timestamp. Only JIMM
 // administrators can perform this operation. The number of logs purged is
 // returned.
-func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (int64, error) {
+func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (_ int64, err error) {
 	op := errors.Op("jimm.PurgeLogs")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
 	if !user.JimmAdmin {
 		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
@@ -0,0 +1,292 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// reconcilePageSize is the number of tuples read from OpenFGA at a time
+// when reconciling.
+const reconcilePageSize = 100
+
+// A ReconcileReport describes the differences found between JIMM's
+// database and the tuples stored in OpenFGA.
+type ReconcileReport struct {
+	// Missing holds the tuples implied by the database that were not
+	// found in OpenFGA, such as a model's owner.
+	Missing []openfga.Tuple
+
+	// Orphaned holds the tuples that refer to a model, controller,
+	// cloud or group that is not in the database. Tuples referring to
+	// users are never orphaned as access may be granted to a user
+	// before they first log in.
+	Orphaned []openfga.Tuple
+
+	// Repaired is true if the missing tuples were added and the
+	// orphaned tuples removed.
+	Repaired bool
+}
+
+// Reconcile compares the models, controllers, clouds and groups in the
+// database with the tuples in OpenFGA. If repair is true any missing
+// tuples are added and any orphaned tuples are removed, otherwise the
+// differences are only reported. Only JIMM administrators may reconcile.
+func (j *JIMM) Reconcile(ctx context.Context, user *openfga.User, repair bool) (_ *ReconcileReport, err error) {
+	const op = errors.Op("jimm.Reconcile")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	report, err := j.reconcile(ctx, repair)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return report, nil
+}
+
+func (j *JIMM) reconcile(ctx context.Context, repair bool) (*ReconcileReport, error) {
+	expected, known, err := j.expectedTuples(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var report ReconcileReport
+	var ct string
+	for {
+		tuples, next, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{}, reconcilePageSize, ct)
+		if err != nil {
+			return nil, errors.E(errors.CodeOpenFGARequestFailed, err)
+		}
+		for _, t := range tuples {
+			delete(expected, tupleKey(t))
+			if !known.contains(t.Object) || !known.contains(t.Target) {
+				report.Orphaned = append(report.Orphaned, t)
+			}
+		}
+		if next == "" {
+			break
+		}
+		ct = next
+	}
+	for _, t := range expected {
+		report.Missing = append(report.Missing, t)
+	}
+	if !repair || (len(report.Missing) == 0 && len(report.Orphaned) == 0) {
+		return &report, nil
+	}
+
+	for _, ts := range chunkTuples(report.Missing, reconcilePageSize) {
+		if err := j.OpenFGAClient.AddRelation(ctx, ts...); err != nil {
+			return &report, errors.E(errors.CodeOpenFGARequestFailed, err)
+		}
+	}
+	orphaned := make([]openfga.Tuple, len(report.Orphaned))
+	for i, t := range report.Orphaned {
+		if t.Object.Kind == openfga.UserType && t.Object.ID == ofganames.EveryoneUser {
+			// Tuples are returned with the everyone user in place of
+			// the wildcard, which must be restored before removal.
+			t.Object = ofganames.ConvertTagWithRelation(names.NewUserTag(ofganames.EveryoneUser), t.Object.Relation)
+		}
+		orphaned[i] = t
+	}
+	for _, ts := range chunkTuples(orphaned, reconcilePageSize) {
+		if err := j.OpenFGAClient.RemoveRelation(ctx, ts...); err != nil {
+			return &report, errors.E(errors.CodeOpenFGARequestFailed, err)
+		}
+	}
+	report.Repaired = true
+	j.notifyAccessChanged(AccessChange{})
+	return &report, nil
+}
+
+// knownEntities holds the IDs of the entities of each kind that are in
+// the database.
+type knownEntities map[string]map[string]bool
+
+func (k knownEntities) add(kind, id string) {
+	if k[kind] == nil {
+		k[kind] = make(map[string]bool)
+	}
+	k[kind][id] = true
+}
+
+// contains reports whether the given entity is known. Only the kinds of
+// entity that are reconciled are ever unknown.
+func (k knownEntities) contains(e *ofganames.Tag) bool {
+	ids, ok := k[string(e.Kind)]
+	if !ok {
+		return true
+	}
+	return ids[e.ID]
+}
+
+// expectedTuples returns the tuples implied by the contents of the
+// database, keyed by tupleKey, and the entities that exist.
+func (j *JIMM) expectedTuples(ctx context.Context) (map[string]openfga.Tuple, knownEntities, error) {
+	expected := make(map[string]openfga.Tuple)
+	add := func(t openfga.Tuple) {
+		expected[tupleKey(t)] = t
+	}
+	known := knownEntities{
+		names.ModelTagKind:      {},
+		names.ControllerTagKind: {j.UUID: true},
+		names.CloudTagKind:      {},
+		jimmnames.GroupTagKind:  {},
+	}
+	jimmTag := j.ResourceTag()
+
+	err := j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		known.add(names.ControllerTagKind, ctl.UUID)
+		add(openfga.Tuple{
+			Object:   ofganames.ConvertTag(jimmTag),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(ctl.ResourceTag()),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clouds, err := j.Database.GetClouds(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, cloud := range clouds {
+		known.add(names.CloudTagKind, cloud.Name)
+		for _, r := range cloud.Regions {
+			for _, rc := range r.Controllers {
+				add(openfga.Tuple{
+					Object:   ofganames.ConvertTag(rc.Controller.ResourceTag()),
+					Relation: ofganames.ControllerRelation,
+					Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+				})
+			}
+		}
+	}
+
+	err = j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
+		if !m.UUID.Valid {
+			// The model is still being created.
+			return nil
+		}
+		known.add(names.ModelTagKind, m.UUID.String)
+		mt := m.ResourceTag()
+		add(openfga.Tuple{
+			Object:   ofganames.ConvertTag(m.Controller.ResourceTag()),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(mt),
+		})
+		add(openfga.Tuple{
+			Object:   ofganames.ConvertTag(m.Owner.ResourceTag()),
+			Relation: ofganames.AdministratorRelation,
+			Target:   ofganames.ConvertTag(mt),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	const groupPageSize = 100
+	for offset := 0; ; offset += groupPageSize {
+		var n int
+		err := j.Database.ForEachGroup(ctx, groupPageSize, offset, func(g *dbmodel.GroupEntry) error {
+			known.add(jimmnames.GroupTagKind, g.UUID)
+			n++
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if n < groupPageSize {
+			break
+		}
+	}
+	return expected, known, nil
+}
+
+// chunkTuples splits tuples into slices of at most n tuples, so that
+// each write to OpenFGA stays within its request limits.
+func chunkTuples(tuples []openfga.Tuple, n int) [][]openfga.Tuple {
+	var chunks [][]openfga.Tuple
+	for len(tuples) > n {
+		chunks = append(chunks, tuples[:n])
+		tuples = tuples[n:]
+	}
+	if len(tuples) > 0 {
+		chunks = append(chunks, tuples)
+	}
+	return chunks
+}
+
+// tupleKey returns a string uniquely identifying the given tuple.
+func tupleKey(t openfga.Tuple) string {
+	return t.Object.String() + " " + string(t.Relation) + " " + t.Target.String()
+}
+
+// reconcileService periodically reconciles the database with OpenFGA.
+type reconcileService struct {
+	jimm     *JIMM
+	interval time.Duration
+	repair   bool
+}
+
+// NewReconcileService returns a service that reconciles the database
+// with OpenFGA every interval. If repair is false the differences are
+// only logged.
+func NewReconcileService(j *JIMM, interval time.Duration, repair bool) *reconcileService {
+	return &reconcileService{
+		jimm:     j,
+		interval: interval,
+		repair:   repair,
+	}
+}
+
+// Start starts a routine which periodically reconciles the database with
+// OpenFGA.
+func (s *reconcileService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *reconcileService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			report, err := s.jimm.reconcile(ctx, s.repair)
+			if err != nil {
+				zapctx.Error(ctx, "failed to reconcile database with OpenFGA", zap.Error(err))
+				continue
+			}
+			if len(report.Missing) == 0 && len(report.Orphaned) == 0 {
+				continue
+			}
+			zapctx.Warn(ctx, "database and OpenFGA differ",
+				zap.Int("missing", len(report.Missing)),
+				zap.Int("orphaned", len(report.Orphaned)),
+				zap.Bool("repaired", report.Repaired),
+			)
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting reconcile polling")
+			return
+		}
+	}
+}
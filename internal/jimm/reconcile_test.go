@@ -0,0 +1,94 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestReconcile(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, controller, model, _, cloud, _ := createTestControllerEnvironment(ctx, c, j.Database)
+
+	orphan := openfga.Tuple{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(uuid.NewString())),
+	}
+	kept := openfga.Tuple{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}
+	err = ofgaClient.AddRelation(ctx, orphan, kept)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&user, ofgaClient)
+	_, err = j.Reconcile(ctx, u, false)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	report, err := j.Reconcile(ctx, u, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(report.Repaired, qt.IsFalse)
+	c.Check(report.Orphaned, qt.DeepEquals, []openfga.Tuple{orphan})
+	c.Check(report.Missing, qt.ContentEquals, []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(j.ResourceTag()),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(controller.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(controller.ResourceTag()),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(cloud.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(controller.ResourceTag()),
+		Relation: ofganames.ControllerRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}, {
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}})
+
+	report, err = j.Reconcile(ctx, u, true)
+	c.Assert(err, qt.IsNil)
+	c.Check(report.Repaired, qt.IsTrue)
+
+	report, err = j.Reconcile(ctx, u, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(report.Missing, qt.HasLen, 0)
+	c.Check(report.Orphaned, qt.HasLen, 0)
+
+	allowed, err := ofgaClient.CheckRelation(ctx, kept, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+}
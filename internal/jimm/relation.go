@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
@@ -13,13 +14,16 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 // AddRelation checks user permission and add given relations tuples.
 // At the moment user is required be admin.
-func (j *JIMM) AddRelation(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error {
+func (j *JIMM) AddRelation(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) (err error) {
 	const op = errors.Op("jimm.AddRelation")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
@@ -31,13 +35,16 @@ func (j *JIMM) AddRelation(ctx context.Context, user *openfga.User, tuples []api
 	if err != nil {
 		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
 	}
+	j.notifyAccessChanged(AccessChange{})
 	return nil
 }
 
 // RemoveRelation checks user permission and remove given relations tuples.
 // At the moment user is required be admin.
-func (j *JIMM) RemoveRelation(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error {
+func (j *JIMM) RemoveRelation(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) (err error) {
 	const op = errors.Op("jimm.RemoveRelation")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if !user.JimmAdmin {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
@@ -49,6 +56,7 @@ func (j *JIMM) RemoveRelation(ctx context.Context, user *openfga.User, tuples []
 	if err != nil {
 		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
 	}
+	j.notifyAccessChanged(AccessChange{})
 	return nil
 }
 
@@ -56,6 +64,8 @@ func (j *JIMM) RemoveRelation(ctx context.Context, user *openfga.User, tuples []
 // At the moment user is required be admin or checking its own relations
 func (j *JIMM) CheckRelation(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, trace bool) (_ bool, err error) {
 	const op = errors.Op("jimm.CheckRelation")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	allowed := false
 	parsedTuple, err := j.parseTuple(ctx, tuple)
 	if err != nil {
@@ -76,14 +86,15 @@ func (j *JIMM) CheckRelation(ctx context.Context, user *openfga.User, tuple apip
 
 // ListRelationshipTuples checks user permission and lists relationship tuples based of tuple struct with pagination.
 // Listing filters can be relaxed: optionally exclude tuple.Relation or tuple.Object or specify only tuple.TargetObject.Kind.
-func (j *JIMM) ListRelationshipTuples(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
+func (j *JIMM) ListRelationshipTuples(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) (_ []openfga.Tuple, _ string, err error) {
 	const op = errors.Op("jimm.ListRelationshipTuples")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	if !user.JimmAdmin {
 		return nil, "", errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 	// if targetObject is not specified returns all tuples.
 	parsedTuple := &openfga.Tuple{}
-	var err error
 	if tuple.TargetObject != "" {
 		parsedTuple, err = j.parseTuple(ctx, tuple)
 		if err != nil {
@@ -104,8 +115,10 @@ func (j *JIMM) ListRelationshipTuples(ctx context.Context, user *openfga.User, t
 // Useful for listing all the resources that a group or user have access to.
 //
 // This functions provides a slightly higher-level abstraction in favor of ListRelationshipTuples.
-func (j *JIMM) ListObjectRelations(ctx context.Context, user *openfga.User, object string, pageSize int32, entitlementToken pagination.EntitlementToken) ([]openfga.Tuple, pagination.EntitlementToken, error) {
+func (j *JIMM) ListObjectRelations(ctx context.Context, user *openfga.User, object string, pageSize int32, entitlementToken pagination.EntitlementToken) (_ []openfga.Tuple, _ pagination.EntitlementToken, err error) {
 	const op = errors.Op("jimm.ListObjectRelations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	var e pagination.EntitlementToken
 	if !user.JimmAdmin {
 		return nil, e, errors.E(op, errors.CodeUnauthorized, "unauthorized")
@@ -135,6 +148,72 @@ func (j *JIMM) ListObjectRelations(ctx context.Context, user *openfga.User, obje
 	return responseTuples, nextEntitlementToken, nil
 }
 
+// ExportRelations returns a page of the relation tuples stored in
+// OpenFGA, in the form stored. Tuples granting public access are
+// returned with the everyone user as the object. The returned
+// continuation token is used to fetch the next page, it is empty once
+// every tuple has been returned. Only JIMM administrators may export
+// relations.
+func (j *JIMM) ExportRelations(ctx context.Context, user *openfga.User, pageSize int32, continuationToken string) (_ []openfga.Tuple, _ string, err error) {
+	const op = errors.Op("jimm.ExportRelations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !user.JimmAdmin {
+		return nil, "", errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	tuples, ct, err := j.OpenFGAClient.ReadRelatedObjects(ctx, openfga.Tuple{}, pageSize, continuationToken)
+	if err != nil {
+		return nil, "", errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	return tuples, ct, nil
+}
+
+// ImportRelations adds the given relation tuples, as returned by
+// ExportRelations, to OpenFGA. Tuples that already exist are left
+// unchanged. The number of tuples added and the number that already
+// existed are returned. Only JIMM administrators may import relations.
+func (j *JIMM) ImportRelations(ctx context.Context, user *openfga.User, tuples []openfga.Tuple) (added, existing int, err error) {
+	const op = errors.Op("jimm.ImportRelations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !user.JimmAdmin {
+		return 0, 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	present := make(map[string]bool)
+	all, err := j.readAllTuples(ctx)
+	if err != nil {
+		return 0, 0, errors.E(op, err)
+	}
+	for _, t := range all {
+		present[tupleKey(t)] = true
+	}
+	var missing []openfga.Tuple
+	for _, t := range tuples {
+		if t.Object == nil || t.Target == nil || t.Relation == "" {
+			return 0, 0, errors.E(op, errors.CodeBadRequest, "incomplete relation tuple")
+		}
+		if present[tupleKey(t)] {
+			existing++
+			continue
+		}
+		present[tupleKey(t)] = true
+		if t.Object.Kind == openfga.UserType && t.Object.ID == ofganames.EveryoneUser {
+			// Tuples are exported with the everyone user in place of
+			// the wildcard, which must be restored before writing.
+			t.Object = ofganames.ConvertTagWithRelation(names.NewUserTag(ofganames.EveryoneUser), t.Object.Relation)
+		}
+		missing = append(missing, t)
+	}
+	for _, ts := range chunkTuples(missing, reconcilePageSize) {
+		if err := j.OpenFGAClient.AddRelation(ctx, ts...); err != nil {
+			return added, existing, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		added += len(ts)
+		j.notifyAccessChanged(AccessChange{})
+	}
+	return added, existing, nil
+}
+
 // parseTuples translate the api request struct containing tuples to a slice of openfga tuple keys.
 // This method utilises the parseTuple method which does all the heavy lifting.
 func (j *JIMM) parseTuples(ctx context.Context, tuples []apiparams.RelationshipTuple) ([]openfga.Tuple, error) {
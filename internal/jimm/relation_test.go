@@ -17,6 +17,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimmtest"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	"github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/pubsub"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
@@ -267,3 +268,159 @@ func TestListObjectRelations(t *testing.T) {
 		})
 	}
 }
+
+func TestTemporaryRelations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	u.JimmAdmin = true
+
+	user, _, _, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	tuple := apiparams.RelationshipTuple{
+		Object:       user.Tag().String(),
+		Relation:     names.ReaderRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}
+
+	nonAdmin := openfga.NewUser(&user, ofgaClient)
+	_, err = j.AddTemporaryRelation(ctx, nonAdmin, tuple, time.Hour)
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	_, err = j.AddTemporaryRelation(ctx, u, tuple, 0)
+	c.Assert(err, qt.ErrorMatches, "duration must be positive")
+
+	grant, err := j.AddTemporaryRelation(ctx, u, tuple, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Check(grant.Tuple(), qt.DeepEquals, tuple)
+	c.Check(grant.GrantedBy, qt.Equals, "admin@canonical.com")
+
+	allowed, err := j.CheckRelation(ctx, u, tuple, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+
+	_, err = j.AddTemporaryRelation(ctx, u, tuple, time.Hour)
+	c.Assert(err, qt.ErrorMatches, "relation already exists")
+
+	grants, err := j.ListTemporaryRelations(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Assert(grants, qt.HasLen, 1)
+	c.Check(grants[0].ID, qt.Equals, grant.ID)
+
+	removed, err := j.RemoveExpiredTemporaryRelations(ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Check(removed, qt.Equals, 0)
+
+	changes := make(chan jimm.AccessChange, 1)
+	j.Pubsub = &pubsub.Hub{MaxConcurrency: 10}
+	unsubscribe, err := j.Pubsub.Subscribe(jimm.AccessChangedTopic, func(_ string, msg interface{}) {
+		changes <- msg.(jimm.AccessChange)
+	})
+	c.Assert(err, qt.IsNil)
+	defer unsubscribe()
+
+	removed, err = j.RemoveExpiredTemporaryRelations(ctx, time.Now().Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(removed, qt.Equals, 1)
+
+	select {
+	case change := <-changes:
+		c.Check(change, qt.DeepEquals, jimm.AccessChange{Identity: user.Name, Model: model.UUID.String})
+	case <-time.After(time.Second):
+		c.Fatalf("access change not published")
+	}
+
+	allowed, err = j.CheckRelation(ctx, u, tuple, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	grants, err = j.ListTemporaryRelations(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Check(grants, qt.HasLen, 0)
+}
+
+func TestExportImportRelations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	u.JimmAdmin = true
+
+	user, _, _, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	err = j.AddRelation(ctx, u, []apiparams.RelationshipTuple{{
+		Object:       user.Tag().String(),
+		Relation:     names.ReaderRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}, {
+		Object:       "user-" + names.EveryoneUser,
+		Relation:     names.ReaderRelation.String(),
+		TargetObject: model.ResourceTag().String(),
+	}})
+	c.Assert(err, qt.IsNil)
+
+	_, _, err = j.ExportRelations(ctx, openfga.NewUser(&user, ofgaClient), 10, "")
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	var exported []openfga.Tuple
+	var ct string
+	for {
+		tuples, next, err := j.ExportRelations(ctx, u, 1, ct)
+		c.Assert(err, qt.IsNil)
+		exported = append(exported, tuples...)
+		if next == "" {
+			break
+		}
+		ct = next
+	}
+	c.Assert(exported, qt.HasLen, 2)
+
+	// Import the tuples into an empty authorisation model.
+	ofgaClient2, _, _, err := jimmtest.SetupTestOFGAClient(c.Name() + "-import")
+	c.Assert(err, qt.IsNil)
+	j2 := &jimm.JIMM{
+		UUID:          j.UUID,
+		Database:      j.Database,
+		OpenFGAClient: ofgaClient2,
+	}
+	added, existing, err := j2.ImportRelations(ctx, u, exported)
+	c.Assert(err, qt.IsNil)
+	c.Check(added, qt.Equals, 2)
+	c.Check(existing, qt.Equals, 0)
+
+	allowed, err := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, ofgaClient2).IsModelReader(ctx, model.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+
+	added, existing, err = j2.ImportRelations(ctx, u, exported)
+	c.Assert(err, qt.IsNil)
+	c.Check(added, qt.Equals, 0)
+	c.Check(existing, qt.Equals, 2)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// A SelfTestResult holds the result of one of the checks performed by
+// ServiceSelfTest.
+type SelfTestResult struct {
+	// Name identifies the check. Controller checks are named
+	// "controller/<controller name>".
+	Name string
+
+	// Duration holds the time taken by the check.
+	Duration time.Duration
+
+	// Error holds the error that caused the check to fail, it is nil
+	// if the check passed.
+	Error error
+}
+
+// ServiceSelfTest exercises each of the services JIMM depends on and
+// returns the result of every check. The database is checked by writing
+// an audit log entry recording the self test and reading it back,
+// OpenFGA by checking that the user is a JIMM administrator, each known
+// controller is dialed and pinged and the OAuth authenticator by minting
+// and verifying a session token. A failed check does not prevent the
+// remaining checks from being run. Only JIMM administrators may run the
+// self test.
+func (j *JIMM) ServiceSelfTest(ctx context.Context, user *openfga.User) (_ []SelfTestResult, err error) {
+	const op = errors.Op("jimm.ServiceSelfTest")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var results []SelfTestResult
+	run := func(name string, f func(context.Context) error) {
+		start := time.Now()
+		err := f(ctx)
+		results = append(results, SelfTestResult{
+			Name:     name,
+			Duration: time.Since(start),
+			Error:    err,
+		})
+	}
+
+	run("database", func(ctx context.Context) error {
+		return j.selfTestDatabase(ctx, user)
+	})
+	run("openfga", func(ctx context.Context) error {
+		ok, err := openfga.IsAdministrator(ctx, user, j.ResourceTag())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.E("administrator check returned false for a JIMM administrator")
+		}
+		return nil
+	})
+	var controllers []dbmodel.Controller
+	err = j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		controllers = append(controllers, *ctl)
+		return nil
+	})
+	if err != nil {
+		results = append(results, SelfTestResult{Name: "controllers", Error: err})
+	}
+	for i := range controllers {
+		ctl := &controllers[i]
+		run("controller/"+ctl.Name, func(ctx context.Context) error {
+			api, err := j.dial(ctx, ctl, names.ModelTag{})
+			if err != nil {
+				return err
+			}
+			defer api.Close()
+			return api.Ping(ctx)
+		})
+	}
+	run("oauth", func(ctx context.Context) error {
+		if j.OAuthAuthenticator == nil {
+			return errors.E("no OAuth authenticator configured")
+		}
+		token, err := j.OAuthAuthenticator.MintSessionToken(user.Name)
+		if err != nil {
+			return err
+		}
+		t, err := j.OAuthAuthenticator.VerifySessionToken(token)
+		if err != nil {
+			return err
+		}
+		if t.Subject() != user.Name {
+			return errors.E("verified session token has the wrong subject")
+		}
+		return nil
+	})
+	return results, nil
+}
+
+// selfTestDatabase writes an audit log entry recording the self test and
+// reads it back.
+func (j *JIMM) selfTestDatabase(ctx context.Context, user *openfga.User) error {
+	ale := dbmodel.AuditLogEntry{
+		Time:           time.Now().UTC().Round(time.Millisecond),
+		ConversationId: uuid.NewString(),
+		FacadeName:     "JIMM",
+		FacadeMethod:   "ServiceSelfTest",
+		IdentityTag:    user.Tag().String(),
+		Params:         dbmodel.JSON("{}"),
+	}
+	if err := j.Database.AddAuditLogEntry(ctx, &ale); err != nil {
+		return err
+	}
+	found := false
+	err := j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{ConversationId: ale.ConversationId}, func(e *dbmodel.AuditLogEntry) error {
+		found = e.ID == ale.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.E("audit log entry written by the self test could not be read")
+	}
+	return nil
+}
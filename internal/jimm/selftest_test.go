@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+func TestServiceSelfTest(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	authenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	dialer := &jimmtest.Dialer{
+		API: &jimmtest.API{
+			Ping_: func(context.Context) error { return nil },
+		},
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient:      ofgaClient,
+		OAuthAuthenticator: &authenticator,
+		Dialer:             dialer,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	_, _, controller, _, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+
+	identity, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.DB.Create(identity).Error, qt.IsNil)
+	u := openfga.NewUser(identity, ofgaClient)
+
+	_, err = j.ServiceSelfTest(ctx, u)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(identity.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(j.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+	u.JimmAdmin = true
+
+	results, err := j.ServiceSelfTest(ctx, u)
+	c.Assert(err, qt.IsNil)
+	var names []string
+	for _, r := range results {
+		c.Check(r.Error, qt.IsNil, qt.Commentf("check %s", r.Name))
+		names = append(names, r.Name)
+	}
+	c.Check(names, qt.DeepEquals, []string{"database", "openfga", "controller/" + controller.Name, "oauth"})
+
+	// A failing check does not stop the others from running.
+	dialer.Err = errors.E(errors.CodeConnectionFailed, "no route to controller")
+	results, err = j.ServiceSelfTest(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 4)
+	c.Check(results[2].Error, qt.ErrorMatches, `no route to controller`)
+	c.Check(results[3].Error, qt.IsNil)
+}
@@ -4,23 +4,33 @@ package jimm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
 	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
 
 // AddServiceAccount checks that no one owns the service account yet
 // and then adds a relation between the logged in user and the service account.
-func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId string) error {
+// The user is recorded as the service account's creator, along with the
+// given purpose.
+func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId, purpose string) (err error) {
 	op := errors.Op("jimm.AddServiceAccount")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	svcTag := jimmnames.NewServiceAccountTag(clientId)
 	key := openfga.Tuple{
@@ -47,6 +57,30 @@ func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId
 	if len(tuples) > 0 {
 		return errors.E(op, "service account already owned")
 	}
+
+	identity, err := dbmodel.NewIdentity(clientId)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.GetIdentity(ctx, identity); err != nil {
+		return errors.E(op, err)
+	}
+	if identity.Disabled {
+		// The service account was previously deleted.
+		identity.Disabled = false
+		if err := j.Database.UpdateIdentity(ctx, identity); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	err = j.Database.AddServiceAccount(ctx, &dbmodel.ServiceAccount{
+		IdentityName:        identity.Name,
+		CreatorIdentityName: u.Name,
+		Purpose:             purpose,
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
 	addTuple := openfga.Tuple{
 		Object:   ofganames.ConvertTag(u.ResourceTag()),
 		Relation: ofganames.AdministratorRelation,
@@ -59,10 +93,151 @@ func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId
 	return nil
 }
 
+// ListServiceAccounts returns the service accounts the given user
+// administers. JIMM administrators see every service account.
+func (j *JIMM) ListServiceAccounts(ctx context.Context, u *openfga.User) (_ []dbmodel.ServiceAccount, err error) {
+	const op = errors.Op("jimm.ListServiceAccounts")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	sas, err := j.Database.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if u.JimmAdmin {
+		return sas, nil
+	}
+	var administered []dbmodel.ServiceAccount
+	for _, sa := range sas {
+		if !jimmnames.IsValidServiceAccountId(sa.IdentityName) {
+			continue
+		}
+		ok, err := u.IsServiceAccountAdmin(ctx, jimmnames.NewServiceAccountTag(sa.IdentityName))
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if ok {
+			administered = append(administered, sa)
+		}
+	}
+	return administered, nil
+}
+
+// SetServiceAccountDisabled disables, or re-enables, the given service
+// account. Disabled service accounts cannot authenticate. The user must
+// administer the service account, or be a JIMM administrator.
+func (j *JIMM) SetServiceAccountDisabled(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, disabled bool) (err error) {
+	const op = errors.Op("jimm.SetServiceAccountDisabled")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkServiceAccountAdmin(ctx, u, svcAccTag); err != nil {
+		return errors.E(op, err)
+	}
+
+	sa := dbmodel.ServiceAccount{IdentityName: svcAccTag.Id()}
+	if err := j.Database.GetServiceAccount(ctx, &sa); err != nil {
+		return errors.E(op, err)
+	}
+	sa.Identity.Disabled = disabled
+	if err := j.Database.UpdateIdentity(ctx, &sa.Identity); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// DeleteServiceAccount removes a service account from JIMM. The service
+// account's cloud credentials are revoked, all OpenFGA relations granting
+// it access, or granting access to administer it, are removed and the
+// service account is disabled so that it can no longer authenticate. The
+// service account must not own any models. Its identity is kept so that
+// audit records continue to refer to it. The user must administer the
+// service account, or be a JIMM administrator.
+func (j *JIMM) DeleteServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) (err error) {
+	const op = errors.Op("jimm.DeleteServiceAccount")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkServiceAccountAdmin(ctx, u, svcAccTag); err != nil {
+		return errors.E(op, err)
+	}
+
+	sa := dbmodel.ServiceAccount{IdentityName: svcAccTag.Id()}
+	if err := j.Database.GetServiceAccount(ctx, &sa); err != nil {
+		return errors.E(op, err)
+	}
+	identity := &sa.Identity
+
+	var owned int
+	err = j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
+		if m.OwnerIdentityName == identity.Name {
+			owned++
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if owned > 0 {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("service account owns %d model(s)", owned))
+	}
+
+	var args []RevokeCloudCredentialArgs
+	err = j.Database.ForEachCloudCredential(ctx, identity.Name, "", func(cred *dbmodel.CloudCredential) error {
+		args = append(args, RevokeCloudCredentialArgs{Tag: cred.ResourceTag()})
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for _, err := range j.RevokeCloudCredentials(ctx, identity, args) {
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
+	defer j.notifyAccessChanged(AccessChange{Identity: identity.Name})
+	if _, err := j.OpenFGAClient.RemoveUser(ctx, identity.ResourceTag()); err != nil {
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	if err := j.OpenFGAClient.RemoveServiceAccount(ctx, svcAccTag); err != nil {
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+
+	identity.Disabled = true
+	identity.AllowedCIDRs = nil
+	if err := j.Database.UpdateIdentity(ctx, identity); err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.DeleteServiceAccount(ctx, &sa); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// checkServiceAccountAdmin returns an error with a code of
+// CodeUnauthorized unless the user is a JIMM administrator or
+// administers the given service account.
+func (j *JIMM) checkServiceAccountAdmin(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+	if u.JimmAdmin {
+		return nil
+	}
+	ok, err := u.IsServiceAccountAdmin(ctx, svcAccTag)
+	if err != nil {
+		return errors.E(err)
+	}
+	if !ok {
+		return errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	return nil
+}
+
 // CopyServiceAccountCredential attempts to create a copy of a user's cloud-credential
 // for a service account.
-func (j *JIMM) CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cred names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error) {
+func (j *JIMM) CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cred names.CloudCredentialTag) (_ names.CloudCredentialTag, _ []jujuparams.UpdateCredentialModelResult, err error) {
 	op := errors.Op("jimm.AddServiceAccountCredential")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 
 	credential, err := j.GetCloudCredential(ctx, u, cred)
 	if err != nil {
@@ -93,8 +268,10 @@ func (j *JIMM) CopyServiceAccountCredential(ctx context.Context, u *openfga.User
 // GrantServiceAccountAccess creates an administrator relation between the tags provided
 // and the service account. The provided tags must be users or groups (with the member relation)
 // otherwise OpenFGA will report an error.
-func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, entities []string) error {
+func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, entities []string) (err error) {
 	op := errors.Op("jimm.GrantServiceAccountAccess")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	tags := make([]*ofganames.Tag, 0, len(entities))
 	// Validate tags
 	for _, val := range entities {
@@ -120,10 +297,111 @@ func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, s
 		}
 		tuples = append(tuples, tuple)
 	}
-	err := j.OpenFGAClient.AddRelation(ctx, tuples...)
+	err = j.OpenFGAClient.AddRelation(ctx, tuples...)
 	if err != nil {
 		zapctx.Error(ctx, "failed to add tuple(s)", zap.NamedError("add-relation-error", err))
 		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
 	}
 	return nil
 }
+
+// SetServiceAccountAllowedCIDRs sets the network ranges, in CIDR notation,
+// from which the service account may authenticate. An empty list removes
+// the restriction. The user must be a JIMM administrator.
+func (j *JIMM) SetServiceAccountAllowedCIDRs(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, cidrs []string) (err error) {
+	const op = errors.Op("jimm.SetServiceAccountAllowedCIDRs")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !u.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var allowed dbmodel.Strings
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid CIDR %q", cidr))
+		}
+		allowed = append(allowed, ipNet.String())
+	}
+
+	sa := dbmodel.ServiceAccount{IdentityName: svcAccTag.Id()}
+	if err := j.Database.GetServiceAccount(ctx, &sa); err != nil {
+		return errors.E(op, err)
+	}
+	sa.Identity.AllowedCIDRs = allowed
+	if err := j.Database.UpdateIdentity(ctx, &sa.Identity); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// checkServiceAccountLogin returns an error if the service account has
+// been disabled, or if it restricts the addresses it may authenticate
+// from and the client address held in the context is outside the allowed
+// ranges. Attempts from disallowed addresses are recorded in the audit
+// log.
+func (j *JIMM) checkServiceAccountLogin(ctx context.Context, clientID string) error {
+	const op = errors.Op("jimm.checkServiceAccountLogin")
+
+	identity, err := dbmodel.NewIdentity(clientID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.GetIdentity(ctx, identity); err != nil {
+		return errors.E(op, err)
+	}
+	if identity.Disabled {
+		return errors.E(op, errors.CodeUnauthorized, "service account is disabled")
+	}
+	if len(identity.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	remoteAddr := auth.RemoteAddrFromContext(ctx)
+	if addressAllowed(remoteAddr, identity.AllowedCIDRs) {
+		return nil
+	}
+
+	zapctx.Warn(ctx, "service account login from disallowed address", zap.String("client-id", clientID), zap.String("remote-addr", remoteAddr))
+	params, _ := json.Marshal(map[string]string{
+		"client-id":   clientID,
+		"remote-addr": remoteAddr,
+	})
+	errs, _ := json.Marshal(map[string]string{
+		"error": "address not allowed",
+	})
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "Admin",
+		FacadeMethod: "LoginWithClientCredentials",
+		IdentityTag:  identity.Tag().String(),
+		IsResponse:   true,
+		Params:       params,
+		Errors:       errs,
+	})
+	return errors.E(op, errors.CodeUnauthorized, "login from this address is not allowed")
+}
+
+// addressAllowed reports whether the host part of addr falls within any
+// of the given CIDR ranges.
+func addressAllowed(addr string, cidrs []string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmtest"
 	"github.com/canonical/jimm/v3/internal/openfga"
@@ -28,8 +29,12 @@ func TestAddServiceAccount(t *testing.T) {
 	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
 	c.Assert(err, qt.IsNil)
 	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
 		OpenFGAClient: client,
 	}
+	err = j.Database.Migrate(ctx, false)
 	c.Assert(err, qt.IsNil)
 
 	bob, err := dbmodel.NewIdentity("bob@canonical.com")
@@ -39,10 +44,16 @@ func TestAddServiceAccount(t *testing.T) {
 		client,
 	)
 	clientID := "39caae91-b914-41ae-83f8-c7b86ca5ad5a@serviceaccount"
-	err = j.AddServiceAccount(ctx, user, clientID)
+	err = j.AddServiceAccount(ctx, user, clientID, "ci")
 	c.Assert(err, qt.IsNil)
-	err = j.AddServiceAccount(ctx, user, clientID)
+	err = j.AddServiceAccount(ctx, user, clientID, "")
+	c.Assert(err, qt.IsNil)
+
+	sa := dbmodel.ServiceAccount{IdentityName: clientID}
+	err = j.Database.GetServiceAccount(ctx, &sa)
 	c.Assert(err, qt.IsNil)
+	c.Check(sa.CreatorIdentityName, qt.Equals, "bob@canonical.com")
+	c.Check(sa.Purpose, qt.Equals, "ci")
 
 	alive, err := dbmodel.NewIdentity("alive@canonical.com")
 	c.Assert(err, qt.IsNil)
@@ -50,10 +61,81 @@ func TestAddServiceAccount(t *testing.T) {
 		alive,
 		client,
 	)
-	err = j.AddServiceAccount(ctx, userAlice, clientID)
+	err = j.AddServiceAccount(ctx, userAlice, clientID, "")
 	c.Assert(err, qt.ErrorMatches, "service account already owned")
 }
 
+func TestServiceAccountLifecycle(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, nil)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient:      client,
+		OAuthAuthenticator: &mockAuthenticator,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	bobIdentity, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	bob := openfga.NewUser(bobIdentity, client)
+	aliceIdentity, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	alice := openfga.NewUser(aliceIdentity, client)
+
+	clientID := "39caae91-b914-41ae-83f8-c7b86ca5ad5a@serviceaccount"
+	svcAccTag := jimmnames.NewServiceAccountTag(clientID)
+	err = j.AddServiceAccount(ctx, bob, clientID, "ci")
+	c.Assert(err, qt.IsNil)
+
+	// Only the service account's administrators see it.
+	sas, err := j.ListServiceAccounts(ctx, bob)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sas, qt.HasLen, 1)
+	c.Check(sas[0].IdentityName, qt.Equals, clientID)
+	sas, err = j.ListServiceAccounts(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(sas, qt.HasLen, 0)
+
+	err = j.SetServiceAccountDisabled(ctx, alice, svcAccTag, true)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// A disabled service account cannot log in.
+	err = j.SetServiceAccountDisabled(ctx, bob, svcAccTag, true)
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginClientCredentials(ctx, clientID, "secret")
+	c.Check(err, qt.ErrorMatches, "service account is disabled")
+	err = j.SetServiceAccountDisabled(ctx, bob, svcAccTag, false)
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginClientCredentials(ctx, clientID, "secret")
+	c.Check(err, qt.IsNil)
+
+	// Deleting the service account removes its relations, so it may be
+	// added again by another user.
+	err = j.DeleteServiceAccount(ctx, bob, svcAccTag)
+	c.Assert(err, qt.IsNil)
+	ok, err := bob.IsServiceAccountAdmin(ctx, svcAccTag)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsFalse)
+	sas, err = j.ListServiceAccounts(ctx, bob)
+	c.Assert(err, qt.IsNil)
+	c.Check(sas, qt.HasLen, 0)
+	_, err = j.LoginClientCredentials(ctx, clientID, "secret")
+	c.Check(err, qt.ErrorMatches, "service account is disabled")
+
+	err = j.AddServiceAccount(ctx, alice, clientID, "")
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginClientCredentials(ctx, clientID, "secret")
+	c.Check(err, qt.IsNil)
+}
+
 func TestCopyServiceAccountCredential(t *testing.T) {
 	c := qt.New(t)
 
@@ -0,0 +1,164 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// sessionTokenKey returns the key under which the device session with
+// the given session token is recorded. Only a hash of the token is
+// stored.
+func sessionTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordDeviceSession records the device session identified by the
+// given session token, minted by JIMM, so that it can be listed and
+// revoked.
+func (j *JIMM) recordDeviceSession(ctx context.Context, email, token string) error {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return err
+	}
+	t, err := jwt.ParseInsecure(decoded)
+	if err != nil {
+		return err
+	}
+	identity, err := dbmodel.NewIdentity(email)
+	if err != nil {
+		return err
+	}
+	// Ensure the identity exists, sessions are removed along with it.
+	if err := j.Database.GetIdentity(ctx, identity); err != nil {
+		return err
+	}
+	return j.Database.AddSession(ctx, &dbmodel.Session{
+		IdentityName: identity.Name,
+		Kind:         dbmodel.DeviceSession,
+		Key:          sessionTokenKey(token),
+		ExpiresAt:    t.Expiration(),
+	})
+}
+
+// checkDeviceSession returns an error with a code of
+// CodeSessionTokenInvalid if the device session identified by the given
+// session token has been revoked. Session tokens minted by device logins
+// and for dashboard links are recorded, tokens minted before sessions
+// were recorded are not and so cannot be revoked; they remain valid
+// until they expire.
+func (j *JIMM) checkDeviceSession(ctx context.Context, token string) error {
+	s := dbmodel.Session{Key: sessionTokenKey(token)}
+	if err := j.Database.GetSession(ctx, &s); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil
+		}
+		return err
+	}
+	if s.RevokedAt.Valid {
+		return errors.E(errors.CodeSessionTokenInvalid, "JIMM session revoked")
+	}
+	return nil
+}
+
+// ListSessions returns the active browser and device login sessions. If
+// identityName is not empty only the sessions of that identity are
+// returned. Only JIMM administrators may list sessions.
+func (j *JIMM) ListSessions(ctx context.Context, user *openfga.User, identityName string) (_ []dbmodel.Session, err error) {
+	const op = errors.Op("jimm.ListSessions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	sessions, err := j.Database.ListSessions(ctx, identityName, time.Now())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return sessions, nil
+}
+
+// RevokeSessions revokes all the active browser and device login
+// sessions of the given identity, which must log in again. It returns the
+// number of sessions revoked. Only JIMM administrators may revoke
+// sessions.
+func (j *JIMM) RevokeSessions(ctx context.Context, user *openfga.User, identityName string) (_ int, err error) {
+	const op = errors.Op("jimm.RevokeSessions")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return 0, errors.E(op, err)
+	}
+	revoked, err := j.Database.RevokeSessions(ctx, identityName, time.Now())
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	zapctx.Info(ctx, "revoked sessions", zap.String("identity", identityName), zap.String("revoked-by", user.Name), zap.Int("count", revoked))
+	return revoked, nil
+}
+
+// sessionCleanupService periodically removes expired login sessions and
+// failed login records.
+type sessionCleanupService struct {
+	db       db.Database
+	interval time.Duration
+}
+
+// NewSessionCleanupService returns a service that removes expired login
+// sessions and failed login records every interval.
+func NewSessionCleanupService(db db.Database, interval time.Duration) *sessionCleanupService {
+	return &sessionCleanupService{
+		db:       db,
+		interval: interval,
+	}
+}
+
+// Start starts a routine which periodically removes expired login
+// sessions and failed login records.
+func (s *sessionCleanupService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *sessionCleanupService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			removed, err := s.db.DeleteExpiredSessions(ctx, time.Now())
+			if err != nil {
+				zapctx.Error(ctx, "failed to remove expired sessions", zap.Error(err))
+				continue
+			}
+			zapctx.Debug(ctx, "expired sessions removed", zap.Int64("count", removed))
+			removed, err = s.db.DeleteExpiredLoginFailures(ctx, time.Now())
+			if err != nil {
+				zapctx.Error(ctx, "failed to remove expired login failures", zap.Error(err))
+				continue
+			}
+			zapctx.Debug(ctx, "expired login failures removed", zap.Int64("count", removed))
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting session cleanup polling")
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// RotateSessionKey replaces the key used to sign session tokens with a
+// new random key. The key being replaced is kept as the only previous
+// key, so tokens signed with it stay valid until the next rotation and
+// tokens signed with any older key are rejected immediately.
+//
+// The keys are stored in the database and, once a key has been rotated,
+// they take precedence over the keys configured when JIMM was started.
+// The stored keys are locked while they are replaced, so concurrent
+// rotations do not lose each other's keys. Every replica is told to
+// load the new keys. The keys used for browser session cookies are not
+// changed. Only JIMM administrators may rotate the session key.
+func (j *JIMM) RotateSessionKey(ctx context.Context, user *openfga.User) (err error) {
+	const op = errors.Op("jimm.RotateSessionKey")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return errors.E(op, err)
+	}
+	if j.OAuthAuthenticator == nil {
+		return errors.E(op, errors.CodeServerConfiguration, "authenticator not configured")
+	}
+
+	newKey, err := newSessionKey()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	var keys []string
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		current, err := tx.LockSessionKeys(ctx, j.OAuthAuthenticator.SessionKeys())
+		if err != nil {
+			return err
+		}
+		keys = []string{newKey, current[0]}
+		return tx.PutSessionKeys(ctx, keys)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	j.OAuthAuthenticator.SetSessionKeys(keys)
+	j.notifyCacheInvalidation(ctx, SessionKeysCache)
+	return nil
+}
+
+// LoadSessionKeys sets the keys used for session tokens to those stored
+// by RotateSessionKey. If no keys have been stored the configured keys
+// are kept.
+func (j *JIMM) LoadSessionKeys(ctx context.Context) (err error) {
+	const op = errors.Op("jimm.LoadSessionKeys")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if j.OAuthAuthenticator == nil {
+		return nil
+	}
+	keys, err := j.Database.GetSessionKeys(ctx)
+	switch {
+	case errors.ErrorCode(err) == errors.CodeNotFound:
+		return nil
+	case err != nil:
+		return errors.E(op, err)
+	}
+	j.OAuthAuthenticator.SetSessionKeys(keys)
+	return nil
+}
+
+// reloadSessionKeys loads the stored session keys, logging any failure.
+func (j *JIMM) reloadSessionKeys() {
+	ctx := context.Background()
+	if err := j.LoadSessionKeys(ctx); err != nil {
+		zapctx.Error(ctx, "failed to load session keys", zap.Error(err))
+	}
+}
+
+// newSessionKey returns a new random key for signing session tokens.
+func newSessionKey() (string, error) {
+	var buf [64]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antonlindstrom/pgstore"
+	"github.com/coreos/go-oidc/v3/oidc"
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// newTestAuthenticationService returns an authentication service that
+// signs session tokens with the given key. This requires the local
+// docker compose to be running and keycloak to be available.
+func newTestAuthenticationService(c *qt.C, database *db.Database, key string) *auth.AuthenticationService {
+	sqldb, err := database.DB.DB()
+	c.Assert(err, qt.IsNil)
+	sessionStore, err := pgstore.NewPGStoreFromPool(sqldb, []byte("secretsecretdigletts"))
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(sessionStore.Close)
+
+	authSvc, err := auth.NewAuthenticationService(context.Background(), auth.AuthenticationServiceParams{
+		IssuerURL:           "http://localhost:8082/realms/jimm",
+		ClientID:            "jimm-device",
+		ClientSecret:        "SwjDofnbDzJDm9iyfUhEp67FfUFMY8L4",
+		Scopes:              []string{oidc.ScopeOpenID, "profile", "email"},
+		SessionTokenExpiry:  time.Hour,
+		RedirectURL:         "http://localhost:8080/auth/callback",
+		Store:               database,
+		SessionStore:        sessionStore,
+		SessionCookieMaxAge: 60,
+		JWTSessionKey:       key,
+	})
+	c.Assert(err, qt.IsNil)
+	return authSvc
+}
+
+func TestRotateSessionKey(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	bus := new(testCacheInvalidationBus)
+	j1 := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient:        ofgaClient,
+		CacheInvalidationBus: bus,
+	}
+	err = j1.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	auth1 := newTestAuthenticationService(c, &j1.Database, "configured-secret-key")
+	j1.OAuthAuthenticator = auth1
+	j2 := &jimm.JIMM{
+		Database:             j1.Database,
+		CacheInvalidationBus: bus,
+	}
+	auth2 := newTestAuthenticationService(c, &j2.Database, "configured-secret-key")
+	j2.OAuthAuthenticator = auth2
+	bus.replicas = []*jimm.JIMM{j1, j2}
+
+	token, err := auth1.MintSessionToken("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, ofgaClient)
+	err = j1.RotateSessionKey(ctx, bob)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, ofgaClient)
+	alice.JimmAdmin = true
+	err = j1.RotateSessionKey(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	c.Check(bus.notified, qt.DeepEquals, []string{jimm.SessionKeysCache})
+
+	// After one rotation both replicas sign with the new key and still
+	// accept tokens signed with the configured key.
+	for _, a := range []*auth.AuthenticationService{auth1, auth2} {
+		_, err = a.VerifySessionToken(token)
+		c.Check(err, qt.IsNil)
+	}
+	newToken, err := auth2.MintSessionToken("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	_, err = auth1.VerifySessionToken(newToken)
+	c.Check(err, qt.IsNil)
+
+	// After a second rotation tokens signed with the configured key are
+	// rejected, tokens signed with the first rotated key are not.
+	err = j1.RotateSessionKey(ctx, alice)
+	c.Assert(err, qt.IsNil)
+	for _, a := range []*auth.AuthenticationService{auth1, auth2} {
+		_, err = a.VerifySessionToken(token)
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+		_, err = a.VerifySessionToken(newToken)
+		c.Check(err, qt.IsNil)
+	}
+
+	// A replica started later uses the stored keys rather than its
+	// configured key.
+	j3 := &jimm.JIMM{
+		Database: j1.Database,
+	}
+	auth3 := newTestAuthenticationService(c, &j3.Database, "configured-secret-key")
+	j3.OAuthAuthenticator = auth3
+	err = j3.LoadSessionKeys(ctx)
+	c.Assert(err, qt.IsNil)
+	_, err = auth3.VerifySessionToken(token)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+	_, err = auth3.VerifySessionToken(newToken)
+	c.Check(err, qt.IsNil)
+}
+
+func TestRotateSessionKeyConcurrent(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j1 := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+	}
+	err := j1.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	j1.OAuthAuthenticator = newTestAuthenticationService(c, &j1.Database, "configured-secret-key")
+	j2 := &jimm.JIMM{
+		Database: j1.Database,
+	}
+	j2.OAuthAuthenticator = newTestAuthenticationService(c, &j2.Database, "configured-secret-key")
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	alice.JimmAdmin = true
+
+	// Both replicas rotate at the same time. The second rotation must
+	// keep the key made by the first rather than the configured key.
+	var wg sync.WaitGroup
+	for _, j := range []*jimm.JIMM{j1, j2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Check(j.RotateSessionKey(ctx, alice), qt.IsNil)
+		}()
+	}
+	wg.Wait()
+
+	keys, err := j1.Database.GetSessionKeys(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(keys, qt.HasLen, 2)
+	c.Check(keys[1], qt.Not(qt.Equals), "configured-secret-key")
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AddModelAsync starts creating a model as AddModel does, but returns as
+// soon as the creation has been recorded rather than waiting for the
+// controller to create the model. The returned task records the progress
+// of the creation, it can be retrieved with GetTask. On success the
+// task's result holds the UUID of the new model.
+func (j *JIMM) AddModelAsync(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (_ *dbmodel.Task, err error) {
+	const op = errors.Op("jimm.AddModelAsync")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	// Only JIMM admins are able to add models on behalf of other users,
+	// check this before starting the task so that the caller gets the
+	// error immediately.
+	if args.Owner.Id() != user.Name && !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	task := dbmodel.Task{
+		Kind:         dbmodel.TaskKindAddModel,
+		IdentityName: user.Name,
+		Status:       dbmodel.TaskPending,
+	}
+	if err := j.Database.AddTask(ctx, &task); err != nil {
+		return nil, errors.E(op, err)
+	}
+	result := task
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		task.Status = dbmodel.TaskRunning
+		task.Progress = "creating model " + args.Name
+		j.updateTask(ctx, &task)
+
+		info, err := j.AddModel(ctx, user, args)
+		task.CompletedAt = sql.NullTime{Time: time.Now().UTC().Round(time.Millisecond), Valid: true}
+		task.Progress = ""
+		if err != nil {
+			task.Status = dbmodel.TaskFailed
+			task.Error = err.Error()
+		} else {
+			task.Status = dbmodel.TaskSucceeded
+			task.Result = info.UUID
+		}
+		j.updateTask(ctx, &task)
+	}()
+	return &result, nil
+}
+
+// updateTask records the state of the given task, logging any failure.
+func (j *JIMM) updateTask(ctx context.Context, task *dbmodel.Task) {
+	if err := j.Database.UpdateTask(ctx, task); err != nil {
+		zapctx.Error(ctx, "cannot update task", zap.Uint("task-id", task.ID), zap.Error(err))
+	}
+}
+
+// GetTask returns the task with the given ID. Users may only retrieve
+// the tasks they started, JIMM administrators may retrieve any task.
+func (j *JIMM) GetTask(ctx context.Context, user *openfga.User, id uint) (_ *dbmodel.Task, err error) {
+	const op = errors.Op("jimm.GetTask")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	task := dbmodel.Task{ID: id}
+	if err := j.Database.GetTask(ctx, &task); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if task.IdentityName != user.Name && !user.JimmAdmin {
+		// Don't reveal the existence of other users' tasks.
+		return nil, errors.E(op, errors.CodeNotFound, "task not found")
+	}
+	return &task, nil
+}
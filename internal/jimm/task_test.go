@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestAddModelAsync(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.GetIdentity(ctx, alice), qt.IsNil)
+	u := openfga.NewUser(alice, ofgaClient)
+	eve := openfga.NewUser(&dbmodel.Identity{Name: "eve@canonical.com"}, ofgaClient)
+
+	// Users cannot add models on behalf of other users.
+	_, err = j.AddModelAsync(ctx, eve, &jimm.ModelCreateArgs{
+		Name:  "test-model",
+		Owner: names.NewUserTag(alice.Name),
+	})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// There is no cloud to host the model, so the task fails.
+	task, err := j.AddModelAsync(ctx, u, &jimm.ModelCreateArgs{
+		Name:  "test-model",
+		Owner: names.NewUserTag(alice.Name),
+		Cloud: names.NewCloudTag("no-such-cloud"),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(task.Kind, qt.Equals, dbmodel.TaskKindAddModel)
+	c.Check(task.Status, qt.Equals, dbmodel.TaskPending)
+
+	for !task.Done() {
+		time.Sleep(10 * time.Millisecond)
+		task, err = j.GetTask(ctx, u, task.ID)
+		c.Assert(err, qt.IsNil)
+	}
+	c.Check(task.Status, qt.Equals, dbmodel.TaskFailed)
+	c.Check(task.Error, qt.Not(qt.Equals), "")
+	c.Check(task.CompletedAt.Valid, qt.IsTrue)
+
+	// Other users cannot see the task.
+	_, err = j.GetTask(ctx, eve, task.ID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// AddTemporaryRelation grants the given relation for the specified
+// duration. Once the grant has expired the relation is removed by
+// RemoveExpiredTemporaryRelations. The user must be a JIMM administrator.
+func (j *JIMM) AddTemporaryRelation(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, duration time.Duration) (_ *dbmodel.TemporaryGrant, err error) {
+	const op = errors.Op("jimm.AddTemporaryRelation")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if duration <= 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "duration must be positive")
+	}
+	parsedTuple, err := j.parseTuple(ctx, tuple)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// Refuse to create a temporary grant for a relation that already
+	// exists, otherwise a standing relation would be removed when the
+	// grant expires.
+	existing, _, err := j.OpenFGAClient.ReadRelatedObjects(ctx, *parsedTuple, 1, "")
+	if err != nil {
+		return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	if len(existing) > 0 {
+		return nil, errors.E(op, errors.CodeAlreadyExists, "relation already exists")
+	}
+
+	now := time.Now().UTC()
+	grant := dbmodel.TemporaryGrant{
+		Object:       tuple.Object,
+		Relation:     tuple.Relation,
+		TargetObject: tuple.TargetObject,
+		GrantedBy:    user.Name,
+		ExpiresAt:    now.Add(duration),
+	}
+	// The grant is recorded before the relation is added so that a
+	// relation is never left in place without a record of its expiry.
+	if err := j.Database.AddTemporaryGrant(ctx, &grant); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.OpenFGAClient.AddRelation(ctx, *parsedTuple); err != nil {
+		if derr := j.Database.DeleteTemporaryGrant(ctx, &grant); derr != nil {
+			zapctx.Error(ctx, "failed to delete temporary grant", zap.Error(derr))
+		}
+		return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	return &grant, nil
+}
+
+// ListTemporaryRelations returns all temporary grants that have not
+// yet expired. The user must be a JIMM administrator.
+func (j *JIMM) ListTemporaryRelations(ctx context.Context, user *openfga.User) (_ []dbmodel.TemporaryGrant, err error) {
+	const op = errors.Op("jimm.ListTemporaryRelations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	grants, err := j.Database.ListTemporaryGrants(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return grants, nil
+}
+
+// RemoveExpiredTemporaryRelations removes all relations granted by
+// AddTemporaryRelation that expired at or before the given time. It
+// returns the number of grants removed.
+func (j *JIMM) RemoveExpiredTemporaryRelations(ctx context.Context, now time.Time) (_ int, err error) {
+	const op = errors.Op("jimm.RemoveExpiredTemporaryRelations")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+	grants, err := j.Database.ListExpiredTemporaryGrants(ctx, now)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	var removed int
+	for _, grant := range grants {
+		parsedTuple, err := j.parseTuple(ctx, grant.Tuple())
+		if err != nil {
+			// The entities in the tuple no longer exist, so there is
+			// no relation left to remove.
+			zapctx.Warn(ctx, "cannot parse expired temporary grant", zap.Uint("id", grant.ID), zap.Error(err))
+		} else {
+			if err := j.OpenFGAClient.RemoveRelation(ctx, *parsedTuple); err != nil {
+				zapctx.Error(ctx, "failed to remove expired temporary relation", zap.Uint("id", grant.ID), zap.Error(err))
+				continue
+			}
+			j.notifyAccessChanged(tupleAccessChange(*parsedTuple))
+		}
+		if err := j.Database.DeleteTemporaryGrant(ctx, &grant); err != nil {
+			return removed, errors.E(op, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// tupleAccessChange returns the AccessChange describing a change to the
+// given tuple. Tuples whose object is not a single identity, or whose
+// target is not a model, may affect the access of any identity or to any
+// model.
+func tupleAccessChange(t openfga.Tuple) AccessChange {
+	var change AccessChange
+	if t.Object.Kind == openfga.UserType && !t.Object.IsPublicAccess() {
+		change.Identity = t.Object.ID
+	}
+	if t.Target.Kind == openfga.ModelType {
+		change.Model = t.Target.ID
+	}
+	return change
+}
+
+// temporaryGrantCleanupService periodically removes expired temporary
+// relations.
+type temporaryGrantCleanupService struct {
+	jimm     *JIMM
+	interval time.Duration
+}
+
+// NewTemporaryGrantCleanupService returns a service that removes expired
+// temporary relations every interval.
+func NewTemporaryGrantCleanupService(j *JIMM, interval time.Duration) *temporaryGrantCleanupService {
+	return &temporaryGrantCleanupService{
+		jimm:     j,
+		interval: interval,
+	}
+}
+
+// Start starts a routine which periodically removes expired temporary
+// relations.
+func (s *temporaryGrantCleanupService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *temporaryGrantCleanupService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			removed, err := s.jimm.RemoveExpiredTemporaryRelations(ctx, time.Now().UTC())
+			if err != nil {
+				zapctx.Error(ctx, "failed to remove expired temporary relations", zap.Error(err))
+				continue
+			}
+			zapctx.Debug(ctx, "expired temporary relations removed", zap.Int("count", removed))
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting temporary grant cleanup polling")
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+// AccrueModelUsage adds the usage of every model between from and to,
+// at the model's current machine and unit counts, to the model's daily
+// usage records. Periods spanning midnight UTC are divided between the
+// days.
+func (j *JIMM) AccrueModelUsage(ctx context.Context, from, to time.Time) (err error) {
+	const op = errors.Op("jimm.AccrueModelUsage")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	from, to = from.UTC(), to.UTC()
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		for start := from; start.Before(to); {
+			end := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, time.UTC)
+			if end.After(to) {
+				end = to
+			}
+			if err := tx.AccrueModelUsage(ctx, start, end.Sub(start).Hours()); err != nil {
+				return err
+			}
+			start = end
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UsageReport returns the machine and unit usage of the models for the
+// UTC days from from to to inclusive. If daily is true the usage of each
+// model on each day is returned, otherwise the total usage of each model
+// over the period is returned, ordered by model name. Only JIMM
+// administrators may report usage.
+func (j *JIMM) UsageReport(ctx context.Context, user *openfga.User, from, to time.Time, daily bool) (_ []dbmodel.UsageRecord, err error) {
+	const op = errors.Op("jimm.UsageReport")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if to.Before(from) {
+		return nil, errors.E(op, errors.CodeBadRequest, "report period ends before it starts")
+	}
+	records, err := j.Database.ListUsageRecords(ctx, from, to)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if daily {
+		return records, nil
+	}
+
+	totals := make(map[string]*dbmodel.UsageRecord)
+	for _, r := range records {
+		t, ok := totals[r.ModelUUID]
+		if !ok {
+			t = &dbmodel.UsageRecord{ModelUUID: r.ModelUUID}
+			totals[r.ModelUUID] = t
+		}
+		// Records are ordered by date, so the model's details are
+		// taken from the latest record.
+		t.ModelName = r.ModelName
+		t.OwnerIdentityName = r.OwnerIdentityName
+		t.ControllerName = r.ControllerName
+		t.MachineHours += r.MachineHours
+		t.UnitHours += r.UnitHours
+	}
+	report := make([]dbmodel.UsageRecord, 0, len(totals))
+	for _, t := range totals {
+		report = append(report, *t)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].ModelName != report[j].ModelName {
+			return report[i].ModelName < report[j].ModelName
+		}
+		return report[i].ModelUUID < report[j].ModelUUID
+	})
+	return report, nil
+}
+
+// usageAggregationService periodically accrues the usage of all models.
+type usageAggregationService struct {
+	jimm     *JIMM
+	interval time.Duration
+}
+
+// NewUsageAggregationService returns a service that accrues the usage of
+// all models every interval. Usage is sampled from the machine and unit
+// counts the watcher maintains from controller deltas, so changes within
+// an interval are attributed to the end of it.
+func NewUsageAggregationService(j *JIMM, interval time.Duration) *usageAggregationService {
+	return &usageAggregationService{
+		jimm:     j,
+		interval: interval,
+	}
+}
+
+// Start starts a routine which periodically accrues the usage of all
+// models.
+func (s *usageAggregationService) Start(ctx context.Context) {
+	go s.poll(ctx)
+}
+
+// poll is designed to be run in a routine where it can be cancelled safely
+// from the service's context.
+func (s *usageAggregationService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	// Usage is only accrued while the service is running, periods in
+	// which no replica was running it are not counted.
+	last := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			if err := s.jimm.AccrueModelUsage(ctx, last, now); err != nil {
+				// Leave last unchanged so that the period is
+				// accrued on the next attempt.
+				zapctx.Error(ctx, "failed to accrue model usage", zap.Error(err))
+				continue
+			}
+			last = now
+		case <-ctx.Done():
+			zapctx.Debug(ctx, "exiting usage aggregation polling")
+			return
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestUsageReport(t *testing.T) {
+	ctx := context.Background()
+	c := qt.New(t)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, getModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	m := env.Models[0].DBObject(c, j.Database)
+	m.Machines = 2
+	m.Units = 4
+	err = j.Database.UpdateModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+
+	// The period spans midnight, so it is divided between two days.
+	from := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	err = j.AccrueModelUsage(ctx, from, from.Add(3*time.Hour))
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, client)
+	admin.JimmAdmin = true
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	records, err := j.UsageReport(ctx, admin, day1, day2, true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(records, qt.HasLen, 2)
+	c.Check(records[0].Date.Equal(day1), qt.IsTrue)
+	c.Check(records[0].ModelName, qt.Equals, "model-1")
+	c.Check(records[0].OwnerIdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(records[0].ControllerName, qt.Equals, "controller-1")
+	c.Check(records[0].MachineHours, qt.Equals, 2.0)
+	c.Check(records[0].UnitHours, qt.Equals, 4.0)
+	c.Check(records[1].Date.Equal(day2), qt.IsTrue)
+	c.Check(records[1].MachineHours, qt.Equals, 4.0)
+	c.Check(records[1].UnitHours, qt.Equals, 8.0)
+
+	records, err = j.UsageReport(ctx, admin, day1, day2, false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(records, qt.HasLen, 1)
+	c.Check(records[0].Date.IsZero(), qt.IsTrue)
+	c.Check(records[0].ModelUUID, qt.Equals, env.Models[0].UUID)
+	c.Check(records[0].MachineHours, qt.Equals, 6.0)
+	c.Check(records[0].UnitHours, qt.Equals, 12.0)
+
+	records, err = j.UsageReport(ctx, admin, day2, day2, false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(records, qt.HasLen, 1)
+	c.Check(records[0].MachineHours, qt.Equals, 4.0)
+
+	_, err = j.UsageReport(ctx, admin, day2, day1, false)
+	c.Check(err, qt.ErrorMatches, `report period ends before it starts`)
+
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	_, err = j.UsageReport(ctx, alice, day1, day2, false)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+}
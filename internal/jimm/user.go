@@ -5,20 +5,30 @@ package jimm
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/juju/names/v5"
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 // UserLogin fetches a user based on their identityName and updates their last login time.
-func (j *JIMM) UserLogin(ctx context.Context, identityName string) (*openfga.User, error) {
+// Disabled identities, such as offboarded users, are not allowed to log in.
+func (j *JIMM) UserLogin(ctx context.Context, identityName string) (_ *openfga.User, err error) {
 	const op = errors.Op("jimm.UserLogin")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
 	user, err := j.getUser(ctx, identityName)
 	if err != nil {
 		return nil, errors.E(op, err, errors.CodeUnauthorized)
 	}
+	if user.Disabled {
+		return nil, errors.E(op, errors.CodeUnauthorized, "identity is disabled")
+	}
 	err = j.updateUserLastLogin(ctx, identityName)
 	if err != nil {
 		return nil, errors.E(op, err, errors.CodeUnauthorized)
@@ -71,3 +81,146 @@ func (j *JIMM) updateUserLastLogin(ctx context.Context, identifier string) error
 	}
 	return nil
 }
+
+// An OffboardUserSummary describes the changes made when offboarding a user.
+type OffboardUserSummary struct {
+	// RemovedRelations holds the OpenFGA tuples that granted the user
+	// access and have been removed.
+	RemovedRelations []openfga.Tuple
+
+	// RevokedCredentials holds the cloud credentials that have been
+	// revoked.
+	RevokedCredentials []names.CloudCredentialTag
+
+	// RetainedCredentials holds the cloud credentials that could not be
+	// revoked because they are still used by models.
+	RetainedCredentials []names.CloudCredentialTag
+
+	// ReassignedModels holds the models whose ownership was given to
+	// another user.
+	ReassignedModels []names.ModelTag
+
+	// RetainedModels holds the models still owned by the removed user.
+	RetainedModels []names.ModelTag
+}
+
+// OffboardUser offboards the given user by disabling their identity,
+// revoking their login sessions, removing all of their access in
+// OpenFGA and revoking their cloud credentials. If reassignTo is not empty any models owned by the
+// removed user are given to that user, except those whose name the new
+// owner already uses, which are retained. Cloud credentials still used by
+// models are not revoked. The identity itself is kept so that audit
+// records and models continue to refer to it. Only JIMM administrators
+// may remove users.
+func (j *JIMM) OffboardUser(ctx context.Context, user *openfga.User, identityName, reassignTo string) (_ *OffboardUserSummary, err error) {
+	const op = errors.Op("jimm.OffboardUser")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if identityName == user.Name {
+		return nil, errors.E(op, errors.CodeBadRequest, "cannot remove yourself")
+	}
+	identity, err := dbmodel.NewIdentity(identityName)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeBadRequest, err)
+	}
+	if err := j.Database.GetIdentity(ctx, identity); err != nil {
+		return nil, errors.E(op, err)
+	}
+	var newOwner *dbmodel.Identity
+	if reassignTo != "" {
+		if reassignTo == identity.Name {
+			return nil, errors.E(op, errors.CodeBadRequest, "cannot reassign models to the removed user")
+		}
+		newOwner, err = dbmodel.NewIdentity(reassignTo)
+		if err != nil {
+			return nil, errors.E(op, errors.CodeBadRequest, err)
+		}
+		if err := j.Database.GetIdentity(ctx, newOwner); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	var summary OffboardUserSummary
+	defer j.notifyAccessChanged(AccessChange{Identity: identity.Name})
+
+	// The user is locked out first so that they cannot log in again even
+	// if a later step fails.
+	identity.Disabled = true
+	if err := j.Database.UpdateIdentity(ctx, identity); err != nil {
+		return &summary, errors.E(op, err)
+	}
+	if _, err := j.Database.RevokeSessions(ctx, identity.Name, time.Now()); err != nil {
+		return &summary, errors.E(op, err)
+	}
+
+	summary.RemovedRelations, err = j.OpenFGAClient.RemoveUser(ctx, identity.ResourceTag())
+	if err != nil {
+		return &summary, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+
+	var owned []dbmodel.Model
+	err = j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
+		if m.OwnerIdentityName == identity.Name {
+			owned = append(owned, *m)
+		}
+		return nil
+	})
+	if err != nil {
+		return &summary, errors.E(op, err)
+	}
+	for _, m := range owned {
+		if newOwner == nil {
+			summary.RetainedModels = append(summary.RetainedModels, m.ResourceTag())
+			continue
+		}
+		err := j.checkModelNameAvailable(ctx, newOwner.Name, m.Name)
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			// The new owner already has a model with this name.
+			summary.RetainedModels = append(summary.RetainedModels, m.ResourceTag())
+			continue
+		}
+		if err != nil {
+			return &summary, errors.E(op, err)
+		}
+		if err := j.transferModelOwnership(ctx, &m, newOwner); err != nil {
+			return &summary, errors.E(op, err)
+		}
+		summary.ReassignedModels = append(summary.ReassignedModels, m.ResourceTag())
+	}
+
+	var creds []names.CloudCredentialTag
+	err = j.Database.ForEachCloudCredential(ctx, identity.Name, "", func(cred *dbmodel.CloudCredential) error {
+		creds = append(creds, cred.ResourceTag())
+		return nil
+	})
+	if err != nil {
+		return &summary, errors.E(op, err)
+	}
+	for _, tag := range creds {
+		err := j.RevokeCloudCredential(ctx, identity, tag, false)
+		switch {
+		case err == nil:
+			summary.RevokedCredentials = append(summary.RevokedCredentials, tag)
+		case errors.ErrorCode(err) == errors.CodeBadRequest:
+			// The credential is still used by at least one model.
+			summary.RetainedCredentials = append(summary.RetainedCredentials, tag)
+		default:
+			return &summary, errors.E(op, err)
+		}
+	}
+
+	// Without tokens any remaining browser sessions fail to refresh and
+	// are removed on their next use.
+	identity.AccessToken = ""
+	identity.RefreshToken = ""
+	identity.AccessTokenExpiry = time.Time{}
+	identity.AccessTokenType = ""
+	if err := j.Database.UpdateIdentity(ctx, identity); err != nil {
+		return &summary, errors.E(op, err)
+	}
+	return &summary, nil
+}
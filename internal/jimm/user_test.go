@@ -12,8 +12,10 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
 
@@ -93,3 +95,101 @@ func TestUpdateUserLastLogin(t *testing.T) {
 	c.Assert(user.LastLogin.Time, qt.Equals, now)
 	c.Assert(user.LastLogin.Valid, qt.IsTrue)
 }
+
+func TestOffboardUser(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	j := &jimm.JIMM{
+		UUID: "test",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	user, _, _, model, _, _, cred := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, client)
+	err = u.SetModelAccess(ctx, model.ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	admin, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.GetIdentity(ctx, admin), qt.IsNil)
+	adminUser := openfga.NewUser(admin, client)
+
+	carol, err := dbmodel.NewIdentity("carol@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.GetIdentity(ctx, carol), qt.IsNil)
+
+	_, err = j.OffboardUser(ctx, adminUser, user.Name, carol.Name)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	adminUser.JimmAdmin = true
+	summary, err := j.OffboardUser(ctx, adminUser, user.Name, carol.Name)
+	c.Assert(err, qt.IsNil)
+	c.Check(summary.ReassignedModels, qt.DeepEquals, []names.ModelTag{model.ResourceTag()})
+	c.Check(summary.RetainedModels, qt.HasLen, 0)
+	c.Check(summary.RetainedCredentials, qt.DeepEquals, []names.CloudCredentialTag{cred.ResourceTag()})
+	c.Check(summary.RevokedCredentials, qt.HasLen, 0)
+	c.Check(summary.RemovedRelations, qt.DeepEquals, []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}})
+
+	m := dbmodel.Model{UUID: model.UUID}
+	c.Assert(j.Database.GetModel(ctx, &m), qt.IsNil)
+	c.Check(m.OwnerIdentityName, qt.Equals, carol.Name)
+
+	access := openfga.NewUser(carol, client).GetModelAccess(ctx, model.ResourceTag())
+	c.Check(access, qt.Equals, ofganames.AdministratorRelation)
+	access = u.GetModelAccess(ctx, model.ResourceTag())
+	c.Check(access, qt.Equals, ofganames.NoRelation)
+}
+
+func TestLoginAfterOffboardUser(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	pollingChan := make(chan string, 1)
+	mockAuthenticator := jimmtest.NewMockOAuthAuthenticator(c, pollingChan)
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	j := &jimm.JIMM{
+		UUID: "test",
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OAuthAuthenticator: &mockAuthenticator,
+		OpenFGAClient:      client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	pollingChan <- "bob"
+	token, err := j.GetDeviceSessionToken(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	_, err = j.LoginWithSessionToken(ctx, token)
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, client)
+	admin.JimmAdmin = true
+	_, err = j.OffboardUser(ctx, admin, "bob@canonical.com", "")
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.LoginWithSessionToken(ctx, token)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeSessionTokenInvalid)
+
+	// Logins that do not use a recorded session are rejected because
+	// the identity is disabled.
+	_, err = j.UserLogin(ctx, "bob@canonical.com")
+	c.Check(err, qt.ErrorMatches, `identity is disabled`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	_, err = j.LoginWithSessionCookie(ctx, "bob@canonical.com")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
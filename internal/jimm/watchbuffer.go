@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"sync"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// An OverflowPolicy determines what a watcher does when its client does
+// not read events as quickly as they are produced and the watcher's
+// buffer fills up.
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest discards the oldest buffered events to make
+	// room for new ones. The number of events discarded is returned with
+	// the next batch of events so that the client knows it has missed
+	// some and can resynchronise.
+	OverflowDropOldest OverflowPolicy = iota
+
+	// OverflowDisconnect discards all buffered events and fails the
+	// watcher. The client's next read returns an error and the client
+	// must start a new watcher.
+	OverflowDisconnect
+)
+
+// errWatcherOverflow is returned by a watcher using OverflowDisconnect
+// once its buffer has overflowed.
+var errWatcherOverflow = errors.E("watcher stopped: client did not read events quickly enough")
+
+// A watchBuffer holds the events waiting to be read by a single watcher
+// client. It never holds more than limit events, so one slow client
+// cannot cause unbounded memory growth, and it never blocks the producer.
+type watchBuffer[T any] struct {
+	kind   string
+	limit  int
+	policy OverflowPolicy
+
+	// ready receives a value whenever the buffer may have changed.
+	ready chan struct{}
+
+	mu         sync.Mutex
+	items      []T
+	dropped    int
+	overflowed bool
+}
+
+// newWatchBuffer returns a watchBuffer holding at most limit events.
+// The kind is used to label the watcher metrics.
+func newWatchBuffer[T any](kind string, limit int, policy OverflowPolicy) *watchBuffer[T] {
+	return &watchBuffer[T]{
+		kind:   kind,
+		limit:  limit,
+		policy: policy,
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// push adds the given events to the end of the buffer, applying the
+// overflow policy if the buffer becomes full. It reports whether the
+// buffer can still accept events.
+func (b *watchBuffer[T]) push(items ...T) bool {
+	b.mu.Lock()
+	ok := b.add(items, false)
+	b.mu.Unlock()
+	b.wake()
+	return ok
+}
+
+// requeue adds the given events to the front of the buffer, so that they
+// are returned again ahead of any newer events.
+func (b *watchBuffer[T]) requeue(items []T) {
+	b.mu.Lock()
+	b.add(items, true)
+	b.mu.Unlock()
+	b.wake()
+}
+
+// add adds items to the buffer, which must be locked.
+func (b *watchBuffer[T]) add(items []T, front bool) bool {
+	if b.overflowed {
+		return false
+	}
+	if front {
+		b.items = append(items[:len(items):len(items)], b.items...)
+	} else {
+		b.items = append(b.items, items...)
+	}
+	n := len(b.items) - b.limit
+	if n <= 0 {
+		return true
+	}
+	switch b.policy {
+	case OverflowDisconnect:
+		servermon.WatcherDroppedEventsCount.WithLabelValues(b.kind).Add(float64(len(b.items)))
+		b.items = nil
+		b.overflowed = true
+		return false
+	default:
+		servermon.WatcherDroppedEventsCount.WithLabelValues(b.kind).Add(float64(n))
+		b.items = append([]T(nil), b.items[n:]...)
+		b.dropped += n
+		return true
+	}
+}
+
+// take removes and returns every buffered event along with the number
+// of events discarded since the previous call. If the buffer has
+// overflowed and the policy is OverflowDisconnect an error is returned.
+func (b *watchBuffer[T]) take() ([]T, int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.overflowed {
+		return nil, 0, errWatcherOverflow
+	}
+	items, dropped := b.items, b.dropped
+	b.items, b.dropped = nil, 0
+	if len(items) > 0 || dropped > 0 {
+		servermon.WatcherQueueDepthHistogram.WithLabelValues(b.kind).Observe(float64(len(items) + dropped))
+	}
+	return items, dropped, nil
+}
+
+// wake signals anything waiting on the ready channel.
+func (b *watchBuffer[T]) wake() {
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWatchBufferDropOldest(t *testing.T) {
+	c := qt.New(t)
+
+	b := newWatchBuffer[int]("test", 3, OverflowDropOldest)
+	c.Check(b.push(1, 2), qt.IsTrue)
+	c.Check(b.push(3, 4, 5), qt.IsTrue)
+
+	items, dropped, err := b.take()
+	c.Assert(err, qt.IsNil)
+	c.Check(items, qt.DeepEquals, []int{3, 4, 5})
+	c.Check(dropped, qt.Equals, 2)
+
+	b.push(6)
+	b.requeue([]int{4, 5})
+	items, dropped, err = b.take()
+	c.Assert(err, qt.IsNil)
+	c.Check(items, qt.DeepEquals, []int{4, 5, 6})
+	c.Check(dropped, qt.Equals, 0)
+
+	items, dropped, err = b.take()
+	c.Assert(err, qt.IsNil)
+	c.Check(items, qt.HasLen, 0)
+	c.Check(dropped, qt.Equals, 0)
+}
+
+func TestWatchBufferDisconnect(t *testing.T) {
+	c := qt.New(t)
+
+	b := newWatchBuffer[int]("test", 3, OverflowDisconnect)
+	c.Check(b.push(1, 2, 3), qt.IsTrue)
+	items, _, err := b.take()
+	c.Assert(err, qt.IsNil)
+	c.Check(items, qt.DeepEquals, []int{1, 2, 3})
+
+	c.Check(b.push(4, 5), qt.IsTrue)
+	c.Check(b.push(6, 7), qt.IsFalse)
+	c.Check(b.push(8), qt.IsFalse)
+	_, _, err = b.take()
+	c.Check(err, qt.ErrorMatches, "watcher stopped: client did not read events quickly enough")
+}
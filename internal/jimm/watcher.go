@@ -16,6 +16,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notifications"
 	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
@@ -38,6 +39,24 @@ type Watcher struct {
 	// model summaries.
 	Pubsub Publisher
 
+	// SummaryDebounce, if non-zero, is the period for which a model
+	// summary is held before it is published. Summaries for the same
+	// model received during this period are coalesced so that only the
+	// latest is published.
+	SummaryDebounce time.Duration
+
+	// Notifier, if set, is notified of status transitions in watched
+	// models.
+	Notifier ModelEventNotifier
+
+	// VersionNotifier, if set, is notified when a controller is seen
+	// to be running a different agent version.
+	VersionNotifier ControllerVersionNotifier
+
+	// Lifecycle, if set, is sent the model-destroyed and
+	// controller-unavailable lifecycle events.
+	Lifecycle *notifications.Dispatcher
+
 	controllerUnavailableChan chan error
 	deltaProcessedChan        chan bool
 }
@@ -134,12 +153,15 @@ func (w *Watcher) dialController(ctx context.Context, ctl *dbmodel.Controller) (
 	const op = errors.Op("jimm.dialController")
 
 	updateController := false
+	versionChanged := false
 	defer func() {
 		if !updateController {
 			return
 		}
 		if uerr := w.Database.UpdateController(ctx, ctl); uerr != nil {
 			zapctx.Error(ctx, "cannot set controller available", zap.Error(uerr))
+		} else if versionChanged && w.VersionNotifier != nil {
+			w.VersionNotifier.ControllerVersionChanged(ctx, ctl)
 		}
 		// Note (alesstimec) This channel is only available in tests.
 		if w.controllerUnavailableChan != nil {
@@ -151,8 +173,16 @@ func (w *Watcher) dialController(ctx context.Context, ctl *dbmodel.Controller) (
 	}()
 
 	// connect to the controller
+	agentVersion := ctl.AgentVersion
 	api, err = w.Dialer.Dial(ctx, ctl, names.ModelTag{}, nil)
+	w.reportAvailability(ctx, ctl, err)
 	if err != nil {
+		if !ctl.UnavailableSince.Valid {
+			w.Lifecycle.Dispatch(ctx, notifications.Event{
+				Event:      notifications.EventControllerUnavailable,
+				Controller: ctl.Name,
+			})
+		}
 		ctl.UnavailableSince = db.Now()
 		updateController = true
 
@@ -162,21 +192,89 @@ func (w *Watcher) dialController(ctx context.Context, ctl *dbmodel.Controller) (
 		ctl.UnavailableSince = sql.NullTime{}
 		updateController = true
 	}
+	if ctl.AgentVersion != agentVersion {
+		zapctx.Info(ctx, "controller version changed", zap.String("from", agentVersion), zap.String("to", ctl.AgentVersion))
+		updateController = true
+		versionChanged = true
+	}
 	return api, nil
 }
 
+// reportAvailability records whether the given controller could be
+// dialed. A controller that cannot be dialed during one of its
+// maintenance windows is not reported as unavailable, so that alerts are
+// not raised for planned maintenance.
+func (w *Watcher) reportAvailability(ctx context.Context, ctl *dbmodel.Controller, dialErr error) {
+	now := time.Now()
+	inMaintenance := false
+	windows, err := w.Database.ListMaintenanceWindows(ctx, ctl.ID, now)
+	if err != nil {
+		zapctx.Error(ctx, "cannot check controller maintenance windows", zap.Error(err))
+	}
+	for _, mw := range windows {
+		if mw.Active(now) {
+			inMaintenance = true
+			break
+		}
+	}
+
+	unavailable := 0.0
+	switch {
+	case dialErr == nil:
+	case inMaintenance:
+		zapctx.Info(ctx, "controller unavailable during maintenance window", zap.Error(dialErr))
+	default:
+		zapctx.Error(ctx, "controller unavailable", zap.Error(dialErr))
+		unavailable = 1
+	}
+	servermon.ControllerUnavailable.WithLabelValues(ctl.Name).Set(unavailable)
+	if inMaintenance {
+		servermon.ControllerInMaintenance.WithLabelValues(ctl.Name).Set(1)
+	} else {
+		servermon.ControllerInMaintenance.WithLabelValues(ctl.Name).Set(0)
+	}
+}
+
+// A ControllerVersionNotifier is notified when a controller's agent
+// version changes.
+type ControllerVersionNotifier interface {
+	// ControllerVersionChanged is called after the new agent version
+	// of the given controller has been stored.
+	ControllerVersionChanged(ctx context.Context, ctl *dbmodel.Controller)
+}
+
 // A modelState holds the in-memory state of a model for the watcher.
 type modelState struct {
 	// id is the database id of the model.
 	id      uint
 	changed bool
 
+	// active records whether a delta for an application, machine or
+	// unit in the model has been seen since the model's last activity
+	// was recorded.
+	active bool
+
 	// machines maps the Id of all the machines that have been seen to
 	// the number of cores reported.
 	machines map[string]int64
 
 	// units stores the ids of all units that have been seen.
 	units map[string]bool
+
+	// statuses holds the last status seen for each unit and machine,
+	// used to detect status transitions.
+	statuses map[string]string
+}
+
+// setStatus records the status of the given entity and returns the
+// status previously recorded, which is empty if none was.
+func (s *modelState) setStatus(entity, status string) string {
+	if s.statuses == nil {
+		s.statuses = make(map[string]string)
+	}
+	prev := s.statuses[entity]
+	s.statuses[entity] = status
+	return prev
 }
 
 func (w *Watcher) checkControllerModels(ctx context.Context, ctl *dbmodel.Controller, checks ...func(*dbmodel.Model) error) (map[string]*modelState, error) {
@@ -306,6 +404,10 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 		return modelStates[uuid]
 	}
 
+	// The first set of deltas describes the current state of the models
+	// rather than any changes to them, so it is not recorded as
+	// activity.
+	initial := true
 	for {
 		// wait for updates from the all watcher.
 		deltas, err := api.AllModelWatcherNext(ctx, id)
@@ -328,7 +430,9 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 				delete(modelStates, k)
 				continue
 			}
-			if v.changed {
+			recordActivity := v.active && !initial
+			v.active = false
+			if v.changed || recordActivity {
 				v.changed = false
 				// Update changed model.
 				err := w.Database.Transaction(func(tx *db.Database) error {
@@ -346,6 +450,9 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 					m.Cores = cores
 					m.Machines = machines
 					m.Units = int64(len(v.units))
+					if recordActivity {
+						m.LastActivity = sql.NullTime{Time: time.Now(), Valid: true}
+					}
 					if err := tx.UpdateModel(ctx, &m); err != nil {
 						return err
 					}
@@ -357,6 +464,7 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 				}
 			}
 		}
+		initial = false
 	}
 }
 
@@ -394,6 +502,15 @@ func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Contr
 		return errors.E(op, err)
 	}
 
+	var pubsub Publisher = w.Pubsub
+	if w.SummaryDebounce > 0 {
+		cp := newCoalescingPublisher(w.Pubsub, w.SummaryDebounce)
+		defer cp.Flush()
+		pubsub = cp
+	}
+	changed := newChangedPublisher(pubsub)
+	pubsub = changed
+
 	modelIDf := func(uuid string) uint {
 		state, ok := modelStates[uuid]
 		if ok {
@@ -445,7 +562,10 @@ func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Contr
 				admins = append(admins, admin)
 			}
 			summary.Admins = admins
-			w.Pubsub.Publish(summary.UUID, summary)
+			pubsub.Publish(summary.UUID, summary)
+			if summary.Removed {
+				changed.Forget(summary.UUID)
+			}
 		}
 	}
 }
@@ -458,6 +578,10 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 		return nil
 	}
 	switch eid.Kind {
+	case "application", "machine", "unit":
+		state.active = true
+	}
+	switch eid.Kind {
 	case "application":
 		if d.Removed {
 			return nil
@@ -480,6 +604,11 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 			state.machines[eid.Id] = cores
 			state.changed = true
 		}
+		agentStatus := string(machine.AgentStatus.Current)
+		prev := state.setStatus("machine-"+eid.Id, agentStatus)
+		if prev != "" && prev != agentStatus && agentStatus == "down" {
+			w.notify(ctx, state.id, eid.ModelUUID, ModelEventMachineDown, "machine-"+eid.Id, machine.AgentStatus.Message)
+		}
 	case "model":
 		model := dbmodel.Model{
 			ID: state.id,
@@ -487,7 +616,15 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 		if d.Removed {
 			return w.deleteModel(ctx, &model)
 		}
-		return w.updateModel(ctx, &model, d.Entity.(*jujuparams.ModelUpdate))
+		info := d.Entity.(*jujuparams.ModelUpdate)
+		// Juju suspends a model when its cloud credential is no
+		// longer valid.
+		modelStatus := string(info.Status.Current)
+		prev := state.setStatus("model", modelStatus)
+		if prev != "" && prev != modelStatus && modelStatus == "suspended" {
+			w.notify(ctx, state.id, eid.ModelUUID, ModelEventCredentialInvalid, "", info.Status.Message)
+		}
+		return w.updateModel(ctx, &model, info)
 	case "unit":
 		if d.Removed {
 			state.changed = true
@@ -498,10 +635,37 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 			state.changed = true
 			state.units[eid.Id] = true
 		}
+		unit := d.Entity.(*jujuparams.UnitInfo)
+		unitStatus, message := string(unit.WorkloadStatus.Current), unit.WorkloadStatus.Message
+		if unit.AgentStatus.Current == "error" {
+			unitStatus, message = "error", unit.AgentStatus.Message
+		}
+		// Transitions are only reported for units that have been seen
+		// before, so that existing errors are not reported every time
+		// the watcher starts.
+		prev := state.setStatus("unit-"+eid.Id, unitStatus)
+		if prev != "" && prev != unitStatus && unitStatus == "error" {
+			w.notify(ctx, state.id, eid.ModelUUID, ModelEventUnitError, "unit-"+eid.Id, message)
+		}
 	}
 	return nil
 }
 
+// notify informs the Notifier, if there is one, of an event in a model.
+func (w *Watcher) notify(ctx context.Context, modelID uint, modelUUID, event, entity, message string) {
+	if w.Notifier == nil {
+		return
+	}
+	m := dbmodel.Model{
+		ID: modelID,
+		UUID: sql.NullString{
+			String: modelUUID,
+			Valid:  true,
+		},
+	}
+	w.Notifier.NotifyModelEvent(ctx, &m, event, entity, message)
+}
+
 func (w *Watcher) deleteModel(ctx context.Context, model *dbmodel.Model) error {
 	const op = errors.Op("watcher.deleteModel")
 
@@ -515,6 +679,17 @@ func (w *Watcher) deleteModel(ctx context.Context, model *dbmodel.Model) error {
 			// If the model hasn't been marked as dying, don't remove it.
 			return nil
 		}
+		// Notify before the model is deleted, as deleting it also
+		// removes its webhooks.
+		if w.Notifier != nil && model.ID != 0 {
+			w.Notifier.NotifyModelEvent(ctx, model, ModelEventModelDestroyed, "", "")
+		}
+		w.Lifecycle.Dispatch(ctx, notifications.Event{
+			Event:      notifications.EventModelDestroyed,
+			ModelUUID:  model.UUID.String,
+			ModelName:  model.Name,
+			Controller: model.Controller.Name,
+		})
 		return db.DeleteModel(ctx, model)
 	})
 	if err != nil {
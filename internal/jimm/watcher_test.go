@@ -672,6 +672,37 @@ var modelSummaryWatcherTests = []struct {
 			},
 		})
 	},
+}, {
+	name: "UnchangedSummariesNotRepublished",
+	summaries: [][]jujuparams.ModelAbstract{
+		{{
+			UUID:   "00000002-0000-0000-0000-000000000001",
+			Status: "test status",
+		}},
+		{{
+			UUID:   "00000002-0000-0000-0000-000000000001",
+			Status: "test status",
+		}},
+		{{
+			UUID:   "00000002-0000-0000-0000-000000000001",
+			Status: "test status 2",
+		}},
+		nil,
+	},
+	checkPublisher: func(c *qt.C, publisher *testPublisher) {
+		c.Assert(publisher.messages, qt.DeepEquals, []interface{}{
+			jujuparams.ModelAbstract{
+				UUID:   "00000002-0000-0000-0000-000000000001",
+				Status: "test status",
+				Admins: []string{},
+			},
+			jujuparams.ModelAbstract{
+				UUID:   "00000002-0000-0000-0000-000000000001",
+				Status: "test status 2",
+				Admins: []string{},
+			},
+		})
+	},
 }}
 
 func TestModelSummaryWatcher(t *testing.T) {
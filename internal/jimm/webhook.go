@@ -0,0 +1,251 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	"github.com/canonical/jimm/v3/internal/webhook"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// The model status transitions that may trigger a model webhook.
+const (
+	// ModelEventUnitError is triggered when a unit enters an error
+	// state.
+	ModelEventUnitError = "unit-error"
+
+	// ModelEventMachineDown is triggered when a machine agent is
+	// reported as down.
+	ModelEventMachineDown = "machine-down"
+
+	// ModelEventModelDestroyed is triggered when a model is removed.
+	ModelEventModelDestroyed = "model-destroyed"
+
+	// ModelEventCredentialInvalid is triggered when a model is
+	// suspended because its cloud credential is no longer valid.
+	ModelEventCredentialInvalid = "credential-invalid"
+)
+
+var modelEvents = map[string]bool{
+	ModelEventUnitError:         true,
+	ModelEventMachineDown:       true,
+	ModelEventModelDestroyed:    true,
+	ModelEventCredentialInvalid: true,
+}
+
+// AddModelWebhook registers a webhook on the given model that is called
+// whenever one of the given events occurs. The user must be an
+// administrator of the model. The URL must be acceptable to
+// webhook.CheckURL. The returned webhook holds the secret used to sign
+// notifications, which is not otherwise made available.
+func (j *JIMM) AddModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, webhookURL string, events []string) (_ *dbmodel.ModelWebhook, err error) {
+	const op = errors.Op("jimm.AddModelWebhook")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	m, err := j.getModelWebhookModel(ctx, user, mt)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := webhook.CheckURL(ctx, webhookURL, j.WebhookAllowedNetworks); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if len(events) == 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "no events specified")
+	}
+	for _, e := range events {
+		if !modelEvents[e] {
+			return nil, errors.E(op, errors.CodeBadRequest, "unknown event "+e)
+		}
+	}
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	wh := dbmodel.ModelWebhook{
+		ModelID:   m.ID,
+		CreatedBy: user.Name,
+		URL:       webhookURL,
+		Secret:    hex.EncodeToString(buf[:]),
+		Events:    dbmodel.Strings(events),
+	}
+	if err := j.Database.AddModelWebhook(ctx, &wh); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &wh, nil
+}
+
+// ListModelWebhooks returns the webhooks registered on the given model.
+// The user must be an administrator of the model.
+func (j *JIMM) ListModelWebhooks(ctx context.Context, user *openfga.User, mt names.ModelTag) (_ []dbmodel.ModelWebhook, err error) {
+	const op = errors.Op("jimm.ListModelWebhooks")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	m, err := j.getModelWebhookModel(ctx, user, mt)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	webhooks, err := j.Database.ListModelWebhooks(ctx, m.ID)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return webhooks, nil
+}
+
+// RemoveModelWebhook removes the webhook with the given ID from the given
+// model. The user must be an administrator of the model.
+func (j *JIMM) RemoveModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, id uint) (err error) {
+	const op = errors.Op("jimm.RemoveModelWebhook")
+	ctx, span := tracing.Start(ctx, string(op))
+	defer tracing.End(span, &err)
+
+	m, err := j.getModelWebhookModel(ctx, user, mt)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	wh := dbmodel.ModelWebhook{ID: id}
+	if err := j.Database.GetModelWebhook(ctx, &wh); err != nil {
+		return errors.E(op, err)
+	}
+	if wh.ModelID != m.ID {
+		return errors.E(op, errors.CodeNotFound, "webhook not found")
+	}
+	if err := j.Database.DeleteModelWebhook(ctx, &wh); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// getModelWebhookModel returns the given model if the user may manage its
+// webhooks.
+func (j *JIMM) getModelWebhookModel(ctx context.Context, user *openfga.User, mt names.ModelTag) (*dbmodel.Model, error) {
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, err
+	}
+	if !user.JimmAdmin && user.GetModelAccess(ctx, mt) != ofganames.AdministratorRelation {
+		return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	return &m, nil
+}
+
+// A ModelEventNotifier is notified of status transitions in models.
+type ModelEventNotifier interface {
+	// NotifyModelEvent is called when the given event occurs in the
+	// model with the given database ID. Entity identifies the unit or
+	// machine concerned, if any. NotifyModelEvent must not block.
+	NotifyModelEvent(ctx context.Context, model *dbmodel.Model, event, entity, message string)
+}
+
+// A WebhookNotifier is a ModelEventNotifier that calls the webhooks
+// registered on the model.
+type WebhookNotifier struct {
+	// Database is the database holding the registered webhooks.
+	Database db.Database
+
+	// Sender delivers the notifications.
+	Sender *webhook.Sender
+}
+
+// NotifyModelEvent implements ModelEventNotifier. The webhooks are found
+// before NotifyModelEvent returns, so it is safe to remove the model
+// afterwards, but notifications are delivered in the background.
+func (n *WebhookNotifier) NotifyModelEvent(ctx context.Context, model *dbmodel.Model, event, entity, message string) {
+	webhooks, err := n.Database.ListModelWebhooks(ctx, model.ID)
+	if err != nil {
+		zapctx.Error(ctx, "cannot list model webhooks", zap.Error(err))
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+	if model.Name == "" {
+		if err := n.Database.GetModel(ctx, model); err != nil {
+			zapctx.Warn(ctx, "cannot get model", zap.Error(err))
+		}
+	}
+	payload, err := json.Marshal(apiparams.ModelWebhookEvent{
+		Event:     event,
+		ModelUUID: model.UUID.String,
+		ModelName: model.Name,
+		Entity:    entity,
+		Message:   message,
+		Time:      time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		zapctx.Error(ctx, "cannot marshal model webhook event", zap.Error(err))
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, wh := range webhooks {
+		if !wh.HasEvent(event) {
+			continue
+		}
+		wh := wh
+		go func() {
+			if err := n.Sender.Send(ctx, wh.URL, event, []byte(wh.Secret), payload); err != nil {
+				zapctx.Warn(ctx, "model webhook delivery failed", zap.Uint("webhook-id", wh.ID), zap.Error(err))
+			}
+		}()
+	}
+}
+
+// ModelEventNotifiers is a ModelEventNotifier that notifies each of the
+// notifiers in turn.
+type ModelEventNotifiers []ModelEventNotifier
+
+// NotifyModelEvent implements ModelEventNotifier.
+func (ns ModelEventNotifiers) NotifyModelEvent(ctx context.Context, model *dbmodel.Model, event, entity, message string) {
+	for _, n := range ns {
+		n.NotifyModelEvent(ctx, model, event, entity, message)
+	}
+}
+
+// An OwnerNotifier is a ModelEventNotifier that sends a notification to
+// the owner of the model when an event that needs their attention, such
+// as the model's credential becoming invalid, occurs.
+type OwnerNotifier struct {
+	JIMM *JIMM
+}
+
+// NotifyModelEvent implements ModelEventNotifier.
+func (n OwnerNotifier) NotifyModelEvent(ctx context.Context, model *dbmodel.Model, event, entity, message string) {
+	if event != ModelEventCredentialInvalid {
+		return
+	}
+	if model.OwnerIdentityName == "" {
+		if err := n.JIMM.Database.GetModel(ctx, model); err != nil {
+			zapctx.Warn(ctx, "cannot get model", zap.Error(err))
+			return
+		}
+	}
+	text := fmt.Sprintf("Model %s/%s (%s) has been suspended because its cloud credential %s is no longer valid. Update the credential to resume the model.", model.OwnerIdentityName, model.Name, model.UUID.String, model.CloudCredential.Name)
+	if message != "" {
+		text += "\n\n" + message
+	}
+	n.JIMM.sendNotification(ctx, model.OwnerIdentityName, notify.Notification{
+		Kind:    notify.KindCredentialExpiry,
+		Subject: fmt.Sprintf("Cloud credential for model %s is no longer valid", model.Name),
+		Message: text,
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/webhook"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func TestModelWebhooks(t *testing.T) {
+	ctx := context.Background()
+	c := qt.New(t)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), t.Name())
+	c.Assert(err, qt.IsNil)
+
+	// The test server listens on a loopback address.
+	allowed := []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		WebhookAllowedNetworks: allowed,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, modelInfoTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	mt := names.NewModelTag(env.Models[0].UUID)
+
+	_, err = j.AddModelWebhook(ctx, bob, mt, "https://203.0.113.10/hook", []string{jimm.ModelEventUnitError})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	_, err = j.AddModelWebhook(ctx, alice, mt, "not a url", []string{jimm.ModelEventUnitError})
+	c.Check(err, qt.ErrorMatches, "invalid webhook URL")
+	_, err = j.AddModelWebhook(ctx, alice, mt, "http://203.0.113.10/hook", []string{jimm.ModelEventUnitError})
+	c.Check(err, qt.ErrorMatches, "webhook URL must use https")
+	_, err = j.AddModelWebhook(ctx, alice, mt, "https://169.254.169.254/hook", []string{jimm.ModelEventUnitError})
+	c.Check(err, qt.ErrorMatches, "webhook destination 169.254.169.254 is not allowed")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	_, err = j.AddModelWebhook(ctx, alice, mt, "https://203.0.113.10/hook", []string{"no-such-event"})
+	c.Check(err, qt.ErrorMatches, "unknown event no-such-event")
+
+	received := make(chan apiparams.ModelWebhookEvent, 1)
+	var secret []byte
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(webhook.Verify(secret, req.Header, body, time.Now(), 0), qt.IsNil)
+		var ev apiparams.ModelWebhookEvent
+		c.Check(json.Unmarshal(body, &ev), qt.IsNil)
+		received <- ev
+	}))
+	defer srv.Close()
+
+	wh, err := j.AddModelWebhook(ctx, alice, mt, srv.URL, []string{jimm.ModelEventUnitError, jimm.ModelEventModelDestroyed})
+	c.Assert(err, qt.IsNil)
+	c.Assert(wh.Secret, qt.Not(qt.Equals), "")
+	secret = []byte(wh.Secret)
+
+	webhooks, err := j.ListModelWebhooks(ctx, alice, mt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(webhooks, qt.HasLen, 1)
+	c.Check(webhooks[0].URL, qt.Equals, srv.URL)
+	c.Check([]string(webhooks[0].Events), qt.DeepEquals, []string{jimm.ModelEventUnitError, jimm.ModelEventModelDestroyed})
+
+	n := jimm.WebhookNotifier{
+		Database: j.Database,
+		Sender: &webhook.Sender{
+			Client:          srv.Client(),
+			Backoff:         time.Millisecond,
+			AllowedNetworks: allowed,
+		},
+	}
+	m := env.Models[0].DBObject(c, j.Database)
+	// Events the webhook is not registered for are not delivered.
+	n.NotifyModelEvent(ctx, &m, jimm.ModelEventMachineDown, "machine-0", "")
+	n.NotifyModelEvent(ctx, &m, jimm.ModelEventUnitError, "unit-app-0", "hook failed")
+	select {
+	case ev := <-received:
+		c.Check(ev.Event, qt.Equals, jimm.ModelEventUnitError)
+		c.Check(ev.ModelUUID, qt.Equals, env.Models[0].UUID)
+		c.Check(ev.ModelName, qt.Equals, "model-1")
+		c.Check(ev.Entity, qt.Equals, "unit-app-0")
+		c.Check(ev.Message, qt.Equals, "hook failed")
+	case <-time.After(5 * time.Second):
+		c.Fatal("webhook not called")
+	}
+
+	err = j.RemoveModelWebhook(ctx, bob, mt, wh.ID)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	err = j.RemoveModelWebhook(ctx, alice, mt, wh.ID)
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveModelWebhook(ctx, alice, mt, wh.ID)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	webhooks, err = j.ListModelWebhooks(ctx, alice, mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(webhooks, qt.HasLen, 0)
+}
@@ -27,6 +27,7 @@ const (
 	WhoAmIEndpoint       = "/whoami"
 	LogOutEndpoint       = "/logout"
 	LoginEndpoint        = "/login"
+	BrandingEndpoint     = "/branding"
 )
 
 // OAuthHandler handles the oauth2.0 browser flow for JIMM.
@@ -35,6 +36,7 @@ type OAuthHandler struct {
 	Router                    *chi.Mux
 	authenticator             BrowserOAuthAuthenticator
 	dashboardFinalRedirectURL string
+	branding                  params.Branding
 }
 
 // OAuthHandlerParams holds the parameters to configure the OAuthHandler.
@@ -45,12 +47,16 @@ type OAuthHandlerParams struct {
 	// DashboardFinalRedirectURL is the final redirection URL to send users to
 	// upon completing the authorisation code flow.
 	DashboardFinalRedirectURL string
+
+	// Branding holds the branding and messaging shown to users on the
+	// login pages.
+	Branding params.Branding
 }
 
 // BrowserOAuthAuthenticator handles authorisation code authentication within JIMM
 // via OIDC.
 type BrowserOAuthAuthenticator interface {
-	AuthCodeURL() (string, string, error)
+	AuthCodeURL(hint string) (string, string, error)
 	Exchange(ctx context.Context, code string) (*oauth2.Token, error)
 	ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error)
 	Email(idToken *oidc.IDToken) (string, error)
@@ -78,6 +84,7 @@ func NewOAuthHandler(p OAuthHandlerParams) (*OAuthHandler, error) {
 		Router:                    chi.NewRouter(),
 		authenticator:             p.Authenticator,
 		dashboardFinalRedirectURL: p.DashboardFinalRedirectURL,
+		branding:                  p.Branding,
 	}, nil
 }
 
@@ -88,6 +95,7 @@ func (oah *OAuthHandler) Routes() chi.Router {
 	oah.Router.Get(CallbackEndpoint, oah.Callback)
 	oah.Router.Get(LogOutEndpoint, oah.Logout)
 	oah.Router.Get(WhoAmIEndpoint, oah.Whoami)
+	oah.Router.Get(BrandingEndpoint, oah.Branding)
 	return oah.Router
 }
 
@@ -95,10 +103,12 @@ func (oah *OAuthHandler) Routes() chi.Router {
 func (oah *OAuthHandler) SetupMiddleware() {
 }
 
-// Login handles /auth/login.
+// Login handles /auth/login. The optional "hint" query parameter selects
+// the identity provider, it may be the name of an issuer or the user's
+// email address or domain.
 func (oah *OAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	redirectURL, state, err := oah.authenticator.AuthCodeURL()
+	redirectURL, state, err := oah.authenticator.AuthCodeURL(r.URL.Query().Get("hint"))
 	if err != nil {
 		writeError(ctx, w, http.StatusInternalServerError, err, "failed to generate auth redirect URL")
 		return
@@ -140,6 +150,7 @@ func (oah *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 
 	authSvc := oah.authenticator
 
+	ctx = auth.ContextWithIssuer(ctx, auth.IssuerFromState(stateByURL))
 	token, err := authSvc.Exchange(ctx, code)
 	if err != nil {
 		writeError(ctx, w, http.StatusForbidden, err, "failed to exchange authcode")
@@ -231,3 +242,22 @@ func (oah *OAuthHandler) Whoami(w http.ResponseWriter, r *http.Request) {
 		zapctx.Error(ctx, "failed to write whoami body", zap.Error(err))
 	}
 }
+
+// Branding handles /auth/branding. It returns the organisation name, logo
+// URL and message of the day configured for JIMM, so that the login pages
+// can show users whose JAAS they are signing into. No authentication is
+// required.
+func (oah *OAuthHandler) Branding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	b, err := json.Marshal(oah.branding)
+	if err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, err, "failed to marshal branding")
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		zapctx.Error(ctx, "failed to write branding body", zap.Error(err))
+	}
+}
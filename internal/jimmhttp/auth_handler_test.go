@@ -204,3 +204,37 @@ func TestCallbackFailsExchange(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(string(b), qt.Equals, http.StatusText(http.StatusForbidden)+" - authorisation code exchange failed")
 }
+
+func TestBranding(t *testing.T) {
+	c := qt.New(t)
+
+	// The branding endpoint does not use the authenticator.
+	var authenticator struct {
+		jimmhttp.BrowserOAuthAuthenticator
+	}
+	h, err := jimmhttp.NewOAuthHandler(jimmhttp.OAuthHandlerParams{
+		Authenticator:             authenticator,
+		DashboardFinalRedirectURL: "https://dashboard.example.com",
+		Branding: params.Branding{
+			OrganisationName: "Example Org",
+			LogoURL:          "https://example.com/logo.svg",
+			MessageOfTheDay:  "Maintenance on Saturday.",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	s := httptest.NewServer(h.Routes())
+	defer s.Close()
+
+	res, err := http.Get(s.URL + jimmhttp.BrandingEndpoint)
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+	c.Check(res.Header.Get("Content-Type"), qt.Equals, "application/json")
+	b, err := io.ReadAll(res.Body)
+	c.Assert(err, qt.IsNil)
+	c.Check(string(b), qt.JSONEquals, params.Branding{
+		OrganisationName: "Example Org",
+		LogoURL:          "https://example.com/logo.svg",
+		MessageOfTheDay:  "Maintenance on Saturday.",
+	})
+}
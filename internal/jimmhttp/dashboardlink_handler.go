@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/middleware"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// These consts hold the endpoint paths for dashboard links, relative to
+// the path at which the DashboardLinkHandler is mounted.
+const (
+	DashboardLinkModelEndpoint      = "/models/{uuid}"
+	DashboardLinkControllerEndpoint = "/controllers/{name}"
+)
+
+// DashboardLinker resolves JIMM models and controllers to the URL of the
+// Juju Dashboard of the controller they belong to.
+type DashboardLinker interface {
+	middleware.JIMMAuthner
+	ModelDashboardURL(ctx context.Context, user *openfga.User, modelUUID string) (string, error)
+	ControllerDashboardURL(ctx context.Context, user *openfga.User, controllerName string) (string, error)
+}
+
+// DashboardLinkHandler redirects authenticated browsers to the Juju
+// Dashboard of the controller owning a model or controller, so that
+// "open in dashboard" links from JIMM-facing UIs only need to know the
+// JIMM identifier of the model or controller.
+// Implements jimmhttp.JIMMHttpHandler.
+type DashboardLinkHandler struct {
+	Router *chi.Mux
+	jimm   DashboardLinker
+}
+
+// NewDashboardLinkHandler returns a new DashboardLinkHandler.
+func NewDashboardLinkHandler(jimm DashboardLinker) *DashboardLinkHandler {
+	return &DashboardLinkHandler{Router: chi.NewRouter(), jimm: jimm}
+}
+
+// Routes returns the grouped routers routes with group specific middlewares.
+func (h *DashboardLinkHandler) Routes() chi.Router {
+	h.SetupMiddleware()
+	h.Router.Get(DashboardLinkModelEndpoint, h.ModelLink)
+	h.Router.Get(DashboardLinkControllerEndpoint, h.ControllerLink)
+	return h.Router
+}
+
+// SetupMiddleware applies the browser session authentication middleware.
+func (h *DashboardLinkHandler) SetupMiddleware() {
+	h.Router.Use(func(next http.Handler) http.Handler {
+		return middleware.AuthenticateUserViaCookie(next, h.jimm)
+	})
+}
+
+// ModelLink handles /models/{uuid}, redirecting to the model in the
+// dashboard of its controller.
+func (h *DashboardLinkHandler) ModelLink(w http.ResponseWriter, r *http.Request) {
+	h.redirect(w, r, func(ctx context.Context, user *openfga.User) (string, error) {
+		return h.jimm.ModelDashboardURL(ctx, user, chi.URLParam(r, "uuid"))
+	})
+}
+
+// ControllerLink handles /controllers/{name}, redirecting to the
+// dashboard of the controller.
+func (h *DashboardLinkHandler) ControllerLink(w http.ResponseWriter, r *http.Request) {
+	h.redirect(w, r, func(ctx context.Context, user *openfga.User) (string, error) {
+		return h.jimm.ControllerDashboardURL(ctx, user, chi.URLParam(r, "name"))
+	})
+}
+
+// redirect redirects the request to the dashboard URL returned by f for
+// the authenticated user.
+func (h *DashboardLinkHandler) redirect(w http.ResponseWriter, r *http.Request, f func(context.Context, *openfga.User) (string, error)) {
+	ctx := r.Context()
+
+	user, err := middleware.IdentityFromContext(ctx)
+	if err != nil {
+		writeError(ctx, w, http.StatusUnauthorized, err, "cannot authenticate user")
+		return
+	}
+	u, err := f(ctx, user)
+	if err != nil {
+		switch errors.ErrorCode(err) {
+		case errors.CodeBadRequest:
+			writeError(ctx, w, http.StatusBadRequest, err, "invalid dashboard link")
+		case errors.CodeUnauthorized:
+			writeError(ctx, w, http.StatusForbidden, err, "no access to the resource")
+		case errors.CodeNotFound:
+			writeError(ctx, w, http.StatusNotFound, err, "dashboard not found")
+		default:
+			writeError(ctx, w, http.StatusInternalServerError, err, "cannot find dashboard")
+		}
+		return
+	}
+	// The URL contains credentials so it must not be cached.
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, u, http.StatusFound)
+}
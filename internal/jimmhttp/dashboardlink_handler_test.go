@@ -0,0 +1,105 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// dashboardLinker is a DashboardLinker that authenticates every browser
+// session as alice and knows a single model and controller.
+type dashboardLinker struct {
+	authenticated bool
+}
+
+func (l dashboardLinker) AuthenticateBrowserSession(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, error) {
+	if !l.authenticated {
+		return ctx, errors.E(errors.CodeUnauthorized, "no session")
+	}
+	return auth.ContextWithSessionIdentity(ctx, "alice@canonical.com"), nil
+}
+
+func (l dashboardLinker) LoginWithSessionToken(ctx context.Context, sessionToken string) (*openfga.User, error) {
+	return nil, errors.E(errors.CodeNotImplemented)
+}
+
+func (l dashboardLinker) UserLogin(ctx context.Context, identityName string) (*openfga.User, error) {
+	return openfga.NewUser(&dbmodel.Identity{Name: identityName}, nil), nil
+}
+
+func (l dashboardLinker) ModelDashboardURL(ctx context.Context, user *openfga.User, modelUUID string) (string, error) {
+	if user.Name != "alice@canonical.com" {
+		return "", errors.E(errors.CodeUnauthorized)
+	}
+	if modelUUID != "00000002-0000-0000-0000-000000000001" {
+		return "", errors.E(errors.CodeNotFound, "model not found")
+	}
+	return "https://dashboard.example.com/models/alice@canonical.com/model-1?model-uuid=" + modelUUID, nil
+}
+
+func (l dashboardLinker) ControllerDashboardURL(ctx context.Context, user *openfga.User, controllerName string) (string, error) {
+	return "", errors.E(errors.CodeUnauthorized)
+}
+
+func TestDashboardLinks(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about            string
+		unauthenticated  bool
+		path             string
+		expectedStatus   int
+		expectedLocation string
+	}{{
+		about:            "model link redirects to the controller's dashboard",
+		path:             "/models/00000002-0000-0000-0000-000000000001",
+		expectedStatus:   http.StatusFound,
+		expectedLocation: "https://dashboard.example.com/models/alice@canonical.com/model-1?model-uuid=00000002-0000-0000-0000-000000000001",
+	}, {
+		about:          "unknown model",
+		path:           "/models/00000002-0000-0000-0000-000000000002",
+		expectedStatus: http.StatusNotFound,
+	}, {
+		about:          "controller link without access",
+		path:           "/controllers/controller-1",
+		expectedStatus: http.StatusForbidden,
+	}, {
+		about:           "unauthenticated",
+		unauthenticated: true,
+		path:            "/models/00000002-0000-0000-0000-000000000001",
+		expectedStatus:  http.StatusUnauthorized,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			h := jimmhttp.NewDashboardLinkHandler(dashboardLinker{authenticated: !test.unauthenticated})
+			s := httptest.NewServer(h.Routes())
+			defer s.Close()
+
+			client := &http.Client{
+				CheckRedirect: func(*http.Request, []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			}
+			res, err := client.Get(s.URL + test.path)
+			c.Assert(err, qt.IsNil)
+			defer res.Body.Close()
+			_, err = io.ReadAll(res.Body)
+			c.Assert(err, qt.IsNil)
+			c.Check(res.StatusCode, qt.Equals, test.expectedStatus)
+			c.Check(res.Header.Get("Location"), qt.Equals, test.expectedLocation)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// These consts hold the endpoint paths for the health checks, relative to
+// the path at which the HealthHandler is mounted.
+const (
+	HealthzEndpoint = "/healthz"
+	ReadyzEndpoint  = "/readyz"
+)
+
+// Health check statuses.
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// defaultHealthCheckTimeout is the time allowed for each health check if
+// the HealthHandler does not specify one.
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// A HealthCheck checks that a dependency of JIMM is available.
+type HealthCheck struct {
+	// Name is the name of the dependency, it is used as the key of the
+	// result in the response.
+	Name string
+
+	// Liveness records whether the check is part of the liveness check,
+	// served from /healthz. Every check is part of the readiness check,
+	// served from /readyz.
+	Liveness bool
+
+	// Check checks the dependency, returning an error if it is not
+	// available. Check may return a value describing the dependency,
+	// which is included in the response.
+	Check func(context.Context) (interface{}, error)
+}
+
+// HealthResponse is the body of a /healthz or /readyz response.
+type HealthResponse struct {
+	// Status is HealthStatusOK if all checks passed, otherwise it is
+	// HealthStatusUnavailable.
+	Status string `json:"status"`
+
+	// Checks holds the result of each check, keyed by name.
+	Checks map[string]HealthCheckResult `json:"checks"`
+}
+
+// HealthCheckResult is the result of a single health check.
+type HealthCheckResult struct {
+	// Status is HealthStatusOK if the check passed, otherwise it is
+	// HealthStatusUnavailable.
+	Status string `json:"status"`
+
+	// Value holds any value returned by a passing check.
+	Value interface{} `json:"value,omitempty"`
+
+	// Error holds the error returned by a failing check.
+	Error string `json:"error,omitempty"`
+
+	// Duration is the time the check took.
+	Duration time.Duration `json:"duration"`
+}
+
+// HealthHandler serves liveness and readiness checks for use by load
+// balancers and Kubernetes probes. Each endpoint runs its checks
+// concurrently and responds with the status of each dependency, the
+// response status is 503 if any check fails.
+// Implements jimmhttp.JIMMHttpHandler.
+type HealthHandler struct {
+	Router *chi.Mux
+
+	// Checks holds the checks to perform.
+	Checks []HealthCheck
+
+	// Timeout is the time allowed for each check. If this is zero a
+	// default of 5 seconds is used.
+	Timeout time.Duration
+}
+
+// NewHealthHandler returns a new HealthHandler performing the given
+// checks.
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{Router: chi.NewRouter(), Checks: checks}
+}
+
+// Routes returns the grouped routers routes with group specific middlewares.
+func (h *HealthHandler) Routes() chi.Router {
+	h.Router.Get(HealthzEndpoint, h.Healthz)
+	h.Router.Get(ReadyzEndpoint, h.Readyz)
+	return h.Router
+}
+
+// Healthz handles /healthz, performing the liveness checks.
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	var checks []HealthCheck
+	for _, check := range h.Checks {
+		if check.Liveness {
+			checks = append(checks, check)
+		}
+	}
+	h.serve(w, r, checks)
+}
+
+// Readyz handles /readyz, performing all checks.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.Checks)
+}
+
+func (h *HealthHandler) serve(w http.ResponseWriter, r *http.Request, checks []HealthCheck) {
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	resp := HealthResponse{
+		Status: HealthStatusOK,
+		Checks: make(map[string]HealthCheckResult, len(checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for _, check := range checks {
+		check := check
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			start := time.Now()
+			v, err := check.Check(ctx)
+			result := HealthCheckResult{
+				Status:   HealthStatusOK,
+				Value:    v,
+				Duration: time.Since(start),
+			}
+			if err != nil {
+				result.Status = HealthStatusUnavailable
+				result.Value = nil
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[check.Name] = result
+			if err != nil {
+				resp.Status = HealthStatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Health checks must always reflect the current state.
+	w.Header().Set("Cache-Control", "no-store")
+	if resp.Status != HealthStatusOK {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+)
+
+func TestHealthHandler(t *testing.T) {
+	c := qt.New(t)
+
+	controllerErr := errors.E("no controllers can be reached")
+	h := jimmhttp.NewHealthHandler(jimmhttp.HealthCheck{
+		Name:     "database",
+		Liveness: true,
+		Check: func(context.Context) (interface{}, error) {
+			return nil, nil
+		},
+	}, jimmhttp.HealthCheck{
+		Name: "controller",
+		Check: func(context.Context) (interface{}, error) {
+			if controllerErr != nil {
+				return nil, controllerErr
+			}
+			return "controller-1", nil
+		},
+	}, jimmhttp.HealthCheck{
+		Name: "slow",
+		Check: func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	h.Timeout = 10 * time.Millisecond
+	srv := httptest.NewServer(h.Routes())
+	defer srv.Close()
+
+	get := func(path string) (int, jimmhttp.HealthResponse) {
+		resp, err := http.Get(srv.URL + path)
+		c.Assert(err, qt.IsNil)
+		defer resp.Body.Close()
+		c.Check(resp.Header.Get("Cache-Control"), qt.Equals, "no-store")
+		var hr jimmhttp.HealthResponse
+		err = json.NewDecoder(resp.Body).Decode(&hr)
+		c.Assert(err, qt.IsNil)
+		return resp.StatusCode, hr
+	}
+
+	// Liveness only runs the liveness checks.
+	code, hr := get(jimmhttp.HealthzEndpoint)
+	c.Check(code, qt.Equals, http.StatusOK)
+	c.Check(hr.Status, qt.Equals, jimmhttp.HealthStatusOK)
+	c.Check(hr.Checks, qt.HasLen, 1)
+	c.Check(hr.Checks["database"].Status, qt.Equals, jimmhttp.HealthStatusOK)
+
+	// Readiness reports the status of every dependency.
+	code, hr = get(jimmhttp.ReadyzEndpoint)
+	c.Check(code, qt.Equals, http.StatusServiceUnavailable)
+	c.Check(hr.Status, qt.Equals, jimmhttp.HealthStatusUnavailable)
+	c.Check(hr.Checks, qt.HasLen, 3)
+	c.Check(hr.Checks["database"].Status, qt.Equals, jimmhttp.HealthStatusOK)
+	c.Check(hr.Checks["controller"], qt.DeepEquals, jimmhttp.HealthCheckResult{
+		Status:   jimmhttp.HealthStatusUnavailable,
+		Error:    "no controllers can be reached",
+		Duration: hr.Checks["controller"].Duration,
+	})
+	c.Check(hr.Checks["slow"].Status, qt.Equals, jimmhttp.HealthStatusUnavailable)
+	c.Check(hr.Checks["slow"].Error, qt.Equals, "context deadline exceeded")
+
+	controllerErr = nil
+	_, hr = get(jimmhttp.ReadyzEndpoint)
+	c.Check(hr.Checks["controller"].Status, qt.Equals, jimmhttp.HealthStatusOK)
+	c.Check(hr.Checks["controller"].Value, qt.Equals, "controller-1")
+}
@@ -63,11 +63,15 @@ func (hph *HTTPProxyHandler) ProxyHTTP(w http.ResponseWriter, req *http.Request)
 		writeError(ctx, w, http.StatusNotFound, err, "cannot get model")
 		return
 	}
-	u, p, err := hph.jimm.GetCredentialStore().GetControllerCredentials(ctx, model.Controller.Name)
+	u, p, err := hph.jimm.ControllerCredentialStore().GetControllerCredentials(ctx, model.Controller.Name)
 	if err != nil {
 		writeError(ctx, w, http.StatusNotFound, err, "cannot retrieve credentials")
 		return
 	}
+	if err := hph.jimm.LoadControllerCACertificate(ctx, &model.Controller); err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, err, "cannot retrieve controller CA certificate")
+		return
+	}
 	req.SetBasicAuth(names.NewUserTag(u).String(), p)
 
 	err = rpc.ProxyHTTP(ctx, &model.Controller, w, req)
@@ -0,0 +1,252 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/fips"
+)
+
+// TLSParams holds the parameters used to configure TLS on JIMM's HTTP
+// listeners.
+type TLSParams struct {
+	// CertFile and KeyFile hold the paths of the PEM encoded certificate
+	// and private key to serve. If either is empty TLS is not enabled.
+	CertFile string
+	KeyFile  string
+
+	// MinVersion holds the minimum TLS version to accept, for example
+	// "1.2" or "1.3". If this is empty TLS 1.2 is used.
+	MinVersion string
+
+	// CipherSuites holds the names of the cipher suites to allow for
+	// TLS 1.2 and earlier connections, as named by crypto/tls. If this
+	// is empty the Go defaults are used.
+	CipherSuites []string
+
+	// ReloadInterval is how often the certificate files are checked for
+	// changes. If this is zero the certificate is never reloaded.
+	ReloadInterval time.Duration
+
+	// FIPS restricts the TLS configuration to FIPS approved algorithms.
+	// An error is returned if the requested configuration is not
+	// compliant.
+	FIPS bool
+
+	// ClientCAFile, if set, holds the path of a PEM encoded bundle of CA
+	// certificates. Clients must present a certificate signed by one of
+	// these CAs.
+	ClientCAFile string
+}
+
+// Enabled reports whether TLS has been configured.
+func (p TLSParams) Enabled() bool {
+	return p.CertFile != "" && p.KeyFile != ""
+}
+
+// NewTLSConfig returns a tls.Config implementing the policy in the given
+// parameters. The certificate is served by a CertificateReloader which,
+// if p.ReloadInterval is non-zero, watches the certificate files for
+// changes until the given context is cancelled.
+func NewTLSConfig(ctx context.Context, p TLSParams) (*tls.Config, error) {
+	const op = errors.Op("jimmhttp.NewTLSConfig")
+
+	minVersion, err := ParseTLSVersion(p.MinVersion)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	cipherSuites, err := ParseCipherSuites(p.CipherSuites)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var curves []tls.CurveID
+	if p.FIPS {
+		if err := fips.CheckTLS(minVersion, cipherSuites); err != nil {
+			return nil, errors.E(op, err)
+		}
+		if len(cipherSuites) == 0 {
+			cipherSuites = fips.CipherSuites
+		}
+		curves = fips.CurvePreferences
+	}
+	reloader, err := NewCertificateReloader(p.CertFile, p.KeyFile)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	config := &tls.Config{
+		MinVersion:       minVersion,
+		CipherSuites:     cipherSuites,
+		CurvePreferences: curves,
+		GetCertificate:   reloader.GetCertificate,
+	}
+	if p.ClientCAFile != "" {
+		buf, err := os.ReadFile(p.ClientCAFile)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, errors.E(op, fmt.Sprintf("no certificates found in %q", p.ClientCAFile))
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if p.ReloadInterval > 0 {
+		go reloader.Watch(ctx, p.ReloadInterval)
+	}
+	return config, nil
+}
+
+// ParseTLSVersion parses a TLS version such as "1.2" into its crypto/tls
+// constant. An empty string is treated as TLS 1.2.
+func ParseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported TLS version %q", v))
+	}
+}
+
+// ParseCipherSuites converts the given cipher suite names into their
+// crypto/tls identifiers. Only suites that crypto/tls considers secure are
+// accepted. A nil slice is returned if no names are given.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, cs := range tls.CipherSuites() {
+		known[cs.Name] = cs.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported cipher suite %q", name))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// A CertificateReloader serves a certificate loaded from disk, reloading
+// it when the underlying files change.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewCertificateReloader returns a CertificateReloader that serves the
+// certificate in the given files. The certificate is loaded immediately
+// and an error is returned if that fails.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Watch checks the certificate files for changes every interval,
+// reloading the certificate when they are modified. Watch returns when
+// the given context is cancelled. If a reload fails the previous
+// certificate continues to be served.
+func (r *CertificateReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := r.changed()
+			if err != nil {
+				zapctx.Error(ctx, "cannot check certificate files", zap.Error(err))
+				continue
+			}
+			if !changed {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				zapctx.Error(ctx, "cannot reload certificate", zap.Error(err))
+				continue
+			}
+			zapctx.Info(ctx, "reloaded TLS certificate", zap.String("cert-file", r.certFile))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// changed reports whether either certificate file has been modified
+// since the certificate was last loaded.
+func (r *CertificateReloader) changed() (bool, error) {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !modTime.Equal(r.modTime), nil
+}
+
+func (r *CertificateReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, errors.E(err)
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (r *CertificateReloader) reload() error {
+	const op = errors.Op("jimmhttp.CertificateReloader.reload")
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical.
+
+package jimmhttp_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	c := qt.New(t)
+
+	v, err := jimmhttp.ParseTLSVersion("")
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, uint16(tls.VersionTLS12))
+
+	v, err = jimmhttp.ParseTLSVersion("1.3")
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, uint16(tls.VersionTLS13))
+
+	_, err = jimmhttp.ParseTLSVersion("2.0")
+	c.Check(err, qt.ErrorMatches, `unsupported TLS version "2.0"`)
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	c := qt.New(t)
+
+	ids, err := jimmhttp.ParseCipherSuites(nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.IsNil)
+
+	ids, err = jimmhttp.ParseCipherSuites([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256})
+
+	_, err = jimmhttp.ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+	c.Check(err, qt.ErrorMatches, `unsupported cipher suite "TLS_RSA_WITH_RC4_128_SHA"`)
+}
+
+func TestCertificateReloader(t *testing.T) {
+	c := qt.New(t)
+
+	dir := c.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCertificate(c, certFile, keyFile, "first")
+
+	r, err := jimmhttp.NewCertificateReloader(certFile, keyFile)
+	c.Assert(err, qt.IsNil)
+	c.Check(certificateName(c, r), qt.Equals, "first")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	writeCertificate(c, certFile, keyFile, "second")
+	// Ensure the modification time changes even on filesystems with
+	// coarse timestamps.
+	future := time.Now().Add(time.Minute)
+	c.Assert(os.Chtimes(certFile, future, future), qt.IsNil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for certificateName(c, r) != "second" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Check(certificateName(c, r), qt.Equals, "second")
+}
+
+func TestNewTLSConfigClientCA(t *testing.T) {
+	c := qt.New(t)
+
+	dir := c.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCertificate(c, certFile, keyFile, "server")
+
+	config, err := jimmhttp.NewTLSConfig(context.Background(), jimmhttp.TLSParams{
+		CertFile: certFile,
+		KeyFile:  keyFile,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(config.ClientAuth, qt.Equals, tls.NoClientCert)
+
+	config, err = jimmhttp.NewTLSConfig(context.Background(), jimmhttp.TLSParams{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: certFile,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(config.ClientAuth, qt.Equals, tls.RequireAndVerifyClientCert)
+	c.Check(config.ClientCAs, qt.Not(qt.IsNil))
+
+	_, err = jimmhttp.NewTLSConfig(context.Background(), jimmhttp.TLSParams{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: keyFile,
+	})
+	c.Check(err, qt.ErrorMatches, `no certificates found in ".*key.pem"`)
+}
+
+func certificateName(c *qt.C, r *jimmhttp.CertificateReloader) string {
+	cert, err := r.GetCertificate(nil)
+	c.Assert(err, qt.IsNil)
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	c.Assert(err, qt.IsNil)
+	return x509Cert.Subject.CommonName
+}
+
+func writeCertificate(c *qt.C, certFile, keyFile, name string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, qt.IsNil)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	c.Assert(err, qt.IsNil)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	c.Assert(err, qt.IsNil)
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	c.Assert(err, qt.IsNil)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	c.Assert(err, qt.IsNil)
+}
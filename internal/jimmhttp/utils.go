@@ -5,7 +5,9 @@ package jimmhttp
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"strings"
 
@@ -72,3 +74,55 @@ func writeError(ctx context.Context, w http.ResponseWriter, status int, err erro
 		zapctx.Error(ctx, "failed to write status text error", zap.Error(err))
 	}
 }
+
+// ClientAddr returns the network address of the client that made the
+// given request. If the request was received directly from one of the
+// trusted proxies, the X-Forwarded-For header is used to find the
+// address of the client: the header is read from right to left and the
+// first address that is not a trusted proxy is returned. The header is
+// ignored on requests from any other address, as it can be set to
+// anything by the client.
+func ClientAddr(req *http.Request, trustedProxies []netip.Prefix) string {
+	if len(trustedProxies) == 0 || !trusted(req.RemoteAddr, trustedProxies) {
+		return req.RemoteAddr
+	}
+	var hops []string
+	for _, v := range req.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	addr := req.RemoteAddr
+	for i := len(hops) - 1; i >= 0; i-- {
+		if _, err := netip.ParseAddr(hops[i]); err != nil {
+			// The proxies are trusted to send valid addresses, an
+			// invalid one can only have come from the client.
+			break
+		}
+		addr = hops[i]
+		if !trusted(addr, trustedProxies) {
+			break
+		}
+	}
+	return addr
+}
+
+// trusted reports whether the host part of addr falls within any of the
+// given prefixes.
+func trusted(addr string, prefixes []netip.Prefix) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ package jimmhttp_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -67,3 +68,61 @@ func TestStripPathElement(t *testing.T) {
 		})
 	}
 }
+
+var clientAddrTests = []struct {
+	name          string
+	remoteAddr    string
+	xForwardedFor []string
+	expect        string
+}{{
+	name:       "no_header",
+	remoteAddr: "10.0.0.1:1234",
+	expect:     "10.0.0.1:1234",
+}, {
+	name:          "untrusted_peer",
+	remoteAddr:    "192.0.2.1:1234",
+	xForwardedFor: []string{"198.51.100.1"},
+	expect:        "192.0.2.1:1234",
+}, {
+	name:          "trusted_peer",
+	remoteAddr:    "10.0.0.1:1234",
+	xForwardedFor: []string{"198.51.100.1"},
+	expect:        "198.51.100.1",
+}, {
+	name:          "spoofed_entries_ignored",
+	remoteAddr:    "10.0.0.1:1234",
+	xForwardedFor: []string{"203.0.113.1, 198.51.100.1"},
+	expect:        "198.51.100.1",
+}, {
+	name:          "chained_proxies",
+	remoteAddr:    "10.0.0.1:1234",
+	xForwardedFor: []string{"198.51.100.1, 10.0.0.2", "10.0.0.3"},
+	expect:        "198.51.100.1",
+}, {
+	name:          "invalid_entry",
+	remoteAddr:    "10.0.0.1:1234",
+	xForwardedFor: []string{"not-an-address, 10.0.0.2"},
+	expect:        "10.0.0.2",
+}}
+
+func TestClientAddr(t *testing.T) {
+	c := qt.New(t)
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	for _, test := range clientAddrTests {
+		c.Run(test.name, func(c *qt.C) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			for _, v := range test.xForwardedFor {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			c.Check(jimmhttp.ClientAddr(req, trusted), qt.Equals, test.expect)
+		})
+	}
+
+	// Without trusted proxies the header is never used.
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	c.Check(jimmhttp.ClientAddr(req, nil), qt.Equals, "10.0.0.1:1234")
+}
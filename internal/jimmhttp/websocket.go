@@ -6,12 +6,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
@@ -25,6 +27,10 @@ type WSHandler struct {
 	// Server is the websocket server that will handle the websocket
 	// connection.
 	Server WSServer
+
+	// TrustedProxies holds the addresses of the proxies whose
+	// X-Forwarded-For headers are used to find the client address.
+	TrustedProxies []netip.Prefix
 }
 
 // ServeHTTP implements http.Handler by upgrading the HTTP request to a
@@ -37,6 +43,7 @@ func (h *WSHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	ctx = auth.ContextWithRemoteAddr(ctx, ClientAddr(req, h.TrustedProxies))
 
 	ctx, authErr := h.Server.Authenticate(ctx, w, req)
 	if authErr != nil {
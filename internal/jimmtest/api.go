@@ -145,6 +145,8 @@ type API struct {
 	GrantModelAccess_                  func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 	IsBroken_                          bool
 	ListApplicationOffers_             func(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	MigrationTargetPrechecks_          func(context.Context, *jujuparams.MigrationModelInfo) error
+	ModelGet_                          func(context.Context) (map[string]interface{}, error)
 	ModelInfo_                         func(context.Context, *jujuparams.ModelInfo) error
 	ModelStatus_                       func(context.Context, *jujuparams.ModelStatus) error
 	ModelSummaryWatcherNext_           func(context.Context, string) ([]jujuparams.ModelAbstract, error)
@@ -158,6 +160,7 @@ type API struct {
 	RevokeCloudAccess_                 func(context.Context, names.CloudTag, names.UserTag, string) error
 	RevokeCredential_                  func(context.Context, names.CloudCredentialTag) error
 	RevokeModelAccess_                 func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
+	SetPassword_                       func(context.Context, names.UserTag, string) error
 	SupportsCheckCredentialModels_     bool
 	SupportsModelSummaryWatcher_       bool
 	Status_                            func(context.Context, []string) (*jujuparams.FullStatus, error)
@@ -330,6 +333,20 @@ func (a *API) ListApplicationOffers(ctx context.Context, f []jujuparams.OfferFil
 	return a.ListApplicationOffers_(ctx, f)
 }
 
+func (a *API) MigrationTargetPrechecks(ctx context.Context, info *jujuparams.MigrationModelInfo) error {
+	if a.MigrationTargetPrechecks_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.MigrationTargetPrechecks_(ctx, info)
+}
+
+func (a *API) ModelGet(ctx context.Context) (map[string]interface{}, error) {
+	if a.ModelGet_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelGet_(ctx)
+}
+
 func (a *API) ModelInfo(ctx context.Context, mi *jujuparams.ModelInfo) error {
 	if a.ModelInfo_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -407,6 +424,13 @@ func (a *API) RevokeModelAccess(ctx context.Context, mt names.ModelTag, ut names
 	return a.RevokeModelAccess_(ctx, mt, ut, p)
 }
 
+func (a *API) SetPassword(ctx context.Context, user names.UserTag, password string) error {
+	if a.SetPassword_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.SetPassword_(ctx, user, password)
+}
+
 func (a *API) SupportsCheckCredentialModels() bool {
 	return a.SupportsCheckCredentialModels_
 }
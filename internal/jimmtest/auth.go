@@ -33,6 +33,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	jimmerrors "github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
@@ -75,6 +76,7 @@ type mockOAuthAuthenticator struct {
 	PollingChan     <-chan string
 	polledUsername  string
 	mockAccessToken string
+	sessionKeys     []string
 }
 
 // NewMockOAuthAuthenticator creates a mock authenticator for tests. An channel can be passed in
@@ -136,6 +138,21 @@ func (m *mockOAuthAuthenticator) VerifySessionToken(token string) (jwt.Token, er
 	return parsedToken, nil
 }
 
+// SessionKeys returns the keys set with SetSessionKeys, or a single test
+// key if none have been set.
+func (m *mockOAuthAuthenticator) SessionKeys() []string {
+	if len(m.sessionKeys) == 0 {
+		return []string{"test-secret"}
+	}
+	return m.sessionKeys
+}
+
+// SetSessionKeys records the given keys. Session tokens are not verified
+// by the mock, so the keys have no other effect.
+func (m *mockOAuthAuthenticator) SetSessionKeys(keys []string) {
+	m.sessionKeys = keys
+}
+
 // ExtractAndVerifyIDToken returns an ID token where the subject is equal to the username obtained during the device flow.
 // The auth token must match the one returned during the device flow.
 // If the polled username is empty it indicates an error that the device flow was not run prior to calling this function.
@@ -159,6 +176,11 @@ func (m *mockOAuthAuthenticator) UpdateIdentity(ctx context.Context, email strin
 	return nil
 }
 
+// SyncIdentityProfile is a no-op mock.
+func (m *mockOAuthAuthenticator) SyncIdentityProfile(ctx context.Context, identity *dbmodel.Identity) error {
+	return nil
+}
+
 // MintSessionToken creates an unsigned session token with the email provided.
 func (m *mockOAuthAuthenticator) MintSessionToken(email string) (string, error) {
 	return newSessionToken(m.c, email, ""), nil
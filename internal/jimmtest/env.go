@@ -5,6 +5,7 @@ package jimmtest
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"strings"
 
@@ -36,6 +37,9 @@ type Environment struct {
 	Models           []Model           `json:"models"`
 	Users            []User            `json:"users"`
 	UserDefaults     []UserDefaults    `json:"user-defaults"`
+	Units            []Unit            `json:"units"`
+	Offers           []Offer           `json:"application-offers"`
+	Secrets          []Secret          `json:"secrets"`
 }
 
 func ParseEnvironment(c Tester, env string) *Environment {
@@ -106,6 +110,36 @@ func (e *Environment) Model(owner, name string) *Model {
 	return nil
 }
 
+func (e *Environment) Offer(name string) *Offer {
+	for i := range e.Offers {
+		if e.Offers[i].Name == name {
+			e.Offers[i].env = e
+			return &e.Offers[i]
+		}
+	}
+	return nil
+}
+
+func (e *Environment) Secret(secretType, tag string) *Secret {
+	for i := range e.Secrets {
+		if e.Secrets[i].Type == secretType && e.Secrets[i].Tag == tag {
+			return &e.Secrets[i]
+		}
+	}
+	return nil
+}
+
+// ModelUnits returns the units declared in the given model.
+func (e *Environment) ModelUnits(owner, model string) []Unit {
+	var units []Unit
+	for _, u := range e.Units {
+		if u.ModelOwner == owner && u.Model == model {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
 func (e *Environment) User(name string) *User {
 	for i := range e.Users {
 		if e.Users[i].Username == name {
@@ -180,6 +214,30 @@ func (m Model) addModelRelations(c *qt.C, db db.Database, client *openfga.OFGACl
 	c.Assert(err, qt.IsNil)
 }
 
+// addOfferRelations adds permissions the application offer should have and adds permissions for users to the offer.
+func (o Offer) addOfferRelations(c *qt.C, db db.Database, client *openfga.OFGAClient) {
+	err := client.AddModelApplicationOffer(context.Background(), o.dbo.Model.ResourceTag(), o.dbo.ResourceTag())
+	c.Assert(err, qt.IsNil)
+
+	for _, u := range o.Users {
+		dbUser := o.env.User(u.User).DBObject(c, db)
+		var relation openfga.Relation
+		switch u.Access {
+		case "admin":
+			relation = ofganames.AdministratorRelation
+		case "consume":
+			relation = ofganames.ConsumerRelation
+		case "read":
+			relation = ofganames.ReaderRelation
+		default:
+			c.Fatalf("unknown offer access: %s %s", dbUser.Name, u.Access)
+		}
+		user := openfga.NewUser(&dbUser, client)
+		err := user.SetApplicationOfferAccess(context.Background(), o.dbo.ResourceTag(), relation)
+		c.Assert(err, qt.IsNil)
+	}
+}
+
 // addControllerRelations adds permissions the model should have and adds permissions for users to the controller.
 func (ctl Controller) addControllerRelations(c *qt.C, client *openfga.OFGAClient) {
 	if ctl.dbo.AdminIdentityName != "" {
@@ -211,6 +269,9 @@ func (e *Environment) addJIMMRelations(c *qt.C, jimmTag names.ControllerTag, db
 	for _, m := range e.Models {
 		m.addModelRelations(c, db, client)
 	}
+	for _, o := range e.Offers {
+		o.addOfferRelations(c, db, client)
+	}
 	for _, ctl := range e.Controllers {
 		ctl.addControllerRelations(c, client)
 	}
@@ -252,6 +313,13 @@ func (e *Environment) PopulateDB(c Tester, db db.Database) {
 		e.UserDefaults[i].env = e
 		e.UserDefaults[i].DBObject(c, db)
 	}
+	for i := range e.Offers {
+		e.Offers[i].env = e
+		e.Offers[i].DBObject(c, db)
+	}
+	for i := range e.Secrets {
+		e.Secrets[i].DBObject(c, db)
+	}
 }
 
 // UserDefaults represents user's default configuration for a new model.
@@ -494,6 +562,9 @@ func (m *Model) DBObject(c Tester, db db.Database) dbmodel.Model {
 	m.dbo.Cores = m.Cores
 	m.dbo.Machines = m.Machines
 	m.dbo.Units = m.Units
+	if m.dbo.Units == 0 {
+		m.dbo.Units = int64(len(m.env.ModelUnits(m.Owner, m.Name)))
+	}
 
 	err := db.AddModel(context.Background(), &m.dbo)
 	if err != nil {
@@ -503,6 +574,109 @@ func (m *Model) DBObject(c Tester, db db.Database) dbmodel.Model {
 	return m.dbo
 }
 
+// A Unit represents the definition of a unit in a test environment. JIMM
+// does not store units, units declared in a model are counted in the
+// model's unit count unless an explicit count is given.
+type Unit struct {
+	ModelOwner  string `json:"model-owner"`
+	Model       string `json:"model"`
+	Application string `json:"application"`
+	Name        string `json:"name"`
+	Machine     string `json:"machine"`
+	Life        string `json:"life"`
+}
+
+// An Offer represents the definition of an application offer in a test
+// environment.
+type Offer struct {
+	Name                   string          `json:"name"`
+	UUID                   string          `json:"uuid"`
+	URL                    string          `json:"url"`
+	ModelOwner             string          `json:"model-owner"`
+	Model                  string          `json:"model"`
+	Application            string          `json:"application"`
+	ApplicationDescription string          `json:"application-description"`
+	CharmURL               string          `json:"charm-url"`
+	Endpoints              []OfferEndpoint `json:"endpoints"`
+	Users                  []UserAccess    `json:"users"`
+
+	env *Environment
+	dbo dbmodel.ApplicationOffer
+}
+
+// An OfferEndpoint represents an endpoint of an application offer in a
+// test environment.
+type OfferEndpoint struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Interface string `json:"interface"`
+	Limit     int    `json:"limit"`
+}
+
+func (o *Offer) DBObject(c Tester, db db.Database) dbmodel.ApplicationOffer {
+	if o.dbo.ID != 0 {
+		return o.dbo
+	}
+	m := o.env.Model(o.ModelOwner, o.Model)
+	if m == nil {
+		c.Fatalf("unknown model for offer %s: %s/%s", o.Name, o.ModelOwner, o.Model)
+	}
+	o.dbo.Model = m.DBObject(c, db)
+	o.dbo.ModelID = o.dbo.Model.ID
+	o.dbo.Name = o.Name
+	o.dbo.UUID = o.UUID
+	o.dbo.URL = o.URL
+	if o.dbo.URL == "" {
+		o.dbo.URL = o.ModelOwner + "/" + o.Model + "." + o.Name
+	}
+	o.dbo.ApplicationName = o.Application
+	o.dbo.ApplicationDescription = o.ApplicationDescription
+	o.dbo.CharmURL = o.CharmURL
+	for _, ep := range o.Endpoints {
+		o.dbo.Endpoints = append(o.dbo.Endpoints, dbmodel.ApplicationOfferRemoteEndpoint{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+		})
+	}
+
+	err := db.AddApplicationOffer(context.Background(), &o.dbo)
+	if err != nil {
+		c.Fatalf("err is not nil: %s", err)
+	}
+
+	return o.dbo
+}
+
+// A Secret represents the definition of a stored secret in a test
+// environment. The data is stored as the JSON encoding of the given map.
+type Secret struct {
+	Type string            `json:"type"`
+	Tag  string            `json:"tag"`
+	Data map[string]string `json:"data"`
+
+	dbo dbmodel.Secret
+}
+
+func (s *Secret) DBObject(c Tester, db db.Database) dbmodel.Secret {
+	if s.dbo.ID != 0 {
+		return s.dbo
+	}
+	data, err := json.Marshal(s.Data)
+	if err != nil {
+		c.Fatalf("err is not nil: %s", err)
+	}
+	s.dbo = dbmodel.NewSecret(s.Type, s.Tag, data)
+
+	err = db.UpsertSecret(context.Background(), &s.dbo)
+	if err != nil {
+		c.Fatalf("err is not nil: %s", err)
+	}
+
+	return s.dbo
+}
+
 type User struct {
 	Username         string `json:"username"`
 	DisplayName      string `json:"display-name"`
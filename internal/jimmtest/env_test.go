@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical.
+
+package jimmtest_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+const testOffersSecretsUnitsEnv = `
+models:
+- name: model-1
+  owner: alice@canonical.com
+  uuid: 00000002-0000-0000-0000-000000000001
+units:
+- model-owner: alice@canonical.com
+  model: model-1
+  application: app-1
+  name: app-1/0
+  machine: "0"
+- model-owner: alice@canonical.com
+  model: model-1
+  application: app-1
+  name: app-1/1
+  machine: "1"
+application-offers:
+- name: offer-1
+  uuid: 00000012-0000-0000-0000-000000000001
+  model-owner: alice@canonical.com
+  model: model-1
+  application: app-1
+  endpoints:
+  - name: db
+    role: provider
+    interface: mysql
+  users:
+  - user: bob@canonical.com
+    access: consume
+secrets:
+- type: controller
+  tag: controller-1
+  data:
+    username: admin
+    password: secret
+`
+
+func TestParseEnvironmentOffersSecretsUnits(t *testing.T) {
+	c := qt.New(t)
+
+	env := jimmtest.ParseEnvironment(c, testOffersSecretsUnitsEnv)
+
+	o := env.Offer("offer-1")
+	c.Assert(o, qt.IsNotNil)
+	c.Check(o.Model, qt.Equals, "model-1")
+	c.Check(o.Endpoints, qt.DeepEquals, []jimmtest.OfferEndpoint{{Name: "db", Role: "provider", Interface: "mysql"}})
+	c.Check(o.Users, qt.DeepEquals, []jimmtest.UserAccess{{User: "bob@canonical.com", Access: "consume"}})
+	c.Check(env.Offer("offer-2"), qt.IsNil)
+
+	units := env.ModelUnits("alice@canonical.com", "model-1")
+	c.Assert(units, qt.HasLen, 2)
+	c.Check(units[1].Name, qt.Equals, "app-1/1")
+	c.Check(env.ModelUnits("bob@canonical.com", "model-1"), qt.HasLen, 0)
+
+	s := env.Secret("controller", "controller-1")
+	c.Assert(s, qt.IsNotNil)
+	c.Check(s.Data, qt.DeepEquals, map[string]string{"username": "admin", "password": "secret"})
+}
@@ -0,0 +1,393 @@
+// Copyright 2024 Canonical.
+
+package jimmtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// estateTuplesPerWrite is the number of tuples written to OpenFGA in
+// each request when populating an estate.
+const estateTuplesPerWrite = 100
+
+// EstateParams holds the parameters of a generated estate.
+type EstateParams struct {
+	// Seed seeds the random choices made when generating the estate,
+	// estates generated with the same parameters are identical.
+	Seed int64
+
+	// Clouds is the number of clouds, each with RegionsPerCloud
+	// regions. If either is zero then 1 is used.
+	Clouds          int
+	RegionsPerCloud int
+
+	// Controllers is the number of controllers, which are spread evenly
+	// across the clouds.
+	Controllers int
+
+	// Users is the number of users.
+	Users int
+
+	// Groups is the number of groups. Each user is a member of a small
+	// number of groups.
+	Groups int
+
+	// Models is the number of models. Model ownership follows a Zipf
+	// distribution, so a few users own many models and most users own
+	// few or none. Each model is shared with a small number of other
+	// users and occasionally with a group.
+	Models int
+}
+
+// An Estate is a generated set of clouds, controllers, users, groups
+// and models, along with the access users and groups have to the
+// models. Entities refer to each other by name so that the estate can
+// be generated without a database.
+type Estate struct {
+	Clouds      []EstateCloud
+	Controllers []EstateController
+	Users       []string
+	Groups      []EstateGroup
+	Models      []EstateModel
+}
+
+// An EstateCloud is a cloud in a generated estate.
+type EstateCloud struct {
+	Name    string
+	Type    string
+	Regions []string
+}
+
+// An EstateController is a controller in a generated estate.
+type EstateController struct {
+	Name         string
+	UUID         string
+	Cloud        string
+	Region       string
+	AgentVersion string
+}
+
+// An EstateGroup is a group in a generated estate.
+type EstateGroup struct {
+	Name    string
+	Members []string
+}
+
+// An EstateModel is a model in a generated estate.
+type EstateModel struct {
+	Name       string
+	UUID       string
+	Owner      string
+	Controller string
+	Cloud      string
+	Region     string
+	Life       string
+	Machines   int64
+	Units      int64
+	Access     []EstateAccess
+}
+
+// An EstateAccess is access granted on a model to a user, or if User is
+// empty, to the members of a group.
+type EstateAccess struct {
+	User     string
+	Group    string
+	Relation openfga.Relation
+}
+
+// estateCloudTypes holds the types given to generated clouds.
+var estateCloudTypes = []string{"ec2", "gce", "azure", "openstack", "kubernetes"}
+
+// GenerateEstate generates an estate with the given parameters. The
+// estate only depends on the parameters, so performance measurements
+// made against estates generated with the same parameters can be
+// compared.
+func GenerateEstate(p EstateParams) *Estate {
+	//nolint:gosec // The estate is deliberately predictable.
+	r := rand.New(rand.NewSource(p.Seed))
+	id := func(kind string, i int) string {
+		return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("%d/%s/%d", p.Seed, kind, i))).String()
+	}
+	var e Estate
+
+	nClouds := max(p.Clouds, 1)
+	nRegions := max(p.RegionsPerCloud, 1)
+	for i := 0; i < nClouds; i++ {
+		cl := EstateCloud{
+			Name: fmt.Sprintf("cloud-%d", i),
+			Type: estateCloudTypes[i%len(estateCloudTypes)],
+		}
+		for j := 0; j < nRegions; j++ {
+			cl.Regions = append(cl.Regions, fmt.Sprintf("region-%d", j))
+		}
+		e.Clouds = append(e.Clouds, cl)
+	}
+
+	for i := 0; i < p.Controllers; i++ {
+		cl := e.Clouds[i%len(e.Clouds)]
+		ctl := EstateController{
+			Name:         fmt.Sprintf("controller-%d", i),
+			UUID:         id("controller", i),
+			Cloud:        cl.Name,
+			Region:       cl.Regions[r.Intn(len(cl.Regions))],
+			AgentVersion: fmt.Sprintf("3.%d.%d", 1+r.Intn(4), r.Intn(10)),
+		}
+		e.Controllers = append(e.Controllers, ctl)
+	}
+
+	for i := 0; i < p.Users; i++ {
+		e.Users = append(e.Users, fmt.Sprintf("user-%d@canonical.com", i))
+	}
+
+	for i := 0; i < p.Groups; i++ {
+		e.Groups = append(e.Groups, EstateGroup{Name: fmt.Sprintf("group-%d", i)})
+	}
+	if len(e.Groups) > 0 {
+		for _, u := range e.Users {
+			// Most users are in one or two groups, some are in none.
+			n := r.Intn(4)
+			seen := make(map[int]bool)
+			for j := 0; j < n; j++ {
+				g := r.Intn(len(e.Groups))
+				if seen[g] {
+					continue
+				}
+				seen[g] = true
+				e.Groups[g].Members = append(e.Groups[g].Members, u)
+			}
+		}
+	}
+
+	if len(e.Users) == 0 || len(e.Controllers) == 0 {
+		return &e
+	}
+	owners := rand.NewZipf(r, 1.2, 1, uint64(len(e.Users)-1))
+	for i := 0; i < p.Models; i++ {
+		ctl := e.Controllers[r.Intn(len(e.Controllers))]
+		cl := e.Clouds[0]
+		for _, c := range e.Clouds {
+			if c.Name == ctl.Cloud {
+				cl = c
+			}
+		}
+		m := EstateModel{
+			Name:       fmt.Sprintf("model-%d", i),
+			UUID:       id("model", i),
+			Owner:      e.Users[owners.Uint64()],
+			Controller: ctl.Name,
+			Cloud:      cl.Name,
+			Region:     cl.Regions[r.Intn(len(cl.Regions))],
+			Life:       "alive",
+			Machines:   int64(r.ExpFloat64() * 5),
+		}
+		if r.Intn(50) == 0 {
+			m.Life = "dying"
+		}
+		m.Units = m.Machines + int64(r.ExpFloat64()*float64(m.Machines))
+
+		m.Access = append(m.Access, EstateAccess{User: m.Owner, Relation: ofganames.AdministratorRelation})
+		shared := map[string]bool{m.Owner: true}
+		// The number of users a model is shared with is geometrically
+		// distributed with a mean of about 1.
+		for r.Intn(2) == 0 {
+			u := e.Users[r.Intn(len(e.Users))]
+			if shared[u] {
+				continue
+			}
+			shared[u] = true
+			relation := ofganames.ReaderRelation
+			switch n := r.Intn(20); {
+			case n == 0:
+				relation = ofganames.AdministratorRelation
+			case n < 6:
+				relation = ofganames.WriterRelation
+			}
+			m.Access = append(m.Access, EstateAccess{User: u, Relation: relation})
+		}
+		if len(e.Groups) > 0 && r.Intn(5) == 0 {
+			g := e.Groups[r.Intn(len(e.Groups))]
+			m.Access = append(m.Access, EstateAccess{Group: g.Name, Relation: ofganames.ReaderRelation})
+		}
+		e.Models = append(e.Models, m)
+	}
+	return &e
+}
+
+// Populate adds the estate to the given database and writes the
+// relation tuples describing the estate to OpenFGA. The JIMM controller
+// is given a controller relation to every controller in the estate, and
+// everyone may add models to the clouds. Each model owner is given a
+// cloud credential for the model's cloud. The database must not already
+// contain any of the entities in the estate.
+func (e *Estate) Populate(ctx context.Context, database *db.Database, client *openfga.OFGAClient, jimmTag names.ControllerTag) error {
+	const op = errors.Op("jimmtest.Estate.Populate")
+
+	var tuples []openfga.Tuple
+	everyone := ofganames.ConvertTag(names.NewUserTag(ofganames.EveryoneUser))
+
+	clouds := make(map[string]*dbmodel.Cloud, len(e.Clouds))
+	for _, c := range e.Clouds {
+		cl := dbmodel.Cloud{
+			Name:      c.Name,
+			Type:      c.Type,
+			AuthTypes: dbmodel.Strings{"empty"},
+		}
+		for _, r := range c.Regions {
+			cl.Regions = append(cl.Regions, dbmodel.CloudRegion{Name: r})
+		}
+		if err := database.AddCloud(ctx, &cl); err != nil {
+			return errors.E(op, err)
+		}
+		clouds[cl.Name] = &cl
+		tuples = append(tuples, openfga.Tuple{
+			Object:   everyone,
+			Relation: ofganames.CanAddModelRelation,
+			Target:   ofganames.ConvertTag(cl.ResourceTag()),
+		})
+	}
+
+	identities := make(map[string]dbmodel.Identity, len(e.Users))
+	for _, u := range e.Users {
+		i := dbmodel.Identity{Name: u}
+		if err := database.GetIdentity(ctx, &i); err != nil {
+			return errors.E(op, err)
+		}
+		identities[u] = i
+	}
+
+	groups := make(map[string]*dbmodel.GroupEntry, len(e.Groups))
+	for _, g := range e.Groups {
+		ge, err := database.AddGroup(ctx, g.Name)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		groups[g.Name] = ge
+		for _, u := range g.Members {
+			tuples = append(tuples, openfga.Tuple{
+				Object:   ofganames.ConvertTag(names.NewUserTag(u)),
+				Relation: ofganames.MemberRelation,
+				Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(ge.UUID)),
+			})
+		}
+	}
+
+	controllers := make(map[string]*dbmodel.Controller, len(e.Controllers))
+	for _, c := range e.Controllers {
+		cl := clouds[c.Cloud]
+		ctl := dbmodel.Controller{
+			Name:          c.Name,
+			UUID:          c.UUID,
+			CloudName:     c.Cloud,
+			CloudRegion:   c.Region,
+			AgentVersion:  c.AgentVersion,
+			PublicAddress: c.Name + ".example.com:443",
+		}
+		for _, r := range cl.Regions {
+			var priority uint = dbmodel.CloudRegionControllerPrioritySupported
+			if r.Name == c.Region {
+				priority = dbmodel.CloudRegionControllerPriorityDeployed
+			}
+			ctl.CloudRegions = append(ctl.CloudRegions, dbmodel.CloudRegionControllerPriority{
+				CloudRegion: r,
+				Priority:    priority,
+			})
+		}
+		if err := database.AddController(ctx, &ctl); err != nil {
+			return errors.E(op, err)
+		}
+		controllers[ctl.Name] = &ctl
+		tuples = append(tuples, openfga.Tuple{
+			Object:   ofganames.ConvertTag(jimmTag),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(ctl.ResourceTag()),
+		}, openfga.Tuple{
+			Object:   ofganames.ConvertTag(ctl.ResourceTag()),
+			Relation: ofganames.ControllerRelation,
+			Target:   ofganames.ConvertTag(cl.ResourceTag()),
+		})
+	}
+
+	credentials := make(map[string]dbmodel.CloudCredential)
+	for _, m := range e.Models {
+		cl := clouds[m.Cloud]
+		ctl := controllers[m.Controller]
+		owner := identities[m.Owner]
+
+		credKey := m.Owner + "/" + m.Cloud
+		cred, ok := credentials[credKey]
+		if !ok {
+			cred = dbmodel.CloudCredential{
+				Name:              "default",
+				CloudName:         cl.Name,
+				Cloud:             *cl,
+				OwnerIdentityName: owner.Name,
+				Owner:             owner,
+				AuthType:          "empty",
+			}
+			if err := database.SetCloudCredential(ctx, &cred); err != nil {
+				return errors.E(op, err)
+			}
+			credentials[credKey] = cred
+		}
+
+		model := dbmodel.Model{
+			Name:            m.Name,
+			UUID:            sql.NullString{String: m.UUID, Valid: true},
+			Owner:           owner,
+			Controller:      *ctl,
+			CloudRegion:     cl.Region(m.Region),
+			CloudCredential: cred,
+			Type:            "iaas",
+			Life:            m.Life,
+			Machines:        m.Machines,
+			Units:           m.Units,
+		}
+		if cl.Type == "kubernetes" {
+			model.Type = "caas"
+		}
+		model.Status.Status = "available"
+		if err := database.AddModel(ctx, &model); err != nil {
+			return errors.E(op, err)
+		}
+		mt := ofganames.ConvertTag(model.ResourceTag())
+		tuples = append(tuples, openfga.Tuple{
+			Object:   ofganames.ConvertTag(ctl.ResourceTag()),
+			Relation: ofganames.ControllerRelation,
+			Target:   mt,
+		})
+		for _, a := range m.Access {
+			t := openfga.Tuple{
+				Relation: a.Relation,
+				Target:   mt,
+			}
+			if a.User != "" {
+				t.Object = ofganames.ConvertTag(names.NewUserTag(a.User))
+			} else {
+				t.Object = ofganames.ConvertTagWithRelation(jimmnames.NewGroupTag(groups[a.Group].UUID), ofganames.MemberRelation)
+			}
+			tuples = append(tuples, t)
+		}
+	}
+
+	for len(tuples) > 0 {
+		n := min(len(tuples), estateTuplesPerWrite)
+		if err := client.AddRelation(ctx, tuples[:n]...); err != nil {
+			return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		tuples = tuples[n:]
+	}
+	return nil
+}
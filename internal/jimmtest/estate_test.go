@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical.
+
+package jimmtest_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestGenerateEstate(t *testing.T) {
+	c := qt.New(t)
+
+	p := jimmtest.EstateParams{
+		Seed:            1,
+		Clouds:          3,
+		RegionsPerCloud: 2,
+		Controllers:     10,
+		Users:           200,
+		Groups:          10,
+		Models:          1000,
+	}
+	e := jimmtest.GenerateEstate(p)
+	c.Check(e.Clouds, qt.HasLen, 3)
+	c.Check(e.Controllers, qt.HasLen, 10)
+	c.Check(e.Users, qt.HasLen, 200)
+	c.Check(e.Groups, qt.HasLen, 10)
+	c.Assert(e.Models, qt.HasLen, 1000)
+
+	// The same parameters always generate the same estate.
+	c.Check(jimmtest.GenerateEstate(p), qt.DeepEquals, e)
+	p.Seed = 2
+	c.Check(jimmtest.GenerateEstate(p), qt.Not(qt.DeepEquals), e)
+
+	controllers := make(map[string]jimmtest.EstateController)
+	for _, ctl := range e.Controllers {
+		controllers[ctl.Name] = ctl
+	}
+	owned := make(map[string]int)
+	for _, m := range e.Models {
+		c.Assert(controllers[m.Controller].Cloud, qt.Equals, m.Cloud)
+		c.Assert(m.Access[0], qt.Equals, jimmtest.EstateAccess{User: m.Owner, Relation: "administrator"})
+		owned[m.Owner]++
+	}
+	// Ownership is skewed, the most prolific owner has many more models
+	// than an even distribution would give.
+	most := 0
+	for _, n := range owned {
+		most = max(most, n)
+	}
+	c.Check(most > 5*1000/200, qt.IsTrue, qt.Commentf("most models owned by one user: %d", most))
+}
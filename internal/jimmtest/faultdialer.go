@@ -0,0 +1,550 @@
+// Copyright 2024 Canonical.
+
+package jimmtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
+	"github.com/juju/juju/core/crossmodel"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+// ErrDisconnected is the error returned by calls made on a connection
+// that a FaultDialer has disconnected.
+var ErrDisconnected = errors.E(errors.CodeConnectionFailed, "connection is shut down")
+
+// ControllerFaults describes the faults a FaultDialer injects into the
+// connections to a simulated controller.
+type ControllerFaults struct {
+	// DialLatency is the time each dial takes to complete.
+	DialLatency time.Duration
+
+	// DialErr, if not nil, is returned from every dial.
+	DialErr error
+
+	// CallLatency is the time added to each API call.
+	CallLatency time.Duration
+
+	// CallErr, if not nil, is returned from every API call instead of
+	// making the call.
+	CallErr error
+
+	// DisconnectAfter, if positive, is the number of API calls a
+	// connection serves before it is disconnected.
+	DisconnectAfter int
+}
+
+// A FaultDialer is a jimm.Dialer that simulates a number of distinct
+// controllers, each with its own Dialer, and injects latency, errors
+// and disconnections into the connections made to them. Faults may be
+// changed while connections are open, allowing controller selection,
+// fan-out and retry logic to be tested.
+type FaultDialer struct {
+	mu          sync.Mutex
+	controllers map[string]*faultController
+}
+
+// faultController holds the state of a controller simulated by a
+// FaultDialer.
+type faultController struct {
+	dialer *Dialer
+	faults ControllerFaults
+	dials  int
+	calls  int
+	conns  map[*faultConn]bool
+}
+
+// NewFaultDialer returns a new FaultDialer without any controllers.
+func NewFaultDialer() *FaultDialer {
+	return &FaultDialer{
+		controllers: make(map[string]*faultController),
+	}
+}
+
+// AddController adds a controller with the given name. Connections to the
+// controller are made using the given Dialer, with the given faults
+// injected.
+func (d *FaultDialer) AddController(name string, dialer *Dialer, faults ControllerFaults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.controllers[name] = &faultController{
+		dialer: dialer,
+		faults: faults,
+		conns:  make(map[*faultConn]bool),
+	}
+}
+
+// SetFaults changes the faults injected into the connections to the
+// named controller, including those that are already open.
+func (d *FaultDialer) SetFaults(name string, faults ControllerFaults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.controllers[name]; ok {
+		c.faults = faults
+	}
+}
+
+// Disconnect disconnects every open connection to the named controller.
+// Subsequent calls on those connections fail with ErrDisconnected.
+func (d *FaultDialer) Disconnect(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.controllers[name]; ok {
+		for conn := range c.conns {
+			conn.broken = true
+		}
+	}
+}
+
+// Dials returns the number of times the named controller has been dialed,
+// including failed attempts.
+func (d *FaultDialer) Dials(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.controllers[name]; ok {
+		return c.dials
+	}
+	return 0
+}
+
+// Calls returns the number of API calls made to the named controller,
+// including those that failed because of an injected fault.
+func (d *FaultDialer) Calls(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.controllers[name]; ok {
+		return c.calls
+	}
+	return 0
+}
+
+// Dial implements jimm.Dialer.
+func (d *FaultDialer) Dial(ctx context.Context, ctl *dbmodel.Controller, mt names.ModelTag, requiredPermissions map[string]string) (jimm.API, error) {
+	d.mu.Lock()
+	c, ok := d.controllers[ctl.Name]
+	if !ok {
+		d.mu.Unlock()
+		return nil, errors.E(fmt.Sprintf("dialer not configured for controller %s", ctl.Name))
+	}
+	c.dials++
+	faults := c.faults
+	d.mu.Unlock()
+
+	if err := sleepContext(ctx, faults.DialLatency); err != nil {
+		return nil, err
+	}
+	if faults.DialErr != nil {
+		return nil, faults.DialErr
+	}
+	api, err := c.dialer.Dial(ctx, ctl, mt, requiredPermissions)
+	if err != nil {
+		return nil, err
+	}
+	conn := &faultConn{
+		API:        api,
+		dialer:     d,
+		controller: c,
+	}
+	d.mu.Lock()
+	c.conns[conn] = true
+	d.mu.Unlock()
+	return conn, nil
+}
+
+// sleepContext waits for the given duration, or until the context is
+// done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// faultConn is a connection to a controller simulated by a FaultDialer.
+type faultConn struct {
+	jimm.API
+
+	dialer     *FaultDialer
+	controller *faultController
+
+	// The fields below are protected by dialer.mu.
+	calls  int
+	broken bool
+}
+
+// fault applies the faults configured for the controller to an API call,
+// returning the error the call should fail with, if any.
+func (c *faultConn) fault(ctx context.Context) error {
+	c.dialer.mu.Lock()
+	c.controller.calls++
+	c.calls++
+	faults := c.controller.faults
+	if faults.DisconnectAfter > 0 && c.calls > faults.DisconnectAfter {
+		c.broken = true
+	}
+	broken := c.broken
+	c.dialer.mu.Unlock()
+
+	if broken {
+		return ErrDisconnected
+	}
+	if err := sleepContext(ctx, faults.CallLatency); err != nil {
+		return err
+	}
+	return faults.CallErr
+}
+
+// APICall implements base.APICaller.APICall.
+func (c *faultConn) APICall(objType string, version int, id, request string, params, response interface{}) error {
+	if err := c.fault(context.Background()); err != nil {
+		return err
+	}
+	return c.API.APICall(objType, version, id, request, params, response)
+}
+
+// Close closes the connection.
+func (c *faultConn) Close() error {
+	c.dialer.mu.Lock()
+	delete(c.controller.conns, c)
+	c.dialer.mu.Unlock()
+	return c.API.Close()
+}
+
+// IsBroken reports whether the connection has been disconnected.
+func (c *faultConn) IsBroken() bool {
+	c.dialer.mu.Lock()
+	broken := c.broken
+	c.dialer.mu.Unlock()
+	return broken || c.API.IsBroken()
+}
+
+func (c *faultConn) AddCloud(ctx context.Context, tag names.CloudTag, cld jujuparams.Cloud, force bool) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.AddCloud(ctx, tag, cld, force)
+}
+
+func (c *faultConn) AllModelWatcherNext(ctx context.Context, id string) ([]jujuparams.Delta, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.AllModelWatcherNext(ctx, id)
+}
+
+func (c *faultConn) AllModelWatcherStop(ctx context.Context, id string) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.AllModelWatcherStop(ctx, id)
+}
+
+func (c *faultConn) CheckCredentialModels(ctx context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.CheckCredentialModels(ctx, cred)
+}
+
+func (c *faultConn) Cloud(ctx context.Context, tag names.CloudTag, ci *jujuparams.Cloud) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.Cloud(ctx, tag, ci)
+}
+
+func (c *faultConn) CloudInfo(ctx context.Context, tag names.CloudTag, ci *jujuparams.CloudInfo) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.CloudInfo(ctx, tag, ci)
+}
+
+func (c *faultConn) Clouds(ctx context.Context) (map[names.CloudTag]jujuparams.Cloud, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.Clouds(ctx)
+}
+
+func (c *faultConn) ControllerModelSummary(ctx context.Context, ms *jujuparams.ModelSummary) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ControllerModelSummary(ctx, ms)
+}
+
+func (c *faultConn) CreateModel(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.CreateModel(ctx, args, mi)
+}
+
+func (c *faultConn) DestroyApplicationOffer(ctx context.Context, offerURL string, force bool) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.DestroyApplicationOffer(ctx, offerURL, force)
+}
+
+func (c *faultConn) DestroyModel(ctx context.Context, tag names.ModelTag, destroyStorage *bool, force *bool, maxWait, timeout *time.Duration) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.DestroyModel(ctx, tag, destroyStorage, force, maxWait, timeout)
+}
+
+func (c *faultConn) DumpModel(ctx context.Context, mt names.ModelTag, simplified bool) (string, error) {
+	if err := c.fault(ctx); err != nil {
+		return "", err
+	}
+	return c.API.DumpModel(ctx, mt, simplified)
+}
+
+func (c *faultConn) DumpModelDB(ctx context.Context, mt names.ModelTag) (map[string]interface{}, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.DumpModelDB(ctx, mt)
+}
+
+func (c *faultConn) FindApplicationOffers(ctx context.Context, f []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.FindApplicationOffers(ctx, f)
+}
+
+func (c *faultConn) GetApplicationOffer(ctx context.Context, offer *jujuparams.ApplicationOfferAdminDetailsV5) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GetApplicationOffer(ctx, offer)
+}
+
+func (c *faultConn) GetApplicationOfferConsumeDetails(ctx context.Context, tag names.UserTag, cod *jujuparams.ConsumeOfferDetails, v bakery.Version) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GetApplicationOfferConsumeDetails(ctx, tag, cod, v)
+}
+
+func (c *faultConn) GrantApplicationOfferAccess(ctx context.Context, offerURL string, tag names.UserTag, p jujuparams.OfferAccessPermission) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GrantApplicationOfferAccess(ctx, offerURL, tag, p)
+}
+
+func (c *faultConn) GrantCloudAccess(ctx context.Context, ct names.CloudTag, ut names.UserTag, access string) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GrantCloudAccess(ctx, ct, ut, access)
+}
+
+func (c *faultConn) GrantJIMMModelAdmin(ctx context.Context, tag names.ModelTag) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GrantJIMMModelAdmin(ctx, tag)
+}
+
+func (c *faultConn) GrantModelAccess(ctx context.Context, mt names.ModelTag, ut names.UserTag, p jujuparams.UserAccessPermission) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.GrantModelAccess(ctx, mt, ut, p)
+}
+
+func (c *faultConn) ListApplicationOffers(ctx context.Context, f []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ListApplicationOffers(ctx, f)
+}
+
+func (c *faultConn) ModelInfo(ctx context.Context, mi *jujuparams.ModelInfo) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ModelInfo(ctx, mi)
+}
+
+func (c *faultConn) ModelStatus(ctx context.Context, ms *jujuparams.ModelStatus) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ModelStatus(ctx, ms)
+}
+
+func (c *faultConn) ModelSummaryWatcherNext(ctx context.Context, id string) ([]jujuparams.ModelAbstract, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ModelSummaryWatcherNext(ctx, id)
+}
+
+func (c *faultConn) ModelSummaryWatcherStop(ctx context.Context, id string) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ModelSummaryWatcherStop(ctx, id)
+}
+
+func (c *faultConn) Offer(ctx context.Context, offerURL crossmodel.OfferURL, aao jujuparams.AddApplicationOffer) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.Offer(ctx, offerURL, aao)
+}
+
+func (c *faultConn) Ping(ctx context.Context) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.Ping(ctx)
+}
+
+func (c *faultConn) RemoveCloud(ctx context.Context, tag names.CloudTag) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.RemoveCloud(ctx, tag)
+}
+
+func (c *faultConn) RevokeApplicationOfferAccess(ctx context.Context, offerURL string, tag names.UserTag, p jujuparams.OfferAccessPermission) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.RevokeApplicationOfferAccess(ctx, offerURL, tag, p)
+}
+
+func (c *faultConn) RevokeCloudAccess(ctx context.Context, ct names.CloudTag, ut names.UserTag, access string) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.RevokeCloudAccess(ctx, ct, ut, access)
+}
+
+func (c *faultConn) RevokeCredential(ctx context.Context, tag names.CloudCredentialTag) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.RevokeCredential(ctx, tag)
+}
+
+func (c *faultConn) RevokeModelAccess(ctx context.Context, mt names.ModelTag, ut names.UserTag, p jujuparams.UserAccessPermission) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.RevokeModelAccess(ctx, mt, ut, p)
+}
+
+func (c *faultConn) Status(ctx context.Context, patterns []string) (*jujuparams.FullStatus, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.Status(ctx, patterns)
+}
+
+func (c *faultConn) UpdateCloud(ctx context.Context, tag names.CloudTag, cloud jujuparams.Cloud) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.UpdateCloud(ctx, tag, cloud)
+}
+
+func (c *faultConn) UpdateCredential(ctx context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.UpdateCredential(ctx, cred)
+}
+
+func (c *faultConn) ValidateModelUpgrade(ctx context.Context, tag names.ModelTag, force bool) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ValidateModelUpgrade(ctx, tag, force)
+}
+
+func (c *faultConn) WatchAllModelSummaries(ctx context.Context) (string, error) {
+	if err := c.fault(ctx); err != nil {
+		return "", err
+	}
+	return c.API.WatchAllModelSummaries(ctx)
+}
+
+func (c *faultConn) WatchAllModels(ctx context.Context) (string, error) {
+	if err := c.fault(ctx); err != nil {
+		return "", err
+	}
+	return c.API.WatchAllModels(ctx)
+}
+
+func (c *faultConn) ChangeModelCredential(ctx context.Context, model names.ModelTag, cred names.CloudCredentialTag) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ChangeModelCredential(ctx, model, cred)
+}
+
+func (c *faultConn) ModelWatcherNext(ctx context.Context, id string) ([]jujuparams.Delta, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ModelWatcherNext(ctx, id)
+}
+
+func (c *faultConn) ModelWatcherStop(ctx context.Context, id string) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	return c.API.ModelWatcherStop(ctx, id)
+}
+
+func (c *faultConn) WatchAll(ctx context.Context) (string, error) {
+	if err := c.fault(ctx); err != nil {
+		return "", err
+	}
+	return c.API.WatchAll(ctx)
+}
+
+func (c *faultConn) ListFilesystems(ctx context.Context, machines []string) ([]jujuparams.FilesystemDetailsListResult, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ListFilesystems(ctx, machines)
+}
+
+func (c *faultConn) ListVolumes(ctx context.Context, machines []string) ([]jujuparams.VolumeDetailsListResult, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ListVolumes(ctx, machines)
+}
+
+func (c *faultConn) ListStorageDetails(ctx context.Context) ([]jujuparams.StorageDetails, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return c.API.ListStorageDetails(ctx)
+}
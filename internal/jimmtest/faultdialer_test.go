@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical.
+
+package jimmtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestFaultDialer(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	d := jimmtest.NewFaultDialer()
+	d.AddController("controller-1", &jimmtest.Dialer{
+		API:  &jimmtest.API{},
+		UUID: "00000001-0000-0000-0000-000000000001",
+	}, jimmtest.ControllerFaults{DisconnectAfter: 2})
+	d.AddController("controller-2", &jimmtest.Dialer{
+		API:  &jimmtest.API{},
+		UUID: "00000001-0000-0000-0000-000000000002",
+	}, jimmtest.ControllerFaults{DialErr: errors.E("controller-2 is down")})
+
+	ctl1 := dbmodel.Controller{Name: "controller-1"}
+	api, err := d.Dial(ctx, &ctl1, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl1.UUID, qt.Equals, "00000001-0000-0000-0000-000000000001")
+	c.Check(api.Ping(ctx), qt.IsNil)
+	c.Check(api.Ping(ctx), qt.IsNil)
+	c.Check(api.Ping(ctx), qt.Equals, jimmtest.ErrDisconnected)
+	c.Check(api.IsBroken(), qt.IsTrue)
+	c.Check(api.Close(), qt.IsNil)
+	c.Check(d.Calls("controller-1"), qt.Equals, 3)
+
+	_, err = d.Dial(ctx, &dbmodel.Controller{Name: "controller-2"}, names.ModelTag{}, nil)
+	c.Check(err, qt.ErrorMatches, "controller-2 is down")
+	c.Check(d.Dials("controller-2"), qt.Equals, 1)
+
+	_, err = d.Dial(ctx, &dbmodel.Controller{Name: "controller-3"}, names.ModelTag{}, nil)
+	c.Check(err, qt.ErrorMatches, "dialer not configured for controller controller-3")
+
+	// Faults can be changed on open connections.
+	api, err = d.Dial(ctx, &ctl1, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	defer api.Close()
+	d.SetFaults("controller-1", jimmtest.ControllerFaults{CallErr: errors.E("call failed")})
+	c.Check(api.Ping(ctx), qt.ErrorMatches, "call failed")
+
+	d.SetFaults("controller-1", jimmtest.ControllerFaults{CallLatency: time.Minute})
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	c.Check(api.Ping(cctx), qt.Equals, context.DeadlineExceeded)
+
+	d.SetFaults("controller-1", jimmtest.ControllerFaults{})
+	c.Check(api.Ping(ctx), qt.IsNil)
+	d.Disconnect("controller-1")
+	c.Check(api.Ping(ctx), qt.Equals, jimmtest.ErrDisconnected)
+	c.Check(api.IsBroken(), qt.IsTrue)
+}
@@ -37,10 +37,16 @@ type JIMM struct {
 	AddAuditLogEntry_                  func(ale *dbmodel.AuditLogEntry)
 	AddCloudToController_              func(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddHostedCloud_                    func(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
-	AddServiceAccount_                 func(ctx context.Context, u *openfga.User, clientId string) error
+	AddMaintenanceWindow_              func(ctx context.Context, user *openfga.User, controllerName string, startsAt, endsAt time.Time, reason string) (*dbmodel.MaintenanceWindow, error)
+	AddModelAsync_                     func(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (*dbmodel.Task, error)
+	AddModelWebhook_                   func(ctx context.Context, user *openfga.User, mt names.ModelTag, webhookURL string, events []string) (*dbmodel.ModelWebhook, error)
+	AddNotificationRoute_              func(ctx context.Context, user *openfga.User, subject, kind, transport, destination string) (*jimm.NotificationRoute, error)
+	AddServiceAccount_                 func(ctx context.Context, u *openfga.User, clientId, purpose string) error
+	Backup_                            func(ctx context.Context, user *openfga.User) (*jimm.Backup, error)
 	Authenticate_                      func(ctx context.Context, req *jujuparams.LoginRequest) (*openfga.User, error)
 	CheckPermission_                   func(ctx context.Context, user *openfga.User, cachedPerms map[string]string, desiredPerms map[string]interface{}) (map[string]string, error)
 	CopyServiceAccountCredential_      func(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
+	DeleteServiceAccount_              func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error
 	DestroyOffer_                      func(ctx context.Context, user *openfga.User, offerURL string, force bool) error
 	FindApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	FindAuditEvents_                   func(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
@@ -54,9 +60,24 @@ type JIMM struct {
 	GetCloudCredentialAttributes_      func(ctx context.Context, u *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error)
 	GetCredentialStore_                func() jimmcreds.CredentialStore
 	GetJimmControllerAccess_           func(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error)
+	GetMaintenanceMode_                func(ctx context.Context) (*dbmodel.MaintenanceMode, error)
+	GetModelArchive_                   func(ctx context.Context, user *openfga.User, modelUUID string) (*dbmodel.ModelArchive, error)
+	GetModelConfigTemplate_            func(ctx context.Context, user *openfga.User, path string) (*dbmodel.ModelConfigTemplate, error)
 	FetchIdentity_                     func(ctx context.Context, username string) (*openfga.User, error)
 	CountIdentities_                   func(ctx context.Context, user *openfga.User) (int, error)
+	GetTask_                           func(ctx context.Context, user *openfga.User, id uint) (*dbmodel.Task, error)
+	GrantControllerAccess_             func(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error)
+	ListCloudCredentials_              func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error)
+	ListExpiringCredentials_           func(ctx context.Context, user *openfga.User, within time.Duration) ([]dbmodel.CloudCredential, error)
 	ListIdentities_                    func(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]openfga.User, error)
+	ListLimits_                        func(ctx context.Context, user *openfga.User) ([]jimm.LimitUsage, error)
+	ListMaintenanceWindows_            func(ctx context.Context, user *openfga.User, controllerName string, all bool) ([]dbmodel.MaintenanceWindow, error)
+	ListMigrations_                    func(ctx context.Context, user *openfga.User, filter db.MigrationFilter) ([]dbmodel.Migration, error)
+	ListModelConfigPolicies_           func(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigPolicy, error)
+	ListModelAliases_                  func(ctx context.Context, user *openfga.User) ([]dbmodel.ModelAlias, error)
+	ListModelWebhooks_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]dbmodel.ModelWebhook, error)
+	ListModelConfigTemplates_          func(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigTemplate, error)
+	ListNotificationRoutes_            func(ctx context.Context, user *openfga.User) ([]jimm.NotificationRoute, error)
 	GetUserCloudAccess_                func(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error)
 	GetUserControllerAccess_           func(ctx context.Context, user *openfga.User, controller names.ControllerTag) (string, error)
 	GetUserModelAccess_                func(ctx context.Context, user *openfga.User, model names.ModelTag) (string, error)
@@ -68,31 +89,65 @@ type JIMM struct {
 	InitiateMigration_                 func(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	InitiateInternalMigration_         func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ListDeprecatedFacadeUsage_         func(ctx context.Context, user *openfga.User) ([]jimm.DeprecatedFacadeUsage, error)
 	ListResources_                     func(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error)
+	ListServiceAccounts_               func(ctx context.Context, u *openfga.User) ([]dbmodel.ServiceAccount, error)
+	ListSessions_                      func(ctx context.Context, user *openfga.User, identityName string) ([]dbmodel.Session, error)
 	Offer_                             func(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	PubSubHub_                         func() *pubsub.Hub
 	PurgeLogs_                         func(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
+	RecordDeprecatedFacadeUsage_       func(ctx context.Context, user *openfga.User, facade string, version int)
 	RemoveCloud_                       func(ctx context.Context, u *openfga.User, ct names.CloudTag) error
 	RemoveCloudFromController_         func(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
+	RemoveLimit_                       func(ctx context.Context, user *openfga.User, entity, scope string) error
+	RemoveMaintenanceWindow_           func(ctx context.Context, user *openfga.User, id uint) error
+	RemoveModelConfigPolicy_           func(ctx context.Context, user *openfga.User, key string) error
+	RemoveModelAlias_                  func(ctx context.Context, user *openfga.User, alias string) error
+	RemoveModelConfigTemplate_         func(ctx context.Context, user *openfga.User, path string) error
+	RemoveModelWebhook_                func(ctx context.Context, user *openfga.User, mt names.ModelTag, id uint) error
+	ResolveModelTag_                   func(ctx context.Context, user *openfga.User, ref string) (names.ModelTag, error)
+	RemoveNotificationRoute_           func(ctx context.Context, user *openfga.User, id uint) error
+	OffboardUser_                      func(ctx context.Context, user *openfga.User, identityName, reassignTo string) (*jimm.OffboardUserSummary, error)
 	ResourceTag_                       func() names.ControllerTag
+	Restore_                           func(ctx context.Context, user *openfga.User, b *jimm.Backup) error
 	RevokeAuditLogAccess_              func(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	RevokeCloudAccess_                 func(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
-	RevokeCloudCredential_             func(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
+	RevokeCloudCredentials_            func(ctx context.Context, user *dbmodel.Identity, args []jimm.RevokeCloudCredentialArgs) []error
+	RevokeControllerAccess_            func(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error)
 	RevokeModelAccess_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess_                 func(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	RevokeSessions_                    func(ctx context.Context, user *openfga.User, identityName string) (int, error)
+	RotateSessionKey_                  func(ctx context.Context, user *openfga.User) error
+	ScheduleMigration_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag, targetController string) (*dbmodel.Migration, error)
+	SearchOffers_                      func(ctx context.Context, user *openfga.User, filter jimm.OfferDirectoryFilter) ([]jimm.OfferDirectoryEntry, error)
+	ServiceSelfTest_                   func(ctx context.Context, user *openfga.User) ([]jimm.SelfTestResult, error)
+	SetCloudCredentialExpiry_          func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, expiresAt *time.Time) error
+	SetCloudRegionDisabled_            func(ctx context.Context, user *openfga.User, ct names.CloudTag, regionName string, disabled bool) error
 	SetIdentityModelDefaults_          func(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error
+	SetLimit_                          func(ctx context.Context, user *openfga.User, entity, scope string, value int64) error
+	SetModelConfigPolicy_              func(ctx context.Context, user *openfga.User, key, action string, value *string, description string) error
+	SetMaintenanceMode_                func(ctx context.Context, user *openfga.User, enabled bool, message string) error
+	SetModelAlias_                     func(ctx context.Context, user *openfga.User, alias, ref string) (*dbmodel.ModelAlias, error)
+	SetModelConfigTemplate_            func(ctx context.Context, user *openfga.User, path string, config map[string]any, sharedWith []string) (*dbmodel.ModelConfigTemplate, error)
+	SetModelLabels_                    func(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string) error
+	SetServiceAccountAllowedCIDRs_     func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, cidrs []string) error
+	SetServiceAccountDisabled_         func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, disabled bool) error
 	ToJAASTag_                         func(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
+	TransferModelOwnership_            func(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag) error
 	UpdateApplicationOffer_            func(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
 	UpdateCloud_                       func(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error
 	UpdateCloudCredential_             func(ctx context.Context, u *openfga.User, args jimm.UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error)
+	UsageReport_                       func(ctx context.Context, user *openfga.User, from, to time.Time, daily bool) ([]dbmodel.UsageRecord, error)
 	UserLogin_                         func(ctx context.Context, identityName string) (*openfga.User, error)
+	WatchAllModels_                    func(ctx context.Context, user *openfga.User, modelUUIDs []string) (*jimm.AllModelWatcher, error)
+	WatchAuditEvents_                  func(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) (*jimm.AuditEventWatcher, error)
 }
 
 func (j *JIMM) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
 	if j.AddAuditLogEntry_ == nil {
 		panic("not implemented")
 	}
-	j.AddAuditLogEntry(ale)
+	j.AddAuditLogEntry_(ale)
 }
 func (j *JIMM) AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error {
 	if j.AddCloudToController_ == nil {
@@ -106,12 +161,42 @@ func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names
 	}
 	return j.AddHostedCloud_(ctx, user, tag, cloud, force)
 }
+func (j *JIMM) AddMaintenanceWindow(ctx context.Context, user *openfga.User, controllerName string, startsAt, endsAt time.Time, reason string) (*dbmodel.MaintenanceWindow, error) {
+	if j.AddMaintenanceWindow_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddMaintenanceWindow_(ctx, user, controllerName, startsAt, endsAt, reason)
+}
+func (j *JIMM) AddModelAsync(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (*dbmodel.Task, error) {
+	if j.AddModelAsync_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddModelAsync_(ctx, user, args)
+}
+func (j *JIMM) AddModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, webhookURL string, events []string) (*dbmodel.ModelWebhook, error) {
+	if j.AddModelWebhook_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddModelWebhook_(ctx, user, mt, webhookURL, events)
+}
+func (j *JIMM) AddNotificationRoute(ctx context.Context, user *openfga.User, subject, kind, transport, destination string) (*jimm.NotificationRoute, error) {
+	if j.AddNotificationRoute_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddNotificationRoute_(ctx, user, subject, kind, transport, destination)
+}
 
-func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId string) error {
+func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId, purpose string) error {
 	if j.AddServiceAccount_ == nil {
 		return errors.E(errors.CodeNotImplemented)
 	}
-	return j.AddServiceAccount_(ctx, u, clientId)
+	return j.AddServiceAccount_(ctx, u, clientId, purpose)
+}
+func (j *JIMM) Backup(ctx context.Context, user *openfga.User) (*jimm.Backup, error) {
+	if j.Backup_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.Backup_(ctx, user)
 }
 
 func (j *JIMM) CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error) {
@@ -134,6 +219,12 @@ func (j *JIMM) CheckPermission(ctx context.Context, user *openfga.User, cachedPe
 	}
 	return j.CheckPermission_(ctx, user, cachedPerms, desiredPerms)
 }
+func (j *JIMM) DeleteServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+	if j.DeleteServiceAccount_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.DeleteServiceAccount_(ctx, u, svcAccTag)
+}
 func (j *JIMM) DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) error {
 	if j.DestroyOffer_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -215,6 +306,24 @@ func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User,
 	}
 	return j.GetJimmControllerAccess_(ctx, user, tag)
 }
+func (j *JIMM) GetMaintenanceMode(ctx context.Context) (*dbmodel.MaintenanceMode, error) {
+	if j.GetMaintenanceMode_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetMaintenanceMode_(ctx)
+}
+func (j *JIMM) GetModelArchive(ctx context.Context, user *openfga.User, modelUUID string) (*dbmodel.ModelArchive, error) {
+	if j.GetModelArchive_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetModelArchive_(ctx, user, modelUUID)
+}
+func (j *JIMM) GetModelConfigTemplate(ctx context.Context, user *openfga.User, path string) (*dbmodel.ModelConfigTemplate, error) {
+	if j.GetModelConfigTemplate_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetModelConfigTemplate_(ctx, user, path)
+}
 func (j *JIMM) FetchIdentity(ctx context.Context, username string) (*openfga.User, error) {
 	if j.FetchIdentity_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -227,12 +336,84 @@ func (j *JIMM) CountIdentities(ctx context.Context, user *openfga.User) (int, er
 	}
 	return j.CountIdentities_(ctx, user)
 }
+func (j *JIMM) GetTask(ctx context.Context, user *openfga.User, id uint) (*dbmodel.Task, error) {
+	if j.GetTask_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetTask_(ctx, user, id)
+}
+func (j *JIMM) GrantControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error) {
+	if j.GrantControllerAccess_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GrantControllerAccess_(ctx, user, access, users)
+}
+func (j *JIMM) ListCloudCredentials(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error) {
+	if j.ListCloudCredentials_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListCloudCredentials_(ctx, user, filter)
+}
+func (j *JIMM) ListExpiringCredentials(ctx context.Context, user *openfga.User, within time.Duration) ([]dbmodel.CloudCredential, error) {
+	if j.ListExpiringCredentials_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListExpiringCredentials_(ctx, user, within)
+}
 func (j *JIMM) ListIdentities(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]openfga.User, error) {
 	if j.ListIdentities_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.ListIdentities_(ctx, user, filter)
 }
+func (j *JIMM) ListLimits(ctx context.Context, user *openfga.User) ([]jimm.LimitUsage, error) {
+	if j.ListLimits_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListLimits_(ctx, user)
+}
+func (j *JIMM) ListMaintenanceWindows(ctx context.Context, user *openfga.User, controllerName string, all bool) ([]dbmodel.MaintenanceWindow, error) {
+	if j.ListMaintenanceWindows_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListMaintenanceWindows_(ctx, user, controllerName, all)
+}
+func (j *JIMM) ListMigrations(ctx context.Context, user *openfga.User, filter db.MigrationFilter) ([]dbmodel.Migration, error) {
+	if j.ListMigrations_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListMigrations_(ctx, user, filter)
+}
+func (j *JIMM) ListModelConfigPolicies(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigPolicy, error) {
+	if j.ListModelConfigPolicies_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListModelConfigPolicies_(ctx, user)
+}
+func (j *JIMM) ListModelAliases(ctx context.Context, user *openfga.User) ([]dbmodel.ModelAlias, error) {
+	if j.ListModelAliases_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListModelAliases_(ctx, user)
+}
+func (j *JIMM) ListModelWebhooks(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]dbmodel.ModelWebhook, error) {
+	if j.ListModelWebhooks_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListModelWebhooks_(ctx, user, mt)
+}
+func (j *JIMM) ListModelConfigTemplates(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigTemplate, error) {
+	if j.ListModelConfigTemplates_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListModelConfigTemplates_(ctx, user)
+}
+func (j *JIMM) ListNotificationRoutes(ctx context.Context, user *openfga.User) ([]jimm.NotificationRoute, error) {
+	if j.ListNotificationRoutes_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListNotificationRoutes_(ctx, user)
+}
 func (j *JIMM) GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error) {
 	if j.GetUserCloudAccess_ == nil {
 		return "", errors.E(errors.CodeNotImplemented)
@@ -282,7 +463,6 @@ func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, s
 	}
 	return j.GrantServiceAccountAccess_(ctx, u, svcAccTag, entities)
 }
-
 func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
 	if j.InitiateMigration_ == nil {
 		return jujuparams.InitiateMigrationResult{}, errors.E(errors.CodeNotImplemented)
@@ -301,12 +481,30 @@ func (j *JIMM) ListApplicationOffers(ctx context.Context, user *openfga.User, fi
 	}
 	return j.ListApplicationOffers_(ctx, user, filters...)
 }
+func (j *JIMM) ListDeprecatedFacadeUsage(ctx context.Context, user *openfga.User) ([]jimm.DeprecatedFacadeUsage, error) {
+	if j.ListDeprecatedFacadeUsage_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListDeprecatedFacadeUsage_(ctx, user)
+}
 func (j *JIMM) ListResources(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error) {
 	if j.ListResources_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.ListResources_(ctx, user, filter, namePrefixFilter, typeFilter)
 }
+func (j *JIMM) ListServiceAccounts(ctx context.Context, u *openfga.User) ([]dbmodel.ServiceAccount, error) {
+	if j.ListServiceAccounts_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListServiceAccounts_(ctx, u)
+}
+func (j *JIMM) ListSessions(ctx context.Context, user *openfga.User, identityName string) ([]dbmodel.Session, error) {
+	if j.ListSessions_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListSessions_(ctx, user, identityName)
+}
 func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error {
 	if j.Offer_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -325,6 +523,11 @@ func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Ti
 	}
 	return j.PurgeLogs_(ctx, user, before)
 }
+func (j *JIMM) RecordDeprecatedFacadeUsage(ctx context.Context, user *openfga.User, facade string, version int) {
+	if j.RecordDeprecatedFacadeUsage_ != nil {
+		j.RecordDeprecatedFacadeUsage_(ctx, user, facade, version)
+	}
+}
 func (j *JIMM) RemoveCloud(ctx context.Context, u *openfga.User, ct names.CloudTag) error {
 	if j.RemoveCloud_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -337,12 +540,72 @@ func (j *JIMM) RemoveCloudFromController(ctx context.Context, u *openfga.User, c
 	}
 	return j.RemoveCloudFromController_(ctx, u, controllerName, ct)
 }
+func (j *JIMM) RemoveLimit(ctx context.Context, user *openfga.User, entity, scope string) error {
+	if j.RemoveLimit_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveLimit_(ctx, user, entity, scope)
+}
+func (j *JIMM) RemoveMaintenanceWindow(ctx context.Context, user *openfga.User, id uint) error {
+	if j.RemoveMaintenanceWindow_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveMaintenanceWindow_(ctx, user, id)
+}
+func (j *JIMM) RemoveModelConfigPolicy(ctx context.Context, user *openfga.User, key string) error {
+	if j.RemoveModelConfigPolicy_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelConfigPolicy_(ctx, user, key)
+}
+func (j *JIMM) RemoveModelAlias(ctx context.Context, user *openfga.User, alias string) error {
+	if j.RemoveModelAlias_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelAlias_(ctx, user, alias)
+}
+func (j *JIMM) RemoveModelConfigTemplate(ctx context.Context, user *openfga.User, path string) error {
+	if j.RemoveModelConfigTemplate_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelConfigTemplate_(ctx, user, path)
+}
+func (j *JIMM) RemoveModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, id uint) error {
+	if j.RemoveModelWebhook_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelWebhook_(ctx, user, mt, id)
+}
+func (j *JIMM) ResolveModelTag(ctx context.Context, user *openfga.User, ref string) (names.ModelTag, error) {
+	if j.ResolveModelTag_ == nil {
+		return names.ModelTag{}, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ResolveModelTag_(ctx, user, ref)
+}
+func (j *JIMM) RemoveNotificationRoute(ctx context.Context, user *openfga.User, id uint) error {
+	if j.RemoveNotificationRoute_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveNotificationRoute_(ctx, user, id)
+}
+func (j *JIMM) OffboardUser(ctx context.Context, user *openfga.User, identityName, reassignTo string) (*jimm.OffboardUserSummary, error) {
+	if j.OffboardUser_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.OffboardUser_(ctx, user, identityName, reassignTo)
+}
 func (j *JIMM) ResourceTag() names.ControllerTag {
 	if j.ResourceTag_ == nil {
 		return names.NewControllerTag(uuid.NewString())
 	}
 	return j.ResourceTag_()
 }
+func (j *JIMM) Restore(ctx context.Context, user *openfga.User, b *jimm.Backup) error {
+	if j.Restore_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.Restore_(ctx, user, b)
+}
 func (j *JIMM) RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error {
 	if j.RevokeAuditLogAccess_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -355,11 +618,21 @@ func (j *JIMM) RevokeCloudAccess(ctx context.Context, user *openfga.User, ct nam
 	}
 	return j.RevokeCloudAccess_(ctx, user, ct, ut, access)
 }
-func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error {
-	if j.RevokeCloudCredential_ == nil {
-		return errors.E(errors.CodeNotImplemented)
+func (j *JIMM) RevokeCloudCredentials(ctx context.Context, user *dbmodel.Identity, args []jimm.RevokeCloudCredentialArgs) []error {
+	if j.RevokeCloudCredentials_ == nil {
+		errs := make([]error, len(args))
+		for i := range errs {
+			errs[i] = errors.E(errors.CodeNotImplemented)
+		}
+		return errs
 	}
-	return j.RevokeCloudCredential_(ctx, user, tag, force)
+	return j.RevokeCloudCredentials_(ctx, user, args)
+}
+func (j *JIMM) RevokeControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error) {
+	if j.RevokeControllerAccess_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.RevokeControllerAccess_(ctx, user, access, users)
 }
 func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
 	if j.RevokeModelAccess_ == nil {
@@ -373,12 +646,102 @@ func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerU
 	}
 	return j.RevokeOfferAccess_(ctx, user, offerURL, ut, access)
 }
+func (j *JIMM) RevokeSessions(ctx context.Context, user *openfga.User, identityName string) (int, error) {
+	if j.RevokeSessions_ == nil {
+		return 0, errors.E(errors.CodeNotImplemented)
+	}
+	return j.RevokeSessions_(ctx, user, identityName)
+}
+func (j *JIMM) RotateSessionKey(ctx context.Context, user *openfga.User) error {
+	if j.RotateSessionKey_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RotateSessionKey_(ctx, user)
+}
+func (j *JIMM) ScheduleMigration(ctx context.Context, user *openfga.User, mt names.ModelTag, targetController string) (*dbmodel.Migration, error) {
+	if j.ScheduleMigration_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ScheduleMigration_(ctx, user, mt, targetController)
+}
+func (j *JIMM) SearchOffers(ctx context.Context, user *openfga.User, filter jimm.OfferDirectoryFilter) ([]jimm.OfferDirectoryEntry, error) {
+	if j.SearchOffers_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SearchOffers_(ctx, user, filter)
+}
+func (j *JIMM) ServiceSelfTest(ctx context.Context, user *openfga.User) ([]jimm.SelfTestResult, error) {
+	if j.ServiceSelfTest_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ServiceSelfTest_(ctx, user)
+}
+func (j *JIMM) SetCloudCredentialExpiry(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, expiresAt *time.Time) error {
+	if j.SetCloudCredentialExpiry_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetCloudCredentialExpiry_(ctx, user, tag, expiresAt)
+}
+func (j *JIMM) SetCloudRegionDisabled(ctx context.Context, user *openfga.User, ct names.CloudTag, regionName string, disabled bool) error {
+	if j.SetCloudRegionDisabled_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetCloudRegionDisabled_(ctx, user, ct, regionName, disabled)
+}
 func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error {
 	if j.SetIdentityModelDefaults_ == nil {
 		return errors.E(errors.CodeNotImplemented)
 	}
 	return j.SetIdentityModelDefaults_(ctx, user, configs)
 }
+func (j *JIMM) SetLimit(ctx context.Context, user *openfga.User, entity, scope string, value int64) error {
+	if j.SetLimit_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetLimit_(ctx, user, entity, scope, value)
+}
+func (j *JIMM) SetModelConfigPolicy(ctx context.Context, user *openfga.User, key, action string, value *string, description string) error {
+	if j.SetModelConfigPolicy_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelConfigPolicy_(ctx, user, key, action, value, description)
+}
+func (j *JIMM) SetMaintenanceMode(ctx context.Context, user *openfga.User, enabled bool, message string) error {
+	if j.SetMaintenanceMode_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetMaintenanceMode_(ctx, user, enabled, message)
+}
+func (j *JIMM) SetModelAlias(ctx context.Context, user *openfga.User, alias, ref string) (*dbmodel.ModelAlias, error) {
+	if j.SetModelAlias_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelAlias_(ctx, user, alias, ref)
+}
+func (j *JIMM) SetModelConfigTemplate(ctx context.Context, user *openfga.User, path string, config map[string]any, sharedWith []string) (*dbmodel.ModelConfigTemplate, error) {
+	if j.SetModelConfigTemplate_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelConfigTemplate_(ctx, user, path, config, sharedWith)
+}
+func (j *JIMM) SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string) error {
+	if j.SetModelLabels_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelLabels_(ctx, user, mt, labels)
+}
+func (j *JIMM) SetServiceAccountAllowedCIDRs(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, cidrs []string) error {
+	if j.SetServiceAccountAllowedCIDRs_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetServiceAccountAllowedCIDRs_(ctx, u, svcAccTag, cidrs)
+}
+func (j *JIMM) SetServiceAccountDisabled(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, disabled bool) error {
+	if j.SetServiceAccountDisabled_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetServiceAccountDisabled_(ctx, u, svcAccTag, disabled)
+}
 func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error) {
 	if j.ToJAASTag_ == nil {
 		return "", errors.E(errors.CodeNotImplemented)
@@ -386,6 +749,12 @@ func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs b
 	return j.ToJAASTag_(ctx, tag, resolveUUIDs)
 }
 
+func (j *JIMM) TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag) error {
+	if j.TransferModelOwnership_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.TransferModelOwnership_(ctx, user, mt, newOwner)
+}
 func (j *JIMM) UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error {
 	if j.UpdateApplicationOffer_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -404,9 +773,27 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, u *openfga.User, args
 	}
 	return j.UpdateCloudCredential_(ctx, u, args)
 }
+func (j *JIMM) UsageReport(ctx context.Context, user *openfga.User, from, to time.Time, daily bool) ([]dbmodel.UsageRecord, error) {
+	if j.UsageReport_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.UsageReport_(ctx, user, from, to, daily)
+}
 func (j *JIMM) UserLogin(ctx context.Context, identityName string) (*openfga.User, error) {
 	if j.UserLogin_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.UserLogin_(ctx, identityName)
 }
+func (j *JIMM) WatchAllModels(ctx context.Context, user *openfga.User, modelUUIDs []string) (*jimm.AllModelWatcher, error) {
+	if j.WatchAllModels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.WatchAllModels_(ctx, user, modelUUIDs)
+}
+func (j *JIMM) WatchAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) (*jimm.AuditEventWatcher, error) {
+	if j.WatchAuditEvents_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.WatchAuditEvents_(ctx, user, filter)
+}
@@ -9,26 +9,30 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 )
 
 // ControllerService is an implementation of the jujuapi.ControllerService interface.
 type ControllerService struct {
-	AddController_             func(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error
-	ControllerInfo_            func(ctx context.Context, name string) (*dbmodel.Controller, error)
-	GetControllerConfig_       func(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
-	EarliestControllerVersion_ func(ctx context.Context) (version.Number, error)
-	ListControllers_           func(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
-	RemoveController_          func(ctx context.Context, user *openfga.User, controllerName string, force bool) error
-	SetControllerConfig_       func(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
-	SetControllerDeprecated_   func(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	AddControllerWithDiscovery_   func(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) ([]jimm.DiscoveredCloudRegion, error)
+	ControllerInfo_               func(ctx context.Context, name string) (*dbmodel.Controller, error)
+	ControllerModelCounts_        func(ctx context.Context, user *openfga.User) (map[string]int, error)
+	DrainController_              func(ctx context.Context, user *openfga.User, controllerName string, draining, migrate bool) ([]jimm.DrainMigration, error)
+	GetControllerConfig_          func(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
+	EarliestControllerVersion_    func(ctx context.Context) (version.Number, error)
+	ListControllers_              func(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
+	MigrateControllerCredentials_ func(ctx context.Context, user *openfga.User) (*jimm.MigrateControllerCredentialsResult, error)
+	RemoveController_             func(ctx context.Context, user *openfga.User, controllerName string, force bool) error
+	SetControllerConfig_          func(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
+	SetControllerDeprecated_      func(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
 }
 
-func (j *ControllerService) AddController(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error {
-	if j.AddController_ == nil {
-		return errors.E(errors.CodeNotImplemented)
+func (j *ControllerService) AddControllerWithDiscovery(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) ([]jimm.DiscoveredCloudRegion, error) {
+	if j.AddControllerWithDiscovery_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
 	}
-	return j.AddController_(ctx, u, ctl)
+	return j.AddControllerWithDiscovery_(ctx, u, ctl)
 }
 
 func (j *ControllerService) ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error) {
@@ -38,6 +42,20 @@ func (j *ControllerService) ControllerInfo(ctx context.Context, name string) (*d
 	return j.ControllerInfo_(ctx, name)
 }
 
+func (j *ControllerService) ControllerModelCounts(ctx context.Context, user *openfga.User) (map[string]int, error) {
+	if j.ControllerModelCounts_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ControllerModelCounts_(ctx, user)
+}
+
+func (j *ControllerService) DrainController(ctx context.Context, user *openfga.User, controllerName string, draining, migrate bool) ([]jimm.DrainMigration, error) {
+	if j.DrainController_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.DrainController_(ctx, user, controllerName, draining, migrate)
+}
+
 func (j *ControllerService) EarliestControllerVersion(ctx context.Context) (version.Number, error) {
 	if j.EarliestControllerVersion_ == nil {
 		return version.Number{}, errors.E(errors.CodeNotImplemented)
@@ -79,3 +97,10 @@ func (j *ControllerService) SetControllerDeprecated(ctx context.Context, user *o
 	}
 	return j.SetControllerDeprecated_(ctx, user, controllerName, deprecated)
 }
+
+func (j *ControllerService) MigrateControllerCredentials(ctx context.Context, user *openfga.User) (*jimm.MigrateControllerCredentialsResult, error) {
+	if j.MigrateControllerCredentials_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.MigrateControllerCredentials_(ctx, user)
+}
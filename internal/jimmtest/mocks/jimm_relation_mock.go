@@ -4,9 +4,12 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
@@ -18,6 +21,13 @@ type RelationService struct {
 	CheckRelation_          func(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, trace bool) (_ bool, err error)
 	ListRelationshipTuples_ func(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error)
 	ListObjectRelations_    func(ctx context.Context, user *openfga.User, object string, pageSize int32, continuationToken pagination.EntitlementToken) ([]openfga.Tuple, pagination.EntitlementToken, error)
+	AddTemporaryRelation_   func(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, duration time.Duration) (*dbmodel.TemporaryGrant, error)
+	ListTemporaryRelations_ func(ctx context.Context, user *openfga.User) ([]dbmodel.TemporaryGrant, error)
+	ListEveryoneDefaults_   func(ctx context.Context, user *openfga.User) ([]openfga.Tuple, error)
+	SetEveryoneDefault_     func(ctx context.Context, user *openfga.User, target string, relation string, granted bool) error
+	Reconcile_              func(ctx context.Context, user *openfga.User, repair bool) (*jimm.ReconcileReport, error)
+	ExportRelations_        func(ctx context.Context, user *openfga.User, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error)
+	ImportRelations_        func(ctx context.Context, user *openfga.User, tuples []openfga.Tuple) (added, existing int, err error)
 }
 
 func (j *RelationService) AddRelation(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error {
@@ -54,3 +64,52 @@ func (j *RelationService) ListObjectRelations(ctx context.Context, user *openfga
 	}
 	return j.ListObjectRelations_(ctx, user, object, pageSize, entitlementToken)
 }
+
+func (j *RelationService) AddTemporaryRelation(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, duration time.Duration) (*dbmodel.TemporaryGrant, error) {
+	if j.AddTemporaryRelation_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddTemporaryRelation_(ctx, user, tuple, duration)
+}
+
+func (j *RelationService) ListTemporaryRelations(ctx context.Context, user *openfga.User) ([]dbmodel.TemporaryGrant, error) {
+	if j.ListTemporaryRelations_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListTemporaryRelations_(ctx, user)
+}
+
+func (j *RelationService) Reconcile(ctx context.Context, user *openfga.User, repair bool) (*jimm.ReconcileReport, error) {
+	if j.Reconcile_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.Reconcile_(ctx, user, repair)
+}
+
+func (j *RelationService) ListEveryoneDefaults(ctx context.Context, user *openfga.User) ([]openfga.Tuple, error) {
+	if j.ListEveryoneDefaults_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListEveryoneDefaults_(ctx, user)
+}
+
+func (j *RelationService) SetEveryoneDefault(ctx context.Context, user *openfga.User, target string, relation string, granted bool) error {
+	if j.SetEveryoneDefault_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetEveryoneDefault_(ctx, user, target, relation, granted)
+}
+
+func (j *RelationService) ExportRelations(ctx context.Context, user *openfga.User, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
+	if j.ExportRelations_ == nil {
+		return nil, "", errors.E(errors.CodeNotImplemented)
+	}
+	return j.ExportRelations_(ctx, user, pageSize, continuationToken)
+}
+
+func (j *RelationService) ImportRelations(ctx context.Context, user *openfga.User, tuples []openfga.Tuple) (added, existing int, err error) {
+	if j.ImportRelations_ == nil {
+		return 0, 0, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ImportRelations_(ctx, user, tuples)
+}
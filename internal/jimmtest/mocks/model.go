@@ -17,26 +17,27 @@ import (
 
 // ModelManager defines the mock struct used to implement the ModelManger interface.
 type ModelManager struct {
-	AddModel_               func(ctx context.Context, u *openfga.User, args *jimm.ModelCreateArgs) (*jujuparams.ModelInfo, error)
-	ChangeModelCredential_  func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error
-	DestroyModel_           func(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error
-	DumpModel_              func(ctx context.Context, u *openfga.User, mt names.ModelTag, simplified bool) (string, error)
-	DumpModelDB_            func(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
-	ForEachModel_           func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
-	ForEachUserModel_       func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
-	FullModelStatus_        func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error)
-	GetModel_               func(ctx context.Context, uuid string) (dbmodel.Model, error)
-	ImportModel_            func(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error
-	IdentityModelDefaults_  func(ctx context.Context, user *dbmodel.Identity) (map[string]interface{}, error)
-	ModelDefaultsForCloud_  func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error)
-	ModelInfo_              func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error)
-	ModelStatus_            func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelStatus, error)
-	QueryModelsJq_          func(ctx context.Context, models []string, jqQuery string) (params.CrossModelQueryResponse, error)
-	SetModelDefaults_       func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) error
-	UnsetModelDefaults_     func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, keys []string) error
-	UpdateMigratedModel_    func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) error
-	ValidateModelUpgrade_   func(ctx context.Context, u *openfga.User, mt names.ModelTag, force bool) error
-	WatchAllModelSummaries_ func(ctx context.Context, controller *dbmodel.Controller) (_ func() error, err error)
+	AddModel_                 func(ctx context.Context, u *openfga.User, args *jimm.ModelCreateArgs) (*jujuparams.ModelInfo, error)
+	ChangeModelCredential_    func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error
+	DestroyModel_             func(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error
+	DumpModel_                func(ctx context.Context, u *openfga.User, mt names.ModelTag, simplified bool) (string, error)
+	DumpModelDB_              func(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
+	ForEachModel_             func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
+	ForEachUserModel_         func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
+	ForEachUserModelMatching_ func(ctx context.Context, u *openfga.User, sel jimm.LabelSelector, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
+	FullModelStatus_          func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error)
+	GetModel_                 func(ctx context.Context, uuid string) (dbmodel.Model, error)
+	ImportModel_              func(ctx context.Context, user *openfga.User, controllerName string, modelTag names.ModelTag, newOwner string) error
+	IdentityModelDefaults_    func(ctx context.Context, user *dbmodel.Identity) (map[string]interface{}, error)
+	ModelDefaultsForCloud_    func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error)
+	ModelInfo_                func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error)
+	ModelStatus_              func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelStatus, error)
+	QueryModelsJq_            func(ctx context.Context, models []string, jqQuery string) (params.CrossModelQueryResponse, error)
+	SetModelDefaults_         func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) error
+	UnsetModelDefaults_       func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, keys []string) error
+	UpdateMigratedModel_      func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) error
+	ValidateModelUpgrade_     func(ctx context.Context, u *openfga.User, mt names.ModelTag, force bool) error
+	WatchAllModelSummaries_   func(ctx context.Context, controller *dbmodel.Controller) (_ func() error, err error)
 }
 
 func (j *ModelManager) AddModel(ctx context.Context, u *openfga.User, args *jimm.ModelCreateArgs) (_ *jujuparams.ModelInfo, err error) {
@@ -87,6 +88,13 @@ func (j *ModelManager) ForEachUserModel(ctx context.Context, u *openfga.User, f
 	return j.ForEachUserModel_(ctx, u, f)
 }
 
+func (j *ModelManager) ForEachUserModelMatching(ctx context.Context, u *openfga.User, sel jimm.LabelSelector, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+	if j.ForEachUserModelMatching_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.ForEachUserModelMatching_(ctx, u, sel, f)
+}
+
 func (j *ModelManager) FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error) {
 	if j.FullModelStatus_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -28,6 +28,7 @@ type InMemoryCredentialStore struct {
 	oauthKey                  []byte
 	oauthSessionStoreSecret   []byte
 	controllerCredentials     map[string]controllerCredentials
+	controllerCACertificates  map[string]string
 	cloudCredentialAttributes map[string]map[string]string
 }
 
@@ -86,6 +87,32 @@ func (s *InMemoryCredentialStore) GetControllerCredentials(ctx context.Context,
 	return cc.username, cc.password, nil
 }
 
+// GetControllerCACertificate retrieves the CA certificate for the given
+// controller.
+func (s *InMemoryCredentialStore) GetControllerCACertificate(ctx context.Context, controllerName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.controllerCACertificates[controllerName], nil
+}
+
+// PutControllerCACertificate stores the CA certificate for the given
+// controller.
+func (s *InMemoryCredentialStore) PutControllerCACertificate(ctx context.Context, controllerName string, caCert string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if caCert == "" {
+		delete(s.controllerCACertificates, controllerName)
+		return nil
+	}
+	if s.controllerCACertificates == nil {
+		s.controllerCACertificates = make(map[string]string)
+	}
+	s.controllerCACertificates[controllerName] = caCert
+	return nil
+}
+
 // PutControllerCredentials stores the controller credentials in a vault
 // service.
 func (s *InMemoryCredentialStore) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error {
@@ -85,6 +85,8 @@ func (s *JIMMSuite) SetUpTest(c *gc.C) {
 		Pubsub:          &pubsub.Hub{MaxConcurrency: 10},
 		UUID:            ControllerUUID,
 		OpenFGAClient:   s.OFGAClient,
+		// The suite adds the same test controller under several names.
+		AllowDuplicateControllers: true,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -172,7 +174,7 @@ func (s *JIMMSuite) setupMacaroonDischarger(c *gc.C) *discharger.MacaroonDischar
 		PrivateKey:             "ly/dzsI9Nt/4JxUILQeAX79qZ4mygDiuYGqc2ZEiDEc=",
 		PublicKey:              "izcYsQy3TePp6bLjqOo3IRPFvkQd2IKtyODGqC6SdFk=",
 	}
-	macaroonDischarger, err := discharger.NewMacaroonDischarger(cfg, &s.JIMM.Database, s.JIMM.OpenFGAClient)
+	macaroonDischarger, err := discharger.NewMacaroonDischarger(cfg, s.JIMM.RootKeyStore(), s.JIMM.OpenFGAClient)
 	c.Assert(err, gc.IsNil)
 	return macaroonDischarger
 }
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
@@ -15,6 +16,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
@@ -153,6 +155,15 @@ func (r *controllerRoot) AddRelation(ctx context.Context, req apiparams.AddRelat
 func (r *controllerRoot) RemoveRelation(ctx context.Context, req apiparams.RemoveRelationRequest) error {
 	const op = errors.Op("jujuapi.RemoveRelation")
 
+	for _, t := range req.Tuples {
+		if r.isJIMMAdministratorTuple(t) {
+			if err := r.checkReason(req.Reason); err != nil {
+				return errors.E(op, err)
+			}
+			break
+		}
+	}
+
 	err := r.jimm.RemoveRelation(ctx, r.user, req.Tuples)
 	if err != nil {
 		zapctx.Error(ctx, "failed to delete tuple(s)", zap.NamedError("remove-relation-error", err))
@@ -211,3 +222,165 @@ func (r *controllerRoot) ListRelationshipTuples(ctx context.Context, req apipara
 		Errors:            errors,
 	}, nil
 }
+
+// ExportRelations returns a page of the relation tuples stored in
+// OpenFGA, in the form stored.
+func (r *controllerRoot) ExportRelations(ctx context.Context, req apiparams.ExportRelationsRequest) (apiparams.ExportRelationsResponse, error) {
+	const op = errors.Op("jujuapi.ExportRelations")
+
+	responseTuples, ct, err := r.jimm.ExportRelations(ctx, r.user, req.PageSize, req.ContinuationToken)
+	if err != nil {
+		return apiparams.ExportRelationsResponse{}, errors.E(op, err)
+	}
+	tuples := make([]apiparams.RelationshipTuple, len(responseTuples))
+	for i, t := range responseTuples {
+		tuples[i] = apiparams.RelationshipTuple{
+			Object:       t.Object.String(),
+			Relation:     string(t.Relation),
+			TargetObject: t.Target.String(),
+		}
+	}
+	return apiparams.ExportRelationsResponse{
+		Tuples:            tuples,
+		ContinuationToken: ct,
+	}, nil
+}
+
+// ImportRelations adds relation tuples, as returned by ExportRelations,
+// to OpenFGA.
+func (r *controllerRoot) ImportRelations(ctx context.Context, req apiparams.ImportRelationsRequest) (apiparams.ImportRelationsResponse, error) {
+	const op = errors.Op("jujuapi.ImportRelations")
+
+	tuples := make([]openfga.Tuple, len(req.Tuples))
+	for i, t := range req.Tuples {
+		object, err := openfga.ParseTag(t.Object)
+		if err != nil {
+			return apiparams.ImportRelationsResponse{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		target, err := openfga.ParseTag(t.TargetObject)
+		if err != nil {
+			return apiparams.ImportRelationsResponse{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		tuples[i] = openfga.Tuple{
+			Object:   &object,
+			Relation: openfga.Relation(t.Relation),
+			Target:   &target,
+		}
+	}
+	added, existing, err := r.jimm.ImportRelations(ctx, r.user, tuples)
+	if err != nil {
+		return apiparams.ImportRelationsResponse{}, errors.E(op, err)
+	}
+	return apiparams.ImportRelationsResponse{Added: added, Existing: existing}, nil
+}
+
+// AddTemporaryRelation adds a tuple within OpenFGA that is removed
+// automatically once the requested duration has passed.
+func (r *controllerRoot) AddTemporaryRelation(ctx context.Context, req apiparams.AddTemporaryRelationRequest) (apiparams.TemporaryGrant, error) {
+	const op = errors.Op("jujuapi.AddTemporaryRelation")
+
+	grant, err := r.jimm.AddTemporaryRelation(ctx, r.user, req.Tuple, req.Duration)
+	if err != nil {
+		zapctx.Error(ctx, "failed to add temporary relation", zaputil.Error(err))
+		return apiparams.TemporaryGrant{}, errors.E(op, err)
+	}
+	return grant.ToAPITemporaryGrant(), nil
+}
+
+// ListTemporaryRelations returns the temporary relations that have not
+// yet expired.
+func (r *controllerRoot) ListTemporaryRelations(ctx context.Context) (apiparams.ListTemporaryRelationsResponse, error) {
+	const op = errors.Op("jujuapi.ListTemporaryRelations")
+
+	grants, err := r.jimm.ListTemporaryRelations(ctx, r.user)
+	if err != nil {
+		return apiparams.ListTemporaryRelationsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListTemporaryRelationsResponse{
+		Grants: make([]apiparams.TemporaryGrant, len(grants)),
+	}
+	for i, g := range grants {
+		resp.Grants[i] = g.ToAPITemporaryGrant()
+	}
+	return resp, nil
+}
+
+// ListEveryoneDefaults returns the relations granted to every user via
+// the everyone@external user.
+func (r *controllerRoot) ListEveryoneDefaults(ctx context.Context) (apiparams.ListEveryoneDefaultsResponse, error) {
+	const op = errors.Op("jujuapi.ListEveryoneDefaults")
+
+	tuples, err := r.jimm.ListEveryoneDefaults(ctx, r.user)
+	if err != nil {
+		return apiparams.ListEveryoneDefaultsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListEveryoneDefaultsResponse{
+		Defaults: make([]apiparams.EveryoneDefault, len(tuples)),
+	}
+	for i, t := range tuples {
+		target, err := r.jimm.ToJAASTag(ctx, t.Target, true)
+		if err != nil {
+			target = t.Target.String()
+		}
+		resp.Defaults[i] = apiparams.EveryoneDefault{
+			TargetObject: target,
+			Relation:     string(t.Relation),
+		}
+	}
+	return resp, nil
+}
+
+// SetEveryoneDefault grants or revokes a relation for every user via
+// the everyone@external user.
+func (r *controllerRoot) SetEveryoneDefault(ctx context.Context, req apiparams.SetEveryoneDefaultRequest) error {
+	const op = errors.Op("jujuapi.SetEveryoneDefault")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.SetEveryoneDefault(ctx, r.user, req.TargetObject, req.Relation, req.Granted); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Reconcile compares JIMM's database with the tuples in OpenFGA,
+// optionally repairing any differences found.
+func (r *controllerRoot) Reconcile(ctx context.Context, req apiparams.ReconcileRequest) (apiparams.ReconcileResponse, error) {
+	const op = errors.Op("jujuapi.Reconcile")
+
+	report, err := r.jimm.Reconcile(ctx, r.user, req.Repair)
+	if err != nil {
+		return apiparams.ReconcileResponse{}, errors.E(op, err)
+	}
+	// UUIDs are not resolved as orphaned tuples refer to entities that
+	// no longer exist.
+	toAPITuples := func(tuples []openfga.Tuple) []apiparams.RelationshipTuple {
+		res := make([]apiparams.RelationshipTuple, len(tuples))
+		for i, t := range tuples {
+			object, _ := r.jimm.ToJAASTag(ctx, t.Object, false)
+			target, _ := r.jimm.ToJAASTag(ctx, t.Target, false)
+			res[i] = apiparams.RelationshipTuple{
+				Object:       object,
+				Relation:     string(t.Relation),
+				TargetObject: target,
+			}
+		}
+		return res
+	}
+	return apiparams.ReconcileResponse{
+		Missing:  toAPITuples(report.Missing),
+		Orphaned: toAPITuples(report.Orphaned),
+		Repaired: report.Repaired,
+	}, nil
+}
+
+// isJIMMAdministratorTuple reports whether the given tuple grants
+// administrator access to JIMM itself.
+func (r *controllerRoot) isJIMMAdministratorTuple(t apiparams.RelationshipTuple) bool {
+	if t.Relation != string(ofganames.AdministratorRelation) {
+		return false
+	}
+	return t.TargetObject == names.NewControllerTag(jimmControllerName).String() ||
+		t.TargetObject == names.NewControllerTag(r.params.ControllerUUID).String()
+}
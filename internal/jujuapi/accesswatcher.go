@@ -0,0 +1,224 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	"github.com/canonical/jimm/v3/internal/pubsub"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// accessWatcherPollPeriod is how often an access watcher checks for
+// access changes made outside of this JIMM instance.
+var accessWatcherPollPeriod = 30 * time.Second
+
+func init() {
+	facadeInit["AccessWatcher"] = func(r *controllerRoot) []int {
+		nextMethod := rpc.Method(r.AccessWatcherNext)
+		stopMethod := rpc.Method(r.AccessWatcherStop)
+
+		r.AddMethod("AccessWatcher", 1, "Next", nextMethod)
+		r.AddMethod("AccessWatcher", 1, "Stop", stopMethod)
+
+		return []int{1}
+	}
+}
+
+// WatchAccess implements the WatchAccess method on the JIMM facade. It
+// starts a watcher that reports changes to the models the authenticated
+// user has access to.
+func (r *controllerRoot) WatchAccess(ctx context.Context) (apiparams.WatchAccessResponse, error) {
+	const op = errors.Op("jujuapi.WatchAccess")
+
+	if err := r.setupUUIDGenerator(); err != nil {
+		return apiparams.WatchAccessResponse{}, errors.E(op, err)
+	}
+	user := r.user
+	getAccess := func(ctx context.Context) (map[string]string, error) {
+		access := make(map[string]string)
+		err := r.jimm.ForEachUserModel(ctx, user, func(m *dbmodel.Model, a jujuparams.UserAccessPermission) error {
+			access[m.UUID.String] = string(a)
+			return nil
+		})
+		return access, err
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+	w, err := newAccessWatcher(ctx, r.jimm.PubSubHub(), getAccess)
+	if err != nil {
+		return apiparams.WatchAccessResponse{}, errors.E(op, err)
+	}
+	r.accessWatchers.register(id, w)
+	token, err := r.makeResumable(id, resumableAccess)
+	if err != nil {
+		return apiparams.WatchAccessResponse{}, errors.E(op, err)
+	}
+	return apiparams.WatchAccessResponse{WatcherID: id, ResumeToken: token}, nil
+}
+
+// AccessWatcherNext implements the Next method on the AccessWatcher
+// facade. The first call returns the user's current access to every
+// model, subsequent calls block until that access changes.
+func (r *controllerRoot) AccessWatcherNext(ctx context.Context, objID string) (apiparams.AccessWatcherNextResponse, error) {
+	const op = errors.Op("jujuapi.AccessWatcherNext")
+
+	w, err := r.accessWatchers.get(objID)
+	if err != nil {
+		return apiparams.AccessWatcherNextResponse{}, errors.E(op, err)
+	}
+	changes, err := w.next(ctx)
+	if err != nil {
+		return apiparams.AccessWatcherNextResponse{}, errors.E(op, err)
+	}
+	return apiparams.AccessWatcherNextResponse{Changes: changes}, nil
+}
+
+// AccessWatcherStop implements the Stop method on the AccessWatcher
+// facade.
+func (r *controllerRoot) AccessWatcherStop(ctx context.Context, objID string) error {
+	const op = errors.Op("jujuapi.AccessWatcherStop")
+
+	w, err := r.accessWatchers.remove(objID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.forgetResumable(objID)
+	return w.Stop()
+}
+
+// An accessWatcher reports changes to the access a single user has to
+// models. It is woken by access change notifications published by JIMM
+// and also polls periodically so that changes made elsewhere are seen.
+type accessWatcher struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	getAccess func(context.Context) (map[string]string, error)
+	wake      chan struct{}
+	unsub     func()
+	stopOnce  sync.Once
+
+	// mu serialises calls to next and protects the fields below it.
+	mu sync.Mutex
+	// delivered holds the access returned by the last call to next and
+	// acked the access the client is known to have received. A call to
+	// next acknowledges the previous one unless the watcher has been
+	// resumed.
+	delivered map[string]string
+	acked     map[string]string
+	resumed   bool
+}
+
+func newAccessWatcher(ctx context.Context, hub *pubsub.Hub, getAccess func(context.Context) (map[string]string, error)) (*accessWatcher, error) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	w := &accessWatcher{
+		ctx:       ctx,
+		cancel:    cancel,
+		getAccess: getAccess,
+		wake:      make(chan struct{}, 1),
+		unsub:     func() {},
+	}
+	if hub != nil {
+		unsub, err := hub.Subscribe(jimm.AccessChangedTopic, func(string, interface{}) {
+			select {
+			case w.wake <- struct{}{}:
+			default:
+			}
+		})
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		w.unsub = unsub
+	}
+	servermon.WatchersActive.WithLabelValues("access").Inc()
+	return w, nil
+}
+
+// next returns the changes in access since the previous call. On the
+// first call the current access to every model is returned.
+func (w *accessWatcher) next(ctx context.Context) ([]apiparams.AccessChange, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.resumed {
+		w.acked = w.delivered
+	}
+	w.resumed = false
+
+	ticker := time.NewTicker(accessWatcherPollPeriod)
+	defer ticker.Stop()
+	for {
+		access, err := w.getAccess(w.ctx)
+		if err != nil {
+			return nil, err
+		}
+		changes := diffAccess(w.acked, access)
+		if w.acked == nil || len(changes) > 0 {
+			w.delivered = access
+			return changes, nil
+		}
+		select {
+		case <-w.wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-w.ctx.Done():
+			return nil, errors.E("watcher stopped")
+		}
+	}
+}
+
+// Resume causes the next call to next to return the changes returned by
+// the previous call again, along with any new changes.
+func (w *accessWatcher) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.resumed = true
+}
+
+// Stop stops the watcher.
+func (w *accessWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		w.cancel()
+		w.unsub()
+		servermon.WatchersActive.WithLabelValues("access").Dec()
+	})
+	return nil
+}
+
+// diffAccess returns the changes required to go from the old access map
+// to the new one. Revoked access is reported with an empty access level.
+func diffAccess(old, new map[string]string) []apiparams.AccessChange {
+	var changes []apiparams.AccessChange
+	for uuid, a := range new {
+		if old[uuid] != a {
+			changes = append(changes, apiparams.AccessChange{
+				ModelTag: names.NewModelTag(uuid).String(),
+				Access:   a,
+			})
+		}
+	}
+	for uuid := range old {
+		if _, ok := new[uuid]; !ok {
+			changes = append(changes, apiparams.AccessChange{
+				ModelTag: names.NewModelTag(uuid).String(),
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ModelTag < changes[j].ModelTag
+	})
+	return changes
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/jujuapi"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/pubsub"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type accessWatcherSuite struct{}
+
+var _ = gc.Suite(&accessWatcherSuite{})
+
+func (s *accessWatcherSuite) TestAccessWatcher(c *gc.C) {
+	ctx := context.Background()
+
+	hub := &pubsub.Hub{MaxConcurrency: 10}
+	var mu sync.Mutex
+	access := map[string]jujuparams.UserAccessPermission{
+		"00000002-0000-0000-0000-000000000001": "read",
+	}
+	j := &jimmtest.JIMM{
+		PubSubHub_: func() *pubsub.Hub { return hub },
+	}
+	j.ForEachUserModel_ = func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for uuid, a := range access {
+			m := dbmodel.Model{UUID: sql.NullString{String: uuid, Valid: true}}
+			if err := f(&m, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cr := jujuapi.NewControllerRoot(j, jujuapi.Params{})
+	jujuapi.SetUser(cr, openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil))
+
+	id, err := cr.WatchAccess(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Assert(cr.AccessWatcherStop(ctx, id.WatcherID), jc.ErrorIsNil)
+	}()
+
+	result, err := cr.AccessWatcherNext(ctx, id.WatcherID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, gc.DeepEquals, []apiparams.AccessChange{{
+		ModelTag: "model-00000002-0000-0000-0000-000000000001",
+		Access:   "read",
+	}})
+
+	mu.Lock()
+	access = map[string]jujuparams.UserAccessPermission{
+		"00000002-0000-0000-0000-000000000002": "admin",
+	}
+	mu.Unlock()
+	<-hub.Publish(jimm.AccessChangedTopic, jimm.AccessChange{})
+
+	result, err = cr.AccessWatcherNext(ctx, id.WatcherID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, gc.DeepEquals, []apiparams.AccessChange{{
+		ModelTag: "model-00000002-0000-0000-0000-000000000001",
+	}, {
+		ModelTag: "model-00000002-0000-0000-0000-000000000002",
+		Access:   "admin",
+	}})
+}
+
+func (s *accessWatcherSuite) TestResumeAccessWatcher(c *gc.C) {
+	ctx := context.Background()
+
+	hub := &pubsub.Hub{MaxConcurrency: 10}
+	var mu sync.Mutex
+	access := map[string]jujuparams.UserAccessPermission{
+		"00000002-0000-0000-0000-000000000001": "read",
+	}
+	j := &jimmtest.JIMM{
+		PubSubHub_: func() *pubsub.Hub { return hub },
+	}
+	j.ForEachUserModel_ = func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for uuid, a := range access {
+			m := dbmodel.Model{UUID: sql.NullString{String: uuid, Valid: true}}
+			if err := f(&m, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	alice := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	cr := jujuapi.NewControllerRoot(j, jujuapi.Params{})
+	jujuapi.SetUser(cr, alice)
+
+	id, err := cr.WatchAccess(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id.ResumeToken, gc.Not(gc.Equals), "")
+
+	_, err = cr.AccessWatcherNext(ctx, id.WatcherID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mu.Lock()
+	access["00000002-0000-0000-0000-000000000002"] = "write"
+	mu.Unlock()
+	<-hub.Publish(jimm.AccessChangedTopic, jimm.AccessChange{})
+
+	// The client loses these changes when its connection fails.
+	expect := []apiparams.AccessChange{{
+		ModelTag: "model-00000002-0000-0000-0000-000000000002",
+		Access:   "write",
+	}}
+	result, err := cr.AccessWatcherNext(ctx, id.WatcherID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, gc.DeepEquals, expect)
+	jujuapi.CloseControllerRoot(cr)
+
+	// Another user cannot resume the watcher.
+	cr2 := jujuapi.NewControllerRoot(j, jujuapi.Params{})
+	jujuapi.SetUser(cr2, openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil))
+	_, err = cr2.ResumeWatcher(ctx, apiparams.ResumeWatcherRequest{ResumeToken: id.ResumeToken})
+	c.Assert(err, gc.ErrorMatches, "watcher not found")
+
+	cr3 := jujuapi.NewControllerRoot(j, jujuapi.Params{})
+	jujuapi.SetUser(cr3, alice)
+	defer jujuapi.CloseControllerRoot(cr3)
+	resumed, err := cr3.ResumeWatcher(ctx, apiparams.ResumeWatcherRequest{ResumeToken: id.ResumeToken})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resumed.Kind, gc.Equals, "access")
+
+	result, err = cr3.AccessWatcherNext(ctx, resumed.WatcherID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, gc.DeepEquals, expect)
+	c.Assert(cr3.AccessWatcherStop(ctx, resumed.WatcherID), jc.ErrorIsNil)
+}
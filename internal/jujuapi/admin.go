@@ -64,6 +64,7 @@ func (r *controllerRoot) LoginDevice(ctx context.Context) (params.LoginDeviceRes
 
 	response.UserCode = deviceResponse.UserCode
 	response.VerificationURI = deviceResponse.VerificationURI
+	response.Branding = r.params.Branding
 
 	return response, nil
 }
@@ -0,0 +1,231 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/tracing"
+	"github.com/canonical/jimm/v3/pkg/api/adminrpc"
+)
+
+// adminRPCMethod identifies the facade method that implements a method of
+// the gRPC admin API.
+type adminRPCMethod struct {
+	facade  string
+	version int
+	method  string
+}
+
+// adminRPCMethods maps the methods of the gRPC admin service, defined in
+// pkg/api/adminrpc/admin.proto, to the facade methods that implement
+// them.
+var adminRPCMethods = map[string]adminRPCMethod{
+	"AddCloudToController":      {"JIMM", 4, "AddCloudToController"},
+	"AddController":             {"JIMM", 4, "AddController"},
+	"AddRelation":               {"JIMM", 4, "AddRelation"},
+	"CheckRelation":             {"JIMM", 4, "CheckRelation"},
+	"CloudInfo":                 {"Cloud", 7, "CloudInfo"},
+	"DrainController":           {"JIMM", 4, "DrainController"},
+	"FindAuditEvents":           {"JIMM", 4, "FindAuditEvents"},
+	"FullModelStatus":           {"JIMM", 4, "FullModelStatus"},
+	"ListClouds":                {"Cloud", 7, "Clouds"},
+	"ListControllers":           {"JIMM", 4, "ListControllers"},
+	"ListModelStatuses":         {"JIMM", 4, "ListModelStatuses"},
+	"ListRelationshipTuples":    {"JIMM", 4, "ListRelationshipTuples"},
+	"MigrateModel":              {"JIMM", 4, "MigrateModel"},
+	"PurgeLogs":                 {"JIMM", 4, "PurgeLogs"},
+	"RemoveCloudFromController": {"JIMM", 4, "RemoveCloudFromController"},
+	"RemoveController":          {"JIMM", 4, "RemoveController"},
+	"RemoveRelation":            {"JIMM", 4, "RemoveRelation"},
+	"SetControllerDeprecated":   {"JIMM", 4, "SetControllerDeprecated"},
+	"TransferModelOwnership":    {"JIMM", 4, "TransferModelOwnership"},
+}
+
+// NewAdminGRPCServer returns a gRPC server serving JIMM's admin API. The
+// caller is identified by the common name of their verified TLS client
+// certificate, so the server options must include transport credentials
+// that require and verify client certificates.
+//
+// Each request is handled by the same facade method as the equivalent
+// websocket request, so it is subject to the same authorisation checks,
+// maintenance mode and rate limits, and is recorded in the audit log in
+// the same way.
+func NewAdminGRPCServer(j JIMM, p Params, opts ...grpc.ServerOption) *grpc.Server {
+	s := &adminRPCServer{
+		jimm:   j,
+		params: p,
+	}
+	desc := grpc.ServiceDesc{
+		ServiceName: adminrpc.ServiceName,
+		HandlerType: (*interface{})(nil),
+		Metadata:    "admin.proto",
+	}
+	for name := range adminRPCMethods {
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: name,
+			Handler:    s.handler(name),
+		})
+	}
+	srv := grpc.NewServer(opts...)
+	srv.RegisterService(&desc, s)
+	return srv
+}
+
+// An adminRPCServer implements the gRPC admin service.
+type adminRPCServer struct {
+	jimm   JIMM
+	params Params
+}
+
+// handler returns the gRPC handler for the named admin method.
+func (s *adminRPCServer) handler(name string) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(structpb.Struct)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		call := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return s.call(ctx, name, req.(*structpb.Struct))
+		}
+		if interceptor == nil {
+			return call(ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     s,
+			FullMethod: "/" + adminrpc.ServiceName + "/" + name,
+		}
+		return interceptor(ctx, in, info, call)
+	}
+}
+
+// call authenticates the caller and calls the facade method implementing
+// the named admin method.
+func (s *adminRPCServer) call(ctx context.Context, name string, in *structpb.Struct) (_ *structpb.Struct, err error) {
+	ctx, span := tracing.Start(ctx, "jujuapi.AdminRPC."+name)
+	defer tracing.End(span, &err)
+
+	m := adminRPCMethods[name]
+	identityName, err := peerIdentityName(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	user, err := s.jimm.UserLogin(ctx, identityName)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if user.Disabled {
+		return nil, status.Error(codes.Unauthenticated, "identity is disabled")
+	}
+
+	r := newControllerRoot(s.jimm, s.params, "")
+	defer r.cleanup()
+	r.mu.Lock()
+	r.user = user
+	r.mu.Unlock()
+	facadeInit[m.facade](r)
+
+	mc, err := r.FindMethod(m.facade, m.version, m.method)
+	if err != nil {
+		return nil, adminRPCError(err)
+	}
+	var arg reflect.Value
+	var body interface{}
+	if pt := mc.ParamsType(); pt != nil {
+		pv := reflect.New(pt)
+		if err := adminrpc.Unmarshal(in, pv.Interface()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		arg = pv.Elem()
+		body = arg.Interface()
+	}
+
+	recorder := jimm.NewRecorder(r.newAuditLogger())
+	req := rpc.Request{
+		Type:    m.facade,
+		Version: m.version,
+		Action:  m.method,
+	}
+	if err := recorder.HandleRequest(&rpc.Header{Request: req}, body); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	rv, err := mc.Call(ctx, "", arg)
+	var result interface{}
+	if err == nil && rv.IsValid() {
+		result = rv.Interface()
+	}
+	replyHeader := rpc.Header{Request: req}
+	if perr := mapError(err); perr != nil {
+		replyHeader.Error = perr.Message
+		replyHeader.ErrorCode = perr.Code
+		replyHeader.ErrorInfo = perr.Info
+	}
+	if err := recorder.HandleReply(req, &replyHeader, result); err != nil {
+		zapctx.Error(ctx, "cannot record admin RPC reply", zap.Error(err))
+	}
+	if err != nil {
+		return nil, adminRPCError(err)
+	}
+	out, err := adminrpc.Marshal(result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return out, nil
+}
+
+// peerIdentityName returns the name of the identity making the request,
+// which is the common name of the peer's verified client certificate.
+func peerIdentityName(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", errors.E("no peer information")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", errors.E("client certificate required")
+	}
+	cn := info.State.VerifiedChains[0][0].Subject.CommonName
+	if cn == "" {
+		return "", errors.E("client certificate has no common name")
+	}
+	return cn, nil
+}
+
+// adminRPCError converts an error returned by a facade method to a gRPC
+// status error. The caller has always been authenticated by the time a
+// facade method is called, so CodeUnauthorized is a permission failure.
+func adminRPCError(err error) error {
+	code := codes.Unknown
+	switch errors.ErrorCode(err) {
+	case errors.CodeBadRequest, errors.CodeFailedToParseTupleKey:
+		code = codes.InvalidArgument
+	case errors.CodeNotFound, errors.CodeModelNotFound:
+		code = codes.NotFound
+	case errors.CodeAlreadyExists:
+		code = codes.AlreadyExists
+	case errors.CodeUnauthorized, errors.CodeForbidden:
+		code = codes.PermissionDenied
+	case errors.CodeNotImplemented, errors.CodeNotSupported:
+		code = codes.Unimplemented
+	case errors.CodeTooManyRequests, errors.CodeQuotaLimitExceeded:
+		code = codes.ResourceExhausted
+	case errors.CodeUpgradeInProgress, errors.CodeConnectionFailed:
+		code = codes.Unavailable
+	case errors.CodePolicyViolation, errors.CodeStillAlive:
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest/mocks"
+	"github.com/canonical/jimm/v3/internal/jujuapi"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/pkg/api/adminrpc"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func TestAdminGRPCServer(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	maintenance := false
+	var audit []dbmodel.AuditLogEntry
+	j := &jimmtest.JIMM{
+		AddAuditLogEntry_: func(ale *dbmodel.AuditLogEntry) {
+			audit = append(audit, *ale)
+		},
+		ControllerService: mocks.ControllerService{
+			ControllerModelCounts_: func(context.Context, *openfga.User) (map[string]int, error) {
+				return map[string]int{"controller-1": 2}, nil
+			},
+			ListControllers_: func(context.Context, *openfga.User) ([]dbmodel.Controller, error) {
+				return []dbmodel.Controller{{Name: "controller-1", CloudName: "test-cloud"}}, nil
+			},
+		},
+		GetMaintenanceMode_: func(context.Context) (*dbmodel.MaintenanceMode, error) {
+			return &dbmodel.MaintenanceMode{Enabled: maintenance}, nil
+		},
+		PurgeLogs_: func(ctx context.Context, user *openfga.User, before time.Time) (int64, error) {
+			if !user.JimmAdmin {
+				return 0, errors.E(errors.CodeUnauthorized, "unauthorized")
+			}
+			return 3, nil
+		},
+		UserLogin_: func(_ context.Context, identityName string) (*openfga.User, error) {
+			u := openfga.NewUser(&dbmodel.Identity{Name: identityName}, nil)
+			u.JimmAdmin = identityName == "admin@canonical.com"
+			return u, nil
+		},
+	}
+
+	dial := startAdminGRPCServer(c, j)
+
+	admin := dial("admin@canonical.com")
+	var controllers params.ListControllersResponse
+	err := admin.Call(ctx, "ListControllers", nil, &controllers)
+	c.Assert(err, qt.IsNil)
+	c.Assert(controllers.Controllers, qt.HasLen, 1)
+	c.Check(controllers.Controllers[0].Name, qt.Equals, "controller-1")
+	c.Check(controllers.Controllers[0].ModelCount, qt.Equals, 2)
+
+	var purged params.PurgeLogsResponse
+	err = admin.Call(ctx, "PurgeLogs", params.PurgeLogsRequest{Date: time.Now()}, &purged)
+	c.Assert(err, qt.IsNil)
+	c.Check(purged.DeletedCount, qt.Equals, int64(3))
+
+	// Requests and responses are recorded in the audit log.
+	c.Assert(audit, qt.HasLen, 4)
+	c.Check(audit[2].IdentityTag, qt.Equals, "user-admin@canonical.com")
+	c.Check(audit[2].FacadeName, qt.Equals, "JIMM")
+	c.Check(audit[2].FacadeMethod, qt.Equals, "PurgeLogs")
+	c.Check(audit[2].IsResponse, qt.IsFalse)
+	c.Check(audit[3].FacadeMethod, qt.Equals, "PurgeLogs")
+	c.Check(audit[3].IsResponse, qt.IsTrue)
+
+	// Facade authorisation checks are applied to the identity named in
+	// the client certificate.
+	user := dial("alice@canonical.com")
+	err = user.Call(ctx, "PurgeLogs", params.PurgeLogsRequest{Date: time.Now()}, nil)
+	c.Check(status.Code(err), qt.Equals, codes.PermissionDenied)
+
+	// Maintenance mode is honoured.
+	maintenance = true
+	err = admin.Call(ctx, "RemoveController", params.RemoveControllerRequest{Name: "controller-1"}, nil)
+	c.Check(status.Code(err), qt.Equals, codes.Unavailable)
+
+	// Methods not in the admin API are not served.
+	err = admin.Call(ctx, "Backup", nil, nil)
+	c.Check(status.Code(err), qt.Equals, codes.Unimplemented)
+
+	// Clients without a certificate cannot connect.
+	err = dial("").Call(ctx, "ListControllers", nil, nil)
+	c.Check(err, qt.Not(qt.IsNil))
+	c.Check(status.Code(err), qt.Not(qt.Equals), codes.OK)
+}
+
+func TestAdminGRPCServerAuditLog(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	dial := startAdminGRPCServer(c, j)
+	err = dial("alice@canonical.com").Call(ctx, "PurgeLogs", params.PurgeLogsRequest{Date: time.Now()}, nil)
+	c.Check(status.Code(err), qt.Equals, codes.PermissionDenied)
+
+	var logs []dbmodel.AuditLogEntry
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "PurgeLogs"}, func(ale *dbmodel.AuditLogEntry) error {
+		logs = append(logs, *ale)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(logs, qt.HasLen, 2)
+	for _, ale := range logs {
+		c.Check(ale.IdentityTag, qt.Equals, "user-alice@canonical.com")
+		c.Check(ale.FacadeName, qt.Equals, "JIMM")
+		c.Check(ale.FacadeVersion, qt.Equals, 4)
+	}
+	c.Check(logs[0].IsResponse, qt.IsFalse)
+	c.Check(string(logs[0].Params), qt.Contains, "date")
+	c.Check(logs[1].IsResponse, qt.IsTrue)
+	c.Check(string(logs[1].Errors), qt.Contains, "unauthorized")
+}
+
+// startAdminGRPCServer starts an admin gRPC server using the given JIMM.
+// It returns a function that connects to the server with a client
+// certificate with the given common name, or without a client
+// certificate if the name is empty.
+func startAdminGRPCServer(c *qt.C, j jujuapi.JIMM) func(cn string) *adminrpc.Client {
+	ca, caKey := newTestCertificate(c, "ca", nil, nil)
+	serverCert := newTLSCertificate(c, "localhost", ca, caKey)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := jujuapi.NewAdminGRPCServer(j, jujuapi.Params{}, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	go srv.Serve(lis)
+	c.Cleanup(srv.Stop)
+
+	return func(cn string) *adminrpc.Client {
+		var certs []tls.Certificate
+		if cn != "" {
+			certs = append(certs, newTLSCertificate(c, cn, ca, caKey))
+		}
+		conn, err := grpc.Dial("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				Certificates: certs,
+				RootCAs:      pool,
+				ServerName:   "localhost",
+			})),
+		)
+		c.Assert(err, qt.IsNil)
+		c.Cleanup(func() { conn.Close() })
+		return adminrpc.NewClient(conn)
+	}
+}
+
+// newTestCertificate returns a new certificate with the given common
+// name, signed by the given parent. If parent is nil a self-signed CA
+// certificate is returned.
+func newTestCertificate(c *qt.C, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, qt.IsNil)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{cn},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	c.Assert(err, qt.IsNil)
+	cert, err := x509.ParseCertificate(der)
+	c.Assert(err, qt.IsNil)
+	return cert, key
+}
+
+// newTLSCertificate returns a tls.Certificate with the given common name
+// signed by the given CA.
+func newTLSCertificate(c *qt.C, cn string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	cert, key := newTestCertificate(c, cn, ca, caKey)
+	return tls.Certificate{
+		Certificate: [][]byte{cert.Raw},
+		PrivateKey:  key,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func init() {
+	facadeInit["AllModelWatcher"] = func(r *controllerRoot) []int {
+		nextMethod := rpc.Method(r.AllModelWatcherNext)
+		stopMethod := rpc.Method(r.AllModelWatcherStop)
+
+		r.AddMethod("AllModelWatcher", 4, "Next", nextMethod)
+		r.AddMethod("AllModelWatcher", 4, "Stop", stopMethod)
+
+		return []int{4}
+	}
+}
+
+// WatchAllModels implements the WatchAllModels method on the Controller
+// facade. It starts a watcher that returns the deltas for every model
+// the authenticated user can read, across all controllers.
+func (r *controllerRoot) WatchAllModels(ctx context.Context) (jujuparams.AllWatcherId, error) {
+	const op = errors.Op("jujuapi.WatchAllModels")
+
+	id, err := r.watchAllModels(ctx, nil)
+	if err != nil {
+		return jujuparams.AllWatcherId{}, errors.E(op, err)
+	}
+	return jujuparams.AllWatcherId{AllWatcherId: id}, nil
+}
+
+// JIMMWatchAllModels implements the WatchAllModels method on the JIMM
+// facade. It is like Controller.WatchAllModels but allows the deltas to
+// be restricted to a set of models.
+func (r *controllerRoot) JIMMWatchAllModels(ctx context.Context, req apiparams.WatchAllModelsRequest) (jujuparams.AllWatcherId, error) {
+	const op = errors.Op("jujuapi.JIMMWatchAllModels")
+
+	modelUUIDs := make([]string, len(req.ModelTags))
+	for i, tag := range req.ModelTags {
+		mt, err := names.ParseModelTag(tag)
+		if err != nil {
+			return jujuparams.AllWatcherId{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		modelUUIDs[i] = mt.Id()
+	}
+	id, err := r.watchAllModels(ctx, modelUUIDs)
+	if err != nil {
+		return jujuparams.AllWatcherId{}, errors.E(op, err)
+	}
+	return jujuparams.AllWatcherId{AllWatcherId: id}, nil
+}
+
+func (r *controllerRoot) watchAllModels(ctx context.Context, modelUUIDs []string) (string, error) {
+	if err := r.setupUUIDGenerator(); err != nil {
+		return "", err
+	}
+	w, err := r.jimm.WatchAllModels(ctx, r.user, modelUUIDs)
+	if err != nil {
+		return "", err
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+	r.allWatchers.register(id, w)
+	return id, nil
+}
+
+// AllModelWatcherNext implements the Next method on the AllModelWatcher
+// facade. It returns the next set of deltas when they are available.
+func (r *controllerRoot) AllModelWatcherNext(ctx context.Context, objID string) (jujuparams.AllWatcherNextResults, error) {
+	const op = errors.Op("jujuapi.AllModelWatcherNext")
+
+	w, err := r.allWatchers.get(objID)
+	if err != nil {
+		return jujuparams.AllWatcherNextResults{}, errors.E(op, err)
+	}
+	deltas, err := w.Next(ctx)
+	if err != nil {
+		return jujuparams.AllWatcherNextResults{}, errors.E(op, err)
+	}
+	return jujuparams.AllWatcherNextResults{Deltas: deltas}, nil
+}
+
+// AllModelWatcherStop implements the Stop method on the AllModelWatcher
+// facade.
+func (r *controllerRoot) AllModelWatcherStop(ctx context.Context, objID string) error {
+	const op = errors.Op("jujuapi.AllModelWatcherStop")
+
+	w, err := r.allWatchers.remove(objID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return w.Stop()
+}
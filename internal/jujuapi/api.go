@@ -6,9 +6,12 @@ package jujuapi
 import (
 	"context"
 	"net/http"
+	"net/netip"
 
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	jimmRPC "github.com/canonical/jimm/v3/internal/rpc"
+	"github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 // A Params object holds the parameters needed to configure the API
@@ -20,6 +23,28 @@ type Params struct {
 	// PublicDNSName is the name to advertise as the public address of
 	// the juju controller.
 	PublicDNSName string
+
+	// RequireReasonForPrivilegedOperations, if true, causes destructive
+	// or privileged operations (removing controllers, removing clouds
+	// from controllers and revoking JIMM administrator access) to be
+	// rejected unless the caller supplies a reason.
+	RequireReasonForPrivilegedOperations bool
+
+	// CharmHubCache, if set, caches the responses to charm metadata
+	// requests proxied to controllers.
+	CharmHubCache *jimmRPC.CharmHubCache
+
+	// Branding, if set, is returned to users starting a login so that
+	// they can see whose JAAS they are signing into.
+	Branding *params.Branding
+
+	// RateLimiter, if set, limits the rate of requests made on
+	// controller connections.
+	RateLimiter *RateLimiter
+
+	// TrustedProxies holds the addresses of the proxies whose
+	// X-Forwarded-For headers are used to find the client address.
+	TrustedProxies []netip.Prefix
 }
 
 // APIHandler returns an http Handler for the /api endpoint.
@@ -30,6 +55,7 @@ func APIHandler(ctx context.Context, jimm *jimm.JIMM, p Params) http.Handler {
 			jimm:   jimm,
 			params: p,
 		},
+		TrustedProxies: p.TrustedProxies,
 	}
 }
 
@@ -39,14 +65,17 @@ func ModelHandler(ctx context.Context, jimm *jimm.JIMM, p Params) http.Handler {
 	mux.Handle("/{uuid}/api", &jimmhttp.WSHandler{
 		Upgrader: websocketUpgrader,
 		Server: &apiProxier{apiServer: apiServer{
-			jimm: jimm,
+			jimm:   jimm,
+			params: p,
 		}},
+		TrustedProxies: p.TrustedProxies,
 	})
 	mux.Handle("/{uuid}/log", &jimmhttp.WSHandler{
 		Upgrader: websocketUpgrader,
 		Server: &streamProxier{apiServer: apiServer{
 			jimm: jimm,
 		}},
+		TrustedProxies: p.TrustedProxies,
 	})
 	return mux
 }
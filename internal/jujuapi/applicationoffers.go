@@ -27,15 +27,29 @@ func init() {
 		findOffersMethod := rpc.Method(r.FindApplicationOffers)
 		applicationOffersMethod := rpc.Method(r.ApplicationOffers)
 
-		r.AddMethod("ApplicationOffers", 4, "Offer", offerMethod)
+		r.addMutatingMethod("ApplicationOffers", 4, "Offer", offerMethod)
 		r.AddMethod("ApplicationOffers", 4, "GetConsumeDetails", getConsumeDetailsMethod)
 		r.AddMethod("ApplicationOffers", 4, "ListApplicationOffers", listOffersMethod)
-		r.AddMethod("ApplicationOffers", 4, "ModifyOfferAccess", modifyOfferAccessMethod)
-		r.AddMethod("ApplicationOffers", 4, "DestroyOffers", destroyOffersMethod)
+		r.addMutatingMethod("ApplicationOffers", 4, "ModifyOfferAccess", modifyOfferAccessMethod)
+		r.addMutatingMethod("ApplicationOffers", 4, "DestroyOffers", destroyOffersMethod)
 		r.AddMethod("ApplicationOffers", 4, "FindApplicationOffers", findOffersMethod)
 		r.AddMethod("ApplicationOffers", 4, "ApplicationOffers", applicationOffersMethod)
 
-		return []int{4}
+		// Versions 4 and 5 share parameters for every method. The only
+		// difference is in the ListApplicationOffers results: version 4
+		// offer details may carry spaces and bindings, which version 5
+		// removed. Juju sends both fields with omitempty and JIMM never
+		// populates them, so the version 5 results are also valid
+		// version 4 results.
+		r.addMutatingMethod("ApplicationOffers", 5, "Offer", offerMethod)
+		r.AddMethod("ApplicationOffers", 5, "GetConsumeDetails", getConsumeDetailsMethod)
+		r.AddMethod("ApplicationOffers", 5, "ListApplicationOffers", listOffersMethod)
+		r.addMutatingMethod("ApplicationOffers", 5, "ModifyOfferAccess", modifyOfferAccessMethod)
+		r.addMutatingMethod("ApplicationOffers", 5, "DestroyOffers", destroyOffersMethod)
+		r.AddMethod("ApplicationOffers", 5, "FindApplicationOffers", findOffersMethod)
+		r.AddMethod("ApplicationOffers", 5, "ApplicationOffers", applicationOffersMethod)
+
+		return []int{4, 5}
 	}
 }
 
@@ -280,6 +280,46 @@ func (s *applicationOffersSuite) TestListApplicationOffers(c *gc.C) {
 	}})
 }
 
+func (s *applicationOffersSuite) TestListApplicationOffersFacadeVersions(c *gc.C) {
+	conn := s.open(c, nil, "bob@canonical.com")
+	defer conn.Close()
+	c.Assert(conn.BestFacadeVersion("ApplicationOffers"), gc.Equals, 5)
+
+	results, err := applicationoffers.NewClient(conn).Offer(
+		s.Model.UUID.String,
+		"test-app",
+		[]string{s.endpoint.Name},
+		"bob@canonical.com",
+		"test-offer1",
+		"test offer 1 description",
+	)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.Equals, (*jujuparams.Error)(nil))
+
+	args := jujuparams.OfferFilters{
+		Filters: []jujuparams.OfferFilter{{
+			ModelName:       s.Model.Name,
+			ApplicationName: "test-app",
+			OfferName:       "test-offer1",
+		}},
+	}
+	var v5 jujuparams.QueryApplicationOffersResultsV5
+	err = conn.APICall("ApplicationOffers", 5, "", "ListApplicationOffers", args, &v5)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(v5.Results, gc.HasLen, 1)
+	c.Check(v5.Results[0].OfferName, gc.Equals, "test-offer1")
+	c.Check(v5.Results[0].OfferURL, gc.Equals, "bob@canonical.com/model-1.test-offer1")
+
+	var v4 jujuparams.QueryApplicationOffersResultsV4
+	err = conn.APICall("ApplicationOffers", 4, "", "ListApplicationOffers", args, &v4)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(v4.Results, gc.HasLen, 1)
+	c.Check(v4.Results[0].ApplicationOfferAdminDetailsV5, jc.DeepEquals, v5.Results[0])
+	c.Check(v4.Results[0].Spaces, gc.HasLen, 0)
+	c.Check(v4.Results[0].Bindings, gc.HasLen, 0)
+}
+
 func (s *applicationOffersSuite) TestModifyOfferAccess(c *gc.C) {
 	/*
 		ctx := context.Background()
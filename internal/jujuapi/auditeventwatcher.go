@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func init() {
+	facadeInit["AuditEventWatcher"] = func(r *controllerRoot) []int {
+		nextMethod := rpc.Method(r.AuditEventWatcherNext)
+		stopMethod := rpc.Method(r.AuditEventWatcherStop)
+
+		r.AddMethod("AuditEventWatcher", 1, "Next", nextMethod)
+		r.AddMethod("AuditEventWatcher", 1, "Stop", stopMethod)
+
+		return []int{1}
+	}
+}
+
+// WatchAuditEvents implements the WatchAuditEvents method on the JIMM
+// facade. It starts a watcher that returns audit events matching the
+// given filters as they are added. Only users that can view the audit log
+// may watch it.
+func (r *controllerRoot) WatchAuditEvents(ctx context.Context, req apiparams.WatchAuditEventsRequest) (apiparams.WatchAuditEventsResponse, error) {
+	const op = errors.Op("jujuapi.WatchAuditEvents")
+
+	filter := db.AuditLogFilter{
+		Model:  req.Model,
+		Method: req.Method,
+	}
+	if req.UserTag != "" {
+		tag, err := names.ParseUserTag(req.UserTag)
+		if err != nil {
+			return apiparams.WatchAuditEventsResponse{}, errors.E(op, err, errors.CodeBadRequest, `invalid "user-tag" filter`)
+		}
+		filter.IdentityTag = tag.String()
+	}
+	if err := r.setupUUIDGenerator(); err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+	w, err := r.jimm.WatchAuditEvents(ctx, r.user, filter)
+	if err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+	r.auditWatchers.register(id, w)
+	token, err := r.makeResumable(id, resumableAuditEvents)
+	if err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+	return apiparams.WatchAuditEventsResponse{WatcherID: id, ResumeToken: token}, nil
+}
+
+// AuditEventWatcherNext implements the Next method on the
+// AuditEventWatcher facade. It blocks until new audit events are
+// available.
+func (r *controllerRoot) AuditEventWatcherNext(ctx context.Context, objID string) (apiparams.AuditEventWatcherNextResponse, error) {
+	const op = errors.Op("jujuapi.AuditEventWatcherNext")
+
+	w, err := r.auditWatchers.get(objID)
+	if err != nil {
+		return apiparams.AuditEventWatcherNextResponse{}, errors.E(op, err)
+	}
+	entries, dropped, err := w.Next(ctx)
+	if err != nil {
+		return apiparams.AuditEventWatcherNextResponse{}, errors.E(op, err)
+	}
+	events := make([]apiparams.AuditEvent, len(entries))
+	for i, ent := range entries {
+		events[i] = ent.ToAPIAuditEvent()
+	}
+	return apiparams.AuditEventWatcherNextResponse{
+		Events:  events,
+		Dropped: dropped,
+	}, nil
+}
+
+// AuditEventWatcherStop implements the Stop method on the
+// AuditEventWatcher facade.
+func (r *controllerRoot) AuditEventWatcherStop(ctx context.Context, objID string) error {
+	const op = errors.Op("jujuapi.AuditEventWatcherStop")
+
+	w, err := r.auditWatchers.remove(objID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.forgetResumable(objID)
+	return w.Stop()
+}
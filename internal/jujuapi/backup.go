@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// Backup returns a snapshot of JIMM's database and OpenFGA relation
+// tuples. Tuples are returned using OpenFGA's own entity names, rather
+// than JAAS tags, so that they can be restored exactly.
+func (r *controllerRoot) Backup(ctx context.Context) (apiparams.Backup, error) {
+	const op = errors.Op("jujuapi.Backup")
+
+	b, err := r.jimm.Backup(ctx, r.user)
+	if err != nil {
+		return apiparams.Backup{}, errors.E(op, err)
+	}
+	resp := apiparams.Backup{
+		Time:                 b.Time,
+		DatabaseMajorVersion: b.Version.Major,
+		DatabaseMinorVersion: b.Version.Minor,
+		Tables:               make([]apiparams.BackupTable, len(b.Tables)),
+		Tuples:               make([]apiparams.RelationshipTuple, len(b.Tuples)),
+	}
+	for i, t := range b.Tables {
+		resp.Tables[i] = apiparams.BackupTable{
+			Name: t.Name,
+			Rows: t.Rows,
+		}
+	}
+	for i, t := range b.Tuples {
+		resp.Tuples[i] = apiparams.RelationshipTuple{
+			Object:       t.Object.String(),
+			Relation:     string(t.Relation),
+			TargetObject: t.Target.String(),
+		}
+	}
+	return resp, nil
+}
+
+// Restore restores a backup returned by Backup into a fresh JIMM
+// deployment.
+func (r *controllerRoot) Restore(ctx context.Context, req apiparams.RestoreRequest) error {
+	const op = errors.Op("jujuapi.Restore")
+
+	b := jimm.Backup{
+		Time: req.Backup.Time,
+		Version: dbmodel.Version{
+			Component: dbmodel.Component,
+			Major:     req.Backup.DatabaseMajorVersion,
+			Minor:     req.Backup.DatabaseMinorVersion,
+		},
+		Tables: make([]db.TableDump, len(req.Backup.Tables)),
+		Tuples: make([]openfga.Tuple, len(req.Backup.Tuples)),
+	}
+	for i, t := range req.Backup.Tables {
+		b.Tables[i] = db.TableDump{
+			Name: t.Name,
+			Rows: t.Rows,
+		}
+	}
+	for i, t := range req.Backup.Tuples {
+		object, err := openfga.ParseTag(t.Object)
+		if err != nil {
+			return errors.E(op, errors.CodeBadRequest, err)
+		}
+		target, err := openfga.ParseTag(t.TargetObject)
+		if err != nil {
+			return errors.E(op, errors.CodeBadRequest, err)
+		}
+		b.Tuples[i] = openfga.Tuple{
+			Object:   &object,
+			Relation: openfga.Relation(t.Relation),
+			Target:   &target,
+		}
+	}
+	if err := r.jimm.Restore(ctx, r.user, &b); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
@@ -37,8 +38,8 @@ func init() {
 		updateCredentialsCheckModelsMethod := rpc.Method(r.UpdateCredentialsCheckModels)
 		userCredentialsMethod := rpc.Method(r.UserCredentials)
 
-		r.AddMethod("Cloud", 7, "AddCloud", addCloudMethod)
-		r.AddMethod("Cloud", 7, "AddCredentials", addCredentialsMethod)
+		r.addMutatingMethod("Cloud", 7, "AddCloud", addCloudMethod)
+		r.addMutatingMethod("Cloud", 7, "AddCredentials", addCredentialsMethod)
 		r.AddMethod("Cloud", 7, "CheckCredentialsModels", checkCredentialsModelsMethod)
 		r.AddMethod("Cloud", 7, "Cloud", cloudMethod)
 		r.AddMethod("Cloud", 7, "CloudInfo", cloudInfoMethod)
@@ -46,11 +47,11 @@ func init() {
 		r.AddMethod("Cloud", 7, "Credential", credentialMethod)
 		r.AddMethod("Cloud", 7, "CredentialContents", credentialContentsMethod)
 		r.AddMethod("Cloud", 7, "ListCloudInfo", listCloudInfoMethod)
-		r.AddMethod("Cloud", 7, "ModifyCloudAccess", modifyCloudAccessMethod)
-		r.AddMethod("Cloud", 7, "RemoveClouds", removeCloudsMethod)
-		r.AddMethod("Cloud", 7, "RevokeCredentialsCheckModels", revokeCredentialsCheckModelsMethod)
-		r.AddMethod("Cloud", 7, "UpdateCloud", updateCloudMethod)
-		r.AddMethod("Cloud", 7, "UpdateCredentialsCheckModels", updateCredentialsCheckModelsMethod)
+		r.addMutatingMethod("Cloud", 7, "ModifyCloudAccess", modifyCloudAccessMethod)
+		r.addMutatingMethod("Cloud", 7, "RemoveClouds", removeCloudsMethod)
+		r.addMutatingMethod("Cloud", 7, "RevokeCredentialsCheckModels", revokeCredentialsCheckModelsMethod)
+		r.addMutatingMethod("Cloud", 7, "UpdateCloud", updateCloudMethod)
+		r.addMutatingMethod("Cloud", 7, "UpdateCredentialsCheckModels", updateCredentialsCheckModelsMethod)
 		r.AddMethod("Cloud", 7, "UserCredentials", userCredentialsMethod)
 
 		return []int{7}
@@ -147,13 +148,29 @@ func (r *controllerRoot) UserCredentials(ctx context.Context, userclouds jujupar
 	}, nil
 }
 
+// RevokeCredentialsCheckModels implements the RevokeCredentialsCheckModels
+// method of the Cloud facade. All the credentials are revoked together,
+// see jimm.RevokeCloudCredentials.
 func (r *controllerRoot) RevokeCredentialsCheckModels(ctx context.Context, args jujuparams.RevokeCredentialArgs) (jujuparams.ErrorResults, error) {
+	const op = errors.Op("jujuapi.RevokeCredentialsCheckModels")
+
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 	results := make([]jujuparams.ErrorResult, len(args.Credentials))
+	revokeArgs := make([]jimm.RevokeCloudCredentialArgs, 0, len(args.Credentials))
+	indexes := make([]int, 0, len(args.Credentials))
 	for i, ent := range args.Credentials {
-		if err := r.revokeCredential(ctx, ent.Tag, ent.Force); err != nil {
-			results[i].Error = mapError(err)
+		ct, err := names.ParseCloudCredentialTag(ent.Tag)
+		if err != nil {
+			results[i].Error = mapError(errors.E(op, err, errors.CodeBadRequest))
+			continue
+		}
+		revokeArgs = append(revokeArgs, jimm.RevokeCloudCredentialArgs{Tag: ct, Force: ent.Force})
+		indexes = append(indexes, i)
+	}
+	for i, err := range r.jimm.RevokeCloudCredentials(ctx, r.user.Identity, revokeArgs) {
+		if err != nil {
+			results[indexes[i]].Error = mapError(errors.E(op, err))
 		}
 	}
 	return jujuparams.ErrorResults{
@@ -161,20 +178,6 @@ func (r *controllerRoot) RevokeCredentialsCheckModels(ctx context.Context, args
 	}, nil
 }
 
-// RevokeCredentials revokes a set of cloud credentials.
-func (r *controllerRoot) revokeCredential(ctx context.Context, tag string, force bool) error {
-	const op = errors.Op("jujuapi.RevokeCredentialsCheckModels")
-
-	ct, err := names.ParseCloudCredentialTag(tag)
-	if err != nil {
-		return errors.E(op, err, errors.CodeBadRequest)
-	}
-	if err := r.jimm.RevokeCloudCredential(ctx, r.user.Identity, ct, force); err != nil {
-		return errors.E(op, err)
-	}
-	return nil
-}
-
 // Credential implements the Credential method of the Cloud facade.
 func (r *controllerRoot) Credential(ctx context.Context, args jujuparams.Entities) (jujuparams.CloudCredentialResults, error) {
 	results := make([]jujuparams.CloudCredentialResult, len(args.Entities))
@@ -358,18 +361,17 @@ func getIdentityCredentials(ctx context.Context, user *openfga.User, j JIMM, arg
 		return jujuparams.CredentialContentResults{Results: results}, nil
 	}
 
-	err := j.ForEachUserCloudCredential(ctx, user.Identity, names.CloudTag{}, func(c *dbmodel.CloudCredential) error {
+	creds, err := j.ListCloudCredentials(ctx, user, db.CloudCredentialFilter{OwnerIdentityName: user.Name})
+	if err != nil {
+		return jujuparams.CredentialContentResults{}, errors.E(op, err)
+	}
+	for i := range creds {
 		var result jujuparams.CredentialContentResult
-		var err error
-		result.Result, err = credentialContents(c)
+		result.Result, err = credentialContents(&creds[i])
 		if err != nil {
 			result.Error = mapError(errors.E(op, err))
 		}
 		results = append(results, result)
-		return nil
-	})
-	if err != nil {
-		return jujuparams.CredentialContentResults{}, errors.E(op, err)
 	}
 	return jujuparams.CredentialContentResults{Results: results}, nil
 }
@@ -396,6 +398,10 @@ func (r *controllerRoot) RemoveClouds(ctx context.Context, args jujuparams.Entit
 	return result, nil
 }
 
+// CheckCredentialsModels implements the CheckCredentialsModels method of
+// the Cloud facade. It performs a dry run of updating the given
+// credentials, reporting the impact on each model using them without
+// changing anything.
 func (r *controllerRoot) CheckCredentialsModels(ctx context.Context, args jujuparams.TaggedCredentials) (jujuparams.UpdateCredentialResults, error) {
 	return r.updateCredentials(ctx, args.Credentials, false, true)
 }
@@ -446,13 +452,13 @@ func (r *controllerRoot) UpdateCredentialsCheckModels(ctx context.Context, args
 	return r.updateCredentials(ctx, args.Credentials, args.Force, false)
 }
 
-func (r *controllerRoot) updateCredentials(ctx context.Context, args []jujuparams.TaggedCredential, skipCheck, skipUpdate bool) (jujuparams.UpdateCredentialResults, error) {
+func (r *controllerRoot) updateCredentials(ctx context.Context, args []jujuparams.TaggedCredential, skipCheck, dryRun bool) (jujuparams.UpdateCredentialResults, error) {
 	results := jujuparams.UpdateCredentialResults{
 		Results: make([]jujuparams.UpdateCredentialResult, len(args)),
 	}
 	for i, arg := range args {
 		var err error
-		models, err := r.updateCredential(ctx, arg, skipCheck, skipUpdate)
+		models, err := r.updateCredential(ctx, arg, skipCheck, dryRun)
 		results.Results[i] = jujuparams.UpdateCredentialResult{
 			CredentialTag: arg.Tag,
 			Error:         mapError(err),
@@ -463,7 +469,7 @@ func (r *controllerRoot) updateCredentials(ctx context.Context, args []jujuparam
 	return results, nil
 }
 
-func (r *controllerRoot) updateCredential(ctx context.Context, cred jujuparams.TaggedCredential, skipCheck, skipUpdate bool) ([]jujuparams.UpdateCredentialModelResult, error) {
+func (r *controllerRoot) updateCredential(ctx context.Context, cred jujuparams.TaggedCredential, skipCheck, dryRun bool) ([]jujuparams.UpdateCredentialModelResult, error) {
 	tag, err := names.ParseCloudCredentialTag(cred.Tag)
 	if err != nil {
 		return nil, errors.E(err, errors.CodeBadRequest)
@@ -472,30 +478,31 @@ func (r *controllerRoot) updateCredential(ctx context.Context, cred jujuparams.T
 		CredentialTag: tag,
 		Credential:    cred.Credential,
 		SkipCheck:     skipCheck,
-		SkipUpdate:    skipUpdate,
+		DryRun:        dryRun,
 	})
 }
 
-// UpdateCloud updates the specified clouds.
+// UpdateCloud implements the UpdateCloud method of the Cloud facade. It
+// updates the definitions of the specified clouds, which may add or
+// remove regions, on JIMM and on the controllers hosting them.
 func (r *controllerRoot) UpdateCloud(ctx context.Context, args jujuparams.UpdateCloudArgs) (jujuparams.ErrorResults, error) {
+	const op = errors.Op("jujuapi.UpdateCloud")
+
 	results := jujuparams.ErrorResults{
 		Results: make([]jujuparams.ErrorResult, len(args.Clouds)),
 	}
-	for i := range args.Clouds {
-		err := r.updateCloud()
-		if err != nil {
-			results.Results[i].Error = mapError(err)
+	for i, cloud := range args.Clouds {
+		if !names.IsValidCloud(cloud.Name) {
+			results.Results[i].Error = mapError(errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid cloud name %q", cloud.Name)))
+			continue
+		}
+		if err := r.jimm.UpdateCloud(ctx, r.user, names.NewCloudTag(cloud.Name), cloud.Cloud); err != nil {
+			results.Results[i].Error = mapError(errors.E(op, err))
 		}
 	}
 	return results, nil
 }
 
-func (r *controllerRoot) updateCloud() error {
-	// TODO(mhilton) work out how to support updating clouds, for now
-	// tell everyone they're not allowed.
-	return errors.E(errors.CodeForbidden, "permission denied")
-}
-
 // CloudInfo implements the cloud facades CloudInfo method.
 func (r *controllerRoot) CloudInfo(ctx context.Context, args jujuparams.Entities) (jujuparams.CloudInfoResults, error) {
 	const op = errors.Op("jujuapi.CloudInfo")
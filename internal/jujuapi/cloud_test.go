@@ -875,15 +875,73 @@ func (s *cloudSuite) TestUpdateCloud(c *gc.C) {
 	conn := s.open(c, nil, "test")
 	defer conn.Close()
 	client := cloudapi.NewClient(conn)
-	err := client.UpdateCloud(cloud.Cloud{
+	err := client.AddCloud(cloud.Cloud{
 		Name:             "test-cloud",
 		Type:             "kubernetes",
 		AuthTypes:        cloud.AuthTypes{cloud.CertificateAuthType},
 		Endpoint:         "https://0.1.2.3:5678",
 		IdentityEndpoint: "https://0.1.2.3:5679",
 		StorageEndpoint:  "https://0.1.2.3:5680",
+		HostCloudRegion:  jimmtest.TestCloudName + "/" + jimmtest.TestCloudRegionName,
+	}, false)
+	c.Assert(err, gc.Equals, nil)
+
+	err = client.UpdateCloud(cloud.Cloud{
+		Name:             "test-cloud",
+		Type:             "kubernetes",
+		AuthTypes:        cloud.AuthTypes{cloud.CertificateAuthType},
+		Endpoint:         "https://0.1.2.4:5678",
+		IdentityEndpoint: "https://0.1.2.4:5679",
+		StorageEndpoint:  "https://0.1.2.4:5680",
+		HostCloudRegion:  jimmtest.TestCloudName + "/" + jimmtest.TestCloudRegionName,
+		Regions: []cloud.Region{{
+			Name: "default",
+		}},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	clouds, err := client.Clouds()
+	c.Assert(err, gc.Equals, nil)
+	c.Check(clouds[names.NewCloudTag("test-cloud")], jc.DeepEquals, cloud.Cloud{
+		Name:             "test-cloud",
+		Type:             "kubernetes",
+		AuthTypes:        cloud.AuthTypes{"certificate"},
+		Endpoint:         "https://0.1.2.4:5678",
+		IdentityEndpoint: "https://0.1.2.4:5679",
+		StorageEndpoint:  "https://0.1.2.4:5680",
+		Regions: []cloud.Region{{
+			Name: "default",
+		}},
+	})
+
+	// Other users cannot update the cloud.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := cloudapi.NewClient(conn2)
+	err = client2.UpdateCloud(cloud.Cloud{
+		Name:      "test-cloud",
+		Type:      "kubernetes",
+		AuthTypes: cloud.AuthTypes{cloud.CertificateAuthType},
+		Regions: []cloud.Region{{
+			Name: "default",
+		}},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized`)
+}
+
+func (s *cloudSuite) TestUpdateCloudNotFound(c *gc.C) {
+	conn := s.open(c, nil, "test")
+	defer conn.Close()
+	client := cloudapi.NewClient(conn)
+	err := client.UpdateCloud(cloud.Cloud{
+		Name:      "no-such-cloud",
+		Type:      "kubernetes",
+		AuthTypes: cloud.AuthTypes{cloud.CertificateAuthType},
+		Regions: []cloud.Region{{
+			Name: "default",
+		}},
 	})
-	c.Assert(jujuparams.IsCodeForbidden(err), gc.Equals, true, gc.Commentf("%#v", err))
+	c.Check(err, gc.ErrorMatches, `cloud "no-such-cloud" not found`)
 }
 
 func (s *cloudSuite) TestCloudInfo(c *gc.C) {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	jimmversion "github.com/canonical/jimm/v3/version"
@@ -33,9 +34,10 @@ func init() {
 		watchModelSummariesMethod := rpc.Method(r.WatchModelSummaries)
 		watchAllModelSummariesMethod := rpc.Method(r.WatchAllModelSummaries)
 		initiateMigrationMethod := rpc.Method(r.InitiateMigration)
+		watchAllModelsMethod := rpc.Method(r.WatchAllModels)
 
 		r.AddMethod("Controller", 11, "AllModels", allModelsMethod)
-		r.AddMethod("Controller", 11, "ConfigSet", configSetMethod)
+		r.addMutatingMethod("Controller", 11, "ConfigSet", configSetMethod)
 		r.AddMethod("Controller", 11, "ControllerConfig", controllerConfigMethod)
 		r.AddMethod("Controller", 11, "ControllerVersion", controllerVersionMethod)
 		r.AddMethod("Controller", 11, "GetControllerAccess", getControllerAccessMethod)
@@ -45,7 +47,8 @@ func init() {
 		r.AddMethod("Controller", 11, "MongoVersion", mongoVersionMethod)
 		r.AddMethod("Controller", 11, "WatchModelSummaries", watchModelSummariesMethod)
 		r.AddMethod("Controller", 11, "WatchAllModelSummaries", watchAllModelSummariesMethod)
-		r.AddMethod("Controller", 11, "InitiateMigration", initiateMigrationMethod)
+		r.addMutatingMethod("Controller", 11, "InitiateMigration", initiateMigrationMethod)
+		r.AddMethod("Controller", 11, "WatchAllModels", watchAllModelsMethod)
 
 		return []int{11}
 	}
@@ -53,14 +56,17 @@ func init() {
 
 // ControllerService defines the methods used to manage controllers.
 type ControllerService interface {
-	AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error
+	AddControllerWithDiscovery(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) ([]jimm.DiscoveredCloudRegion, error)
 	ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error)
 	EarliestControllerVersion(ctx context.Context) (version.Number, error)
 	ListControllers(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
+	ControllerModelCounts(ctx context.Context, user *openfga.User) (map[string]int, error)
 	GetControllerConfig(ctx context.Context, user *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	SetControllerConfig(ctx context.Context, user *openfga.User, args jujuparams.ControllerConfigSet) error
 	RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	DrainController(ctx context.Context, user *openfga.User, controllerName string, draining, migrate bool) ([]jimm.DrainMigration, error)
+	MigrateControllerCredentials(ctx context.Context, user *openfga.User) (*jimm.MigrateControllerCredentialsResult, error)
 }
 
 // ConfigSet changes the value of specified controller configuration
@@ -5,6 +5,7 @@ package jujuapi
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,9 +37,15 @@ type JIMM interface {
 	AddAuditLogEntry(ale *dbmodel.AuditLogEntry)
 	AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddHostedCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
-	AddServiceAccount(ctx context.Context, u *openfga.User, clientId string) error
+	AddMaintenanceWindow(ctx context.Context, user *openfga.User, controllerName string, startsAt, endsAt time.Time, reason string) (*dbmodel.MaintenanceWindow, error)
+	AddModelAsync(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (*dbmodel.Task, error)
+	AddModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, webhookURL string, events []string) (*dbmodel.ModelWebhook, error)
+	AddNotificationRoute(ctx context.Context, user *openfga.User, subject, kind, transport, destination string) (*jimm.NotificationRoute, error)
+	AddServiceAccount(ctx context.Context, u *openfga.User, clientId, purpose string) error
+	Backup(ctx context.Context, user *openfga.User) (*jimm.Backup, error)
 	CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
 	CountIdentities(ctx context.Context, user *openfga.User) (int, error)
+	DeleteServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error
 	DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) error
 	FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
@@ -52,38 +59,87 @@ type JIMM interface {
 	GetCloudCredentialAttributes(ctx context.Context, u *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error)
 	GetCredentialStore() credentials.CredentialStore
 	GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error)
+	GetMaintenanceMode(ctx context.Context) (*dbmodel.MaintenanceMode, error)
+	GetModelArchive(ctx context.Context, user *openfga.User, modelUUID string) (*dbmodel.ModelArchive, error)
+	GetModelConfigTemplate(ctx context.Context, user *openfga.User, path string) (*dbmodel.ModelConfigTemplate, error)
 	// FetchIdentity finds the user in jimm or returns a not-found error
 	FetchIdentity(ctx context.Context, username string) (*openfga.User, error)
+	GetTask(ctx context.Context, user *openfga.User, id uint) (*dbmodel.Task, error)
 	GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error)
 	GetUserControllerAccess(ctx context.Context, user *openfga.User, controller names.ControllerTag) (string, error)
 	GetUserModelAccess(ctx context.Context, user *openfga.User, model names.ModelTag) (string, error)
 	GrantAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	GrantCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
+	GrantControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error)
 	GrantModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	GrantOfferAccess(ctx context.Context, u *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) error
 	GrantServiceAccountAccess(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, tags []string) error
 	InitiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error)
 	InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ListCloudCredentials(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error)
+	ListDeprecatedFacadeUsage(ctx context.Context, user *openfga.User) ([]jimm.DeprecatedFacadeUsage, error)
+	ListExpiringCredentials(ctx context.Context, user *openfga.User, within time.Duration) ([]dbmodel.CloudCredential, error)
 	ListIdentities(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]openfga.User, error)
+	ListLimits(ctx context.Context, user *openfga.User) ([]jimm.LimitUsage, error)
+	ListMaintenanceWindows(ctx context.Context, user *openfga.User, controllerName string, all bool) ([]dbmodel.MaintenanceWindow, error)
+	ListMigrations(ctx context.Context, user *openfga.User, filter db.MigrationFilter) ([]dbmodel.Migration, error)
+	ListModelConfigPolicies(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigPolicy, error)
+	ListModelAliases(ctx context.Context, user *openfga.User) ([]dbmodel.ModelAlias, error)
+	ListModelWebhooks(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]dbmodel.ModelWebhook, error)
+	ListModelConfigTemplates(ctx context.Context, user *openfga.User) ([]dbmodel.ModelConfigTemplate, error)
+	ListNotificationRoutes(ctx context.Context, user *openfga.User) ([]jimm.NotificationRoute, error)
 	ListResources(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error)
+	ListServiceAccounts(ctx context.Context, u *openfga.User) ([]dbmodel.ServiceAccount, error)
+	ListSessions(ctx context.Context, user *openfga.User, identityName string) ([]dbmodel.Session, error)
 	Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	PubSubHub() *pubsub.Hub
 	PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
+	RecordDeprecatedFacadeUsage(ctx context.Context, user *openfga.User, facade string, version int)
 	RemoveCloud(ctx context.Context, u *openfga.User, ct names.CloudTag) error
 	RemoveCloudFromController(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
 	RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error
+	RemoveLimit(ctx context.Context, user *openfga.User, entity, scope string) error
+	RemoveMaintenanceWindow(ctx context.Context, user *openfga.User, id uint) error
+	RemoveModelConfigPolicy(ctx context.Context, user *openfga.User, key string) error
+	RemoveModelAlias(ctx context.Context, user *openfga.User, alias string) error
+	RemoveModelConfigTemplate(ctx context.Context, user *openfga.User, path string) error
+	RemoveModelWebhook(ctx context.Context, user *openfga.User, mt names.ModelTag, id uint) error
+	ResolveModelTag(ctx context.Context, user *openfga.User, ref string) (names.ModelTag, error)
+	RemoveNotificationRoute(ctx context.Context, user *openfga.User, id uint) error
+	OffboardUser(ctx context.Context, user *openfga.User, identityName, reassignTo string) (*jimm.OffboardUserSummary, error)
 	ResourceTag() names.ControllerTag
+	Restore(ctx context.Context, user *openfga.User, b *jimm.Backup) error
 	RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	RevokeCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
-	RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
+	RevokeCloudCredentials(ctx context.Context, user *dbmodel.Identity, args []jimm.RevokeCloudCredentialArgs) []error
+	RevokeControllerAccess(ctx context.Context, user *openfga.User, access string, users []names.UserTag) ([]error, error)
 	RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	RevokeSessions(ctx context.Context, user *openfga.User, identityName string) (int, error)
+	RotateSessionKey(ctx context.Context, user *openfga.User) error
+	ScheduleMigration(ctx context.Context, user *openfga.User, mt names.ModelTag, targetController string) (*dbmodel.Migration, error)
+	SearchOffers(ctx context.Context, user *openfga.User, filter jimm.OfferDirectoryFilter) ([]jimm.OfferDirectoryEntry, error)
+	ServiceSelfTest(ctx context.Context, user *openfga.User) ([]jimm.SelfTestResult, error)
+	SetCloudCredentialExpiry(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, expiresAt *time.Time) error
+	SetCloudRegionDisabled(ctx context.Context, user *openfga.User, ct names.CloudTag, regionName string, disabled bool) error
+	SetLimit(ctx context.Context, user *openfga.User, entity, scope string, value int64) error
+	SetMaintenanceMode(ctx context.Context, user *openfga.User, enabled bool, message string) error
+	SetModelAlias(ctx context.Context, user *openfga.User, alias, ref string) (*dbmodel.ModelAlias, error)
+	SetModelConfigPolicy(ctx context.Context, user *openfga.User, key, action string, value *string, description string) error
+	SetModelConfigTemplate(ctx context.Context, user *openfga.User, path string, config map[string]any, sharedWith []string) (*dbmodel.ModelConfigTemplate, error)
+	SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string) error
+	SetServiceAccountAllowedCIDRs(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, cidrs []string) error
+	SetServiceAccountDisabled(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, disabled bool) error
 	ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
+	TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag) error
 	UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
 	UpdateCloud(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error
 	UpdateCloudCredential(ctx context.Context, u *openfga.User, args jimm.UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error)
+	UsageReport(ctx context.Context, user *openfga.User, from, to time.Time, daily bool) ([]dbmodel.UsageRecord, error)
 	UserLogin(ctx context.Context, identityName string) (*openfga.User, error)
+	WatchAllModels(ctx context.Context, user *openfga.User, modelUUIDs []string) (*jimm.AllModelWatcher, error)
+	WatchAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) (*jimm.AuditEventWatcher, error)
 }
 
 // controllerRoot is the root for endpoints served on controller connections.
@@ -95,11 +151,21 @@ type controllerRoot struct {
 	watchers *watcherRegistry
 	pingF    func()
 
+	allWatchers    *stopperRegistry[*jimm.AllModelWatcher]
+	accessWatchers *stopperRegistry[*accessWatcher]
+	auditWatchers  *stopperRegistry[*jimm.AuditEventWatcher]
+
+	// mutatingMu protects mutating, which holds the methods, in the
+	// form "<facade>.<method>", that modify JIMM's state.
+	mutatingMu sync.RWMutex
+	mutating   map[string]bool
+
 	// mu protects the fields below it
 	mu                    sync.Mutex
 	user                  *openfga.User
 	controllerUUIDMasking bool
 	generator             *fastuuid.Generator
+	resumables            map[string]resumable
 
 	// deviceOAuthResponse holds a device code flow response for this request,
 	// such that JIMM can retrieve the access and ID tokens via polling the Authentication
@@ -122,6 +188,9 @@ func newControllerRoot(j JIMM, p Params, identityId string) *controllerRoot {
 		params:                p,
 		jimm:                  j,
 		watchers:              watcherRegistry,
+		allWatchers:           &stopperRegistry[*jimm.AllModelWatcher]{},
+		accessWatchers:        &stopperRegistry[*accessWatcher]{},
+		auditWatchers:         &stopperRegistry[*jimm.AuditEventWatcher]{},
 		pingF:                 func() {},
 		controllerUUIDMasking: true,
 		identityId:            identityId,
@@ -184,7 +253,11 @@ func (r *controllerRoot) setPingF(f func()) {
 
 // cleanup releases all resources used by the controllerRoot.
 func (r *controllerRoot) cleanup() {
+	r.parkResumables()
 	r.watchers.stop()
+	r.allWatchers.stop()
+	r.accessWatchers.stop()
+	r.auditWatchers.stop()
 }
 
 func (r *controllerRoot) setupUUIDGenerator() error {
@@ -216,3 +289,12 @@ func (r *controllerRoot) getUser() names.UserTag {
 	}
 	return names.UserTag{}
 }
+
+// checkReason returns an error if the deployment requires a reason for
+// privileged operations and none has been supplied.
+func (r *controllerRoot) checkReason(reason string) error {
+	if r.params.RequireReasonForPrivilegedOperations && strings.TrimSpace(reason) == "" {
+		return errors.E(errors.CodeBadRequest, "a reason is required for this operation")
+	}
+	return nil
+}
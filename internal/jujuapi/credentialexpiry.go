@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SetCloudCredentialExpiry records the time at which a cloud credential
+// expires.
+func (r *controllerRoot) SetCloudCredentialExpiry(ctx context.Context, req apiparams.SetCloudCredentialExpiryRequest) error {
+	const op = errors.Op("jujuapi.SetCloudCredentialExpiry")
+
+	tag, err := names.ParseCloudCredentialTag(req.CredentialTag)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if err := r.jimm.SetCloudCredentialExpiry(ctx, r.user, tag, req.ExpiresAt); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListExpiringCredentials returns the cloud credentials visible to the
+// authenticated user that expire within the requested period.
+func (r *controllerRoot) ListExpiringCredentials(ctx context.Context, req apiparams.ListExpiringCredentialsRequest) (apiparams.ListExpiringCredentialsResponse, error) {
+	const op = errors.Op("jujuapi.ListExpiringCredentials")
+
+	if req.Within < 0 {
+		return apiparams.ListExpiringCredentialsResponse{}, errors.E(op, errors.CodeBadRequest, "period cannot be negative")
+	}
+	creds, err := r.jimm.ListExpiringCredentials(ctx, r.user, req.Within)
+	if err != nil {
+		return apiparams.ListExpiringCredentialsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListExpiringCredentialsResponse{
+		Credentials: make([]apiparams.ExpiringCredential, 0, len(creds)),
+	}
+	for _, cred := range creds {
+		resp.Credentials = append(resp.Credentials, apiparams.ExpiringCredential{
+			CredentialTag: cred.ResourceTag().String(),
+			Owner:         cred.OwnerIdentityName,
+			ExpiresAt:     cred.ExpiresAt.Time.UTC(),
+		})
+	}
+	return resp, nil
+}
+
+// ListCloudCredentials returns the cloud credentials visible to the
+// authenticated user that match the request.
+func (r *controllerRoot) ListCloudCredentials(ctx context.Context, req apiparams.ListCloudCredentialsRequest) (apiparams.ListCloudCredentialsResponse, error) {
+	const op = errors.Op("jujuapi.ListCloudCredentials")
+
+	if req.Offset < 0 || req.Limit < 0 {
+		return apiparams.ListCloudCredentialsResponse{}, errors.E(op, errors.CodeBadRequest, "offset and limit cannot be negative")
+	}
+	creds, err := r.jimm.ListCloudCredentials(ctx, r.user, db.CloudCredentialFilter{
+		OwnerIdentityName: req.Owner,
+		CloudName:         req.Cloud,
+		AuthType:          req.AuthType,
+		Valid:             req.Valid,
+		Offset:            req.Offset,
+		Limit:             req.Limit,
+	})
+	if err != nil {
+		return apiparams.ListCloudCredentialsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListCloudCredentialsResponse{
+		Credentials: make([]apiparams.CloudCredentialInfo, 0, len(creds)),
+	}
+	for _, cred := range creds {
+		info := apiparams.CloudCredentialInfo{
+			CredentialTag: cred.ResourceTag().String(),
+			Cloud:         cred.CloudName,
+			Owner:         cred.OwnerIdentityName,
+			Name:          cred.Name,
+			AuthType:      cred.AuthType,
+			Models:        len(cred.Models),
+		}
+		if cred.Valid.Valid {
+			info.Valid = &cred.Valid.Bool
+		}
+		if cred.ExpiresAt.Valid {
+			t := cred.ExpiresAt.Time.UTC()
+			info.ExpiresAt = &t
+		}
+		resp.Credentials = append(resp.Credentials, info)
+	}
+	return resp, nil
+}
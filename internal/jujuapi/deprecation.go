@@ -0,0 +1,180 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/rpcreflect"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// deprecatedFacadeVersions holds, for each facade that has deprecated
+// versions, the earliest version that is not deprecated. Calls made on
+// earlier versions are logged, recorded for the deprecated facade usage
+// report and have a warning attached to their response.
+var deprecatedFacadeVersions = map[string]int{
+	"Admin": 4,
+}
+
+// deprecationWarningKey is the key under which the deprecation warning is
+// attached to responses.
+const deprecationWarningKey = "jimm-deprecation-warning"
+
+// deprecationWarning returns the warning for calls made on the given
+// version of the facade, or an empty string if the version is not
+// deprecated.
+func deprecationWarning(facade string, version int) string {
+	v, ok := deprecatedFacadeVersions[facade]
+	if !ok || version >= v {
+		return ""
+	}
+	return fmt.Sprintf("%s facade version %d is deprecated and will be removed, use version %d or later", facade, version, v)
+}
+
+// deprecatedMethodCaller wraps an rpcreflect.MethodCaller for a method on
+// a deprecated facade version so that each call is logged and recorded.
+type deprecatedMethodCaller struct {
+	rpcreflect.MethodCaller
+
+	root    *controllerRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call implements rpcreflect.MethodCaller.Call.
+func (c deprecatedMethodCaller) Call(ctx context.Context, objID string, arg reflect.Value) (reflect.Value, error) {
+	c.root.mu.Lock()
+	user := c.root.user
+	c.root.mu.Unlock()
+
+	var identityName string
+	if user != nil && user.Identity != nil {
+		identityName = user.Name
+	}
+	servermon.DeprecatedFacadeCallCount.WithLabelValues(c.facade, strconv.Itoa(c.version)).Inc()
+	zapctx.Warn(ctx, "deprecated facade version called",
+		zap.String("facade", c.facade),
+		zap.Int("version", c.version),
+		zap.String("method", c.method),
+		zap.String("identity", identityName),
+		zap.String("remote-addr", auth.RemoteAddrFromContext(ctx)),
+	)
+	c.root.jimm.RecordDeprecatedFacadeUsage(ctx, user, c.facade, c.version)
+	return c.MethodCaller.Call(ctx, objID, arg)
+}
+
+// deprecationCodec wraps an rpc.Codec so that the response to each
+// request made on a deprecated facade version has a deprecation warning
+// attached. The warning is added to the response object, or to the error
+// info of an error response, so that clients that do not know about the
+// warning are unaffected.
+type deprecationCodec struct {
+	rpc.Codec
+
+	mu       sync.Mutex
+	warnings map[uint64]string
+}
+
+// newDeprecationCodec returns a deprecationCodec wrapping the given
+// codec.
+func newDeprecationCodec(codec rpc.Codec) *deprecationCodec {
+	return &deprecationCodec{
+		Codec:    codec,
+		warnings: make(map[uint64]string),
+	}
+}
+
+// ReadHeader implements rpc.Codec.ReadHeader.
+func (c *deprecationCodec) ReadHeader(hdr *rpc.Header) error {
+	if err := c.Codec.ReadHeader(hdr); err != nil {
+		return err
+	}
+	if !hdr.IsRequest() {
+		return nil
+	}
+	if msg := deprecationWarning(hdr.Request.Type, hdr.Request.Version); msg != "" {
+		c.mu.Lock()
+		c.warnings[hdr.RequestId] = msg
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// WriteMessage implements rpc.Codec.WriteMessage.
+func (c *deprecationCodec) WriteMessage(hdr *rpc.Header, body interface{}) error {
+	if hdr.IsRequest() {
+		return c.Codec.WriteMessage(hdr, body)
+	}
+	c.mu.Lock()
+	msg, ok := c.warnings[hdr.RequestId]
+	delete(c.warnings, hdr.RequestId)
+	c.mu.Unlock()
+	if !ok {
+		return c.Codec.WriteMessage(hdr, body)
+	}
+	if hdr.Error != "" {
+		h := *hdr
+		h.ErrorInfo = make(map[string]interface{}, len(hdr.ErrorInfo)+1)
+		for k, v := range hdr.ErrorInfo {
+			h.ErrorInfo[k] = v
+		}
+		h.ErrorInfo[deprecationWarningKey] = msg
+		return c.Codec.WriteMessage(&h, body)
+	}
+	return c.Codec.WriteMessage(hdr, withDeprecationWarning(body, msg))
+}
+
+// withDeprecationWarning returns the body with the warning added. If the
+// body is not encoded as a JSON object it is returned unchanged.
+func withDeprecationWarning(body interface{}, msg string) interface{} {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return body
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil || m == nil {
+		return body
+	}
+	m[deprecationWarningKey], _ = json.Marshal(msg)
+	return m
+}
+
+// ListDeprecatedFacadeUsage returns the clients that have called
+// deprecated facade versions since JIMM started.
+func (r *controllerRoot) ListDeprecatedFacadeUsage(ctx context.Context) (apiparams.ListDeprecatedFacadeUsageResponse, error) {
+	const op = errors.Op("jujuapi.ListDeprecatedFacadeUsage")
+
+	usage, err := r.jimm.ListDeprecatedFacadeUsage(ctx, r.user)
+	if err != nil {
+		return apiparams.ListDeprecatedFacadeUsageResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListDeprecatedFacadeUsageResponse{
+		Usage: make([]apiparams.DeprecatedFacadeUsage, len(usage)),
+	}
+	for i, u := range usage {
+		resp.Usage[i] = apiparams.DeprecatedFacadeUsage{
+			Facade:        u.Facade,
+			Version:       u.Version,
+			Identity:      u.IdentityName,
+			RemoteAddress: u.RemoteAddr,
+			Calls:         u.Calls,
+			FirstCall:     u.FirstCall,
+			LastCall:      u.LastCall,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	jujurpc "github.com/juju/juju/rpc"
+
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// deprecationJIMM is a JIMM that only records deprecated facade usage.
+type deprecationJIMM struct {
+	JIMM
+
+	recorded []string
+}
+
+func (j *deprecationJIMM) RecordDeprecatedFacadeUsage(_ context.Context, _ *openfga.User, facade string, version int) {
+	j.recorded = append(j.recorded, facade+"."+deprecationWarning(facade, version))
+}
+
+func TestDeprecatedFacadeCallsAreRecorded(t *testing.T) {
+	c := qt.New(t)
+
+	j := &deprecationJIMM{}
+	r := newControllerRoot(j, Params{}, "")
+	defer r.cleanup()
+
+	m, err := r.FindMethod("Admin", 3, "Login")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.ValueOf(struct{}{}))
+	c.Check(err, qt.Not(qt.IsNil))
+	c.Check(j.recorded, qt.DeepEquals, []string{
+		"Admin.Admin facade version 3 is deprecated and will be removed, use version 4 or later",
+	})
+
+	// Current versions are not recorded.
+	r.AddMethod("Admin", 4, "Test", rpc.Method(func() {}))
+	m, err = r.FindMethod("Admin", 4, "Test")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(err, qt.IsNil)
+	c.Check(j.recorded, qt.HasLen, 1)
+}
+
+// testCodec is an rpc.Codec that reads the given headers and records the
+// messages written.
+type testCodec struct {
+	jujurpc.Codec
+
+	headers []jujurpc.Header
+	written []jujurpc.Header
+	bodies  []interface{}
+}
+
+func (c *testCodec) ReadHeader(hdr *jujurpc.Header) error {
+	*hdr = c.headers[0]
+	c.headers = c.headers[1:]
+	return nil
+}
+
+func (c *testCodec) WriteMessage(hdr *jujurpc.Header, body interface{}) error {
+	c.written = append(c.written, *hdr)
+	c.bodies = append(c.bodies, body)
+	return nil
+}
+
+func TestDeprecationCodec(t *testing.T) {
+	c := qt.New(t)
+
+	tc := &testCodec{
+		headers: []jujurpc.Header{{
+			RequestId: 1,
+			Request:   jujurpc.Request{Type: "Admin", Version: 3, Action: "Login"},
+		}, {
+			RequestId: 2,
+			Request:   jujurpc.Request{Type: "Admin", Version: 2, Action: "Login"},
+		}, {
+			RequestId: 3,
+			Request:   jujurpc.Request{Type: "Admin", Version: 4, Action: "LoginDevice"},
+		}},
+	}
+	codec := newDeprecationCodec(tc)
+	for range tc.headers {
+		var hdr jujurpc.Header
+		c.Assert(codec.ReadHeader(&hdr), qt.IsNil)
+	}
+
+	type result struct {
+		Value string `json:"value"`
+	}
+	c.Assert(codec.WriteMessage(&jujurpc.Header{RequestId: 1}, result{Value: "v"}), qt.IsNil)
+	c.Assert(codec.WriteMessage(&jujurpc.Header{RequestId: 2, Error: "failed"}, struct{}{}), qt.IsNil)
+	c.Assert(codec.WriteMessage(&jujurpc.Header{RequestId: 3}, result{Value: "v"}), qt.IsNil)
+
+	data, err := json.Marshal(tc.bodies[0])
+	c.Assert(err, qt.IsNil)
+	c.Check(string(data), qt.JSONEquals, map[string]string{
+		"value":                    "v",
+		"jimm-deprecation-warning": "Admin facade version 3 is deprecated and will be removed, use version 4 or later",
+	})
+	c.Check(tc.written[1].ErrorInfo, qt.DeepEquals, map[string]interface{}{
+		"jimm-deprecation-warning": "Admin facade version 2 is deprecated and will be removed, use version 4 or later",
+	})
+	c.Check(tc.bodies[2], qt.Equals, result{Value: "v"})
+	c.Check(tc.written[2].ErrorInfo, qt.IsNil)
+	c.Check(codec.warnings, qt.HasLen, 0)
+}
@@ -31,6 +31,10 @@ func PublishToWatcher(w *modelSummaryWatcher, model string, data interface{}) {
 	w.pubsubHandler(model, data)
 }
 
+func PruneWatcher(w *modelSummaryWatcher, models map[string]bool) {
+	w.prune(models)
+}
+
 func ModelAccessWatcherMatch(w *modelAccessWatcher, model string) bool {
 	return w.match(model)
 }
@@ -46,6 +50,8 @@ func ToJAASTag(db db.Database, tag *ofganames.Tag, resolveUUIDs bool) (string, e
 	return jimm.ToJAASTag(context.Background(), tag, resolveUUIDs)
 }
 
+type ControllerRoot = controllerRoot
+
 func NewControllerRoot(j JIMM, p Params) *controllerRoot {
 	return newControllerRoot(j, p, "")
 }
@@ -59,3 +65,7 @@ var SetUser = func(r *controllerRoot, u *openfga.User) {
 	r.user = u
 	r.mu.Unlock()
 }
+
+func CloseControllerRoot(r *controllerRoot) {
+	r.cleanup()
+}
@@ -4,8 +4,11 @@ package jujuapi
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/pkg/api/params"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
@@ -31,11 +35,19 @@ func init() {
 		disableControllerUUIDMaskingMethod := rpc.Method(r.DisableControllerUUIDMasking)
 		findAuditEventsMethod := rpc.Method(r.FindAuditEvents)
 		grantAuditLogAccessMethod := rpc.Method(r.GrantAuditLogAccess)
+		grantControllerAccessMethod := rpc.Method(r.GrantControllerAccess)
 		importModelMethod := rpc.Method(r.ImportModel)
 		listControllersMethod := rpc.Method(r.ListControllers)
+		migrateControllerCredentialsMethod := rpc.Method(r.MigrateControllerCredentials)
 		removeControllerMethod := rpc.Method(r.RemoveController)
 		revokeAuditLogAccessMethod := rpc.Method(r.RevokeAuditLogAccess)
+		revokeControllerAccessMethod := rpc.Method(r.RevokeControllerAccess)
+		listSessionsMethod := rpc.Method(r.ListSessions)
+		revokeSessionsMethod := rpc.Method(r.RevokeSessions)
+		usageReportMethod := rpc.Method(r.UsageReport)
 		setControllerDeprecatedMethod := rpc.Method(r.SetControllerDeprecated)
+		setCloudRegionDisabledMethod := rpc.Method(r.SetCloudRegionDisabled)
+		drainControllerMethod := rpc.Method(r.DrainController)
 		fullModelStatusMethod := rpc.Method(r.FullModelStatus)
 		updateMigratedModelMethod := rpc.Method(r.UpdateMigratedModel)
 		addCloudToControllerMethod := rpc.Method(r.AddCloudToController)
@@ -49,50 +61,174 @@ func init() {
 		removeRelationMethod := rpc.Method(r.RemoveRelation)
 		checkRelationMethod := rpc.Method(r.CheckRelation)
 		listRelationshipTuplesMethod := rpc.Method(r.ListRelationshipTuples)
+		addTemporaryRelationMethod := rpc.Method(r.AddTemporaryRelation)
+		listTemporaryRelationsMethod := rpc.Method(r.ListTemporaryRelations)
+		reconcileMethod := rpc.Method(r.Reconcile)
+		exportRelationsMethod := rpc.Method(r.ExportRelations)
+		importRelationsMethod := rpc.Method(r.ImportRelations)
+		listEveryoneDefaultsMethod := rpc.Method(r.ListEveryoneDefaults)
+		setEveryoneDefaultMethod := rpc.Method(r.SetEveryoneDefault)
 		crossModelQueryMethod := rpc.Method(r.CrossModelQuery)
 		purgeLogsMethod := rpc.Method(r.PurgeLogs)
+		rotateSessionKeyMethod := rpc.Method(r.RotateSessionKey)
+		getModelArchiveMethod := rpc.Method(r.GetModelArchive)
+		offboardUserMethod := rpc.Method(r.OffboardUser)
+		transferModelOwnershipMethod := rpc.Method(r.TransferModelOwnership)
 		migrateModel := rpc.Method(r.MigrateModel)
 		addServiceAccountMethod := rpc.Method(r.AddServiceAccount)
 		copyServiceAccountCredentialMethod := rpc.Method(r.CopyServiceAccountCredential)
 		updateServiceAccountCredentials := rpc.Method(r.UpdateServiceAccountCredentials)
 		listServiceAccountCredentials := rpc.Method(r.ListServiceAccountCredentials)
 		grantServiceAccountAccess := rpc.Method(r.GrantServiceAccountAccess)
+		setServiceAccountAllowedCIDRs := rpc.Method(r.SetServiceAccountAllowedCIDRs)
+		listServiceAccounts := rpc.Method(r.ListServiceAccounts)
+		setServiceAccountDisabled := rpc.Method(r.SetServiceAccountDisabled)
+		deleteServiceAccount := rpc.Method(r.DeleteServiceAccount)
 		version := rpc.Method(r.Version)
+		watchAllModelsMethod := rpc.Method(r.JIMMWatchAllModels)
+		watchModelSummariesMethod := rpc.Method(r.JIMMWatchModelSummaries)
+		watchAccessMethod := rpc.Method(r.WatchAccess)
+		watchAuditEventsMethod := rpc.Method(r.WatchAuditEvents)
+		resumeWatcherMethod := rpc.Method(r.ResumeWatcher)
+		addModelWebhookMethod := rpc.Method(r.AddModelWebhook)
+		listModelWebhooksMethod := rpc.Method(r.ListModelWebhooks)
+		removeModelWebhookMethod := rpc.Method(r.RemoveModelWebhook)
+		listLimitsMethod := rpc.Method(r.ListLimits)
+		setLimitMethod := rpc.Method(r.SetLimit)
+		removeLimitMethod := rpc.Method(r.RemoveLimit)
+		getMaintenanceModeMethod := rpc.Method(r.GetMaintenanceMode)
+		setMaintenanceModeMethod := rpc.Method(r.SetMaintenanceMode)
+		addNotificationRouteMethod := rpc.Method(r.AddNotificationRoute)
+		listNotificationRoutesMethod := rpc.Method(r.ListNotificationRoutes)
+		removeNotificationRouteMethod := rpc.Method(r.RemoveNotificationRoute)
+		setModelConfigTemplateMethod := rpc.Method(r.SetModelConfigTemplate)
+		getModelConfigTemplateMethod := rpc.Method(r.GetModelConfigTemplate)
+		listModelConfigTemplatesMethod := rpc.Method(r.ListModelConfigTemplates)
+		removeModelConfigTemplateMethod := rpc.Method(r.RemoveModelConfigTemplate)
+		setModelAliasMethod := rpc.Method(r.SetModelAlias)
+		listModelAliasesMethod := rpc.Method(r.ListModelAliases)
+		removeModelAliasMethod := rpc.Method(r.RemoveModelAlias)
+		listModelStatusesMethod := rpc.Method(r.ListModelStatuses)
+		setCloudCredentialExpiryMethod := rpc.Method(r.SetCloudCredentialExpiry)
+		listExpiringCredentialsMethod := rpc.Method(r.ListExpiringCredentials)
+		listCloudCredentialsMethod := rpc.Method(r.ListCloudCredentials)
+		addModelAsyncMethod := rpc.Method(r.AddModelAsync)
+		getTaskStatusMethod := rpc.Method(r.GetTaskStatus)
+		listDeprecatedFacadeUsageMethod := rpc.Method(r.ListDeprecatedFacadeUsage)
+		backupMethod := rpc.Method(r.Backup)
+		restoreMethod := rpc.Method(r.Restore)
+		serviceSelfTestMethod := rpc.Method(r.ServiceSelfTest)
+		searchOffersMethod := rpc.Method(r.SearchOffers)
+		addMaintenanceWindowMethod := rpc.Method(r.AddMaintenanceWindow)
+		listMaintenanceWindowsMethod := rpc.Method(r.ListMaintenanceWindows)
+		removeMaintenanceWindowMethod := rpc.Method(r.RemoveMaintenanceWindow)
+		setModelConfigPolicyMethod := rpc.Method(r.SetModelConfigPolicy)
+		listModelConfigPoliciesMethod := rpc.Method(r.ListModelConfigPolicies)
+		removeModelConfigPolicyMethod := rpc.Method(r.RemoveModelConfigPolicy)
+		scheduleMigrationsMethod := rpc.Method(r.ScheduleMigrations)
+		listMigrationsMethod := rpc.Method(r.ListMigrations)
+		setModelLabelsMethod := rpc.Method(r.SetModelLabels)
 
 		// JIMM Generic RPC
-		r.AddMethod("JIMM", 4, "AddController", addControllerMethod)
+		r.addMutatingMethod("JIMM", 4, "AddController", addControllerMethod)
 		r.AddMethod("JIMM", 4, "DisableControllerUUIDMasking", disableControllerUUIDMaskingMethod)
 		r.AddMethod("JIMM", 4, "FindAuditEvents", findAuditEventsMethod)
 		r.AddMethod("JIMM", 4, "FullModelStatus", fullModelStatusMethod)
-		r.AddMethod("JIMM", 4, "GrantAuditLogAccess", grantAuditLogAccessMethod)
-		r.AddMethod("JIMM", 4, "ImportModel", importModelMethod)
+		r.addMutatingMethod("JIMM", 4, "GrantAuditLogAccess", grantAuditLogAccessMethod)
+		r.addMutatingMethod("JIMM", 4, "GrantControllerAccess", grantControllerAccessMethod)
+		r.addMutatingMethod("JIMM", 4, "ImportModel", importModelMethod)
 		r.AddMethod("JIMM", 4, "ListControllers", listControllersMethod)
-		r.AddMethod("JIMM", 4, "RemoveController", removeControllerMethod)
-		r.AddMethod("JIMM", 4, "RevokeAuditLogAccess", revokeAuditLogAccessMethod)
-		r.AddMethod("JIMM", 4, "SetControllerDeprecated", setControllerDeprecatedMethod)
-		r.AddMethod("JIMM", 4, "UpdateMigratedModel", updateMigratedModelMethod)
-		r.AddMethod("JIMM", 4, "AddCloudToController", addCloudToControllerMethod)
-		r.AddMethod("JIMM", 4, "RemoveCloudFromController", removeCloudFromControllerMethod)
-		r.AddMethod("JIMM", 4, "PurgeLogs", purgeLogsMethod)
-		r.AddMethod("JIMM", 4, "MigrateModel", migrateModel)
+		r.addMutatingMethod("JIMM", 4, "MigrateControllerCredentials", migrateControllerCredentialsMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveController", removeControllerMethod)
+		r.addMutatingMethod("JIMM", 4, "RevokeAuditLogAccess", revokeAuditLogAccessMethod)
+		r.addMutatingMethod("JIMM", 4, "RevokeControllerAccess", revokeControllerAccessMethod)
+		r.AddMethod("JIMM", 4, "ListSessions", listSessionsMethod)
+		r.addMutatingMethod("JIMM", 4, "RevokeSessions", revokeSessionsMethod)
+		r.AddMethod("JIMM", 4, "UsageReport", usageReportMethod)
+		r.addMutatingMethod("JIMM", 4, "SetControllerDeprecated", setControllerDeprecatedMethod)
+		r.addMutatingMethod("JIMM", 4, "SetCloudRegionDisabled", setCloudRegionDisabledMethod)
+		r.addMutatingMethod("JIMM", 4, "DrainController", drainControllerMethod)
+		r.addMutatingMethod("JIMM", 4, "UpdateMigratedModel", updateMigratedModelMethod)
+		r.addMutatingMethod("JIMM", 4, "AddCloudToController", addCloudToControllerMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveCloudFromController", removeCloudFromControllerMethod)
+		r.addMutatingMethod("JIMM", 4, "PurgeLogs", purgeLogsMethod)
+		r.addMutatingMethod("JIMM", 4, "RotateSessionKey", rotateSessionKeyMethod)
+		r.AddMethod("JIMM", 4, "GetModelArchive", getModelArchiveMethod)
+		r.addMutatingMethod("JIMM", 4, "OffboardUser", offboardUserMethod)
+		r.addMutatingMethod("JIMM", 4, "TransferModelOwnership", transferModelOwnershipMethod)
+		r.addMutatingMethod("JIMM", 4, "MigrateModel", migrateModel)
+		r.AddMethod("JIMM", 4, "WatchAllModels", watchAllModelsMethod)
+		r.AddMethod("JIMM", 4, "WatchModelSummaries", watchModelSummariesMethod)
+		r.AddMethod("JIMM", 4, "WatchAccess", watchAccessMethod)
+		r.AddMethod("JIMM", 4, "WatchAuditEvents", watchAuditEventsMethod)
+		r.AddMethod("JIMM", 4, "ResumeWatcher", resumeWatcherMethod)
+		r.addMutatingMethod("JIMM", 4, "AddModelWebhook", addModelWebhookMethod)
+		r.AddMethod("JIMM", 4, "ListModelWebhooks", listModelWebhooksMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveModelWebhook", removeModelWebhookMethod)
+		r.AddMethod("JIMM", 4, "ListLimits", listLimitsMethod)
+		r.addMutatingMethod("JIMM", 4, "SetLimit", setLimitMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveLimit", removeLimitMethod)
+		r.AddMethod("JIMM", 4, "GetMaintenanceMode", getMaintenanceModeMethod)
+		r.AddMethod("JIMM", 4, "SetMaintenanceMode", setMaintenanceModeMethod)
+		r.addMutatingMethod("JIMM", 4, "AddNotificationRoute", addNotificationRouteMethod)
+		r.AddMethod("JIMM", 4, "ListNotificationRoutes", listNotificationRoutesMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveNotificationRoute", removeNotificationRouteMethod)
+		r.addMutatingMethod("JIMM", 4, "SetModelConfigTemplate", setModelConfigTemplateMethod)
+		r.AddMethod("JIMM", 4, "GetModelConfigTemplate", getModelConfigTemplateMethod)
+		r.AddMethod("JIMM", 4, "ListModelConfigTemplates", listModelConfigTemplatesMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveModelConfigTemplate", removeModelConfigTemplateMethod)
+		r.addMutatingMethod("JIMM", 4, "SetModelAlias", setModelAliasMethod)
+		r.AddMethod("JIMM", 4, "ListModelAliases", listModelAliasesMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveModelAlias", removeModelAliasMethod)
+		r.AddMethod("JIMM", 4, "ListModelStatuses", listModelStatusesMethod)
+		r.addMutatingMethod("JIMM", 4, "SetCloudCredentialExpiry", setCloudCredentialExpiryMethod)
+		r.AddMethod("JIMM", 4, "ListExpiringCredentials", listExpiringCredentialsMethod)
+		r.AddMethod("JIMM", 4, "ListCloudCredentials", listCloudCredentialsMethod)
+		r.addMutatingMethod("JIMM", 4, "AddModelAsync", addModelAsyncMethod)
+		r.AddMethod("JIMM", 4, "GetTaskStatus", getTaskStatusMethod)
+		r.AddMethod("JIMM", 4, "ListDeprecatedFacadeUsage", listDeprecatedFacadeUsageMethod)
+		r.AddMethod("JIMM", 4, "Backup", backupMethod)
+		r.addMutatingMethod("JIMM", 4, "Restore", restoreMethod)
+		r.AddMethod("JIMM", 4, "ServiceSelfTest", serviceSelfTestMethod)
+		r.AddMethod("JIMM", 4, "SearchOffers", searchOffersMethod)
+		r.addMutatingMethod("JIMM", 4, "AddMaintenanceWindow", addMaintenanceWindowMethod)
+		r.AddMethod("JIMM", 4, "ListMaintenanceWindows", listMaintenanceWindowsMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveMaintenanceWindow", removeMaintenanceWindowMethod)
+		r.addMutatingMethod("JIMM", 4, "SetModelConfigPolicy", setModelConfigPolicyMethod)
+		r.AddMethod("JIMM", 4, "ListModelConfigPolicies", listModelConfigPoliciesMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveModelConfigPolicy", removeModelConfigPolicyMethod)
+		r.addMutatingMethod("JIMM", 4, "ScheduleMigrations", scheduleMigrationsMethod)
+		r.AddMethod("JIMM", 4, "ListMigrations", listMigrationsMethod)
+		r.addMutatingMethod("JIMM", 4, "SetModelLabels", setModelLabelsMethod)
 		// JIMM ReBAC RPC
-		r.AddMethod("JIMM", 4, "AddGroup", addGroupMethod)
+		r.addMutatingMethod("JIMM", 4, "AddGroup", addGroupMethod)
 		r.AddMethod("JIMM", 4, "GetGroup", getGroupMethod)
-		r.AddMethod("JIMM", 4, "RenameGroup", renameGroupMethod)
-		r.AddMethod("JIMM", 4, "RemoveGroup", removeGroupMethod)
+		r.addMutatingMethod("JIMM", 4, "RenameGroup", renameGroupMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveGroup", removeGroupMethod)
 		r.AddMethod("JIMM", 4, "ListGroups", listGroupsMethod)
-		r.AddMethod("JIMM", 4, "AddRelation", addRelationMethod)
-		r.AddMethod("JIMM", 4, "RemoveRelation", removeRelationMethod)
+		r.addMutatingMethod("JIMM", 4, "AddRelation", addRelationMethod)
+		r.addMutatingMethod("JIMM", 4, "RemoveRelation", removeRelationMethod)
 		r.AddMethod("JIMM", 4, "CheckRelation", checkRelationMethod)
 		r.AddMethod("JIMM", 4, "ListRelationshipTuples", listRelationshipTuplesMethod)
+		r.addMutatingMethod("JIMM", 4, "AddTemporaryRelation", addTemporaryRelationMethod)
+		r.AddMethod("JIMM", 4, "ListTemporaryRelations", listTemporaryRelationsMethod)
+		r.addMutatingMethod("JIMM", 4, "Reconcile", reconcileMethod)
+		r.AddMethod("JIMM", 4, "ExportRelations", exportRelationsMethod)
+		r.addMutatingMethod("JIMM", 4, "ImportRelations", importRelationsMethod)
+		r.AddMethod("JIMM", 4, "ListEveryoneDefaults", listEveryoneDefaultsMethod)
+		r.addMutatingMethod("JIMM", 4, "SetEveryoneDefault", setEveryoneDefaultMethod)
 		// JIMM Cross-model queries
 		r.AddMethod("JIMM", 4, "CrossModelQuery", crossModelQueryMethod)
 		// JIMM Service Accounts
-		r.AddMethod("JIMM", 4, "AddServiceAccount", addServiceAccountMethod)
-		r.AddMethod("JIMM", 4, "CopyServiceAccountCredential", copyServiceAccountCredentialMethod)
-		r.AddMethod("JIMM", 4, "UpdateServiceAccountCredentials", updateServiceAccountCredentials)
+		r.addMutatingMethod("JIMM", 4, "AddServiceAccount", addServiceAccountMethod)
+		r.addMutatingMethod("JIMM", 4, "CopyServiceAccountCredential", copyServiceAccountCredentialMethod)
+		r.addMutatingMethod("JIMM", 4, "UpdateServiceAccountCredentials", updateServiceAccountCredentials)
 		r.AddMethod("JIMM", 4, "ListServiceAccountCredentials", listServiceAccountCredentials)
-		r.AddMethod("JIMM", 4, "GrantServiceAccountAccess", grantServiceAccountAccess)
+		r.addMutatingMethod("JIMM", 4, "GrantServiceAccountAccess", grantServiceAccountAccess)
+		r.addMutatingMethod("JIMM", 4, "SetServiceAccountAllowedCIDRs", setServiceAccountAllowedCIDRs)
+		r.AddMethod("JIMM", 4, "ListServiceAccounts", listServiceAccounts)
+		r.addMutatingMethod("JIMM", 4, "SetServiceAccountDisabled", setServiceAccountDisabled)
+		r.addMutatingMethod("JIMM", 4, "DeleteServiceAccount", deleteServiceAccount)
 		r.AddMethod("JIMM", 4, "Version", version)
 
 		return []int{4}
@@ -161,29 +297,65 @@ func (r *controllerRoot) AddCloudToController(ctx context.Context, req apiparams
 }
 
 // AddController allows adds a controller to the pool of controllers
-// available to JIMM.
+// available to JIMM. The clouds and regions found on the controller are
+// imported and reported in the returned ControllerInfo.
 func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddControllerRequest) (apiparams.ControllerInfo, error) {
 	const op = errors.Op("jujuapi.AddController")
 
 	if req.Name == jimmControllerName {
 		return apiparams.ControllerInfo{}, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cannot add a controller with name %q", jimmControllerName))
 	}
+	ctl, err := newController(req)
+	if err != nil {
+		return apiparams.ControllerInfo{}, errors.E(op, err)
+	}
+	discovered, err := r.jimm.AddControllerWithDiscovery(ctx, r.user, &ctl)
+	if err != nil {
+		zapctx.Error(ctx, "failed to add controller", zaputil.Error(err))
+		return apiparams.ControllerInfo{}, errors.E(op, err)
+	}
+	info := ctl.ToAPIControllerInfo()
+	for _, d := range discovered {
+		info.DiscoveredCloudRegions = append(info.DiscoveredCloudRegions, apiparams.DiscoveredCloudRegion{
+			Cloud:    d.CloudName,
+			Region:   d.RegionName,
+			Priority: d.Priority,
+			New:      d.New,
+		})
+	}
+	return info, nil
+}
+
+// newController validates the given AddControllerRequest and returns the
+// controller it describes.
+func newController(req apiparams.AddControllerRequest) (dbmodel.Controller, error) {
 	if req.PublicAddress != "" {
 		host, port, err := net.SplitHostPort(req.PublicAddress)
 		if err != nil {
-			return apiparams.ControllerInfo{}, errors.E(op, err, errors.CodeBadRequest)
+			return dbmodel.Controller{}, errors.E(err, errors.CodeBadRequest)
 		}
 		if host == "" {
-			return apiparams.ControllerInfo{}, errors.E(op, fmt.Sprintf("address %s: host not specified in public address", req.PublicAddress), errors.CodeBadRequest)
+			return dbmodel.Controller{}, errors.E(fmt.Sprintf("address %s: host not specified in public address", req.PublicAddress), errors.CodeBadRequest)
 		}
 		if port == "" {
-			return apiparams.ControllerInfo{}, errors.E(op, fmt.Sprintf("address %s: port not specified in public address", req.PublicAddress), errors.CodeBadRequest)
+			return dbmodel.Controller{}, errors.E(fmt.Sprintf("address %s: port not specified in public address", req.PublicAddress), errors.CodeBadRequest)
+		}
+	}
+
+	if req.SRVName != "" && strings.ContainsAny(req.SRVName, ":/ \t") {
+		return dbmodel.Controller{}, errors.E(fmt.Sprintf("invalid SRV name %q", req.SRVName), errors.CodeBadRequest)
+	}
+
+	if req.DashboardURL != "" {
+		u, err := url.Parse(req.DashboardURL)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return dbmodel.Controller{}, errors.E(fmt.Sprintf("invalid dashboard URL %q", req.DashboardURL), errors.CodeBadRequest)
 		}
 	}
 
 	nphps, err := network.ParseProviderHostPorts(req.APIAddresses...)
 	if err != nil {
-		return apiparams.ControllerInfo{}, errors.E(op, errors.CodeBadRequest, err)
+		return dbmodel.Controller{}, errors.E(errors.CodeBadRequest, err)
 	}
 	for i := range nphps {
 		// Mark all the unknown scopes public.
@@ -193,7 +365,7 @@ func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddCon
 	}
 
 	// TODO(ale8k): Don't build dbmodel here, do it as params to AddController.
-	ctl := dbmodel.Controller{
+	return dbmodel.Controller{
 		UUID:              req.UUID,
 		Name:              req.Name,
 		PublicAddress:     req.PublicAddress,
@@ -201,13 +373,10 @@ func (r *controllerRoot) AddController(ctx context.Context, req apiparams.AddCon
 		AdminIdentityName: req.Username,
 		AdminPassword:     req.Password,
 		TLSHostname:       req.TLSHostname,
+		SRVName:           req.SRVName,
+		DashboardURL:      req.DashboardURL,
 		Addresses:         dbmodel.HostPorts{jujuparams.FromProviderHostPorts(nphps)},
-	}
-	if err := r.jimm.AddController(ctx, r.user, &ctl); err != nil {
-		zapctx.Error(ctx, "failed to add controller", zaputil.Error(err))
-		return apiparams.ControllerInfo{}, errors.E(op, err)
-	}
-	return ctl.ToAPIControllerInfo(), nil
+	}, nil
 }
 
 // ListControllers returns the list of juju controllers hosting models
@@ -240,9 +409,15 @@ func (r *controllerRoot) ListControllers(ctx context.Context) (apiparams.ListCon
 	if err != nil {
 		return apiparams.ListControllersResponse{}, errors.E(op, err)
 	}
+	modelCounts, err := r.jimm.ControllerModelCounts(ctx, r.user)
+	if err != nil {
+		return apiparams.ListControllersResponse{}, errors.E(op, err)
+	}
 	controllersInfo := make([]apiparams.ControllerInfo, 0, len(dbControllers))
 	for _, ctl := range dbControllers {
-		controllersInfo = append(controllersInfo, ctl.ToAPIControllerInfo())
+		ci := ctl.ToAPIControllerInfo()
+		ci.ModelCount = modelCounts[ctl.Name]
+		controllersInfo = append(controllersInfo, ci)
 	}
 	return apiparams.ListControllersResponse{
 		Controllers: controllersInfo,
@@ -253,6 +428,10 @@ func (r *controllerRoot) ListControllers(ctx context.Context) (apiparams.ListCon
 func (r *controllerRoot) RemoveController(ctx context.Context, req apiparams.RemoveControllerRequest) (apiparams.ControllerInfo, error) {
 	const op = errors.Op("jujuapi.RemoveController")
 
+	if err := r.checkReason(req.Reason); err != nil {
+		return apiparams.ControllerInfo{}, errors.E(op, err)
+	}
+
 	ctl, err := r.jimm.ControllerInfo(ctx, req.Name)
 	if err != nil {
 		return apiparams.ControllerInfo{}, errors.E(op, err)
@@ -278,6 +457,68 @@ func (r *controllerRoot) SetControllerDeprecated(ctx context.Context, req apipar
 	return ctl.ToAPIControllerInfo(), nil
 }
 
+// SetCloudRegionDisabled enables or disables the placement of new models
+// in a cloud region.
+func (r *controllerRoot) SetCloudRegionDisabled(ctx context.Context, req apiparams.SetCloudRegionDisabledRequest) error {
+	const op = errors.Op("jujuapi.SetCloudRegionDisabled")
+
+	ct, err := names.ParseCloudTag(req.CloudTag)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if err := r.jimm.SetCloudRegionDisabled(ctx, r.user, ct, req.Region, req.Disabled); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// DrainController sets the draining status of a controller, optionally
+// migrating the controller's models to other controllers.
+func (r *controllerRoot) DrainController(ctx context.Context, req apiparams.DrainControllerRequest) (apiparams.DrainControllerResponse, error) {
+	const op = errors.Op("jujuapi.DrainController")
+
+	migrations, err := r.jimm.DrainController(ctx, r.user, req.Name, req.Draining, req.Migrate)
+	if err != nil {
+		return apiparams.DrainControllerResponse{}, errors.E(op, err)
+	}
+	ctl, err := r.jimm.ControllerInfo(ctx, req.Name)
+	if err != nil {
+		return apiparams.DrainControllerResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.DrainControllerResponse{
+		Controller: ctl.ToAPIControllerInfo(),
+	}
+	for _, m := range migrations {
+		dm := apiparams.DrainMigration{
+			ModelTag:         m.Model.String(),
+			TargetController: m.TargetController,
+			MigrationID:      m.MigrationID,
+		}
+		if m.Err != nil {
+			dm.Error = m.Err.Error()
+		}
+		resp.Migrations = append(resp.Migrations, dm)
+	}
+	return resp, nil
+}
+
+// MigrateControllerCredentials moves controller admin credentials and
+// macaroon root keys held in JIMM's database into the configured
+// credential store.
+func (r *controllerRoot) MigrateControllerCredentials(ctx context.Context) (apiparams.MigrateControllerCredentialsResponse, error) {
+	const op = errors.Op("jujuapi.MigrateControllerCredentials")
+
+	result, err := r.jimm.MigrateControllerCredentials(ctx, r.user)
+	if err != nil {
+		return apiparams.MigrateControllerCredentialsResponse{}, errors.E(op, err)
+	}
+	return apiparams.MigrateControllerCredentialsResponse{
+		Controllers: result.Controllers,
+		Skipped:     result.Skipped,
+		RootKeys:    result.RootKeys,
+	}, nil
+}
+
 // maxLimit is the maximum number of audit-log entries that will be
 // returned from the audit log, no matter how many are requested.
 const maxLimit = 1000
@@ -288,6 +529,7 @@ func auditParamsToFilter(req apiparams.FindAuditEventsRequest) (db.AuditLogFilte
 	var err error
 	filter.Method = req.Method
 	filter.Model = req.Model
+	filter.ModelUUID = req.ModelUUID
 	filter.SortTime = req.SortTime
 
 	if req.After != "" {
@@ -323,9 +565,46 @@ func auditParamsToFilter(req apiparams.FindAuditEventsRequest) (db.AuditLogFilte
 		offset = 0
 	}
 	filter.Offset = offset
+	if req.Cursor != "" {
+		if offset != 0 {
+			return filter, errors.E(errors.CodeBadRequest, "cannot specify both offset and cursor")
+		}
+		filter.AfterTime, filter.AfterID, err = decodeAuditCursor(req.Cursor)
+		if err != nil {
+			return filter, errors.E(err, errors.CodeBadRequest, `invalid "cursor"`)
+		}
+	}
 	return filter, nil
 }
 
+// encodeAuditCursor returns an opaque cursor identifying the position of
+// the given entry in the audit log.
+func encodeAuditCursor(e *dbmodel.AuditLogEntry) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", e.Time.UnixNano(), e.ID)))
+}
+
+// decodeAuditCursor returns the time and ID of the audit log entry
+// identified by the given cursor.
+func decodeAuditCursor(cursor string) (time.Time, uint, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	ts, id, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return time.Time{}, 0, errors.E("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n == 0 {
+		return time.Time{}, 0, errors.E("malformed cursor")
+	}
+	return time.Unix(0, nanos).UTC(), uint(n), nil
+}
+
 // FindAuditEvents finds the audit-log entries that match the given filter.
 func (r *controllerRoot) FindAuditEvents(ctx context.Context, req apiparams.FindAuditEventsRequest) (apiparams.AuditEvents, error) {
 	const op = errors.Op("jujuapi.FindAuditEvents")
@@ -342,8 +621,13 @@ func (r *controllerRoot) FindAuditEvents(ctx context.Context, req apiparams.Find
 	for i, ent := range entries {
 		events[i] = ent.ToAPIAuditEvent()
 	}
+	var nextCursor string
+	if len(entries) == filter.Limit {
+		nextCursor = encodeAuditCursor(&entries[len(entries)-1])
+	}
 	return apiparams.AuditEvents{
-		Events: events,
+		Events:     events,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -383,13 +667,119 @@ func (r *controllerRoot) RevokeAuditLogAccess(ctx context.Context, req apiparams
 	return nil
 }
 
+// GrantControllerAccess grants JIMM controller access at the specified
+// level to each of the specified users. Only JIMM administrators can grant
+// controller access.
+func (r *controllerRoot) GrantControllerAccess(ctx context.Context, req apiparams.ControllerAccessRequest) (apiparams.ControllerAccessResults, error) {
+	const op = errors.Op("jujuapi.GrantControllerAccess")
+	return r.modifyControllerAccess(ctx, op, req, r.jimm.GrantControllerAccess)
+}
+
+// RevokeControllerAccess revokes JIMM controller access at the specified
+// level, and any level above it, from each of the specified users. Only
+// JIMM administrators can revoke controller access.
+func (r *controllerRoot) RevokeControllerAccess(ctx context.Context, req apiparams.ControllerAccessRequest) (apiparams.ControllerAccessResults, error) {
+	const op = errors.Op("jujuapi.RevokeControllerAccess")
+	return r.modifyControllerAccess(ctx, op, req, r.jimm.RevokeControllerAccess)
+}
+
+// modifyControllerAccess applies the given access modification to the
+// users in the given request, reporting the result for each user.
+func (r *controllerRoot) modifyControllerAccess(ctx context.Context, op errors.Op, req apiparams.ControllerAccessRequest, modify func(context.Context, *openfga.User, string, []names.UserTag) ([]error, error)) (apiparams.ControllerAccessResults, error) {
+	results := make([]apiparams.ControllerAccessResult, len(req.UserTags))
+	var uts []names.UserTag
+	var indexes []int
+	for i, tag := range req.UserTags {
+		results[i].UserTag = tag
+		ut, err := parseUserTag(tag)
+		if err != nil {
+			results[i].Error = errors.E(op, err, errors.CodeBadRequest).Error()
+			continue
+		}
+		uts = append(uts, ut)
+		indexes = append(indexes, i)
+	}
+
+	errs, err := modify(ctx, r.user, req.Access, uts)
+	if err != nil {
+		return apiparams.ControllerAccessResults{}, errors.E(op, err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			results[indexes[i]].Error = errors.E(op, err).Error()
+		}
+	}
+	return apiparams.ControllerAccessResults{Results: results}, nil
+}
+
+// ListSessions returns the active browser and device login sessions,
+// optionally restricted to those of a single user. Only JIMM
+// administrators can list sessions.
+func (r *controllerRoot) ListSessions(ctx context.Context, req apiparams.ListSessionsRequest) (apiparams.ListSessionsResponse, error) {
+	const op = errors.Op("jujuapi.ListSessions")
+
+	var identityName string
+	if req.UserTag != "" {
+		ut, err := parseUserTag(req.UserTag)
+		if err != nil {
+			return apiparams.ListSessionsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+		}
+		identityName = ut.Id()
+	}
+	sessions, err := r.jimm.ListSessions(ctx, r.user, identityName)
+	if err != nil {
+		return apiparams.ListSessionsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListSessionsResponse{
+		Sessions: make([]apiparams.Session, len(sessions)),
+	}
+	for i, s := range sessions {
+		resp.Sessions[i] = s.ToAPISession()
+	}
+	return resp, nil
+}
+
+// RevokeSessions revokes all the active browser and device login sessions
+// of a user. Only JIMM administrators can revoke sessions.
+func (r *controllerRoot) RevokeSessions(ctx context.Context, req apiparams.RevokeSessionsRequest) (apiparams.RevokeSessionsResponse, error) {
+	const op = errors.Op("jujuapi.RevokeSessions")
+
+	ut, err := parseUserTag(req.UserTag)
+	if err != nil {
+		return apiparams.RevokeSessionsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	revoked, err := r.jimm.RevokeSessions(ctx, r.user, ut.Id())
+	if err != nil {
+		return apiparams.RevokeSessionsResponse{}, errors.E(op, err)
+	}
+	return apiparams.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// UsageReport returns the machine and unit usage of the models over the
+// requested period. Only JIMM administrators can report usage.
+func (r *controllerRoot) UsageReport(ctx context.Context, req apiparams.UsageReportRequest) (apiparams.UsageReportResponse, error) {
+	const op = errors.Op("jujuapi.UsageReport")
+
+	records, err := r.jimm.UsageReport(ctx, r.user, req.From, req.To, req.Daily)
+	if err != nil {
+		return apiparams.UsageReportResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.UsageReportResponse{
+		Records: make([]apiparams.UsageRecord, len(records)),
+	}
+	for i, rec := range records {
+		resp.Records[i] = rec.ToAPIUsageRecord()
+	}
+	return resp, nil
+}
+
 // FullModelStatus returns the full status of the juju model.
 func (r *controllerRoot) FullModelStatus(ctx context.Context, req apiparams.FullModelStatusRequest) (jujuparams.FullStatus, error) {
 	const op = errors.Op("jujuapi.FullModelStatus")
 
-	mt, err := names.ParseModelTag(req.ModelTag)
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
 	if err != nil {
-		return jujuparams.FullStatus{}, errors.E(op, err, errors.CodeBadRequest)
+		return jujuparams.FullStatus{}, errors.E(op, err)
 	}
 
 	status, err := r.jimm.FullModelStatus(ctx, r.user, mt, req.Patterns)
@@ -440,6 +830,9 @@ func (r *controllerRoot) ImportModel(ctx context.Context, req apiparams.ImportMo
 // RemoveCloudFromController removes the specified cloud from a specific controller.
 func (r *controllerRoot) RemoveCloudFromController(ctx context.Context, req apiparams.RemoveCloudFromControllerRequest) error {
 	const op = errors.Op("jujuapi.RemoveCloudFromController")
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
 	ct, err := names.ParseCloudTag(req.CloudTag)
 	if err != nil {
 		return errors.E(op, err, errors.CodeBadRequest)
@@ -484,6 +877,97 @@ func (r *controllerRoot) PurgeLogs(ctx context.Context, req apiparams.PurgeLogsR
 	}, nil
 }
 
+// RotateSessionKey replaces the key used to sign CLI session tokens. Tokens
+// signed with the replaced key remain valid until the next rotation.
+func (r *controllerRoot) RotateSessionKey(ctx context.Context) error {
+	const op = errors.Op("jujuapi.RotateSessionKey")
+
+	if err := r.jimm.RotateSessionKey(ctx, r.user); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GetModelArchive returns the final snapshot of a destroyed model.
+func (r *controllerRoot) GetModelArchive(ctx context.Context, req apiparams.GetModelArchiveRequest) (apiparams.ModelArchive, error) {
+	const op = errors.Op("jujuapi.GetModelArchive")
+
+	archive, err := r.jimm.GetModelArchive(ctx, r.user, req.ModelUUID)
+	if err != nil {
+		return apiparams.ModelArchive{}, errors.E(op, err)
+	}
+	return archive.ToAPIModelArchive(), nil
+}
+
+// OffboardUser offboards a user, removing all of their access, cloud
+// credentials and sessions.
+func (r *controllerRoot) OffboardUser(ctx context.Context, req apiparams.OffboardUserRequest) (apiparams.OffboardUserResponse, error) {
+	const op = errors.Op("jujuapi.OffboardUser")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return apiparams.OffboardUserResponse{}, errors.E(op, err)
+	}
+	ut, err := names.ParseUserTag(req.UserTag)
+	if err != nil {
+		return apiparams.OffboardUserResponse{}, errors.E(op, errors.CodeBadRequest, err)
+	}
+	var reassignTo string
+	if req.ReassignModelsTo != "" {
+		nt, err := names.ParseUserTag(req.ReassignModelsTo)
+		if err != nil {
+			return apiparams.OffboardUserResponse{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		reassignTo = nt.Id()
+	}
+	summary, err := r.jimm.OffboardUser(ctx, r.user, ut.Id(), reassignTo)
+	if err != nil {
+		return apiparams.OffboardUserResponse{}, errors.E(op, err)
+	}
+	var resp apiparams.OffboardUserResponse
+	for _, t := range summary.RemovedRelations {
+		target, _ := r.jimm.ToJAASTag(ctx, t.Target, false)
+		resp.RemovedRelations = append(resp.RemovedRelations, apiparams.RelationshipTuple{
+			Object:       ut.String(),
+			Relation:     string(t.Relation),
+			TargetObject: target,
+		})
+	}
+	for _, tag := range summary.RevokedCredentials {
+		resp.RevokedCredentials = append(resp.RevokedCredentials, tag.String())
+	}
+	for _, tag := range summary.RetainedCredentials {
+		resp.RetainedCredentials = append(resp.RetainedCredentials, tag.String())
+	}
+	for _, tag := range summary.ReassignedModels {
+		resp.ReassignedModels = append(resp.ReassignedModels, tag.String())
+	}
+	for _, tag := range summary.RetainedModels {
+		resp.RetainedModels = append(resp.RetainedModels, tag.String())
+	}
+	return resp, nil
+}
+
+// TransferModelOwnership makes the given user the owner of a model.
+func (r *controllerRoot) TransferModelOwnership(ctx context.Context, req apiparams.TransferModelOwnershipRequest) error {
+	const op = errors.Op("jujuapi.TransferModelOwnership")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	ut, err := names.ParseUserTag(req.OwnerTag)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if err := r.jimm.TransferModelOwnership(ctx, r.user, mt, ut); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // MigrateModel is a JIMM specific method for migrating models between two controllers that
 // are already attached to JIMM. See InitiateMigration in controller.go to migrate a model
 // in a controller attached to JIMM to one not managed by JIMM.
@@ -492,7 +976,7 @@ func (r *controllerRoot) MigrateModel(ctx context.Context, args apiparams.Migrat
 
 	results := make([]jujuparams.InitiateMigrationResult, len(args.Specs))
 	for i, arg := range args.Specs {
-		mt, err := names.ParseModelTag(arg.ModelTag)
+		mt, err := r.jimm.ResolveModelTag(ctx, r.user, arg.ModelTag)
 		if err != nil {
 			results[i].Error = mapError(errors.E(op, err))
 			continue
@@ -4,8 +4,11 @@ package jujuapi
 
 import (
 	"context"
+	"time"
 
 	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
@@ -17,4 +20,11 @@ type RelationService interface {
 	CheckRelation(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, trace bool) (_ bool, err error)
 	ListRelationshipTuples(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error)
 	ListObjectRelations(ctx context.Context, user *openfga.User, object string, pageSize int32, entitlementToken pagination.EntitlementToken) ([]openfga.Tuple, pagination.EntitlementToken, error)
+	AddTemporaryRelation(ctx context.Context, user *openfga.User, tuple apiparams.RelationshipTuple, duration time.Duration) (*dbmodel.TemporaryGrant, error)
+	ListTemporaryRelations(ctx context.Context, user *openfga.User) ([]dbmodel.TemporaryGrant, error)
+	ListEveryoneDefaults(ctx context.Context, user *openfga.User) ([]openfga.Tuple, error)
+	SetEveryoneDefault(ctx context.Context, user *openfga.User, target string, relation string, granted bool) error
+	Reconcile(ctx context.Context, user *openfga.User, repair bool) (*jimm.ReconcileReport, error)
+	ExportRelations(ctx context.Context, user *openfga.User, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error)
+	ImportRelations(ctx context.Context, user *openfga.User, tuples []openfga.Tuple) (added, existing int, err error)
 }
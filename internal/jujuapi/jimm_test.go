@@ -528,13 +528,18 @@ func (s *jimmSuite) TestFullModelStatus(c *gc.C) {
 	_, err := client.FullModelStatus(&apiparams.FullModelStatusRequest{
 		ModelTag: "invalid-model-tag",
 	})
-	c.Assert(err, gc.ErrorMatches, `"invalid-model-tag" is not a valid tag \(bad request\)`)
+	c.Assert(err, gc.ErrorMatches, `model "invalid-model-tag" not found \(not found\)`)
 
 	_, err = client.FullModelStatus(&apiparams.FullModelStatusRequest{
 		ModelTag: mt.String(),
 	})
 	c.Assert(err, gc.ErrorMatches, "unauthorized.*")
 
+	_, err = client.FullModelStatus(&apiparams.FullModelStatusRequest{
+		ModelTag: "charlie@canonical.com/model-1",
+	})
+	c.Assert(err, gc.ErrorMatches, "unauthorized.*")
+
 	conn = s.open(c, nil, "alice@canonical.com")
 	defer conn.Close()
 	client = api.NewClient(conn)
@@ -900,3 +905,184 @@ func (s *jimmSuite) TestVersion(c *gc.C) {
 	c.Assert(versionInfo.Version, gc.Not(gc.Equals), "")
 	c.Assert(versionInfo.Commit, gc.Not(gc.Equals), "")
 }
+
+func (s *jimmSuite) TestListModelStatuses(c *gc.C) {
+	ctx := context.Background()
+	s.Model.Machines = 2
+	s.Model.Units = 3
+	err := s.JIMM.Database.UpdateModel(ctx, s.Model)
+	c.Assert(err, gc.Equals, nil)
+
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	statuses, err := client.ListModelStatuses(nil)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(statuses, jimmtest.CmpEquals(
+		cmpopts.IgnoreFields(apiparams.ModelStatus{}, "StatusSince", "LastUpdated"),
+		cmpopts.SortSlices(func(a, b apiparams.ModelStatus) bool {
+			return a.Name < b.Name
+		}),
+	), []apiparams.ModelStatus{{
+		ModelTag:   s.Model.ResourceTag().String(),
+		Name:       "model-1",
+		Owner:      "bob@canonical.com",
+		Controller: "controller-1",
+		Life:       "alive",
+		Status:     "available",
+		Machines:   2,
+		Units:      3,
+	}, {
+		ModelTag:   s.Model3.ResourceTag().String(),
+		Name:       "model-3",
+		Owner:      "charlie@canonical.com",
+		Controller: "controller-1",
+		Life:       "alive",
+		Status:     "available",
+	}})
+	for _, st := range statuses {
+		c.Check(st.LastUpdated.IsZero(), gc.Equals, false)
+	}
+}
+
+func (s *jimmSuite) TestSetModelLabels(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	err := client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: "bob@canonical.com/model-1",
+		Labels:   map[string]string{"team": "payments", "env": "prod"},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	statuses, err := client.ListModelStatuses(&apiparams.ListModelStatusesRequest{
+		LabelSelector: "team=payments,env=prod",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(statuses, gc.HasLen, 1)
+	c.Check(statuses[0].ModelTag, gc.Equals, s.Model.ResourceTag().String())
+	c.Check(statuses[0].Labels, gc.DeepEquals, map[string]string{"team": "payments", "env": "prod"})
+
+	statuses, err = client.ListModelStatuses(&apiparams.ListModelStatusesRequest{
+		LabelSelector: "env!=prod",
+	})
+	c.Assert(err, gc.Equals, nil)
+	for _, st := range statuses {
+		c.Check(st.ModelTag, gc.Not(gc.Equals), s.Model.ResourceTag().String())
+	}
+
+	_, err = client.ListModelStatuses(&apiparams.ListModelStatusesRequest{
+		LabelSelector: "team=",
+	})
+	c.Assert(err, gc.Equals, nil)
+	_, err = client.ListModelStatuses(&apiparams.ListModelStatusesRequest{
+		LabelSelector: "=payments",
+	})
+	c.Check(err, gc.ErrorMatches, `invalid label selector "=payments".*`)
+
+	// Only model administrators may set labels.
+	conn2 := s.open(c, nil, "charlie")
+	defer conn2.Close()
+	err = api.NewClient(conn2).SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model.ResourceTag().String(),
+		Labels:   map[string]string{"team": "billing"},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized.*`)
+}
+
+func TestPrivilegedOperationReason(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about         string
+		requireReason bool
+		call          func(*jujuapi.ControllerRoot) error
+		expectedError string
+	}{{
+		about:         "remove controller without a reason when not required",
+		requireReason: false,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			_, err := cr.RemoveController(context.Background(), apiparams.RemoveControllerRequest{Name: "controller-1"})
+			return err
+		},
+	}, {
+		about:         "remove controller without a reason when required",
+		requireReason: true,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			_, err := cr.RemoveController(context.Background(), apiparams.RemoveControllerRequest{Name: "controller-1"})
+			return err
+		},
+		expectedError: "a reason is required for this operation",
+	}, {
+		about:         "remove controller with a reason when required",
+		requireReason: true,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			_, err := cr.RemoveController(context.Background(), apiparams.RemoveControllerRequest{Name: "controller-1", Reason: "decommissioned"})
+			return err
+		},
+	}, {
+		about:         "remove cloud from controller without a reason when required",
+		requireReason: true,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			return cr.RemoveCloudFromController(context.Background(), apiparams.RemoveCloudFromControllerRequest{
+				CloudTag:       "cloud-aws",
+				ControllerName: "controller-1",
+			})
+		},
+		expectedError: "a reason is required for this operation",
+	}, {
+		about:         "revoke jimm administrator without a reason when required",
+		requireReason: true,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			return cr.RemoveRelation(context.Background(), apiparams.RemoveRelationRequest{
+				Tuples: []apiparams.RelationshipTuple{{
+					Object:       "user-alice@canonical.com",
+					Relation:     "administrator",
+					TargetObject: "controller-jimm",
+				}},
+			})
+		},
+		expectedError: "a reason is required for this operation",
+	}, {
+		about:         "remove other relation without a reason when required",
+		requireReason: true,
+		call: func(cr *jujuapi.ControllerRoot) error {
+			return cr.RemoveRelation(context.Background(), apiparams.RemoveRelationRequest{
+				Tuples: []apiparams.RelationshipTuple{{
+					Object:       "user-alice@canonical.com",
+					Relation:     "member",
+					TargetObject: "group-test",
+				}},
+			})
+		},
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			j := &jimmtest.JIMM{}
+			j.ControllerInfo_ = func(ctx context.Context, name string) (*dbmodel.Controller, error) {
+				return &dbmodel.Controller{Name: name, CloudName: "aws"}, nil
+			}
+			j.RemoveController_ = func(ctx context.Context, user *openfga.User, controllerName string, force bool) error {
+				return nil
+			}
+			j.RemoveCloudFromController_ = func(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error {
+				return nil
+			}
+			j.RemoveRelation_ = func(ctx context.Context, user *openfga.User, tuples []apiparams.RelationshipTuple) error {
+				return nil
+			}
+			cr := jujuapi.NewControllerRoot(j, jujuapi.Params{
+				RequireReasonForPrivilegedOperations: test.requireReason,
+			})
+			err := test.call(cr)
+			if test.expectedError == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, test.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// ListLimits returns the limits on resource usage.
+func (r *controllerRoot) ListLimits(ctx context.Context) (apiparams.ListLimitsResponse, error) {
+	const op = errors.Op("jujuapi.ListLimits")
+
+	limits, err := r.jimm.ListLimits(ctx, r.user)
+	if err != nil {
+		return apiparams.ListLimitsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListLimitsResponse{
+		Limits: make([]apiparams.Limit, len(limits)),
+	}
+	for i, l := range limits {
+		resp.Limits[i] = apiparams.Limit{
+			Entity: l.Entity,
+			Scope:  l.Scope,
+			Value:  l.Value,
+			Usage:  l.Usage,
+		}
+	}
+	return resp, nil
+}
+
+// SetLimit sets a limit on resource usage.
+func (r *controllerRoot) SetLimit(ctx context.Context, req apiparams.SetLimitRequest) error {
+	const op = errors.Op("jujuapi.SetLimit")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.SetLimit(ctx, r.user, req.Entity, req.Scope, req.Value); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveLimit removes a limit on resource usage.
+func (r *controllerRoot) RemoveLimit(ctx context.Context, req apiparams.RemoveLimitRequest) error {
+	const op = errors.Op("jujuapi.RemoveLimit")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.RemoveLimit(ctx, r.user, req.Entity, req.Scope); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
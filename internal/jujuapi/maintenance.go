@@ -0,0 +1,130 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/juju/rpcreflect"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// addMutatingMethod adds the given method to the root and marks it as
+// modifying JIMM's state, so that it is rejected while JIMM is in
+// maintenance mode. Methods that only read state, logins and watchers
+// are added with AddMethod and are always allowed, as are calls on
+// proxied model connections.
+func (r *controllerRoot) addMutatingMethod(rootName string, version int, methodName string, mc rpcreflect.MethodCaller) {
+	r.mutatingMu.Lock()
+	if r.mutating == nil {
+		r.mutating = make(map[string]bool)
+	}
+	r.mutating[rootName+"."+methodName] = true
+	r.mutatingMu.Unlock()
+	r.AddMethod(rootName, version, methodName, mc)
+}
+
+// isMutating reports whether the given method was added with
+// addMutatingMethod.
+func (r *controllerRoot) isMutating(rootName, methodName string) bool {
+	r.mutatingMu.RLock()
+	defer r.mutatingMu.RUnlock()
+	return r.mutating[rootName+"."+methodName]
+}
+
+// FindMethod implements rpc.Root. Methods that modify JIMM's state are
+// wrapped so that they are rejected while JIMM is in maintenance mode,
+// methods are wrapped so that they are subject to any configured rate
+// limits and methods on deprecated facade versions are wrapped so that
+// their use is recorded.
+func (r *controllerRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	mc, err := r.Root.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return mc, err
+	}
+	if r.isMutating(rootName, methodName) {
+		mc = maintenanceMethodCaller{
+			MethodCaller: mc,
+			jimm:         r.jimm,
+		}
+	}
+	if r.params.RateLimiter != nil && !rateLimitExemptFacades[rootName] {
+		mc = rateLimitedMethodCaller{
+			MethodCaller: mc,
+			root:         r,
+			limiter:      r.params.RateLimiter,
+			facade:       rootName,
+		}
+	}
+	if deprecationWarning(rootName, version) != "" {
+		mc = deprecatedMethodCaller{
+			MethodCaller: mc,
+			root:         r,
+			facade:       rootName,
+			version:      version,
+			method:       methodName,
+		}
+	}
+	return mc, nil
+}
+
+// maintenanceMethodCaller wraps an rpcreflect.MethodCaller so that the
+// method fails if JIMM is in maintenance mode.
+type maintenanceMethodCaller struct {
+	rpcreflect.MethodCaller
+
+	jimm JIMM
+}
+
+// Call implements rpcreflect.MethodCaller.Call.
+func (c maintenanceMethodCaller) Call(ctx context.Context, objID string, arg reflect.Value) (reflect.Value, error) {
+	mm, err := c.jimm.GetMaintenanceMode(ctx)
+	if err != nil {
+		// Failing to read the maintenance mode should not prevent
+		// JIMM from working, the method will fail anyway if the
+		// database is unavailable.
+		zapctx.Warn(ctx, "cannot determine maintenance mode", zap.Error(err))
+	} else if mm.Enabled {
+		msg := "JIMM is in maintenance mode, changes are not currently allowed"
+		if mm.Message != "" {
+			msg += ": " + mm.Message
+		}
+		return reflect.Value{}, errors.E(errors.CodeUpgradeInProgress, msg)
+	}
+	return c.MethodCaller.Call(ctx, objID, arg)
+}
+
+// GetMaintenanceMode returns JIMM's current maintenance mode.
+func (r *controllerRoot) GetMaintenanceMode(ctx context.Context) (apiparams.MaintenanceMode, error) {
+	const op = errors.Op("jujuapi.GetMaintenanceMode")
+
+	mm, err := r.jimm.GetMaintenanceMode(ctx)
+	if err != nil {
+		return apiparams.MaintenanceMode{}, errors.E(op, err)
+	}
+	return apiparams.MaintenanceMode{
+		Enabled:   mm.Enabled,
+		Message:   mm.Message,
+		SetBy:     mm.SetBy,
+		UpdatedAt: mm.UpdatedAt,
+	}, nil
+}
+
+// SetMaintenanceMode enables or disables maintenance mode. It is never
+// itself rejected by maintenance mode.
+func (r *controllerRoot) SetMaintenanceMode(ctx context.Context, req apiparams.SetMaintenanceModeRequest) error {
+	const op = errors.Op("jujuapi.SetMaintenanceMode")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.SetMaintenanceMode(ctx, r.user, req.Enabled, req.Message); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
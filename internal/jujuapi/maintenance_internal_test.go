@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+)
+
+// maintenanceJIMM is a JIMM that only implements GetMaintenanceMode.
+type maintenanceJIMM struct {
+	JIMM
+
+	mm dbmodel.MaintenanceMode
+}
+
+func (j *maintenanceJIMM) GetMaintenanceMode(context.Context) (*dbmodel.MaintenanceMode, error) {
+	mm := j.mm
+	return &mm, nil
+}
+
+func TestMaintenanceModeRejectsMutatingMethods(t *testing.T) {
+	c := qt.New(t)
+
+	j := &maintenanceJIMM{
+		mm: dbmodel.MaintenanceMode{Enabled: true, Message: "upgrading"},
+	}
+	r := newControllerRoot(j, Params{}, "")
+	defer r.cleanup()
+	r.AddMethod("JIMM", 4, "ListLimits", rpc.Method(func() {}))
+	r.addMutatingMethod("JIMM", 4, "SetLimit", rpc.Method(func() {}))
+
+	m, err := r.FindMethod("JIMM", 4, "SetLimit")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(err, qt.ErrorMatches, `JIMM is in maintenance mode, changes are not currently allowed: upgrading`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	// Methods that do not change JIMM's state are allowed.
+	m, err = r.FindMethod("JIMM", 4, "ListLimits")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(err, qt.IsNil)
+
+	j.mm.Enabled = false
+	m, err = r.FindMethod("JIMM", 4, "SetLimit")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(err, qt.IsNil)
+}
+
+// readOnlyJIMMMethods holds the JIMM facade methods that do not modify
+// JIMM's state and so are allowed in maintenance mode. A new JIMM method
+// must either be added here or be added with addMutatingMethod.
+var readOnlyJIMMMethods = map[string]bool{
+	"Backup":                        true,
+	"CheckRelation":                 true,
+	"CrossModelQuery":               true,
+	"DisableControllerUUIDMasking":  true,
+	"ExportRelations":               true,
+	"FindAuditEvents":               true,
+	"FullModelStatus":               true,
+	"GetGroup":                      true,
+	"GetMaintenanceMode":            true,
+	"GetModelArchive":               true,
+	"GetModelConfigTemplate":        true,
+	"GetTaskStatus":                 true,
+	"ListCloudCredentials":          true,
+	"ListControllers":               true,
+	"ListDeprecatedFacadeUsage":     true,
+	"ListEveryoneDefaults":          true,
+	"ListExpiringCredentials":       true,
+	"ListGroups":                    true,
+	"ListLimits":                    true,
+	"ListMaintenanceWindows":        true,
+	"ListMigrations":                true,
+	"ListModelAliases":              true,
+	"ListModelConfigPolicies":       true,
+	"ListModelConfigTemplates":      true,
+	"ListModelStatuses":             true,
+	"ListModelWebhooks":             true,
+	"ListNotificationRoutes":        true,
+	"ListRelationshipTuples":        true,
+	"ListServiceAccountCredentials": true,
+	"ListServiceAccounts":           true,
+	"ListSessions":                  true,
+	"ListTemporaryRelations":        true,
+	"ResumeWatcher":                 true,
+	"SearchOffers":                  true,
+	"ServiceSelfTest":               true,
+	"SetMaintenanceMode":            true,
+	"UsageReport":                   true,
+	"Version":                       true,
+	"WatchAccess":                   true,
+	"WatchAllModels":                true,
+	"WatchAuditEvents":              true,
+	"WatchModelSummaries":           true,
+}
+
+func TestJIMMMethodsClassified(t *testing.T) {
+	c := qt.New(t)
+
+	r := newControllerRoot(&maintenanceJIMM{}, Params{}, "")
+	defer r.cleanup()
+	facadeInit["JIMM"](r)
+
+	methods := r.Methods("JIMM", 4)
+	c.Assert(methods, qt.Not(qt.HasLen), 0)
+	for _, m := range methods {
+		mutating := r.isMutating("JIMM", m)
+		c.Check(mutating != readOnlyJIMMMethods[m], qt.IsTrue, qt.Commentf("JIMM.%s must be either mutating or read-only", m))
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// AddMaintenanceWindow declares a maintenance window for a controller.
+func (r *controllerRoot) AddMaintenanceWindow(ctx context.Context, req apiparams.AddMaintenanceWindowRequest) (apiparams.MaintenanceWindow, error) {
+	const op = errors.Op("jujuapi.AddMaintenanceWindow")
+
+	w, err := r.jimm.AddMaintenanceWindow(ctx, r.user, req.Controller, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		return apiparams.MaintenanceWindow{}, errors.E(op, err)
+	}
+	return maintenanceWindowToParams(*w, time.Now()), nil
+}
+
+// ListMaintenanceWindows returns controller maintenance windows.
+func (r *controllerRoot) ListMaintenanceWindows(ctx context.Context, req apiparams.ListMaintenanceWindowsRequest) (apiparams.ListMaintenanceWindowsResponse, error) {
+	const op = errors.Op("jujuapi.ListMaintenanceWindows")
+
+	windows, err := r.jimm.ListMaintenanceWindows(ctx, r.user, req.Controller, req.All)
+	if err != nil {
+		return apiparams.ListMaintenanceWindowsResponse{}, errors.E(op, err)
+	}
+	now := time.Now()
+	resp := apiparams.ListMaintenanceWindowsResponse{
+		Windows: make([]apiparams.MaintenanceWindow, len(windows)),
+	}
+	for i, w := range windows {
+		resp.Windows[i] = maintenanceWindowToParams(w, now)
+	}
+	return resp, nil
+}
+
+// RemoveMaintenanceWindow removes a controller maintenance window.
+func (r *controllerRoot) RemoveMaintenanceWindow(ctx context.Context, req apiparams.RemoveMaintenanceWindowRequest) error {
+	const op = errors.Op("jujuapi.RemoveMaintenanceWindow")
+
+	if err := r.jimm.RemoveMaintenanceWindow(ctx, r.user, req.ID); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func maintenanceWindowToParams(w dbmodel.MaintenanceWindow, now time.Time) apiparams.MaintenanceWindow {
+	return apiparams.MaintenanceWindow{
+		ID:         w.ID,
+		Controller: w.Controller.Name,
+		StartsAt:   w.StartsAt.UTC(),
+		EndsAt:     w.EndsAt.UTC(),
+		Reason:     w.Reason,
+		CreatedBy:  w.CreatedBy,
+		Active:     w.Active(now),
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// ScheduleMigrations queues the migration of models between controllers
+// attached to JIMM. Queued migrations are pre-checked against the source
+// and target controllers before they are started.
+func (r *controllerRoot) ScheduleMigrations(ctx context.Context, req apiparams.ScheduleMigrationsRequest) (apiparams.ScheduleMigrationsResponse, error) {
+	const op = errors.Op("jujuapi.ScheduleMigrations")
+
+	results := make([]apiparams.ScheduleMigrationResult, len(req.Specs))
+	for i, spec := range req.Specs {
+		mt, err := r.jimm.ResolveModelTag(ctx, r.user, spec.ModelTag)
+		if err != nil {
+			results[i].Error = errors.E(op, err).Error()
+			continue
+		}
+		m, err := r.jimm.ScheduleMigration(ctx, r.user, mt, spec.TargetController)
+		if err != nil {
+			results[i].Error = errors.E(op, err).Error()
+			continue
+		}
+		status := m.ToAPIMigrationStatus()
+		results[i].Migration = &status
+	}
+	return apiparams.ScheduleMigrationsResponse{Results: results}, nil
+}
+
+// ListMigrations returns the migrations queued with the migration
+// scheduler.
+func (r *controllerRoot) ListMigrations(ctx context.Context, req apiparams.ListMigrationsRequest) (apiparams.ListMigrationsResponse, error) {
+	const op = errors.Op("jujuapi.ListMigrations")
+
+	filter := db.MigrationFilter{ModelUUID: req.ModelUUID}
+	if req.Pending {
+		filter.Statuses = jimm.PendingMigrationStatuses()
+	}
+	migrations, err := r.jimm.ListMigrations(ctx, r.user, filter)
+	if err != nil {
+		return apiparams.ListMigrationsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListMigrationsResponse{
+		Migrations: make([]apiparams.MigrationStatus, len(migrations)),
+	}
+	for i, m := range migrations {
+		resp.Migrations[i] = m.ToAPIMigrationStatus()
+	}
+	return resp, nil
+}
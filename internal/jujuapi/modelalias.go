@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SetModelAlias gives a model an alias for the authenticated user.
+func (r *controllerRoot) SetModelAlias(ctx context.Context, req apiparams.SetModelAliasRequest) (apiparams.ModelAlias, error) {
+	const op = errors.Op("jujuapi.SetModelAlias")
+
+	a, err := r.jimm.SetModelAlias(ctx, r.user, req.Alias, req.Model)
+	if err != nil {
+		return apiparams.ModelAlias{}, errors.E(op, err)
+	}
+	return modelAliasToParams(*a), nil
+}
+
+// ListModelAliases returns the authenticated user's model aliases.
+func (r *controllerRoot) ListModelAliases(ctx context.Context) (apiparams.ListModelAliasesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelAliases")
+
+	aliases, err := r.jimm.ListModelAliases(ctx, r.user)
+	if err != nil {
+		return apiparams.ListModelAliasesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelAliasesResponse{
+		Aliases: make([]apiparams.ModelAlias, len(aliases)),
+	}
+	for i, a := range aliases {
+		resp.Aliases[i] = modelAliasToParams(a)
+	}
+	return resp, nil
+}
+
+// RemoveModelAlias removes one of the authenticated user's model
+// aliases.
+func (r *controllerRoot) RemoveModelAlias(ctx context.Context, req apiparams.RemoveModelAliasRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelAlias")
+
+	if err := r.jimm.RemoveModelAlias(ctx, r.user, req.Alias); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func modelAliasToParams(a dbmodel.ModelAlias) apiparams.ModelAlias {
+	return apiparams.ModelAlias{
+		Alias:     a.Alias,
+		ModelTag:  a.Model.ResourceTag().String(),
+		ModelPath: a.Model.OwnerIdentityName + "/" + a.Model.Name,
+	}
+}
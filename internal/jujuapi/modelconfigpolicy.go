@@ -0,0 +1,61 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SetModelConfigPolicy sets the policy for a model config key.
+func (r *controllerRoot) SetModelConfigPolicy(ctx context.Context, req apiparams.SetModelConfigPolicyRequest) error {
+	const op = errors.Op("jujuapi.SetModelConfigPolicy")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.SetModelConfigPolicy(ctx, r.user, req.Key, req.Action, req.Value, req.Description); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListModelConfigPolicies returns the model config policies.
+func (r *controllerRoot) ListModelConfigPolicies(ctx context.Context) (apiparams.ListModelConfigPoliciesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelConfigPolicies")
+
+	policies, err := r.jimm.ListModelConfigPolicies(ctx, r.user)
+	if err != nil {
+		return apiparams.ListModelConfigPoliciesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelConfigPoliciesResponse{
+		Policies: make([]apiparams.ModelConfigPolicy, len(policies)),
+	}
+	for i, p := range policies {
+		resp.Policies[i] = apiparams.ModelConfigPolicy{
+			Key:         p.Key,
+			Action:      p.Action,
+			Description: p.Description,
+		}
+		if p.Value.Valid {
+			v := p.Value.String
+			resp.Policies[i].Value = &v
+		}
+	}
+	return resp, nil
+}
+
+// RemoveModelConfigPolicy removes the policy for a model config key.
+func (r *controllerRoot) RemoveModelConfigPolicy(ctx context.Context, req apiparams.RemoveModelConfigPolicyRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelConfigPolicy")
+
+	if err := r.checkReason(req.Reason); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.RemoveModelConfigPolicy(ctx, r.user, req.Key); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
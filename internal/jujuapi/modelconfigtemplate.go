@@ -0,0 +1,70 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SetModelConfigTemplate creates, or replaces, a model config template.
+func (r *controllerRoot) SetModelConfigTemplate(ctx context.Context, req apiparams.SetModelConfigTemplateRequest) (apiparams.ModelConfigTemplate, error) {
+	const op = errors.Op("jujuapi.SetModelConfigTemplate")
+
+	t, err := r.jimm.SetModelConfigTemplate(ctx, r.user, req.Path, req.Config, req.SharedWith)
+	if err != nil {
+		return apiparams.ModelConfigTemplate{}, errors.E(op, err)
+	}
+	return modelConfigTemplateToParams(*t), nil
+}
+
+// GetModelConfigTemplate returns a model config template.
+func (r *controllerRoot) GetModelConfigTemplate(ctx context.Context, req apiparams.ModelConfigTemplateRequest) (apiparams.ModelConfigTemplate, error) {
+	const op = errors.Op("jujuapi.GetModelConfigTemplate")
+
+	t, err := r.jimm.GetModelConfigTemplate(ctx, r.user, req.Path)
+	if err != nil {
+		return apiparams.ModelConfigTemplate{}, errors.E(op, err)
+	}
+	return modelConfigTemplateToParams(*t), nil
+}
+
+// ListModelConfigTemplates returns the model config templates the
+// authenticated user may use.
+func (r *controllerRoot) ListModelConfigTemplates(ctx context.Context) (apiparams.ListModelConfigTemplatesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelConfigTemplates")
+
+	templates, err := r.jimm.ListModelConfigTemplates(ctx, r.user)
+	if err != nil {
+		return apiparams.ListModelConfigTemplatesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelConfigTemplatesResponse{
+		Templates: make([]apiparams.ModelConfigTemplate, len(templates)),
+	}
+	for i, t := range templates {
+		resp.Templates[i] = modelConfigTemplateToParams(t)
+	}
+	return resp, nil
+}
+
+// RemoveModelConfigTemplate removes a model config template.
+func (r *controllerRoot) RemoveModelConfigTemplate(ctx context.Context, req apiparams.ModelConfigTemplateRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelConfigTemplate")
+
+	if err := r.jimm.RemoveModelConfigTemplate(ctx, r.user, req.Path); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func modelConfigTemplateToParams(t dbmodel.ModelConfigTemplate) apiparams.ModelConfigTemplate {
+	return apiparams.ModelConfigTemplate{
+		Path:       t.Path(),
+		Config:     t.Config,
+		SharedWith: t.SharedWith,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
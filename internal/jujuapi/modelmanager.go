@@ -36,19 +36,19 @@ func init() {
 		unsetModelDefaultsMethod := rpc.Method(r.UnsetModelDefaults)
 		modelDefaultsForCloudsMethod := rpc.Method(r.ModelDefaultsForClouds)
 
-		r.AddMethod("ModelManager", 9, "ChangeModelCredential", changeModelCredentialMethod)
-		r.AddMethod("ModelManager", 9, "CreateModel", createModelMethod)
-		r.AddMethod("ModelManager", 9, "DestroyModels", destroyModelsMethod)
+		r.addMutatingMethod("ModelManager", 9, "ChangeModelCredential", changeModelCredentialMethod)
+		r.addMutatingMethod("ModelManager", 9, "CreateModel", createModelMethod)
+		r.addMutatingMethod("ModelManager", 9, "DestroyModels", destroyModelsMethod)
 		r.AddMethod("ModelManager", 9, "DumpModels", dumpModelsMethod)
 		r.AddMethod("ModelManager", 9, "DumpModelsDB", dumpModelsDBMethod)
 		r.AddMethod("ModelManager", 9, "ListModelSummaries", listModelSummariesMethod)
 		r.AddMethod("ModelManager", 9, "ListModels", listModelsMethod)
 		r.AddMethod("ModelManager", 9, "ModelInfo", modelInfoMethod)
 		r.AddMethod("ModelManager", 9, "ModelStatus", modelStatusMethod)
-		r.AddMethod("ModelManager", 9, "ModifyModelAccess", modifyModelAccessMethod)
+		r.addMutatingMethod("ModelManager", 9, "ModifyModelAccess", modifyModelAccessMethod)
 		r.AddMethod("ModelManager", 9, "ValidateModelUpgrades", validateModelUpgradesMethod)
-		r.AddMethod("ModelManager", 9, "SetModelDefaults", setModelDefaultsMethod)
-		r.AddMethod("ModelManager", 9, "UnsetModelDefaults", unsetModelDefaultsMethod)
+		r.addMutatingMethod("ModelManager", 9, "SetModelDefaults", setModelDefaultsMethod)
+		r.addMutatingMethod("ModelManager", 9, "UnsetModelDefaults", unsetModelDefaultsMethod)
 		r.AddMethod("ModelManager", 9, "ModelDefaultsForClouds", modelDefaultsForCloudsMethod)
 
 		return []int{9}
@@ -64,6 +64,7 @@ type ModelManager interface {
 	DumpModelDB(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
 	ForEachModel(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	ForEachUserModel(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
+	ForEachUserModelMatching(ctx context.Context, u *openfga.User, sel jimm.LabelSelector, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error
 	FullModelStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag, patterns []string) (*jujuparams.FullStatus, error)
 	GetModel(ctx context.Context, uuid string) (dbmodel.Model, error)
 	IdentityModelDefaults(ctx context.Context, user *dbmodel.Identity) (map[string]interface{}, error)
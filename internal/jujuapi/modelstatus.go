@@ -0,0 +1,79 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// ListModelStatuses returns the status of every model the authenticated
+// user can read with labels matching the requested label selector. The
+// statuses are those recorded in JIMM's database, no controllers are
+// contacted.
+func (r *controllerRoot) ListModelStatuses(ctx context.Context, req apiparams.ListModelStatusesRequest) (apiparams.ListModelStatusesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelStatuses")
+
+	sel, err := jimm.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return apiparams.ListModelStatusesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelStatusesResponse{
+		Models: []apiparams.ModelStatus{},
+	}
+	idleSince := time.Now().Add(-req.IdleFor)
+	err = r.jimm.ForEachUserModelMatching(ctx, r.user, sel, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+		if req.IdleFor > 0 && m.LastActive().After(idleSince) {
+			return nil
+		}
+		resp.Models = append(resp.Models, modelStatusToParams(m))
+		return nil
+	})
+	if err != nil {
+		return apiparams.ListModelStatusesResponse{}, errors.E(op, err)
+	}
+	return resp, nil
+}
+
+// SetModelLabels replaces the labels set on a model.
+func (r *controllerRoot) SetModelLabels(ctx context.Context, req apiparams.SetModelLabelsRequest) error {
+	const op = errors.Op("jujuapi.SetModelLabels")
+
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.SetModelLabels(ctx, r.user, mt, req.Labels); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func modelStatusToParams(m *dbmodel.Model) apiparams.ModelStatus {
+	ms := apiparams.ModelStatus{
+		ModelTag:     m.ResourceTag().String(),
+		Name:         m.Name,
+		Owner:        m.OwnerIdentityName,
+		Controller:   m.Controller.Name,
+		Life:         m.Life,
+		Status:       m.Status.Status,
+		StatusInfo:   m.Status.Info,
+		Machines:     m.Machines,
+		Units:        m.Units,
+		LastUpdated:  m.UpdatedAt.UTC(),
+		LastActivity: m.LastActive().UTC(),
+		Labels:       m.Labels,
+	}
+	if m.Status.Since.Valid {
+		since := m.Status.Since.Time.UTC()
+		ms.StatusSince = &since
+	}
+	return ms
+}
@@ -10,13 +10,18 @@ import (
 	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	"github.com/canonical/jimm/v3/internal/pubsub"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 func init() {
@@ -53,10 +58,108 @@ func (r *controllerRoot) ModelSummaryWatcherStop(ctx context.Context, objID stri
 	if err != nil {
 		return errors.E(op, err)
 	}
+	r.forgetResumable(objID)
 
 	return w.Stop()
 }
 
+// JIMMWatchModelSummaries implements the WatchModelSummaries method on
+// the JIMM facade. It is like Controller.WatchModelSummaries, but only
+// summaries for models matching the requested filters are returned. The
+// resulting watcher is used with the ModelSummaryWatcher facade.
+func (r *controllerRoot) JIMMWatchModelSummaries(ctx context.Context, req apiparams.WatchModelSummariesRequest) (apiparams.WatchModelSummariesResponse, error) {
+	const op = errors.Op("jujuapi.JIMMWatchModelSummaries")
+
+	if req.All && !r.user.JimmAdmin {
+		return apiparams.WatchModelSummariesResponse{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	filter, err := newModelSummaryFilter(req)
+	if err != nil {
+		return apiparams.WatchModelSummariesResponse{}, errors.E(op, err)
+	}
+	if err := r.setupUUIDGenerator(); err != nil {
+		return apiparams.WatchModelSummariesResponse{}, errors.E(op, err)
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+
+	getModels := func(ctx context.Context) ([]string, error) {
+		var modelUUIDs []string
+		f := func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+			if filter.match(m) {
+				modelUUIDs = append(modelUUIDs, m.UUID.String)
+			}
+			return nil
+		}
+		var err error
+		if req.All {
+			err = r.jimm.ForEachModel(ctx, r.user, f)
+		} else {
+			err = r.jimm.ForEachUserModel(ctx, r.user, f)
+		}
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return modelUUIDs, nil
+	}
+	// The watcher outlives this request, and may outlive the connection
+	// if it is resumed.
+	watcher, err := newModelSummaryWatcher(context.WithoutCancel(ctx), id, r.jimm.PubSubHub(), getModels)
+	if err != nil {
+		return apiparams.WatchModelSummariesResponse{}, errors.E(op, err)
+	}
+	r.watchers.register(watcher)
+	token, err := r.makeResumable(id, resumableModelSummary)
+	if err != nil {
+		return apiparams.WatchModelSummariesResponse{}, errors.E(op, err)
+	}
+
+	return apiparams.WatchModelSummariesResponse{
+		WatcherID:   id,
+		ResumeToken: token,
+	}, nil
+}
+
+// modelSummaryFilter selects the models whose summaries are returned by a
+// filtered model summary watcher. Empty fields match every model.
+type modelSummaryFilter struct {
+	owner      string
+	cloud      string
+	controller string
+}
+
+func newModelSummaryFilter(req apiparams.WatchModelSummariesRequest) (modelSummaryFilter, error) {
+	var f modelSummaryFilter
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return f, errors.E(errors.CodeBadRequest, err)
+		}
+		f.owner = ut.Id()
+	}
+	if req.CloudTag != "" {
+		ct, err := names.ParseCloudTag(req.CloudTag)
+		if err != nil {
+			return f, errors.E(errors.CodeBadRequest, err)
+		}
+		f.cloud = ct.Id()
+	}
+	f.controller = req.ControllerName
+	return f, nil
+}
+
+func (f modelSummaryFilter) match(m *dbmodel.Model) bool {
+	if f.owner != "" && m.OwnerIdentityName != f.owner {
+		return false
+	}
+	if f.cloud != "" && m.CloudRegion.CloudName != f.cloud {
+		return false
+	}
+	if f.controller != "" && m.Controller.Name != f.controller {
+		return false
+	}
+	return true
+}
+
 var (
 	defaultModelAccessWatcherPeriod = time.Minute
 )
@@ -89,6 +192,18 @@ func (r *watcherRegistry) register(w *modelSummaryWatcher) {
 	r.watchers[w.id] = w
 }
 
+func (r *watcherRegistry) remove(id string) (*modelSummaryWatcher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.watchers[id]
+	if !ok {
+		return nil, errors.E(errors.CodeNotFound)
+	}
+	delete(r.watchers, id)
+	return w, nil
+}
+
 func (r *watcherRegistry) get(id string) (*modelSummaryWatcher, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -105,31 +220,53 @@ func newModelSummaryWatcher(ctx context.Context, id string, pubsub *pubsub.Hub,
 
 	ctx, cancelContext := context.WithCancel(ctx)
 
+	watcher := &modelSummaryWatcher{
+		id:        id,
+		ctx:       ctx,
+		summaries: make(map[string]jujuparams.ModelAbstract),
+	}
+
 	accessWatcher := &modelAccessWatcher{
 		ctx:             ctx,
 		modelGetterFunc: modelGetterFunc,
 		period:          defaultModelAccessWatcherPeriod,
+		wake:            make(chan struct{}, 1),
+		onChange:        watcher.prune,
 	}
 	err := accessWatcher.do()
 	if err != nil {
 		zapctx.Error(ctx, "failed to list user models", zaputil.Error(err))
 	}
-	go accessWatcher.loop()
 
-	watcher := &modelSummaryWatcher{
-		id:        id,
-		ctx:       ctx,
-		summaries: make(map[string]jujuparams.ModelAbstract),
+	// Check access as soon as it is changed through JIMM, so that
+	// summaries stop being sent as soon as access is revoked.
+	accessCleanup, err := pubsub.Subscribe(jimm.AccessChangedTopic, func(string, interface{}) {
+		select {
+		case accessWatcher.wake <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		cancelContext()
+		return nil, errors.E(op, err)
 	}
+	go accessWatcher.loop()
 
 	cleanupFunction, err := pubsub.SubscribeMatch(accessWatcher.match, watcher.pubsubHandler)
 	if err != nil {
 		cancelContext()
+		accessCleanup()
 		return nil, errors.E(op, err)
 	}
+	servermon.WatchersActive.WithLabelValues("model-summary").Inc()
+	var once sync.Once
 	watcher.cleanup = func() {
-		cancelContext()
-		cleanupFunction()
+		once.Do(func() {
+			cancelContext()
+			accessCleanup()
+			cleanupFunction()
+			servermon.WatchersActive.WithLabelValues("model-summary").Dec()
+		})
 	}
 
 	return watcher, nil
@@ -140,6 +277,9 @@ type modelSummaryWatcher struct {
 	ctx     context.Context
 	cleanup func()
 
+	// summaries holds only the latest summary for each model, so the
+	// memory used by a slow client is bounded by the number of models it
+	// can see rather than the number of updates.
 	mu        sync.RWMutex
 	summaries map[string]jujuparams.ModelAbstract
 }
@@ -161,6 +301,18 @@ func (w *modelSummaryWatcher) pubsubHandler(model string, summaryI interface{})
 	w.summaries[model] = summary
 }
 
+// prune removes the summaries of any models not in the given set, so
+// that they are no longer returned once access to them is revoked.
+func (w *modelSummaryWatcher) prune(models map[string]bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for model := range w.summaries {
+		if !models[model] {
+			delete(w.summaries, model)
+		}
+	}
+}
+
 func (w *modelSummaryWatcher) Next() (jujuparams.SummaryWatcherNextResults, error) {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
@@ -200,6 +352,11 @@ type modelAccessWatcher struct {
 	ctx             context.Context
 	modelGetterFunc func(context.Context) ([]string, error)
 	period          time.Duration
+	// wake, if set, causes access to be checked immediately.
+	wake chan struct{}
+	// onChange, if set, is called with the accessible models after
+	// every check.
+	onChange func(map[string]bool)
 
 	mu     sync.RWMutex
 	models map[string]bool
@@ -220,6 +377,11 @@ func (w *modelAccessWatcher) loop() {
 		select {
 		case <-w.ctx.Done():
 			return
+		case <-w.wake:
+			err := w.do()
+			if err != nil {
+				zapctx.Error(w.ctx, "failed to list user models", zaputil.Error(err))
+			}
 		case <-time.After(w.period):
 			err := w.do()
 			if err != nil {
@@ -241,8 +403,11 @@ func (w *modelAccessWatcher) do() error {
 	}
 
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	w.models = models
+	w.mu.Unlock()
 
+	if w.onChange != nil {
+		w.onChange(models)
+	}
 	return nil
 }
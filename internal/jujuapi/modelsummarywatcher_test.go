@@ -4,6 +4,7 @@ package jujuapi_test
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 	"time"
 
@@ -11,7 +12,12 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
 	"github.com/canonical/jimm/v3/internal/jujuapi"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/pubsub"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 type modelSummaryWatcherSuite struct{}
@@ -52,6 +58,33 @@ func (s *modelSummaryWatcherSuite) TestModelSummaryWatcher(c *gc.C) {
 	})
 }
 
+func (s *modelSummaryWatcherSuite) TestModelSummaryWatcherPrune(c *gc.C) {
+	watcher := jujuapi.NewModelSummaryWatcher()
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+	jujuapi.PublishToWatcher(watcher, "test-model", jujuparams.ModelAbstract{
+		UUID: "12345",
+		Name: "test-model",
+	})
+	jujuapi.PublishToWatcher(watcher, "test-model-2", jujuparams.ModelAbstract{
+		UUID: "12346",
+		Name: "test-model-2",
+	})
+
+	jujuapi.PruneWatcher(watcher, map[string]bool{"test-model-2": true})
+
+	result, err := watcher.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, jujuparams.SummaryWatcherNextResults{
+		Models: []jujuparams.ModelAbstract{{
+			UUID: "12346",
+			Name: "test-model-2",
+		}},
+	})
+}
+
 func (s *modelSummaryWatcherSuite) TestModelAccessWatcher(c *gc.C) {
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -122,3 +155,62 @@ func (t *testModelGetter) getModels(_ context.Context) ([]string, error) {
 	t.called = true
 	return t.models, nil
 }
+
+func (s *modelSummaryWatcherSuite) TestFilteredModelSummaryWatcher(c *gc.C) {
+	ctx := context.Background()
+
+	hub := &pubsub.Hub{MaxConcurrency: 10}
+	models := []dbmodel.Model{{
+		UUID:              sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true},
+		OwnerIdentityName: "alice@canonical.com",
+		Controller:        dbmodel.Controller{Name: "controller-1"},
+		CloudRegion:       dbmodel.CloudRegion{CloudName: "test-cloud"},
+	}, {
+		UUID:              sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true},
+		OwnerIdentityName: "bob@canonical.com",
+		Controller:        dbmodel.Controller{Name: "controller-2"},
+		CloudRegion:       dbmodel.CloudRegion{CloudName: "test-cloud"},
+	}}
+	j := &jimmtest.JIMM{
+		PubSubHub_: func() *pubsub.Hub { return hub },
+	}
+	j.ForEachUserModel_ = func(ctx context.Context, u *openfga.User, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+		for i := range models {
+			if err := f(&models[i], "read"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cr := jujuapi.NewControllerRoot(j, jujuapi.Params{})
+	jujuapi.SetUser(cr, openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil))
+
+	for _, m := range models {
+		<-hub.Publish(m.UUID.String, jujuparams.ModelAbstract{UUID: m.UUID.String})
+	}
+
+	id, err := cr.JIMMWatchModelSummaries(ctx, apiparams.WatchModelSummariesRequest{
+		ControllerName: "controller-2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Assert(cr.ModelSummaryWatcherStop(ctx, id.WatcherID), jc.ErrorIsNil)
+	}()
+
+	var result jujuparams.SummaryWatcherNextResults
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		result, err = cr.ModelSummaryWatcherNext(ctx, id.WatcherID)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(result.Models) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(result.Models, gc.DeepEquals, []jujuparams.ModelAbstract{{
+		UUID: "00000002-0000-0000-0000-000000000002",
+	}})
+
+	_, err = cr.JIMMWatchModelSummaries(ctx, apiparams.WatchModelSummariesRequest{All: true})
+	c.Assert(err, gc.ErrorMatches, "unauthorized")
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// AddModelWebhook registers a webhook on a model that is called when the
+// model undergoes one of the requested status transitions.
+func (r *controllerRoot) AddModelWebhook(ctx context.Context, req apiparams.AddModelWebhookRequest) (apiparams.AddModelWebhookResponse, error) {
+	const op = errors.Op("jujuapi.AddModelWebhook")
+
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
+	if err != nil {
+		return apiparams.AddModelWebhookResponse{}, errors.E(op, err)
+	}
+	wh, err := r.jimm.AddModelWebhook(ctx, r.user, mt, req.URL, req.Events)
+	if err != nil {
+		return apiparams.AddModelWebhookResponse{}, errors.E(op, err)
+	}
+	return apiparams.AddModelWebhookResponse{
+		Webhook: wh.ToAPIModelWebhook(mt),
+		Secret:  wh.Secret,
+	}, nil
+}
+
+// ListModelWebhooks returns the webhooks registered on a model.
+func (r *controllerRoot) ListModelWebhooks(ctx context.Context, req apiparams.ListModelWebhooksRequest) (apiparams.ListModelWebhooksResponse, error) {
+	const op = errors.Op("jujuapi.ListModelWebhooks")
+
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
+	if err != nil {
+		return apiparams.ListModelWebhooksResponse{}, errors.E(op, err)
+	}
+	webhooks, err := r.jimm.ListModelWebhooks(ctx, r.user, mt)
+	if err != nil {
+		return apiparams.ListModelWebhooksResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelWebhooksResponse{
+		Webhooks: make([]apiparams.ModelWebhook, len(webhooks)),
+	}
+	for i, wh := range webhooks {
+		resp.Webhooks[i] = wh.ToAPIModelWebhook(mt)
+	}
+	return resp, nil
+}
+
+// RemoveModelWebhook removes a webhook from a model.
+func (r *controllerRoot) RemoveModelWebhook(ctx context.Context, req apiparams.RemoveModelWebhookRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelWebhook")
+
+	mt, err := r.jimm.ResolveModelTag(ctx, r.user, req.ModelTag)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.RemoveModelWebhook(ctx, r.user, mt, req.ID); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
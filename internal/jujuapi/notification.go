@@ -0,0 +1,61 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// AddNotificationRoute adds a route along which notifications are
+// delivered.
+func (r *controllerRoot) AddNotificationRoute(ctx context.Context, req apiparams.AddNotificationRouteRequest) (apiparams.NotificationRoute, error) {
+	const op = errors.Op("jujuapi.AddNotificationRoute")
+
+	route, err := r.jimm.AddNotificationRoute(ctx, r.user, req.Subject, req.Kind, req.Transport, req.Destination)
+	if err != nil {
+		return apiparams.NotificationRoute{}, errors.E(op, err)
+	}
+	return notificationRouteToParams(*route), nil
+}
+
+// ListNotificationRoutes returns the notification routes visible to the
+// authenticated user.
+func (r *controllerRoot) ListNotificationRoutes(ctx context.Context) (apiparams.ListNotificationRoutesResponse, error) {
+	const op = errors.Op("jujuapi.ListNotificationRoutes")
+
+	routes, err := r.jimm.ListNotificationRoutes(ctx, r.user)
+	if err != nil {
+		return apiparams.ListNotificationRoutesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListNotificationRoutesResponse{
+		Routes: make([]apiparams.NotificationRoute, len(routes)),
+	}
+	for i, route := range routes {
+		resp.Routes[i] = notificationRouteToParams(route)
+	}
+	return resp, nil
+}
+
+// RemoveNotificationRoute removes a notification route.
+func (r *controllerRoot) RemoveNotificationRoute(ctx context.Context, req apiparams.RemoveNotificationRouteRequest) error {
+	const op = errors.Op("jujuapi.RemoveNotificationRoute")
+
+	if err := r.jimm.RemoveNotificationRoute(ctx, r.user, req.ID); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func notificationRouteToParams(route jimm.NotificationRoute) apiparams.NotificationRoute {
+	return apiparams.NotificationRoute{
+		ID:          route.ID,
+		Subject:     route.Subject,
+		Kind:        route.Kind,
+		Transport:   route.Transport,
+		Destination: route.Destination,
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SearchOffers returns the application offers, across all controllers,
+// that the authenticated user can consume and that match the request.
+func (r *controllerRoot) SearchOffers(ctx context.Context, req apiparams.SearchOffersRequest) (apiparams.SearchOffersResponse, error) {
+	const op = errors.Op("jujuapi.SearchOffers")
+
+	entries, err := r.jimm.SearchOffers(ctx, r.user, jimm.OfferDirectoryFilter{
+		Interface:       req.Interface,
+		ApplicationName: req.ApplicationName,
+		OwnerName:       req.OwnerName,
+	})
+	if err != nil {
+		return apiparams.SearchOffersResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.SearchOffersResponse{
+		Offers: make([]apiparams.OfferDirectoryEntry, len(entries)),
+	}
+	for i, e := range entries {
+		resp.Offers[i] = apiparams.OfferDirectoryEntry{
+			OfferURL:               e.Offer.URL,
+			OfferUUID:              e.Offer.UUID,
+			OfferName:              e.Offer.Name,
+			ApplicationName:        e.Offer.ApplicationName,
+			ApplicationDescription: e.Offer.ApplicationDescription,
+			OwnerName:              e.Offer.Model.OwnerIdentityName,
+			ModelName:              e.Offer.Model.Name,
+			ControllerName:         e.Offer.Model.Controller.Name,
+			Endpoints:              e.Offer.ToJujuApplicationOfferDetailsV5().Endpoints,
+			Access:                 string(e.Access),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/rpcreflect"
+	"golang.org/x/time/rate"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// rateLimitExemptFacades holds the facades whose methods are never rate
+// limited, so that clients can always log in and keep their connections
+// alive.
+var rateLimitExemptFacades = map[string]bool{
+	"Admin":  true,
+	"Pinger": true,
+}
+
+// idleUserLimitExpiry is how long the limits of an identity that has made
+// no requests are kept.
+const idleUserLimitExpiry = 10 * time.Minute
+
+// RateLimitParams holds the parameters used to configure a RateLimiter.
+// A zero limit is not enforced.
+type RateLimitParams struct {
+	// UserRate is the number of requests per second each identity may
+	// make on a controller connection.
+	UserRate float64
+
+	// UserBurst is the number of requests each identity may make at
+	// once above UserRate. If it is zero the burst is the UserRate
+	// rounded up.
+	UserBurst int
+
+	// UserConcurrency is the number of requests each identity may have
+	// in progress at once.
+	UserConcurrency int
+
+	// FacadeRates holds the number of requests per second that may be
+	// made on each named facade, across all identities.
+	FacadeRates map[string]float64
+}
+
+// ParseFacadeRates parses per-facade rate limits in the form
+// "<facade>=<requests per second>" separated by whitespace, for example
+// "ModelManager=20 Cloud=5.5".
+func ParseFacadeRates(s string) (map[string]float64, error) {
+	var rates map[string]float64
+	for _, f := range strings.Fields(s) {
+		facade, r, ok := strings.Cut(f, "=")
+		if !ok || facade == "" {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid facade rate %q", f))
+		}
+		n, err := strconv.ParseFloat(r, 64)
+		if err != nil || n < 0 {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid facade rate %q", f))
+		}
+		if rates == nil {
+			rates = make(map[string]float64)
+		}
+		rates[facade] = n
+	}
+	return rates, nil
+}
+
+// A RateLimiter limits the rate of the requests made on JIMM's controller
+// API, both per identity and per facade. Requests that exceed a limit
+// are rejected with an error with the code CodeTooManyRequests.
+type RateLimiter struct {
+	params RateLimitParams
+
+	facades map[string]*rate.Limiter
+
+	mu          sync.Mutex
+	users       map[string]*userLimits
+	lastPruning time.Time
+}
+
+// userLimits holds the state of the limits of a single identity.
+type userLimits struct {
+	limiter  *rate.Limiter
+	inFlight int
+	lastUsed time.Time
+}
+
+// NewRateLimiter returns a RateLimiter enforcing the given limits.
+func NewRateLimiter(p RateLimitParams) *RateLimiter {
+	l := &RateLimiter{
+		params:  p,
+		facades: make(map[string]*rate.Limiter, len(p.FacadeRates)),
+		users:   make(map[string]*userLimits),
+	}
+	for facade, r := range p.FacadeRates {
+		if r > 0 {
+			l.facades[facade] = rate.NewLimiter(rate.Limit(r), burst(r, 0))
+		}
+	}
+	return l
+}
+
+// burst returns the burst to use for a limit of r requests per second,
+// b is the configured burst, if any.
+func burst(r float64, b int) int {
+	if b > 0 {
+		return b
+	}
+	return max(1, int(math.Ceil(r)))
+}
+
+// acquire reserves a request by the given identity on the given facade.
+// If the request is allowed the returned function must be called once the
+// request has completed.
+func (l *RateLimiter) acquire(identity, facade string, now time.Time) (func(), error) {
+	if fl, ok := l.facades[facade]; ok && !fl.AllowN(now, 1) {
+		servermon.RateLimitedRequestsCount.WithLabelValues(facade, "facade-rate").Inc()
+		return nil, errors.E(errors.CodeTooManyRequests, fmt.Sprintf("too many requests on the %s facade, try again later", facade))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+	u, ok := l.users[identity]
+	if !ok {
+		u = &userLimits{}
+		if l.params.UserRate > 0 {
+			u.limiter = rate.NewLimiter(rate.Limit(l.params.UserRate), burst(l.params.UserRate, l.params.UserBurst))
+		}
+		l.users[identity] = u
+	}
+	u.lastUsed = now
+	if l.params.UserConcurrency > 0 && u.inFlight >= l.params.UserConcurrency {
+		servermon.RateLimitedRequestsCount.WithLabelValues(facade, "user-concurrency").Inc()
+		return nil, errors.E(errors.CodeTooManyRequests, "too many concurrent requests, try again later")
+	}
+	if u.limiter != nil && !u.limiter.AllowN(now, 1) {
+		servermon.RateLimitedRequestsCount.WithLabelValues(facade, "user-rate").Inc()
+		return nil, errors.E(errors.CodeTooManyRequests, "too many requests, try again later")
+	}
+	u.inFlight++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		u.inFlight--
+	}, nil
+}
+
+// prune removes the limits of identities that have been idle for longer
+// than idleUserLimitExpiry. It must be called with l.mu held.
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruning) < idleUserLimitExpiry {
+		return
+	}
+	l.lastPruning = now
+	for identity, u := range l.users {
+		if u.inFlight == 0 && now.Sub(u.lastUsed) > idleUserLimitExpiry {
+			delete(l.users, identity)
+		}
+	}
+}
+
+// rateLimitedMethodCaller wraps an rpcreflect.MethodCaller so that calls
+// are subject to the root's RateLimiter.
+type rateLimitedMethodCaller struct {
+	rpcreflect.MethodCaller
+
+	root    *controllerRoot
+	limiter *RateLimiter
+	facade  string
+}
+
+// Call implements rpcreflect.MethodCaller.Call.
+func (c rateLimitedMethodCaller) Call(ctx context.Context, objID string, arg reflect.Value) (reflect.Value, error) {
+	c.root.mu.Lock()
+	user := c.root.user
+	c.root.mu.Unlock()
+
+	var identityName string
+	if user != nil && user.Identity != nil {
+		identityName = user.Name
+	}
+	release, err := c.limiter.acquire(identityName, c.facade, time.Now())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	defer release()
+	return c.MethodCaller.Call(ctx, objID, arg)
+}
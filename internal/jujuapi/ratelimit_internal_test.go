@@ -0,0 +1,139 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestRateLimiterUserRate(t *testing.T) {
+	c := qt.New(t)
+
+	l := NewRateLimiter(RateLimitParams{UserRate: 1, UserBurst: 2})
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire("alice@canonical.com", "JIMM", now)
+		c.Assert(err, qt.IsNil)
+		release()
+	}
+	_, err := l.acquire("alice@canonical.com", "JIMM", now)
+	c.Check(err, qt.ErrorMatches, `too many requests, try again later`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeTooManyRequests)
+
+	// Identities are limited independently.
+	release, err := l.acquire("bob@canonical.com", "JIMM", now)
+	c.Assert(err, qt.IsNil)
+	release()
+
+	// The limit is replenished over time.
+	release, err = l.acquire("alice@canonical.com", "JIMM", now.Add(time.Second))
+	c.Assert(err, qt.IsNil)
+	release()
+}
+
+func TestRateLimiterUserConcurrency(t *testing.T) {
+	c := qt.New(t)
+
+	l := NewRateLimiter(RateLimitParams{UserConcurrency: 1})
+	now := time.Now()
+
+	release, err := l.acquire("alice@canonical.com", "JIMM", now)
+	c.Assert(err, qt.IsNil)
+	_, err = l.acquire("alice@canonical.com", "ModelManager", now)
+	c.Check(err, qt.ErrorMatches, `too many concurrent requests, try again later`)
+	release()
+
+	release, err = l.acquire("alice@canonical.com", "ModelManager", now)
+	c.Assert(err, qt.IsNil)
+	release()
+}
+
+func TestRateLimiterFacadeRate(t *testing.T) {
+	c := qt.New(t)
+
+	l := NewRateLimiter(RateLimitParams{FacadeRates: map[string]float64{"ModelManager": 1}})
+	now := time.Now()
+
+	release, err := l.acquire("alice@canonical.com", "ModelManager", now)
+	c.Assert(err, qt.IsNil)
+	release()
+	_, err = l.acquire("bob@canonical.com", "ModelManager", now)
+	c.Check(err, qt.ErrorMatches, `too many requests on the ModelManager facade, try again later`)
+
+	// Other facades are not limited.
+	release, err = l.acquire("bob@canonical.com", "JIMM", now)
+	c.Assert(err, qt.IsNil)
+	release()
+}
+
+func TestRateLimiterPrunesIdleIdentities(t *testing.T) {
+	c := qt.New(t)
+
+	l := NewRateLimiter(RateLimitParams{UserRate: 1})
+	now := time.Now()
+
+	release, err := l.acquire("alice@canonical.com", "JIMM", now)
+	c.Assert(err, qt.IsNil)
+	release()
+	c.Check(l.users, qt.HasLen, 1)
+
+	release, err = l.acquire("bob@canonical.com", "JIMM", now.Add(2*idleUserLimitExpiry))
+	c.Assert(err, qt.IsNil)
+	release()
+	c.Check(l.users, qt.HasLen, 1)
+	c.Check(l.users["bob@canonical.com"], qt.Not(qt.IsNil))
+}
+
+func TestParseFacadeRates(t *testing.T) {
+	c := qt.New(t)
+
+	rates, err := ParseFacadeRates("ModelManager=20 Cloud=5.5")
+	c.Assert(err, qt.IsNil)
+	c.Check(rates, qt.DeepEquals, map[string]float64{"ModelManager": 20, "Cloud": 5.5})
+
+	rates, err = ParseFacadeRates("")
+	c.Assert(err, qt.IsNil)
+	c.Check(rates, qt.IsNil)
+
+	_, err = ParseFacadeRates("ModelManager")
+	c.Check(err, qt.ErrorMatches, `invalid facade rate "ModelManager"`)
+	_, err = ParseFacadeRates("Cloud=fast")
+	c.Check(err, qt.ErrorMatches, `invalid facade rate "Cloud=fast"`)
+}
+
+func TestRateLimitedMethods(t *testing.T) {
+	c := qt.New(t)
+
+	r := newControllerRoot(&maintenanceJIMM{}, Params{
+		RateLimiter: NewRateLimiter(RateLimitParams{UserRate: 1}),
+	}, "")
+	defer r.cleanup()
+	r.user = openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	r.AddMethod("JIMM", 4, "ListLimits", rpc.Method(func() {}))
+
+	m, err := r.FindMethod("JIMM", 4, "ListLimits")
+	c.Assert(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(err, qt.IsNil)
+	_, err = m.Call(context.Background(), "", reflect.Value{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeTooManyRequests)
+
+	// Pings are never limited.
+	m, err = r.FindMethod("Pinger", 1, "Ping")
+	c.Assert(err, qt.IsNil)
+	for i := 0; i < 3; i++ {
+		_, err = m.Call(context.Background(), "", reflect.Value{})
+		c.Check(err, qt.IsNil)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// resumeGracePeriod is how long a resumable watcher is kept after the
+// connection that created it closes.
+var resumeGracePeriod = 2 * time.Minute
+
+// The kinds of watcher that may be resumed.
+const (
+	resumableAccess       = "access"
+	resumableAuditEvents  = "audit-events"
+	resumableModelSummary = "model-summary"
+)
+
+// parkedWatchers holds the resumable watchers whose connections have
+// closed, indexed by resume token. It is shared by all connections so
+// that a client may resume a watcher on a new connection.
+var parkedWatchers = &watcherParking{}
+
+// A resumable records the resume token and kind of a watcher registered
+// on a connection.
+type resumable struct {
+	token string
+	kind  string
+}
+
+// A resumer is a watcher that can be told it has been resumed, so that
+// anything returned by the last call to Next, which may not have reached
+// the client, is returned again.
+type resumer interface {
+	stopper
+	Resume()
+}
+
+type parkedWatcher struct {
+	identity string
+	kind     string
+	w        stopper
+	timer    *time.Timer
+}
+
+// A watcherParking holds watchers between connections until they are
+// claimed or their grace period expires.
+type watcherParking struct {
+	mu       sync.Mutex
+	watchers map[string]*parkedWatcher
+}
+
+// park holds the given watcher under the given token. If the watcher is
+// not claimed within resumeGracePeriod it is stopped.
+func (p *watcherParking) park(token, identity, kind string, w stopper) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.watchers == nil {
+		p.watchers = make(map[string]*parkedWatcher)
+	}
+	p.watchers[token] = &parkedWatcher{
+		identity: identity,
+		kind:     kind,
+		w:        w,
+		timer: time.AfterFunc(resumeGracePeriod, func() {
+			p.expire(token)
+		}),
+	}
+}
+
+func (p *watcherParking) expire(token string) {
+	p.mu.Lock()
+	pw, ok := p.watchers[token]
+	delete(p.watchers, token)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := pw.w.Stop(); err != nil {
+		zapctx.Error(context.Background(), "failed to stop parked watcher", zaputil.Error(err))
+	}
+}
+
+// claim removes and returns the watcher held under the given token. The
+// watcher must have been created by the same identity.
+func (p *watcherParking) claim(token, identity string) (*parkedWatcher, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pw, ok := p.watchers[token]
+	if !ok || pw.identity != identity {
+		return nil, errors.E(errors.CodeNotFound, "watcher not found")
+	}
+	pw.timer.Stop()
+	delete(p.watchers, token)
+	return pw, nil
+}
+
+// newResumeToken returns a new random resume token.
+func newResumeToken() (string, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
+
+// makeResumable issues a resume token for the watcher with the given ID
+// on this connection.
+func (r *controllerRoot) makeResumable(id, kind string) (string, error) {
+	token, err := newResumeToken()
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.resumables == nil {
+		r.resumables = make(map[string]resumable)
+	}
+	r.resumables[id] = resumable{token: token, kind: kind}
+	return token, nil
+}
+
+// parkResumables moves the resumable watchers on this connection into
+// parkedWatchers so that they survive the connection closing.
+func (r *controllerRoot) parkResumables() {
+	r.mu.Lock()
+	resumables := r.resumables
+	r.resumables = nil
+	user := r.user
+	r.mu.Unlock()
+	if user == nil {
+		return
+	}
+
+	for id, res := range resumables {
+		var w stopper
+		var err error
+		switch res.kind {
+		case resumableAccess:
+			w, err = r.accessWatchers.remove(id)
+		case resumableAuditEvents:
+			w, err = r.auditWatchers.remove(id)
+		case resumableModelSummary:
+			w, err = r.watchers.remove(id)
+		}
+		if err != nil {
+			// The watcher has already been stopped.
+			continue
+		}
+		parkedWatchers.park(res.token, user.Name, res.kind, w)
+	}
+}
+
+// ResumeWatcher implements the ResumeWatcher method on the JIMM facade. It
+// continues a watcher created on an earlier connection by the same user,
+// returning the ID to use with the watcher's facade on this connection.
+// Anything returned by the last call to Next on the earlier connection is
+// returned again, as the client may not have received it.
+func (r *controllerRoot) ResumeWatcher(ctx context.Context, req apiparams.ResumeWatcherRequest) (apiparams.ResumeWatcherResponse, error) {
+	const op = errors.Op("jujuapi.ResumeWatcher")
+
+	pw, err := parkedWatchers.claim(req.ResumeToken, r.user.Name)
+	if err != nil {
+		return apiparams.ResumeWatcherResponse{}, errors.E(op, err)
+	}
+	if rw, ok := pw.w.(resumer); ok {
+		rw.Resume()
+	}
+	if err := r.setupUUIDGenerator(); err != nil {
+		parkedWatchers.park(req.ResumeToken, pw.identity, pw.kind, pw.w)
+		return apiparams.ResumeWatcherResponse{}, errors.E(op, err)
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+	switch w := pw.w.(type) {
+	case *accessWatcher:
+		r.accessWatchers.register(id, w)
+	case *jimm.AuditEventWatcher:
+		r.auditWatchers.register(id, w)
+	case *modelSummaryWatcher:
+		w.id = id
+		r.watchers.register(w)
+	}
+	r.mu.Lock()
+	if r.resumables == nil {
+		r.resumables = make(map[string]resumable)
+	}
+	r.resumables[id] = resumable{token: req.ResumeToken, kind: pw.kind}
+	r.mu.Unlock()
+	return apiparams.ResumeWatcherResponse{
+		WatcherID: id,
+		Kind:      pw.kind,
+	}, nil
+}
+
+// forgetResumable stops the watcher with the given ID from being parked
+// when the connection closes.
+func (r *controllerRoot) forgetResumable(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.resumables, id)
+}
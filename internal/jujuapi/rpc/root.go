@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/juju/rpcreflect"
@@ -40,6 +42,22 @@ func (r *Root) RemoveMethod(rootName string, version int, methodName string) {
 	delete(r.methods, fmt.Sprintf("%s-%d-%s", rootName, version, methodName))
 }
 
+// Methods returns the sorted names of the methods in the given root at
+// the given version.
+func (r *Root) Methods(rootName string, version int) []string {
+	prefix := fmt.Sprintf("%s-%d-", rootName, version)
+	r.methodMu.RLock()
+	defer r.methodMu.RUnlock()
+	var names []string
+	for k := range r.methods {
+		if strings.HasPrefix(k, prefix) {
+			names = append(names, strings.TrimPrefix(k, prefix))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // FindMethod implements rpc.Root.
 func (r *Root) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
 	key := fmt.Sprintf("%s-%d-%s", rootName, version, methodName)
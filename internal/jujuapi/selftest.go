@@ -0,0 +1,38 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// ServiceSelfTest checks each of the services JIMM depends on and
+// returns the result of every check. It is intended to be run after an
+// upgrade to confirm that JIMM is working.
+func (r *controllerRoot) ServiceSelfTest(ctx context.Context) (apiparams.ServiceSelfTestResponse, error) {
+	const op = errors.Op("jujuapi.ServiceSelfTest")
+
+	results, err := r.jimm.ServiceSelfTest(ctx, r.user)
+	if err != nil {
+		return apiparams.ServiceSelfTestResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ServiceSelfTestResponse{
+		Passed:  true,
+		Results: make([]apiparams.SelfTestResult, len(results)),
+	}
+	for i, res := range results {
+		resp.Results[i] = apiparams.SelfTestResult{
+			Name:       res.Name,
+			Passed:     res.Error == nil,
+			DurationMS: res.Duration.Milliseconds(),
+		}
+		if res.Error != nil {
+			resp.Passed = false
+			resp.Results[i].Error = res.Error.Error()
+		}
+	}
+	return resp, nil
+}
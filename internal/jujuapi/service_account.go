@@ -27,7 +27,7 @@ func (r *controllerRoot) AddServiceAccount(ctx context.Context, req apiparams.Ad
 		return errors.E(op, errors.CodeBadRequest, err)
 	}
 
-	return r.jimm.AddServiceAccount(ctx, r.user, clientIdWithDomain)
+	return r.jimm.AddServiceAccount(ctx, r.user, clientIdWithDomain, req.Purpose)
 }
 
 // CopyServiceAccountCredential copies a users cloud-credential to a service account.
@@ -78,7 +78,8 @@ func (r *controllerRoot) getServiceAccount(ctx context.Context, clientID string)
 // UpdateServiceAccountCredentialsCheckModels updates a set of cloud credentials' content.
 // If there are any models that are using a credential and these models
 // are not going to be visible with updated credential content,
-// there will be detailed validation errors per model.
+// there will be detailed validation errors per model. If req.DryRun is
+// set the credentials are only checked, nothing is updated.
 //
 // This method checks that the authenticated user has permission to manage the service account.
 func (r *controllerRoot) UpdateServiceAccountCredentials(ctx context.Context, req apiparams.UpdateServiceAccountCredentialsRequest) (jujuparams.UpdateCredentialResults, error) {
@@ -105,6 +106,7 @@ func (r *controllerRoot) UpdateServiceAccountCredentials(ctx context.Context, re
 				SkipCheck: false,
 				// Update all credentials on target controllers.
 				SkipUpdate: false,
+				DryRun:     req.DryRun,
 			})
 		}
 		results.Results[i] = jujuparams.UpdateCredentialResult{
@@ -128,6 +130,23 @@ func (r *controllerRoot) ListServiceAccountCredentials(ctx context.Context, req
 	return getIdentityCredentials(ctx, targetIdentity, r.jimm, req.CloudCredentialArgs)
 }
 
+// SetServiceAccountAllowedCIDRs sets the network ranges from which a
+// service account may authenticate.
+func (r *controllerRoot) SetServiceAccountAllowedCIDRs(ctx context.Context, req apiparams.SetServiceAccountAllowedCIDRsRequest) error {
+	const op = errors.Op("jujuapi.SetServiceAccountAllowedCIDRs")
+
+	clientIdWithDomain, err := jimmnames.EnsureValidServiceAccountId(req.ClientID)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	svcAccTag := jimmnames.NewServiceAccountTag(clientIdWithDomain)
+
+	if err := r.jimm.SetServiceAccountAllowedCIDRs(ctx, r.user, svcAccTag, req.CIDRs); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // GrantServiceAccountAccess is the method handler for granting new users/groups with access
 // to service accounts.
 func (r *controllerRoot) GrantServiceAccountAccess(ctx context.Context, req apiparams.GrantServiceAccountAccess) error {
@@ -146,3 +165,53 @@ func (r *controllerRoot) GrantServiceAccountAccess(ctx context.Context, req apip
 
 	return r.jimm.GrantServiceAccountAccess(ctx, r.user, svcAccTag, req.Entities)
 }
+
+// ListServiceAccounts returns the service accounts the authenticated user
+// administers.
+func (r *controllerRoot) ListServiceAccounts(ctx context.Context) (apiparams.ListServiceAccountsResponse, error) {
+	const op = errors.Op("jujuapi.ListServiceAccounts")
+
+	sas, err := r.jimm.ListServiceAccounts(ctx, r.user)
+	if err != nil {
+		return apiparams.ListServiceAccountsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListServiceAccountsResponse{
+		ServiceAccounts: make([]apiparams.ServiceAccount, len(sas)),
+	}
+	for i, sa := range sas {
+		resp.ServiceAccounts[i] = sa.ToAPIServiceAccount()
+	}
+	return resp, nil
+}
+
+// SetServiceAccountDisabled disables, or re-enables, a service account.
+func (r *controllerRoot) SetServiceAccountDisabled(ctx context.Context, req apiparams.SetServiceAccountDisabledRequest) error {
+	const op = errors.Op("jujuapi.SetServiceAccountDisabled")
+
+	clientIdWithDomain, err := jimmnames.EnsureValidServiceAccountId(req.ClientID)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	svcAccTag := jimmnames.NewServiceAccountTag(clientIdWithDomain)
+
+	if err := r.jimm.SetServiceAccountDisabled(ctx, r.user, svcAccTag, req.Disabled); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// DeleteServiceAccount removes a service account from JIMM.
+func (r *controllerRoot) DeleteServiceAccount(ctx context.Context, req apiparams.DeleteServiceAccountRequest) error {
+	const op = errors.Op("jujuapi.DeleteServiceAccount")
+
+	clientIdWithDomain, err := jimmnames.EnsureValidServiceAccountId(req.ClientID)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	svcAccTag := jimmnames.NewServiceAccountTag(clientIdWithDomain)
+
+	if err := r.jimm.DeleteServiceAccount(ctx, r.user, svcAccTag); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
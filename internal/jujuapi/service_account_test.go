@@ -69,7 +69,7 @@ func TestAddServiceAccount(t *testing.T) {
 		test := test
 		c.Run(test.about, func(c *qt.C) {
 			jimm := &jimmtest.JIMM{
-				AddServiceAccount_: func(_ context.Context, _ *openfga.User, clientID string) error {
+				AddServiceAccount_: func(_ context.Context, _ *openfga.User, clientID, _ string) error {
 					c.Assert(clientID, qt.Equals, test.addedClientId)
 					return nil
 				},
@@ -484,7 +484,7 @@ func TestListServiceAccountCredentials(t *testing.T) {
 		about                        string
 		getCloudCredential           func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error)
 		getCloudCredentialAttributes func(ctx context.Context, u *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error)
-		ForEachUserCloudCredential   func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error
+		listCloudCredentials         func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error)
 		args                         params.ListServiceAccountCredentialsRequest
 		username                     string
 		addTuples                    []openfga.Tuple
@@ -492,8 +492,8 @@ func TestListServiceAccountCredentials(t *testing.T) {
 		expectedError                string
 	}{{
 		about: "Valid request without domain",
-		ForEachUserCloudCredential: func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error {
-			return nil
+		listCloudCredentials: func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error) {
+			return nil, nil
 		},
 		expectedResult: jujuparams.CredentialContentResults{
 			Results: []jujuparams.CredentialContentResult{}},
@@ -515,8 +515,8 @@ func TestListServiceAccountCredentials(t *testing.T) {
 		}},
 	}, {
 		about: "Valid request with domain",
-		ForEachUserCloudCredential: func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error {
-			return nil
+		listCloudCredentials: func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error) {
+			return nil, nil
 		},
 		expectedResult: jujuparams.CredentialContentResults{
 			Results: []jujuparams.CredentialContentResult{}},
@@ -538,8 +538,8 @@ func TestListServiceAccountCredentials(t *testing.T) {
 		}},
 	}, {
 		about: "Invalid Service account ID",
-		ForEachUserCloudCredential: func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error {
-			return nil
+		listCloudCredentials: func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error) {
+			return nil, nil
 		},
 		args: params.ListServiceAccountCredentialsRequest{
 			ClientID: "_123_",
@@ -555,8 +555,8 @@ func TestListServiceAccountCredentials(t *testing.T) {
 		expectedError: "invalid client ID",
 	}, {
 		about: "Missing service account administrator permission",
-		ForEachUserCloudCredential: func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error {
-			return nil
+		listCloudCredentials: func(ctx context.Context, user *openfga.User, filter db.CloudCredentialFilter) ([]dbmodel.CloudCredential, error) {
+			return nil, nil
 		},
 		args: params.ListServiceAccountCredentialsRequest{
 			ClientID: "fca1f605-736e-4d1f-bcd2-aecc726923be",
@@ -585,7 +585,7 @@ func TestListServiceAccountCredentials(t *testing.T) {
 			jimm := &jimmtest.JIMM{
 				GetCloudCredential_:           test.getCloudCredential,
 				GetCloudCredentialAttributes_: test.getCloudCredentialAttributes,
-				ForEachUserCloudCredential_:   test.ForEachUserCloudCredential,
+				ListCloudCredentials_:         test.listCloudCredentials,
 				UserLogin_: func(ctx context.Context, email string) (*openfga.User, error) {
 					var u dbmodel.Identity
 					u.SetTag(names.NewUserTag(email))
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// A stopper is a long running object, such as a watcher, that must be
+// stopped when the connection that created it closes.
+type stopper interface {
+	Stop() error
+}
+
+// A stopperRegistry holds the long running objects of a single type
+// created on a connection, indexed by their object ID.
+type stopperRegistry[T stopper] struct {
+	mu      sync.Mutex
+	objects map[string]T
+}
+
+func (r *stopperRegistry[T]) register(id string, o T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.objects == nil {
+		r.objects = make(map[string]T)
+	}
+	r.objects[id] = o
+}
+
+func (r *stopperRegistry[T]) get(id string) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.objects[id]
+	if !ok {
+		return o, errors.E(errors.CodeNotFound)
+	}
+	return o, nil
+}
+
+func (r *stopperRegistry[T]) remove(id string) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.objects[id]
+	if !ok {
+		return o, errors.E(errors.CodeNotFound)
+	}
+	delete(r.objects, id)
+	return o, nil
+}
+
+func (r *stopperRegistry[T]) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, o := range r.objects {
+		if err := o.Stop(); err != nil {
+			zapctx.Error(context.Background(), "failed to stop watcher", zaputil.Error(err))
+		}
+	}
+	r.objects = nil
+}
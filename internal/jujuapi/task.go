@@ -0,0 +1,41 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// AddModelAsync starts creating a model and returns the ID of the task
+// creating it, without waiting for the controller to create the model.
+func (r *controllerRoot) AddModelAsync(ctx context.Context, args jujuparams.ModelCreateArgs) (apiparams.AddModelAsyncResponse, error) {
+	const op = errors.Op("jujuapi.AddModelAsync")
+
+	var mca jimm.ModelCreateArgs
+	if err := mca.FromJujuModelCreateArgs(&args); err != nil {
+		return apiparams.AddModelAsyncResponse{}, errors.E(op, err)
+	}
+	task, err := r.jimm.AddModelAsync(ctx, r.user, &mca)
+	if err != nil {
+		return apiparams.AddModelAsyncResponse{}, errors.E(op, err)
+	}
+	return apiparams.AddModelAsyncResponse{TaskID: task.ID}, nil
+}
+
+// GetTaskStatus returns the status of a task started by the
+// authenticated user.
+func (r *controllerRoot) GetTaskStatus(ctx context.Context, req apiparams.GetTaskStatusRequest) (apiparams.TaskStatus, error) {
+	const op = errors.Op("jujuapi.GetTaskStatus")
+
+	task, err := r.jimm.GetTask(ctx, r.user, req.TaskID)
+	if err != nil {
+		return apiparams.TaskStatus{}, errors.E(op, err)
+	}
+	return task.ToAPITaskStatus(), nil
+}
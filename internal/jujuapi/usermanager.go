@@ -22,13 +22,13 @@ func init() {
 		resetPasswordMethod := rpc.Method(r.ResetPassword)
 		modelUserInfoMethod := rpc.Method(r.ModelUserInfo)
 
-		r.AddMethod("UserManager", 3, "AddUser", addUserMethod)
-		r.AddMethod("UserManager", 3, "DisableUser", disableUserMethod)
-		r.AddMethod("UserManager", 3, "EnableUser", enableUserMethod)
-		r.AddMethod("UserManager", 3, "RemoveUser", removeUserMethod)
-		r.AddMethod("UserManager", 3, "SetPassword", setPasswordMethod)
+		r.addMutatingMethod("UserManager", 3, "AddUser", addUserMethod)
+		r.addMutatingMethod("UserManager", 3, "DisableUser", disableUserMethod)
+		r.addMutatingMethod("UserManager", 3, "EnableUser", enableUserMethod)
+		r.addMutatingMethod("UserManager", 3, "RemoveUser", removeUserMethod)
+		r.addMutatingMethod("UserManager", 3, "SetPassword", setPasswordMethod)
 		r.AddMethod("UserManager", 3, "UserInfo", userInfoMethod)
-		r.AddMethod("UserManager", 3, "ResetPassword", resetPasswordMethod)
+		r.addMutatingMethod("UserManager", 3, "ResetPassword", resetPasswordMethod)
 		r.AddMethod("UserManager", 3, "ModelUserInfo", modelUserInfoMethod)
 
 		return []int{3}
@@ -5,6 +5,7 @@ package jujuapi
 import (
 	"context"
 	"database/sql"
+	stderrors "errors"
 	"net/http"
 	"regexp"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
 	"github.com/juju/zaputil"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
@@ -105,7 +107,7 @@ func serveRoot(ctx context.Context, root root, logger jimm.DbAuditLogger, wsConn
 	// Note that although NewConn accepts a `RecorderFactory` input, the call to conn.ServeRoot
 	// also accepts a `RecorderFactory` and will override anything set during the call to NewConn.
 	conn := rpc.NewConn(
-		jsoncodec.NewWebsocket(wsConn),
+		newDeprecationCodec(jsoncodec.NewWebsocket(wsConn)),
 		nil,
 	)
 	rpcRecorderFactory := func() rpc.Recorder {
@@ -133,10 +135,17 @@ func mapError(err error) *jujuparams.Error {
 	// TODO the error mapper should really accept a context from the RPC package.
 	zapctx.Debug(context.TODO(), "rpc error", zaputil.Error(err))
 
-	return &jujuparams.Error{
+	perr := &jujuparams.Error{
 		Message: err.Error(),
 		Code:    string(errors.ErrorCode(err)),
 	}
+	// Errors carrying structured details, such as credential
+	// validation errors, return them to the client.
+	var infoErr interface{ Info() map[string]interface{} }
+	if stderrors.As(err, &infoErr) {
+		perr.Info = infoErr.Info()
+	}
+	return perr
 }
 
 // apiProxier serves the /commands and /api server for a model by
@@ -176,6 +185,14 @@ func modelInfoFromPath(path string) (uuid string, finalPath string, err error) {
 func (s apiProxier) ServeWS(ctx context.Context, clientConn *websocket.Conn) {
 	jwtGenerator := jimm.NewJWTGenerator(&s.jimm.Database, s.jimm, s.jimm.JWTService)
 	connectionFunc := controllerConnectionFunc(s, &jwtGenerator)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	unsub, err := s.watchModelAccess(ctx, &jwtGenerator, cancel)
+	if err != nil {
+		zapctx.Error(ctx, "failed to watch model access", zap.Error(err))
+		return
+	}
+	defer unsub()
 	zapctx.Debug(ctx, "Starting proxier")
 	auditLogger := s.jimm.AddAuditLogEntry
 	proxyHelpers := jimmRPC.ProxyHelpers{
@@ -185,12 +202,57 @@ func (s apiProxier) ServeWS(ctx context.Context, clientConn *websocket.Conn) {
 		AuditLog:                auditLogger,
 		LoginService:            s.jimm,
 		AuthenticatedIdentityID: auth.SessionIdentityFromContext(ctx),
+		CheckModelConfig:        s.jimm.CheckModelConfig,
+		CharmHubCache:           s.params.CharmHubCache,
+		Branding:                s.params.Branding,
 	}
 	if err := jimmRPC.ProxySockets(ctx, proxyHelpers); err != nil {
 		zapctx.Error(ctx, "failed to start jimm model proxy", zap.Error(err))
 	}
 }
 
+// watchModelAccess calls revoke if the user authenticated on the proxied
+// connection loses access to the model being proxied. Access is checked
+// whenever JIMM makes a change that may affect the user's access to the
+// model. The returned function stops the checks.
+func (s apiProxier) watchModelAccess(ctx context.Context, tokenGen *jimm.JWTGenerator, revoke func()) (func(), error) {
+	hub := s.jimm.PubSubHub()
+	if hub == nil {
+		return func() {}, nil
+	}
+	path := jimmhttp.PathElementFromContext(ctx, "path")
+	uuid, _, err := modelInfoFromPath(path)
+	if err != nil {
+		// The connection will fail when it is first used.
+		return func() {}, nil
+	}
+	mt := names.NewModelTag(uuid)
+	return hub.Subscribe(jimm.AccessChangedTopic, func(_ string, data interface{}) {
+		ut := tokenGen.GetUser()
+		if ut.Id() == "" {
+			// Not logged in yet, access is checked on login.
+			return
+		}
+		if change, ok := data.(jimm.AccessChange); ok && !change.Affects(ut.Id(), uuid) {
+			return
+		}
+		user, err := s.jimm.FetchIdentity(ctx, ut.Id())
+		if err != nil {
+			zapctx.Error(ctx, "failed to fetch identity", zap.String("identity", ut.Id()), zap.Error(err))
+			return
+		}
+		ok, err := user.IsModelReader(ctx, mt)
+		if err != nil {
+			zapctx.Error(ctx, "failed to check model access", zap.String("model", uuid), zap.Error(err))
+			return
+		}
+		if !ok {
+			zapctx.Info(ctx, "model access revoked, closing proxied connection", zap.String("identity", ut.Id()), zap.String("model", uuid))
+			revoke()
+		}
+	})
+}
+
 // controllerConnectionFunc returns a function that will be used to
 // connect to a controller when a client makes a request.
 func controllerConnectionFunc(s apiProxier, jwtGenerator *jimm.JWTGenerator) func(context.Context) (jimmRPC.WebsocketConnectionWithMetadata, error) {
@@ -221,11 +283,17 @@ func controllerConnectionFunc(s apiProxier, jwtGenerator *jimm.JWTGenerator) fun
 			zapctx.Error(ctx, "cannot dial controller", zap.String("controller", m.Controller.Name), zap.Error(err))
 			return jimmRPC.WebsocketConnectionWithMetadata{}, err
 		}
+		// A client connecting to the model counts as activity in it.
+		m.LastActivity = sql.NullTime{Time: time.Now(), Valid: true}
+		if err := s.jimm.Database.UpdateModelLastActivity(ctx, &m); err != nil {
+			zapctx.Error(ctx, "cannot record model activity", zap.String("uuid", uuid), zap.Error(err))
+		}
 		fullModelName := m.Controller.Name + "/" + m.Name
 		return jimmRPC.WebsocketConnectionWithMetadata{
 			Conn:           controllerConn,
 			ControllerUUID: m.Controller.UUID,
 			ModelName:      fullModelName,
+			ModelUUID:      m.UUID.String,
 		}, nil
 	}
 }
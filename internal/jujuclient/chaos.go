@@ -0,0 +1,158 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// ChaosParams configures the faults injected into controller connections
+// in chaos mode. Chaos mode continuously exercises JIMM's retry and
+// failover paths and must only ever be enabled in staging deployments.
+type ChaosParams struct {
+	// DialFailureRate is the probability, between 0 and 1, that a dial
+	// to a controller fails.
+	DialFailureRate float64
+
+	// MaxLatency is the maximum latency added before each dial and
+	// each call. The latency added is chosen uniformly between zero
+	// and MaxLatency.
+	MaxLatency time.Duration
+
+	// DisconnectRate is the probability, between 0 and 1, that a
+	// connection is dropped while a call is in progress.
+	DisconnectRate float64
+
+	// Seed is used to seed the random source used to choose faults. If
+	// it is zero the current time is used.
+	Seed int64
+}
+
+// Enabled returns whether the parameters inject any faults.
+func (p ChaosParams) Enabled() bool {
+	return p.DialFailureRate > 0 || p.MaxLatency > 0 || p.DisconnectRate > 0
+}
+
+// Validate checks that the parameters are in range.
+func (p ChaosParams) Validate() error {
+	if p.DialFailureRate < 0 || p.DialFailureRate > 1 {
+		return errors.E("chaos dial failure rate must be between 0 and 1")
+	}
+	if p.DisconnectRate < 0 || p.DisconnectRate > 1 {
+		return errors.E("chaos disconnect rate must be between 0 and 1")
+	}
+	if p.MaxLatency < 0 {
+		return errors.E("chaos maximum latency must not be negative")
+	}
+	return nil
+}
+
+// A Chaos injects faults into the connections made by a Dialer.
+type Chaos struct {
+	params ChaosParams
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewChaos returns a Chaos injecting faults as described by the given
+// parameters.
+func NewChaos(p ChaosParams) *Chaos {
+	seed := p.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Chaos{
+		params: p,
+		rand:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// float64 returns a random number in [0, 1).
+func (c *Chaos) float64() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rand.Float64()
+}
+
+// delay returns a random duration up to the configured maximum latency.
+func (c *Chaos) delay() time.Duration {
+	if c.params.MaxLatency <= 0 {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Duration(c.rand.Int63n(int64(c.params.MaxLatency) + 1))
+}
+
+// sleep waits for a random latency, or until the context is done.
+func (c *Chaos) sleep(ctx context.Context) error {
+	d := c.delay()
+	if d == 0 {
+		return nil
+	}
+	servermon.ChaosFaultCount.WithLabelValues("latency").Inc()
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// beforeDial is called before a controller is dialed. It adds latency
+// and returns an error if the dial should fail.
+func (c *Chaos) beforeDial(ctx context.Context, controller string) error {
+	const op = errors.Op("jujuclient.Chaos.beforeDial")
+	if c == nil {
+		return nil
+	}
+	if err := c.sleep(ctx); err != nil {
+		return errors.E(op, err)
+	}
+	if c.float64() < c.params.DialFailureRate {
+		servermon.ChaosFaultCount.WithLabelValues("dial").Inc()
+		zapctx.Debug(ctx, "chaos: failing dial", zap.String("controller", controller))
+		return errors.E(op, errors.CodeConnectionFailed, "chaos: injected dial failure")
+	}
+	return nil
+}
+
+// beforeCall is called before a call is made on a connection. It adds
+// latency and, if the connection should be dropped during the call,
+// arranges for disconnect to be called shortly after the call starts.
+// The returned function must be called once the call completes.
+func (c *Chaos) beforeCall(ctx context.Context, controller string, disconnect func()) (func(), error) {
+	const op = errors.Op("jujuclient.Chaos.beforeCall")
+	if c == nil {
+		return func() {}, nil
+	}
+	if err := c.sleep(ctx); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if c.float64() >= c.params.DisconnectRate {
+		return func() {}, nil
+	}
+	servermon.ChaosFaultCount.WithLabelValues("disconnect").Inc()
+	zapctx.Debug(ctx, "chaos: dropping connection", zap.String("controller", controller))
+	// Disconnect after a short random delay so that the request has
+	// normally been sent, if the call completes first the connection
+	// is dropped immediately afterwards.
+	t := time.AfterFunc(c.delay()/10, disconnect)
+	return func() {
+		if t.Stop() {
+			disconnect()
+		}
+	}, nil
+}
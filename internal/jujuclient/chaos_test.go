@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical.
+
+package jujuclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuclient"
+)
+
+func TestChaosParamsValidate(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(jujuclient.ChaosParams{}.Enabled(), qt.IsFalse)
+	c.Check(jujuclient.ChaosParams{MaxLatency: time.Millisecond}.Enabled(), qt.IsTrue)
+	c.Check(jujuclient.ChaosParams{DialFailureRate: 0.5, DisconnectRate: 0.1}.Validate(), qt.IsNil)
+	c.Check(jujuclient.ChaosParams{DialFailureRate: 1.5}.Validate(), qt.ErrorMatches, `chaos dial failure rate must be between 0 and 1`)
+	c.Check(jujuclient.ChaosParams{DisconnectRate: -1}.Validate(), qt.ErrorMatches, `chaos disconnect rate must be between 0 and 1`)
+	c.Check(jujuclient.ChaosParams{MaxLatency: -time.Second}.Validate(), qt.ErrorMatches, `chaos maximum latency must not be negative`)
+}
+
+func TestChaosDialFailure(t *testing.T) {
+	c := qt.New(t)
+
+	d := jujuclient.Dialer{
+		Chaos: jujuclient.NewChaos(jujuclient.ChaosParams{
+			DialFailureRate: 1,
+			MaxLatency:      time.Millisecond,
+			Seed:            1,
+		}),
+	}
+	ctl := dbmodel.Controller{Name: "test-controller"}
+	_, err := d.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Check(err, qt.ErrorMatches, `chaos: injected dial failure`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeConnectionFailed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Chaos = jujuclient.NewChaos(jujuclient.ChaosParams{MaxLatency: time.Hour})
+	_, err = d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Check(err, qt.ErrorMatches, `context canceled`)
+}
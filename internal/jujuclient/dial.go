@@ -15,15 +15,18 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
+	"github.com/gorilla/websocket"
 	jujuhttp "github.com/juju/http/v2"
 	"github.com/juju/juju/api/base"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"gopkg.in/httprequest.v1"
 
@@ -33,6 +36,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
 	"github.com/canonical/jimm/v3/internal/rpc"
 	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/internal/tracing"
 )
 
 const (
@@ -47,11 +51,93 @@ type ControllerCredentialsStore interface {
 	GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error)
 }
 
+// A ControllerCACertificateStore is a store for controller CA
+// certificates.
+type ControllerCACertificateStore interface {
+	// GetControllerCACertificate retrieves the CA certificate for the
+	// given controller.
+	GetControllerCACertificate(ctx context.Context, controllerName string) (string, error)
+}
+
 // A Dialer is an implementation of a jimm.Dialer that adapts a juju API
 // connection to provide a jimm API.
 type Dialer struct {
 	ControllerCredentialsStore ControllerCredentialsStore
 	JWTService                 *jimmjwx.JWTService
+
+	// CACertificateStore, if set, is used to find the CA certificate of
+	// controllers whose records do not hold one.
+	CACertificateStore ControllerCACertificateStore
+
+	// Chaos, if set, injects faults into the connections made by the
+	// dialer. It must only be set in staging deployments.
+	Chaos *Chaos
+
+	// Router, if set, is used to choose the order in which a
+	// controller's addresses are dialed. If it is not set all of the
+	// addresses are dialed simultaneously.
+	Router *rpc.EndpointRouter
+
+	// MaxConcurrentDials, if positive, is the maximum number of
+	// connections to controllers that may be being dialed at once.
+	// Further dials wait for one of the current dials to complete.
+	MaxConcurrentDials int
+
+	dialSemOnce sync.Once
+	dialSem     chan struct{}
+}
+
+// acquireDial waits until a new dial may be started, returning a function
+// that must be called once the dial has completed. An error is returned
+// if the context is done before the dial may start.
+func (d *Dialer) acquireDial(ctx context.Context) (func(), error) {
+	if d.MaxConcurrentDials <= 0 {
+		return func() {}, nil
+	}
+	d.dialSemOnce.Do(func() {
+		d.dialSem = make(chan struct{}, d.MaxConcurrentDials)
+	})
+	select {
+	case d.dialSem <- struct{}{}:
+		return func() { <-d.dialSem }, nil
+	default:
+	}
+	start := time.Now()
+	select {
+	case d.dialSem <- struct{}{}:
+		servermon.ControllerDialWaitDuration.Observe(time.Since(start).Seconds())
+		return func() { <-d.dialSem }, nil
+	case <-ctx.Done():
+		servermon.ControllerDialRejectedCount.Inc()
+		return nil, errors.E(errors.CodeTooManyRequests, "too many concurrent controller connections, try again later", ctx.Err())
+	}
+}
+
+// dialController connects to the given controller/model using the
+// configured Router, if any. At most MaxConcurrentDials dials are made at
+// once. If the controller record has no CA certificate one is looked up
+// in the CACertificateStore, if configured.
+func (d *Dialer) dialController(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, finalPath string, headers http.Header) (*websocket.Conn, error) {
+	if ctl.CACertificate == "" && d.CACertificateStore != nil {
+		caCert, err := d.CACertificateStore.GetControllerCACertificate(ctx, ctl.Name)
+		if err != nil {
+			return nil, errors.E(err, "cannot retrieve controller CA certificate")
+		}
+		if caCert != "" {
+			ctl1 := *ctl
+			ctl1.CACertificate = caCert
+			ctl = &ctl1
+		}
+	}
+	release, err := d.acquireDial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if d.Router != nil {
+		return d.Router.Dial(ctx, ctl, modelTag, finalPath, headers)
+	}
+	return rpc.Dial(ctx, ctl, modelTag, finalPath, headers)
 }
 
 func (d *Dialer) createLoginRequest(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, p map[string]string) (*jujuparams.LoginRequest, error) {
@@ -83,10 +169,18 @@ func (d *Dialer) createLoginRequest(ctx context.Context, ctl *dbmodel.Controller
 }
 
 // Dial implements jimm.Dialer.
-func (d *Dialer) Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, requiredPermissions map[string]string) (jimm.API, error) {
+func (d *Dialer) Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, requiredPermissions map[string]string) (_ jimm.API, err error) {
 	const op = errors.Op("jujuclient.Dial")
+	ctx, span := tracing.Start(ctx, string(op),
+		attribute.String("controller", ctl.Name),
+		attribute.String("model", modelTag.Id()),
+	)
+	defer tracing.End(span, &err)
 
-	conn, err := rpc.Dial(ctx, ctl, modelTag, "", nil)
+	if err := d.Chaos.beforeDial(ctx, ctl.Name); err != nil {
+		return nil, err
+	}
+	conn, err := d.dialController(ctx, ctl, modelTag, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +352,13 @@ func (c *Connection) Call(ctx context.Context, facade string, version int, id, m
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.JujuCallErrorCount, &err, labels...)
 
-	err = c.client.Call(ctx, facade, version, id, method, args, resp)
+	client := c.client
+	done, err := c.dialer.Chaos.beforeCall(ctx, labels[2], client.Disconnect)
+	if err != nil {
+		return err
+	}
+	err = client.Call(ctx, facade, version, id, method, args, resp)
+	done()
 	if err != nil {
 		if rpcErr, ok := err.(*rpc.Error); ok {
 			// if we get a permission check required error, we redial the controller
@@ -346,19 +446,34 @@ func (c *Connection) ConnectStream(path string, attrs url.Values) (base.Stream,
 		return nil, errors.E(op, "no model found")
 	}
 
-	user, pass, err := c.dialer.ControllerCredentialsStore.GetControllerCredentials(c.ctx, c.ctl.Name)
+	user, pass, err := c.controllerCredentials()
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 	requestHeader := jujuhttp.BasicAuthHeader(names.NewUserTag(user).String(), pass)
 
-	conn, err := rpc.Dial(c.ctx, c.ctl, modelTag, path, requestHeader)
+	conn, err := c.dialer.dialController(c.ctx, c.ctl, modelTag, path, requestHeader)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 	return conn, nil
 }
 
+// controllerCredentials returns the admin credentials used to connect to
+// the controller. Credentials are only fetched from the credential store
+// when they are needed, falling back to any credentials still held on the
+// controller record for deployments whose credentials have not yet been
+// migrated to the credential store.
+func (c *Connection) controllerCredentials() (string, string, error) {
+	if c.ctl.AdminIdentityName != "" && c.ctl.AdminPassword != "" {
+		return c.ctl.AdminIdentityName, c.ctl.AdminPassword, nil
+	}
+	if c.dialer.ControllerCredentialsStore == nil {
+		return "", "", errors.E(errors.CodeServerConfiguration, "controller credentials store not configured")
+	}
+	return c.dialer.ControllerCredentialsStore.GetControllerCredentials(c.ctx, c.ctl.Name)
+}
+
 // ConnectControllerStream connects to the given HTTP websocket
 // endpoint path and returns the resulting connection. The given
 // values are used as URL query values when making the initial
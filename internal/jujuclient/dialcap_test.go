@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical.
+
+package jujuclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuclient"
+)
+
+func TestMaxConcurrentDials(t *testing.T) {
+	c := qt.New(t)
+
+	d := &jujuclient.Dialer{MaxConcurrentDials: 1}
+	release, err := jujuclient.AcquireDial(d, context.Background())
+	c.Assert(err, qt.IsNil)
+
+	// A second dial waits until the first completes, or its context is
+	// done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = jujuclient.AcquireDial(d, ctx)
+	c.Check(err, qt.ErrorMatches, `too many concurrent controller connections, try again later`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeTooManyRequests)
+
+	acquired := make(chan func())
+	go func() {
+		release, err := jujuclient.AcquireDial(d, context.Background())
+		c.Check(err, qt.IsNil)
+		acquired <- release
+	}()
+	release()
+	(<-acquired)()
+
+	// Dials are not limited by default.
+	d = &jujuclient.Dialer{}
+	for i := 0; i < 3; i++ {
+		_, err := jujuclient.AcquireDial(d, context.Background())
+		c.Assert(err, qt.IsNil)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import "context"
+
+// AcquireDial exposes acquireDial for testing.
+func AcquireDial(d *Dialer, ctx context.Context) (func(), error) {
+	return d.acquireDial(ctx)
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	"github.com/juju/juju/api"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// MigrationTargetPrechecks asks the controller whether it can accept the
+// migration of the described model. If the facade versions in the info
+// are not set the versions supported by the Juju API client are used.
+// MigrationTargetPrechecks uses the Prechecks procedure on the
+// MigrationTarget facade version 3.
+func (c Connection) MigrationTargetPrechecks(ctx context.Context, info *jujuparams.MigrationModelInfo) error {
+	const op = errors.Op("jujuclient.MigrationTargetPrechecks")
+	if info.FacadeVersions == nil {
+		info.FacadeVersions = make(map[string][]int)
+		for name, versions := range api.SupportedFacadeVersions() {
+			info.FacadeVersions[name] = versions
+		}
+	}
+	if err := c.Call(ctx, "MigrationTarget", 3, "", "Prechecks", info, nil); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	return nil
+}
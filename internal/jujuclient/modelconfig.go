@@ -0,0 +1,29 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// ModelGet returns the configuration values of the model the connection
+// is logged in to. ModelGet uses the ModelGet procedure on the
+// ModelConfig facade.
+func (c Connection) ModelGet(ctx context.Context) (map[string]interface{}, error) {
+	const op = errors.Op("jujuclient.ModelGet")
+
+	var out jujuparams.ModelConfigResults
+	if err := c.CallHighestFacadeVersion(ctx, "ModelConfig", []int{3, 2}, "", "ModelGet", nil, &out); err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	config := make(map[string]interface{}, len(out.Config))
+	for k, v := range out.Config {
+		config[k] = v.Value
+	}
+	return config, nil
+}
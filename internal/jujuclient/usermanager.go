@@ -0,0 +1,36 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"context"
+
+	jujuerrors "github.com/juju/errors"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// SetPassword changes the password of the given local user on the
+// controller. SetPassword uses the SetPassword procedure on the
+// UserManager facade version 3.
+func (c Connection) SetPassword(ctx context.Context, user names.UserTag, password string) error {
+	const op = errors.Op("jujuclient.SetPassword")
+	args := jujuparams.EntityPasswords{
+		Changes: []jujuparams.EntityPassword{{
+			Tag:      user.String(),
+			Password: password,
+		}},
+	}
+	resp := jujuparams.ErrorResults{
+		Results: make([]jujuparams.ErrorResult, 1),
+	}
+	if err := c.Call(ctx, "UserManager", 3, "", "SetPassword", &args, &resp); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	if resp.Results[0].Error != nil {
+		return errors.E(op, resp.Results[0].Error)
+	}
+	return nil
+}
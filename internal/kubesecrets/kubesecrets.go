@@ -0,0 +1,197 @@
+// Copyright 2024 Canonical.
+
+// Package kubesecrets provides a store for the secrets JIMM uses to
+// connect to controllers that keeps them in Kubernetes secrets.
+package kubesecrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+const (
+	usernameKey = "username"
+	passwordKey = "password"
+	caCertKey   = "ca-cert"
+
+	// controllerAnnotation is the annotation on each secret holding the
+	// name of the controller the secret belongs to.
+	controllerAnnotation = "jimm.canonical.com/controller"
+
+	// DefaultPrefix is the prefix used for secret names if no other
+	// prefix is configured.
+	DefaultPrefix = "jimm-controller-"
+)
+
+// A SecretStore stores controller admin credentials and CA certificates
+// in Kubernetes secrets. Each controller has a single opaque secret
+// holding any of the keys "username", "password" and "ca-cert". The
+// secret is removed once it holds no keys.
+type SecretStore struct {
+	// Client is the client used to communicate with the Kubernetes API
+	// server.
+	Client kubernetes.Interface
+
+	// Namespace is the namespace in which the secrets are stored.
+	Namespace string
+
+	// Prefix is prepended to the controller name to form the name of the
+	// secret. If this is empty DefaultPrefix is used.
+	Prefix string
+}
+
+// GetControllerCredentials retrieves the credentials for the given
+// controller.
+func (s *SecretStore) GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error) {
+	const op = errors.Op("kubesecrets.GetControllerCredentials")
+
+	data, err := s.get(ctx, controllerName)
+	if err != nil {
+		return "", "", errors.E(op, err)
+	}
+	return string(data[usernameKey]), string(data[passwordKey]), nil
+}
+
+// PutControllerCredentials stores the credentials for the given
+// controller. If either the username or password is empty any stored
+// credentials are removed.
+func (s *SecretStore) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error {
+	const op = errors.Op("kubesecrets.PutControllerCredentials")
+
+	err := s.update(ctx, controllerName, func(data map[string][]byte) {
+		if username == "" || password == "" {
+			delete(data, usernameKey)
+			delete(data, passwordKey)
+			return
+		}
+		data[usernameKey] = []byte(username)
+		data[passwordKey] = []byte(password)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GetControllerCACertificate retrieves the CA certificate for the given
+// controller.
+func (s *SecretStore) GetControllerCACertificate(ctx context.Context, controllerName string) (string, error) {
+	const op = errors.Op("kubesecrets.GetControllerCACertificate")
+
+	data, err := s.get(ctx, controllerName)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return string(data[caCertKey]), nil
+}
+
+// PutControllerCACertificate stores the CA certificate for the given
+// controller. If the certificate is empty any stored certificate is
+// removed.
+func (s *SecretStore) PutControllerCACertificate(ctx context.Context, controllerName string, caCert string) error {
+	const op = errors.Op("kubesecrets.PutControllerCACertificate")
+
+	err := s.update(ctx, controllerName, func(data map[string][]byte) {
+		if caCert == "" {
+			delete(data, caCertKey)
+			return
+		}
+		data[caCertKey] = []byte(caCert)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Ping checks that the secrets in the configured namespace can be read.
+func (s *SecretStore) Ping(ctx context.Context) error {
+	const op = errors.Op("kubesecrets.Ping")
+
+	_, err := s.Client.CoreV1().Secrets(s.Namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// get returns the data held in the secret for the given controller. If
+// there is no such secret a nil map is returned.
+func (s *SecretStore) get(ctx context.Context, controllerName string) (map[string][]byte, error) {
+	secret, err := s.Client.CoreV1().Secrets(s.Namespace).Get(ctx, s.secretName(controllerName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// update applies f to the data held in the secret for the given
+// controller, creating the secret if it does not exist and deleting it if
+// f leaves it empty.
+func (s *SecretStore) update(ctx context.Context, controllerName string, f func(map[string][]byte)) error {
+	secrets := s.Client.CoreV1().Secrets(s.Namespace)
+	name := s.secretName(controllerName)
+
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		data := make(map[string][]byte)
+		f(data)
+		if len(data) == 0 {
+			return nil
+		}
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{controllerAnnotation: controllerName},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	f(secret.Data)
+	if len(secret.Data) == 0 {
+		err = secrets.Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			err = nil
+		}
+		return err
+	}
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// secretName returns the name of the secret holding the given
+// controller's secrets. Controller names that would not form a valid
+// secret name are replaced by a hash of the name.
+func (s *SecretStore) secretName(controllerName string) string {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	name := prefix + controllerName
+	if controllerName == strings.ToLower(controllerName) && len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(controllerName))
+	return prefix + hex.EncodeToString(sum[:16])
+}
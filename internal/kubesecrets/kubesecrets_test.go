@@ -0,0 +1,170 @@
+// Copyright 2024 Canonical.
+
+package kubesecrets_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/canonical/jimm/v3/internal/kubesecrets"
+)
+
+func TestControllerCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client := newFakeClient()
+	s := &kubesecrets.SecretStore{
+		Client:    client,
+		Namespace: "jimm",
+	}
+
+	username, password, err := s.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "")
+	c.Check(password, qt.Equals, "")
+
+	err = s.PutControllerCredentials(ctx, "controller-1", "admin", "secret")
+	c.Assert(err, qt.IsNil)
+	err = s.PutControllerCACertificate(ctx, "controller-1", "CA CERT")
+	c.Assert(err, qt.IsNil)
+
+	secret, err := client.CoreV1().Secrets("jimm").Get(ctx, "jimm-controller-controller-1", metav1.GetOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Check(secret.Annotations["jimm.canonical.com/controller"], qt.Equals, "controller-1")
+	c.Check(secret.Data, qt.DeepEquals, map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+		"ca-cert":  []byte("CA CERT"),
+	})
+
+	username, password, err = s.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "secret")
+	caCert, err := s.GetControllerCACertificate(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(caCert, qt.Equals, "CA CERT")
+
+	err = s.PutControllerCredentials(ctx, "controller-1", "", "")
+	c.Assert(err, qt.IsNil)
+	username, password, err = s.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "")
+	c.Check(password, qt.Equals, "")
+	caCert, err = s.GetControllerCACertificate(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(caCert, qt.Equals, "CA CERT")
+
+	err = s.PutControllerCACertificate(ctx, "controller-1", "")
+	c.Assert(err, qt.IsNil)
+	_, err = client.CoreV1().Secrets("jimm").Get(ctx, "jimm-controller-controller-1", metav1.GetOptions{})
+	c.Check(apierrors.IsNotFound(err), qt.IsTrue)
+}
+
+func TestSecretName(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client := newFakeClient()
+	s := &kubesecrets.SecretStore{
+		Client:    client,
+		Namespace: "jimm",
+		Prefix:    "ctl-",
+	}
+
+	err := s.PutControllerCACertificate(ctx, "Controller_1", "CA CERT")
+	c.Assert(err, qt.IsNil)
+
+	secrets, err := client.CoreV1().Secrets("jimm").List(ctx, metav1.ListOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(secrets.Items, qt.HasLen, 1)
+	c.Check(secrets.Items[0].Name, qt.Matches, `ctl-[0-9a-f]{32}`)
+	c.Check(secrets.Items[0].Annotations["jimm.canonical.com/controller"], qt.Equals, "Controller_1")
+
+	caCert, err := s.GetControllerCACertificate(ctx, "Controller_1")
+	c.Assert(err, qt.IsNil)
+	c.Check(caCert, qt.Equals, "CA CERT")
+}
+
+// fakeClient is a kubernetes.Interface that stores secrets in memory.
+// Only the methods used by the SecretStore are implemented.
+type fakeClient struct {
+	kubernetes.Interface
+	secrets map[string]map[string]*corev1.Secret
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{secrets: make(map[string]map[string]*corev1.Secret)}
+}
+
+func (c *fakeClient) CoreV1() typedcorev1.CoreV1Interface {
+	return fakeCoreV1{client: c}
+}
+
+type fakeCoreV1 struct {
+	typedcorev1.CoreV1Interface
+	client *fakeClient
+}
+
+func (c fakeCoreV1) Secrets(namespace string) typedcorev1.SecretInterface {
+	if c.client.secrets[namespace] == nil {
+		c.client.secrets[namespace] = make(map[string]*corev1.Secret)
+	}
+	return fakeSecrets{secrets: c.client.secrets[namespace]}
+}
+
+type fakeSecrets struct {
+	typedcorev1.SecretInterface
+	secrets map[string]*corev1.Secret
+}
+
+var secretsResource = schema.GroupResource{Resource: "secrets"}
+
+func (s fakeSecrets) Get(_ context.Context, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	secret, ok := s.secrets[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(secretsResource, name)
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (s fakeSecrets) List(_ context.Context, _ metav1.ListOptions) (*corev1.SecretList, error) {
+	var l corev1.SecretList
+	for _, secret := range s.secrets {
+		l.Items = append(l.Items, *secret.DeepCopy())
+	}
+	return &l, nil
+}
+
+func (s fakeSecrets) Create(_ context.Context, secret *corev1.Secret, _ metav1.CreateOptions) (*corev1.Secret, error) {
+	if _, ok := s.secrets[secret.Name]; ok {
+		return nil, apierrors.NewAlreadyExists(secretsResource, secret.Name)
+	}
+	s.secrets[secret.Name] = secret.DeepCopy()
+	return secret, nil
+}
+
+func (s fakeSecrets) Update(_ context.Context, secret *corev1.Secret, _ metav1.UpdateOptions) (*corev1.Secret, error) {
+	if _, ok := s.secrets[secret.Name]; !ok {
+		return nil, apierrors.NewNotFound(secretsResource, secret.Name)
+	}
+	s.secrets[secret.Name] = secret.DeepCopy()
+	return secret, nil
+}
+
+func (s fakeSecrets) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	if _, ok := s.secrets[name]; !ok {
+		return apierrors.NewNotFound(secretsResource, name)
+	}
+	delete(s.secrets, name)
+	return nil
+}
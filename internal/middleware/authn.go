@@ -40,6 +40,31 @@ func AuthenticateViaCookie(next http.Handler, jimm JIMMAuthner) http.Handler {
 	})
 }
 
+// AuthenticateUserViaCookie performs browser session authentication and puts
+// the OpenFGA user for the session identity in the request's context, from
+// where it can be retrieved with IdentityFromContext.
+func AuthenticateUserViaCookie(next http.Handler, jimm JIMMAuthner) http.Handler {
+	return AuthenticateViaCookie(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		identity := auth.SessionIdentityFromContext(ctx)
+		if identity == "" {
+			zapctx.Error(ctx, "no identity found in session")
+			http.Error(w, "internal authentication error", http.StatusInternalServerError)
+			return
+		}
+
+		user, err := jimm.UserLogin(ctx, identity)
+		if err != nil {
+			zapctx.Error(ctx, "failed to get openfga user", zap.Error(err))
+			http.Error(w, "internal authentication error", http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withIdentity(ctx, user)))
+	}), jimm)
+}
+
 // Set of ReBAC Admin API endpoints that do not require authentication.
 var unauthenticatedEndpoints = map[string]struct{}{
 	"/v1/swagger.json": {},
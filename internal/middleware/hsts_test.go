@@ -0,0 +1,22 @@
+// Copyright 2024 Canonical.
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/middleware"
+)
+
+func TestHSTS(t *testing.T) {
+	c := qt.New(t)
+
+	handler := middleware.HSTS(365*24*time.Hour, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	c.Check(rr.Header().Get("Strict-Transport-Security"), qt.Equals, "max-age=31536000; includeSubDomains")
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Canonical.
+
+// Package notifications posts signed JSON webhooks describing lifecycle
+// events in JIMM, such as a model being created, to a set of endpoints
+// configured by the JIMM operator. Every delivery is recorded in the
+// database so that failed deliveries can be investigated.
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+// The lifecycle events posted to the configured endpoints.
+const (
+	// EventModelCreated is posted when a model has been created.
+	EventModelCreated = "model-created"
+
+	// EventModelDestroyed is posted when a model has been removed from
+	// its controller.
+	EventModelDestroyed = "model-destroyed"
+
+	// EventControllerUnavailable is posted when a controller that was
+	// available can no longer be contacted.
+	EventControllerUnavailable = "controller-unavailable"
+
+	// EventCredentialUpdated is posted when a cloud credential has been
+	// updated.
+	EventCredentialUpdated = "credential-updated"
+)
+
+// An Event is the JSON payload posted for a lifecycle event.
+type Event struct {
+	// Event is the name of the event, for example "model-created".
+	Event string `json:"event"`
+
+	// Time is the time at which the event occurred.
+	Time time.Time `json:"time"`
+
+	// ModelUUID is the UUID of the model the event concerns, if any.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// ModelName is the name of the model the event concerns, if any.
+	ModelName string `json:"model-name,omitempty"`
+
+	// Controller is the name of the controller the event concerns, if
+	// any.
+	Controller string `json:"controller,omitempty"`
+
+	// CloudCredential is the ID of the cloud credential the event
+	// concerns, if any.
+	CloudCredential string `json:"cloud-credential,omitempty"`
+
+	// Identity is the name of the identity that caused the event, if
+	// any.
+	Identity string `json:"identity,omitempty"`
+}
+
+// An Endpoint is an address to which lifecycle events are posted.
+type Endpoint struct {
+	// URL is the address to which events are posted.
+	URL string
+
+	// Secret is the key used to sign the events posted to the
+	// endpoint.
+	Secret []byte
+}
+
+// A Dispatcher posts lifecycle events to every configured endpoint. A nil
+// Dispatcher, or one with no endpoints, discards all events.
+type Dispatcher struct {
+	// Endpoints holds the addresses events are posted to.
+	Endpoints []Endpoint
+
+	// Sender delivers the events, retrying failed deliveries.
+	Sender *webhook.Sender
+
+	// Database, if set, records the outcome of every delivery.
+	Database *db.Database
+}
+
+// Dispatch posts the given event to every endpoint. If the event time
+// is not set the current time is used. Dispatch does not block, events
+// are delivered in the background.
+func (d *Dispatcher) Dispatch(ctx context.Context, e Event) {
+	if d == nil || len(d.Endpoints) == 0 {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		zapctx.Error(ctx, "cannot marshal lifecycle event", zap.Error(err))
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, ep := range d.Endpoints {
+		ep := ep
+		delivery := dbmodel.WebhookDelivery{
+			Event:   e.Event,
+			URL:     ep.URL,
+			Payload: payload,
+			Status:  dbmodel.WebhookDeliveryPending,
+		}
+		if d.Database != nil {
+			if err := d.Database.AddWebhookDelivery(ctx, &delivery); err != nil {
+				zapctx.Warn(ctx, "cannot record webhook delivery", zap.Error(err))
+			}
+		}
+		go d.deliver(ctx, ep, &delivery)
+	}
+}
+
+// deliver sends the given delivery to its endpoint and records the
+// outcome.
+func (d *Dispatcher) deliver(ctx context.Context, ep Endpoint, delivery *dbmodel.WebhookDelivery) {
+	sender := d.Sender
+	if sender == nil {
+		sender = &webhook.Sender{}
+	}
+	err := sender.Send(ctx, ep.URL, delivery.Event, ep.Secret, delivery.Payload)
+	if err != nil {
+		zapctx.Warn(ctx, "lifecycle webhook delivery failed", zap.String("url", ep.URL), zap.String("event", delivery.Event), zap.Error(err))
+		delivery.Status = dbmodel.WebhookDeliveryFailed
+		delivery.Error = err.Error()
+	} else {
+		delivery.Status = dbmodel.WebhookDeliveryDelivered
+		delivery.DeliveredAt = db.Now()
+	}
+	if d.Database == nil || delivery.ID == 0 {
+		return
+	}
+	if err := d.Database.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		zapctx.Warn(ctx, "cannot record webhook delivery", zap.Error(err))
+	}
+}
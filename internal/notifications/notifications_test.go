@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical.
+
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/notifications"
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+func TestDispatch(t *testing.T) {
+	c := qt.New(t)
+
+	secret := []byte("test-secret")
+	events := make(chan notifications.Event, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(req.Header.Get(webhook.EventHeader), qt.Equals, notifications.EventModelCreated)
+		c.Check(webhook.Verify(secret, req.Header, body, time.Now(), 0), qt.IsNil)
+		var e notifications.Event
+		c.Check(json.Unmarshal(body, &e), qt.IsNil)
+		events <- e
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d := notifications.Dispatcher{
+		Endpoints: []notifications.Endpoint{{
+			URL:    srv.URL + "/a",
+			Secret: secret,
+		}, {
+			URL:    srv.URL + "/b",
+			Secret: secret,
+		}},
+		Sender: &webhook.Sender{Backoff: time.Millisecond, Unrestricted: true},
+	}
+	d.Dispatch(context.Background(), notifications.Event{
+		Event:     notifications.EventModelCreated,
+		ModelUUID: "00000002-0000-0000-0000-000000000001",
+		ModelName: "model-1",
+		Identity:  "alice@canonical.com",
+	})
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-events:
+			c.Check(e.Event, qt.Equals, notifications.EventModelCreated)
+			c.Check(e.ModelName, qt.Equals, "model-1")
+			c.Check(e.Time.IsZero(), qt.IsFalse)
+		case <-time.After(5 * time.Second):
+			c.Fatal("timed out waiting for event")
+		}
+	}
+}
+
+func TestDispatchNilDispatcher(t *testing.T) {
+	var d *notifications.Dispatcher
+	d.Dispatch(context.Background(), notifications.Event{Event: notifications.EventModelCreated})
+}
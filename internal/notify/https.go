@@ -0,0 +1,45 @@
+// Copyright 2024 Canonical.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+// An HTTPSTransport delivers notifications by posting them, encoded as
+// JSON, to an https URL. Requests are signed in the same way as model
+// webhooks and the notification kind is sent in the webhook.EventHeader
+// header.
+type HTTPSTransport struct {
+	// Sender delivers the notifications.
+	Sender *webhook.Sender
+
+	// Secret is used to sign the notifications.
+	Secret []byte
+}
+
+// ValidateDestination implements Transport.
+func (t *HTTPSTransport) ValidateDestination(destination string) error {
+	return validateHTTPSURL(destination)
+}
+
+// Send implements Transport.
+func (t *HTTPSTransport) Send(ctx context.Context, destination string, n Notification) error {
+	const op = errors.Op("notify.HTTPSTransport.Send")
+
+	if err := t.ValidateDestination(destination); err != nil {
+		return errors.E(op, err)
+	}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := t.Sender.Send(ctx, destination, n.Kind, t.Secret, payload); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+// Package notify delivers notifications about events that need the
+// attention of JIMM users, such as approaching a usage limit, through
+// pluggable transports.
+package notify
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// The kinds of notification sent by JIMM.
+const (
+	// KindCredentialExpiry is sent when a cloud credential used by a
+	// model is no longer valid, for example because it has expired.
+	KindCredentialExpiry = "credential-expiry"
+
+	// KindUsageAlert is sent when a user approaches one of their
+	// resource limits.
+	KindUsageAlert = "usage-alert"
+
+	// KindMigrationComplete is sent when a model has been migrated to
+	// another controller.
+	KindMigrationComplete = "migration-complete"
+
+	// KindControllerMaintenance is sent when a user creates a model on
+	// a controller that has a current or upcoming maintenance window.
+	KindControllerMaintenance = "controller-maintenance"
+)
+
+// Kinds holds all the kinds of notification sent by JIMM.
+var Kinds = []string{KindCredentialExpiry, KindUsageAlert, KindMigrationComplete, KindControllerMaintenance}
+
+// The names of the built-in transports.
+const (
+	TransportEmail = "email"
+	TransportSlack = "slack"
+	TransportHTTPS = "https"
+)
+
+// A Notification is a message sent to a user.
+type Notification struct {
+	// Kind is the kind of notification, one of Kinds.
+	Kind string `json:"kind"`
+
+	// Subject is a short summary of the notification.
+	Subject string `json:"subject"`
+
+	// Message is the body of the notification.
+	Message string `json:"message"`
+
+	// Recipient is the name of the identity the notification is for.
+	Recipient string `json:"recipient"`
+
+	// Time is the time at which the notification was raised.
+	Time time.Time `json:"time"`
+}
+
+// A Transport delivers notifications to destinations of a single type,
+// such as email addresses.
+type Transport interface {
+	// ValidateDestination checks that the given destination can be used
+	// with the transport.
+	ValidateDestination(destination string) error
+
+	// Send delivers the notification to the given destination.
+	Send(ctx context.Context, destination string, n Notification) error
+}
+
+// validateHTTPSURL checks that the given destination is an https URL.
+func validateHTTPSURL(destination string) error {
+	u, err := url.Parse(destination)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.E(errors.CodeBadRequest, "destination must be an https URL")
+	}
+	return nil
+}
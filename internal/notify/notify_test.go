@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/notify"
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+var testNotification = notify.Notification{
+	Kind:      notify.KindUsageAlert,
+	Subject:   "Approaching models limit",
+	Message:   "You are using 8 of your limit of 10 models.",
+	Recipient: "alice@canonical.com",
+	Time:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestSlackTransport(t *testing.T) {
+	c := qt.New(t)
+
+	var text string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		c.Check(json.NewDecoder(req.Body).Decode(&body), qt.IsNil)
+		text = body.Text
+	}))
+	defer srv.Close()
+
+	tr := notify.SlackTransport{Client: srv.Client()}
+	c.Check(tr.ValidateDestination(srv.URL), qt.IsNil)
+	c.Check(tr.ValidateDestination("http://hooks.slack.com/services/x"), qt.ErrorMatches, `destination must be an https URL`)
+
+	err := tr.Send(context.Background(), srv.URL, testNotification)
+	c.Assert(err, qt.IsNil)
+	c.Check(text, qt.Equals, "*Approaching models limit*\nYou are using 8 of your limit of 10 models.")
+}
+
+func TestSlackTransportError(t *testing.T) {
+	c := qt.New(t)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	tr := notify.SlackTransport{Client: srv.Client()}
+	err := tr.Send(context.Background(), srv.URL, testNotification)
+	c.Check(err, qt.ErrorMatches, `slack returned status 403`)
+}
+
+func TestHTTPSTransport(t *testing.T) {
+	c := qt.New(t)
+
+	secret := []byte("test-secret")
+	var got notify.Notification
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(req.Header.Get(webhook.EventHeader), qt.Equals, notify.KindUsageAlert)
+		c.Check(webhook.Verify(secret, req.Header, body, time.Now(), 0), qt.IsNil)
+		c.Check(json.Unmarshal(body, &got), qt.IsNil)
+	}))
+	defer srv.Close()
+
+	tr := notify.HTTPSTransport{
+		Sender: &webhook.Sender{
+			Client:  srv.Client(),
+			Backoff: time.Millisecond,
+			// The test server listens on a loopback address.
+			AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+		},
+		Secret: secret,
+	}
+	err := tr.Send(context.Background(), srv.URL, testNotification)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, testNotification)
+
+	err = tr.Send(context.Background(), "http://example.com", testNotification)
+	c.Check(err, qt.ErrorMatches, `destination must be an https URL`)
+}
+
+func TestSMTPTransportValidateDestination(t *testing.T) {
+	c := qt.New(t)
+
+	var tr notify.SMTPTransport
+	c.Check(tr.ValidateDestination("alice@canonical.com"), qt.IsNil)
+	c.Check(tr.ValidateDestination("Alice <alice@canonical.com>"), qt.ErrorMatches, `destination must be an email address`)
+	c.Check(tr.ValidateDestination("alice@canonical.com\r\nBcc: bob@canonical.com"), qt.ErrorMatches, `destination must be an email address`)
+	c.Check(tr.ValidateDestination("not-an-address"), qt.ErrorMatches, `destination must be an email address`)
+}
+
+func TestFormatEmail(t *testing.T) {
+	c := qt.New(t)
+
+	n := testNotification
+	n.Subject = "Approaching\r\nBcc: bob@canonical.com"
+	msg := notify.FormatEmail("jimm@canonical.com", "alice@canonical.com", n)
+	c.Check(string(msg), qt.Equals, "From: jimm@canonical.com\r\n"+
+		"To: alice@canonical.com\r\n"+
+		"Subject: Approaching Bcc: bob@canonical.com\r\n"+
+		"Date: Wed, 01 May 2024 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"\r\n"+
+		"You are using 8 of your limit of 10 models.\r\n")
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Canonical.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// A SlackTransport delivers notifications to Slack. Destinations are
+// Slack incoming webhook URLs.
+type SlackTransport struct {
+	// Client is the HTTP client used to post messages. If this is nil
+	// a client with a short timeout is used.
+	Client *http.Client
+}
+
+// ValidateDestination implements Transport.
+func (t *SlackTransport) ValidateDestination(destination string) error {
+	return validateHTTPSURL(destination)
+}
+
+// Send implements Transport.
+func (t *SlackTransport) Send(ctx context.Context, destination string, n Notification) error {
+	const op = errors.Op("notify.SlackTransport.Send")
+
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	payload, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{
+		Text: fmt.Sprintf("*%s*\n%s", n.Subject, n.Message),
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination, bytes.NewReader(payload))
+	if err != nil {
+		return errors.E(op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.E(op, fmt.Sprintf("slack returned status %d", resp.StatusCode))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// An SMTPTransport delivers notifications by email. Destinations are
+// email addresses.
+type SMTPTransport struct {
+	// Addr is the host:port address of the SMTP server.
+	Addr string
+
+	// From is the address from which notifications are sent.
+	From string
+
+	// Username and Password, if set, are used to authenticate with the
+	// SMTP server using the PLAIN mechanism, which net/smtp only allows
+	// over TLS or to localhost.
+	Username string
+	Password string
+}
+
+// ValidateDestination implements Transport.
+func (t *SMTPTransport) ValidateDestination(destination string) error {
+	addr, err := mail.ParseAddress(destination)
+	if err != nil || addr.Address != destination {
+		return errors.E(errors.CodeBadRequest, "destination must be an email address")
+	}
+	return nil
+}
+
+// Send implements Transport. The SMTP exchange cannot be cancelled, so
+// the context is only checked before sending.
+func (t *SMTPTransport) Send(ctx context.Context, destination string, n Notification) error {
+	const op = errors.Op("notify.SMTPTransport.Send")
+
+	if err := ctx.Err(); err != nil {
+		return errors.E(op, err)
+	}
+	if err := t.ValidateDestination(destination); err != nil {
+		return errors.E(op, err)
+	}
+	var auth smtp.Auth
+	if t.Username != "" {
+		host, _, err := net.SplitHostPort(t.Addr)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		auth = smtp.PlainAuth("", t.Username, t.Password, host)
+	}
+	if err := smtp.SendMail(t.Addr, auth, t.From, []string{destination}, formatEmail(t.From, destination, n)); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// formatEmail returns the email message for the given notification.
+func formatEmail(from, to string, n Notification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", singleLine(n.Subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", n.Time.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(n.Message, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// singleLine replaces any line breaks in s, so that it cannot be used to
+// inject headers.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+package openfga_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestFakeOpenFGAServer(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	srv := jimmtest.NewFakeOpenFGAServer()
+	defer srv.Close()
+	client, _, _, err := srv.NewClient(ctx)
+	c.Assert(err, qt.IsNil)
+
+	group := jimmnames.NewGroupTag(uuid.NewString())
+	controller := names.NewControllerTag(uuid.NewString())
+	model := names.NewModelTag(uuid.NewString())
+	otherModel := names.NewModelTag(uuid.NewString())
+
+	err = client.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("eve")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group),
+	}, openfga.Tuple{
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(controller),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(client.AddControllerModel(ctx, controller, model), qt.IsNil)
+	c.Assert(client.AddControllerModel(ctx, controller, otherModel), qt.IsNil)
+
+	newUser := func(name string) *openfga.User {
+		i, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		return openfga.NewUser(i, client)
+	}
+	eve := newUser("eve")
+	alice := newUser("alice")
+	bob := newUser("bob")
+
+	// Access is derived through groups and the controller.
+	c.Check(eve.GetModelAccess(ctx, model), qt.Equals, ofganames.AdministratorRelation)
+
+	// Setting access is idempotent.
+	c.Assert(alice.SetModelAccess(ctx, model, ofganames.WriterRelation), qt.IsNil)
+	c.Assert(alice.SetModelAccess(ctx, model, ofganames.WriterRelation), qt.IsNil)
+	c.Check(alice.GetModelAccess(ctx, model), qt.Equals, ofganames.WriterRelation)
+	ok, err := alice.IsModelReader(ctx, model)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsTrue)
+	c.Check(bob.GetModelAccess(ctx, model), qt.Equals, ofganames.NoRelation)
+
+	// Access granted to everyone applies to every user.
+	everyone := newUser(ofganames.EveryoneUser)
+	c.Assert(everyone.SetModelAccess(ctx, otherModel, ofganames.ReaderRelation), qt.IsNil)
+	c.Check(bob.GetModelAccess(ctx, otherModel), qt.Equals, ofganames.ReaderRelation)
+
+	models, err := client.ListObjects(ctx, ofganames.ConvertTag(names.NewUserTag("eve")), ofganames.ReaderRelation, "model", nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 2)
+	models, err = client.ListObjects(ctx, ofganames.ConvertTag(names.NewUserTag("alice")), ofganames.WriterRelation, "model", nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.DeepEquals, []ofganames.Tag{*ofganames.ConvertTag(model)})
+
+	// Removing a resource removes every tuple that refers to it.
+	c.Assert(client.RemoveGroup(ctx, group), qt.IsNil)
+	c.Check(eve.GetModelAccess(ctx, model), qt.Equals, ofganames.NoRelation)
+	tuples, _, err := client.ReadRelatedObjects(ctx, openfga.Tuple{Target: ofganames.ConvertTag(group)}, 10, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(tuples, qt.HasLen, 0)
+
+	// Unsetting access that is not set is not an error.
+	c.Assert(alice.UnsetModelAccess(ctx, model, ofganames.WriterRelation), qt.IsNil)
+	c.Assert(alice.UnsetModelAccess(ctx, model, ofganames.WriterRelation), qt.IsNil)
+	c.Check(alice.GetModelAccess(ctx, model), qt.Equals, ofganames.NoRelation)
+}
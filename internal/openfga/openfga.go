@@ -17,7 +17,7 @@ import (
 
 var (
 	// resourceTypes contains a list of all resource kinds (i.e. tags) used throughout JIMM.
-	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind}
+	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, names.CloudTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind}
 )
 
 // Tuple represents a relation between an object and a target.
@@ -168,6 +168,13 @@ func (o *OFGAClient) listObjects(ctx context.Context, user *Tag, relation Relati
 	return entities, nil
 }
 
+// Ping checks that OpenFGA can be reached and that the configured
+// authorisation model exists.
+func (o *OFGAClient) Ping(ctx context.Context) error {
+	_, err := o.cofgaClient.GetAuthModel(ctx, o.cofgaClient.AuthModelID())
+	return err
+}
+
 // AddRelation adds given relations (tuples).
 func (o *OFGAClient) AddRelation(ctx context.Context, tuples ...Tuple) (err error) {
 	op := errors.Op("openfga.AddRelation")
@@ -340,6 +347,60 @@ func (o *OFGAClient) RemoveGroup(ctx context.Context, group jimmnames.GroupTag)
 	return nil
 }
 
+// RemoveUser removes all access that a user has and returns the tuples
+// that were removed.
+func (o *OFGAClient) RemoveUser(ctx context.Context, user names.UserTag) ([]Tuple, error) {
+	var removed []Tuple
+	// The OpenFGA Read API requires the target type to be specified
+	// along with the user, so each resource type is read in turn.
+	for _, kind := range resourceTypes {
+		kt, err := ofganames.BlankKindTag(kind)
+		if err != nil {
+			return removed, errors.E(err)
+		}
+		var tuples []Tuple
+		var ct string
+		for {
+			page, next, err := o.ReadRelatedObjects(ctx, Tuple{
+				Object: ofganames.ConvertTag(user),
+				Target: kt,
+			}, 50, ct)
+			if err != nil {
+				return removed, errors.E(err)
+			}
+			tuples = append(tuples, page...)
+			if next == "" {
+				break
+			}
+			ct = next
+		}
+		for len(tuples) > 0 {
+			n := min(len(tuples), 50)
+			if err := o.RemoveRelation(ctx, tuples[:n]...); err != nil {
+				return removed, errors.E(err)
+			}
+			removed = append(removed, tuples[:n]...)
+			tuples = tuples[n:]
+		}
+	}
+	return removed, nil
+}
+
+// RemoveServiceAccount removes the relations that grant identities and
+// groups access to administer a service account. The access the service
+// account itself has is removed with RemoveUser.
+func (o *OFGAClient) RemoveServiceAccount(ctx context.Context, svcAcc jimmnames.ServiceAccountTag) error {
+	if err := o.removeTuples(
+		ctx,
+		Tuple{
+			Target: ofganames.ConvertTag(svcAcc),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
 // RemoveCloud removes a cloud.
 func (o *OFGAClient) RemoveCloud(ctx context.Context, cloud names.CloudTag) error {
 	if err := o.removeTuples(
@@ -214,6 +214,12 @@ func (u *User) SetControllerAccess(ctx context.Context, resource names.Controlle
 	return u.client.setResourceAccess(ctx, u.ResourceTag(), resource, relation)
 }
 
+// UnsetControllerAccess removes direct relations between the user and the controller.
+// Note that the action is idempotent (i.e., does not return error if the relation does not exist).
+func (u *User) UnsetControllerAccess(ctx context.Context, resource names.ControllerTag, relations ...Relation) error {
+	return unsetMultipleResourceAccesses(ctx, u, resource, relations, 0)
+}
+
 // UnsetAuditLogViewerAccess removes a direct audit log viewer relation between the user and a controller.
 // Note that the action is idempotent (i.e., does not return error if the relation does not exist).
 func (u *User) UnsetAuditLogViewerAccess(ctx context.Context, resource names.ControllerTag) error {
@@ -0,0 +1,114 @@
+// Copyright 2024 Canonical.
+
+// Package pgnotify provides a notification bus, built on the Postgres
+// LISTEN and NOTIFY commands, that JIMM replicas sharing a database use
+// to tell each other about changes to shared state.
+package pgnotify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// DefaultChannel is the channel used if no other channel is configured.
+const DefaultChannel = "jimm_cache_invalidation"
+
+// defaultReconnectDelay is the delay before reconnecting if no other
+// delay is configured.
+const defaultReconnectDelay = 5 * time.Second
+
+// A Bus sends and receives notifications on a Postgres channel. A
+// notification sent on the bus is received by every listener on the
+// channel, including any on the sending replica.
+type Bus struct {
+	// DB is the database used to send notifications.
+	DB *gorm.DB
+
+	// DSN is the data source name used to open the dedicated connection
+	// on which notifications are received. A "pgx:" prefix is ignored.
+	DSN string
+
+	// Channel is the name of the Postgres channel. If this is empty
+	// DefaultChannel is used.
+	Channel string
+
+	// ReconnectDelay is the time to wait before reconnecting after the
+	// listening connection fails. If this is zero a default delay is
+	// used.
+	ReconnectDelay time.Duration
+}
+
+// Notify sends a notification with the given payload on the bus. The
+// notification is delivered when the current transaction, if any,
+// commits.
+func (b *Bus) Notify(ctx context.Context, payload string) error {
+	const op = errors.Op("pgnotify.Notify")
+
+	if err := b.DB.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel(), payload).Error; err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Listen receives notifications on the bus, calling f with the payload
+// of each one, until the given context is cancelled. If the listening
+// connection fails Listen reconnects. Notifications sent while there is
+// no connection are lost, so after every connection is established f is
+// called with an empty payload to indicate that any state derived from
+// notifications should be discarded.
+func (b *Bus) Listen(ctx context.Context, f func(payload string)) error {
+	delay := b.ReconnectDelay
+	if delay == 0 {
+		delay = defaultReconnectDelay
+	}
+	for {
+		err := b.listen(ctx, f)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		zapctx.Warn(ctx, "notification listener failed", zap.String("channel", b.channel()), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// listen opens a connection and receives notifications on it until it
+// fails or the given context is cancelled.
+func (b *Bus) listen(ctx context.Context, f func(payload string)) error {
+	conn, err := pgx.Connect(ctx, strings.TrimPrefix(b.DSN, "pgx:"))
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel()}.Sanitize()); err != nil {
+		return err
+	}
+	zapctx.Debug(ctx, "listening for notifications", zap.String("channel", b.channel()))
+	f("")
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		f(n.Payload)
+	}
+}
+
+func (b *Bus) channel() string {
+	if b.Channel == "" {
+		return DefaultChannel
+	}
+	return b.Channel
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/utils/v2/parallel"
 
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
 // HandlerFunc takes two arguments - a model ID and the message about this model.
@@ -52,6 +53,7 @@ func (h *Hub) Publish(model string, content interface{}) <-chan struct{} {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	servermon.PubsubMessagesPublishedCount.Inc()
 	done := make(chan struct{})
 	wait := sync.WaitGroup{}
 
@@ -114,6 +116,7 @@ func (h *Hub) SubscribeMatch(modelMatcher func(string) bool, handler HandlerFunc
 		h.subscribers = make(map[int]subscriber)
 	}
 	h.subscribers[idx] = s
+	servermon.PubsubSubscribers.Inc()
 
 	// check if there are any messages for matching models and
 	// call the handler function if appropriate.
@@ -128,7 +131,10 @@ func (h *Hub) SubscribeMatch(modelMatcher func(string) bool, handler HandlerFunc
 	return func() {
 		h.mu.Lock()
 		defer h.mu.Unlock()
-		delete(h.subscribers, idx)
+		if _, ok := h.subscribers[idx]; ok {
+			delete(h.subscribers, idx)
+			servermon.PubsubSubscribers.Dec()
+		}
 	}, nil
 }
 
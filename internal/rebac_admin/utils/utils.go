@@ -4,6 +4,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
@@ -20,13 +21,24 @@ func FromUserToIdentity(user openfga.User) resources.Identity {
 	id := fmt.Sprintf("%d", user.ID)
 	joined := user.CreatedAt.Format(time.RFC3339)
 	lastLogin := user.LastLogin.Time.Format(time.RFC3339)
-	return resources.Identity{
+	identity := resources.Identity{
 		Email:     user.Name,
 		Id:        &id,
 		Joined:    &joined,
 		LastLogin: &lastLogin,
 		Source:    "",
 	}
+	// Names are only reported once they have been synchronised from the
+	// identity provider, before then the display name is derived from
+	// the identity's name.
+	if user.ProfileSyncedAt.Valid && user.DisplayName != "" {
+		firstName, lastName, _ := strings.Cut(user.DisplayName, " ")
+		identity.FirstName = &firstName
+		if lastName != "" {
+			identity.LastName = &lastName
+		}
+	}
+	return identity
 }
 
 // ToRebacResource parses db.Resource into resources.Resource.
@@ -57,6 +57,7 @@ type WebsocketConnectionWithMetadata struct {
 	Conn           WebsocketConnection
 	ControllerUUID string
 	ModelName      string
+	ModelUUID      string
 }
 
 // LoginService represents the LoginService interface used by the proxy.
@@ -78,6 +79,19 @@ type ProxyHelpers struct {
 	AuditLog                func(*dbmodel.AuditLogEntry)
 	LoginService            LoginService
 	AuthenticatedIdentityID string
+
+	// CheckModelConfig, if set, is called with the model config values
+	// set and the keys unset by ModelConfig facade calls before they
+	// are sent to the controller. If it returns an error the call is
+	// not sent and the error is returned to the client.
+	CheckModelConfig func(ctx context.Context, set map[string]any, unset []string) error
+
+	// CharmHubCache, if set, caches the responses to charm metadata
+	// requests.
+	CharmHubCache *CharmHubCache
+
+	// Branding, if set, is returned to users starting a device login.
+	Branding *apiparams.Branding
 }
 
 // ProxySockets will proxy requests from a client connection through to a controller
@@ -111,7 +125,10 @@ func ProxySockets(ctx context.Context, helpers ProxyHelpers) error {
 			conversationId:          utils.NewConversationID(),
 			loginService:            helpers.LoginService,
 			authenticatedIdentityID: helpers.AuthenticatedIdentityID,
+			charmHubCache:           helpers.CharmHubCache,
+			branding:                helpers.Branding,
 		},
+		checkModelConfig:     helpers.CheckModelConfig,
 		errChan:              errChan,
 		createControllerConn: helpers.ConnectController,
 	}
@@ -245,8 +262,11 @@ type modelProxy struct {
 	tokenGen                TokenGenerator
 	loginService            LoginService
 	modelName               string
+	modelUUID               string
 	conversationId          string
 	authenticatedIdentityID string
+	charmHubCache           *CharmHubCache
+	branding                *apiparams.Branding
 
 	deviceOAuthResponse *oauth2.DeviceAuthResponse
 }
@@ -275,6 +295,7 @@ func (p *modelProxy) auditLogMessage(msg *message, isResponse bool) error {
 		MessageId:      msg.RequestID,
 		IdentityTag:    p.tokenGen.GetUser().String(),
 		Model:          p.modelName,
+		ModelUUID:      p.modelUUID,
 		ConversationId: p.conversationId,
 		FacadeName:     msg.Type,
 		FacadeMethod:   msg.Request,
@@ -331,6 +352,7 @@ type clientProxy struct {
 	errChan              chan error
 	createControllerConn func(context.Context) (WebsocketConnectionWithMetadata, error)
 	connectController    sync.Once
+	checkModelConfig     func(ctx context.Context, set map[string]any, unset []string) error
 }
 
 // start begins the client->controller proxier.
@@ -360,6 +382,22 @@ func (p *clientProxy) start(ctx context.Context) error {
 		if err := p.auditLogMessage(msg, false); err != nil {
 			zapctx.Error(ctx, "failed to audit log message", zap.Error(err))
 		}
+		if err := p.checkModelConfigPolicy(ctx, msg); err != nil {
+			p.sendError(p.src, msg, err)
+			continue
+		}
+		// Charm metadata requests are answered from the cache once the
+		// client has logged in.
+		if p.msgs.getLoginMessage() != nil {
+			if response, ok := p.charmHubCache.get(p.msgs.controllerUUID, msg); ok {
+				resp := &message{RequestID: msg.RequestID, Response: response}
+				p.src.sendMessage(nil, resp)
+				if err := p.auditLogMessage(resp, true); err != nil {
+					zapctx.Error(ctx, "failed to audit log message", zap.Error(err))
+				}
+				continue
+			}
+		}
 		// All requests should be proxied as transparently as possible through to the controller
 		// except for auth related requests like Login because JIMM is auth gateway.
 		if msg.Type == "Admin" {
@@ -391,6 +429,29 @@ func (p *clientProxy) start(ctx context.Context) error {
 	}
 }
 
+// checkModelConfigPolicy checks the model config changed by a
+// ModelConfig facade call against the model config policies.
+func (p *clientProxy) checkModelConfigPolicy(ctx context.Context, msg *message) error {
+	if p.checkModelConfig == nil || msg.Type != "ModelConfig" {
+		return nil
+	}
+	switch msg.Request {
+	case "ModelSet":
+		var args params.ModelSet
+		if err := json.Unmarshal(msg.Params, &args); err != nil {
+			return errors.E(errors.CodeBadRequest, err)
+		}
+		return p.checkModelConfig(ctx, args.Config, nil)
+	case "ModelUnset":
+		var args params.ModelUnset
+		if err := json.Unmarshal(msg.Params, &args); err != nil {
+			return errors.E(errors.CodeBadRequest, err)
+		}
+		return p.checkModelConfig(ctx, nil, args.Keys)
+	}
+	return nil
+}
+
 // makeControllerConnection dials a controller and starts a go routine for
 // proxying requests from the controller to the client.
 func (p *clientProxy) makeControllerConnection(ctx context.Context) error {
@@ -405,6 +466,7 @@ func (p *clientProxy) makeControllerConnection(ctx context.Context) error {
 
 		p.msgs.controllerUUID = connWithMetadata.ControllerUUID
 		p.modelName = connWithMetadata.ModelName
+		p.modelUUID = connWithMetadata.ModelUUID
 		p.dst = &writeLockConn{conn: connWithMetadata.Conn}
 		controllerToClient := controllerProxy{
 			modelProxy: modelProxy{
@@ -414,7 +476,9 @@ func (p *clientProxy) makeControllerConnection(ctx context.Context) error {
 				auditLog:       p.auditLog,
 				tokenGen:       p.tokenGen,
 				modelName:      p.modelName,
+				modelUUID:      p.modelUUID,
 				conversationId: p.conversationId,
+				charmHubCache:  p.charmHubCache,
 			},
 		}
 		p.wg.Add(1)
@@ -474,6 +538,9 @@ func (p *controllerProxy) start(ctx context.Context) error {
 				return fmt.Errorf("error modifying controller response: %w", err)
 			}
 		}
+		if msg.Error == "" {
+			p.charmHubCache.put(p.msgs.controllerUUID, p.msgs.getMessage(msg.RequestID), msg.Response)
+		}
 		p.msgs.removeMessage(msg.RequestID)
 		if err := p.auditLogMessage(msg, true); err != nil {
 			zapctx.Error(context.Background(), "failed to audit log message", zap.Error(err))
@@ -648,6 +715,7 @@ func (p *clientProxy) handleAdminFacade(ctx context.Context, msg *message) (clie
 		data, err := json.Marshal(apiparams.LoginDeviceResponse{
 			VerificationURI: deviceResponse.VerificationURI,
 			UserCode:        deviceResponse.UserCode,
+			Branding:        p.branding,
 		})
 		if err != nil {
 			return errorFnc(err)
@@ -69,6 +69,7 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 		expectedClientResponse    *message
 		expectedControllerMessage *message
 		oauthAuthenticatorError   error
+		branding                  *apiparams.Branding
 	}{{
 		about: "login device call - client gets response with both user code and verification uri",
 		messageToSend: message{
@@ -94,6 +95,22 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 			Error:     "a silly error",
 		},
 		oauthAuthenticatorError: errors.E("a silly error"),
+	}, {
+		about: "login device call with branding - client gets response with the branding",
+		messageToSend: message{
+			RequestID: 1,
+			Type:      "Admin",
+			Version:   4,
+			Request:   "LoginDevice",
+		},
+		branding: &apiparams.Branding{
+			OrganisationName: "Example Org",
+			MessageOfTheDay:  "Maintenance on Saturday.",
+		},
+		expectedClientResponse: &message{
+			RequestID: 1,
+			Response:  []byte(`{"verification-uri":"http://no-such-uri.canonical.com","user-code":"test-user-code","branding":{"organisation-name":"Example Org","message-of-the-day":"Maintenance on Saturday."}}`),
+		},
 	}, {
 		about: "get device session token call - client gets response with a session token",
 		messageToSend: message{
@@ -229,6 +246,50 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 			ErrorCode: "unauthorized access",
 		},
 		oauthAuthenticatorError: errors.E(errors.CodeUnauthorized),
+	}, {
+		about: "model config set violating a policy - client gets an error",
+		messageToSend: message{
+			RequestID: 1,
+			Type:      "ModelConfig",
+			Version:   3,
+			Request:   "ModelSet",
+			Params:    []byte(`{"config":{"firewall-mode":"none"}}`),
+		},
+		expectedClientResponse: &message{
+			RequestID: 1,
+			Error:     "firewall-mode cannot be set",
+			ErrorCode: "policy violation",
+		},
+	}, {
+		about: "model config unset violating a policy - client gets an error",
+		messageToSend: message{
+			RequestID: 1,
+			Type:      "ModelConfig",
+			Version:   3,
+			Request:   "ModelUnset",
+			Params:    []byte(`{"keys":["logging-config"]}`),
+		},
+		expectedClientResponse: &message{
+			RequestID: 1,
+			Error:     "logging-config cannot be unset",
+			ErrorCode: "policy violation",
+		},
+	}, {
+		about: "model config set within the policies - gets forwarded to the controller",
+		messageToSend: message{
+			RequestID: 1,
+			Type:      "ModelConfig",
+			Version:   3,
+			Request:   "ModelSet",
+			Params:    []byte(`{"config":{"logging-config":"<root>=DEBUG"}}`),
+		},
+		expectedControllerMessage: &message{
+			RequestID: 1,
+			Type:      "ModelConfig",
+			Version:   3,
+			Request:   "ModelSet",
+			Params:    []byte(`{"config":{"logging-config":"<root>=DEBUG"}}`),
+		},
 	}}
 
 	for _, test := range tests {
@@ -258,6 +319,8 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 				AuditLog:                func(*dbmodel.AuditLogEntry) {},
 				LoginService:            loginSvc,
 				AuthenticatedIdentityID: test.authenticateEntityID,
+				CheckModelConfig:        checkModelConfig,
+				Branding:                test.branding,
 			}
 			var wg sync.WaitGroup
 			wg.Add(1)
@@ -293,6 +356,20 @@ func TestProxySocketsAdminFacade(t *testing.T) {
 	}
 }
 
+// checkModelConfig forbids setting firewall-mode and unsetting
+// logging-config.
+func checkModelConfig(ctx context.Context, set map[string]any, unset []string) error {
+	if _, ok := set["firewall-mode"]; ok {
+		return errors.E(errors.CodePolicyViolation, "firewall-mode cannot be set")
+	}
+	for _, k := range unset {
+		if k == "logging-config" {
+			return errors.E(errors.CodePolicyViolation, "logging-config cannot be unset")
+		}
+	}
+	return nil
+}
+
 type mockLoginService struct {
 	err          error
 	email        string
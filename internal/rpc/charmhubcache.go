@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical.
+
+package rpc
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+const (
+	// DefaultCharmHubCacheTTL is the default time for which cached
+	// charm metadata responses are used.
+	DefaultCharmHubCacheTTL = 10 * time.Minute
+
+	// DefaultCharmHubCacheSize is the default maximum total size, in
+	// bytes, of the cached charm metadata responses.
+	DefaultCharmHubCacheSize = 64 << 20
+)
+
+// charmHubCacheMethods holds the facade methods whose responses are
+// cached. These only return metadata fetched from CharmHub, which is the
+// same for every model on a controller.
+var charmHubCacheMethods = map[string]map[string]bool{
+	"CharmHub": {"Find": true, "Info": true},
+	"Charms":   {"ResolveCharms": true},
+}
+
+// A CharmHubCache caches the responses to the charm metadata requests
+// that clients send through the model proxy, so that charm resolution
+// remains fast when the controllers' access to CharmHub is slow or rate
+// limited. Responses are cached for each controller, as controllers may
+// use different CharmHub instances, and only successful responses are
+// cached. The least recently used responses are evicted once the total
+// size of the cached responses exceeds the size limit.
+type CharmHubCache struct {
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// A charmHubCacheEntry is a cached response.
+type charmHubCacheEntry struct {
+	key      string
+	response json.RawMessage
+	expires  time.Time
+}
+
+// NewCharmHubCache returns a CharmHubCache that holds responses for the
+// given TTL, up to a total of maxSize bytes. If either is zero the
+// default is used.
+func NewCharmHubCache(ttl time.Duration, maxSize int) *CharmHubCache {
+	if ttl <= 0 {
+		ttl = DefaultCharmHubCacheTTL
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultCharmHubCacheSize
+	}
+	return &CharmHubCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// cacheKey returns the key for the response to the given request sent to
+// the given controller. If the response cannot be cached ok is false.
+func (c *CharmHubCache) cacheKey(controllerUUID string, req *message) (key string, ok bool) {
+	if c == nil || req == nil || !charmHubCacheMethods[req.Type][req.Request] {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s.%d.%s %s", controllerUUID, req.Type, req.Version, req.Request, req.Params), true
+}
+
+// get returns the cached response to the given request sent to the given
+// controller, if there is one.
+func (c *CharmHubCache) get(controllerUUID string, req *message) (json.RawMessage, bool) {
+	key, ok := c.cacheKey(controllerUUID, req)
+	if !ok {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := "miss"
+	defer func() {
+		servermon.CharmHubCacheRequestCount.WithLabelValues(req.Type, req.Request, result).Inc()
+	}()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*charmHubCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(e)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	result = "hit"
+	return entry.response, true
+}
+
+// put caches the response to the given request sent to the given
+// controller. Responses larger than the cache are not cached.
+func (c *CharmHubCache) put(controllerUUID string, req *message, response json.RawMessage) {
+	key, ok := c.cacheKey(controllerUUID, req)
+	if !ok || len(response) > c.maxSize {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	c.entries[key] = c.lru.PushFront(&charmHubCacheEntry{
+		key:      key,
+		response: response,
+		expires:  time.Now().Add(c.ttl),
+	})
+	c.size += len(response)
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	servermon.CharmHubCacheSize.Set(float64(c.size))
+}
+
+// remove removes the given element from the cache. The cache's lock must
+// be held.
+func (c *CharmHubCache) remove(e *list.Element) {
+	entry := c.lru.Remove(e).(*charmHubCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.response)
+	servermon.CharmHubCacheSize.Set(float64(c.size))
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical.
+
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/rpc"
+)
+
+func TestProxySocketsCharmHubCache(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientWebsocket := newMockWebsocketConnection(10)
+	controllerWebsocket := newMockWebsocketConnection(10)
+	helpers := rpc.ProxyHelpers{
+		ConnClient: clientWebsocket,
+		TokenGen:   &mockTokenGenerator{},
+		ConnectController: func(ctx context.Context) (rpc.WebsocketConnectionWithMetadata, error) {
+			return rpc.WebsocketConnectionWithMetadata{
+				Conn:           controllerWebsocket,
+				ModelName:      "test model",
+				ControllerUUID: uuid.NewString(),
+			}, nil
+		},
+		AuditLog:      func(*dbmodel.AuditLogEntry) {},
+		LoginService:  &mockLoginService{email: "alice@wonderland.io"},
+		CharmHubCache: rpc.NewCharmHubCache(time.Minute, 0),
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := rpc.ProxySockets(ctx, helpers)
+		c.Check(err, qt.ErrorMatches, "Context cancelled")
+	}()
+	defer wg.Wait()
+	defer cancel()
+
+	send := func(conn *mockWebsocketConnection, msg message) {
+		data, err := json.Marshal(msg)
+		c.Assert(err, qt.IsNil)
+		conn.read <- data
+	}
+	receive := func(conn *mockWebsocketConnection) message {
+		select {
+		case data := <-conn.write:
+			var msg message
+			err := json.Unmarshal(data, &msg)
+			c.Assert(err, qt.IsNil)
+			return msg
+		case <-time.After(2 * time.Second):
+			c.Fatal("timed out waiting for message")
+		}
+		return message{}
+	}
+
+	send(clientWebsocket, message{
+		RequestID: 1,
+		Type:      "Admin",
+		Version:   4,
+		Request:   "LoginWithSessionToken",
+		Params:    []byte(`{"session-token":"test session token"}`),
+	})
+	c.Check(receive(controllerWebsocket).Request, qt.Equals, "Login")
+	send(controllerWebsocket, message{RequestID: 1, Response: []byte(`{}`)})
+	c.Check(receive(clientWebsocket).RequestID, qt.Equals, uint64(1))
+
+	info := message{
+		RequestID: 2,
+		Type:      "CharmHub",
+		Version:   1,
+		Request:   "Info",
+		Params:    []byte(`{"tag":"application-mysql"}`),
+	}
+	send(clientWebsocket, info)
+	c.Check(receive(controllerWebsocket).Request, qt.Equals, "Info")
+	send(controllerWebsocket, message{RequestID: 2, Response: []byte(`{"result":{"name":"mysql"}}`)})
+	c.Check(string(receive(clientWebsocket).Response), qt.JSONEquals, map[string]any{"result": map[string]any{"name": "mysql"}})
+
+	// The same request is answered from the cache.
+	info.RequestID = 3
+	send(clientWebsocket, info)
+	resp := receive(clientWebsocket)
+	c.Check(resp.RequestID, qt.Equals, uint64(3))
+	c.Check(string(resp.Response), qt.JSONEquals, map[string]any{"result": map[string]any{"name": "mysql"}})
+
+	// Other requests are still sent to the controller.
+	send(clientWebsocket, message{
+		RequestID: 4,
+		Type:      "Client",
+		Version:   7,
+		Request:   "FullStatus",
+		Params:    []byte(`{}`),
+	})
+	c.Check(receive(controllerWebsocket).RequestID, qt.Equals, uint64(4))
+	select {
+	case data := <-controllerWebsocket.write:
+		c.Fatalf("unexpected message sent to controller: %s", data)
+	default:
+	}
+}
+
+func TestCharmHubCacheLimits(t *testing.T) {
+	c := qt.New(t)
+
+	req := func(name string) *rpc.Message {
+		return &rpc.Message{Type: "CharmHub", Version: 1, Request: "Info", Params: []byte(`{"tag":"application-` + name + `"}`)}
+	}
+	cache := rpc.NewCharmHubCache(time.Minute, 20)
+
+	// Only charm metadata requests are cached.
+	cache.CachePut("ctl-1", &rpc.Message{Type: "Client", Request: "FullStatus"}, []byte(`{}`))
+	_, ok := cache.CacheGet("ctl-1", &rpc.Message{Type: "Client", Request: "FullStatus"})
+	c.Check(ok, qt.IsFalse)
+
+	cache.CachePut("ctl-1", req("a"), []byte(`"aaaaaaaa"`))
+	cache.CachePut("ctl-1", req("b"), []byte(`"bbbbbbbb"`))
+	_, ok = cache.CacheGet("ctl-1", req("a"))
+	c.Check(ok, qt.IsTrue)
+	_, ok = cache.CacheGet("ctl-2", req("a"))
+	c.Check(ok, qt.IsFalse)
+
+	// Adding a third response evicts the least recently used.
+	cache.CachePut("ctl-1", req("c"), []byte(`"cccccccc"`))
+	_, ok = cache.CacheGet("ctl-1", req("b"))
+	c.Check(ok, qt.IsFalse)
+	resp, ok := cache.CacheGet("ctl-1", req("a"))
+	c.Check(ok, qt.IsTrue)
+	c.Check(string(resp), qt.Equals, `"aaaaaaaa"`)
+
+	// Responses larger than the cache are not cached.
+	cache.CachePut("ctl-1", req("d"), []byte(`"dddddddddddddddddddddddd"`))
+	_, ok = cache.CacheGet("ctl-1", req("d"))
+	c.Check(ok, qt.IsFalse)
+
+	// Responses expire after the TTL.
+	cache = rpc.NewCharmHubCache(time.Millisecond, 0)
+	cache.CachePut("ctl-1", req("a"), []byte(`"aaaaaaaa"`))
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cache.CacheGet("ctl-1", req("a"))
+	c.Check(ok, qt.IsFalse)
+}
@@ -264,6 +264,17 @@ func (c *Client) Close() error {
 	return c.err
 }
 
+// Disconnect abruptly closes the underlying connection, without
+// attempting the close handshake, as happens when the network between
+// JIMM and the server fails. Any outstanding calls fail and the client
+// is marked as broken.
+func (c *Client) Disconnect() {
+	c.mu.Lock()
+	c.broken = true
+	c.mu.Unlock()
+	c.conn.Close()
+}
+
 // IsBroken returns true if client has determined that it is no longer able
 // to send messages to the server.
 func (c *Client) IsBroken() bool {
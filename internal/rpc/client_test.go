@@ -116,6 +116,36 @@ func TestCallClosedWithoutResponse(t *testing.T) {
 	c.Check(res, qt.Equals, "")
 }
 
+func TestCallDisconnected(t *testing.T) {
+	c := qt.New(t)
+
+	received := make(chan struct{})
+	srv := newServer(func(conn *websocket.Conn) error {
+		var req map[string]interface{}
+		if err := conn.ReadJSON(&req); err != nil {
+			return err
+		}
+		close(received)
+		// Never respond, wait for the client to go away.
+		return conn.ReadJSON(&req)
+	})
+	defer srv.Close()
+	conn, err := srv.dialer.Dial(context.Background(), srv.URL, nil)
+	c.Assert(err, qt.IsNil)
+	defer conn.Close()
+
+	go func() {
+		<-received
+		conn.Disconnect()
+	}()
+	var res string
+	err = conn.Call(context.Background(), "Test", 1, "", "Test", "SUCCESS", &res)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(conn.IsBroken(), qt.IsTrue)
+	err = conn.Call(context.Background(), "Test", 1, "", "Test", "SUCCESS", &res)
+	c.Check(err, qt.Not(qt.IsNil))
+}
+
 func TestCallErrorResponse(t *testing.T) {
 	c := qt.New(t)
 
@@ -6,7 +6,6 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
 	"net/http"
 	"net/url"
 	"path"
@@ -21,6 +20,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/fips"
 )
 
 // A Dialer is used to create client connections to an RPC URL.
@@ -58,6 +58,20 @@ func (d Dialer) DialWebsocket(ctx context.Context, url string, headers http.Head
 // that can be used as is.
 // It accepts the endpoints to dial, normally /api or /commands.
 func Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, finalPath string, headers http.Header) (*websocket.Conn, error) {
+	return dial(ctx, nil, ctl, modelTag, finalPath, headers)
+}
+
+// An endpoint is a controller address to dial.
+type endpoint struct {
+	addr string
+	url  string
+}
+
+// dial connects to the controller/model. If router is not nil the
+// controller's addresses are dialed in the order it prefers, otherwise
+// they are all dialed simultaneously. If the controller has an SRV name
+// the addresses dialed are resolved from it.
+func dial(ctx context.Context, router *EndpointRouter, ctl *dbmodel.Controller, modelTag names.ModelTag, finalPath string, headers http.Header) (*websocket.Conn, error) {
 	var tlsConfig *tls.Config
 	if ctl.CACertificate != "" {
 		cp := x509.NewCertPool()
@@ -71,6 +85,7 @@ func Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag,
 			MinVersion: tls.VersionTLS12,
 		}
 	}
+	tlsConfig = fips.ClientTLSConfig(tlsConfig)
 	dialer := Dialer{
 		TLSConfig: tlsConfig,
 	}
@@ -85,22 +100,34 @@ func Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag,
 			return conn, nil
 		}
 	}
-	var urls []string
-	for _, hps := range ctl.Addresses {
-		for _, hp := range hps {
-			if maybeReachable(hp.Scope) {
-				urls = append(urls, websocketURL(fmt.Sprintf("%s:%d", hp.Value, hp.Port), modelTag, finalPath))
-			}
-		}
-	}
-	zapctx.Debug(ctx, "Dialling all URLs", zap.Any("urls", urls))
-	conn, err := dialAll(ctx, &dialer, urls, headers)
+	var conn *websocket.Conn
+	err := withControllerAddrs(ctx, ctl, maybeReachable, func(addrs []string) error {
+		var err error
+		conn, err = dialAddrs(ctx, router, &dialer, addrs, modelTag, finalPath, headers)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	return conn, nil
 }
 
+// dialAddrs dials the given controller addresses, using router to order
+// them if it is not nil.
+func dialAddrs(ctx context.Context, router *EndpointRouter, dialer *Dialer, addrs []string, modelTag names.ModelTag, finalPath string, headers http.Header) (*websocket.Conn, error) {
+	endpoints := make([]endpoint, len(addrs))
+	urls := make([]string, len(addrs))
+	for i, addr := range addrs {
+		urls[i] = websocketURL(addr, modelTag, finalPath)
+		endpoints[i] = endpoint{addr: addr, url: urls[i]}
+	}
+	if router != nil {
+		return router.dialAll(ctx, dialer, endpoints, headers)
+	}
+	zapctx.Debug(ctx, "Dialling all URLs", zap.Any("urls", urls))
+	return dialAll(ctx, dialer, urls, headers)
+}
+
 // maybeReachable decides what kinds of links JIMM should try to connect via.
 // Local IPs like localhost for example are excluded but public IPs and Cloud local IPs are potentially reachable.
 func maybeReachable(scope string) bool {
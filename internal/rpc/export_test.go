@@ -1,4 +1,35 @@
 // Copyright 2024 Canonical.
 package rpc
 
+import (
+	"context"
+	"encoding/json"
+	"net"
+)
+
 type Message message
+
+// SetSRVResolver sets the function used to look up the SRV records of
+// controllers and clears the cache of resolved records. It returns a
+// function that restores the original resolver.
+func SetSRVResolver(f func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)) func() {
+	old := srvCache
+	srvCache = newSRVResolverCache(srvResolverFunc(f))
+	return func() { srvCache = old }
+}
+
+type srvResolverFunc func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+
+func (f srvResolverFunc) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	return f(ctx, service, proto, name)
+}
+
+// CacheGet returns the cached response to the given request.
+func (c *CharmHubCache) CacheGet(controllerUUID string, req *Message) (json.RawMessage, bool) {
+	return c.get(controllerUUID, (*message)(req))
+}
+
+// CachePut caches the response to the given request.
+func (c *CharmHubCache) CachePut(controllerUUID string, req *Message, response json.RawMessage) {
+	c.put(controllerUUID, (*message)(req), response)
+}
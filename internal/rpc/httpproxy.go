@@ -6,7 +6,6 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -16,6 +15,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/fips"
 )
 
 type httpOptions struct {
@@ -39,6 +39,7 @@ func ProxyHTTP(ctx context.Context, ctl *dbmodel.Controller, w http.ResponseWrit
 			MinVersion: tls.VersionTLS12,
 		}
 	}
+	tlsConfig = fips.ClientTLSConfig(tlsConfig)
 
 	if ctl.PublicAddress != "" {
 		err := doRequest(ctx, w, req, httpOptions{
@@ -49,11 +50,11 @@ func ProxyHTTP(ctx context.Context, ctl *dbmodel.Controller, w http.ResponseWrit
 			return nil
 		}
 	}
-	for _, hps := range ctl.Addresses {
-		for _, hp := range hps {
+	return withControllerAddrs(ctx, ctl, nil, func(addrs []string) error {
+		for _, addr := range addrs {
 			err := doRequest(ctx, w, req, httpOptions{
 				TLSConfig: tlsConfig,
-				URL:       createURLWithNewHost(*req.URL, fmt.Sprintf("%s:%d", hp.Value, hp.Port)),
+				URL:       createURLWithNewHost(*req.URL, addr),
 			})
 			if err == nil {
 				return nil
@@ -61,9 +62,8 @@ func ProxyHTTP(ctx context.Context, ctl *dbmodel.Controller, w http.ResponseWrit
 				zapctx.Error(ctx, "failed to proxy request: continue to next addr", zaputil.Error(err))
 			}
 		}
-	}
-
-	return errgo.New("couldn't reach a valid address for controller")
+		return errgo.New("couldn't reach a valid address for controller")
+	})
 }
 
 func doRequest(ctx context.Context, w http.ResponseWriter, req *http.Request, opt httpOptions) error {
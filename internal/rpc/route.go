@@ -0,0 +1,260 @@
+// Copyright 2024 Canonical.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+const (
+	// DefaultDialStagger is the default delay between dialing
+	// successive controller endpoints.
+	DefaultDialStagger = 250 * time.Millisecond
+
+	// failedDialLatency is the latency recorded for an endpoint that
+	// could not be dialed, so that it is tried after working endpoints
+	// of the same weight.
+	failedDialLatency = 30 * time.Second
+)
+
+// An EndpointWeight gives the weight of the controller endpoints that
+// match it. Endpoints with a higher weight are dialed first.
+type EndpointWeight struct {
+	// Match is either a CIDR, which matches IP address endpoints in
+	// the network, or a domain name starting with a "." which matches
+	// host name endpoints in, or equal to, the domain.
+	Match string
+
+	// Weight is the weight given to matching endpoints. Endpoints
+	// that match no EndpointWeight have a weight of 0.
+	Weight int
+}
+
+// An endpointMatcher is a parsed EndpointWeight.
+type endpointMatcher struct {
+	network *net.IPNet
+	domain  string
+	weight  int
+}
+
+// matches returns whether the given endpoint host matches.
+func (m endpointMatcher) matches(host string) bool {
+	if m.network != nil {
+		ip := net.ParseIP(host)
+		return ip != nil && m.network.Contains(ip)
+	}
+	host = strings.ToLower(host)
+	return host == m.domain[1:] || strings.HasSuffix(host, m.domain)
+}
+
+// ParseEndpointWeights parses endpoint weights in the form
+// "<match>=<weight>" separated by whitespace, for example
+// "10.10.0.0/16=10 .eu-west-1.example.com=5".
+func ParseEndpointWeights(s string) ([]EndpointWeight, error) {
+	var weights []EndpointWeight
+	for _, f := range strings.Fields(s) {
+		match, weight, ok := strings.Cut(f, "=")
+		if !ok || match == "" {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid endpoint weight %q", f))
+		}
+		n, err := strconv.Atoi(weight)
+		if err != nil {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid endpoint weight %q", f))
+		}
+		weights = append(weights, EndpointWeight{Match: match, Weight: n})
+	}
+	return weights, nil
+}
+
+// An EndpointRouter dials controllers preferring the endpoints closest to
+// this JIMM replica. Endpoints are ordered by their configured weight
+// and then by the latency observed when dialing them, and are dialed in
+// turn with a short delay between each, so that a nearby endpoint is
+// used whenever it is reachable but an unreachable one does not hold up
+// the connection.
+type EndpointRouter struct {
+	matchers []endpointMatcher
+	stagger  time.Duration
+
+	mu      sync.Mutex
+	latency map[string]time.Duration
+}
+
+// NewEndpointRouter returns an EndpointRouter using the given weights.
+// The stagger is the delay between dialing successive endpoints, if it
+// is zero DefaultDialStagger is used.
+func NewEndpointRouter(weights []EndpointWeight, stagger time.Duration) (*EndpointRouter, error) {
+	r := EndpointRouter{
+		matchers: make([]endpointMatcher, len(weights)),
+		stagger:  stagger,
+		latency:  make(map[string]time.Duration),
+	}
+	if r.stagger <= 0 {
+		r.stagger = DefaultDialStagger
+	}
+	for i, w := range weights {
+		m := endpointMatcher{weight: w.Weight}
+		if strings.Contains(w.Match, "/") {
+			_, n, err := net.ParseCIDR(w.Match)
+			if err != nil {
+				return nil, errors.E(errors.CodeBadRequest, err)
+			}
+			m.network = n
+		} else if strings.HasPrefix(w.Match, ".") {
+			m.domain = strings.ToLower(w.Match)
+		} else {
+			return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid endpoint match %q, must be a CIDR or a domain suffix starting with \".\"", w.Match))
+		}
+		r.matchers[i] = m
+	}
+	return &r, nil
+}
+
+// Dial connects to the controller/model in the same way as Dial, but
+// dials the controller's addresses in order of preference.
+func (r *EndpointRouter) Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, finalPath string, headers http.Header) (*websocket.Conn, error) {
+	return dial(ctx, r, ctl, modelTag, finalPath, headers)
+}
+
+// weight returns the configured weight of the given host.
+func (r *EndpointRouter) weight(host string) int {
+	for _, m := range r.matchers {
+		if m.matches(host) {
+			return m.weight
+		}
+	}
+	return 0
+}
+
+// order sorts the given endpoints into the order in which they should
+// be dialed.
+func (r *EndpointRouter) order(endpoints []endpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	type key struct {
+		weight  int
+		latency time.Duration
+	}
+	keys := make(map[string]key, len(endpoints))
+	for _, ep := range endpoints {
+		host, _, _ := net.SplitHostPort(ep.addr)
+		keys[ep.addr] = key{weight: r.weight(host), latency: r.latency[ep.addr]}
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		ki, kj := keys[endpoints[i].addr], keys[endpoints[j].addr]
+		if ki.weight != kj.weight {
+			return ki.weight > kj.weight
+		}
+		return ki.latency < kj.latency
+	})
+}
+
+// observe records the result of dialing the given endpoint. Latency is
+// recorded as a moving average so that a single slow dial does not move
+// traffic away from an endpoint.
+func (r *EndpointRouter) observe(addr string, d time.Duration, err error) {
+	if err != nil {
+		d = failedDialLatency
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.latency[addr]; ok && err == nil {
+		d = (7*old + 3*d) / 10
+	}
+	r.latency[addr] = d
+}
+
+// dialAll dials the given endpoints in order, starting the next dial
+// when the previous one fails or the stagger delay has passed, and
+// returns the first connection established.
+func (r *EndpointRouter) dialAll(ctx context.Context, dialer *Dialer, endpoints []endpoint, headers http.Header) (*websocket.Conn, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.E("no urls to dial")
+	}
+	r.order(endpoints)
+	urls := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		urls[i] = ep.url
+	}
+	zapctx.Debug(ctx, "dialing URLs in order", zap.Strings("urls", urls))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		conn *websocket.Conn
+		err  error
+	}
+	results := make(chan result, len(endpoints))
+	next, pending := 0, 0
+	var staggerC <-chan time.Time
+	start := func() {
+		ep := endpoints[next]
+		next++
+		pending++
+		if next < len(endpoints) {
+			staggerC = time.After(r.stagger)
+		} else {
+			staggerC = nil
+		}
+		go func() {
+			t0 := time.Now()
+			conn, err := dialer.DialWebsocket(ctx, ep.url, headers)
+			r.observe(ep.addr, time.Since(t0), err)
+			results <- result{conn: conn, err: err}
+		}()
+	}
+
+	// drain closes any connections established by dials that are
+	// still pending once the result is known.
+	drain := func(n int) {
+		go func() {
+			for ; n > 0; n-- {
+				if res := <-results; res.conn != nil {
+					res.conn.Close()
+				}
+			}
+		}()
+	}
+
+	var firstErr error
+	start()
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				drain(pending)
+				return res.conn, nil
+			}
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			if next < len(endpoints) {
+				start()
+			}
+		case <-staggerC:
+			start()
+		case <-ctx.Done():
+			drain(pending)
+			return nil, errors.E(ctx.Err())
+		}
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical.
+
+package rpc_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gorilla/websocket"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/rpc"
+)
+
+func TestParseEndpointWeights(t *testing.T) {
+	c := qt.New(t)
+
+	weights, err := rpc.ParseEndpointWeights("10.10.0.0/16=10  .eu-west-1.example.com=5\n")
+	c.Assert(err, qt.IsNil)
+	c.Check(weights, qt.DeepEquals, []rpc.EndpointWeight{
+		{Match: "10.10.0.0/16", Weight: 10},
+		{Match: ".eu-west-1.example.com", Weight: 5},
+	})
+
+	weights, err = rpc.ParseEndpointWeights("")
+	c.Assert(err, qt.IsNil)
+	c.Check(weights, qt.HasLen, 0)
+
+	_, err = rpc.ParseEndpointWeights("10.10.0.0/16")
+	c.Check(err, qt.ErrorMatches, `invalid endpoint weight "10.10.0.0/16"`)
+	_, err = rpc.ParseEndpointWeights(".example.com=high")
+	c.Check(err, qt.ErrorMatches, `invalid endpoint weight ".example.com=high"`)
+}
+
+func TestNewEndpointRouterInvalidMatch(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := rpc.NewEndpointRouter([]rpc.EndpointWeight{{Match: "10.10.0.0/33", Weight: 1}}, 0)
+	c.Check(err, qt.ErrorMatches, `invalid CIDR address: 10.10.0.0/33`)
+	_, err = rpc.NewEndpointRouter([]rpc.EndpointWeight{{Match: "example.com", Weight: 1}}, 0)
+	c.Check(err, qt.ErrorMatches, `invalid endpoint match "example.com", must be a CIDR or a domain suffix starting with "."`)
+}
+
+// newCountingServer starts a TLS websocket server that counts the
+// connections made to it.
+func newCountingServer(c *qt.C) (*httptest.Server, *int64) {
+	var count int64
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt64(&count, 1)
+		var u websocket.Upgrader
+		conn, err := u.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _, _ = conn.ReadMessage()
+	}))
+	c.Cleanup(srv.Close)
+	return srv, &count
+}
+
+func hostPort(c *qt.C, host string, srv *httptest.Server) jujuparams.HostPort {
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	c.Assert(err, qt.IsNil)
+	p, err := strconv.Atoi(port)
+	c.Assert(err, qt.IsNil)
+	return jujuparams.HostPort{
+		Address: jujuparams.Address{Value: host, Type: "ipv4", Scope: "public"},
+		Port:    p,
+	}
+}
+
+func TestEndpointRouterDial(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	far, farCount := newCountingServer(c)
+	near, nearCount := newCountingServer(c)
+
+	// Every httptest server uses the same certificate, which is valid
+	// for example.com.
+	ctl := dbmodel.Controller{
+		CACertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: far.Certificate().Raw})),
+		TLSHostname:   "example.com",
+		Addresses: dbmodel.HostPorts{{
+			hostPort(c, "127.0.0.1", far),
+			hostPort(c, "localhost", near),
+		}},
+	}
+	router, err := rpc.NewEndpointRouter([]rpc.EndpointWeight{{Match: ".localhost", Weight: 10}}, time.Minute)
+	c.Assert(err, qt.IsNil)
+
+	conn, err := router.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(atomic.LoadInt64(nearCount), qt.Equals, int64(1))
+	c.Check(atomic.LoadInt64(farCount), qt.Equals, int64(0))
+
+	// If the preferred endpoint cannot be reached the next one is
+	// dialed without waiting for the stagger delay.
+	near.Close()
+	start := time.Now()
+	conn, err = router.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(time.Since(start) < time.Minute, qt.IsTrue)
+	c.Check(atomic.LoadInt64(farCount), qt.Equals, int64(1))
+}
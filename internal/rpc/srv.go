@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// srvCacheTTL is the time for which resolved SRV records are used before
+// they are resolved again.
+const srvCacheTTL = time.Minute
+
+// An srvResolver looks up DNS SRV records, it is implemented by
+// *net.Resolver.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// srvCache is the cache used to resolve controller SRV names.
+var srvCache = newSRVResolverCache(net.DefaultResolver)
+
+// An srvCacheEntry holds the addresses resolved from an SRV name.
+type srvCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// An srvResolverCache resolves SRV names to host:port addresses, caching
+// the results for srvCacheTTL.
+type srvResolverCache struct {
+	resolver srvResolver
+
+	mu      sync.Mutex
+	entries map[string]srvCacheEntry
+}
+
+func newSRVResolverCache(r srvResolver) *srvResolverCache {
+	return &srvResolverCache{
+		resolver: r,
+		entries:  make(map[string]srvCacheEntry),
+	}
+}
+
+// lookup returns the addresses for the given SRV name in the order in
+// which they should be tried. Unless refresh is true, addresses resolved
+// within the last srvCacheTTL are returned without resolving the name
+// again, in which case cached is true.
+func (c *srvResolverCache) lookup(ctx context.Context, name string, refresh bool) (addrs []string, cached bool, err error) {
+	const op = errors.Op("rpc.lookupSRV")
+
+	name = strings.ToLower(name)
+	if !refresh {
+		c.mu.Lock()
+		e, ok := c.entries[name]
+		c.mu.Unlock()
+		if ok && time.Now().Before(e.expires) {
+			return e.addrs, true, nil
+		}
+	}
+
+	// Records are returned sorted by priority and randomized by weight
+	// within each priority.
+	_, records, err := c.resolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, false, errors.E(op, errors.CodeConnectionFailed, err)
+	}
+	for _, r := range records {
+		// A target of "." means the service is not available.
+		host := strings.TrimSuffix(r.Target, ".")
+		if host == "" {
+			continue
+		}
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+	}
+	if len(addrs) == 0 {
+		return nil, false, errors.E(op, errors.CodeConnectionFailed, "no SRV records found for "+name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = srvCacheEntry{addrs: addrs, expires: time.Now().Add(srvCacheTTL)}
+	return addrs, false, nil
+}
+
+// controllerAddrs returns the host:port addresses to dial for the given
+// controller, other than its public address. If the controller has an
+// SRV name the addresses are resolved from it, falling back to the
+// controller's stored addresses if it cannot be resolved. If reachable
+// is not nil only stored addresses with a scope for which it returns
+// true are included. If refresh is true any cached SRV records are
+// resolved again. The returned cached value is true if the addresses
+// came from SRV records that were not freshly resolved.
+func controllerAddrs(ctx context.Context, ctl *dbmodel.Controller, reachable func(scope string) bool, refresh bool) (addrs []string, cached bool) {
+	if ctl.SRVName != "" {
+		addrs, cached, err := srvCache.lookup(ctx, ctl.SRVName, refresh)
+		if err == nil {
+			return addrs, cached
+		}
+		zapctx.Warn(ctx, "cannot resolve controller SRV name, using stored addresses", zap.String("srv-name", ctl.SRVName), zaputil.Error(err))
+	}
+	for _, hps := range ctl.Addresses {
+		for _, hp := range hps {
+			if reachable == nil || reachable(hp.Scope) {
+				addrs = append(addrs, net.JoinHostPort(hp.Value, strconv.Itoa(hp.Port)))
+			}
+		}
+	}
+	return addrs, false
+}
+
+// withControllerAddrs calls f with the addresses to dial for the given
+// controller, as returned by controllerAddrs. If f fails using cached
+// SRV records, the controller's SRV name is resolved again and, if the
+// addresses have changed, f is called again with the new addresses.
+func withControllerAddrs(ctx context.Context, ctl *dbmodel.Controller, reachable func(scope string) bool, f func(addrs []string) error) error {
+	addrs, cached := controllerAddrs(ctx, ctl, reachable, false)
+	err := f(addrs)
+	if err == nil || !cached {
+		return err
+	}
+	newAddrs, _ := controllerAddrs(ctx, ctl, reachable, true)
+	if slices.Equal(addrs, newAddrs) {
+		return err
+	}
+	zapctx.Info(ctx, "controller SRV records changed, dialing again", zap.String("srv-name", ctl.SRVName), zap.Strings("addresses", newAddrs))
+	return f(newAddrs)
+}
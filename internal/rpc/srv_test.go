@@ -0,0 +1,110 @@
+// Copyright 2024 Canonical.
+
+package rpc_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/rpc"
+)
+
+// srvRecord returns an SRV record for the given host and port.
+func srvRecord(host string, port int) *net.SRV {
+	return &net.SRV{Target: host + ".", Port: uint16(port)}
+}
+
+// serverPort returns the port of the given listener address.
+func serverPort(c *qt.C, addr string) int {
+	_, port, err := net.SplitHostPort(addr)
+	c.Assert(err, qt.IsNil)
+	p, err := strconv.Atoi(port)
+	c.Assert(err, qt.IsNil)
+	return p
+}
+
+func TestDialSRV(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	old, oldCount := newCountingServer(c)
+	current, currentCount := newCountingServer(c)
+
+	var lookups int64
+	records := []*net.SRV{srvRecord("localhost", serverPort(c, old.Listener.Addr().String()))}
+	c.Cleanup(rpc.SetSRVResolver(func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		atomic.AddInt64(&lookups, 1)
+		c.Check(service, qt.Equals, "")
+		c.Check(proto, qt.Equals, "")
+		c.Check(name, qt.Equals, "_juju-api._tcp.controller.example.com")
+		return name, records, nil
+	}))
+
+	// The stored address is never dialed while the SRV name resolves.
+	stale, staleCount := newCountingServer(c)
+	ctl := dbmodel.Controller{
+		CACertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: old.Certificate().Raw})),
+		TLSHostname:   "example.com",
+		SRVName:       "_juju-api._tcp.controller.example.com",
+		Addresses:     dbmodel.HostPorts{{hostPort(c, "127.0.0.1", stale)}},
+	}
+
+	conn, err := rpc.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(atomic.LoadInt64(oldCount), qt.Equals, int64(1))
+	c.Check(atomic.LoadInt64(&lookups), qt.Equals, int64(1))
+
+	// Resolved records are cached.
+	conn, err = rpc.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(atomic.LoadInt64(oldCount), qt.Equals, int64(2))
+	c.Check(atomic.LoadInt64(&lookups), qt.Equals, int64(1))
+
+	// When the cached endpoints fail the name is resolved again.
+	old.Close()
+	records = []*net.SRV{srvRecord("localhost", serverPort(c, current.Listener.Addr().String()))}
+	conn, err = rpc.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(atomic.LoadInt64(currentCount), qt.Equals, int64(1))
+	c.Check(atomic.LoadInt64(&lookups), qt.Equals, int64(2))
+	c.Check(atomic.LoadInt64(staleCount), qt.Equals, int64(0))
+}
+
+func TestDialSRVFallback(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	c.Cleanup(rpc.SetSRVResolver(func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}))
+
+	srv, count := newCountingServer(c)
+	ctl := dbmodel.Controller{
+		CACertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})),
+		TLSHostname:   "example.com",
+		SRVName:       "_juju-api._tcp.controller.example.com",
+		Addresses:     dbmodel.HostPorts{{hostPort(c, "127.0.0.1", srv)}},
+	}
+
+	// If the SRV name cannot be resolved the stored addresses are used.
+	conn, err := rpc.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Assert(err, qt.IsNil)
+	conn.Close()
+	c.Check(atomic.LoadInt64(count), qt.Equals, int64(1))
+
+	// With no stored addresses the dial fails.
+	ctl.Addresses = nil
+	_, err = rpc.Dial(ctx, &ctl, names.ModelTag{}, "", nil)
+	c.Check(err, qt.ErrorMatches, "no urls to dial")
+}
@@ -131,6 +131,103 @@ var (
 		Name:      "controller",
 		Help:      "The number of controllers managed by JIMM.",
 	})
+	PubsubSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "pubsub",
+		Name:      "subscribers",
+		Help:      "The number of active pub-sub subscriptions.",
+	})
+	PubsubMessagesPublishedCount = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "pubsub",
+		Name:      "messages_published_total",
+		Help:      "The number of messages published to the pub-sub hub.",
+	})
+	WatchersActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "watcher",
+		Name:      "active",
+		Help:      "The number of active watchers.",
+	}, []string{"type"})
+	WatcherQueueDepthHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "jimm",
+		Subsystem: "watcher",
+		Name:      "queue_depth",
+		Help:      "Histogram of the number of events waiting for a watcher client when it reads them.",
+		Buckets:   []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"type"})
+	WatcherDroppedEventsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "watcher",
+		Name:      "dropped_events_total",
+		Help:      "The number of events discarded because a watcher client did not read them quickly enough.",
+	}, []string{"type"})
+	DeprecatedFacadeCallCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "jujuapi",
+		Name:      "deprecated_facade_calls_total",
+		Help:      "The number of calls made on deprecated facade versions.",
+	}, []string{"facade", "version"})
+	ChaosFaultCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "chaos",
+		Name:      "faults_total",
+		Help:      "The number of faults injected into controller connections by chaos mode.",
+	}, []string{"fault"})
+	ControllerUnavailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "system",
+		Name:      "controller_unavailable",
+		Help:      "Set to 1 while a controller cannot be reached outside of a maintenance window.",
+	}, []string{"controller"})
+	ControllerInMaintenance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "system",
+		Name:      "controller_in_maintenance",
+		Help:      "Set to 1 while a controller is in a declared maintenance window.",
+	}, []string{"controller"})
+	CharmHubCacheRequestCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "charmhub_cache",
+		Name:      "requests_total",
+		Help:      "The number of cacheable charm metadata requests, by whether they were served from the cache.",
+	}, []string{"facade", "method", "result"})
+	CloudCredentialsExpiringSoon = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "system",
+		Name:      "cloud_credentials_expiring_soon",
+		Help:      "The number of cloud credentials that are about to expire.",
+	})
+	CharmHubCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "charmhub_cache",
+		Name:      "size_bytes",
+		Help:      "The size of the responses held in the charm metadata cache.",
+	})
+	RateLimitedRequestsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "jujuapi",
+		Name:      "rate_limited_requests_total",
+		Help:      "The number of requests rejected by the rate limiter, by the limit that was exceeded.",
+	}, []string{"facade", "limit"})
+	ControllerDialWaitDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "jimm",
+		Subsystem: "jujuclient",
+		Name:      "dial_wait_duration_seconds",
+		Help:      "The time spent waiting to dial a controller when the concurrent dial limit is reached.",
+	})
+	ControllerDialRejectedCount = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "jujuclient",
+		Name:      "dials_rejected_total",
+		Help:      "The number of controller dials abandoned while waiting for the concurrent dial limit.",
+	})
+	DBReplicaFallbackCount = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "db",
+		Name:      "replica_fallback_total",
+		Help:      "The number of read queries run on the primary database because the read replica was unavailable.",
+	})
 )
 
 // DurationObserver returns a function that, when run with `defer` will
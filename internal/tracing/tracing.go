@@ -0,0 +1,96 @@
+// Copyright 2024 Canonical.
+
+// Package tracing provides OpenTelemetry tracing of JIMM operations.
+// Spans are created with Start and are only exported once Setup has
+// been called, until then they are discarded.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/version"
+)
+
+// tracerName is the name of the tracer used for all JIMM spans.
+const tracerName = "github.com/canonical/jimm"
+
+// Params holds the parameters used to configure tracing.
+type Params struct {
+	// Endpoint is the host and port of the OTLP HTTP collector that
+	// spans are exported to. If this is empty tracing is disabled.
+	Endpoint string
+
+	// Insecure, if true, exports spans over plain HTTP rather than
+	// HTTPS.
+	Insecure bool
+
+	// SampleRatio is the fraction of traces that are sampled, between 0
+	// and 1. If it is zero every trace is sampled.
+	SampleRatio float64
+}
+
+// Setup configures the global tracer provider to export spans according
+// to the given parameters. The returned function flushes any pending
+// spans and stops the exporter, it should be called when the server
+// shuts down.
+func Setup(ctx context.Context, p Params) (func(context.Context) error, error) {
+	const op = errors.Op("tracing.Setup")
+
+	if p.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if p.SampleRatio < 0 || p.SampleRatio > 1 {
+		return nil, errors.E(op, errors.CodeBadRequest, "sample ratio must be between 0 and 1")
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(p.Endpoint)}
+	if p.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	sampler := sdktrace.AlwaysSample()
+	if p.SampleRatio > 0 {
+		sampler = sdktrace.TraceIDRatioBased(p.SampleRatio)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "jimm"),
+			attribute.String("service.version", version.VersionInfo.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start starts a span with the given name as a child of any span in the
+// given context. The returned context contains the new span, which must
+// be ended by the caller.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the given span. If the error pointed to by err is not nil it
+// is recorded on the span and the span's status is set to Error. End is
+// intended to be deferred with a pointer to a named error result.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical.
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/tracing"
+)
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	c := qt.New(t)
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Params{})
+	c.Assert(err, qt.IsNil)
+	c.Check(shutdown(context.Background()), qt.IsNil)
+}
+
+func TestSetupInvalidSampleRatio(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := tracing.Setup(context.Background(), tracing.Params{
+		Endpoint:    "localhost:4318",
+		SampleRatio: 2,
+	})
+	c.Check(err, qt.ErrorMatches, `sample ratio must be between 0 and 1`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+func TestStart(t *testing.T) {
+	c := qt.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	c.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx, parent := tracing.Start(context.Background(), "jimm.Parent")
+	_, child := tracing.Start(ctx, "db.Child", attribute.String("model", "model-1"))
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 2)
+	c.Check(spans[0].Name(), qt.Equals, "db.Child")
+	c.Assert(spans[0].Attributes(), qt.HasLen, 1)
+	c.Check(spans[0].Attributes()[0].Key, qt.Equals, attribute.Key("model"))
+	c.Check(spans[0].Attributes()[0].Value.AsString(), qt.Equals, "model-1")
+	c.Check(spans[0].Parent().SpanID(), qt.Equals, spans[1].SpanContext().SpanID())
+	c.Check(spans[1].Name(), qt.Equals, "jimm.Parent")
+}
+
+func TestEnd(t *testing.T) {
+	c := qt.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	c.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var err error
+	_, span := tracing.Start(context.Background(), "jimm.Success")
+	tracing.End(span, &err)
+
+	err = errors.E("test error")
+	_, span = tracing.Start(context.Background(), "jimm.Failure")
+	tracing.End(span, &err)
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 2)
+	c.Check(spans[0].Status().Code, qt.Equals, codes.Unset)
+	c.Check(spans[0].Events(), qt.HasLen, 0)
+	c.Check(spans[1].Status().Code, qt.Equals, codes.Error)
+	c.Check(spans[1].Status().Description, qt.Equals, "test error")
+	c.Assert(spans[1].Events(), qt.HasLen, 1)
+	c.Check(spans[1].Events()[0].Name, qt.Equals, "exception")
+}
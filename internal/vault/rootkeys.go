@@ -0,0 +1,165 @@
+// Copyright 2024 Canonical.
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	goerr "errors"
+	"path"
+	"time"
+
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
+	"github.com/hashicorp/vault/api"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+const (
+	rootKeyKey        = "root-key"
+	rootKeyCreatedKey = "created"
+	rootKeyExpiresKey = "expires"
+)
+
+// GetKey implements dbrootkeystore.Backing.GetKey.
+func (s *VaultStore) GetKey(id []byte) (_ dbrootkeystore.RootKey, err error) {
+	const op = errors.Op("vault.GetKey")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return dbrootkeystore.RootKey{}, errors.E(op, err)
+	}
+	rk, err := s.getRootKey(ctx, client, hex.EncodeToString(id))
+	if err != nil {
+		return dbrootkeystore.RootKey{}, errors.E(op, err)
+	}
+	if rk == nil {
+		return dbrootkeystore.RootKey{}, bakery.ErrNotFound
+	}
+	return *rk, nil
+}
+
+// FindLatestKey implements dbrootkeystore.Backing.FindLatestKey. Keys
+// that have expired are removed from the vault as they are found.
+func (s *VaultStore) FindLatestKey(createdAfter, expiresAfter, expiresBefore time.Time) (_ dbrootkeystore.RootKey, err error) {
+	const op = errors.Op("vault.FindLatestKey")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return dbrootkeystore.RootKey{}, errors.E(op, err)
+	}
+	secret, err := client.Logical().ListWithContext(ctx, path.Join(s.KVPath, "metadata", s.rootKeysPath()))
+	if err != nil {
+		return dbrootkeystore.RootKey{}, errors.E(op, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return dbrootkeystore.RootKey{}, nil
+	}
+	keys, _ := secret.Data["keys"].([]interface{})
+
+	now := time.Now()
+	var latest dbrootkeystore.RootKey
+	for _, k := range keys {
+		name, ok := k.(string)
+		if !ok {
+			continue
+		}
+		rk, err := s.getRootKey(ctx, client, name)
+		if err != nil {
+			return dbrootkeystore.RootKey{}, errors.E(op, err)
+		}
+		if rk == nil {
+			continue
+		}
+		if rk.Expires.Before(now) {
+			if err := client.KVv2(s.KVPath).DeleteMetadata(ctx, s.rootKeyPath(name)); err != nil {
+				zapctx.Warn(ctx, "cannot remove expired root key", zap.String("id", name), zap.Error(err))
+			}
+			continue
+		}
+		if !rk.Created.After(createdAfter) || rk.Expires.Before(expiresAfter) || rk.Expires.After(expiresBefore) {
+			continue
+		}
+		if rk.Created.After(latest.Created) {
+			latest = *rk
+		}
+	}
+	return latest, nil
+}
+
+// InsertKey implements dbrootkeystore.Backing.InsertKey.
+func (s *VaultStore) InsertKey(key dbrootkeystore.RootKey) (err error) {
+	const op = errors.Op("vault.InsertKey")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	ctx := context.Background()
+	client, err := s.client(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	data := map[string]interface{}{
+		rootKeyKey:        base64.StdEncoding.EncodeToString(key.RootKey),
+		rootKeyCreatedKey: key.Created.UTC().Format(time.RFC3339Nano),
+		rootKeyExpiresKey: key.Expires.UTC().Format(time.RFC3339Nano),
+	}
+	if _, err := client.KVv2(s.KVPath).Put(ctx, s.rootKeyPath(hex.EncodeToString(key.Id)), data); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// getRootKey reads the root key with the given hex encoded ID. If there
+// is no such key nil is returned.
+func (s *VaultStore) getRootKey(ctx context.Context, client *api.Client, name string) (*dbrootkeystore.RootKey, error) {
+	secret, err := client.KVv2(s.KVPath).Get(ctx, s.rootKeyPath(name))
+	if err != nil && goerr.Unwrap(err) != api.ErrSecretNotFound {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+	id, err := hex.DecodeString(name)
+	if err != nil {
+		return nil, errors.E(err, "invalid root key id")
+	}
+	rk := dbrootkeystore.RootKey{Id: id}
+	s1, _ := secret.Data[rootKeyKey].(string)
+	if rk.RootKey, err = base64.StdEncoding.DecodeString(s1); err != nil {
+		return nil, errors.E(err, "invalid root key")
+	}
+	s2, _ := secret.Data[rootKeyCreatedKey].(string)
+	if rk.Created, err = time.Parse(time.RFC3339Nano, s2); err != nil {
+		return nil, errors.E(err, "invalid root key creation time")
+	}
+	s3, _ := secret.Data[rootKeyExpiresKey].(string)
+	if rk.Expires, err = time.Parse(time.RFC3339Nano, s3); err != nil {
+		return nil, errors.E(err, "invalid root key expiry time")
+	}
+	return &rk, nil
+}
+
+func (s *VaultStore) rootKeysPath() string {
+	return "root-keys"
+}
+
+func (s *VaultStore) rootKeyPath(name string) string {
+	return path.Join(s.rootKeysPath(), name)
+}
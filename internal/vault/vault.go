@@ -26,6 +26,7 @@ import (
 const (
 	usernameKey = "username"
 	passwordKey = "password"
+	caCertKey   = "ca-cert"
 )
 
 const (
@@ -207,6 +208,65 @@ func (s *VaultStore) PutControllerCredentials(ctx context.Context, controllerNam
 	return nil
 }
 
+// GetControllerCACertificate retrieves the CA certificate for the given
+// controller from a vault service.
+func (s *VaultStore) GetControllerCACertificate(ctx context.Context, controllerName string) (_ string, err error) {
+	const op = errors.Op("vault.GetControllerCACertificate")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+
+	secret, err := client.KVv2(s.KVPath).Get(ctx, s.controllerCACertificatePath(controllerName))
+	if err != nil && goerr.Unwrap(err) != api.ErrSecretNotFound {
+		return "", errors.E(op, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", nil
+	}
+	caCert, _ := secret.Data[caCertKey].(string)
+	return caCert, nil
+}
+
+// PutControllerCACertificate stores the CA certificate for the given
+// controller in a vault service.
+func (s *VaultStore) PutControllerCACertificate(ctx context.Context, controllerName string, caCert string) (err error) {
+	const op = errors.Op("vault.PutControllerCACertificate")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	if caCert == "" {
+		err = client.KVv2(s.KVPath).Delete(ctx, s.controllerCACertificatePath(controllerName))
+		if rerr, ok := err.(*api.ResponseError); ok && rerr.StatusCode == http.StatusNotFound {
+			// Ignore the error if attempting to delete something that isn't there.
+			err = nil
+		}
+		if err != nil {
+			return errors.E(op, err)
+		}
+		return nil
+	}
+	data := map[string]interface{}{
+		caCertKey: caCert,
+	}
+	if _, err = client.KVv2(s.KVPath).Put(ctx, s.controllerCACertificatePath(controllerName), data); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // CleanupJWKS removes all secrets associated with the JWKS process.
 func (s *VaultStore) CleanupJWKS(ctx context.Context) (err error) {
 	const op = errors.Op("vault.CleanupJWKS")
@@ -460,6 +520,31 @@ func (s *VaultStore) deleteControllerCredentials(ctx context.Context, controller
 	return nil
 }
 
+// Ping checks that the vault service can be reached, is unsealed and
+// that JIMM can log in to it.
+func (s *VaultStore) Ping(ctx context.Context) (err error) {
+	const op = errors.Op("vault.Ping")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	health, err := s.Client.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !health.Initialized {
+		return errors.E(op, "vault is not initialized")
+	}
+	if health.Sealed {
+		return errors.E(op, "vault is sealed")
+	}
+	if _, err := s.client(ctx); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 const ttlLeeway time.Duration = 5 * time.Second
 
 func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
@@ -517,3 +602,7 @@ func (s *VaultStore) path(tag names.CloudCredentialTag) string {
 func (s *VaultStore) controllerCredentialsPath(controllerName string) string {
 	return path.Join("creds", controllerName)
 }
+
+func (s *VaultStore) controllerCACertificatePath(controllerName string) string {
+	return path.Join("controller-ca", controllerName)
+}
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
+	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery/dbrootkeystore"
 	"github.com/google/uuid"
 	"github.com/juju/names/v5"
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -122,6 +124,42 @@ func TestVaultControllerCredentialsStoreRoundTrip(t *testing.T) {
 	c.Check(p, qt.Equals, "")
 }
 
+func TestVaultRootKeyStoreRoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	st := newStore(c)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	rk1 := dbrootkeystore.RootKey{
+		Id:      []byte("root-key-1"),
+		Created: now.Add(-time.Minute),
+		Expires: now.Add(time.Hour),
+		RootKey: []byte("secret-1"),
+	}
+	rk2 := dbrootkeystore.RootKey{
+		Id:      []byte("root-key-2"),
+		Created: now,
+		Expires: now.Add(time.Hour),
+		RootKey: []byte("secret-2"),
+	}
+	c.Assert(st.InsertKey(rk1), qt.IsNil)
+	c.Assert(st.InsertKey(rk2), qt.IsNil)
+
+	rk, err := st.GetKey(rk1.Id)
+	c.Assert(err, qt.IsNil)
+	c.Check(rk, qt.DeepEquals, rk1)
+
+	_, err = st.GetKey([]byte("no-such-key"))
+	c.Check(err, qt.Equals, bakery.ErrNotFound)
+
+	rk, err = st.FindLatestKey(now.Add(-time.Hour), now, now.Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(rk, qt.DeepEquals, rk2)
+
+	rk, err = st.FindLatestKey(now, now, now.Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(rk, qt.DeepEquals, dbrootkeystore.RootKey{})
+}
+
 func TestGetAndPutJWKS(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
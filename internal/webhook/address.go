@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// CheckURL checks that webhooks may be delivered to the given URL. The
+// URL must use https and its host must not resolve to a loopback,
+// private, link-local or unspecified address, unless the address is in
+// one of the allowed networks. This prevents webhooks registered by
+// users from being used to reach services on JIMM's own network.
+func CheckURL(ctx context.Context, rawURL string, allowed []netip.Prefix) error {
+	const op = errors.Op("webhook.CheckURL")
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return errors.E(op, errors.CodeBadRequest, "invalid webhook URL")
+	}
+	if u.Scheme != "https" {
+		return errors.E(op, errors.CodeBadRequest, "webhook URL must use https")
+	}
+	host := u.Hostname()
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("cannot resolve webhook host %q", host))
+		}
+	}
+	for _, addr := range addrs {
+		if err := checkAddress(addr, allowed); err != nil {
+			return errors.E(op, errors.CodeBadRequest, err)
+		}
+	}
+	return nil
+}
+
+// checkAddress returns an error if webhooks may not be delivered to the
+// given address.
+func checkAddress(addr netip.Addr, allowed []netip.Prefix) error {
+	addr = addr.Unmap()
+	for _, p := range allowed {
+		if p.Contains(addr) {
+			return nil
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsUnspecified() || addr.IsMulticast() {
+		return fmt.Errorf("webhook destination %s is not allowed", addr)
+	}
+	return nil
+}
+
+// dialControl returns a function, for use as net.Dialer.Control, that
+// refuses connections to addresses that webhooks may not be delivered
+// to. Checking the address actually dialled guards against a host
+// resolving to a different address after CheckURL has been called.
+func dialControl(allowed []netip.Prefix) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		ap, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return err
+		}
+		return checkAddress(ap.Addr(), allowed)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// EventHeader is the header holding the name of the event being
+// delivered.
+const EventHeader = "X-JIMM-Event"
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	defaultTimeout     = 10 * time.Second
+)
+
+// A Sender delivers signed webhook payloads, retrying failed deliveries.
+type Sender struct {
+	// Client is the HTTP client used to deliver payloads. If this is
+	// nil a client with a short timeout is used.
+	Client *http.Client
+
+	// MaxAttempts is the maximum number of delivery attempts made for
+	// each payload. If this is zero five attempts are made.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. The delay doubles
+	// after every failed attempt. If this is zero a delay of one second
+	// is used.
+	Backoff time.Duration
+
+	// AllowedNetworks lists networks that may receive deliveries even
+	// though their addresses are loopback, private or link-local, see
+	// CheckURL.
+	AllowedNetworks []netip.Prefix
+
+	// Unrestricted, if set, disables the checks made on destination
+	// URLs. It should only be set when every destination is configured
+	// by a JIMM administrator.
+	Unrestricted bool
+}
+
+// Send posts the given JSON payload to the given URL, signed with the
+// given secret. Deliveries that fail because of a network error or a 5xx
+// or 429 response are retried. Send returns once the payload has been
+// accepted, the attempts are exhausted, or the context is cancelled.
+// Unless the Sender is unrestricted the URL is checked with CheckURL
+// before delivery, and the default client refuses to connect to
+// addresses CheckURL would reject.
+func (s *Sender) Send(ctx context.Context, url, event string, secret []byte, payload []byte) error {
+	const op = errors.Op("webhook.Send")
+
+	if !s.Unrestricted {
+		if err := CheckURL(ctx, url, s.AllowedNetworks); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	client := s.Client
+	if client == nil {
+		client = s.defaultClient()
+	}
+	maxAttempts := s.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = s.send(ctx, client, url, event, secret, payload)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return errors.E(op, ctx.Err())
+		}
+		backoff *= 2
+	}
+	return errors.E(op, err)
+}
+
+// defaultClient returns the client used when no Client is configured.
+func (s *Sender) defaultClient() *http.Client {
+	if s.Unrestricted {
+		return &http.Client{Timeout: defaultTimeout}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout: defaultTimeout,
+		Control: dialControl(s.AllowedNetworks),
+	}).DialContext
+	return &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: transport,
+	}
+}
+
+// send makes a single delivery attempt, reporting whether a failed
+// attempt may be retried.
+func (s *Sender) send(ctx context.Context, client *http.Client, url, event string, secret []byte, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	SignRequest(req, secret, time.Now(), payload)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Canonical.
+
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+func TestSend(t *testing.T) {
+	c := qt.New(t)
+
+	secret := []byte("test-secret")
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(req.Header.Get(webhook.EventHeader), qt.Equals, "unit-error")
+		c.Check(webhook.Verify(secret, req.Header, body, time.Now(), 0), qt.IsNil)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := webhook.Sender{Backoff: time.Millisecond, Unrestricted: true}
+	err := s.Send(context.Background(), srv.URL, "unit-error", secret, []byte(`{"event":"unit-error"}`))
+	c.Assert(err, qt.IsNil)
+	c.Check(calls.Load(), qt.Equals, int32(2))
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	c := qt.New(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := webhook.Sender{Backoff: time.Millisecond, Unrestricted: true}
+	err := s.Send(context.Background(), srv.URL, "unit-error", []byte("secret"), []byte(`{}`))
+	c.Assert(err, qt.ErrorMatches, `webhook returned status 400`)
+	c.Check(calls.Load(), qt.Equals, int32(1))
+}
+
+func TestSendRestrictsDestinations(t *testing.T) {
+	c := qt.New(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// The test server listens on a loopback address.
+	s := webhook.Sender{Backoff: time.Millisecond}
+	err := s.Send(context.Background(), srv.URL, "unit-error", []byte("secret"), []byte(`{}`))
+	c.Check(err, qt.ErrorMatches, `webhook destination 127.0.0.1 is not allowed`)
+
+	c.Check(calls.Load(), qt.Equals, int32(0))
+
+	// The default client checks the address actually dialled, in case
+	// the host resolves differently when connecting.
+	control := webhook.DialControl(nil)
+	c.Check(control("tcp", "127.0.0.1:443", nil), qt.ErrorMatches, `webhook destination 127.0.0.1 is not allowed`)
+	c.Check(control("tcp", "203.0.113.10:443", nil), qt.IsNil)
+
+	s = webhook.Sender{
+		Client:          srv.Client(),
+		Backoff:         time.Millisecond,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+	}
+	err = s.Send(context.Background(), srv.URL, "unit-error", []byte("secret"), []byte(`{}`))
+	c.Assert(err, qt.IsNil)
+	c.Check(calls.Load(), qt.Equals, int32(1))
+}
+
+func TestCheckURL(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		url         string
+		allowed     []netip.Prefix
+		expectError string
+	}{{
+		url: "https://203.0.113.10/hook",
+	}, {
+		url:         "not a url",
+		expectError: "invalid webhook URL",
+	}, {
+		url:         "http://203.0.113.10/hook",
+		expectError: "webhook URL must use https",
+	}, {
+		url:         "https://127.0.0.1/hook",
+		expectError: "webhook destination 127.0.0.1 is not allowed",
+	}, {
+		url:         "https://[::1]/hook",
+		expectError: `webhook destination ::1 is not allowed`,
+	}, {
+		url:         "https://10.1.2.3:8443/hook",
+		expectError: "webhook destination 10.1.2.3 is not allowed",
+	}, {
+		url:         "https://169.254.169.254/latest/meta-data",
+		expectError: "webhook destination 169.254.169.254 is not allowed",
+	}, {
+		url:         "https://[::ffff:192.168.0.1]/hook",
+		expectError: "webhook destination 192.168.0.1 is not allowed",
+	}, {
+		url:         "https://0.0.0.0/hook",
+		expectError: "webhook destination 0.0.0.0 is not allowed",
+	}, {
+		url:     "https://10.1.2.3/hook",
+		allowed: []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+	}}
+	for _, test := range tests {
+		c.Run(test.url, func(c *qt.C) {
+			err := webhook.CheckURL(ctx, test.url, test.allowed)
+			if test.expectError == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+			c.Check(err, qt.ErrorMatches, test.expectError)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Canonical.
+
+package webhook_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/webhook"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	c := qt.New(t)
+
+	secret := []byte("endpoint-secret")
+	payload := []byte(`{"event":"model-created"}`)
+	now := time.Unix(1700000000, 0)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(payload)))
+	webhook.SignRequest(req, secret, now, payload)
+	c.Check(req.Header.Get(webhook.TimestampHeader), qt.Equals, "1700000000")
+	c.Check(req.Header.Get(webhook.SignatureHeader), qt.Matches, `v1=[0-9a-f]{64}`)
+
+	c.Check(webhook.Verify(secret, req.Header, payload, now.Add(time.Minute), 0), qt.IsNil)
+
+	err := webhook.Verify([]byte("other-secret"), req.Header, payload, now, 0)
+	c.Check(err, qt.ErrorMatches, "invalid webhook signature")
+
+	err = webhook.Verify(secret, req.Header, []byte(`{"event":"model-destroyed"}`), now, 0)
+	c.Check(err, qt.ErrorMatches, "invalid webhook signature")
+
+	err = webhook.Verify(secret, req.Header, payload, now.Add(10*time.Minute), 0)
+	c.Check(err, qt.ErrorMatches, "webhook timestamp outside tolerance")
+
+	err = webhook.Verify(secret, http.Header{}, payload, now, 0)
+	c.Check(err, qt.ErrorMatches, "missing webhook signature")
+}
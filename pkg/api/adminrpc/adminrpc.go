@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+// Package adminrpc contains the definition of, and a client for, JIMM's
+// gRPC admin API. The service is defined in admin.proto, every method
+// takes and returns a google.protobuf.Struct holding the JSON encoding
+// of the equivalent websocket facade method's parameters and results.
+package adminrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ServiceName is the fully qualified name of the admin gRPC service.
+const ServiceName = "jimm.admin.v1.Admin"
+
+// Marshal returns the JSON encoding of v as a Struct. A nil v is encoded
+// as an empty Struct.
+func Marshal(v interface{}) (*structpb.Struct, error) {
+	s := new(structpb.Struct)
+	if v == nil {
+		return s, nil
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UnmarshalJSON(buf); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Unmarshal decodes the Struct s into v, which must be a pointer to a
+// value that can be unmarshaled from JSON. A nil s leaves v unchanged.
+func Unmarshal(s *structpb.Struct, v interface{}) error {
+	if s == nil {
+		return nil
+	}
+	buf, err := s.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// A Client is a client for JIMM's gRPC admin API.
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewClient returns a new Client using the given connection. The
+// connection must authenticate with a TLS client certificate trusted by
+// JIMM.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+// Call calls the named method of the admin service. The request, which
+// may be nil, is encoded as JSON and the response is decoded from JSON
+// into resp, which may be nil if the response is not required. The
+// request and response types are those of the equivalent websocket
+// facade method, for example params.FindAuditEventsRequest and
+// params.AuditEvents for FindAuditEvents.
+func (c *Client) Call(ctx context.Context, method string, req, resp interface{}, opts ...grpc.CallOption) error {
+	in, err := Marshal(req)
+	if err != nil {
+		return err
+	}
+	out := new(structpb.Struct)
+	if err := c.conn.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, opts...); err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	return Unmarshal(out, resp)
+}
@@ -53,6 +53,44 @@ func (c *Client) FindAuditEvents(req *params.FindAuditEventsRequest) (params.Aud
 	return resp, nil
 }
 
+// GrantControllerAccess grants the given JIMM controller access to the
+// given users.
+func (c *Client) GrantControllerAccess(req *params.ControllerAccessRequest) (params.ControllerAccessResults, error) {
+	var resp params.ControllerAccessResults
+	err := c.caller.APICall("JIMM", 4, "", "GrantControllerAccess", req, &resp)
+	return resp, err
+}
+
+// ListSessions returns the active login sessions.
+func (c *Client) ListSessions(req *params.ListSessionsRequest) (params.ListSessionsResponse, error) {
+	var resp params.ListSessionsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListSessions", req, &resp)
+	return resp, err
+}
+
+// RevokeSessions revokes all the active login sessions of a user.
+func (c *Client) RevokeSessions(req *params.RevokeSessionsRequest) (params.RevokeSessionsResponse, error) {
+	var resp params.RevokeSessionsResponse
+	err := c.caller.APICall("JIMM", 4, "", "RevokeSessions", req, &resp)
+	return resp, err
+}
+
+// UsageReport returns the machine and unit usage of the models over a
+// period.
+func (c *Client) UsageReport(req *params.UsageReportRequest) (params.UsageReportResponse, error) {
+	var resp params.UsageReportResponse
+	err := c.caller.APICall("JIMM", 4, "", "UsageReport", req, &resp)
+	return resp, err
+}
+
+// RevokeControllerAccess revokes the given JIMM controller access from the
+// given users.
+func (c *Client) RevokeControllerAccess(req *params.ControllerAccessRequest) (params.ControllerAccessResults, error) {
+	var resp params.ControllerAccessResults
+	err := c.caller.APICall("JIMM", 4, "", "RevokeControllerAccess", req, &resp)
+	return resp, err
+}
+
 // GrantAuditLogAccess grants the given access to the audit log to the
 // given user.
 func (c *Client) GrantAuditLogAccess(req *params.AuditLogAccessRequest) error {
@@ -67,6 +105,14 @@ func (c *Client) ListControllers() ([]params.ControllerInfo, error) {
 	return resp.Controllers, err
 }
 
+// MigrateControllerCredentials moves controller admin credentials stored
+// in JIMM's database into the configured credential store.
+func (c *Client) MigrateControllerCredentials() (params.MigrateControllerCredentialsResponse, error) {
+	var resp params.MigrateControllerCredentialsResponse
+	err := c.caller.APICall("JIMM", 4, "", "MigrateControllerCredentials", nil, &resp)
+	return resp, err
+}
+
 // RemoveCloudFromController removes the specified cloud from a specific controller.
 func (c *Client) RemoveCloudFromController(req *params.RemoveCloudFromControllerRequest) error {
 	return c.caller.APICall("JIMM", 4, "", "RemoveCloudFromController", req, nil)
@@ -95,6 +141,14 @@ func (c *Client) SetControllerDeprecated(req *params.SetControllerDeprecatedRequ
 	return info, err
 }
 
+// DrainController sets the draining status of a controller, optionally
+// migrating its models to other controllers.
+func (c *Client) DrainController(req *params.DrainControllerRequest) (*params.DrainControllerResponse, error) {
+	var response params.DrainControllerResponse
+	err := c.caller.APICall("JIMM", 4, "", "DrainController", req, &response)
+	return &response, err
+}
+
 // FullModelStatus returns the full status of the juju model.
 func (c *Client) FullModelStatus(req *params.FullModelStatusRequest) (jujuparams.FullStatus, error) {
 	var status jujuparams.FullStatus
@@ -177,6 +231,57 @@ func (c *Client) ListRelationshipTuples(req *params.ListRelationshipTuplesReques
 	return &response, err
 }
 
+// ExportRelations returns a page of the relation tuples stored in
+// OpenFGA.
+func (c *Client) ExportRelations(req *params.ExportRelationsRequest) (*params.ExportRelationsResponse, error) {
+	var response params.ExportRelationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ExportRelations", req, &response)
+	return &response, err
+}
+
+// ImportRelations adds exported relation tuples to OpenFGA.
+func (c *Client) ImportRelations(req *params.ImportRelationsRequest) (*params.ImportRelationsResponse, error) {
+	var response params.ImportRelationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ImportRelations", req, &response)
+	return &response, err
+}
+
+// AddTemporaryRelation adds a relational tuple in JIMM that is removed
+// automatically once the requested duration has passed.
+func (c *Client) AddTemporaryRelation(req *params.AddTemporaryRelationRequest) (*params.TemporaryGrant, error) {
+	var response params.TemporaryGrant
+	err := c.caller.APICall("JIMM", 4, "", "AddTemporaryRelation", req, &response)
+	return &response, err
+}
+
+// ListEveryoneDefaults returns the relations granted to every user.
+func (c *Client) ListEveryoneDefaults() (*params.ListEveryoneDefaultsResponse, error) {
+	var response params.ListEveryoneDefaultsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListEveryoneDefaults", nil, &response)
+	return &response, err
+}
+
+// SetEveryoneDefault grants or revokes a relation for every user.
+func (c *Client) SetEveryoneDefault(req *params.SetEveryoneDefaultRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetEveryoneDefault", req, nil)
+}
+
+// Reconcile compares JIMM's database with the tuples in OpenFGA,
+// repairing any differences if requested.
+func (c *Client) Reconcile(req *params.ReconcileRequest) (*params.ReconcileResponse, error) {
+	var response params.ReconcileResponse
+	err := c.caller.APICall("JIMM", 4, "", "Reconcile", req, &response)
+	return &response, err
+}
+
+// ListTemporaryRelations returns the temporary relations that have not
+// yet expired.
+func (c *Client) ListTemporaryRelations() (*params.ListTemporaryRelationsResponse, error) {
+	var response params.ListTemporaryRelationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListTemporaryRelations", nil, &response)
+	return &response, err
+}
+
 // CrossModelQuery enables users to query all of their available models and each entity within the model.
 //
 // The query will run against output exactly like "juju status --format json", but for each of their models.
@@ -186,6 +291,25 @@ func (c *Client) CrossModelQuery(req *params.CrossModelQueryRequest) (*params.Cr
 	return &response, err
 }
 
+// OffboardUser removes all access, cloud credentials and sessions of a
+// user, optionally giving their models to another user.
+func (c *Client) OffboardUser(req *params.OffboardUserRequest) (*params.OffboardUserResponse, error) {
+	var response params.OffboardUserResponse
+	err := c.caller.APICall("JIMM", 4, "", "OffboardUser", req, &response)
+	return &response, err
+}
+
+// SetCloudRegionDisabled enables or disables the placement of new models
+// in a cloud region.
+func (c *Client) SetCloudRegionDisabled(req *params.SetCloudRegionDisabledRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetCloudRegionDisabled", req, nil)
+}
+
+// TransferModelOwnership makes the given user the owner of a model.
+func (c *Client) TransferModelOwnership(req *params.TransferModelOwnershipRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "TransferModelOwnership", req, nil)
+}
+
 // PurgeLogs purges logs from the database before the given date.
 func (c *Client) PurgeLogs(req *params.PurgeLogsRequest) (*params.PurgeLogsResponse, error) {
 	var response params.PurgeLogsResponse
@@ -193,6 +317,19 @@ func (c *Client) PurgeLogs(req *params.PurgeLogsRequest) (*params.PurgeLogsRespo
 	return &response, err
 }
 
+// RotateSessionKey replaces the key JIMM uses to sign session tokens.
+func (c *Client) RotateSessionKey() error {
+	return c.caller.APICall("JIMM", 4, "", "RotateSessionKey", nil, nil)
+}
+
+// GetModelArchive returns the snapshot of a model taken when it was
+// destroyed.
+func (c *Client) GetModelArchive(req *params.GetModelArchiveRequest) (*params.ModelArchive, error) {
+	var response params.ModelArchive
+	err := c.caller.APICall("JIMM", 4, "", "GetModelArchive", req, &response)
+	return &response, err
+}
+
 // MigrateModel migrates a model between two controllers that are attached to JIMM.
 func (c *Client) MigrateModel(req *params.MigrateModelRequest) (*jujuparams.InitiateMigrationResults, error) {
 	var response jujuparams.InitiateMigrationResults
@@ -200,6 +337,22 @@ func (c *Client) MigrateModel(req *params.MigrateModelRequest) (*jujuparams.Init
 	return &response, err
 }
 
+// ScheduleMigrations queues the migration of models to controllers
+// attached to JIMM. The migrations are pre-checked and run in the
+// background, their progress can be retrieved with ListMigrations.
+func (c *Client) ScheduleMigrations(req *params.ScheduleMigrationsRequest) ([]params.ScheduleMigrationResult, error) {
+	var response params.ScheduleMigrationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ScheduleMigrations", req, &response)
+	return response.Results, err
+}
+
+// ListMigrations returns the migrations queued with ScheduleMigrations.
+func (c *Client) ListMigrations(req *params.ListMigrationsRequest) ([]params.MigrationStatus, error) {
+	var response params.ListMigrationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListMigrations", req, &response)
+	return response.Migrations, err
+}
+
 // AddServiceAccount binds a service account to a user allowing them to manage it.
 func (c *Client) AddServiceAccount(req *params.AddServiceAccountRequest) error {
 	return c.caller.APICall("JIMM", 4, "", "AddServiceAccount", req, nil)
@@ -231,9 +384,356 @@ func (c *Client) GrantServiceAccountAccess(req *params.GrantServiceAccountAccess
 	return c.caller.APICall("JIMM", 4, "", "GrantServiceAccountAccess", req, nil)
 }
 
+// SetServiceAccountAllowedCIDRs sets the network ranges a service account
+// may authenticate from.
+func (c *Client) SetServiceAccountAllowedCIDRs(req *params.SetServiceAccountAllowedCIDRsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetServiceAccountAllowedCIDRs", req, nil)
+}
+
+// ListServiceAccounts lists the service accounts the user administers.
+func (c *Client) ListServiceAccounts() ([]params.ServiceAccount, error) {
+	var response params.ListServiceAccountsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListServiceAccounts", nil, &response)
+	return response.ServiceAccounts, err
+}
+
+// SetServiceAccountDisabled disables, or re-enables, a service account.
+func (c *Client) SetServiceAccountDisabled(req *params.SetServiceAccountDisabledRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetServiceAccountDisabled", req, nil)
+}
+
+// DeleteServiceAccount removes a service account from JIMM.
+func (c *Client) DeleteServiceAccount(req *params.DeleteServiceAccountRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "DeleteServiceAccount", req, nil)
+}
+
+// WatchAllModels starts a watcher returning the deltas for all models the
+// user can read, across every controller. The returned ID is used with
+// AllModelWatcherNext and AllModelWatcherStop.
+func (c *Client) WatchAllModels(req *params.WatchAllModelsRequest) (string, error) {
+	var response jujuparams.AllWatcherId
+	err := c.caller.APICall("JIMM", 4, "", "WatchAllModels", req, &response)
+	return response.AllWatcherId, err
+}
+
+// AllModelWatcherNext returns the next set of deltas from the watcher with
+// the given ID.
+func (c *Client) AllModelWatcherNext(id string) ([]jujuparams.Delta, error) {
+	var response jujuparams.AllWatcherNextResults
+	err := c.caller.APICall("AllModelWatcher", 4, id, "Next", nil, &response)
+	return response.Deltas, err
+}
+
+// AllModelWatcherStop stops the watcher with the given ID.
+func (c *Client) AllModelWatcherStop(id string) error {
+	return c.caller.APICall("AllModelWatcher", 4, id, "Stop", nil, nil)
+}
+
+// WatchModelSummaries starts a model summary watcher returning only the
+// summaries of models matching the given filters. The returned watcher ID
+// is used with the ModelSummaryWatcher facade.
+func (c *Client) WatchModelSummaries(req *params.WatchModelSummariesRequest) (params.WatchModelSummariesResponse, error) {
+	var response params.WatchModelSummariesResponse
+	err := c.caller.APICall("JIMM", 4, "", "WatchModelSummaries", req, &response)
+	return response, err
+}
+
+// WatchAccess starts a watcher that reports changes to the models the
+// user has access to. The returned watcher ID is used with
+// AccessWatcherNext and AccessWatcherStop.
+func (c *Client) WatchAccess() (params.WatchAccessResponse, error) {
+	var response params.WatchAccessResponse
+	err := c.caller.APICall("JIMM", 4, "", "WatchAccess", nil, &response)
+	return response, err
+}
+
+// AccessWatcherNext returns the next set of access changes from the
+// watcher with the given ID. The first call returns the user's current
+// access to every model.
+func (c *Client) AccessWatcherNext(id string) ([]params.AccessChange, error) {
+	var response params.AccessWatcherNextResponse
+	err := c.caller.APICall("AccessWatcher", 1, id, "Next", nil, &response)
+	return response.Changes, err
+}
+
+// AccessWatcherStop stops the watcher with the given ID.
+func (c *Client) AccessWatcherStop(id string) error {
+	return c.caller.APICall("AccessWatcher", 1, id, "Stop", nil, nil)
+}
+
+// WatchAuditEvents starts a watcher that returns new audit events
+// matching the given filters as they are added. The returned watcher ID
+// is used with AuditEventWatcherNext and AuditEventWatcherStop.
+func (c *Client) WatchAuditEvents(req *params.WatchAuditEventsRequest) (params.WatchAuditEventsResponse, error) {
+	var response params.WatchAuditEventsResponse
+	err := c.caller.APICall("JIMM", 4, "", "WatchAuditEvents", req, &response)
+	return response, err
+}
+
+// AuditEventWatcherNext returns the next set of audit events from the
+// watcher with the given ID.
+func (c *Client) AuditEventWatcherNext(id string) (params.AuditEventWatcherNextResponse, error) {
+	var response params.AuditEventWatcherNextResponse
+	err := c.caller.APICall("AuditEventWatcher", 1, id, "Next", nil, &response)
+	return response, err
+}
+
+// AuditEventWatcherStop stops the watcher with the given ID.
+func (c *Client) AuditEventWatcherStop(id string) error {
+	return c.caller.APICall("AuditEventWatcher", 1, id, "Stop", nil, nil)
+}
+
+// ResumeWatcher continues, on this connection, a watcher started on an
+// earlier connection using the resume token returned when it was
+// started.
+func (c *Client) ResumeWatcher(req *params.ResumeWatcherRequest) (params.ResumeWatcherResponse, error) {
+	var response params.ResumeWatcherResponse
+	err := c.caller.APICall("JIMM", 4, "", "ResumeWatcher", req, &response)
+	return response, err
+}
+
+// AddModelWebhook registers a webhook on a model.
+func (c *Client) AddModelWebhook(req *params.AddModelWebhookRequest) (params.AddModelWebhookResponse, error) {
+	var response params.AddModelWebhookResponse
+	err := c.caller.APICall("JIMM", 4, "", "AddModelWebhook", req, &response)
+	return response, err
+}
+
+// ListModelWebhooks returns the webhooks registered on a model.
+func (c *Client) ListModelWebhooks(req *params.ListModelWebhooksRequest) ([]params.ModelWebhook, error) {
+	var response params.ListModelWebhooksResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelWebhooks", req, &response)
+	return response.Webhooks, err
+}
+
+// RemoveModelWebhook removes a webhook from a model.
+func (c *Client) RemoveModelWebhook(req *params.RemoveModelWebhookRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelWebhook", req, nil)
+}
+
+// ListLimits returns the limits on resource usage.
+func (c *Client) ListLimits() ([]params.Limit, error) {
+	var response params.ListLimitsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListLimits", nil, &response)
+	return response.Limits, err
+}
+
+// SetLimit sets a limit on resource usage.
+func (c *Client) SetLimit(req *params.SetLimitRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetLimit", req, nil)
+}
+
+// RemoveLimit removes a limit on resource usage.
+func (c *Client) RemoveLimit(req *params.RemoveLimitRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveLimit", req, nil)
+}
+
+// AddNotificationRoute adds a route along which notifications are
+// delivered.
+func (c *Client) AddNotificationRoute(req *params.AddNotificationRouteRequest) (params.NotificationRoute, error) {
+	var response params.NotificationRoute
+	err := c.caller.APICall("JIMM", 4, "", "AddNotificationRoute", req, &response)
+	return response, err
+}
+
+// ListNotificationRoutes returns the notification routes visible to the
+// authenticated user.
+func (c *Client) ListNotificationRoutes() ([]params.NotificationRoute, error) {
+	var response params.ListNotificationRoutesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListNotificationRoutes", nil, &response)
+	return response.Routes, err
+}
+
+// RemoveNotificationRoute removes a notification route.
+func (c *Client) RemoveNotificationRoute(req *params.RemoveNotificationRouteRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveNotificationRoute", req, nil)
+}
+
+// SetModelConfigTemplate creates, or replaces, a model config template.
+func (c *Client) SetModelConfigTemplate(req *params.SetModelConfigTemplateRequest) (params.ModelConfigTemplate, error) {
+	var response params.ModelConfigTemplate
+	err := c.caller.APICall("JIMM", 4, "", "SetModelConfigTemplate", req, &response)
+	return response, err
+}
+
+// GetModelConfigTemplate returns a model config template.
+func (c *Client) GetModelConfigTemplate(req *params.ModelConfigTemplateRequest) (params.ModelConfigTemplate, error) {
+	var response params.ModelConfigTemplate
+	err := c.caller.APICall("JIMM", 4, "", "GetModelConfigTemplate", req, &response)
+	return response, err
+}
+
+// ListModelConfigTemplates returns the model config templates the
+// authenticated user may use.
+func (c *Client) ListModelConfigTemplates() ([]params.ModelConfigTemplate, error) {
+	var response params.ListModelConfigTemplatesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelConfigTemplates", nil, &response)
+	return response.Templates, err
+}
+
+// RemoveModelConfigTemplate removes a model config template.
+func (c *Client) RemoveModelConfigTemplate(req *params.ModelConfigTemplateRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelConfigTemplate", req, nil)
+}
+
+// SetModelAlias gives a model an alias for the authenticated user.
+func (c *Client) SetModelAlias(req *params.SetModelAliasRequest) (params.ModelAlias, error) {
+	var response params.ModelAlias
+	err := c.caller.APICall("JIMM", 4, "", "SetModelAlias", req, &response)
+	return response, err
+}
+
+// ListModelAliases returns the authenticated user's model aliases.
+func (c *Client) ListModelAliases() ([]params.ModelAlias, error) {
+	var response params.ListModelAliasesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelAliases", nil, &response)
+	return response.Aliases, err
+}
+
+// RemoveModelAlias removes one of the authenticated user's model
+// aliases.
+func (c *Client) RemoveModelAlias(req *params.RemoveModelAliasRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelAlias", req, nil)
+}
+
+// ListModelStatuses returns the status of every model the authenticated
+// user can read, optionally restricted to models with matching labels.
+func (c *Client) ListModelStatuses(req *params.ListModelStatusesRequest) ([]params.ModelStatus, error) {
+	var response params.ListModelStatusesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelStatuses", req, &response)
+	return response.Models, err
+}
+
+// SetModelLabels replaces the labels set on a model.
+func (c *Client) SetModelLabels(req *params.SetModelLabelsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetModelLabels", req, nil)
+}
+
+// SetCloudCredentialExpiry records the time at which a cloud credential
+// expires.
+func (c *Client) SetCloudCredentialExpiry(req *params.SetCloudCredentialExpiryRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetCloudCredentialExpiry", req, nil)
+}
+
+// ListExpiringCredentials returns the cloud credentials that expire within
+// the requested period.
+func (c *Client) ListExpiringCredentials(req *params.ListExpiringCredentialsRequest) ([]params.ExpiringCredential, error) {
+	var response params.ListExpiringCredentialsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListExpiringCredentials", req, &response)
+	return response.Credentials, err
+}
+
+// ListCloudCredentials returns the cloud credentials that match the
+// request.
+func (c *Client) ListCloudCredentials(req *params.ListCloudCredentialsRequest) ([]params.CloudCredentialInfo, error) {
+	var response params.ListCloudCredentialsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListCloudCredentials", req, &response)
+	return response.Credentials, err
+}
+
+// ListDeprecatedFacadeUsage returns the clients that have called
+// deprecated facade versions since JIMM started.
+func (c *Client) ListDeprecatedFacadeUsage() ([]params.DeprecatedFacadeUsage, error) {
+	var response params.ListDeprecatedFacadeUsageResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListDeprecatedFacadeUsage", nil, &response)
+	return response.Usage, err
+}
+
+// Backup returns a snapshot of JIMM's database and OpenFGA relation
+// tuples.
+func (c *Client) Backup() (*params.Backup, error) {
+	var response params.Backup
+	err := c.caller.APICall("JIMM", 4, "", "Backup", nil, &response)
+	return &response, err
+}
+
+// Restore restores a backup into a fresh JIMM deployment.
+func (c *Client) Restore(req *params.RestoreRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "Restore", req, nil)
+}
+
+// ServiceSelfTest checks each of the services JIMM depends on and
+// returns the result of every check.
+func (c *Client) ServiceSelfTest() (*params.ServiceSelfTestResponse, error) {
+	var response params.ServiceSelfTestResponse
+	err := c.caller.APICall("JIMM", 4, "", "ServiceSelfTest", nil, &response)
+	return &response, err
+}
+
+// SearchOffers returns the application offers, across all controllers,
+// that the user can consume and that match the request.
+func (c *Client) SearchOffers(req *params.SearchOffersRequest) (*params.SearchOffersResponse, error) {
+	var response params.SearchOffersResponse
+	err := c.caller.APICall("JIMM", 4, "", "SearchOffers", req, &response)
+	return &response, err
+}
+
+// AddMaintenanceWindow declares a maintenance window for a controller.
+func (c *Client) AddMaintenanceWindow(req *params.AddMaintenanceWindowRequest) (params.MaintenanceWindow, error) {
+	var response params.MaintenanceWindow
+	err := c.caller.APICall("JIMM", 4, "", "AddMaintenanceWindow", req, &response)
+	return response, err
+}
+
+// ListMaintenanceWindows returns controller maintenance windows.
+func (c *Client) ListMaintenanceWindows(req *params.ListMaintenanceWindowsRequest) ([]params.MaintenanceWindow, error) {
+	var response params.ListMaintenanceWindowsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListMaintenanceWindows", req, &response)
+	return response.Windows, err
+}
+
+// RemoveMaintenanceWindow removes a controller maintenance window.
+func (c *Client) RemoveMaintenanceWindow(req *params.RemoveMaintenanceWindowRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveMaintenanceWindow", req, nil)
+}
+
+// SetModelConfigPolicy sets the policy for a model config key.
+func (c *Client) SetModelConfigPolicy(req *params.SetModelConfigPolicyRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetModelConfigPolicy", req, nil)
+}
+
+// ListModelConfigPolicies returns the model config policies.
+func (c *Client) ListModelConfigPolicies() ([]params.ModelConfigPolicy, error) {
+	var response params.ListModelConfigPoliciesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelConfigPolicies", nil, &response)
+	return response.Policies, err
+}
+
+// RemoveModelConfigPolicy removes the policy for a model config key.
+func (c *Client) RemoveModelConfigPolicy(req *params.RemoveModelConfigPolicyRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelConfigPolicy", req, nil)
+}
+
+// GetMaintenanceMode returns JIMM's maintenance mode.
+func (c *Client) GetMaintenanceMode() (*params.MaintenanceMode, error) {
+	var response params.MaintenanceMode
+	err := c.caller.APICall("JIMM", 4, "", "GetMaintenanceMode", nil, &response)
+	return &response, err
+}
+
+// SetMaintenanceMode enables or disables JIMM's maintenance mode.
+func (c *Client) SetMaintenanceMode(req *params.SetMaintenanceModeRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetMaintenanceMode", req, nil)
+}
+
 // Version returns version info of the controller.
 func (c *Client) Version() (params.VersionResponse, error) {
 	var response params.VersionResponse
 	err := c.caller.APICall("JIMM", 4, "", "Version", nil, &response)
 	return response, err
 }
+
+// AddModelAsync starts creating a model and returns the ID of the task
+// creating it without waiting for the model to be created.
+func (c *Client) AddModelAsync(req *jujuparams.ModelCreateArgs) (uint, error) {
+	var response params.AddModelAsyncResponse
+	err := c.caller.APICall("JIMM", 4, "", "AddModelAsync", req, &response)
+	return response.TaskID, err
+}
+
+// GetTaskStatus returns the status of a long-running task.
+func (c *Client) GetTaskStatus(req *params.GetTaskStatusRequest) (*params.TaskStatus, error) {
+	var response params.TaskStatus
+	err := c.caller.APICall("JIMM", 4, "", "GetTaskStatus", req, &response)
+	return &response, err
+}
@@ -3,5 +3,8 @@
 package params
 
 const (
-	CodeStillAlive = "still alive"
+	CodeStillAlive        = "still alive"
+	CodePolicyViolation   = "policy violation"
+	CodeCredentialInvalid = "credential invalid"
+	CodeTooManyRequests   = "too many requests"
 )
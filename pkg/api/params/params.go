@@ -3,6 +3,7 @@
 package params
 
 import (
+	"encoding/json"
 	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
@@ -26,6 +27,9 @@ type RemoveCloudFromControllerRequest struct {
 	// ControllerName is the name of the controller from which the
 	// cloud should be removed.
 	ControllerName string `json:"controller-name"`
+	// Reason is a free-text justification for the removal. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
 }
 
 // An AddControllerRequest is the request sent when adding a new controller
@@ -47,6 +51,15 @@ type AddControllerRequest struct {
 	// TLSHostname is the hostname used for TLS verification.
 	TLSHostname string `json:"tls-hostname,omitempty"`
 
+	// SRVName is a DNS SRV name that is resolved to find the
+	// controller's API endpoints each time JIMM connects to it. If it is
+	// set the resolved endpoints are used in preference to APIAddresses.
+	SRVName string `json:"srv-name,omitempty"`
+
+	// DashboardURL is the URL of the Juju Dashboard serving the
+	// controller, if any.
+	DashboardURL string `json:"dashboard-url,omitempty"`
+
 	// APIAddresses contains the currently known API addresses for the
 	// controller.
 	APIAddresses []string `json:"api-addresses,omitempty"`
@@ -76,6 +89,80 @@ type AuditLogAccessRequest struct {
 	Level string `json:"level"`
 }
 
+// ControllerAccessRequest is the request used to modify the JIMM
+// controller access of a number of users.
+type ControllerAccessRequest struct {
+	// UserTags holds the users whose access is being modified.
+	UserTags []string `json:"user-tags"`
+
+	// Access is the access level being granted or revoked, one of
+	// "login", "add-model" or "superuser".
+	Access string `json:"access"`
+}
+
+// ControllerAccessResults holds the results of modifying the JIMM
+// controller access of a number of users.
+type ControllerAccessResults struct {
+	// Results holds the result for each user, in the order they were
+	// requested.
+	Results []ControllerAccessResult `json:"results" yaml:"results"`
+}
+
+// A ControllerAccessResult is the result of modifying the JIMM controller
+// access of a single user.
+type ControllerAccessResult struct {
+	// UserTag is the user whose access was modified.
+	UserTag string `json:"user-tag" yaml:"user-tag"`
+
+	// Error holds the reason the user's access could not be modified,
+	// if it could not.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ListSessionsRequest holds the request information to list the active
+// login sessions.
+type ListSessionsRequest struct {
+	// UserTag optionally restricts the sessions to those of the given
+	// user.
+	UserTag string `json:"user-tag,omitempty"`
+}
+
+// Session holds the details of an active login session.
+type Session struct {
+	// ID is the ID of the session.
+	ID uint `json:"id" yaml:"id"`
+
+	// User is the name of the identity that logged in.
+	User string `json:"user" yaml:"user"`
+
+	// Kind is the kind of session, either "browser" or "device".
+	Kind string `json:"kind" yaml:"kind"`
+
+	// CreatedAt is the time at which the session was created.
+	CreatedAt string `json:"created-at" yaml:"created-at"`
+
+	// ExpiresAt is the time at which the session expires.
+	ExpiresAt string `json:"expires-at" yaml:"expires-at"`
+}
+
+// ListSessionsResponse holds the response of the ListSessions method.
+type ListSessionsResponse struct {
+	Sessions []Session `json:"sessions" yaml:"sessions"`
+}
+
+// RevokeSessionsRequest holds the request information to revoke the
+// login sessions of a user.
+type RevokeSessionsRequest struct {
+	// UserTag is the user whose sessions are revoked.
+	UserTag string `json:"user-tag"`
+}
+
+// RevokeSessionsResponse holds the response of the RevokeSessions method.
+type RevokeSessionsResponse struct {
+	// Revoked is the number of sessions that were revoked.
+	Revoked int `json:"revoked" yaml:"revoked"`
+}
+
 const (
 	// AuditActionCreate is the Action value in an audit entry that
 	// creates an entity.
@@ -124,6 +211,10 @@ type AuditEvent struct {
 	// Model contains the name of the model the event was performed against.
 	Model string `json:"model,omitempty" yaml:"model,omitempty"`
 
+	// ModelUUID contains the UUID of the model the event was performed
+	// against.
+	ModelUUID string `json:"model-uuid,omitempty" yaml:"model-uuid,omitempty"`
+
 	// IsResponse indicates whether the message is a request/response.
 	IsResponse bool `json:"is-response" yaml:"is-response"`
 
@@ -137,6 +228,28 @@ type AuditEvent struct {
 // An AuditEvents contains events from the audit log.
 type AuditEvents struct {
 	Events []AuditEvent `json:"events"`
+
+	// NextCursor is the cursor used to fetch the next page of events. It
+	// is empty when there are no further events.
+	NextCursor string `json:"next-cursor,omitempty" yaml:"next-cursor,omitempty"`
+}
+
+// A DiscoveredCloudRegion is a cloud region found on a controller when
+// it was added to JIMM.
+type DiscoveredCloudRegion struct {
+	// Cloud is the name of the cloud containing the region.
+	Cloud string `json:"cloud"`
+
+	// Region is the name of the region.
+	Region string `json:"region"`
+
+	// Priority is the priority given to the controller when selecting
+	// a controller for the region.
+	Priority uint `json:"priority"`
+
+	// New is true if the cloud region was not known to JIMM before the
+	// controller was added.
+	New bool `json:"new"`
 }
 
 // A ControllerInfo describes a controller on a JIMM system.
@@ -153,6 +266,14 @@ type ControllerInfo struct {
 	// themselves are migrated.
 	PublicAddress string `json:"public-address,omitempty"`
 
+	// SRVName is the DNS SRV name resolved to find the controller's API
+	// endpoints, if any.
+	SRVName string `json:"srv-name,omitempty"`
+
+	// DashboardURL is the URL of the Juju Dashboard serving the
+	// controller, if any.
+	DashboardURL string `json:"dashboard-url,omitempty"`
+
 	// APIAddresses contains the currently known API addresses for the
 	// controller.
 	APIAddresses []string `json:"api-addresses,omitempty"`
@@ -176,8 +297,23 @@ type ControllerInfo struct {
 	AgentVersion string `json:"agent-version"`
 
 	// Status contains the current status of the controller. The status
-	// will either be "available", "deprecated", or "unavailable".
+	// will either be "available", "draining", "deprecated", or
+	// "unavailable". An unavailable controller's status holds the time
+	// since which it has been unavailable.
 	Status jujuparams.EntityStatus `json:"status"`
+
+	// CloudRegions holds the cloud regions, in the form
+	// "<cloud>/<region>", that the controller hosts models in.
+	CloudRegions []string `json:"cloud-regions,omitempty"`
+
+	// DiscoveredCloudRegions holds the cloud regions found on the
+	// controller when it was added. It is only set in the response to
+	// AddController.
+	DiscoveredCloudRegions []DiscoveredCloudRegion `json:"discovered-cloud-regions,omitempty"`
+
+	// ModelCount is the number of models hosted on the controller. It is
+	// only returned by ListControllers.
+	ModelCount int `json:"model-count,omitempty"`
 }
 
 // A FindAuditEventsRequest finds audit events that match the specified
@@ -201,10 +337,20 @@ type FindAuditEventsRequest struct {
 	// were performed against a specific model.
 	Model string `json:"model,omitempty"`
 
+	// ModelUUID is used to filter the event log to only contain events
+	// that were performed against the model with the given UUID.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
 	// Method is used to filter the event log to only contain events that
 	// called a specific facade method.
 	Method string `json:"method,omitempty"`
 
+	// Cursor is used to return the page of events following a previous
+	// request. It should be set to the NextCursor returned with the
+	// previous page, with the other filters unchanged. Offset must not
+	// be specified with a cursor.
+	Cursor string `json:"cursor,omitempty"`
+
 	// Offset is the number of items to offset the set of returned results.
 	Offset int `json:"offset,omitempty"`
 
@@ -212,7 +358,7 @@ type FindAuditEventsRequest struct {
 	Limit int `json:"limit,omitempty"`
 
 	// SortTime will sort by most recent (time descending) when true.
-	// When false no explicit ordering will be applied.
+	// When false the oldest events are returned first.
 	SortTime bool `json:"sortTime,omitempty"`
 }
 
@@ -227,6 +373,9 @@ type ListControllersResponse struct {
 type RemoveControllerRequest struct {
 	Name  string `json:"name"`
 	Force bool   `json:"force"`
+	// Reason is a free-text justification for the removal. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
 }
 
 // A SetControllerDeprecatedRequest is the request this is sent in a
@@ -240,8 +389,69 @@ type SetControllerDeprecatedRequest struct {
 	Deprecated bool `json:"deprecated"`
 }
 
+// A SetCloudRegionDisabledRequest is the request sent in a
+// SetCloudRegionDisabled method.
+type SetCloudRegionDisabledRequest struct {
+	// CloudTag is the tag of the cloud the region belongs to.
+	CloudTag string `json:"cloud-tag"`
+
+	// Region is the name of the region.
+	Region string `json:"region"`
+
+	// Disabled specifies whether the region should be closed for the
+	// placement of new models.
+	Disabled bool `json:"disabled"`
+}
+
+// A DrainControllerRequest is the request sent in a DrainController
+// method.
+type DrainControllerRequest struct {
+	// Name is the name of the controller to drain.
+	Name string `json:"name"`
+
+	// Draining specifies whether the controller should be draining or
+	// not. No new models are placed on a draining controller.
+	Draining bool `json:"draining"`
+
+	// Migrate, if true, migrates the models on the controller to other
+	// controllers hosting the same cloud region. It may only be set
+	// when Draining is true.
+	Migrate bool `json:"migrate,omitempty"`
+}
+
+// A DrainMigration describes the migration of a model away from a
+// draining controller.
+type DrainMigration struct {
+	// ModelTag is the tag of the model being migrated.
+	ModelTag string `json:"model-tag" yaml:"model-tag"`
+
+	// TargetController is the name of the controller the model is
+	// being migrated to.
+	TargetController string `json:"target-controller,omitempty" yaml:"target-controller,omitempty"`
+
+	// MigrationID is the ID of the started migration.
+	MigrationID string `json:"migration-id,omitempty" yaml:"migration-id,omitempty"`
+
+	// Error holds the reason the migration could not be started, if
+	// any.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// A DrainControllerResponse is the response from a DrainController
+// method.
+type DrainControllerResponse struct {
+	// Controller holds the updated controller information.
+	Controller ControllerInfo `json:"controller" yaml:"controller"`
+
+	// Migrations holds the model migrations started when draining the
+	// controller.
+	Migrations []DrainMigration `json:"migrations,omitempty" yaml:"migrations,omitempty"`
+}
+
 // FullModelStatusRequest is the request that is sent in a FullModelStatus method.
 type FullModelStatusRequest struct {
+	// ModelTag is the tag of the model. It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
 	ModelTag string
 	Patterns []string
 }
@@ -350,6 +560,10 @@ type AddRelationRequest struct {
 // RemoveRelationRequest holds the request information to remove tuples.
 type RemoveRelationRequest struct {
 	Tuples []RelationshipTuple `json:"tuples"`
+	// Reason is a free-text justification for the removal. It is
+	// recorded in the audit log and may be required by the server
+	// when revoking administrator access to JIMM.
+	Reason string `json:"reason,omitempty"`
 }
 
 // CheckRelationRequest holds a tuple containing the object, target object and relation that we wish
@@ -364,6 +578,36 @@ type CheckRelationResponse struct {
 	Allowed bool `json:"allowed" yaml:"allowed"`
 }
 
+// ExportRelationsRequest holds the request information to export the
+// relation tuples stored in OpenFGA.
+type ExportRelationsRequest struct {
+	PageSize          int32  `json:"page_size,omitempty"`
+	ContinuationToken string `json:"continuation_token,omitempty"`
+}
+
+// ExportRelationsResponse holds a page of exported relation tuples. The
+// tuples are in the form stored in OpenFGA, for example
+// "user:alice@canonical.com", "member", "group:<uuid>".
+type ExportRelationsResponse struct {
+	Tuples            []RelationshipTuple `json:"tuples" yaml:"tuples"`
+	ContinuationToken string              `json:"continuation_token,omitempty" yaml:"continuation_token,omitempty"`
+}
+
+// ImportRelationsRequest holds the relation tuples, as returned by
+// ExportRelations, to import into OpenFGA.
+type ImportRelationsRequest struct {
+	Tuples []RelationshipTuple `json:"tuples"`
+}
+
+// ImportRelationsResponse holds the result of an ImportRelations method.
+type ImportRelationsResponse struct {
+	// Added is the number of tuples added.
+	Added int `json:"added" yaml:"added"`
+
+	// Existing is the number of tuples that already existed.
+	Existing int `json:"existing" yaml:"existing"`
+}
+
 // ListRelationshipTuplesRequests holds the request information to list tuples.
 type ListRelationshipTuplesRequest struct {
 	Tuple             RelationshipTuple `json:"tuple,omitempty"`
@@ -379,6 +623,72 @@ type ListRelationshipTuplesResponse struct {
 	ContinuationToken string              `json:"continuation_token,omitempty" yaml:"continuation_token,omitempty"`
 }
 
+// AddTemporaryRelationRequest holds the request information to add a
+// relation that is automatically removed after the given duration.
+type AddTemporaryRelationRequest struct {
+	Tuple RelationshipTuple `json:"tuple"`
+	// Duration is the length of time the relation is granted for.
+	Duration time.Duration `json:"duration"`
+}
+
+// TemporaryGrant holds the details of a time-bounded relation.
+type TemporaryGrant struct {
+	Tuple     RelationshipTuple `json:"tuple" yaml:"tuple"`
+	GrantedBy string            `json:"granted-by" yaml:"granted-by"`
+	CreatedAt string            `json:"created-at" yaml:"created-at"`
+	ExpiresAt string            `json:"expires-at" yaml:"expires-at"`
+}
+
+// ListTemporaryRelationsResponse holds the response of the
+// ListTemporaryRelations method.
+type ListTemporaryRelationsResponse struct {
+	Grants []TemporaryGrant `json:"grants" yaml:"grants"`
+}
+
+// EveryoneDefault holds a relation granted to every user.
+type EveryoneDefault struct {
+	// TargetObject is the resource to which the relation applies.
+	TargetObject string `json:"target_object" yaml:"target_object"`
+	// Relation is the relation every user has to the target.
+	Relation string `json:"relation" yaml:"relation"`
+}
+
+// ListEveryoneDefaultsResponse holds the response of the
+// ListEveryoneDefaults method.
+type ListEveryoneDefaultsResponse struct {
+	Defaults []EveryoneDefault `json:"defaults" yaml:"defaults"`
+}
+
+// SetEveryoneDefaultRequest holds the parameters of the
+// SetEveryoneDefault method.
+type SetEveryoneDefaultRequest struct {
+	EveryoneDefault
+	// Granted determines whether the relation is granted or revoked.
+	Granted bool `json:"granted"`
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReconcileRequest holds the parameters of the Reconcile method.
+type ReconcileRequest struct {
+	// Repair determines whether the differences found are repaired.
+	Repair bool `json:"repair,omitempty"`
+}
+
+// ReconcileResponse holds the differences found between JIMM's database
+// and the tuples in OpenFGA.
+type ReconcileResponse struct {
+	// Missing holds the tuples implied by the database that are not in
+	// OpenFGA.
+	Missing []RelationshipTuple `json:"missing,omitempty" yaml:"missing,omitempty"`
+	// Orphaned holds the tuples that refer to entities that are not in
+	// the database.
+	Orphaned []RelationshipTuple `json:"orphaned,omitempty" yaml:"orphaned,omitempty"`
+	// Repaired is true if the differences have been repaired.
+	Repaired bool `json:"repaired" yaml:"repaired"`
+}
+
 // CrossModelQueryRequest holds the parameters to perform a cross model query against
 // JSON model statuses for every model this user has access to.
 type CrossModelQueryRequest struct {
@@ -395,6 +705,23 @@ type CrossModelQueryResponse struct {
 	Errors  map[string][]string `json:"errors" yaml:"errors"`
 }
 
+// MigrateControllerCredentialsResponse holds the response from a
+// MigrateControllerCredentials call.
+type MigrateControllerCredentialsResponse struct {
+	// Controllers contains the names of the controllers whose admin
+	// credentials were moved to the credential store.
+	Controllers []string `json:"controllers" yaml:"controllers"`
+
+	// Skipped contains the names of the controllers whose admin
+	// credentials were left in JIMM's database because only one of the
+	// username and password is set.
+	Skipped []string `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+
+	// RootKeys contains the number of macaroon root keys that were moved
+	// to the credential store.
+	RootKeys int `json:"root-keys,omitempty" yaml:"root-keys,omitempty"`
+}
+
 // PurgeLogsRequest is the request used to purge logs.
 type PurgeLogsRequest struct {
 	// Date is the date before which logs should be purged.
@@ -408,11 +735,54 @@ type PurgeLogsResponse struct {
 	DeletedCount int64 `json:"deleted-count" yaml:"deleted-count"`
 }
 
+// OffboardUserRequest holds the parameters of the OffboardUser method.
+type OffboardUserRequest struct {
+	// UserTag is the tag of the user to remove.
+	UserTag string `json:"user-tag"`
+	// ReassignModelsTo, if set, is the tag of the user who becomes the
+	// owner of the models owned by the removed user.
+	ReassignModelsTo string `json:"reassign-models-to,omitempty"`
+	// Reason is a free-text justification for the removal. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OffboardUserResponse describes the changes made by the OffboardUser
+// method.
+type OffboardUserResponse struct {
+	// RemovedRelations holds the relations removed from the user.
+	RemovedRelations []RelationshipTuple `json:"removed-relations,omitempty" yaml:"removed-relations,omitempty"`
+	// RevokedCredentials holds the tags of the revoked cloud credentials.
+	RevokedCredentials []string `json:"revoked-credentials,omitempty" yaml:"revoked-credentials,omitempty"`
+	// RetainedCredentials holds the tags of the cloud credentials that
+	// are still used by models and have not been revoked.
+	RetainedCredentials []string `json:"retained-credentials,omitempty" yaml:"retained-credentials,omitempty"`
+	// ReassignedModels holds the tags of the models given to a new owner.
+	ReassignedModels []string `json:"reassigned-models,omitempty" yaml:"reassigned-models,omitempty"`
+	// RetainedModels holds the tags of the models still owned by the
+	// removed user.
+	RetainedModels []string `json:"retained-models,omitempty" yaml:"retained-models,omitempty"`
+}
+
+// TransferModelOwnershipRequest holds the parameters of the
+// TransferModelOwnership method.
+type TransferModelOwnershipRequest struct {
+	// ModelTag is the tag of the model to transfer. It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
+	ModelTag string `json:"model-tag"`
+	// OwnerTag is the tag of the user who becomes the model's owner.
+	OwnerTag string `json:"owner-tag"`
+	// Reason is a free-text justification for the transfer. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
 // MigrateModelInfo represents a single migration where a source model
 // target controller must be specified with both the source model and
 // target controller residing within JIMM.
 type MigrateModelInfo struct {
-	// ModelTag is a tag of the form "model-<UIID>"
+	// ModelTag is a tag of the form "model-<UIID>". It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
 	ModelTag string `json:"model-tag"`
 	// TargetController is the controller name of the form "<name>"
 	TargetController string `json:"target-controller"`
@@ -431,6 +801,21 @@ type LoginDeviceResponse struct {
 	VerificationURI string `json:"verification-uri" yaml:"verification-uri"`
 	// UserCode holds the one-time use user consent code.
 	UserCode string `json:"user-code" yaml:"user-code"`
+	// Branding holds the branding configured by the operator of JIMM,
+	// if any, so that users can see whose JAAS they are signing into.
+	Branding *Branding `json:"branding,omitempty" yaml:"branding,omitempty"`
+}
+
+// Branding holds the branding and messaging configured by the operator of
+// JIMM that is shown to users when they log in.
+type Branding struct {
+	// OrganisationName is the name of the organisation operating JIMM.
+	OrganisationName string `json:"organisation-name,omitempty" yaml:"organisation-name,omitempty"`
+	// LogoURL is the URL of the organisation's logo.
+	LogoURL string `json:"logo-url,omitempty" yaml:"logo-url,omitempty"`
+	// MessageOfTheDay is a message, such as a maintenance notice, shown
+	// to users when they log in.
+	MessageOfTheDay string `json:"message-of-the-day,omitempty" yaml:"message-of-the-day,omitempty"`
 }
 
 // GetDeviceSessionTokenResponse returns a session token to be used against
@@ -470,6 +855,8 @@ type LoginWithClientCredentialsRequest struct {
 type AddServiceAccountRequest struct {
 	// ClientID holds the client id of the service account.
 	ClientID string `json:"client-id"`
+	// Purpose optionally describes what the service account is used for.
+	Purpose string `json:"purpose,omitempty"`
 }
 
 // CopyServiceAccountCredentialRequest holds a request to copy a user cloud-credential to a service account.
@@ -485,6 +872,9 @@ type UpdateServiceAccountCredentialsRequest struct {
 	jujuparams.UpdateCredentialArgs
 	// ClientID holds the client id of the service account.
 	ClientID string `json:"client-id"`
+	// DryRun, if set, checks the credentials against the models using
+	// them and reports the impact without updating anything.
+	DryRun bool `json:"dry-run,omitempty"`
 }
 
 // ListServiceAccountCredentialsRequest holds a request to list
@@ -505,6 +895,1113 @@ type GrantServiceAccountAccess struct {
 	ClientID string `json:"client-id"`
 }
 
+// SetServiceAccountAllowedCIDRsRequest holds a request to restrict the
+// network ranges a service account may authenticate from.
+type SetServiceAccountAllowedCIDRsRequest struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+	// CIDRs holds the allowed network ranges in CIDR notation. An empty
+	// list removes the restriction.
+	CIDRs []string `json:"cidrs"`
+}
+
+// A ServiceAccount describes a service account managed by JIMM.
+type ServiceAccount struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+	// Creator holds the name of the identity that added the service
+	// account, if known.
+	Creator string `json:"creator,omitempty"`
+	// Purpose describes what the service account is used for.
+	Purpose string `json:"purpose,omitempty"`
+	// CreatedAt holds the time the service account was added to JIMM.
+	CreatedAt time.Time `json:"created-at"`
+	// Disabled holds whether the service account is prevented from
+	// authenticating.
+	Disabled bool `json:"disabled"`
+}
+
+// ListServiceAccountsResponse holds the response to a
+// ListServiceAccounts request.
+type ListServiceAccountsResponse struct {
+	// ServiceAccounts holds the service accounts the requesting user
+	// administers.
+	ServiceAccounts []ServiceAccount `json:"service-accounts"`
+}
+
+// SetServiceAccountDisabledRequest holds a request to disable, or
+// re-enable, a service account.
+type SetServiceAccountDisabledRequest struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+	// Disabled holds whether the service account should be prevented
+	// from authenticating.
+	Disabled bool `json:"disabled"`
+}
+
+// DeleteServiceAccountRequest holds a request to delete a service
+// account.
+type DeleteServiceAccountRequest struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+}
+
+// WatchAllModelsRequest holds the request for a WatchAllModels call.
+type WatchAllModelsRequest struct {
+	// ModelTags optionally restricts the watcher to the given models. If
+	// this is empty deltas for every model the user can read are
+	// returned.
+	ModelTags []string `json:"model-tags,omitempty"`
+}
+
+// WatchModelSummariesRequest holds the filters for a WatchModelSummaries
+// call. Only summaries for models matching every non-empty filter are
+// returned.
+type WatchModelSummariesRequest struct {
+	// OwnerTag restricts the summaries to models owned by this user.
+	OwnerTag string `json:"owner-tag,omitempty"`
+	// CloudTag restricts the summaries to models on this cloud.
+	CloudTag string `json:"cloud-tag,omitempty"`
+	// ControllerName restricts the summaries to models hosted on this
+	// controller.
+	ControllerName string `json:"controller-name,omitempty"`
+	// All, if true, considers every model rather than only those the
+	// user has access to. Only JIMM administrators may set this.
+	All bool `json:"all,omitempty"`
+}
+
+// WatchModelSummariesResponse holds the response for a
+// WatchModelSummaries call.
+type WatchModelSummariesResponse struct {
+	// WatcherID is the ID used with the ModelSummaryWatcher facade.
+	WatcherID string `json:"watcher-id"`
+
+	// ResumeToken may be used with ResumeWatcher to continue the
+	// watcher on a new connection.
+	ResumeToken string `json:"resume-token"`
+}
+
+// ResumeWatcherRequest holds the request for a ResumeWatcher call.
+type ResumeWatcherRequest struct {
+	// ResumeToken is the token returned when the watcher was started.
+	ResumeToken string `json:"resume-token"`
+}
+
+// ResumeWatcherResponse holds the response for a ResumeWatcher call.
+type ResumeWatcherResponse struct {
+	// WatcherID is the ID of the resumed watcher on the new connection.
+	WatcherID string `json:"watcher-id"`
+
+	// Kind is the kind of watcher resumed, one of "access",
+	// "audit-events" or "model-summary".
+	Kind string `json:"kind"`
+}
+
+// WatchAuditEventsRequest holds the filters for a WatchAuditEvents call.
+// Only events matching every non-empty filter are returned.
+type WatchAuditEventsRequest struct {
+	// UserTag restricts the events to those performed by this user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// Model restricts the events to those performed against this model.
+	Model string `json:"model,omitempty"`
+
+	// Method restricts the events to those that called this facade
+	// method.
+	Method string `json:"method,omitempty"`
+}
+
+// WatchAuditEventsResponse holds the response for a WatchAuditEvents
+// call.
+type WatchAuditEventsResponse struct {
+	// WatcherID is the ID used with the AuditEventWatcher facade.
+	WatcherID string `json:"watcher-id"`
+
+	// ResumeToken may be used with ResumeWatcher to continue the
+	// watcher on a new connection.
+	ResumeToken string `json:"resume-token"`
+}
+
+// AuditEventWatcherNextResponse holds the response for an
+// AuditEventWatcher Next call.
+type AuditEventWatcherNextResponse struct {
+	// Events holds the audit events added since the previous call.
+	Events []AuditEvent `json:"events"`
+
+	// Dropped holds the number of matching events that were discarded
+	// because the watcher was not read quickly enough. When it is
+	// non-zero the client should query the audit log to resynchronise.
+	Dropped int `json:"dropped,omitempty"`
+}
+
+// WatchAccessResponse holds the response for a WatchAccess call.
+type WatchAccessResponse struct {
+	// WatcherID is the ID used with the AccessWatcher facade.
+	WatcherID string `json:"watcher-id"`
+
+	// ResumeToken may be used with ResumeWatcher to continue the
+	// watcher on a new connection.
+	ResumeToken string `json:"resume-token"`
+}
+
+// AccessChange describes a change to the access a user has to a model.
+type AccessChange struct {
+	// ModelTag is the tag of the model whose access changed.
+	ModelTag string `json:"model-tag"`
+	// Access is the user's new access level on the model. An empty
+	// access level means access has been revoked.
+	Access string `json:"access,omitempty"`
+}
+
+// AccessWatcherNextResponse holds the response for an AccessWatcher
+// Next call.
+type AccessWatcherNextResponse struct {
+	Changes []AccessChange `json:"changes"`
+}
+
+// A ModelWebhook describes a webhook registered on a model.
+type ModelWebhook struct {
+	// ID is the ID of the webhook.
+	ID uint `json:"id"`
+
+	// ModelTag is the tag of the model the webhook is registered on.
+	ModelTag string `json:"model-tag"`
+
+	// URL is the address to which notifications are posted.
+	URL string `json:"url"`
+
+	// Events holds the status transitions that trigger a notification,
+	// any of "unit-error", "machine-down", "model-destroyed" and
+	// "credential-invalid".
+	Events []string `json:"events"`
+
+	// CreatedBy is the name of the user that registered the webhook.
+	CreatedBy string `json:"created-by"`
+
+	// CreatedAt is the time the webhook was registered, in RFC3339
+	// format.
+	CreatedAt string `json:"created-at"`
+}
+
+// AddModelWebhookRequest holds the request for an AddModelWebhook call.
+type AddModelWebhookRequest struct {
+	// ModelTag is the tag of the model to register the webhook on. It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
+	ModelTag string `json:"model-tag"`
+
+	// URL is the address to which notifications are posted.
+	URL string `json:"url"`
+
+	// Events holds the status transitions that trigger a notification.
+	Events []string `json:"events"`
+}
+
+// AddModelWebhookResponse holds the response for an AddModelWebhook
+// call.
+type AddModelWebhookResponse struct {
+	// Webhook describes the registered webhook.
+	Webhook ModelWebhook `json:"webhook"`
+
+	// Secret is the key used to sign notifications. It is only returned
+	// when the webhook is registered.
+	Secret string `json:"secret"`
+}
+
+// ListModelWebhooksRequest holds the request for a ListModelWebhooks
+// call.
+type ListModelWebhooksRequest struct {
+	// ModelTag is the tag of the model whose webhooks are listed. It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
+	ModelTag string `json:"model-tag"`
+}
+
+// ListModelWebhooksResponse holds the response for a ListModelWebhooks
+// call.
+type ListModelWebhooksResponse struct {
+	Webhooks []ModelWebhook `json:"webhooks"`
+}
+
+// RemoveModelWebhookRequest holds the request for a RemoveModelWebhook
+// call.
+type RemoveModelWebhookRequest struct {
+	// ModelTag is the tag of the model the webhook is registered on. It may also be
+	// a model UUID, a path of the form <owner>/<name> or a model alias.
+	ModelTag string `json:"model-tag"`
+
+	// ID is the ID of the webhook to remove.
+	ID uint `json:"id"`
+}
+
+// ModelWebhookEvent is the payload posted to a model webhook.
+type ModelWebhookEvent struct {
+	// Event is the status transition that occurred.
+	Event string `json:"event"`
+
+	// ModelUUID is the UUID of the model in which the event occurred.
+	ModelUUID string `json:"model-uuid"`
+
+	// ModelName is the name of the model in which the event occurred.
+	ModelName string `json:"model-name,omitempty"`
+
+	// Entity is the tag of the unit or machine concerned, if any.
+	Entity string `json:"entity,omitempty"`
+
+	// Message holds any status message associated with the event.
+	Message string `json:"message,omitempty"`
+
+	// Time is the time JIMM observed the event, in RFC3339 format.
+	Time string `json:"time"`
+}
+
+// A Limit restricts the amount of a resource that may be used within a
+// scope.
+type Limit struct {
+	// Entity is the resource that is limited, one of "models",
+	// "machines", "cores" or "units".
+	Entity string `json:"entity" yaml:"entity"`
+
+	// Scope is the user, group, controller or cloud to which the limit
+	// applies, for example "user-alice@canonical.com" or "group-team-a",
+	// or just "user", "controller" or "cloud" for the default limit.
+	Scope string `json:"scope" yaml:"scope"`
+
+	// Value is the maximum amount of the resource that may be used.
+	Value int64 `json:"value" yaml:"value"`
+
+	// Usage holds the amount of the resource currently used within the
+	// scope. It is not set for default limits.
+	Usage *int64 `json:"usage,omitempty" yaml:"usage,omitempty"`
+}
+
+// ListLimitsResponse holds the response for a ListLimits call.
+type ListLimitsResponse struct {
+	Limits []Limit `json:"limits" yaml:"limits"`
+}
+
+// SetLimitRequest holds the request for a SetLimit call.
+type SetLimitRequest struct {
+	Entity string `json:"entity"`
+	Scope  string `json:"scope"`
+	Value  int64  `json:"value"`
+
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// RemoveLimitRequest holds the request for a RemoveLimit call.
+type RemoveLimitRequest struct {
+	Entity string `json:"entity"`
+	Scope  string `json:"scope"`
+
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// NotificationRoute describes a route along which notifications for a
+// user or group are delivered.
+type NotificationRoute struct {
+	// ID uniquely identifies the route.
+	ID uint `json:"id" yaml:"id"`
+
+	// Subject is the tag of the user or group the route is for.
+	Subject string `json:"subject" yaml:"subject"`
+
+	// Kind is the kind of notification delivered along the route, one
+	// of "credential-expiry", "usage-alert", "migration-complete" or
+	// "controller-maintenance". If it is empty notifications of every
+	// kind are delivered.
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	// Transport is the transport used to deliver notifications, one of
+	// "email", "slack" or "https".
+	Transport string `json:"transport" yaml:"transport"`
+
+	// Destination is where notifications are delivered, an email
+	// address for the email transport or a URL otherwise.
+	Destination string `json:"destination" yaml:"destination"`
+}
+
+// AddNotificationRouteRequest holds the request for an
+// AddNotificationRoute call.
+type AddNotificationRouteRequest struct {
+	// Subject is the tag of the user or group the route is for. If it
+	// is empty the route is for the authenticated user.
+	Subject string `json:"subject,omitempty"`
+
+	// Kind is the kind of notification delivered along the route. If
+	// it is empty notifications of every kind are delivered.
+	Kind string `json:"kind,omitempty"`
+
+	// Transport is the transport used to deliver notifications.
+	Transport string `json:"transport"`
+
+	// Destination is where notifications are delivered.
+	Destination string `json:"destination"`
+}
+
+// ListNotificationRoutesResponse holds the response for a
+// ListNotificationRoutes call.
+type ListNotificationRoutesResponse struct {
+	Routes []NotificationRoute `json:"routes" yaml:"routes"`
+}
+
+// RemoveNotificationRouteRequest holds the request for a
+// RemoveNotificationRoute call.
+type RemoveNotificationRouteRequest struct {
+	// ID is the ID of the route to remove.
+	ID uint `json:"id"`
+}
+
+// ModelConfigTemplate describes a named set of model config values that
+// may be applied when a model is added.
+type ModelConfigTemplate struct {
+	// Path is the path of the template, in the form <owner>/<name>.
+	Path string `json:"path" yaml:"path"`
+
+	// Config holds the model config values set by the template.
+	Config map[string]interface{} `json:"config" yaml:"config"`
+
+	// SharedWith holds the names of the identities, other than the
+	// owner, that may use the template. The name "everyone@external"
+	// shares the template with every user.
+	SharedWith []string `json:"shared-with,omitempty" yaml:"shared-with,omitempty"`
+
+	// UpdatedAt holds the time the template was last changed.
+	UpdatedAt time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// SetModelConfigTemplateRequest holds the request for a
+// SetModelConfigTemplate call.
+type SetModelConfigTemplateRequest struct {
+	// Path is the path of the template to set. If it does not include
+	// an owner the template is owned by the authenticated user.
+	Path string `json:"path"`
+
+	// Config holds the model config values set by the template.
+	Config map[string]interface{} `json:"config"`
+
+	// SharedWith holds the names of the identities, other than the
+	// owner, that may use the template.
+	SharedWith []string `json:"shared-with,omitempty"`
+}
+
+// ModelConfigTemplateRequest holds the request for a
+// GetModelConfigTemplate or RemoveModelConfigTemplate call.
+type ModelConfigTemplateRequest struct {
+	// Path is the path of the template. If it does not include an
+	// owner the template is owned by the authenticated user.
+	Path string `json:"path"`
+}
+
+// ListModelConfigTemplatesResponse holds the response for a
+// ListModelConfigTemplates call.
+type ListModelConfigTemplatesResponse struct {
+	Templates []ModelConfigTemplate `json:"templates" yaml:"templates"`
+}
+
+// ModelAlias describes an alias a user has given to a model.
+type ModelAlias struct {
+	// Alias is the alias of the model.
+	Alias string `json:"alias" yaml:"alias"`
+
+	// ModelTag is the tag of the model the alias refers to.
+	ModelTag string `json:"model-tag" yaml:"model-tag"`
+
+	// ModelPath is the path of the model the alias refers to, in the
+	// form <owner>/<name>.
+	ModelPath string `json:"model-path" yaml:"model-path"`
+}
+
+// SetModelAliasRequest holds the request for a SetModelAlias call.
+type SetModelAliasRequest struct {
+	// Alias is the alias to set.
+	Alias string `json:"alias"`
+
+	// Model refers to the model to give the alias to. It may be a model
+	// tag, a model UUID, a path of the form <owner>/<name> or another
+	// model alias.
+	Model string `json:"model"`
+}
+
+// ListModelAliasesResponse holds the response for a ListModelAliases
+// call.
+type ListModelAliasesResponse struct {
+	Aliases []ModelAlias `json:"aliases" yaml:"aliases"`
+}
+
+// RemoveModelAliasRequest holds the request for a RemoveModelAlias call.
+type RemoveModelAliasRequest struct {
+	// Alias is the alias to remove.
+	Alias string `json:"alias"`
+}
+
+// ModelStatus describes the status of a model as last recorded by JIMM.
+type ModelStatus struct {
+	// ModelTag is the tag of the model.
+	ModelTag string `json:"model-tag" yaml:"model-tag"`
+
+	// Name is the name of the model.
+	Name string `json:"name" yaml:"name"`
+
+	// Owner is the name of the model's owner.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Controller is the name of the controller hosting the model.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// Life is the life of the model.
+	Life string `json:"life" yaml:"life"`
+
+	// Status is the status of the model.
+	Status string `json:"status" yaml:"status"`
+
+	// StatusInfo holds any message associated with the status.
+	StatusInfo string `json:"status-info,omitempty" yaml:"status-info,omitempty"`
+
+	// StatusSince is the time the model entered its current status, if
+	// known.
+	StatusSince *time.Time `json:"status-since,omitempty" yaml:"status-since,omitempty"`
+
+	// Machines is the number of machines in the model.
+	Machines int64 `json:"machines" yaml:"machines"`
+
+	// Units is the number of units in the model.
+	Units int64 `json:"units" yaml:"units"`
+
+	// LastUpdated is the time JIMM last updated its record of the
+	// model.
+	LastUpdated time.Time `json:"last-updated" yaml:"last-updated"`
+
+	// LastActivity is the time activity, such as a unit changing or a
+	// client connecting through JIMM, was last seen in the model.
+	LastActivity time.Time `json:"last-activity" yaml:"last-activity"`
+
+	// Labels holds the labels set on the model in JIMM.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// ListModelStatusesRequest holds the request for a ListModelStatuses
+// call.
+type ListModelStatusesRequest struct {
+	// LabelSelector, if set, restricts the returned models to those with
+	// labels matching the selector. The selector is a comma separated
+	// list of requirements of the form "key=value", "key!=value" or
+	// "key", all of which must be met, for example
+	// "team=payments,env=prod".
+	LabelSelector string `json:"label-selector,omitempty"`
+
+	// IdleFor, if set, restricts the returned models to those in which
+	// no activity has been seen for at least this long.
+	IdleFor time.Duration `json:"idle-for,omitempty"`
+}
+
+// SetModelLabelsRequest holds the request for a SetModelLabels call.
+type SetModelLabelsRequest struct {
+	// ModelTag is the tag of the model, it may also be a path of the
+	// form <owner>/<name> or one of the user's model aliases.
+	ModelTag string `json:"model-tag"`
+
+	// Labels holds the labels to set on the model, replacing any
+	// existing labels. Empty labels remove all the model's labels.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ListModelStatusesResponse holds the response for a ListModelStatuses
+// call.
+type ListModelStatusesResponse struct {
+	Models []ModelStatus `json:"models" yaml:"models"`
+}
+
+// DeprecatedFacadeUsage describes the calls a client has made on a
+// deprecated facade version.
+type DeprecatedFacadeUsage struct {
+	// Facade is the name of the facade.
+	Facade string `json:"facade" yaml:"facade"`
+
+	// Version is the deprecated version of the facade that was called.
+	Version int `json:"version" yaml:"version"`
+
+	// Identity is the name of the identity that made the calls, it is
+	// empty for calls made before logging in.
+	Identity string `json:"identity,omitempty" yaml:"identity,omitempty"`
+
+	// RemoteAddress is the network address of the client.
+	RemoteAddress string `json:"remote-address,omitempty" yaml:"remote-address,omitempty"`
+
+	// Calls is the number of calls made.
+	Calls int64 `json:"calls" yaml:"calls"`
+
+	// FirstCall and LastCall are the times of the first and last calls
+	// made.
+	FirstCall time.Time `json:"first-call" yaml:"first-call"`
+	LastCall  time.Time `json:"last-call" yaml:"last-call"`
+}
+
+// ListDeprecatedFacadeUsageResponse holds the response for a
+// ListDeprecatedFacadeUsage call.
+type ListDeprecatedFacadeUsageResponse struct {
+	Usage []DeprecatedFacadeUsage `json:"usage" yaml:"usage"`
+}
+
+// BackupTable holds the rows of a database table in a backup.
+type BackupTable struct {
+	// Name is the name of the table.
+	Name string `json:"name"`
+
+	// Rows holds the rows of the table, each encoded as a JSON object
+	// keyed by column name.
+	Rows []json.RawMessage `json:"rows"`
+}
+
+// Backup holds a snapshot of JIMM's database and OpenFGA relation
+// tuples. It is returned by a Backup call and sent in a Restore request.
+type Backup struct {
+	// Time is the time the backup was taken.
+	Time time.Time `json:"time"`
+
+	// DatabaseMajorVersion and DatabaseMinorVersion hold the version of
+	// the database schema the tables were taken from.
+	DatabaseMajorVersion int `json:"database-major-version"`
+	DatabaseMinorVersion int `json:"database-minor-version"`
+
+	// Tables holds the contents of the database tables.
+	Tables []BackupTable `json:"tables"`
+
+	// Tuples holds the relation tuples stored in OpenFGA.
+	Tuples []RelationshipTuple `json:"tuples"`
+}
+
+// RestoreRequest holds the request for a Restore call.
+type RestoreRequest struct {
+	// Backup holds the backup to restore.
+	Backup Backup `json:"backup"`
+}
+
+// SelfTestResult holds the result of one of the checks performed by a
+// ServiceSelfTest call.
+type SelfTestResult struct {
+	// Name identifies the check.
+	Name string `json:"name"`
+
+	// Passed is true if the check passed.
+	Passed bool `json:"passed"`
+
+	// DurationMS holds the time taken by the check in milliseconds.
+	DurationMS int64 `json:"duration-ms"`
+
+	// Error holds the reason the check failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ServiceSelfTestResponse holds the response for a ServiceSelfTest call.
+type ServiceSelfTestResponse struct {
+	// Passed is true if every check passed.
+	Passed bool `json:"passed"`
+
+	// Results holds the result of each check.
+	Results []SelfTestResult `json:"results"`
+}
+
+// SearchOffersRequest holds the request for a SearchOffers call. Empty
+// fields match every offer.
+type SearchOffersRequest struct {
+	// Interface matches offers with an endpoint using the interface.
+	Interface string `json:"interface,omitempty"`
+
+	// ApplicationName matches offers of the named application.
+	ApplicationName string `json:"application-name,omitempty"`
+
+	// OwnerName matches offers in models owned by the named user.
+	OwnerName string `json:"owner-name,omitempty"`
+}
+
+// OfferDirectoryEntry describes an offer found by a SearchOffers call.
+type OfferDirectoryEntry struct {
+	// OfferURL is the URL used to consume the offer.
+	OfferURL string `json:"offer-url"`
+
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string `json:"offer-uuid"`
+
+	// OfferName is the name of the offer.
+	OfferName string `json:"offer-name"`
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string `json:"application-name"`
+
+	// ApplicationDescription is the description of the offered
+	// application.
+	ApplicationDescription string `json:"application-description,omitempty"`
+
+	// OwnerName is the name of the owner of the offering model.
+	OwnerName string `json:"owner-name"`
+
+	// ModelName is the name of the offering model.
+	ModelName string `json:"model-name"`
+
+	// ControllerName is the name of the controller hosting the offering
+	// model.
+	ControllerName string `json:"controller-name"`
+
+	// Endpoints holds the endpoints exposed by the offer.
+	Endpoints []jujuparams.RemoteEndpoint `json:"endpoints,omitempty"`
+
+	// Access is the requesting user's access level to the offer.
+	Access string `json:"access"`
+}
+
+// SearchOffersResponse holds the response for a SearchOffers call.
+type SearchOffersResponse struct {
+	// Offers holds the matching offers the user can consume.
+	Offers []OfferDirectoryEntry `json:"offers"`
+}
+
+// AddMaintenanceWindowRequest holds the request for an
+// AddMaintenanceWindow call.
+type AddMaintenanceWindowRequest struct {
+	// Controller is the name of the controller the window applies to.
+	Controller string `json:"controller"`
+
+	// StartsAt holds the time the window starts.
+	StartsAt time.Time `json:"starts-at"`
+
+	// EndsAt holds the time the window ends.
+	EndsAt time.Time `json:"ends-at"`
+
+	// Reason is a free-text description of the maintenance.
+	Reason string `json:"reason,omitempty"`
+}
+
+// MaintenanceWindow describes a period during which a controller is
+// expected to be under maintenance.
+type MaintenanceWindow struct {
+	// ID uniquely identifies the window.
+	ID uint `json:"id" yaml:"id"`
+
+	// Controller is the name of the controller the window applies to.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// StartsAt holds the time the window starts.
+	StartsAt time.Time `json:"starts-at" yaml:"starts-at"`
+
+	// EndsAt holds the time the window ends.
+	EndsAt time.Time `json:"ends-at" yaml:"ends-at"`
+
+	// Reason is a free-text description of the maintenance.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// CreatedBy holds the name of the identity that declared the
+	// window.
+	CreatedBy string `json:"created-by" yaml:"created-by"`
+
+	// Active is true if the window was in progress when it was
+	// returned.
+	Active bool `json:"active" yaml:"active"`
+}
+
+// ListMaintenanceWindowsRequest holds the request for a
+// ListMaintenanceWindows call.
+type ListMaintenanceWindowsRequest struct {
+	// Controller, if set, restricts the windows returned to those for
+	// the named controller.
+	Controller string `json:"controller,omitempty"`
+
+	// All requests that windows that have ended are also returned.
+	All bool `json:"all,omitempty"`
+}
+
+// ListMaintenanceWindowsResponse holds the response for a
+// ListMaintenanceWindows call.
+type ListMaintenanceWindowsResponse struct {
+	// Windows holds the maintenance windows, ordered by start time.
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// RemoveMaintenanceWindowRequest holds the request for a
+// RemoveMaintenanceWindow call.
+type RemoveMaintenanceWindowRequest struct {
+	// ID is the ID of the window to remove.
+	ID uint `json:"id"`
+}
+
+// A ModelConfigPolicy restricts the value of a model config key in
+// every model.
+type ModelConfigPolicy struct {
+	// Key is the model config key to which the policy applies.
+	Key string `json:"key" yaml:"key"`
+
+	// Action is either "forbid", which prevents the key being set to
+	// Value, or being set at all if Value is not set, or "force", which
+	// requires the key to have Value.
+	Action string `json:"action" yaml:"action"`
+
+	// Value is the value forbidden or forced by the policy.
+	Value *string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Description explains the policy to users whose requests violate
+	// it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// SetModelConfigPolicyRequest holds the request for a
+// SetModelConfigPolicy call.
+type SetModelConfigPolicyRequest struct {
+	ModelConfigPolicy
+
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ListModelConfigPoliciesResponse holds the response for a
+// ListModelConfigPolicies call.
+type ListModelConfigPoliciesResponse struct {
+	Policies []ModelConfigPolicy `json:"policies" yaml:"policies"`
+}
+
+// RemoveModelConfigPolicyRequest holds the request for a
+// RemoveModelConfigPolicy call.
+type RemoveModelConfigPolicyRequest struct {
+	Key string `json:"key"`
+
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// MaintenanceMode holds the response for a GetMaintenanceMode call.
+type MaintenanceMode struct {
+	// Enabled is true if JIMM is in maintenance mode, in which case
+	// requests that would modify JIMM's state are rejected.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Message is returned to clients whose requests are rejected.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// SetBy holds the name of the identity that last changed the
+	// maintenance mode.
+	SetBy string `json:"set-by,omitempty" yaml:"set-by,omitempty"`
+
+	// UpdatedAt holds the time the maintenance mode was last changed.
+	UpdatedAt time.Time `json:"updated-at,omitempty" yaml:"updated-at,omitempty"`
+}
+
+// SetMaintenanceModeRequest holds the request for a SetMaintenanceMode
+// call.
+type SetMaintenanceModeRequest struct {
+	// Enabled determines whether JIMM is in maintenance mode.
+	Enabled bool `json:"enabled"`
+
+	// Message is returned to clients whose requests are rejected while
+	// in maintenance mode.
+	Message string `json:"message,omitempty"`
+
+	// Reason is a free-text justification for the change. It is
+	// recorded in the audit log and may be required by the server.
+	Reason string `json:"reason,omitempty"`
+}
+
+// A SetCloudCredentialExpiryRequest is the request sent in a
+// SetCloudCredentialExpiry method.
+type SetCloudCredentialExpiryRequest struct {
+	// CredentialTag is the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag"`
+
+	// ExpiresAt is the time at which the credential expires. If this is
+	// nil the credential is recorded as not expiring.
+	ExpiresAt *time.Time `json:"expires-at,omitempty"`
+}
+
+// A ListExpiringCredentialsRequest is the request sent in a
+// ListExpiringCredentials method.
+type ListExpiringCredentialsRequest struct {
+	// Within is the period, from now, within which the returned
+	// credentials expire. Credentials that have already expired are
+	// always returned.
+	Within time.Duration `json:"within"`
+}
+
+// An ExpiringCredential describes a cloud credential that is about to
+// expire.
+type ExpiringCredential struct {
+	// CredentialTag is the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag" yaml:"credential-tag"`
+
+	// Owner is the name of the identity that owns the credential.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// ExpiresAt is the time at which the credential expires.
+	ExpiresAt time.Time `json:"expires-at" yaml:"expires-at"`
+}
+
+// A ListExpiringCredentialsResponse is the response from a
+// ListExpiringCredentials method.
+type ListExpiringCredentialsResponse struct {
+	Credentials []ExpiringCredential `json:"credentials" yaml:"credentials"`
+}
+
+// A ListCloudCredentialsRequest is the request sent in a
+// ListCloudCredentials method. Empty fields match every credential.
+type ListCloudCredentialsRequest struct {
+	// Owner selects the credentials owned by the identity with this
+	// name. Only JIMM administrators may list the credentials of other
+	// identities.
+	Owner string `json:"owner,omitempty"`
+
+	// Cloud selects the credentials for this cloud.
+	Cloud string `json:"cloud,omitempty"`
+
+	// AuthType selects the credentials with this auth-type.
+	AuthType string `json:"auth-type,omitempty"`
+
+	// Valid, if set, selects the credentials known to be valid or
+	// invalid.
+	Valid *bool `json:"valid,omitempty"`
+
+	// Offset is the number of matching credentials to skip.
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of credentials to return. A value of
+	// zero returns every matching credential.
+	Limit int `json:"limit,omitempty"`
+}
+
+// A CloudCredentialInfo describes a cloud credential, without its
+// attributes.
+type CloudCredentialInfo struct {
+	// CredentialTag is the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag" yaml:"credential-tag"`
+
+	// Cloud is the name of the cloud the credential is for.
+	Cloud string `json:"cloud" yaml:"cloud"`
+
+	// Owner is the name of the identity that owns the credential.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Name is the name of the credential.
+	Name string `json:"name" yaml:"name"`
+
+	// AuthType is the auth-type of the credential.
+	AuthType string `json:"auth-type" yaml:"auth-type"`
+
+	// Valid holds whether the credential is known to be valid, it is
+	// not set if the validity of the credential is unknown.
+	Valid *bool `json:"valid,omitempty" yaml:"valid,omitempty"`
+
+	// ExpiresAt holds the time the credential expires, if known.
+	ExpiresAt *time.Time `json:"expires-at,omitempty" yaml:"expires-at,omitempty"`
+
+	// Models is the number of models using the credential.
+	Models int `json:"models" yaml:"models"`
+}
+
+// A ListCloudCredentialsResponse is the response from a
+// ListCloudCredentials method.
+type ListCloudCredentialsResponse struct {
+	Credentials []CloudCredentialInfo `json:"credentials" yaml:"credentials"`
+}
+
+// An AddModelAsyncResponse is the response from an AddModelAsync method.
+type AddModelAsyncResponse struct {
+	// TaskID is the ID of the task creating the model, its progress can
+	// be retrieved with the GetTaskStatus method.
+	TaskID uint `json:"task-id" yaml:"task-id"`
+}
+
+// A GetTaskStatusRequest is the request sent in a GetTaskStatus method.
+type GetTaskStatusRequest struct {
+	// TaskID is the ID of the task.
+	TaskID uint `json:"task-id"`
+}
+
+// A TaskStatus describes the progress of a long-running task.
+type TaskStatus struct {
+	// TaskID is the ID of the task.
+	TaskID uint `json:"task-id" yaml:"task-id"`
+
+	// Kind is the kind of task, for example "add-model".
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Status is the state of the task, one of "pending", "running",
+	// "succeeded" or "failed".
+	Status string `json:"status" yaml:"status"`
+
+	// Progress describes the current step of a running task.
+	Progress string `json:"progress,omitempty" yaml:"progress,omitempty"`
+
+	// Error holds the reason a failed task failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Result holds the result of a successful task. For an add-model
+	// task this is the UUID of the created model.
+	Result string `json:"result,omitempty" yaml:"result,omitempty"`
+
+	// Created is the time at which the task was started.
+	Created time.Time `json:"created" yaml:"created"`
+
+	// Completed is the time at which the task finished, if it has.
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
+}
+
+// A ScheduleMigrationsRequest is the request sent in a
+// ScheduleMigrations method.
+type ScheduleMigrationsRequest struct {
+	// Specs holds the models to migrate and the controllers to migrate
+	// them to.
+	Specs []MigrateModelInfo `json:"specs"`
+}
+
+// A ScheduleMigrationsResponse is the response from a ScheduleMigrations
+// method.
+type ScheduleMigrationsResponse struct {
+	// Results holds the outcome of scheduling each migration, in the
+	// order they were requested.
+	Results []ScheduleMigrationResult `json:"results" yaml:"results"`
+}
+
+// A ScheduleMigrationResult is the outcome of scheduling a migration.
+type ScheduleMigrationResult struct {
+	// Migration holds the queued migration, if it was queued.
+	Migration *MigrationStatus `json:"migration,omitempty" yaml:"migration,omitempty"`
+
+	// Error holds the reason the migration could not be queued.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// A ListMigrationsRequest is the request sent in a ListMigrations
+// method.
+type ListMigrationsRequest struct {
+	// ModelUUID, if set, selects the migrations of this model.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Pending, if true, selects the migrations that have not finished.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// A ListMigrationsResponse is the response from a ListMigrations method.
+type ListMigrationsResponse struct {
+	Migrations []MigrationStatus `json:"migrations" yaml:"migrations"`
+}
+
+// A MigrationStatus describes the progress of a migration queued with
+// the migration scheduler.
+type MigrationStatus struct {
+	// ID is the ID of the scheduled migration.
+	ID uint `json:"id" yaml:"id"`
+
+	// ModelUUID is the UUID of the model being migrated.
+	ModelUUID string `json:"model-uuid" yaml:"model-uuid"`
+
+	// ModelName is the name of the model being migrated.
+	ModelName string `json:"model-name" yaml:"model-name"`
+
+	// SourceController is the controller hosting the model when the
+	// migration was queued.
+	SourceController string `json:"source-controller" yaml:"source-controller"`
+
+	// TargetController is the controller the model is migrating to.
+	TargetController string `json:"target-controller" yaml:"target-controller"`
+
+	// Status is the state of the migration, one of "queued",
+	// "prechecking", "started", "succeeded" or "failed".
+	Status string `json:"status" yaml:"status"`
+
+	// MigrationID is the ID of the migration on the source controller,
+	// once it has started.
+	MigrationID string `json:"migration-id,omitempty" yaml:"migration-id,omitempty"`
+
+	// Error holds the reason a failed migration failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Queued is the time at which the migration was queued.
+	Queued time.Time `json:"queued" yaml:"queued"`
+
+	// Completed is the time at which the migration finished, if it has.
+	Completed *time.Time `json:"completed,omitempty" yaml:"completed,omitempty"`
+}
+
+// GetModelArchiveRequest holds the request for a GetModelArchive call.
+type GetModelArchiveRequest struct {
+	// ModelUUID is the UUID of the archived model.
+	ModelUUID string `json:"model-uuid"`
+}
+
+// UsageReportRequest holds the request information to report model
+// usage.
+type UsageReportRequest struct {
+	// From is the first UTC day included in the report.
+	From time.Time `json:"from"`
+
+	// To is the last UTC day included in the report.
+	To time.Time `json:"to"`
+
+	// Daily requests the usage of each day separately rather than the
+	// total usage of each model over the whole period.
+	Daily bool `json:"daily,omitempty"`
+}
+
+// UsageRecord holds the machine and unit usage of a model.
+type UsageRecord struct {
+	// Date is the UTC day, in the form YYYY-MM-DD, the usage was
+	// accrued on. It is empty when the record holds the usage over a
+	// whole report period.
+	Date string `json:"date,omitempty" yaml:"date,omitempty"`
+
+	// ModelUUID is the UUID of the model.
+	ModelUUID string `json:"model-uuid" yaml:"model-uuid"`
+
+	// ModelName is the name of the model.
+	ModelName string `json:"model-name" yaml:"model-name"`
+
+	// Owner is the name of the identity that owns the model.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Controller is the name of the controller hosting the model.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// MachineHours is the number of machine-hours accrued.
+	MachineHours float64 `json:"machine-hours" yaml:"machine-hours"`
+
+	// UnitHours is the number of unit-hours accrued.
+	UnitHours float64 `json:"unit-hours" yaml:"unit-hours"`
+}
+
+// UsageReportResponse holds the response of the UsageReport method.
+type UsageReportResponse struct {
+	Records []UsageRecord `json:"records" yaml:"records"`
+}
+
+// ModelArchive holds the snapshot of a model taken before it was
+// destroyed.
+type ModelArchive struct {
+	// ModelUUID is the UUID of the archived model.
+	ModelUUID string `json:"model-uuid" yaml:"model-uuid"`
+
+	// ModelName is the name of the archived model.
+	ModelName string `json:"model-name" yaml:"model-name"`
+
+	// Owner is the name of the identity that owned the model.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Controller is the name of the controller that hosted the model.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// ArchivedBy is the name of the identity that destroyed the model.
+	ArchivedBy string `json:"archived-by" yaml:"archived-by"`
+
+	// ArchivedAt is the time at which the snapshot was taken.
+	ArchivedAt time.Time `json:"archived-at" yaml:"archived-at"`
+
+	// Config holds the model's configuration.
+	Config map[string]interface{} `json:"config" yaml:"config"`
+
+	// Status holds the model's full status, including its applications
+	// and machines, as returned by the juju status command.
+	Status map[string]interface{} `json:"status" yaml:"status"`
+}
+
 // WhoamiResponse holds the response for a /auth/whoami call.
 type WhoamiResponse struct {
 	DisplayName string `json:"display-name" yaml:"display-name"`